@@ -21,6 +21,7 @@ package atscfg
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -38,6 +39,11 @@ const RegexRevalidateFileName = "regex_revalidate.config"
 const RegexRevalidateMaxRevalDurationDaysParamName = "maxRevalDurationDays"
 const DefaultMaxRevalDurationDays = 90
 const JobKeywordPurge = "PURGE"
+
+// RegexRevalidateTypeMiss is the regex_revalidate rule type which makes
+// matching content a cache miss, so that it's fetched anew regardless of its
+// validators, rather than revalidated.
+const RegexRevalidateTypeMiss = "MISS"
 const RegexRevalidateMinTTL = time.Hour
 
 const ContentTypeRegexRevalidateDotConfig = ContentTypeTextASCII
@@ -94,7 +100,11 @@ func MakeRegexRevalidateDotConfig(
 
 	txt := makeHdrComment(hdrComment)
 	for _, job := range cfgJobs {
-		txt += job.AssetURL + " " + strconv.FormatInt(job.PurgeEnd.Unix(), 10) + "\n"
+		txt += job.AssetURL + " " + strconv.FormatInt(job.PurgeEnd.Unix(), 10)
+		if job.Type != "" {
+			txt += " " + job.Type
+		}
+		txt += "\n"
 	}
 
 	return Cfg{
@@ -108,6 +118,9 @@ func MakeRegexRevalidateDotConfig(
 type job struct {
 	AssetURL string
 	PurgeEnd time.Time
+	// Type is the regex_revalidate rule type, or empty for the default, which
+	// is to revalidate matching content.
+	Type string
 }
 
 type jobsSort []job
@@ -127,11 +140,15 @@ func (jb jobsSort) Less(i, j int) bool {
 //   - have a start time later than (now + maxReval days). That is, we don't query jobs older than maxReval in the past.
 //   - are "purge" jobs
 //   - have a start_time+ttl > now. That is, jobs that haven't expired yet.
+//
+// REFETCH and PURGE jobs become MISS rules, and PURGE jobs match exactly their
+// asset URL. If there are several jobs for the same regex, the rule lasts
+// until the last of them ends, and is a MISS rule if any of them is.
 // Returns the filtered jobs, and any warnings.
 func filterJobs(jobs []tc.Job, maxReval time.Duration, minTTL time.Duration) ([]job, []string) {
 	warnings := []string{}
 
-	jobMap := map[string]time.Time{}
+	jobMap := map[string]job{}
 	for _, job := range jobs {
 		if job.DeliveryService == "" {
 			continue
@@ -178,16 +195,43 @@ func filterJobs(jobs []tc.Job, maxReval time.Duration, minTTL time.Duration) ([]
 
 		purgeEnd := jobStartTime.Add(ttl)
 
-		if existingPurgeEnd, ok := jobMap[job.AssetURL]; !ok || purgeEnd.After(existingPurgeEnd) {
-			jobMap[job.AssetURL] = purgeEnd
+		assetURL, ruleType, err := jobRule(job)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("job %+v %v, config generation skipping!\n", job, err))
+			continue
+		}
+
+		existing, ok := jobMap[assetURL]
+		if !ok || purgeEnd.After(existing.PurgeEnd) {
+			existing.PurgeEnd = purgeEnd
+		}
+		if ruleType != "" {
+			existing.Type = ruleType
 		}
+		existing.AssetURL = assetURL
+		jobMap[assetURL] = existing
 	}
 
 	newJobs := []job{}
-	for assetURL, purgeEnd := range jobMap {
-		newJobs = append(newJobs, job{AssetURL: assetURL, PurgeEnd: purgeEnd})
+	for _, job := range jobMap {
+		newJobs = append(newJobs, job)
 	}
 	sort.Sort(jobsSort(newJobs))
 
 	return newJobs, warnings
 }
+
+// jobRule returns the regex and rule type of the regex_revalidate rule for the
+// given job, based on its type.
+func jobRule(job tc.Job) (string, string, error) {
+	switch job.InvalidationType {
+	case "", tc.InvalidationTypeRefresh:
+		return job.AssetURL, "", nil
+	case tc.InvalidationTypeRefetch:
+		return job.AssetURL, RegexRevalidateTypeMiss, nil
+	case tc.InvalidationTypePurge:
+		return "^" + regexp.QuoteMeta(job.AssetURL) + "$", RegexRevalidateTypeMiss, nil
+	default:
+		return "", "", fmt.Errorf("has unknown invalidation type '%s'", job.InvalidationType)
+	}
+}
@@ -82,3 +82,62 @@ func TestMakeRegexRevalidateDotConfig(t *testing.T) {
 		t.Errorf("expected no expired job, actual '%v'", txt)
 	}
 }
+
+func TestMakeRegexRevalidateDotConfigInvalidationTypes(t *testing.T) {
+	cdnName := "mycdn"
+	server := makeGenericServer()
+	server.CDNName = &cdnName
+
+	ds := makeGenericDS()
+	ds.CDNName = &cdnName
+	ds.XMLID = util.StrPtr("myds")
+	dses := []DeliveryService{*ds}
+
+	startTime := time.Now().Add(-time.Hour).Format(tc.JobTimeFormat)
+	makeJob := func(assetURL string, invalidationType string) tc.Job {
+		return tc.Job{
+			AssetURL:         assetURL,
+			StartTime:        startTime,
+			DeliveryService:  "myds",
+			Parameters:       "TTL:14h",
+			Keyword:          JobKeywordPurge,
+			InvalidationType: invalidationType,
+		}
+	}
+	jobs := []tc.Job{
+		makeJob("http://origin.example/legacy/.*", ""),
+		makeJob("http://origin.example/refresh/.*", tc.InvalidationTypeRefresh),
+		makeJob("http://origin.example/refetch/.*", tc.InvalidationTypeRefetch),
+		makeJob("http://origin.example/purge/a.jpg?b=c", tc.InvalidationTypePurge),
+		makeJob("http://origin.example/unknown/.*", "UNKNOWN"),
+	}
+
+	cfg, err := MakeRegexRevalidateDotConfig(server, dses, nil, jobs, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := map[string][]string{}
+	for _, line := range strings.Split(cfg.Text, "\n") {
+		if fields := strings.Fields(line); len(fields) > 1 && !strings.HasPrefix(line, "#") {
+			lines[fields[0]] = fields[2:]
+		}
+	}
+
+	for _, regex := range []string{"http://origin.example/legacy/.*", "http://origin.example/refresh/.*"} {
+		if rest, ok := lines[regex]; !ok || len(rest) != 0 {
+			t.Errorf("expected a revalidate rule for '%s', actual: %v", regex, cfg.Text)
+		}
+	}
+	if rest, ok := lines["http://origin.example/refetch/.*"]; !ok || len(rest) != 1 || rest[0] != RegexRevalidateTypeMiss {
+		t.Errorf("expected a MISS rule for the refetch job, actual: %v", cfg.Text)
+	}
+	if rest, ok := lines[`^http://origin\.example/purge/a\.jpg\?b=c$`]; !ok || len(rest) != 1 || rest[0] != RegexRevalidateTypeMiss {
+		t.Errorf("expected an exact MISS rule for the purge job, actual: %v", cfg.Text)
+	}
+	if strings.Contains(cfg.Text, "unknown") {
+		t.Errorf("expected no rule for a job of an unknown type, actual: %v", cfg.Text)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Errorf("expected a warning for the job of an unknown type, actual: %v", cfg.Warnings)
+	}
+}
@@ -0,0 +1,74 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"time"
+)
+
+// These are the ACME challenge types with which Traffic Ops can prove control of a Delivery Service's domain.
+const (
+	// AcmeChallengeTypeDNS01 publishes the challenge as a TXT record served by Traffic Router's DNS.
+	AcmeChallengeTypeDNS01 = "dns-01"
+	// AcmeChallengeTypeHTTP01 publishes the challenge as a token served at /.well-known/acme-challenge/ by Traffic Routers and caches.
+	AcmeChallengeTypeHTTP01 = "http-01"
+)
+
+// DefaultAcmeChallengeType is the ACME challenge type used when none is requested.
+const DefaultAcmeChallengeType = AcmeChallengeTypeDNS01
+
+// AcmeChallengeTypes is every valid AcmeChallengeType.
+var AcmeChallengeTypes = []string{
+	AcmeChallengeTypeDNS01,
+	AcmeChallengeTypeHTTP01,
+}
+
+// IsValidAcmeChallengeType returns whether challengeType is one of the AcmeChallengeType constants.
+func IsValidAcmeChallengeType(challengeType string) bool {
+	for _, valid := range AcmeChallengeTypes {
+		if challengeType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// AcmeHTTPChallengePathPrefix is the request path under which ACME HTTP-01 challenge tokens are served.
+const AcmeHTTPChallengePathPrefix = "/.well-known/acme-challenge/"
+
+// AcmeHTTPChallenge is a pending ACME HTTP-01 challenge. A request for AcmeHTTPChallengePathPrefix + Token on Domain must be answered with KeyAuthorization.
+type AcmeHTTPChallenge struct {
+	Domain           string    `json:"domain" db:"domain"`
+	Token            string    `json:"token" db:"token"`
+	KeyAuthorization string    `json:"keyAuthorization" db:"key_authorization"`
+	LastUpdated      time.Time `json:"lastUpdated" db:"last_updated"`
+}
+
+// AcmeHTTPChallengesResponse is the response of the ACME HTTP-01 challenges API.
+type AcmeHTTPChallengesResponse struct {
+	Response []AcmeHTTPChallenge `json:"response"`
+	Alerts
+}
+
+// IsWildcardDomain returns whether domain is a wildcard domain, for which certificates cannot be obtained with AcmeChallengeTypeHTTP01.
+func IsWildcardDomain(domain string) bool {
+	return strings.HasPrefix(domain, "*.")
+}
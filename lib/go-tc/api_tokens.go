@@ -0,0 +1,91 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// APIToken is a personal access token, which authenticates its user in an
+// "Authorization: Bearer" request header in place of a login cookie.
+//
+// A token is scoped to a Role and a set of Capabilities, and requests made
+// with it may only do what both the token and its user are permitted to do.
+type APIToken struct {
+	ID   *int    `json:"id" db:"id"`
+	Name *string `json:"name" db:"name"`
+	// Role is the ID of the Role the token is scoped to. When omitted from a
+	// request to create a token, it defaults to the Role of the requesting
+	// user.
+	Role     *int    `json:"role" db:"role"`
+	RoleName *string `json:"roleName" db:"role_name"`
+	// Capabilities are the Capabilities the token is scoped to. When omitted
+	// from a request to create a token, they default to the Capabilities of
+	// the requesting user.
+	Capabilities []string   `json:"capabilities" db:"capabilities"`
+	ExpiresAt    *time.Time `json:"expiresAt" db:"expires_at"`
+	LastUsed     *time.Time `json:"lastUsed" db:"last_used"`
+	CreatedAt    *time.Time `json:"createdAt" db:"created_at"`
+	// Token is the token itself. Traffic Ops only stores its hash, so it's
+	// only ever returned in the response to the request which created it.
+	Token *string `json:"token,omitempty"`
+}
+
+// Validate validates that the APIToken is valid for creation.
+func (t *APIToken) Validate(tx *sql.Tx) error {
+	errs := validation.Errors{
+		"name":      validation.Validate(t.Name, validation.Required),
+		"expiresAt": validation.Validate(t.ExpiresAt, validation.Required, validation.By(validateFutureTime)),
+	}
+	return util.JoinErrs(tovalidate.ToErrors(errs))
+}
+
+// validateFutureTime validates that the given *time.Time is in the future.
+func validateFutureTime(value interface{}) error {
+	t, ok := value.(*time.Time)
+	if !ok || t == nil {
+		return nil
+	}
+	if !t.After(time.Now()) {
+		return errors.New("must be in the future")
+	}
+	return nil
+}
+
+// APITokensResponse is the type of a response from Traffic Ops to a request
+// for API tokens.
+type APITokensResponse struct {
+	Response []APIToken `json:"response"`
+	Alerts
+}
+
+// APITokenResponse is the type of a response from Traffic Ops to a request to
+// create an API token.
+type APITokenResponse struct {
+	Response APIToken `json:"response"`
+	Alerts
+}
@@ -0,0 +1,97 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import "time"
+
+// The bounds of the number of days of history a CapacityForecast may be
+// based on. Traffic Stats keeps per-minute data for 30 days.
+const (
+	CapacityForecastDefaultDays = 30
+	CapacityForecastMaxDays     = 30
+)
+
+// CapacityForecastSample is the peak bandwidth of one day.
+type CapacityForecastSample struct {
+	Time time.Time `json:"time"`
+	Kbps float64   `json:"kbps"`
+}
+
+// CapacityForecastEntry is the historical bandwidth of a Cache Group or CDN,
+// its trend, and when it's projected to saturate the capacity of its caches.
+type CapacityForecastEntry struct {
+	// CapacityKbps is the usable capacity of the caches: their maximum
+	// bandwidth, less the available bandwidth health threshold of their
+	// Profiles.
+	CapacityKbps float64 `json:"capacityKbps"`
+	// PeakKbps is the greatest bandwidth of any minute.
+	PeakKbps float64 `json:"peakKbps"`
+	// PeakUtilizationPercent is PeakKbps as a percentage of CapacityKbps, or
+	// nil if the capacity is unknown.
+	PeakUtilizationPercent *float64 `json:"peakUtilizationPercent"`
+	// TrendKbpsPerDay is the slope of the least-squares line through the
+	// daily peaks, or nil if there are fewer than two of them.
+	TrendKbpsPerDay *float64 `json:"trendKbpsPerDay"`
+	// DaysToSaturation is how many days until the trend reaches
+	// CapacityKbps, or nil if it never does or the capacity is unknown.
+	DaysToSaturation *float64 `json:"daysToSaturation"`
+	// SaturationDate is the date on which the trend reaches CapacityKbps, or
+	// nil if DaysToSaturation is.
+	SaturationDate *time.Time               `json:"saturationDate"`
+	DailyPeaks     []CapacityForecastSample `json:"dailyPeaks"`
+}
+
+// CapacityForecastDeliveryService is the peak bandwidth of a Delivery
+// Service in a Cache Group.
+type CapacityForecastDeliveryService struct {
+	XMLID    string  `json:"xmlId"`
+	PeakKbps float64 `json:"peakKbps"`
+	// PeakSharePercent is PeakKbps as a percentage of the PeakKbps of the
+	// Cache Group. Delivery Services don't necessarily peak at the same time
+	// as each other, so these needn't add up to 100.
+	PeakSharePercent float64 `json:"peakSharePercent"`
+}
+
+// CapacityForecastCacheGroup is the capacity forecast of a Cache Group,
+// along with the peaks of the Delivery Services which use it, greatest
+// first.
+type CapacityForecastCacheGroup struct {
+	Name string `json:"name"`
+	CapacityForecastEntry
+	DeliveryServices []CapacityForecastDeliveryService `json:"deliveryServices"`
+}
+
+// CapacityForecast is the capacity forecast of a CDN and each of its Cache
+// Groups, based on the bandwidth recorded by Traffic Stats between StartDate
+// and EndDate.
+type CapacityForecast struct {
+	CDNName     string                       `json:"cdnName"`
+	StartDate   time.Time                    `json:"startDate"`
+	EndDate     time.Time                    `json:"endDate"`
+	Total       CapacityForecastEntry        `json:"total"`
+	CacheGroups []CapacityForecastCacheGroup `json:"cacheGroups"`
+}
+
+// CapacityForecastResponse is the type of a response from Traffic Ops to a
+// request for the capacity forecast of a CDN.
+type CapacityForecastResponse struct {
+	Response CapacityForecast `json:"response"`
+	Alerts
+}
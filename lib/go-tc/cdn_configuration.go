@@ -0,0 +1,274 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// CDNConfigurationVersion is the version of the CDN configuration document format produced and accepted by Traffic Ops.
+const CDNConfigurationVersion = 1
+
+// These are the actions of changes in a CDN configuration plan.
+const (
+	CDNConfigurationCreate = "create"
+	CDNConfigurationUpdate = "update"
+	CDNConfigurationDelete = "delete"
+)
+
+// CDNConfiguration is a declarative document of the configuration of a CDN: its Profiles and their Parameters, its servers, and its Delivery Services, along with the Divisions, Regions, Physical Locations, Cache Groups, Topologies, and Server Capabilities they use.
+//
+// Objects refer to each other by name rather than by ID, so a document exported from one Traffic Ops instance can be applied to another.
+type CDNConfiguration struct {
+	// Version is the version of the document format, which must be CDNConfigurationVersion.
+	Version            int                               `json:"version"`
+	CDN                CDNConfigurationCDN               `json:"cdn"`
+	Divisions          []CDNConfigurationDivision        `json:"divisions"`
+	Regions            []CDNConfigurationRegion          `json:"regions"`
+	PhysLocations      []CDNConfigurationPhysLocation    `json:"physLocations"`
+	CacheGroups        []CDNConfigurationCacheGroup      `json:"cacheGroups"`
+	Topologies         []CDNConfigurationTopology        `json:"topologies"`
+	ServerCapabilities []string                          `json:"serverCapabilities"`
+	Profiles           []CDNConfigurationProfile         `json:"profiles"`
+	Servers            []CDNConfigurationServer          `json:"servers"`
+	DeliveryServices   []CDNConfigurationDeliveryService `json:"deliveryServices"`
+}
+
+// CDNConfigurationCDN is the CDN of a CDNConfiguration.
+type CDNConfigurationCDN struct {
+	Name          string `json:"name"`
+	DomainName    string `json:"domainName"`
+	DNSSECEnabled bool   `json:"dnssecEnabled"`
+}
+
+// CDNConfigurationDivision is a Division in a CDNConfiguration.
+type CDNConfigurationDivision struct {
+	Name string `json:"name"`
+}
+
+// CDNConfigurationRegion is a Region in a CDNConfiguration.
+type CDNConfigurationRegion struct {
+	Name     string `json:"name"`
+	Division string `json:"division"`
+}
+
+// CDNConfigurationPhysLocation is a Physical Location in a CDNConfiguration.
+type CDNConfigurationPhysLocation struct {
+	Name      string  `json:"name"`
+	ShortName string  `json:"shortName"`
+	Region    string  `json:"region"`
+	Address   string  `json:"address"`
+	City      string  `json:"city"`
+	State     string  `json:"state"`
+	Zip       string  `json:"zip"`
+	Comments  *string `json:"comments"`
+	Email     *string `json:"email"`
+	Phone     *string `json:"phone"`
+	POC       *string `json:"poc"`
+}
+
+// CDNConfigurationCacheGroup is a Cache Group in a CDNConfiguration.
+type CDNConfigurationCacheGroup struct {
+	Name                      string               `json:"name"`
+	ShortName                 string               `json:"shortName"`
+	Type                      string               `json:"type"`
+	Latitude                  *float64             `json:"latitude"`
+	Longitude                 *float64             `json:"longitude"`
+	ParentCacheGroup          *string              `json:"parentCacheGroup"`
+	SecondaryParentCacheGroup *string              `json:"secondaryParentCacheGroup"`
+	FallbackToClosest         *bool                `json:"fallbackToClosest"`
+	LocalizationMethods       []LocalizationMethod `json:"localizationMethods"`
+	Fallbacks                 []string             `json:"fallbacks"`
+}
+
+// CDNConfigurationTopology is a Topology in a CDNConfiguration. The Parents of each node are indices into Nodes.
+type CDNConfigurationTopology struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Nodes       []TopologyNode `json:"nodes"`
+}
+
+// CDNConfigurationProfile is a Profile in a CDNConfiguration, along with its Parameters.
+type CDNConfigurationProfile struct {
+	Name            string                      `json:"name"`
+	Description     string                      `json:"description"`
+	Type            string                      `json:"type"`
+	RoutingDisabled bool                        `json:"routingDisabled"`
+	Parameters      []CDNConfigurationParameter `json:"parameters"`
+}
+
+// CDNConfigurationParameter is a Parameter of a Profile in a CDNConfiguration.
+type CDNConfigurationParameter struct {
+	Name       string `json:"name"`
+	ConfigFile string `json:"configFile"`
+	Value      string `json:"value"`
+	Secure     bool   `json:"secure"`
+}
+
+// CDNConfigurationServerOmittedFields are the fields of a ServerV40 which are identifiers, are implied by the CDN, or are maintained by Traffic Ops, and so are omitted from CDN configuration documents.
+var CDNConfigurationServerOmittedFields = []string{"cachegroupId", "cdnId", "cdnName", "deliveryServices", "fqdn", "id", "lastUpdated", "physLocationId", "profileDesc", "profileId", "revalPending", "statusId", "statusLastUpdated", "typeId", "updPending", "xmppId", "xmppPasswd"}
+
+// CDNConfigurationServer is a server in a CDNConfiguration, along with the Server Capabilities it has. When serialized, the CDNConfigurationServerOmittedFields of the server are omitted.
+type CDNConfigurationServer struct {
+	ServerV40
+	Capabilities []string `json:"capabilities"`
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (s CDNConfigurationServer) MarshalJSON() ([]byte, error) {
+	return marshalJSONWithout(s.ServerV40, CDNConfigurationServerOmittedFields, map[string]interface{}{"capabilities": s.Capabilities})
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (s *CDNConfigurationServer) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.ServerV40); err != nil {
+		return err
+	}
+	extra := struct {
+		Capabilities []string `json:"capabilities"`
+	}{}
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
+	}
+	s.Capabilities = extra.Capabilities
+	return nil
+}
+
+// CDNConfigurationDeliveryServiceOmittedFields are the fields of a DeliveryServiceV4 which are identifiers, are implied by the CDN, or are maintained by Traffic Ops, and so are omitted from CDN configuration documents.
+var CDNConfigurationDeliveryServiceOmittedFields = []string{"cdnId", "cdnName", "exampleURLs", "id", "lastUpdated", "matchList", "profileDescription", "profileId", "signed", "sslKeyVersion", "tenantId", "typeId"}
+
+// CDNConfigurationDeliveryService is a Delivery Service in a CDNConfiguration, along with its required Server Capabilities and the host names of the servers assigned to it. When serialized, the CDNConfigurationDeliveryServiceOmittedFields of the Delivery Service are omitted.
+type CDNConfigurationDeliveryService struct {
+	DeliveryServiceV4
+	RequiredCapabilities []string `json:"requiredCapabilities"`
+	Servers              []string `json:"servers"`
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (ds CDNConfigurationDeliveryService) MarshalJSON() ([]byte, error) {
+	return marshalJSONWithout(ds.DeliveryServiceV4, CDNConfigurationDeliveryServiceOmittedFields, map[string]interface{}{
+		"requiredCapabilities": ds.RequiredCapabilities,
+		"servers":              ds.Servers,
+	})
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (ds *CDNConfigurationDeliveryService) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &ds.DeliveryServiceV4); err != nil {
+		return err
+	}
+	extra := struct {
+		RequiredCapabilities []string `json:"requiredCapabilities"`
+		Servers              []string `json:"servers"`
+	}{}
+	if err := json.Unmarshal(data, &extra); err != nil {
+		return err
+	}
+	ds.RequiredCapabilities = extra.RequiredCapabilities
+	ds.Servers = extra.Servers
+	return nil
+}
+
+// marshalJSONWithout serializes v as a JSON object without the given fields, and with the given extra fields.
+func marshalJSONWithout(v interface{}, omit []string, extra map[string]interface{}) ([]byte, error) {
+	bts, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]json.RawMessage{}
+	if err := json.Unmarshal(bts, &obj); err != nil {
+		return nil, err
+	}
+	for _, field := range omit {
+		delete(obj, field)
+	}
+	for field, val := range extra {
+		bts, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		obj[field] = bts
+	}
+	return json.Marshal(obj)
+}
+
+// Validate returns an error if the document isn't of the current version, or if any of its objects are missing their names or share them with another object of the same type. References between objects are validated when the document is applied.
+func (c CDNConfiguration) Validate() error {
+	errs := []error{}
+	if c.Version != CDNConfigurationVersion {
+		errs = append(errs, fmt.Errorf("version: unsupported version %d, must be %d", c.Version, CDNConfigurationVersion))
+	}
+	if c.CDN.Name == "" {
+		errs = append(errs, errors.New("cdn: name is required"))
+	}
+
+	names := func(objType string, n int, name func(int) string) {
+		seen := make(map[string]struct{}, n)
+		for i := 0; i < n; i++ {
+			nm := strings.TrimSpace(name(i))
+			if nm == "" {
+				errs = append(errs, fmt.Errorf("%s %d: name is required", objType, i))
+				continue
+			}
+			if _, ok := seen[nm]; ok {
+				errs = append(errs, fmt.Errorf("%s '%s': duplicate name", objType, nm))
+			}
+			seen[nm] = struct{}{}
+		}
+	}
+	names("divisions", len(c.Divisions), func(i int) string { return c.Divisions[i].Name })
+	names("regions", len(c.Regions), func(i int) string { return c.Regions[i].Name })
+	names("physLocations", len(c.PhysLocations), func(i int) string { return c.PhysLocations[i].Name })
+	names("cacheGroups", len(c.CacheGroups), func(i int) string { return c.CacheGroups[i].Name })
+	names("topologies", len(c.Topologies), func(i int) string { return c.Topologies[i].Name })
+	names("serverCapabilities", len(c.ServerCapabilities), func(i int) string { return c.ServerCapabilities[i] })
+	names("profiles", len(c.Profiles), func(i int) string { return c.Profiles[i].Name })
+	names("servers", len(c.Servers), func(i int) string { return coerceString(c.Servers[i].HostName) })
+	names("deliveryServices", len(c.DeliveryServices), func(i int) string { return coerceString(c.DeliveryServices[i].XMLID) })
+	return util.JoinErrs(errs)
+}
+
+// CDNConfigurationChange is a change to a single object which applying a CDNConfiguration makes, or would make.
+type CDNConfigurationChange struct {
+	// Action is one of CDNConfigurationCreate, CDNConfigurationUpdate, or CDNConfigurationDelete.
+	Action string `json:"action"`
+	// Type is the type of the object, which is the name of its list in a CDNConfiguration, e.g. "deliveryServices".
+	Type string `json:"type"`
+	// Name is the name of the object, or the host name of a server, or the XMLID of a Delivery Service.
+	Name string `json:"name"`
+	// Fields are the fields of the object which are changed by an update.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// CDNConfigurationPlan is the list of changes, in the order they are made, to apply a CDNConfiguration.
+type CDNConfigurationPlan struct {
+	Changes []CDNConfigurationChange `json:"changes"`
+}
+
+// CDNConfigurationPlanResponse is the type of a response from Traffic Ops to a request to plan or apply a CDNConfiguration.
+type CDNConfigurationPlanResponse struct {
+	Response CDNConfigurationPlan `json:"response"`
+	Alerts
+}
@@ -0,0 +1,127 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCDNConfigurationServerJSON(t *testing.T) {
+	id := 42
+	cdnName := "cdn"
+	hostName := "edge"
+	srv := CDNConfigurationServer{Capabilities: []string{"RAM"}}
+	srv.ID = &id
+	srv.CDNName = &cdnName
+	srv.HostName = &hostName
+
+	bts, err := json.Marshal(srv)
+	if err != nil {
+		t.Fatalf("marshalling server: %v", err)
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(bts, &obj); err != nil {
+		t.Fatalf("unmarshalling server: %v", err)
+	}
+	for _, field := range CDNConfigurationServerOmittedFields {
+		if _, ok := obj[field]; ok {
+			t.Errorf("expected field '%s' to be omitted, actual: present", field)
+		}
+	}
+	if obj["hostName"] != hostName {
+		t.Errorf("expected hostName '%s', actual: %v", hostName, obj["hostName"])
+	}
+
+	decoded := CDNConfigurationServer{}
+	if err := json.Unmarshal(bts, &decoded); err != nil {
+		t.Fatalf("decoding server: %v", err)
+	}
+	if decoded.HostName == nil || *decoded.HostName != hostName {
+		t.Errorf("expected decoded hostName '%s', actual: %v", hostName, decoded.HostName)
+	}
+	if decoded.ID != nil {
+		t.Errorf("expected decoded id to be nil, actual: %d", *decoded.ID)
+	}
+	if len(decoded.Capabilities) != 1 || decoded.Capabilities[0] != "RAM" {
+		t.Errorf("expected decoded capabilities [RAM], actual: %v", decoded.Capabilities)
+	}
+}
+
+func TestCDNConfigurationDeliveryServiceJSON(t *testing.T) {
+	id := 7
+	xmlID := "demo"
+	ds := CDNConfigurationDeliveryService{RequiredCapabilities: []string{"RAM"}, Servers: []string{"edge"}}
+	ds.ID = &id
+	ds.XMLID = &xmlID
+
+	bts, err := json.Marshal(ds)
+	if err != nil {
+		t.Fatalf("marshalling delivery service: %v", err)
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(bts, &obj); err != nil {
+		t.Fatalf("unmarshalling delivery service: %v", err)
+	}
+	for _, field := range CDNConfigurationDeliveryServiceOmittedFields {
+		if _, ok := obj[field]; ok {
+			t.Errorf("expected field '%s' to be omitted, actual: present", field)
+		}
+	}
+
+	decoded := CDNConfigurationDeliveryService{}
+	if err := json.Unmarshal(bts, &decoded); err != nil {
+		t.Fatalf("decoding delivery service: %v", err)
+	}
+	if decoded.XMLID == nil || *decoded.XMLID != xmlID {
+		t.Errorf("expected decoded xmlId '%s', actual: %v", xmlID, decoded.XMLID)
+	}
+	if len(decoded.Servers) != 1 || decoded.Servers[0] != "edge" {
+		t.Errorf("expected decoded servers [edge], actual: %v", decoded.Servers)
+	}
+	if len(decoded.RequiredCapabilities) != 1 || decoded.RequiredCapabilities[0] != "RAM" {
+		t.Errorf("expected decoded required capabilities [RAM], actual: %v", decoded.RequiredCapabilities)
+	}
+}
+
+func TestCDNConfigurationValidate(t *testing.T) {
+	valid := CDNConfiguration{
+		Version:   CDNConfigurationVersion,
+		CDN:       CDNConfigurationCDN{Name: "cdn"},
+		Divisions: []CDNConfigurationDivision{{Name: "a"}, {Name: "b"}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected valid configuration, actual error: %v", err)
+	}
+
+	invalid := valid
+	invalid.Version = CDNConfigurationVersion + 1
+	invalid.Divisions = []CDNConfigurationDivision{{Name: "a"}, {Name: "a"}, {Name: ""}}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("expected invalid configuration to return an error, actual: nil")
+	}
+	for _, expected := range []string{"version", "duplicate name", "name is required"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain '%s', actual: %v", expected, err)
+		}
+	}
+}
@@ -52,6 +52,12 @@ type CDN struct {
 	// required: true
 	DNSSECEnabled bool `json:"dnssecEnabled" db:"dnssec_enabled"`
 
+	// DNSSECAlgorithm is the algorithm of the DNSSEC keys generated for the
+	// CDN; if empty, the CDN's algorithm is unchanged, or
+	// DefaultDNSSECAlgorithm for a new CDN
+	//
+	DNSSECAlgorithm string `json:"dnssecAlgorithm" db:"dnssec_algorithm"`
+
 	// DomainName of the CDN
 	//
 	// required: true
@@ -82,6 +88,12 @@ type CDNNullable struct {
 	// required: true
 	DNSSECEnabled *bool `json:"dnssecEnabled" db:"dnssec_enabled"`
 
+	// DNSSECAlgorithm is the algorithm of the DNSSEC keys generated for the
+	// CDN; if null or empty, the CDN's algorithm is unchanged, or
+	// DefaultDNSSECAlgorithm for a new CDN
+	//
+	DNSSECAlgorithm *string `json:"dnssecAlgorithm" db:"dnssec_algorithm"`
+
 	// DomainName of the CDN
 	//
 	// required: true
@@ -33,6 +33,36 @@ type CRStates struct {
 type CRStatesDeliveryService struct {
 	DisabledLocations []CacheGroupName `json:"disabledLocations"`
 	IsAvailable       bool             `json:"isAvailable"`
+	// State is the graded health of the delivery service. It is empty if the Traffic Monitor doesn't evaluate delivery service health thresholds.
+	State DSHealthState `json:"state,omitempty"`
+	// OverCapacityLocations are the cachegroups disabled because the delivery service exceeded its per-cachegroup capacity thresholds in them. They are also included in DisabledLocations.
+	OverCapacityLocations []CacheGroupName `json:"overCapacityLocations,omitempty"`
+}
+
+// DSHealthState is the graded health of a delivery service, as evaluated against its health thresholds.
+type DSHealthState string
+
+const (
+	// DSHealthStateAvailable indicates the delivery service meets all of its health thresholds.
+	DSHealthStateAvailable = DSHealthState("available")
+	// DSHealthStateDegraded indicates the delivery service is still available, but doesn't meet one or more of its degraded thresholds, or has cachegroups marked down for exceeding their capacity.
+	DSHealthStateDegraded = DSHealthState("degraded")
+	// DSHealthStateUnavailable indicates the delivery service doesn't meet one or more of its unavailable thresholds, or has no available caches.
+	DSHealthStateUnavailable = DSHealthState("unavailable")
+)
+
+// Rank returns the ordering of the state, from most to least healthy, starting at 0. Unknown and empty states rank below unavailable.
+func (s DSHealthState) Rank() int {
+	switch s {
+	case DSHealthStateAvailable:
+		return 0
+	case DSHealthStateDegraded:
+		return 1
+	case DSHealthStateUnavailable:
+		return 2
+	default:
+		return 3
+	}
 }
 
 // IsAvailable contains whether the given cache or delivery service is available. It is designed for JSON serialization, namely in the Traffic Monitor 1.0 API.
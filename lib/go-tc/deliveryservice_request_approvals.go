@@ -0,0 +1,149 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// DSRWebhookObjectType is the object type of webhook events of Delivery
+// Service Requests being approved and applied.
+const DSRWebhookObjectType = "deliveryservice_request"
+
+// The actions of the change log entries - and webhook events - of Delivery
+// Service Requests being approved and applied.
+const (
+	DSRActionApproved = "Approved"
+	DSRActionApplied  = "Applied"
+)
+
+// DSRApprovalPolicy is a policy governing the approval of the Delivery
+// Service Requests of the Delivery Services in a Tenant and/or CDN.
+//
+// The policy which applies to a request is the most specific one matching its
+// Delivery Service: a policy for both its Tenant and CDN, then one for its
+// Tenant, then one for its CDN, then one for neither. Requests to which no
+// policy applies don't need to be approved.
+type DSRApprovalPolicy struct {
+	ID *int `json:"id"`
+	// TenantID is the ID of the Tenant of the Delivery Services to which the
+	// policy applies, or nil if it applies to every Tenant.
+	TenantID *int `json:"tenantId"`
+	// Tenant is the name of the Tenant identified by TenantID. It's ignored
+	// in requests.
+	Tenant *string `json:"tenant"`
+	// CDNID is the ID of the CDN of the Delivery Services to which the policy
+	// applies, or nil if it applies to every CDN.
+	CDNID *int `json:"cdnId"`
+	// CDNName is the name of the CDN identified by CDNID. It's ignored in
+	// requests.
+	CDNName *string `json:"cdnName"`
+	// RequiredApprovals is the number of distinct users who must approve a
+	// request before it's applied.
+	RequiredApprovals *int `json:"requiredApprovals"`
+	// ApproverRoles are the names of the Roles of the users who may approve
+	// requests. If it's empty, users with any Role that can reach the
+	// approval endpoint may.
+	ApproverRoles []string `json:"approverRoles"`
+	// AllowSelfApproval is whether the author of a request, and the user who
+	// last edited or submitted it, may approve it.
+	AllowSelfApproval *bool      `json:"allowSelfApproval"`
+	LastUpdated       *TimeNoMod `json:"lastUpdated"`
+}
+
+// Validate validates that the DSRApprovalPolicy is valid for creation or
+// update. Whether its Tenant, CDN and Roles exist is checked by Traffic Ops.
+func (p *DSRApprovalPolicy) Validate(tx *sql.Tx) error {
+	errs := validation.Errors{
+		"requiredApprovals": validation.Validate(p.RequiredApprovals, validation.Required, validation.Min(1)),
+	}
+	for _, role := range p.ApproverRoles {
+		if role == "" {
+			errs["approverRoles"] = errors.New("must not contain empty role names")
+			break
+		}
+	}
+	return util.JoinErrs(tovalidate.ToErrors(errs))
+}
+
+// DSRApprovalPoliciesResponse is the type of a response from Traffic Ops to a
+// request for Delivery Service Request approval policies.
+type DSRApprovalPoliciesResponse struct {
+	Response []DSRApprovalPolicy `json:"response"`
+	Alerts
+}
+
+// DSRApprovalPolicyResponse is the type of a response from Traffic Ops to a
+// request to create or update a Delivery Service Request approval policy.
+type DSRApprovalPolicyResponse struct {
+	Response DSRApprovalPolicy `json:"response"`
+	Alerts
+}
+
+// DeliveryServiceRequestApproval is a user's approval of a Delivery Service
+// Request.
+type DeliveryServiceRequestApproval struct {
+	ApproverID int       `json:"approverId"`
+	Approver   string    `json:"approver"`
+	Comment    *string   `json:"comment"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// DeliveryServiceRequestApprovals is the state of the approval of a Delivery
+// Service Request.
+type DeliveryServiceRequestApprovals struct {
+	DeliveryServiceRequestID int `json:"deliveryServiceRequestId"`
+	// Status is the current status of the request.
+	Status RequestStatus `json:"status"`
+	// PolicyID is the ID of the approval policy which applies to the request,
+	// or nil if none does.
+	PolicyID *int `json:"policyId"`
+	// RequiredApprovals is the number of approvals the request needs before
+	// it's applied. It's 0 if no policy applies to the request.
+	RequiredApprovals int                              `json:"requiredApprovals"`
+	Approvals         []DeliveryServiceRequestApproval `json:"approvals"`
+}
+
+// DeliveryServiceRequestApprovalsResponse is the type of a response from
+// Traffic Ops to a request for - or to make - approvals of a Delivery Service
+// Request.
+type DeliveryServiceRequestApprovalsResponse struct {
+	Response DeliveryServiceRequestApprovals `json:"response"`
+	Alerts
+}
+
+// DeliveryServiceRequestApprovalRequest is the body of a request to approve a
+// Delivery Service Request.
+type DeliveryServiceRequestApprovalRequest struct {
+	Comment *string `json:"comment"`
+}
+
+// Validate implements the ParseValidator interface. Any approval request is
+// valid; whether the user may approve the request is checked by Traffic Ops.
+func (*DeliveryServiceRequestApprovalRequest) Validate(*sql.Tx) error {
+	return nil
+}
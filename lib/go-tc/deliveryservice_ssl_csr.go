@@ -0,0 +1,146 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+// These are the algorithms of private keys which Traffic Ops can generate for Delivery Service SSL certificates.
+const (
+	SSLKeyAlgorithmRSA2048   = "RSA-2048"
+	SSLKeyAlgorithmRSA3072   = "RSA-3072"
+	SSLKeyAlgorithmRSA4096   = "RSA-4096"
+	SSLKeyAlgorithmECDSAP256 = "ECDSA-P256"
+	SSLKeyAlgorithmECDSAP384 = "ECDSA-P384"
+)
+
+// DefaultSSLKeyAlgorithm is the algorithm of generated Delivery Service private keys, when none is requested.
+const DefaultSSLKeyAlgorithm = SSLKeyAlgorithmRSA2048
+
+// SSLKeyAlgorithms is every valid SSLKeyAlgorithm.
+var SSLKeyAlgorithms = []string{
+	SSLKeyAlgorithmRSA2048,
+	SSLKeyAlgorithmRSA3072,
+	SSLKeyAlgorithmRSA4096,
+	SSLKeyAlgorithmECDSAP256,
+	SSLKeyAlgorithmECDSAP384,
+}
+
+// IsValidSSLKeyAlgorithm returns whether alg is one of the SSLKeyAlgorithm constants.
+func IsValidSSLKeyAlgorithm(alg string) bool {
+	for _, valid := range SSLKeyAlgorithms {
+		if alg == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// IsECDSASSLKeyAlgorithm returns whether alg is an ECDSA SSLKeyAlgorithm.
+func IsECDSASSLKeyAlgorithm(alg string) bool {
+	return alg == SSLKeyAlgorithmECDSAP256 || alg == SSLKeyAlgorithmECDSAP384
+}
+
+// DeliveryServiceSSLKeysCSRReq is a request to generate a private key and certificate signing request for a Delivery Service, to be signed by an external Certificate Authority.
+type DeliveryServiceSSLKeysCSRReq struct {
+	SSLKeyRequestFields
+	// KeyAlgorithm is the algorithm of the generated private key, one of the SSLKeyAlgorithm constants. If omitted, DefaultSSLKeyAlgorithm is used.
+	KeyAlgorithm *string `json:"keyAlgorithm,omitempty"`
+}
+
+// Validate implements the github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator interface.
+func (r *DeliveryServiceSSLKeysCSRReq) Validate(tx *sql.Tx) error {
+	errs := []string{}
+	if checkNilOrEmpty(r.BusinessUnit) {
+		errs = append(errs, "businessUnit required")
+	}
+	if checkNilOrEmpty(r.City) {
+		errs = append(errs, "city required")
+	}
+	if checkNilOrEmpty(r.Organization) {
+		errs = append(errs, "organization required")
+	}
+	if checkNilOrEmpty(r.Country) {
+		errs = append(errs, "country required")
+	}
+	if checkNilOrEmpty(r.State) {
+		errs = append(errs, "state required")
+	}
+	if len(errs) > 0 {
+		return errors.New("missing fields: " + strings.Join(errs, "; "))
+	}
+	if r.KeyAlgorithm != nil && !IsValidSSLKeyAlgorithm(*r.KeyAlgorithm) {
+		return errors.New("keyAlgorithm must be one of " + strings.Join(SSLKeyAlgorithms, ", "))
+	}
+	return nil
+}
+
+// DeliveryServiceSSLKeysCSR is a pending certificate signing request of a Delivery Service, waiting for its certificate to be signed by an external Certificate Authority.
+type DeliveryServiceSSLKeysCSR struct {
+	DeliveryService string `json:"deliveryservice"`
+	CDN             string `json:"cdn"`
+	BusinessUnit    string `json:"businessUnit"`
+	City            string `json:"city"`
+	Organization    string `json:"organization"`
+	Hostname        string `json:"hostname"`
+	Country         string `json:"country"`
+	State           string `json:"state"`
+	KeyAlgorithm    string `json:"keyAlgorithm"`
+	// SubjectAlternativeNames are the DNS names requested by the CSR, which the signed certificate must cover.
+	SubjectAlternativeNames []string `json:"subjectAlternativeNames"`
+	// CSR is the PEM-encoded certificate signing request.
+	CSR     string    `json:"csr"`
+	Created time.Time `json:"created"`
+}
+
+// DeliveryServiceSSLKeysPendingCSR is a pending certificate signing request together with its private key, as stored in Traffic Vault. The key is never returned by the API.
+type DeliveryServiceSSLKeysPendingCSR struct {
+	DeliveryServiceSSLKeysCSR
+	// Key is the PEM-encoded private key of the CSR.
+	Key string `json:"key"`
+}
+
+// DeliveryServiceSSLKeysCSRResponse is the response of the Delivery Service SSL keys CSR API.
+type DeliveryServiceSSLKeysCSRResponse struct {
+	Response DeliveryServiceSSLKeysCSR `json:"response"`
+	Alerts
+}
+
+// DeliveryServiceSSLKeysCSRCertReq is the certificate, signed by an external Certificate Authority, of a pending Delivery Service certificate signing request.
+type DeliveryServiceSSLKeysCSRCertReq struct {
+	// Crt is the PEM-encoded signed certificate. It may be followed by its chain of intermediate certificates.
+	Crt *string `json:"crt"`
+	// Chain is the PEM-encoded chain of intermediate certificates, if not included in Crt.
+	Chain *string `json:"chain,omitempty"`
+	// AuthType is the authentication type stored with the keys. If omitted, CertificateAuthorityCertAuthType is used.
+	AuthType *string `json:"authType,omitempty"`
+}
+
+// Validate implements the github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator interface.
+func (r *DeliveryServiceSSLKeysCSRCertReq) Validate(tx *sql.Tx) error {
+	if checkNilOrEmpty(r.Crt) {
+		return errors.New("missing fields: crt required")
+	}
+	return nil
+}
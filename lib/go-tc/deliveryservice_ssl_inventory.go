@@ -0,0 +1,60 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// DeliveryServiceCertificate describes the latest certificate of a Delivery Service in Traffic Vault, as listed by the SSL keys inventory API.
+type DeliveryServiceCertificate struct {
+	DeliveryService string          `json:"deliveryservice"`
+	CDN             string          `json:"cdn"`
+	TenantID        int             `json:"tenantId"`
+	Tenant          string          `json:"tenant"`
+	Version         util.JSONIntStr `json:"version"`
+	AuthType        string          `json:"authType"`
+	// Error is the reason the certificate could not be read from Traffic Vault or parsed. If it is set, only the fields above are.
+	Error *string `json:"error,omitempty"`
+
+	Subject string `json:"subject"`
+	Issuer  string `json:"issuer"`
+	// KeyType is the algorithm of the certificate's public key, e.g. "RSA-2048" or "ECDSA-P256".
+	KeyType                 string    `json:"keyType"`
+	SubjectAlternativeNames []string  `json:"subjectAlternativeNames"`
+	NotBefore               time.Time `json:"notBefore"`
+	NotAfter                time.Time `json:"notAfter"`
+	// DaysToExpiry is the number of whole days until NotAfter, which is negative if the certificate has expired.
+	DaysToExpiry int `json:"daysToExpiry"`
+	// ChainValid is whether the certificate chains to a root trusted by Traffic Ops, through the intermediate certificates stored with it. If not, ChainError is the reason.
+	ChainValid bool    `json:"chainValid"`
+	ChainError *string `json:"chainError,omitempty"`
+	// HostnamesMatch is whether the certificate is valid for every host name of the Delivery Service's example URLs. If not, UnmatchedHostnames lists those it isn't valid for.
+	HostnamesMatch     bool     `json:"hostnamesMatch"`
+	UnmatchedHostnames []string `json:"unmatchedHostnames"`
+}
+
+// DeliveryServiceCertificatesResponse is the response of the SSL keys inventory API.
+type DeliveryServiceCertificatesResponse struct {
+	Response []DeliveryServiceCertificate `json:"response"`
+	Alerts
+}
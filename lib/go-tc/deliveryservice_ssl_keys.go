@@ -56,6 +56,8 @@ type DeliveryServiceSSLKeys struct {
 	Key             string                            `json:"key"`
 	Version         util.JSONIntStr                   `json:"version"`
 	Certificate     DeliveryServiceSSLKeysCertificate `json:"certificate,omitempty"`
+	// AcmeChallengeType is the ACME challenge type with which the certificate was obtained, and with which it is renewed. It is empty for certificates not obtained with ACME.
+	AcmeChallengeType string `json:"acmeChallengeType,omitempty"`
 }
 
 type DeliveryServiceSSLKeysV15 struct {
@@ -158,6 +160,8 @@ func (r *DeliveryServiceAddSSLKeysReq) Validate(tx *sql.Tx) error {
 
 type DeliveryServiceGenSSLKeysReq struct {
 	DeliveryServiceSSLKeysReq
+	// KeyAlgorithm is the algorithm of the generated private key, one of the SSLKeyAlgorithm constants. If omitted, DefaultSSLKeyAlgorithm is used.
+	KeyAlgorithm *string `json:"keyAlgorithm,omitempty"`
 }
 
 func (r *DeliveryServiceGenSSLKeysReq) Validate(tx *sql.Tx) error {
@@ -181,11 +185,16 @@ func (r *DeliveryServiceGenSSLKeysReq) Validate(tx *sql.Tx) error {
 	if len(errs) > 0 {
 		return errors.New("missing fields: " + strings.Join(errs, "; "))
 	}
+	if r.KeyAlgorithm != nil && !IsValidSSLKeyAlgorithm(*r.KeyAlgorithm) {
+		return errors.New("keyAlgorithm must be one of " + strings.Join(SSLKeyAlgorithms, ", "))
+	}
 	return nil
 }
 
 type DeliveryServiceLetsEncryptSSLKeysReq struct {
 	DeliveryServiceSSLKeysReq
+	// ChallengeType is the ACME challenge type used to prove control of the hostname, one of the AcmeChallengeType constants. If omitted, DefaultAcmeChallengeType is used.
+	ChallengeType *string `json:"challengeType,omitempty"`
 }
 
 func (r *DeliveryServiceLetsEncryptSSLKeysReq) Validate(tx *sql.Tx) error {
@@ -194,6 +203,14 @@ func (r *DeliveryServiceLetsEncryptSSLKeysReq) Validate(tx *sql.Tx) error {
 	if len(errs) > 0 {
 		return errors.New("missing fields: " + strings.Join(errs, "; "))
 	}
+	if r.ChallengeType != nil {
+		if !IsValidAcmeChallengeType(*r.ChallengeType) {
+			return errors.New("challengeType must be one of " + strings.Join(AcmeChallengeTypes, ", "))
+		}
+		if *r.ChallengeType == AcmeChallengeTypeHTTP01 && IsWildcardDomain(*r.HostName) {
+			return errors.New("challengeType " + AcmeChallengeTypeHTTP01 + " cannot be used with wildcard hostnames")
+		}
+	}
 	return nil
 }
 
@@ -242,6 +259,9 @@ type DNSSECKeyV11 struct {
 	Public             string                `json:"public"`
 	Private            string                `json:"private"`
 	DSRecord           *DNSSECKeyDSRecordV11 `json:"dsRecord,omitempty"`
+	// RolloverPhase is the key's place in a rollover, one of the DNSSECRolloverPhase constants. Keys generated before
+	// rollovers were tracked have none.
+	RolloverPhase string `json:"rolloverPhase,omitempty"`
 }
 
 // DNSSECKeyDSRecordRiak is a DNSSEC key DS record, as stored in Riak.
@@ -111,3 +111,58 @@ func TestSSLKeysReqValidate(t *testing.T) {
 		t.Error("expected validation to return an error")
 	}
 }
+
+func TestSSLKeysCSRReqValidate(t *testing.T) {
+	req := DeliveryServiceSSLKeysCSRReq{}
+	req.BusinessUnit = util.StrPtr("unit")
+	req.City = util.StrPtr("Denver")
+	req.Organization = util.StrPtr("org")
+	req.Country = util.StrPtr("US")
+	req.State = util.StrPtr("CO")
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request without keyAlgorithm to be valid, actual: %v", err)
+	}
+	req.KeyAlgorithm = util.StrPtr(SSLKeyAlgorithmECDSAP384)
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request with keyAlgorithm %s to be valid, actual: %v", SSLKeyAlgorithmECDSAP384, err)
+	}
+	req.KeyAlgorithm = util.StrPtr("RSA-1024")
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request with invalid keyAlgorithm to return an error")
+	}
+	req.KeyAlgorithm = nil
+	req.State = nil
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request without state to return an error")
+	}
+}
+
+func TestLetsEncryptSSLKeysReqValidateChallengeType(t *testing.T) {
+	req := DeliveryServiceLetsEncryptSSLKeysReq{}
+	req.CDN = util.StrPtr("foo")
+	req.DeliveryService = util.StrPtr("bar")
+	req.Key = util.StrPtr("bar")
+	req.HostName = util.StrPtr("bar.foo.example.net")
+	ver := util.JSONIntStr(1)
+	req.Version = &ver
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request without challengeType to be valid, actual: %v", err)
+	}
+	req.ChallengeType = util.StrPtr(AcmeChallengeTypeHTTP01)
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request with challengeType %s to be valid, actual: %v", AcmeChallengeTypeHTTP01, err)
+	}
+	req.ChallengeType = util.StrPtr("tls-alpn-01")
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request with invalid challengeType to return an error")
+	}
+	req.ChallengeType = util.StrPtr(AcmeChallengeTypeHTTP01)
+	req.HostName = util.StrPtr("*.foo.example.net")
+	if err := req.Validate(nil); err == nil {
+		t.Errorf("expected request with challengeType %s and a wildcard hostname to return an error", AcmeChallengeTypeHTTP01)
+	}
+	req.ChallengeType = util.StrPtr(AcmeChallengeTypeDNS01)
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request with challengeType %s and a wildcard hostname to be valid, actual: %v", AcmeChallengeTypeDNS01, err)
+	}
+}
@@ -0,0 +1,99 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// These are the DNSSEC signing algorithms which may be selected for a CDN, by their IANA mnemonics.
+const (
+	DNSSECAlgorithmRSASHA256       = "RSASHA256"
+	DNSSECAlgorithmECDSAP256SHA256 = "ECDSAP256SHA256"
+	DNSSECAlgorithmED25519         = "ED25519"
+)
+
+// DefaultDNSSECAlgorithm is the DNSSEC signing algorithm of a CDN which doesn't specify one.
+const DefaultDNSSECAlgorithm = DNSSECAlgorithmRSASHA256
+
+// DNSSECAlgorithms is every DNSSEC signing algorithm which may be selected for a CDN.
+var DNSSECAlgorithms = []string{DNSSECAlgorithmRSASHA256, DNSSECAlgorithmECDSAP256SHA256, DNSSECAlgorithmED25519}
+
+// IsValidDNSSECAlgorithm returns whether alg is a DNSSEC signing algorithm which may be selected for a CDN.
+func IsValidDNSSECAlgorithm(alg string) bool {
+	for _, a := range DNSSECAlgorithms {
+		if a == alg {
+			return true
+		}
+	}
+	return false
+}
+
+// These are the phases of a DNSSEC key rollover.
+//
+// A new ZSK is published before its effective date, so resolvers have it cached before anything is signed with it,
+// and becomes active on that date. The ZSK it replaces is retiring, and is removed once it expires.
+//
+// A new CDN KSK signs the DNSKEY RRset alongside the KSK it replaces (a double signature), and is pending until its DS
+// record is confirmed published in the parent zone. Until then, the KSK it replaces is kept from expiring; once it's
+// confirmed, the old KSK is retiring, and is removed once it expires.
+const (
+	DNSSECRolloverPhasePublished = "published"
+	DNSSECRolloverPhaseActive    = "active"
+	DNSSECRolloverPhaseDSPending = "ds-pending"
+	DNSSECRolloverPhaseRetiring  = "retiring"
+)
+
+// DNSSECDSRecord is the DS record of one of a CDN's KSKs, which must be published in the parent zone of the CDN's
+// domain.
+type DNSSECDSRecord struct {
+	KeyTag         uint16    `json:"keyTag"`
+	Algorithm      int64     `json:"algorithm"`
+	DigestType     int64     `json:"digestType"`
+	Digest         string    `json:"digest"`
+	Text           string    `json:"text"`
+	RolloverPhase  string    `json:"rolloverPhase"`
+	EffectiveDate  time.Time `json:"effectiveDate"`
+	ExpirationDate time.Time `json:"expirationDate"`
+	// Pending is whether the record must be published in the parent zone for an in-progress KSK rollover to finish.
+	Pending bool `json:"pending"`
+}
+
+// DNSSECDSRecordsResponse is the response to a request for a CDN's DS records.
+type DNSSECDSRecordsResponse struct {
+	Response []DNSSECDSRecord `json:"response"`
+	Alerts
+}
+
+// DNSSECDSPublishedRequest confirms the DS record of a CDN's pending KSK was published in the parent zone.
+type DNSSECDSPublishedRequest struct {
+	KeyTag *uint16 `json:"keyTag"`
+}
+
+// Validate implements the github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r DNSSECDSPublishedRequest) Validate(tx *sql.Tx) error {
+	if r.KeyTag == nil {
+		return errors.New("keyTag is required")
+	}
+	return nil
+}
@@ -96,6 +96,11 @@ type ParameterName string
 // UseRevalPendingParameterName is the name of a parameter which tells whether or not Traffic Ops should use pending revalidation jobs.
 const UseRevalPendingParameterName = ParameterName("use_reval_pending")
 
+// RefetchEnabledParameterName is the name of a parameter which tells whether or not REFETCH and
+// PURGE content invalidation jobs may be created, which requires that caches' regex_revalidate
+// plugin supports MISS rules.
+const RefetchEnabledParameterName = ParameterName("refetch_enabled")
+
 // ConfigFileName represents the name of a Traffic Ops config file.
 type ConfigFileName string
 
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
@@ -40,6 +41,25 @@ const MaxTTL = math.MaxInt64 / 3600000000000
 
 var twoDays = time.Hour * 48
 
+// The types of content invalidation jobs.
+const (
+	// InvalidationTypeRefresh is the type of jobs which make caches revalidate
+	// matching content with the origin, which may find it hasn't changed. This
+	// is the default.
+	InvalidationTypeRefresh = "REFRESH"
+	// InvalidationTypeRefetch is the type of jobs which make caches fetch
+	// matching content anew, regardless of its validators, as though it
+	// weren't cached.
+	InvalidationTypeRefetch = "REFETCH"
+	// InvalidationTypePurge is the type of jobs which make caches fetch the
+	// content at exactly one URL anew, as though it weren't cached. Their
+	// "regex" is that URL's path, rather than a regular expression.
+	InvalidationTypePurge = "PURGE"
+)
+
+// ValidInvalidationTypes are the valid types of content invalidation jobs.
+var ValidInvalidationTypes = []string{InvalidationTypeRefresh, InvalidationTypeRefetch, InvalidationTypePurge}
+
 // ValidJobRegexPrefix matches the only valid prefixes for a relative-path Content Invalidation Job regex
 var ValidJobRegexPrefix = regexp.MustCompile(`^\?/.*$`)
 
@@ -49,8 +69,11 @@ type InvalidationJob struct {
 	CreatedBy       *string `json:"createdBy"`
 	DeliveryService *string `json:"deliveryService"`
 	ID              *uint64 `json:"id"`
-	Keyword         *string `json:"keyword"`
-	Parameters      *string `json:"parameters"`
+	// InvalidationType is the type of the job - REFRESH, REFETCH or PURGE.
+	// It's omitted by API versions before 4.0, which don't have job types.
+	InvalidationType *string `json:"invalidationType,omitempty"`
+	Keyword          *string `json:"keyword"`
+	Parameters       *string `json:"parameters"`
 
 	// StartTime is the time at which the job will come into effect. Must be in the future, but will
 	// fail to Validate if it is further in the future than two days.
@@ -66,8 +89,16 @@ type InvalidationJobInput struct {
 	// (and any fractional part is discarded, i.e. 2.34 -> 2)
 	DeliveryService *interface{} `json:"deliveryService"`
 
+	// InvalidationType is the type of the job - REFRESH, REFETCH or PURGE. If
+	// not given, it's REFRESH. REFETCH and PURGE jobs require caches whose
+	// regex_revalidate plugin supports MISS rules, so they can only be
+	// created if the refetch_enabled Parameter of the GLOBAL Profile is
+	// "true".
+	InvalidationType *string `json:"invalidationType"`
+
 	// Regex is a regular expression which not only must be valid, but should also start with '/'
-	// (or escaped: '\/')
+	// (or escaped: '\/'). For PURGE jobs, it's instead the exact path of the content to purge,
+	// which must start with '/'.
 	Regex *string `json:"regex"`
 
 	// StartTime is the time at which the job will come into effect. Must be in the future.
@@ -205,8 +236,24 @@ func (job *InvalidationJobInput) Validate(tx *sql.Tx) error {
 		}
 	}
 
+	invalidationType := job.Type()
+	if !isValidInvalidationType(invalidationType) {
+		errs = append(errs, "invalidationType: must be one of "+strings.Join(ValidInvalidationTypes, ", "))
+	} else if invalidationType != InvalidationTypeRefresh {
+		if enabled, err := refetchEnabled(tx); err != nil {
+			log.Errorf("checking whether %s jobs are enabled: %v", invalidationType, err)
+			errs = append(errs, "Unknown error occurred")
+		} else if !enabled {
+			errs = append(errs, "invalidationType: "+invalidationType+" jobs are not enabled; the '"+string(RefetchEnabledParameterName)+"' Parameter of the "+GlobalProfileName+" Profile must be 'true'")
+		}
+	}
+
 	if job.Regex != nil && *job.Regex != "" {
-		if _, err := regexp.Compile(*job.Regex); err != nil {
+		if invalidationType == InvalidationTypePurge {
+			if err := validatePurgePath(*job.Regex); err != nil {
+				errs = append(errs, "regex: "+err.Error())
+			}
+		} else if _, err := regexp.Compile(*job.Regex); err != nil {
 			errs = append(errs, "regex: is not a valid Regular Expression: "+err.Error())
 		}
 	}
@@ -237,6 +284,66 @@ func (job *InvalidationJobInput) Validate(tx *sql.Tx) error {
 	return nil
 }
 
+// Type returns the type of the job, which is REFRESH if not given.
+func (job *InvalidationJobInput) Type() string {
+	if job.InvalidationType == nil || *job.InvalidationType == "" {
+		return InvalidationTypeRefresh
+	}
+	return *job.InvalidationType
+}
+
+// validatePurgePath returns an error if the given "regex" of a PURGE job isn't
+// the path of a URL, beginning with '/', without a scheme, host, query, or
+// fragment.
+func validatePurgePath(path string) error {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+		return errors.New("must be a path starting with a single '/' for " + InvalidationTypePurge + " jobs")
+	}
+	if strings.ContainsAny(path, " \t\r\n?#") {
+		return errors.New("must be a path without whitespace, a query, or a fragment for " + InvalidationTypePurge + " jobs")
+	}
+	u, err := url.Parse(path)
+	if err != nil {
+		return errors.New("is not a valid path: " + err.Error())
+	}
+	if u.Scheme != "" || u.Host != "" || u.Opaque != "" {
+		return errors.New("must be a path without a scheme or host for " + InvalidationTypePurge + " jobs")
+	}
+	return nil
+}
+
+func isValidInvalidationType(invalidationType string) bool {
+	for _, valid := range ValidInvalidationTypes {
+		if invalidationType == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// refetchEnabled returns whether REFETCH and PURGE jobs may be created, as
+// set by the refetch_enabled Parameter of the GLOBAL Profile.
+func refetchEnabled(tx *sql.Tx) (bool, error) {
+	value := ""
+	qry := `
+SELECT p.value
+FROM parameter p
+JOIN profile_parameter pp ON pp.parameter = p.id
+JOIN profile pr ON pr.id = pp.profile
+WHERE pr.name = $1
+AND p.name = $2
+AND p.config_file = $3
+`
+	if err := tx.QueryRow(qry, GlobalProfileName, RefetchEnabledParameterName, GlobalConfigFileName).Scan(&value); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+	return err == nil && enabled, nil
+}
+
 func ValidateJobUniqueness(tx *sql.Tx, dsID uint, startTime time.Time, assetURL string, ttlHours uint) []string {
 	var errs []string
 
@@ -362,6 +469,10 @@ func (job *InvalidationJob) Validate() error {
 		errs = append(errs, err.Error())
 	}
 
+	if job.InvalidationType != nil && !isValidInvalidationType(*job.InvalidationType) {
+		errs = append(errs, "invalidationType: must be one of "+strings.Join(ValidInvalidationTypes, ", "))
+	}
+
 	if job.StartTime == nil {
 		return errors.New(strings.Join(append(errs, "startTime: cannot be blank"), ", "))
 	}
@@ -21,6 +21,7 @@ package tc
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/apache/trafficcontrol/lib/go-util"
@@ -48,7 +49,7 @@ func TestInvalidationJobGetTTL(t *testing.T) {
 }
 
 func ExampleInvalidationJobInput_TTLHours_duration() {
-	j := InvalidationJobInput{nil, nil, nil, util.InterfacePtr("121m"), nil, nil}
+	j := InvalidationJobInput{nil, nil, nil, nil, util.InterfacePtr("121m"), nil, nil}
 	ttl, e := j.TTLHours()
 	if e != nil {
 		fmt.Printf("Error: %v\n", e)
@@ -58,7 +59,7 @@ func ExampleInvalidationJobInput_TTLHours_duration() {
 }
 
 func ExampleInvalidationJobInput_TTLHours_number() {
-	j := InvalidationJobInput{nil, nil, nil, util.InterfacePtr(2.1), nil, nil}
+	j := InvalidationJobInput{nil, nil, nil, nil, util.InterfacePtr(2.1), nil, nil}
 	ttl, e := j.TTLHours()
 	if e != nil {
 		fmt.Printf("Error: %v\n", e)
@@ -66,3 +67,52 @@ func ExampleInvalidationJobInput_TTLHours_number() {
 	fmt.Println(ttl)
 	// Output: 2
 }
+
+func TestInvalidationJobInputType(t *testing.T) {
+	job := InvalidationJobInput{}
+	if invalidationType := job.Type(); invalidationType != InvalidationTypeRefresh {
+		t.Errorf("expected a job without a type to be a %s job, actual: %s", InvalidationTypeRefresh, invalidationType)
+	}
+	job.InvalidationType = util.StrPtr(InvalidationTypePurge)
+	if invalidationType := job.Type(); invalidationType != InvalidationTypePurge {
+		t.Errorf("expected a %s job, actual: %s", InvalidationTypePurge, invalidationType)
+	}
+
+	for _, invalidationType := range ValidInvalidationTypes {
+		if !isValidInvalidationType(invalidationType) {
+			t.Errorf("expected %s to be a valid invalidation type", invalidationType)
+		}
+	}
+	if isValidInvalidationType("refresh") {
+		t.Error("expected invalidation types to be case-sensitive")
+	}
+}
+
+func TestInvalidationJobValidateType(t *testing.T) {
+	job := InvalidationJob{InvalidationType: util.StrPtr("BAN")}
+	if err := job.Validate(); err == nil || !strings.Contains(err.Error(), "invalidationType") {
+		t.Errorf("expected an invalidationType error, actual: %v", err)
+	}
+}
+
+func TestValidatePurgePath(t *testing.T) {
+	for _, path := range []string{"/", "/path/to/content.jpg", "/a%20b/c.js"} {
+		if err := validatePurgePath(path); err != nil {
+			t.Errorf("expected '%s' to be a valid PURGE path, actual error: %v", path, err)
+		}
+	}
+	for _, path := range []string{
+		"path/to/content.jpg",
+		`\/path`,
+		"//cdn.example.net/path",
+		"http://cdn.example.net/path",
+		"https:/path",
+		"/path?query=1",
+		"/path#fragment",
+		"/path with spaces",
+	} {
+		if err := validatePurgePath(path); err == nil {
+			t.Errorf("expected '%s' to be an invalid PURGE path, actual: nil error", path)
+		}
+	}
+}
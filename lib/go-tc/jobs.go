@@ -36,6 +36,10 @@ type Job struct {
 	StartTime       string `json:"startTime"`
 	ID              int64  `json:"id"`
 	DeliveryService string `json:"deliveryService"`
+	// InvalidationType is the type of the job - REFRESH, REFETCH or PURGE.
+	// It's empty for jobs from versions of Traffic Ops without job types,
+	// which are all REFRESH jobs.
+	InvalidationType string `json:"invalidationType"`
 }
 
 // JobRequest contains the data to create a job.
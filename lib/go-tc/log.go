@@ -19,6 +19,10 @@ package tc
  * under the License.
  */
 
+import (
+	"encoding/json"
+)
+
 // LogsResponse is a list of Logs as a response.
 type LogsResponse struct {
 	Response []Log `json:"response"`
@@ -34,6 +38,66 @@ type Log struct {
 	User        *string `json:"user"`
 }
 
+// LogsResponseV4 is a list of LogV4s as a response.
+type LogsResponseV4 struct {
+	Response []LogV4 `json:"response"`
+}
+
+// LogV4 is a Log, along with the structured audit information of the change,
+// if it was recorded. The structured fields of changes which only have a
+// message are null. TenantID is the ID of the Tenant of the changed object,
+// which is null if it has none.
+type LogV4 struct {
+	Log
+	Action     *string `json:"action"`
+	ObjectType *string `json:"objectType"`
+	ObjectID   *string `json:"objectId"`
+	TenantID   *int    `json:"tenantId"`
+	RequestID  *uint64 `json:"requestId"`
+	APIVersion *string `json:"apiVersion"`
+}
+
+// LogDetailResponseV4 is the type of a response from Traffic Ops to a request
+// for a single Log.
+type LogDetailResponseV4 struct {
+	Response LogDetailV4 `json:"response"`
+	Alerts
+}
+
+// LogDetailV4 is a LogV4, along with the changed object as it was before and
+// after the change. Before is null if the object was created, and After is
+// null if it was deleted.
+type LogDetailV4 struct {
+	LogV4
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// LogDiffResponseV4 is the type of a response from Traffic Ops to a request
+// for the field-level difference of the change recorded by a Log.
+type LogDiffResponseV4 struct {
+	Response LogDiffV4 `json:"response"`
+	Alerts
+}
+
+// LogDiffV4 is the field-level difference between the object before and after
+// the change recorded by a Log.
+type LogDiffV4 struct {
+	ID         int              `json:"id"`
+	Action     *string          `json:"action"`
+	ObjectType *string          `json:"objectType"`
+	ObjectID   *string          `json:"objectId"`
+	Changes    []LogFieldChange `json:"changes"`
+}
+
+// LogFieldChange is a change to a single top-level field of an object. Old is
+// null if the field was added, and New is null if it was removed.
+type LogFieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
 // NewLogCountResp is the response returned when the total number of new changes
 // made to the Traffic Control system is requested. "New" means since the last
 // time this information was requested.
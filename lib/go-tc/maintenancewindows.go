@@ -0,0 +1,141 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// The states of a MaintenanceWindow.
+const (
+	// MaintenanceWindowStateScheduled is the state of a window which hasn't
+	// started yet.
+	MaintenanceWindowStateScheduled = "scheduled"
+	// MaintenanceWindowStateActive is the state of a window whose status has
+	// been set on its servers.
+	MaintenanceWindowStateActive = "active"
+	// MaintenanceWindowStateCompleted is the state of a window whose servers'
+	// original statuses have been restored.
+	MaintenanceWindowStateCompleted = "completed"
+	// MaintenanceWindowStateSkipped is the state of a window which ended
+	// before Traffic Ops could start it, e.g. because it wasn't running.
+	MaintenanceWindowStateSkipped = "skipped"
+)
+
+// The actions of the change log entries - and webhook events - of maintenance
+// windows starting and ending.
+const (
+	MaintenanceWindowActionStarted = "Started"
+	MaintenanceWindowActionEnded   = "Ended"
+)
+
+// MaintenanceWindow is a period of time during which Traffic Ops sets the
+// status of a set of servers, and after which it restores their original
+// statuses.
+type MaintenanceWindow struct {
+	ID *int `json:"id"`
+	// Reason is why the servers are taken out of service. It's prefixed with
+	// the window's ID and used as the offline reason of its servers.
+	Reason    *string    `json:"reason"`
+	StartTime *time.Time `json:"startTime"`
+	EndTime   *time.Time `json:"endTime"`
+	// Status is the name of the status set on the window's servers while it's
+	// active, e.g. "ADMIN_DOWN".
+	Status *string `json:"status"`
+	// Snapshot is whether the CDNs of the window's servers are Snapshotted
+	// after their statuses are set, and after they're restored.
+	Snapshot *bool `json:"snapshot"`
+	// ServerIDs are the IDs of the servers in the window.
+	ServerIDs []int `json:"serverIds"`
+	// CacheGroupIDs are the IDs of the Cache Groups in the window. Every
+	// server in them when the window starts is in the window.
+	CacheGroupIDs []int `json:"cachegroupIds"`
+	// State is one of MaintenanceWindowStateScheduled,
+	// MaintenanceWindowStateActive, MaintenanceWindowStateCompleted or
+	// MaintenanceWindowStateSkipped. It's ignored in requests.
+	State string `json:"state"`
+	// Username is the name of the user who created the window, on whose
+	// behalf its changes are made. It's ignored in requests.
+	Username string `json:"username"`
+	// AppliedServers are the servers whose statuses were set when the window
+	// started. It's ignored in requests.
+	AppliedServers []MaintenanceWindowServer `json:"appliedServers"`
+	LastUpdated    *TimeNoMod                `json:"lastUpdated"`
+}
+
+// MaintenanceWindowServer is a server whose status was set by a maintenance
+// window, along with the status it's restored to when the window ends.
+type MaintenanceWindowServer struct {
+	ID                    int     `json:"id"`
+	HostName              string  `json:"hostName"`
+	OriginalStatus        string  `json:"originalStatus"`
+	OriginalOfflineReason *string `json:"originalOfflineReason"`
+}
+
+// Validate validates that the MaintenanceWindow is valid for creation or
+// update. Whether its status, servers and Cache Groups exist is checked by
+// Traffic Ops.
+func (mw *MaintenanceWindow) Validate(tx *sql.Tx) error {
+	errs := validation.Errors{
+		"reason":    validation.Validate(mw.Reason, validation.Required),
+		"startTime": validation.Validate(mw.StartTime, validation.Required),
+		"endTime":   validation.Validate(mw.EndTime, validation.Required),
+		"status":    validation.Validate(mw.Status, validation.Required),
+	}
+	if mw.StartTime != nil && mw.EndTime != nil && !mw.EndTime.After(*mw.StartTime) {
+		errs["endTime"] = errors.New("must be after startTime")
+	}
+	if len(mw.ServerIDs) == 0 && len(mw.CacheGroupIDs) == 0 {
+		errs["serverIds"] = errors.New("serverIds or cachegroupIds must not be empty")
+	}
+	return util.JoinErrs(tovalidate.ToErrors(errs))
+}
+
+// MaintenanceWindowsResponse is the type of a response from Traffic Ops to a
+// request for maintenance windows.
+type MaintenanceWindowsResponse struct {
+	Response []MaintenanceWindow `json:"response"`
+	Alerts
+}
+
+// MaintenanceWindowResponse is the type of a response from Traffic Ops to a
+// request to create or update a maintenance window.
+type MaintenanceWindowResponse struct {
+	Response MaintenanceWindow `json:"response"`
+	Alerts
+}
+
+// ServerMaintenanceWindow is a scheduled or active maintenance window which
+// includes a server, as shown in the server's details.
+type ServerMaintenanceWindow struct {
+	ID        int       `json:"id"`
+	Reason    string    `json:"reason"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	Status    string    `json:"status"`
+	State     string    `json:"state"`
+}
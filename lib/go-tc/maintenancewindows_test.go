@@ -0,0 +1,72 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	start := time.Date(2021, 3, 24, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	valid := MaintenanceWindow{
+		Reason:    util.StrPtr("kernel upgrade"),
+		StartTime: &start,
+		EndTime:   &end,
+		Status:    util.StrPtr(CacheStatusAdminDown.String()),
+		ServerIDs: []int{1},
+	}
+	if err := valid.Validate(nil); err != nil {
+		t.Errorf("expected a window with servers to be valid, got error: %v", err)
+	}
+	cacheGroupsOnly := valid
+	cacheGroupsOnly.ServerIDs = nil
+	cacheGroupsOnly.CacheGroupIDs = []int{1}
+	if err := cacheGroupsOnly.Validate(nil); err != nil {
+		t.Errorf("expected a window with only cache groups to be valid, got error: %v", err)
+	}
+
+	noTargets := valid
+	noTargets.ServerIDs = nil
+	if err := noTargets.Validate(nil); err == nil || !strings.Contains(err.Error(), "serverIds") {
+		t.Errorf("expected a window without servers or cache groups to be invalid, got error: %v", err)
+	}
+
+	backwards := valid
+	backwards.EndTime = &start
+	if err := backwards.Validate(nil); err == nil || !strings.Contains(err.Error(), "endTime") {
+		t.Errorf("expected a window which ends when it starts to be invalid, got error: %v", err)
+	}
+
+	missing := MaintenanceWindow{ServerIDs: []int{1}}
+	err := missing.Validate(nil)
+	if err == nil {
+		t.Fatal("expected a window without a reason, times or status to be invalid")
+	}
+	for _, field := range []string{"reason", "startTime", "endTime", "status"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("expected error to mention '%s', got: %v", field, err)
+		}
+	}
+}
@@ -88,6 +88,9 @@ type ServerDetailV30 struct {
 type ServerDetailV40 struct {
 	ServerDetail
 	ServerInterfaces []ServerInterfaceInfoV40 `json:"interfaces"`
+	// MaintenanceWindows are the scheduled and active maintenance windows
+	// which include the server, directly or through its Cache Group.
+	MaintenanceWindows []ServerMaintenanceWindow `json:"maintenanceWindows"`
 }
 
 // ServersV1DetailResponse is the JSON object returned for a single server for v1.
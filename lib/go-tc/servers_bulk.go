@@ -0,0 +1,144 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// The operations of bulk server requests.
+const (
+	ServerBulkOperationCreate  = "create"
+	ServerBulkOperationUpdate  = "update"
+	ServerBulkOperationStatus  = "status"
+	ServerBulkOperationProfile = "profile"
+	ServerBulkOperationAssign  = "assign"
+)
+
+// ServerBulkRequest is the request body of bulk server creations and updates.
+type ServerBulkRequest struct {
+	// AllOrNothing is whether no server is created or updated if any of them
+	// can't be. Otherwise, the servers that can be are, and the others are
+	// reported as failed.
+	AllOrNothing bool        `json:"allOrNothing"`
+	Servers      []ServerV40 `json:"servers"`
+}
+
+// Validate validates that the request has servers.
+func (r ServerBulkRequest) Validate() error {
+	if len(r.Servers) == 0 {
+		return errors.New("servers: required")
+	}
+	return nil
+}
+
+// ServerBulkStatusRequest is the request body of bulk server status changes.
+type ServerBulkStatusRequest struct {
+	AllOrNothing bool  `json:"allOrNothing"`
+	ServerIDs    []int `json:"serverIds"`
+	// ServerPutStatus is the status to which the servers are set, and the
+	// reason for it, which is required for OFFLINE and ADMIN_DOWN.
+	ServerPutStatus
+}
+
+// Validate validates that the request has servers and a status.
+func (r ServerBulkStatusRequest) Validate() error {
+	errs := []error{}
+	if len(r.ServerIDs) == 0 {
+		errs = append(errs, errors.New("serverIds: required"))
+	}
+	if r.Status.Name == nil && r.Status.ID == nil {
+		errs = append(errs, errors.New("status: required"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// ServerBulkProfileRequest is the request body of bulk server Profile
+// changes.
+type ServerBulkProfileRequest struct {
+	AllOrNothing bool  `json:"allOrNothing"`
+	ServerIDs    []int `json:"serverIds"`
+	// Profile is the name or ID of the Profile given to the servers.
+	Profile util.JSONNameOrIDStr `json:"profile"`
+}
+
+// Validate validates that the request has servers and a Profile.
+func (r ServerBulkProfileRequest) Validate() error {
+	errs := []error{}
+	if len(r.ServerIDs) == 0 {
+		errs = append(errs, errors.New("serverIds: required"))
+	}
+	if r.Profile.Name == nil && r.Profile.ID == nil {
+		errs = append(errs, errors.New("profile: required"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// ServerBulkAssignRequest is the request body of bulk assignments of Delivery
+// Services to servers.
+type ServerBulkAssignRequest struct {
+	AllOrNothing       bool  `json:"allOrNothing"`
+	ServerIDs          []int `json:"serverIds"`
+	DeliveryServiceIDs []int `json:"deliveryServiceIds"`
+	// Replace is whether the Delivery Services replace those already
+	// assigned to each server, rather than being added to them.
+	Replace bool `json:"replace"`
+}
+
+// Validate validates that the request has servers.
+func (r ServerBulkAssignRequest) Validate() error {
+	if len(r.ServerIDs) == 0 {
+		return errors.New("serverIds: required")
+	}
+	return nil
+}
+
+// ServerBulkItemResult is the result of a bulk server operation on a single
+// server.
+type ServerBulkItemResult struct {
+	// Index is the index of the server in the request.
+	Index    int     `json:"index"`
+	ID       *int    `json:"id"`
+	HostName *string `json:"hostName"`
+	// Error is why the operation failed on the server, or null if it
+	// succeeded.
+	Error *string `json:"error"`
+}
+
+// ServerBulkResult is the result of a bulk server operation.
+type ServerBulkResult struct {
+	Operation    string `json:"operation"`
+	AllOrNothing bool   `json:"allOrNothing"`
+	// Committed is whether the changes to the servers that succeeded were
+	// kept. It's false only when AllOrNothing is true and any server failed.
+	Committed bool                   `json:"committed"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Results   []ServerBulkItemResult `json:"results"`
+}
+
+// ServerBulkResponse is the type of a response from Traffic Ops to a request
+// to one of its servers/bulk endpoints.
+type ServerBulkResponse struct {
+	Response ServerBulkResult `json:"response"`
+	Alerts
+}
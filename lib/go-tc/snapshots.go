@@ -0,0 +1,115 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SnapshotHistoryEntry is a Snapshot of a CDN's CRConfig and monitoring
+// configuration retained by Traffic Ops, without its content.
+type SnapshotHistoryEntry struct {
+	ID  int64  `json:"id" db:"id"`
+	CDN string `json:"cdn" db:"cdn"`
+	// Author is the username of the user who took the Snapshot, or rolled
+	// back to it.
+	Author *string `json:"author" db:"author"`
+	// ChangeLogID is the ID of the change log entry of the Snapshot or
+	// rollback, if any.
+	ChangeLogID *int64 `json:"changeLogId" db:"log_id"`
+	// RollbackOf is the ID of the Snapshot this Snapshot was rolled back to,
+	// if it was created by a rollback.
+	RollbackOf *int64    `json:"rollbackOf" db:"rollback_of"`
+	CreatedAt  time.Time `json:"createdAt" db:"created_at"`
+	// Current is whether this is the CDN's current Snapshot.
+	Current bool `json:"current"`
+}
+
+// SnapshotHistoryResponse is the type of a response from Traffic Ops to a
+// request for the retained Snapshots of a CDN.
+type SnapshotHistoryResponse struct {
+	Response []SnapshotHistoryEntry `json:"response"`
+	Alerts
+}
+
+// SnapshotHistoryContent is a retained Snapshot of a CDN, with its content.
+type SnapshotHistoryContent struct {
+	SnapshotHistoryEntry
+	CRConfig   json.RawMessage `json:"crconfig"`
+	Monitoring json.RawMessage `json:"monitoring"`
+}
+
+// SnapshotHistoryContentResponse is the type of a response from Traffic Ops
+// to a request for a single retained Snapshot of a CDN.
+type SnapshotHistoryContentResponse struct {
+	Response SnapshotHistoryContent `json:"response"`
+	Alerts
+}
+
+// SnapshotDiffOp is the kind of a change between two Snapshots.
+type SnapshotDiffOp string
+
+const (
+	SnapshotDiffOpAdded   = SnapshotDiffOp("added")
+	SnapshotDiffOpRemoved = SnapshotDiffOp("removed")
+	SnapshotDiffOpChanged = SnapshotDiffOp("changed")
+)
+
+// These are the special Snapshot identifiers which may be compared, in
+// addition to the IDs of retained Snapshots.
+const (
+	// SnapshotCurrent identifies a CDN's current Snapshot.
+	SnapshotCurrent = "current"
+	// SnapshotNew identifies the Snapshot which would be created by
+	// snapshotting a CDN now.
+	SnapshotNew = "new"
+)
+
+// SnapshotDiffChange is a single change between two Snapshots.
+type SnapshotDiffChange struct {
+	// Path is the location of the changed value, as the object keys (or
+	// array indices) leading to it. Elements of arrays of objects which are
+	// identified by a name - like the "trafficServers" of a monitoring
+	// configuration - are identified by that name rather than their index.
+	Path []string       `json:"path"`
+	Op   SnapshotDiffOp `json:"op"`
+	// Old is the value in the Snapshot compared from, or null if it was
+	// added.
+	Old interface{} `json:"old"`
+	// New is the value in the Snapshot compared to, or null if it was
+	// removed.
+	New interface{} `json:"new"`
+}
+
+// SnapshotDiff is the structured difference between two Snapshots of a CDN.
+type SnapshotDiff struct {
+	From       string               `json:"from"`
+	To         string               `json:"to"`
+	CRConfig   []SnapshotDiffChange `json:"crconfig"`
+	Monitoring []SnapshotDiffChange `json:"monitoring"`
+}
+
+// SnapshotDiffResponse is the type of a response from Traffic Ops to a
+// request for the difference between two Snapshots of a CDN.
+type SnapshotDiffResponse struct {
+	Response SnapshotDiff `json:"response"`
+	Alerts
+}
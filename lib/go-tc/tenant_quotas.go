@@ -0,0 +1,130 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// The resources limited by Tenant quotas, by the names of the TenantQuota
+// properties which limit them.
+const (
+	TenantQuotaDeliveryServices          = "maxDeliveryServices"
+	TenantQuotaJobsPerDay                = "maxJobsPerDay"
+	TenantQuotaRegexesPerDeliveryService = "maxRegexesPerDeliveryService"
+	TenantQuotaBandwidthMbps             = "maxBandwidthMbps"
+)
+
+// TenantQuotas are the names of all of the resources limited by Tenant
+// quotas.
+var TenantQuotas = []string{
+	TenantQuotaDeliveryServices,
+	TenantQuotaJobsPerDay,
+	TenantQuotaRegexesPerDeliveryService,
+	TenantQuotaBandwidthMbps,
+}
+
+// TenantQuota is the limits on the resources a Tenant may use. Null limits
+// are unlimited.
+//
+// Limits apply to a Tenant and all of its descendants together, so a Tenant
+// can't use more than any of its ancestors allow, except for
+// MaxRegexesPerDeliveryService, which limits each Delivery Service separately.
+type TenantQuota struct {
+	TenantID *int `json:"tenantId" db:"tenant_id"`
+	// MaxDeliveryServices is the greatest number of Delivery Services.
+	MaxDeliveryServices *int64 `json:"maxDeliveryServices" db:"max_delivery_services"`
+	// MaxJobsPerDay is the greatest number of content invalidation jobs
+	// created in any 24 hours.
+	MaxJobsPerDay *int64 `json:"maxJobsPerDay" db:"max_jobs_per_day"`
+	// MaxRegexesPerDeliveryService is the greatest number of regular
+	// expressions of any one Delivery Service.
+	MaxRegexesPerDeliveryService *int64 `json:"maxRegexesPerDeliveryService" db:"max_regexes_per_ds"`
+	// MaxBandwidthMbps is the greatest sum of the globalMaxMbps of Delivery
+	// Services.
+	MaxBandwidthMbps *int64     `json:"maxBandwidthMbps" db:"max_bandwidth_mbps"`
+	LastUpdated      *TimeNoMod `json:"lastUpdated" db:"last_updated"`
+}
+
+// Validate validates that the TenantQuota is valid for an update.
+func (q *TenantQuota) Validate(tx *sql.Tx) error {
+	errs := validation.Errors{
+		TenantQuotaDeliveryServices:          validation.Validate(q.MaxDeliveryServices, validation.Min(int64(0))),
+		TenantQuotaJobsPerDay:                validation.Validate(q.MaxJobsPerDay, validation.Min(int64(0))),
+		TenantQuotaRegexesPerDeliveryService: validation.Validate(q.MaxRegexesPerDeliveryService, validation.Min(int64(0))),
+		TenantQuotaBandwidthMbps:             validation.Validate(q.MaxBandwidthMbps, validation.Min(int64(0))),
+	}
+	return util.JoinErrs(tovalidate.ToErrors(errs))
+}
+
+// Limit returns the TenantQuota's limit of the given resource, which is one
+// of the TenantQuota constants, or nil if it's unlimited.
+func (q TenantQuota) Limit(quota string) *int64 {
+	switch quota {
+	case TenantQuotaDeliveryServices:
+		return q.MaxDeliveryServices
+	case TenantQuotaJobsPerDay:
+		return q.MaxJobsPerDay
+	case TenantQuotaRegexesPerDeliveryService:
+		return q.MaxRegexesPerDeliveryService
+	case TenantQuotaBandwidthMbps:
+		return q.MaxBandwidthMbps
+	}
+	return nil
+}
+
+// TenantQuotaUsage is the usage of one of the resources limited by Tenant
+// quotas by a Tenant and its descendants.
+type TenantQuotaUsage struct {
+	// Quota is the name of the resource, one of the TenantQuota constants.
+	Quota string `json:"quota"`
+	// Usage is how much of the resource the Tenant and its descendants use.
+	// For TenantQuotaRegexesPerDeliveryService, it's the greatest number of
+	// regular expressions of any one of their Delivery Services.
+	Usage int64 `json:"usage"`
+	// Available is how much more of the resource the Tenant may use before
+	// reaching the quota of itself or one of its ancestors, or nil if none of
+	// them limit it.
+	Available *int64 `json:"available"`
+	// LimitedBy is the name of the Tenant whose quota determines Available.
+	LimitedBy *string `json:"limitedBy"`
+}
+
+// TenantQuotaReport is the quota of a Tenant, along with its current usage
+// and the limits its ancestors impose on it.
+type TenantQuotaReport struct {
+	TenantID   int    `json:"tenantId"`
+	TenantName string `json:"tenantName"`
+	// Quota is the quota set on the Tenant itself.
+	Quota TenantQuota        `json:"quota"`
+	Usage []TenantQuotaUsage `json:"usage"`
+}
+
+// TenantQuotaReportResponse is the type of a response from Traffic Ops to a
+// request for, or to update, the quota of a Tenant.
+type TenantQuotaReportResponse struct {
+	Response TenantQuotaReport `json:"response"`
+	Alerts
+}
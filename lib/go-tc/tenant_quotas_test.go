@@ -0,0 +1,49 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestTenantQuotaValidate(t *testing.T) {
+	quota := TenantQuota{MaxDeliveryServices: util.Int64Ptr(0), MaxBandwidthMbps: util.Int64Ptr(1000)}
+	if err := quota.Validate(nil); err != nil {
+		t.Errorf("expected zero and positive limits to be valid, actual: %v", err)
+	}
+	quota.MaxJobsPerDay = util.Int64Ptr(-1)
+	if err := quota.Validate(nil); err == nil {
+		t.Error("expected a negative limit to be invalid, actual: nil")
+	}
+}
+
+func TestTenantQuotaLimit(t *testing.T) {
+	quota := TenantQuota{MaxRegexesPerDeliveryService: util.Int64Ptr(5)}
+	if limit := quota.Limit(TenantQuotaRegexesPerDeliveryService); limit == nil || *limit != 5 {
+		t.Errorf("expected regex limit 5, actual: %v", limit)
+	}
+	for _, q := range []string{TenantQuotaDeliveryServices, TenantQuotaJobsPerDay, TenantQuotaBandwidthMbps, "unknown"} {
+		if limit := quota.Limit(q); limit != nil {
+			t.Errorf("expected %s to be unlimited, actual: %d", q, *limit)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/lib/pq"
+)
+
+// TopologySimulationRequest is the request body of the POST
+// topologies/simulate endpoint. It describes a candidate Delivery Service,
+// which need not exist, and the Server Capabilities it would require.
+type TopologySimulationRequest struct {
+	// DeliveryService is the candidate Delivery Service. Its Topology, its
+	// Origin, and its CDN - by ID or by Name - are required.
+	DeliveryService DeliveryServiceV4 `json:"deliveryService"`
+	// RequiredCapabilities are the names of the Server Capabilities the
+	// candidate Delivery Service would require.
+	RequiredCapabilities []string `json:"requiredCapabilities"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r *TopologySimulationRequest) Validate(tx *sql.Tx) error {
+	ds := r.DeliveryService
+	errs := validation.Errors{
+		"deliveryService.topology":      validation.Validate(ds.Topology, validation.Required),
+		"deliveryService.orgServerFqdn": validation.Validate(ds.OrgServerFQDN, validation.Required),
+	}
+	if ds.CDNID == nil && (ds.CDNName == nil || *ds.CDNName == "") {
+		errs["deliveryService.cdnId"] = errors.New("cdnId or cdnName is required")
+	}
+	if err := util.JoinErrs(tovalidate.ToErrors(errs)); err != nil {
+		return err
+	}
+	if len(r.RequiredCapabilities) == 0 {
+		return nil
+	}
+
+	found := []string{}
+	if err := tx.QueryRow(`SELECT ARRAY(SELECT name FROM server_capability WHERE name = ANY($1))`, pq.Array(r.RequiredCapabilities)).Scan(pq.Array(&found)); err != nil {
+		return fmt.Errorf("querying server capabilities: %v", err)
+	}
+	missing := []string{}
+	for _, capability := range r.RequiredCapabilities {
+		if !util.ContainsStr(found, capability) {
+			missing = append(missing, capability)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New("requiredCapabilities: no such server capabilities: " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// TopologySimulation is the result of simulating the placement of a candidate
+// Delivery Service in a Topology.
+type TopologySimulation struct {
+	// Topology is the name of the Topology in which the candidate was placed.
+	Topology string `json:"topology"`
+	// CDNName is the name of the CDN to which the candidate would belong.
+	CDNName string `json:"cdnName"`
+	// RequiredCapabilities are the Server Capabilities the candidate would
+	// require.
+	RequiredCapabilities []string `json:"requiredCapabilities"`
+	// CacheGroups are the Topology's Cache Groups, in the order of its nodes.
+	CacheGroups []TopologySimulationCacheGroup `json:"cachegroups"`
+	// Warnings describe problems the candidate would have in the Topology.
+	Warnings []string `json:"warnings"`
+}
+
+// TopologySimulationCacheGroup is a Cache Group of a simulated Topology, along
+// with the servers in it that would serve the candidate Delivery Service.
+type TopologySimulationCacheGroup struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Parents are the names of the primary and, if any, secondary parent
+	// Cache Groups of the Cache Group in the Topology.
+	Parents []string `json:"parents"`
+	// Servers are the servers in the Cache Group that would serve the
+	// candidate.
+	Servers []TopologySimulationServer `json:"servers"`
+	// ParentConfigServer is the host name of the server for which
+	// ParentConfigLine was generated, or null if the Cache Group has no
+	// servers that would serve the candidate.
+	ParentConfigServer *string `json:"parentConfigServer"`
+	// ParentConfigLine is the line of the parent.config file of the Cache
+	// Group's servers for the candidate, or null if none could be generated.
+	ParentConfigLine *string `json:"parentConfigLine"`
+}
+
+// TopologySimulationServer is a server that would serve the candidate
+// Delivery Service of a Topology simulation.
+type TopologySimulationServer struct {
+	ID         int    `json:"id"`
+	HostName   string `json:"hostName"`
+	DomainName string `json:"domainName"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	Profile    string `json:"profile"`
+}
+
+// TopologySimulationResponse is the type of a response from Traffic Ops to a
+// POST request made to its topologies/simulate endpoint.
+type TopologySimulationResponse struct {
+	Response TopologySimulation `json:"response"`
+	Alerts
+}
@@ -39,6 +39,17 @@ const (
 	StatNameBandwidth = "bandwidth"
 )
 
+const (
+	// DSThresholdPrefix is the prefix of all Names of Delivery Service Profile
+	// Parameters used to define thresholds which, when not met, make a Delivery
+	// Service unavailable.
+	DSThresholdPrefix = "health.ds.threshold."
+	// DSDegradedThresholdPrefix is the prefix of all Names of Delivery Service
+	// Profile Parameters used to define thresholds which, when not met, make a
+	// Delivery Service degraded, but still available.
+	DSDegradedThresholdPrefix = "health.ds.degraded."
+)
+
 // TMConfigResponse is the response to requests made to the
 // cdns/{{Name}}/configs/monitoring endpoint of the Traffic Ops API.
 type TMConfigResponse struct {
@@ -401,6 +412,65 @@ type TMDeliveryService struct {
 	TotalTPSThreshold  int64  `json:"TotalTpsThreshold"`
 	ServerStatus       string `json:"status"`
 	TotalKbpsThreshold int64  `json:"TotalKbpsThreshold"`
+	// Thresholds are the Delivery Service health thresholds which, when not
+	// met, make the Delivery Service unavailable, keyed by stat name (one of
+	// the DSStat constants).
+	Thresholds DSHealthThresholds `json:"thresholds,omitempty"`
+	// DegradedThresholds are the Delivery Service health thresholds which,
+	// when not met, make the Delivery Service degraded, keyed by stat name
+	// (one of the DSStat constants).
+	DegradedThresholds DSHealthThresholds `json:"degradedThresholds,omitempty"`
+}
+
+// These are the names of the stats which may be used in Delivery Service
+// health thresholds.
+const (
+	// DSStatErrorRate is the ratio of 5xx responses to all responses served
+	// for the Delivery Service, across all of its caches.
+	DSStatErrorRate = "error_rate"
+	// DSStatTotalKbps is the bandwidth served for the Delivery Service,
+	// across all of its caches, in kilobits per second.
+	DSStatTotalKbps = "total_kbps"
+	// DSStatTotalTps is the number of transactions per second served for the
+	// Delivery Service, across all of its caches.
+	DSStatTotalTps = "total_tps"
+	// DSStatCacheGroupKbps is the bandwidth served for the Delivery Service
+	// by the caches in each single Cache Group, in kilobits per second. Cache
+	// Groups which don't meet an unavailable threshold for this stat are
+	// disabled for the Delivery Service.
+	DSStatCacheGroupKbps = "cachegroup_kbps"
+)
+
+// DSHealthThresholds is a set of Delivery Service health thresholds, keyed by
+// stat name. It is encoded in JSON as an object whose values are threshold
+// strings, like {"error_rate": "<0.05"}.
+type DSHealthThresholds map[string]HealthThreshold
+
+// MarshalJSON implements the encoding/json.Marshaler interface.
+func (t DSHealthThresholds) MarshalJSON() ([]byte, error) {
+	raw := make(map[string]string, len(t))
+	for stat, threshold := range t {
+		raw[stat] = threshold.String()
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON implements the encoding/json.Unmarshaler interface.
+func (t *DSHealthThresholds) UnmarshalJSON(bytes []byte) error {
+	raw := map[string]string{}
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+	thresholds := make(DSHealthThresholds, len(raw))
+	for stat, v := range raw {
+		threshold, err := ParseHealthThreshold(v)
+		if err != nil {
+			return fmt.Errorf("Unmarshalling DSHealthThresholds stat '%s' value '%s': %v", stat, v, err)
+		}
+		thresholds[stat] = threshold
+	}
+	*t = thresholds
+	return nil
 }
 
 // TMProfile is primarily a collection of the Parameters with special meaning
@@ -444,11 +514,11 @@ func (t HealthThreshold) String() string {
 	return fmt.Sprintf("%s%f", t.Comparator, t.Val)
 }
 
-// strToThreshold takes a string like ">=42" and returns a HealthThreshold with
+// ParseHealthThreshold takes a string like ">=42" and returns a HealthThreshold with
 // a Val of `42` and a Comparator of `">="`. If no comparator exists,
 // `DefaultHealthThresholdComparator` is used. If the string does not match
 // "(>|<|)(=|)\d+" an error is returned.
-func strToThreshold(s string) (HealthThreshold, error) {
+func ParseHealthThreshold(s string) (HealthThreshold, error) {
 	// The order of these is important - don't re-order without considering the
 	// consequences.
 	comparators := []string{">=", "<=", ">", "<", "="}
@@ -521,7 +591,7 @@ func (params *TMParameters) UnmarshalJSON(bytes []byte) (err error) {
 		if strings.HasPrefix(k, ThresholdPrefix) {
 			stat := k[len(ThresholdPrefix):]
 			vStr := fmt.Sprintf("%v", v) // allows string or numeric JSON types. TODO check if a type switch is faster.
-			if t, err := strToThreshold(vStr); err != nil {
+			if t, err := ParseHealthThreshold(vStr); err != nil {
 				return fmt.Errorf("Unmarshalling TMParameters `%s` parameter value not of the form `(>|)(=|)\\d+`: stat '%s' value '%v': %v", ThresholdPrefix, k, v, err)
 			} else {
 				params.Thresholds[stat] = t
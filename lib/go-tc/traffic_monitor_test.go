@@ -612,3 +612,37 @@ func TestTrafficMonitorTransformToMap(t *testing.T) {
 		t.Errorf("Incorrect number of IP addresses on converted traffic server's interface; expected: 1, got: %d", len(converted.TrafficServer["testHostname"].Interfaces[0].IPAddresses))
 	}
 }
+
+func TestTMDeliveryServiceThresholdsJSON(t *testing.T) {
+	raw := `{"xmlId":"foo","status":"REPORTED","thresholds":{"error_rate":"<0.05"},"degradedThresholds":{"total_kbps":"<=1000"}}`
+	ds := TMDeliveryService{}
+	if err := json.Unmarshal([]byte(raw), &ds); err != nil {
+		t.Fatalf("Unexpected error unmarshalling delivery service with thresholds: %v", err)
+	}
+	if threshold, ok := ds.Thresholds[DSStatErrorRate]; !ok {
+		t.Errorf("Expected threshold '%s' to exist, but it didn't", DSStatErrorRate)
+	} else if threshold.Comparator != "<" || threshold.Val != 0.05 {
+		t.Errorf("Incorrect threshold '%s'; expected: <0.05, got: %s", DSStatErrorRate, threshold)
+	}
+	if threshold, ok := ds.DegradedThresholds[DSStatTotalKbps]; !ok {
+		t.Errorf("Expected degraded threshold '%s' to exist, but it didn't", DSStatTotalKbps)
+	} else if threshold.Comparator != "<=" || threshold.Val != 1000 {
+		t.Errorf("Incorrect degraded threshold '%s'; expected: <=1000, got: %s", DSStatTotalKbps, threshold)
+	}
+
+	bts, err := json.Marshal(ds)
+	if err != nil {
+		t.Fatalf("Unexpected error marshalling delivery service with thresholds: %v", err)
+	}
+	roundTripped := TMDeliveryService{}
+	if err := json.Unmarshal(bts, &roundTripped); err != nil {
+		t.Fatalf("Unexpected error unmarshalling marshalled delivery service: %v", err)
+	}
+	if roundTripped.Thresholds[DSStatErrorRate] != ds.Thresholds[DSStatErrorRate] {
+		t.Errorf("Incorrect threshold after round trip; expected: %s, got: %s", ds.Thresholds[DSStatErrorRate], roundTripped.Thresholds[DSStatErrorRate])
+	}
+
+	if err := json.Unmarshal([]byte(`{"xmlId":"foo","thresholds":{"error_rate":"<abc"}}`), &ds); err == nil {
+		t.Error("Expected an error unmarshalling an invalid threshold, but didn't get one")
+	}
+}
@@ -359,3 +359,42 @@ func (urr *UserRegistrationRequest) Validate(tx *sql.Tx) error {
 
 	return util.JoinErrs(errs)
 }
+
+// UserPermissions is what the authenticated user is permitted to do, as
+// returned by the /user/current/permissions endpoint.
+type UserPermissions struct {
+	Username  string `json:"username"`
+	Role      int    `json:"role"`
+	RoleName  string `json:"roleName"`
+	PrivLevel int    `json:"privLevel"`
+	// CapabilityAuthorization is whether the user is authorized by their
+	// Role's Capabilities. If false, they're authorized by privilege level,
+	// either because Capability authorization is disabled, or because their
+	// Role has no Capabilities.
+	CapabilityAuthorization bool `json:"capabilityAuthorization"`
+	// Capabilities are the user's effective Capabilities. If the user's Role
+	// has no Capabilities, these are the Capabilities equivalent to its
+	// privilege level.
+	Capabilities []string `json:"capabilities"`
+	// Endpoints are the authenticated endpoints of the requested API
+	// version, and whether the user may use each of them.
+	Endpoints []EndpointPermission `json:"endpoints"`
+}
+
+// EndpointPermission is whether a user may use an API endpoint.
+type EndpointPermission struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	// RequiredCapabilities are the Capabilities required to use the
+	// endpoint. If empty, it's authorized by privilege level alone.
+	RequiredCapabilities []string `json:"requiredCapabilities"`
+	RequiredPrivLevel    int      `json:"requiredPrivLevel"`
+	Allowed              bool     `json:"allowed"`
+}
+
+// UserPermissionsResponse is the type of a response from Traffic Ops to a
+// request for the current user's permissions.
+type UserPermissionsResponse struct {
+	Response UserPermissions `json:"response"`
+	Alerts
+}
@@ -0,0 +1,206 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// The statuses of a WebhookDelivery.
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+// The headers Traffic Ops sets on the requests it makes to deliver webhook
+// events.
+const (
+	// WebhookEventHeader is the name of the event, e.g. "ds.Updated".
+	WebhookEventHeader = "X-TO-Event"
+	// WebhookDeliveryHeader is the ID of the delivery. It's the same for
+	// every attempt to deliver the same event to the same webhook, so
+	// receivers can use it to ignore duplicates.
+	WebhookDeliveryHeader = "X-TO-Delivery"
+	// WebhookSignatureHeader is "sha256=" followed by the hex-encoded
+	// HMAC-SHA256 of the request body, keyed by the webhook's secret. It's
+	// omitted if the webhook has no secret.
+	WebhookSignatureHeader = "X-TO-Signature"
+)
+
+// WebhookObjectTypeSnapshot is the object type of webhook events of CDN
+// Snapshots, which are identified by the ID of their Snapshot history entry.
+const WebhookObjectTypeSnapshot = "snapshot"
+
+// The actions of webhook events which aren't creations, updates or deletions.
+const (
+	WebhookActionQueued     = "Queued"
+	WebhookActionDequeued   = "Dequeued"
+	WebhookActionRolledBack = "RolledBack"
+	WebhookActionBulk       = "Bulk"
+)
+
+// Webhook is a subscription to events - changes made through Traffic Ops -
+// which are delivered as HTTP POST requests to its URL.
+//
+// An event is delivered to a webhook if it matches all of the webhook's
+// filters. Empty or null filters match every event.
+type Webhook struct {
+	ID   *int    `json:"id" db:"id"`
+	Name *string `json:"name" db:"name"`
+	URL  *string `json:"url" db:"url"`
+	// Secret is the key of the HMAC signature of each delivery. It's never
+	// returned by Traffic Ops, and is left unchanged by updates which omit
+	// it.
+	Secret *string `json:"secret,omitempty" db:"secret"`
+	// HasSecret is whether the webhook has a Secret. It's ignored in
+	// requests.
+	HasSecret bool `json:"hasSecret"`
+	// ObjectTypes are the object types of the events delivered to the
+	// webhook, e.g. "ds", "server" or "snapshot".
+	ObjectTypes []string `json:"objectTypes" db:"object_types"`
+	// Actions are the actions of the events delivered to the webhook, e.g.
+	// "Created", "Updated" or "Deleted".
+	Actions []string `json:"actions" db:"actions"`
+	// CDNName is the name of the CDN of the events delivered to the webhook.
+	// Events of objects which don't belong to a CDN never match it.
+	CDNName *string `json:"cdnName" db:"cdn"`
+	// TenantID is the ID of the Tenant of the objects whose changes are
+	// delivered to the webhook. Changes to objects with no Tenant are
+	// delivered regardless.
+	TenantID    *int       `json:"tenantId" db:"tenant_id"`
+	Active      *bool      `json:"active" db:"active"`
+	LastUpdated *TimeNoMod `json:"lastUpdated" db:"last_updated"`
+}
+
+// Validate validates that the Webhook is valid for creation or update.
+func (w *Webhook) Validate(tx *sql.Tx) error {
+	errs := validation.Errors{
+		"name": validation.Validate(w.Name, validation.Required),
+		"url":  validation.Validate(w.URL, validation.Required, validation.By(validateWebhookURL)),
+	}
+	return util.JoinErrs(tovalidate.ToErrors(errs))
+}
+
+// validateWebhookURL validates that the given *string is an absolute HTTP or
+// HTTPS URL.
+func validateWebhookURL(value interface{}) error {
+	str, ok := value.(*string)
+	if !ok || str == nil {
+		return nil
+	}
+	u, err := url.Parse(*str)
+	if err != nil {
+		return errors.New("must be a valid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("must be an http or https URL")
+	}
+	if u.Host == "" {
+		return errors.New("must have a host")
+	}
+	return nil
+}
+
+// WebhooksResponse is the type of a response from Traffic Ops to a request
+// for webhooks.
+type WebhooksResponse struct {
+	Response []Webhook `json:"response"`
+	Alerts
+}
+
+// WebhookResponse is the type of a response from Traffic Ops to a request to
+// create or update a webhook.
+type WebhookResponse struct {
+	Response Webhook `json:"response"`
+	Alerts
+}
+
+// WebhookEvent is the body of the requests Traffic Ops makes to deliver an
+// event to a webhook.
+type WebhookEvent struct {
+	// Event is the name of the event, which is its ObjectType and Action
+	// separated by a ".", e.g. "ds.Updated".
+	Event      string `json:"event"`
+	Action     string `json:"action"`
+	ObjectType string `json:"objectType"`
+	ObjectID   string `json:"objectId"`
+	// CDNName is the name of the CDN of the changed object, if it has one.
+	CDNName *string `json:"cdnName"`
+	// TenantID is the ID of the Tenant of the changed object, if it has one.
+	TenantID   *int      `json:"tenantId"`
+	User       string    `json:"user"`
+	RequestID  uint64    `json:"requestId"`
+	APIVersion string    `json:"apiVersion"`
+	Time       time.Time `json:"time"`
+	// Before is the changed object as it was before the change, if it was
+	// recorded.
+	Before json.RawMessage `json:"before,omitempty"`
+	// After is the changed object as it was after the change, if it was
+	// recorded.
+	After json.RawMessage `json:"after,omitempty"`
+}
+
+// WebhookDelivery is an event queued for delivery to a webhook, along with
+// the log of the attempts to deliver it.
+type WebhookDelivery struct {
+	ID        int64  `json:"id"`
+	WebhookID int    `json:"webhookId"`
+	Event     string `json:"event"`
+	// Status is one of WebhookDeliveryStatusPending,
+	// WebhookDeliveryStatusDelivered, or WebhookDeliveryStatusFailed, which
+	// means every attempt to deliver the event failed.
+	Status   string `json:"status"`
+	Attempts int    `json:"attempts"`
+	// NextAttempt is when the next attempt to deliver the event will be made,
+	// if it's pending.
+	NextAttempt *time.Time               `json:"nextAttempt"`
+	CreatedAt   time.Time                `json:"createdAt"`
+	Payload     json.RawMessage          `json:"payload"`
+	AttemptLog  []WebhookDeliveryAttempt `json:"attemptLog"`
+}
+
+// WebhookDeliveryAttempt is an attempt to deliver an event to a webhook.
+type WebhookDeliveryAttempt struct {
+	Attempt     int       `json:"attempt"`
+	AttemptedAt time.Time `json:"attemptedAt"`
+	// StatusCode is the HTTP status code of the webhook's response, if it
+	// responded.
+	StatusCode *int `json:"statusCode"`
+	// Error is why the attempt failed, if it did.
+	Error      *string `json:"error"`
+	DurationMS int64   `json:"durationMs"`
+}
+
+// WebhookDeliveriesResponse is the type of a response from Traffic Ops to a
+// request for the deliveries of a webhook.
+type WebhookDeliveriesResponse struct {
+	Response []WebhookDelivery `json:"response"`
+	Alerts
+}
@@ -0,0 +1,254 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// cdn_config exports the configuration of a CDN from Traffic Ops as a YAML or JSON document, and plans or applies such documents.
+//
+// Usage:
+//
+//	cdn_config [flags] export CDN_NAME
+//	cdn_config [flags] plan FILE
+//	cdn_config [flags] apply FILE
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	client "github.com/apache/trafficcontrol/traffic_ops/v4-client"
+
+	"gopkg.in/yaml.v2"
+)
+
+const Version = "0.1"
+const UserAgent = "cdn_config/" + Version
+
+func main() {
+	toURL := flag.String("url", os.Getenv("TO_URL"), "The URL of Traffic Ops. Defaults to the TO_URL environment variable")
+	toUser := flag.String("user", os.Getenv("TO_USER"), "The Traffic Ops user. Defaults to the TO_USER environment variable")
+	toPassword := flag.String("password", os.Getenv("TO_PASSWORD"), "The password of the Traffic Ops user. Defaults to the TO_PASSWORD environment variable")
+	toToken := flag.String("token", os.Getenv("TO_TOKEN"), "A Traffic Ops API token to authenticate with instead of a user and password. Defaults to the TO_TOKEN environment variable")
+	insecure := flag.Bool("insecure", false, "Don't verify the certificate of Traffic Ops")
+	timeout := flag.Duration("timeout", 5*time.Minute, "The timeout of requests to Traffic Ops")
+	format := flag.String("format", "yaml", "The format of exported documents, 'yaml' or 'json'. Documents to plan or apply are read as JSON if their name ends in '.json', otherwise as YAML")
+	output := flag.String("o", "", "The file to export to. Defaults to standard output")
+	yes := flag.Bool("yes", false, "Apply without asking for confirmation")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: cdn_config [flags] export CDN_NAME | plan FILE | apply FILE")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *toURL == "" {
+		fmt.Fprintln(os.Stderr, "ERROR: the Traffic Ops URL is required")
+		os.Exit(2)
+	}
+
+	var (
+		session *client.Session
+		err     error
+	)
+	if *toToken != "" {
+		session, _, err = client.LoginWithToken(*toURL, *toToken, *insecure, UserAgent, false, *timeout)
+	} else {
+		session, _, err = client.LoginWithAgent(*toURL, *toUser, *toPassword, *insecure, UserAgent, false, *timeout)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: logging in to Traffic Ops: "+err.Error())
+		os.Exit(1)
+	}
+
+	cmd, arg := flag.Arg(0), flag.Arg(1)
+	switch cmd {
+	case "export":
+		err = export(session, arg, *format, *output)
+	case "plan":
+		err = planOrApply(session, arg, false, true)
+	case "apply":
+		err = planOrApply(session, arg, true, *yes)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: "+err.Error())
+		os.Exit(1)
+	}
+}
+
+func export(session *client.Session, cdnName string, format string, output string) error {
+	cfg, _, err := session.GetCDNConfiguration(cdnName, nil)
+	if err != nil {
+		return fmt.Errorf("exporting CDN '%s': %v", cdnName, err)
+	}
+	var bts []byte
+	switch format {
+	case "json":
+		if bts, err = json.MarshalIndent(cfg, "", "\t"); err == nil {
+			bts = append(bts, '\n')
+		}
+	case "yaml":
+		bts, err = marshalYAML(cfg)
+	default:
+		return fmt.Errorf("unknown format '%s'", format)
+	}
+	if err != nil {
+		return errors.New("marshalling configuration: " + err.Error())
+	}
+	if output == "" {
+		_, err = os.Stdout.Write(bts)
+		return err
+	}
+	return ioutil.WriteFile(output, bts, 0644)
+}
+
+// planOrApply prints the changes that applying the document in the given file would make, then, if apply is true, applies it, asking for confirmation first unless confirmed is true.
+func planOrApply(session *client.Session, path string, apply bool, confirmed bool) error {
+	cfg, err := readDocument(path)
+	if err != nil {
+		return fmt.Errorf("reading '%s': %v", path, err)
+	}
+	resp, _, err := session.PlanCDNConfiguration(cfg, nil)
+	if err != nil {
+		return fmt.Errorf("planning CDN '%s': %v", cfg.CDN.Name, err)
+	}
+	printPlan(resp.Response)
+	if !apply || len(resp.Response.Changes) == 0 {
+		return nil
+	}
+	if !confirmed {
+		fmt.Printf("Apply these changes to CDN '%s'? Only 'yes' will be accepted: ", cfg.CDN.Name)
+		answer := ""
+		fmt.Scanln(&answer)
+		if strings.TrimSpace(answer) != "yes" {
+			return errors.New("not applied")
+		}
+	}
+	// The plan is made again when applying, so changes made since it was printed are applied too.
+	resp, _, err = session.ApplyCDNConfiguration(cfg, nil)
+	if err != nil {
+		return fmt.Errorf("applying CDN '%s': %v", cfg.CDN.Name, err)
+	}
+	fmt.Printf("Applied %d changes to CDN '%s'\n", len(resp.Response.Changes), cfg.CDN.Name)
+	return nil
+}
+
+func printPlan(p tc.CDNConfigurationPlan) {
+	if len(p.Changes) == 0 {
+		fmt.Println("No changes")
+		return
+	}
+	symbols := map[string]string{
+		tc.CDNConfigurationCreate: "+",
+		tc.CDNConfigurationUpdate: "~",
+		tc.CDNConfigurationDelete: "-",
+	}
+	for _, change := range p.Changes {
+		line := fmt.Sprintf("%s %s %s '%s'", symbols[change.Action], change.Action, change.Type, change.Name)
+		if len(change.Fields) > 0 {
+			line += " (" + strings.Join(change.Fields, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+	fmt.Printf("%d changes\n", len(p.Changes))
+}
+
+// readDocument reads a configuration document, as JSON if the file name ends in '.json', and otherwise as YAML.
+func readDocument(path string) (tc.CDNConfiguration, error) {
+	cfg := tc.CDNConfiguration{}
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if !strings.HasSuffix(path, ".json") {
+		if bts, err = yamlToJSON(bts); err != nil {
+			return cfg, err
+		}
+	}
+	if err := json.Unmarshal(bts, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// yamlToJSON converts a YAML document to JSON, so that it can be decoded with the JSON field names of the tc structures.
+func yamlToJSON(bts []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(bts, &doc); err != nil {
+		return nil, err
+	}
+	doc, err := jsonCompatible(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// jsonCompatible replaces the maps with arbitrary keys that YAML decodes into with maps that can be marshalled as JSON.
+func jsonCompatible(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			keyStr, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v is not a string", key)
+			}
+			compatible, err := jsonCompatible(val)
+			if err != nil {
+				return nil, err
+			}
+			obj[keyStr] = compatible
+		}
+		return obj, nil
+	case []interface{}:
+		for i, val := range v {
+			compatible, err := jsonCompatible(val)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = compatible
+		}
+		return v, nil
+	}
+	return v, nil
+}
+
+// marshalYAML marshals a configuration document as YAML, keeping its keys in the same order as in JSON.
+func marshalYAML(cfg tc.CDNConfiguration) ([]byte, error) {
+	bts, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	doc := yaml.MapSlice{}
+	if err := yaml.Unmarshal(bts, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
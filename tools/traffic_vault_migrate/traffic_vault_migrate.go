@@ -0,0 +1,255 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// traffic_vault_migrate copies all keys from the Riak Traffic Vault backend to the PostgreSQL Traffic Vault backend.
+//
+// It reads the same configuration files as Traffic Ops: cdn.conf (whose traffic_vault_config must contain the PostgreSQL backend configuration), database.conf, and riak.conf.
+// The Traffic Ops database is used to find the CDNs and Delivery Services whose keys are copied, and the Riak servers to copy them from.
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+	tvpostgres "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/postgres"
+
+	_ "github.com/lib/pq"
+)
+
+const Version = "0.1"
+
+func main() {
+	cdnConfPath := flag.String("cfg", "/opt/traffic_ops/app/conf/cdn.conf", "The Traffic Ops cdn.conf file, whose traffic_vault_config configures the PostgreSQL Traffic Vault")
+	dbConfPath := flag.String("dbcfg", "/opt/traffic_ops/app/conf/production/database.conf", "The Traffic Ops database.conf file")
+	riakConfPath := flag.String("riakcfg", "/opt/traffic_ops/app/conf/production/riak.conf", "The Traffic Ops riak.conf file")
+	dryRun := flag.Bool("dry", false, "Read all keys from Riak, and report what would be copied, without writing to PostgreSQL")
+	flag.Parse()
+
+	if err := migrate(*cdnConfPath, *dbConfPath, *riakConfPath, *dryRun); err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: "+err.Error())
+		os.Exit(1)
+	}
+}
+
+// counts is the number of keys of each type found in, and copied from, Riak.
+type counts struct {
+	SSLKeys        int
+	DNSSECKeys     int
+	URLSigKeys     int
+	URISigningKeys int
+	SSLKeysCSRs    int
+	Errors         int
+}
+
+func migrate(cdnConfPath string, dbConfPath string, riakConfPath string, dryRun bool) error {
+	cfg, errs, blockStart := config.LoadConfig(cdnConfPath, dbConfPath, riakConfPath, Version)
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "WARNING: loading config: "+err.Error())
+	}
+	if blockStart {
+		return fmt.Errorf("loading config failed")
+	}
+	if !cfg.RiakEnabled {
+		return fmt.Errorf("Riak is not configured in '%s'", riakConfPath)
+	}
+
+	src := riaksvc.NewTrafficVault(cfg.RiakAuthOptions, cfg.RiakPort)
+	var dst trafficvault.TrafficVault
+	if !dryRun {
+		pg, err := tvpostgres.New(cfg.TrafficVaultConfig)
+		if err != nil {
+			return fmt.Errorf("creating PostgreSQL Traffic Vault: %v", err)
+		}
+		defer pg.Close()
+		if _, err := pg.Ping(nil); err != nil {
+			return err
+		}
+		dst = pg
+	}
+
+	sslStr := "require"
+	if !cfg.DB.SSL {
+		sslStr = "disable"
+	}
+	db, err := sql.Open("postgres", fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=%s&fallback_application_name=traffic_vault_migrate", cfg.DB.User, cfg.DB.Password, cfg.DB.Hostname, cfg.DB.DBName, sslStr))
+	if err != nil {
+		return fmt.Errorf("opening Traffic Ops database: %v", err)
+	}
+	defer db.Close()
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("beginning Traffic Ops database transaction: %v", err)
+	}
+	defer tx.Rollback() // read-only
+
+	c := counts{}
+	if err := migrateCDNs(tx, src, dst, &c); err != nil {
+		return err
+	}
+	if err := migrateDeliveryServices(tx, src, dst, &c); err != nil {
+		return err
+	}
+
+	action := "Copied"
+	if dryRun {
+		action = "Would copy"
+	}
+	fmt.Printf("%s %d SSL keys, %d pending SSL keys CSRs, %d CDN DNSSEC keys, %d URL Sig keys, and %d URI Signing keys\n", action, c.SSLKeys, c.SSLKeysCSRs, c.DNSSECKeys, c.URLSigKeys, c.URISigningKeys)
+	if c.Errors > 0 {
+		return fmt.Errorf("%d keys failed to copy, see above for details", c.Errors)
+	}
+	return nil
+}
+
+// reportErr prints the given error and counts it, so that one missing or malformed key doesn't stop the migration of all others.
+func reportErr(c *counts, format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "ERROR: "+format+"\n", args...)
+	c.Errors++
+}
+
+// migrateCDNs copies the DNSSEC keys of all CDNs. If dst is nil, nothing is written.
+func migrateCDNs(tx *sql.Tx, src trafficvault.TrafficVault, dst trafficvault.TrafficVault, c *counts) error {
+	rows, err := tx.Query(`SELECT name FROM cdn ORDER BY name`)
+	if err != nil {
+		return fmt.Errorf("querying CDNs: %v", err)
+	}
+	cdns := []string{}
+	for rows.Next() {
+		cdn := ""
+		if err := rows.Scan(&cdn); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning CDNs: %v", err)
+		}
+		cdns = append(cdns, cdn)
+	}
+	rows.Close()
+
+	for _, cdn := range cdns {
+		keys, ok, err := src.GetDNSSECKeys(cdn, tx)
+		if err != nil {
+			reportErr(c, "getting DNSSEC keys for CDN '%s': %v", cdn, err)
+			continue
+		} else if !ok {
+			continue
+		}
+		c.DNSSECKeys++
+		if dst == nil {
+			fmt.Printf("DNSSEC keys for CDN '%s'\n", cdn)
+			continue
+		}
+		if err := dst.PutDNSSECKeys(cdn, keys, nil); err != nil {
+			reportErr(c, "putting DNSSEC keys for CDN '%s': %v", cdn, err)
+		}
+	}
+	return nil
+}
+
+type deliveryService struct {
+	XMLID         string
+	SSLKeyVersion int
+}
+
+// migrateDeliveryServices copies the SSL, URL Sig, and URI Signing keys of all Delivery Services. If dst is nil, nothing is written.
+//
+// Every SSL key version up to the Delivery Service's current version is copied, oldest first, followed by the latest keys, so that the latest keys in PostgreSQL are the same as in Riak.
+func migrateDeliveryServices(tx *sql.Tx, src trafficvault.TrafficVault, dst trafficvault.TrafficVault, c *counts) error {
+	rows, err := tx.Query(`SELECT xml_id, COALESCE(ssl_key_version, 0) FROM deliveryservice ORDER BY xml_id`)
+	if err != nil {
+		return fmt.Errorf("querying Delivery Services: %v", err)
+	}
+	dses := []deliveryService{}
+	for rows.Next() {
+		ds := deliveryService{}
+		if err := rows.Scan(&ds.XMLID, &ds.SSLKeyVersion); err != nil {
+			rows.Close()
+			return fmt.Errorf("scanning Delivery Services: %v", err)
+		}
+		dses = append(dses, ds)
+	}
+	rows.Close()
+
+	for _, ds := range dses {
+		versions := []string{}
+		for v := 1; v <= ds.SSLKeyVersion; v++ {
+			versions = append(versions, strconv.Itoa(v))
+		}
+		versions = append(versions, riaksvc.DSSSLKeyVersionLatest)
+		for _, version := range versions {
+			keys, ok, err := src.GetDeliveryServiceSSLKeys(ds.XMLID, version, tx)
+			if err != nil {
+				reportErr(c, "getting SSL keys for Delivery Service '%s' version '%s': %v", ds.XMLID, version, err)
+				continue
+			} else if !ok {
+				continue
+			}
+			c.SSLKeys++
+			if dst == nil {
+				fmt.Printf("SSL keys for Delivery Service '%s' version '%s'\n", ds.XMLID, version)
+				continue
+			}
+			if err := dst.PutDeliveryServiceSSLKeys(keys.DeliveryServiceSSLKeys, nil); err != nil {
+				reportErr(c, "putting SSL keys for Delivery Service '%s' version '%s': %v", ds.XMLID, version, err)
+			}
+		}
+
+		csr, ok, err := src.GetDeliveryServiceSSLKeysCSR(ds.XMLID, tx)
+		if err != nil {
+			reportErr(c, "getting SSL keys CSR for Delivery Service '%s': %v", ds.XMLID, err)
+		} else if ok {
+			c.SSLKeysCSRs++
+			if dst == nil {
+				fmt.Printf("SSL keys CSR for Delivery Service '%s'\n", ds.XMLID)
+			} else if err := dst.PutDeliveryServiceSSLKeysCSR(csr, nil); err != nil {
+				reportErr(c, "putting SSL keys CSR for Delivery Service '%s': %v", ds.XMLID, err)
+			}
+		}
+
+		urlSigKeys, ok, err := src.GetURLSigKeys(tc.DeliveryServiceName(ds.XMLID), tx)
+		if err != nil {
+			reportErr(c, "getting URL Sig keys for Delivery Service '%s': %v", ds.XMLID, err)
+		} else if ok {
+			c.URLSigKeys++
+			if dst == nil {
+				fmt.Printf("URL Sig keys for Delivery Service '%s'\n", ds.XMLID)
+			} else if err := dst.PutURLSigKeys(tc.DeliveryServiceName(ds.XMLID), urlSigKeys, nil); err != nil {
+				reportErr(c, "putting URL Sig keys for Delivery Service '%s': %v", ds.XMLID, err)
+			}
+		}
+
+		uriSigningKeys, ok, err := src.GetURISigningKeys(ds.XMLID, tx)
+		if err != nil {
+			reportErr(c, "getting URI Signing keys for Delivery Service '%s': %v", ds.XMLID, err)
+		} else if ok {
+			c.URISigningKeys++
+			if dst == nil {
+				fmt.Printf("URI Signing keys for Delivery Service '%s'\n", ds.XMLID)
+			} else if err := dst.PutURISigningKeys(ds.XMLID, uriSigningKeys, nil); err != nil {
+				reportErr(c, "putting URI Signing keys for Delivery Service '%s': %v", ds.XMLID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -23,6 +23,7 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -49,6 +50,10 @@ const (
 	TMConfigBackupFile = "/opt/traffic_monitor/tmconfig.backup"
 	//HTTPPollingFormat is the default accept encoding for stats from caches
 	HTTPPollingFormat = "text/json"
+	//StandaloneCRConfigFileName is the name of the CRConfig file in the standalone config directory
+	StandaloneCRConfigFileName = "CRConfig.json"
+	//StandaloneTMConfigFileName is the name of the monitoring config file in the standalone config directory
+	StandaloneTMConfigFileName = "monitoring.json"
 )
 
 // PollingProtocol is a string value indicating whether to use IPv4, IPv6, or both.
@@ -128,6 +133,16 @@ type Config struct {
 	CachePollingProtocol         PollingProtocol `json:"cache_polling_protocol"`
 	PeerPollingProtocol          PollingProtocol `json:"peer_polling_protocol"`
 	HTTPPollingFormat            string          `json:"http_polling_format"`
+	ShardCachePolling            bool            `json:"shard_cache_polling"`
+	ShardReplicas                int             `json:"shard_replicas"`
+	StandaloneConfigDir          string          `json:"standalone_config_dir"`
+	StandaloneCRConfigFile       string          `json:"standalone_crconfig_file"`
+	StandaloneTMConfigFile       string          `json:"standalone_tmconfig_file"`
+}
+
+// Standalone returns whether Traffic Monitor should run without Traffic Ops, loading its CRConfig and monitoring config from the standalone files.
+func (c Config) Standalone() bool {
+	return c.StandaloneCRConfigFile != "" && c.StandaloneTMConfigFile != ""
 }
 
 func (c Config) ErrorLog() log.LogLocation   { return log.LogLocation(c.LogLocationError) }
@@ -169,6 +184,8 @@ var DefaultConfig = Config{
 	CachePollingProtocol:         Both,
 	PeerPollingProtocol:          Both,
 	HTTPPollingFormat:            HTTPPollingFormat,
+	ShardCachePolling:            false,
+	ShardReplicas:                2,
 }
 
 // MarshalJSON marshals custom millisecond durations. Aliasing inspired by http://choly.ca/post/go-json-marshalling/
@@ -289,6 +306,17 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	if aux.HTTPPollingFormat != nil {
 		c.HTTPPollingFormat = *aux.HTTPPollingFormat
 	}
+	if c.StandaloneConfigDir != "" {
+		if c.StandaloneCRConfigFile == "" {
+			c.StandaloneCRConfigFile = filepath.Join(c.StandaloneConfigDir, StandaloneCRConfigFileName)
+		}
+		if c.StandaloneTMConfigFile == "" {
+			c.StandaloneTMConfigFile = filepath.Join(c.StandaloneConfigDir, StandaloneTMConfigFileName)
+		}
+	}
+	if (c.StandaloneCRConfigFile == "") != (c.StandaloneTMConfigFile == "") {
+		return errors.New("standalone_crconfig_file and standalone_tmconfig_file must both be set, or neither")
+	}
 	return nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -63,6 +64,7 @@ func MakeDispatchMap(
 	lastStats threadsafe.LastStats,
 	unpolledCaches threadsafe.UnpolledCaches,
 	monitorConfig threadsafe.TrafficMonitorConfigMap,
+	cacheShards peer.CacheShardsThreadsafe,
 ) map[string]http.HandlerFunc {
 
 	// wrap composes all universal wrapper functions. Right now, it's only the UnpolledCheck, but there may be others later. For example, security headers.
@@ -132,6 +134,12 @@ func MakeDispatchMap(
 		"/api/crconfig-history": wrap(WrapErr(errorCount, func() ([]byte, error) {
 			return srvAPICRConfigHist(toSession)
 		}, rfc.ApplicationJSON)),
+		"/api/cache-shards": wrap(WrapErr(errorCount, func() ([]byte, error) {
+			return srvAPICacheShards(cacheShards)
+		}, rfc.ApplicationJSON)),
+		"/api/health-dry-run": wrap(WrapPostBody(errorCount, func(body []byte) ([]byte, int, error) {
+			return srvAPIHealthDryRun(body, statInfoHistory, statResultHistory, monitorConfig)
+		}, rfc.ApplicationJSON)),
 	}
 	return addTrailingSlashEndpoints(dispatchMap)
 }
@@ -256,6 +264,62 @@ func WrapParams(f SrvFunc, contentType string) http.HandlerFunc {
 	}
 }
 
+// MaxRequestBodyBytes is the largest request body accepted by endpoints wrapped with WrapPostBody. It's large enough for the monitoring config of a large CDN.
+const MaxRequestBodyBytes = 64 * 1024 * 1024
+
+// BodySrvFunc is a function which takes a request body, and returns the response body, response code, and any error. If the error is non-nil and the code is a client error (4xx), the error is returned to the client; otherwise, the error is logged, and only the status text is returned, for security reasons.
+type BodySrvFunc func(body []byte) ([]byte, int, error)
+
+// WrapPostBody takes a BodySrvFunc and wraps it as an http.HandlerFunc which only accepts POST requests, passing the request body to the BodySrvFunc.
+func WrapPostBody(errorCount threadsafe.Uint, f BodySrvFunc, contentType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.EscapedPath()
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeStatusText(w, http.StatusMethodNotAllowed, path)
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxRequestBodyBytes))
+		if err != nil {
+			w.Header().Set("Content-Type", rfc.ContentTypeTextPlain)
+			w.WriteHeader(http.StatusBadRequest)
+			log.Write(w, []byte("reading request body: "+err.Error()), path)
+			return
+		}
+
+		bytes, code, err := f(body)
+		if err != nil {
+			if code >= http.StatusBadRequest && code < http.StatusInternalServerError {
+				w.Header().Set("Content-Type", rfc.ContentTypeTextPlain)
+				w.WriteHeader(code)
+				log.Write(w, []byte(err.Error()), path)
+				return
+			}
+			_, code = WrapErrStatusCode(errorCount, path, nil, code, err)
+			writeStatusText(w, code, path)
+			return
+		}
+
+		bytes, err = gzipIfAccepts(r, w, bytes)
+		if err != nil {
+			log.Errorf("gzipping '%v': %v\n", path, err)
+			writeStatusText(w, http.StatusInternalServerError, path)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(code)
+		log.Write(w, bytes, path)
+	}
+}
+
+// writeStatusText writes the given code, with its status text as the body.
+func writeStatusText(w http.ResponseWriter, code int, path string) {
+	w.WriteHeader(code)
+	log.Write(w, []byte(http.StatusText(code)), path)
+}
+
 func WrapAgeErr(errorCount threadsafe.Uint, f func() ([]byte, time.Time, error), contentType string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		bytes, contentTime, err := f()
@@ -0,0 +1,119 @@
+package datareq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/health"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// HealthDryRunRequest is a candidate monitoring configuration to evaluate
+// against the current stats of the monitored cache servers, without applying
+// it.
+type HealthDryRunRequest struct {
+	// MonitoringConfig is a complete candidate monitoring configuration, in
+	// the format served by the Traffic Ops monitoring configuration endpoint.
+	// If nil, the current monitoring configuration is used.
+	MonitoringConfig *tc.TrafficMonitorConfig `json:"monitoringConfig"`
+	// ProfileThresholds are health thresholds to add to, or replace in, the
+	// Profiles of the monitoring configuration, keyed by Profile name and then
+	// by stat name, with values of the same form as the health.threshold
+	// Parameters, e.g. {"EDGE": {"loadavg": "<25"}}.
+	ProfileThresholds map[string]map[string]string `json:"profileThresholds"`
+}
+
+func srvAPIHealthDryRun(
+	body []byte,
+	statInfoHistory threadsafe.ResultInfoHistory,
+	statResultHistory threadsafe.ResultStatHistory,
+	monitorConfig threadsafe.TrafficMonitorConfigMap,
+) ([]byte, int, error) {
+	json := jsoniter.ConfigFastest
+	req := HealthDryRunRequest{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, http.StatusBadRequest, errors.New("malformed request: " + err.Error())
+	}
+	if req.MonitoringConfig == nil && len(req.ProfileThresholds) == 0 {
+		return nil, http.StatusBadRequest, errors.New("request must contain a monitoringConfig, profileThresholds, or both")
+	}
+
+	current := monitorConfig.Get()
+	candidate := current
+	if req.MonitoringConfig != nil {
+		mc, err := tc.TrafficMonitorTransformToMap(req.MonitoringConfig)
+		if err != nil {
+			return nil, http.StatusBadRequest, errors.New("invalid monitoringConfig: " + err.Error())
+		}
+		candidate = *mc
+	}
+
+	profiles, err := applyProfileThresholds(candidate.Profile, req.ProfileThresholds)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	candidate.Profile = profiles
+
+	bytes, err := json.Marshal(health.EvalDryRun(statInfoHistory.Get(), statResultHistory, &current, &candidate))
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("marshalling health dry run: %v", err)
+	}
+	return bytes, http.StatusOK, nil
+}
+
+// applyProfileThresholds returns a copy of the given Profiles, with the given
+// thresholds added or replaced. The given Profiles are not modified.
+func applyProfileThresholds(profiles map[string]tc.TMProfile, thresholds map[string]map[string]string) (map[string]tc.TMProfile, error) {
+	if len(thresholds) == 0 {
+		return profiles, nil
+	}
+	newProfiles := make(map[string]tc.TMProfile, len(profiles))
+	for name, profile := range profiles {
+		newProfiles[name] = profile
+	}
+	for profileName, profileThresholds := range thresholds {
+		profile, ok := newProfiles[profileName]
+		if !ok {
+			return nil, fmt.Errorf("profileThresholds: no profile '%s' in monitoring config", profileName)
+		}
+		newThresholds := make(map[string]tc.HealthThreshold, len(profile.Parameters.Thresholds)+len(profileThresholds))
+		for stat, threshold := range profile.Parameters.Thresholds {
+			newThresholds[stat] = threshold
+		}
+		for stat, val := range profileThresholds {
+			threshold, err := tc.ParseHealthThreshold(val)
+			if err != nil {
+				return nil, fmt.Errorf("profileThresholds: profile '%s' stat '%s': %v", profileName, stat, err)
+			}
+			newThresholds[strings.TrimPrefix(stat, tc.ThresholdPrefix)] = threshold
+		}
+		profile.Parameters.Thresholds = newThresholds
+		profile.Parameters.MinFreeKbps = int64(newThresholds["availableBandwidthInKbps"].Val)
+		newProfiles[profileName] = profile
+	}
+	return newProfiles, nil
+}
@@ -0,0 +1,81 @@
+package datareq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestApplyProfileThresholds(t *testing.T) {
+	profiles := map[string]tc.TMProfile{
+		"EDGE": {
+			Name: "EDGE",
+			Parameters: tc.TMParameters{
+				Thresholds: map[string]tc.HealthThreshold{
+					"loadavg":                  {Val: 25, Comparator: "<"},
+					"availableBandwidthInKbps": {Val: 1000, Comparator: ">"},
+				},
+				MinFreeKbps: 1000,
+			},
+		},
+		"MID": {Name: "MID"},
+	}
+
+	newProfiles, err := applyProfileThresholds(profiles, map[string]map[string]string{
+		"EDGE": {
+			"loadavg":                        "<10",
+			tc.ThresholdPrefix + "queryTime": "<500",
+			tc.ThresholdPrefix + "availableBandwidthInKbps": ">2000",
+		},
+	})
+	if err != nil {
+		t.Fatalf("applying profile thresholds: unexpected error: %v", err)
+	}
+
+	edge := newProfiles["EDGE"]
+	if threshold := edge.Parameters.Thresholds["loadavg"]; threshold.Val != 10 || threshold.Comparator != "<" {
+		t.Errorf("expected EDGE loadavg threshold <10, actual: %v", threshold)
+	}
+	if threshold, ok := edge.Parameters.Thresholds["queryTime"]; !ok || threshold.Val != 500 {
+		t.Errorf("expected EDGE queryTime threshold <500, actual: %v (exists: %t)", threshold, ok)
+	}
+	if edge.Parameters.MinFreeKbps != 2000 {
+		t.Errorf("expected EDGE MinFreeKbps 2000, actual: %d", edge.Parameters.MinFreeKbps)
+	}
+	if _, ok := newProfiles["MID"]; !ok {
+		t.Error("expected MID profile to be copied, actual: missing")
+	}
+
+	if threshold := profiles["EDGE"].Parameters.Thresholds["loadavg"]; threshold.Val != 25 {
+		t.Errorf("expected original EDGE loadavg threshold to be unmodified, actual: %v", threshold)
+	}
+	if _, ok := profiles["EDGE"].Parameters.Thresholds["queryTime"]; ok {
+		t.Error("expected original EDGE profile to have no queryTime threshold, actual: exists")
+	}
+
+	if _, err := applyProfileThresholds(profiles, map[string]map[string]string{"NOPE": {"loadavg": "<10"}}); err == nil {
+		t.Error("applying thresholds to a nonexistent profile: expected error, actual: nil")
+	}
+	if _, err := applyProfileThresholds(profiles, map[string]map[string]string{"EDGE": {"loadavg": "<ten"}}); err == nil {
+		t.Error("applying an invalid threshold: expected error, actual: nil")
+	}
+}
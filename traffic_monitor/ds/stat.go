@@ -312,15 +312,38 @@ func addDSPerSecStats(lastStats *dsdata.LastStats, dsStats *dsdata.Stats, dsName
 	}
 	addLastStatsToStatCacheStats(&stat.TotalStats, &lastStat.Total)
 
-	dsErr := getDSErr(dsName, stat.TotalStats, mc)
+	dsEval := health.EvalDeliveryService(stat, mc.DeliveryService[dsName.String()])
+	dsEval = health.HoldDSEvaluation(&lastStat.HealthHold, dsEval, time.Now(), health.GetDSHealthHold(mc))
+	var dsErr error
+	if dsEval.State == tc.DSHealthStateUnavailable {
+		dsErr = errors.New(dsEval.Why)
+	}
 	if dsErr != nil {
 		stat.CommonStats.IsAvailable.Value = false
 		stat.CommonStats.IsHealthy.Value = false
 		stat.CommonStats.ErrorStr.Value = dsErr.Error()
 	}
+
+	dsHealthState := dsEval.State
+	if !stat.CommonStats.IsAvailable.Value {
+		dsHealthState = tc.DSHealthStateUnavailable
+	} else if dsHealthState == tc.DSHealthStateDegraded {
+		stat.CommonStats.IsHealthy.Value = false
+		stat.CommonStats.ErrorStr.Value = dsEval.Why
+	}
+	stat.CommonStats.HealthState.Value = string(dsHealthState)
+	for _, cacheGroup := range dsEval.OverCapacityCacheGroups {
+		cacheGroupStat := stat.CacheGroups[cacheGroup]
+		cacheGroupStat.IsAvailable.Value = false
+		cacheGroupStat.ErrorString.Value = "over capacity"
+	}
+
 	//it's ok to ignore the 'ok' return here.  If the DS doesn't exist, an empty struct will be returned and we can use it.
 	dsState, _ := states.GetDeliveryService(dsName)
 	dsState.IsAvailable = stat.CommonStats.IsAvailable.Value
+	dsState.State = dsHealthState
+	dsState.OverCapacityLocations = dsEval.OverCapacityCacheGroups
+	dsState.DisabledLocations = health.MergeDisabledLocations(dsState.DisabledLocations, dsEval.OverCapacityCacheGroups)
 	states.SetDeliveryService(dsName, dsState) // TODO sync.Map? Determine if slow.
 
 	getEvent := func(desc string) health.Event {
@@ -336,10 +359,15 @@ func addDSPerSecStats(lastStats *dsdata.LastStats, dsStats *dsdata.Stats, dsName
 	}
 	if stat.CommonStats.IsAvailable.Value == false && lastStat.Available == true && dsErr != nil {
 		events.Add(getEvent(dsErr.Error())) // TODO change events.Add to not allocate new memory, after the limit is reached.
-	} else if stat.CommonStats.IsAvailable.Value == true && lastStat.Available == false {
-		events.Add(getEvent("REPORTED - available"))
+	} else if stat.CommonStats.IsAvailable.Value == true && (lastStat.Available == false || dsHealthState != lastStat.State) {
+		if dsHealthState == tc.DSHealthStateDegraded {
+			events.Add(getEvent("REPORTED - degraded - " + dsEval.Why))
+		} else {
+			events.Add(getEvent("REPORTED - available"))
+		}
 	}
 
+	lastStat.State = dsHealthState
 	lastStat.Available = stat.CommonStats.IsAvailable.Value
 }
 
@@ -464,16 +492,6 @@ func CreateStats(precomputed map[tc.CacheName]cache.PrecomputedData, toData toda
 	return dsStats, nil
 }
 
-func getDSErr(dsName tc.DeliveryServiceName, dsStats dsdata.StatCacheStats, monitorConfig tc.TrafficMonitorConfigMap) error {
-	if tpsThreshold := monitorConfig.DeliveryService[dsName.String()].TotalTPSThreshold; tpsThreshold > 0 && dsStats.TpsTotal.Value > float64(tpsThreshold) {
-		return fmt.Errorf("total.tps_total too high (%.2f > %v)", dsStats.TpsTotal.Value, tpsThreshold)
-	}
-	if kbpsThreshold := monitorConfig.DeliveryService[dsName.String()].TotalKbpsThreshold; kbpsThreshold > 0 && dsStats.Kbps.Value > float64(kbpsThreshold) {
-		return fmt.Errorf("total.kbps too high (%.2f > %v)", dsStats.Kbps.Value, kbpsThreshold)
-	}
-	return nil
-}
-
 func SumDSAstats(ds *dsdata.StatCacheStats, cacheStat *cache.DSStat) {
 	ds.OutBytes.Value += int64(cacheStat.OutBytes)
 	ds.InBytes.Value += float64(cacheStat.InBytes)
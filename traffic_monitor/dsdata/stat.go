@@ -121,6 +121,7 @@ type StatCommon struct {
 	IsAvailable         StatBool              `json:"is_available"`
 	CachesAvailableNum  StatInt               `json:"caches_available"`
 	CachesDisabled      []string              `json:"disabled_locations"`
+	HealthState         StatString            `json:"health_state"`
 }
 
 // Copy returns a deep copy of this StatCommon object.
@@ -373,6 +374,30 @@ type LastDSStat struct {
 	Type        map[tc.CacheType]*LastStatsData
 	Total       LastStatsData
 	Available   bool
+	State       tc.DSHealthState
+	HealthHold  DSHealthHold
+}
+
+// DSHealthHold is the delivery service health threshold evaluation in effect, and when each of its restrictions started recovering, so recoveries can be held until they last.
+type DSHealthHold struct {
+	State        tc.DSHealthState
+	Why          string
+	OverCapacity []tc.CacheGroupName
+	// StateRecoveringSince is when evaluations started being in a healthier state than State, or zero if they aren't.
+	StateRecoveringSince time.Time
+	// CacheGroupsRecoveringSince is when each cachegroup in OverCapacity started being within capacity. Cachegroups still over capacity aren't in it.
+	CacheGroupsRecoveringSince map[tc.CacheGroupName]time.Time
+}
+
+// Copy returns a deep copy of this DSHealthHold object.
+func (a DSHealthHold) Copy() DSHealthHold {
+	b := a
+	b.OverCapacity = append([]tc.CacheGroupName(nil), a.OverCapacity...)
+	b.CacheGroupsRecoveringSince = make(map[tc.CacheGroupName]time.Time, len(a.CacheGroupsRecoveringSince))
+	for k, v := range a.CacheGroupsRecoveringSince {
+		b.CacheGroupsRecoveringSince[k] = v
+	}
+	return b
 }
 
 // Copy performs a deep copy of this LastDSStat object.
@@ -383,6 +408,8 @@ func (a LastDSStat) Copy() *LastDSStat {
 		Caches:      map[tc.CacheName]*LastStatsData{},
 		Total:       a.Total,
 		Available:   a.Available,
+		State:       a.State,
+		HealthHold:  a.HealthHold.Copy(),
 	}
 	for k, v := range a.CacheGroups {
 		b.CacheGroups[k] = v
@@ -441,6 +468,7 @@ func addCommonData(s *StatsOld, c *StatCommon, deliveryService tc.DeliveryServic
 	add("isAvailable", fmt.Sprintf("%t", c.IsAvailable.Value))
 	add("caches-available", fmt.Sprintf("%d", c.CachesAvailableNum.Value))
 	add("disabledLocations", c.CachesDisabled)
+	add("health-state", c.HealthState.Value)
 	return s
 }
 
@@ -203,6 +203,37 @@ func EvalAggregate(result cache.ResultInfo, resultStats *threadsafe.ResultStatVa
 	return avail, eventDescVal, eventMsg
 }
 
+// EvalCache evaluates whether the given result makes its cache server
+// available, according to both its monitored network interfaces and its
+// aggregate stats, returning the availability, a string describing why, and
+// which stat exceeded a threshold, if any. Unlike CalcAvailability, this
+// doesn't consider the availability of the cache server over the other IP
+// protocol, and it doesn't modify any state, so it may be used to evaluate a
+// result against monitoring configurations other than the current one. The
+// resultStats may be nil, in which case only computed stats are checked
+// against thresholds.
+func EvalCache(result cache.ResultInfo, resultStats *threadsafe.ResultStatValHistory, mc *tc.TrafficMonitorConfigMap) (bool, string, string) {
+	available := true
+	reasons := []string{}
+	for _, inf := range mc.TrafficServer[result.ID].Interfaces {
+		if !inf.Monitor {
+			continue
+		}
+		infAvailable, why := EvalInterface(result.InterfaceVitals, inf)
+		available = available && infAvailable
+		if why != "" {
+			reasons = append(reasons, inf.Name+": "+why)
+		}
+	}
+
+	aggIsAvailable, aggWhyAvailable, aggUnavailableStat := EvalAggregate(result, resultStats, mc)
+	available = available && aggIsAvailable
+	if aggWhyAvailable != "" {
+		reasons = append([]string{aggWhyAvailable}, reasons...)
+	}
+	return available, strings.Join(reasons, "; "), aggUnavailableStat
+}
+
 // getProcessAvailableTuple gets a function to process an availability tuple
 // based on the protocol used.
 func getProcessAvailableTuple(protocol config.PollingProtocol) func(cache.AvailableTuple, tc.TrafficServer) bool {
@@ -276,49 +307,23 @@ func CalcAvailability(
 			}
 		}
 
-		reasons := []string{}
-		resultInfo := cache.ToInfo(result)
-		for _, inf := range serverInfo.Interfaces {
-			if !inf.Monitor {
-				continue
-			}
-
-			available, why := EvalInterface(resultInfo.InterfaceVitals, inf)
-			if result.UsingIPv4 {
-				availStatus.Available.IPv4 = availStatus.Available.IPv4 && available
-			} else {
-				availStatus.Available.IPv6 = availStatus.Available.IPv6 && available
-			}
-
-			if why != "" {
-				reasons = append(reasons, inf.Name+": "+why)
-			}
-		}
-
-		var aggIsAvailable bool
-		var aggWhyAvailable string
-		var aggUnavailableStat string
-
+		var resultStats *threadsafe.ResultStatValHistory
 		if statResultsVal != nil {
-			aggIsAvailable, aggWhyAvailable, aggUnavailableStat = EvalAggregate(cache.ToInfo(result), &statResultsVal.Stats, &mc)
-		} else {
-			aggIsAvailable, aggWhyAvailable, aggUnavailableStat = EvalAggregate(cache.ToInfo(result), nil, &mc)
+			resultStats = &statResultsVal.Stats
 		}
+		isAvailable, whyAvailable, unavailableStat := EvalCache(cache.ToInfo(result), resultStats, &mc)
 
 		if result.UsingIPv4 {
-			availStatus.Available.IPv4 = availStatus.Available.IPv4 && aggIsAvailable
+			availStatus.Available.IPv4 = availStatus.Available.IPv4 && isAvailable
 		} else {
-			availStatus.Available.IPv6 = availStatus.Available.IPv6 && aggIsAvailable
+			availStatus.Available.IPv6 = availStatus.Available.IPv6 && isAvailable
 		}
 
 		availStatus.ProcessedAvailable = processAvailableTuple(availStatus.Available, serverInfo)
 
-		if aggWhyAvailable != "" {
-			reasons = append([]string{aggWhyAvailable}, reasons...)
-		}
-		availStatus.Why = strings.Join(reasons, "; ")
-		if aggUnavailableStat != "" {
-			availStatus.UnavailableStat = aggUnavailableStat
+		availStatus.Why = whyAvailable
+		if unavailableStat != "" {
+			availStatus.UnavailableStat = unavailableStat
 		}
 
 		localStates.SetCache(tc.CacheName(result.ID), tc.IsAvailable{
@@ -410,6 +415,7 @@ func calculateDeliveryServiceState(deliveryServiceServers map[tc.DeliveryService
 			continue
 		}
 		deliveryServiceState.DisabledLocations = getDisabledLocations(deliveryServiceName, toData.DeliveryServiceServers[deliveryServiceName], cacheStates, toData.ServerCachegroups)
+		deliveryServiceState.DisabledLocations = MergeDisabledLocations(deliveryServiceState.DisabledLocations, deliveryServiceState.OverCapacityLocations)
 		states.SetDeliveryService(deliveryServiceName, deliveryServiceState)
 	}
 }
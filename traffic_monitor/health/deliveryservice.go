@@ -0,0 +1,247 @@
+package health
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+)
+
+// DSEvaluation is the result of evaluating a delivery service's stats against its health thresholds.
+type DSEvaluation struct {
+	// State is the graded health of the delivery service.
+	State tc.DSHealthState
+	// Why describes the threshold which made the delivery service degraded or unavailable. It is empty if the delivery service is available.
+	Why string
+	// OverCapacityCacheGroups are the cachegroups in which the delivery service doesn't meet its cachegroup_kbps threshold, sorted by name. These should be disabled for the delivery service.
+	OverCapacityCacheGroups []tc.CacheGroupName
+}
+
+// EvalDeliveryService evaluates the given delivery service stat against the delivery service's health thresholds, including its Global Max Tps and Global Max Mbps limits. It's the delivery service counterpart of EvalAggregate, and is called by ds.CreateStats every time delivery service stats are computed.
+//
+// It can't be part of EvalAggregate itself: EvalAggregate evaluates a single cache's poll result as it arrives, while delivery service stats only exist once ds.CreateStats has summed the results of every cache serving the delivery service, which happens in the stat pipeline, after and independently of cache health polling.
+//
+// The Global Max limits are checked first, then unavailable thresholds, then cachegroup capacity, then degraded thresholds. A delivery service with cachegroups over capacity is degraded, not unavailable, because it can still be served from its other cachegroups.
+// This doesn't consider cache availability; callers are expected to combine that with the result.
+func EvalDeliveryService(stat *dsdata.Stat, ds tc.TMDeliveryService) DSEvaluation {
+	eval := DSEvaluation{State: tc.DSHealthStateAvailable}
+
+	if msg, ok := evalDSGlobalMax(stat.TotalStats, ds); !ok {
+		return DSEvaluation{State: tc.DSHealthStateUnavailable, Why: msg}
+	}
+
+	for _, statName := range sortedThresholdStats(ds.Thresholds) {
+		if statName == tc.DSStatCacheGroupKbps {
+			continue
+		}
+		if msg, ok := evalDSTotalThreshold(statName, ds.Thresholds[statName], stat.TotalStats); !ok {
+			return DSEvaluation{State: tc.DSHealthStateUnavailable, Why: msg}
+		}
+	}
+
+	cacheGroups := make([]tc.CacheGroupName, 0, len(stat.CacheGroups))
+	for cacheGroup := range stat.CacheGroups {
+		cacheGroups = append(cacheGroups, cacheGroup)
+	}
+	sort.Slice(cacheGroups, func(i, j int) bool { return cacheGroups[i] < cacheGroups[j] })
+
+	if threshold, ok := ds.Thresholds[tc.DSStatCacheGroupKbps]; ok {
+		for _, cacheGroup := range cacheGroups {
+			kbps := stat.CacheGroups[cacheGroup].Kbps.Value
+			if inThreshold(threshold, kbps) {
+				continue
+			}
+			if eval.Why == "" {
+				eval.State = tc.DSHealthStateDegraded
+				eval.Why = fmt.Sprintf("location %s over capacity: %s", cacheGroup, exceedsThresholdMsg(tc.DSStatCacheGroupKbps, threshold, kbps))
+			}
+			eval.OverCapacityCacheGroups = append(eval.OverCapacityCacheGroups, cacheGroup)
+		}
+	}
+	if eval.State != tc.DSHealthStateAvailable {
+		return eval
+	}
+
+	for _, statName := range sortedThresholdStats(ds.DegradedThresholds) {
+		threshold := ds.DegradedThresholds[statName]
+		if statName != tc.DSStatCacheGroupKbps {
+			if msg, ok := evalDSTotalThreshold(statName, threshold, stat.TotalStats); !ok {
+				eval.State = tc.DSHealthStateDegraded
+				eval.Why = msg
+				return eval
+			}
+			continue
+		}
+		for _, cacheGroup := range cacheGroups {
+			if kbps := stat.CacheGroups[cacheGroup].Kbps.Value; !inThreshold(threshold, kbps) {
+				eval.State = tc.DSHealthStateDegraded
+				eval.Why = fmt.Sprintf("location %s: %s", cacheGroup, exceedsThresholdMsg(statName, threshold, kbps))
+				return eval
+			}
+		}
+	}
+	return eval
+}
+
+// DSHealthHoldParam is the name of the Traffic Monitor config parameter which sets how long, in milliseconds, a delivery service must continuously evaluate healthier than its current health before it recovers.
+const DSHealthHoldParam = "health.ds.hold"
+
+// GetDSHealthHold returns the delivery service health recovery hold time from the given monitor config. It returns 0, to recover immediately, if the parameter is missing or isn't a non-negative number.
+func GetDSHealthHold(mc tc.TrafficMonitorConfigMap) time.Duration {
+	holdMS, ok := mc.Config[DSHealthHoldParam].(float64)
+	if !ok || holdMS < 0 {
+		return 0
+	}
+	return time.Duration(holdMS * float64(time.Millisecond))
+}
+
+// HoldDSEvaluation applies hysteresis to delivery service health evaluations, so a delivery service hovering around a threshold doesn't flap between states. It returns the evaluation to put in effect, and updates hold with it.
+//
+// An evaluation which is less healthy than the one in effect takes effect immediately. A healthier state, or a cachegroup no longer over capacity, only takes effect once it has lasted for the hold time; until then, the delivery service keeps its previous state, and the cachegroup stays over capacity.
+func HoldDSEvaluation(hold *dsdata.DSHealthHold, eval DSEvaluation, now time.Time, holdTime time.Duration) DSEvaluation {
+	if holdTime <= 0 || hold.State == "" {
+		*hold = dsdata.DSHealthHold{State: eval.State, Why: eval.Why, OverCapacity: eval.OverCapacityCacheGroups}
+		return eval
+	}
+
+	held := DSEvaluation{State: eval.State, Why: eval.Why}
+	if eval.State.Rank() >= hold.State.Rank() {
+		hold.StateRecoveringSince = time.Time{}
+	} else if hold.StateRecoveringSince.IsZero() {
+		hold.StateRecoveringSince = now
+	}
+	if !hold.StateRecoveringSince.IsZero() {
+		if now.Sub(hold.StateRecoveringSince) < holdTime {
+			held.State = hold.State
+			held.Why = hold.Why
+		} else {
+			hold.StateRecoveringSince = time.Time{}
+		}
+	}
+
+	overCapacity := make(map[tc.CacheGroupName]struct{}, len(eval.OverCapacityCacheGroups))
+	for _, cacheGroup := range eval.OverCapacityCacheGroups {
+		overCapacity[cacheGroup] = struct{}{}
+	}
+	recoveringSince := make(map[tc.CacheGroupName]time.Time, len(hold.OverCapacity))
+	held.OverCapacityCacheGroups = append([]tc.CacheGroupName(nil), eval.OverCapacityCacheGroups...)
+	for _, cacheGroup := range hold.OverCapacity {
+		if _, ok := overCapacity[cacheGroup]; ok {
+			continue
+		}
+		since, ok := hold.CacheGroupsRecoveringSince[cacheGroup]
+		if !ok {
+			since = now
+		}
+		if now.Sub(since) < holdTime {
+			recoveringSince[cacheGroup] = since
+			held.OverCapacityCacheGroups = append(held.OverCapacityCacheGroups, cacheGroup)
+		}
+	}
+	sort.Slice(held.OverCapacityCacheGroups, func(i, j int) bool {
+		return held.OverCapacityCacheGroups[i] < held.OverCapacityCacheGroups[j]
+	})
+
+	hold.State = held.State
+	hold.Why = held.Why
+	hold.OverCapacity = held.OverCapacityCacheGroups
+	hold.CacheGroupsRecoveringSince = recoveringSince
+
+	// A delivery service with cachegroups over capacity is never available, even if its state recovered first. This isn't held, so it doesn't delay the state recovering once the cachegroups do.
+	if held.State == tc.DSHealthStateAvailable && len(held.OverCapacityCacheGroups) > 0 {
+		held.State = tc.DSHealthStateDegraded
+		held.Why = fmt.Sprintf("location %s recovering from over capacity", held.OverCapacityCacheGroups[0])
+	}
+	return held
+}
+
+// evalDSGlobalMax returns whether the given delivery service total stats are within the delivery service's Global Max Tps and Global Max Mbps limits, and a message describing the failure if not. A limit of 0 or less is no limit.
+func evalDSGlobalMax(total dsdata.StatCacheStats, ds tc.TMDeliveryService) (string, bool) {
+	if ds.TotalTPSThreshold > 0 && total.TpsTotal.Value > float64(ds.TotalTPSThreshold) {
+		return fmt.Sprintf("total.tps_total too high (%.2f > %v)", total.TpsTotal.Value, ds.TotalTPSThreshold), false
+	}
+	if ds.TotalKbpsThreshold > 0 && total.Kbps.Value > float64(ds.TotalKbpsThreshold) {
+		return fmt.Sprintf("total.kbps too high (%.2f > %v)", total.Kbps.Value, ds.TotalKbpsThreshold), false
+	}
+	return "", true
+}
+
+// evalDSTotalThreshold returns whether the given delivery service total stats meet the threshold for the given stat, and a message describing the failure if not.
+// Unknown stats are logged and treated as meeting their threshold, so a misconfigured parameter never marks a delivery service down.
+func evalDSTotalThreshold(statName string, threshold tc.HealthThreshold, total dsdata.StatCacheStats) (string, bool) {
+	val, ok := dsTotalStat(statName, total)
+	if !ok {
+		log.Warnf("health.EvalDeliveryService unknown delivery service threshold stat '%s', ignoring", statName)
+		return "", true
+	}
+	if inThreshold(threshold, val) {
+		return "", true
+	}
+	return exceedsThresholdMsg(statName, threshold, val), false
+}
+
+// dsTotalStat returns the value of the given delivery service threshold stat from the delivery service total stats, and whether the stat is known.
+func dsTotalStat(statName string, total dsdata.StatCacheStats) (float64, bool) {
+	switch statName {
+	case tc.DSStatErrorRate:
+		if total.TpsTotal.Value <= 0 {
+			return 0, true
+		}
+		return total.Tps5xx.Value / total.TpsTotal.Value, true
+	case tc.DSStatTotalKbps:
+		return total.Kbps.Value, true
+	case tc.DSStatTotalTps:
+		return total.TpsTotal.Value, true
+	default:
+		return 0, false
+	}
+}
+
+// sortedThresholdStats returns the stat names of the given thresholds, sorted, so evaluation and the resulting messages are deterministic.
+func sortedThresholdStats(thresholds tc.DSHealthThresholds) []string {
+	stats := make([]string, 0, len(thresholds))
+	for stat := range thresholds {
+		stats = append(stats, stat)
+	}
+	sort.Strings(stats)
+	return stats
+}
+
+// MergeDisabledLocations returns disabled with each of the given additional cachegroups appended, if they aren't already in it.
+func MergeDisabledLocations(disabled []tc.CacheGroupName, additional []tc.CacheGroupName) []tc.CacheGroupName {
+	for _, cacheGroup := range additional {
+		found := false
+		for _, existing := range disabled {
+			if existing == cacheGroup {
+				found = true
+				break
+			}
+		}
+		if !found {
+			disabled = append(disabled, cacheGroup)
+		}
+	}
+	return disabled
+}
@@ -0,0 +1,222 @@
+package health
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func testDSStat() *dsdata.Stat {
+	stat := dsdata.NewStat()
+	stat.TotalStats.TpsTotal.Value = 1000
+	stat.TotalStats.Tps5xx.Value = 100
+	stat.TotalStats.Kbps.Value = 3000
+	stat.CacheGroups["cg-a"] = &dsdata.StatCacheStats{Kbps: dsdata.StatFloat{Value: 2000}}
+	stat.CacheGroups["cg-b"] = &dsdata.StatCacheStats{Kbps: dsdata.StatFloat{Value: 1000}}
+	return stat
+}
+
+func TestEvalDeliveryService(t *testing.T) {
+	tests := []struct {
+		name         string
+		ds           tc.TMDeliveryService
+		state        tc.DSHealthState
+		why          string
+		overCapacity []tc.CacheGroupName
+	}{
+		{
+			name:  "no thresholds",
+			ds:    tc.TMDeliveryService{},
+			state: tc.DSHealthStateAvailable,
+		},
+		{
+			name: "thresholds met",
+			ds: tc.TMDeliveryService{
+				Thresholds:         tc.DSHealthThresholds{tc.DSStatErrorRate: {Val: 0.2, Comparator: "<"}, tc.DSStatCacheGroupKbps: {Val: 5000, Comparator: "<"}},
+				DegradedThresholds: tc.DSHealthThresholds{tc.DSStatTotalKbps: {Val: 5000, Comparator: "<"}},
+			},
+			state: tc.DSHealthStateAvailable,
+		},
+		{
+			name:  "error rate unavailable",
+			ds:    tc.TMDeliveryService{Thresholds: tc.DSHealthThresholds{tc.DSStatErrorRate: {Val: 0.05, Comparator: "<"}}},
+			state: tc.DSHealthStateUnavailable,
+			why:   tc.DSStatErrorRate + " too high",
+		},
+		{
+			name:  "error rate degraded",
+			ds:    tc.TMDeliveryService{DegradedThresholds: tc.DSHealthThresholds{tc.DSStatErrorRate: {Val: 0.05, Comparator: "<"}}},
+			state: tc.DSHealthStateDegraded,
+			why:   tc.DSStatErrorRate + " too high",
+		},
+		{
+			name:         "cachegroup over capacity",
+			ds:           tc.TMDeliveryService{Thresholds: tc.DSHealthThresholds{tc.DSStatCacheGroupKbps: {Val: 1500, Comparator: "<"}}},
+			state:        tc.DSHealthStateDegraded,
+			why:          "location cg-a over capacity",
+			overCapacity: []tc.CacheGroupName{"cg-a"},
+		},
+		{
+			name:  "cachegroup degraded",
+			ds:    tc.TMDeliveryService{DegradedThresholds: tc.DSHealthThresholds{tc.DSStatCacheGroupKbps: {Val: 1000, Comparator: "<"}}},
+			state: tc.DSHealthStateDegraded,
+			why:   "location cg-a",
+		},
+		{
+			name: "unavailable takes precedence",
+			ds: tc.TMDeliveryService{
+				Thresholds:         tc.DSHealthThresholds{tc.DSStatTotalTps: {Val: 500, Comparator: "<"}, tc.DSStatCacheGroupKbps: {Val: 1500, Comparator: "<"}},
+				DegradedThresholds: tc.DSHealthThresholds{tc.DSStatErrorRate: {Val: 0.05, Comparator: "<"}},
+			},
+			state: tc.DSHealthStateUnavailable,
+			why:   tc.DSStatTotalTps + " too high",
+		},
+		{
+			name: "global max tps",
+			ds: tc.TMDeliveryService{
+				TotalTPSThreshold: 500,
+				Thresholds:        tc.DSHealthThresholds{tc.DSStatErrorRate: {Val: 0.05, Comparator: "<"}},
+			},
+			state: tc.DSHealthStateUnavailable,
+			why:   "total.tps_total too high",
+		},
+		{
+			name:  "global max kbps",
+			ds:    tc.TMDeliveryService{TotalTPSThreshold: 5000, TotalKbpsThreshold: 2000},
+			state: tc.DSHealthStateUnavailable,
+			why:   "total.kbps too high",
+		},
+		{
+			name:  "global max met",
+			ds:    tc.TMDeliveryService{TotalTPSThreshold: 5000, TotalKbpsThreshold: 5000},
+			state: tc.DSHealthStateAvailable,
+		},
+		{
+			name:  "unknown stat ignored",
+			ds:    tc.TMDeliveryService{Thresholds: tc.DSHealthThresholds{"not_a_stat": {Val: 0, Comparator: "<"}}},
+			state: tc.DSHealthStateAvailable,
+		},
+	}
+
+	for _, test := range tests {
+		eval := EvalDeliveryService(testDSStat(), test.ds)
+		if eval.State != test.state {
+			t.Errorf("%s: expected state '%s', actual '%s' (%s)", test.name, test.state, eval.State, eval.Why)
+		}
+		if !strings.HasPrefix(eval.Why, test.why) || (test.why == "" && eval.Why != "") {
+			t.Errorf("%s: expected reason starting with '%s', actual '%s'", test.name, test.why, eval.Why)
+		}
+		if !reflect.DeepEqual(eval.OverCapacityCacheGroups, test.overCapacity) {
+			t.Errorf("%s: expected over capacity cachegroups %v, actual %v", test.name, test.overCapacity, eval.OverCapacityCacheGroups)
+		}
+	}
+}
+
+func TestHoldDSEvaluation(t *testing.T) {
+	start := time.Now()
+	holdTime := time.Minute
+	available := DSEvaluation{State: tc.DSHealthStateAvailable}
+	overA := DSEvaluation{State: tc.DSHealthStateDegraded, Why: "location cg-a over capacity", OverCapacityCacheGroups: []tc.CacheGroupName{"cg-a"}}
+	overB := DSEvaluation{State: tc.DSHealthStateDegraded, Why: "location cg-b over capacity", OverCapacityCacheGroups: []tc.CacheGroupName{"cg-b"}}
+	unavailable := DSEvaluation{State: tc.DSHealthStateUnavailable, Why: tc.DSStatErrorRate + " too high"}
+
+	tests := []struct {
+		name         string
+		eval         DSEvaluation
+		at           time.Duration
+		state        tc.DSHealthState
+		why          string
+		overCapacity []tc.CacheGroupName
+	}{
+		{name: "first evaluation", eval: available, state: tc.DSHealthStateAvailable},
+		{name: "worse", eval: unavailable, state: tc.DSHealthStateUnavailable, why: unavailable.Why},
+		{name: "healthier", eval: available, at: holdTime / 2, state: tc.DSHealthStateUnavailable, why: unavailable.Why},
+		{name: "relapse", eval: unavailable, at: holdTime, state: tc.DSHealthStateUnavailable, why: unavailable.Why},
+		{name: "healthier after relapse", eval: overA, at: holdTime + holdTime/2, state: tc.DSHealthStateUnavailable, why: unavailable.Why, overCapacity: []tc.CacheGroupName{"cg-a"}},
+		{name: "healthier for hold time", eval: overA, at: 2*holdTime + holdTime/2, state: tc.DSHealthStateDegraded, why: overA.Why, overCapacity: []tc.CacheGroupName{"cg-a"}},
+		{name: "cachegroup recovering", eval: overB, at: 3 * holdTime, state: tc.DSHealthStateDegraded, why: overB.Why, overCapacity: []tc.CacheGroupName{"cg-a", "cg-b"}},
+		{name: "cachegroups recovering", eval: available, at: 3*holdTime + holdTime/2, state: tc.DSHealthStateDegraded, why: overB.Why, overCapacity: []tc.CacheGroupName{"cg-a", "cg-b"}},
+		{name: "cachegroup recovered", eval: available, at: 4 * holdTime, state: tc.DSHealthStateDegraded, why: overB.Why, overCapacity: []tc.CacheGroupName{"cg-b"}},
+		{name: "all recovered", eval: available, at: 4*holdTime + holdTime/2, state: tc.DSHealthStateAvailable},
+	}
+
+	hold := dsdata.DSHealthHold{}
+	for _, test := range tests {
+		eval := HoldDSEvaluation(&hold, test.eval, start.Add(test.at), holdTime)
+		if eval.State != test.state || eval.Why != test.why {
+			t.Errorf("%s: expected state '%s' (%s), actual '%s' (%s)", test.name, test.state, test.why, eval.State, eval.Why)
+		}
+		if len(eval.OverCapacityCacheGroups) != 0 || len(test.overCapacity) != 0 {
+			if !reflect.DeepEqual(eval.OverCapacityCacheGroups, test.overCapacity) {
+				t.Errorf("%s: expected over capacity cachegroups %v, actual %v", test.name, test.overCapacity, eval.OverCapacityCacheGroups)
+			}
+		}
+	}
+
+	// the state recovers from unavailable before the cachegroup, but the delivery service stays degraded while it's over capacity
+	hold = dsdata.DSHealthHold{}
+	HoldDSEvaluation(&hold, unavailable, start, holdTime)
+	HoldDSEvaluation(&hold, overA, start, holdTime)
+	HoldDSEvaluation(&hold, available, start.Add(holdTime/2), holdTime)
+	eval := HoldDSEvaluation(&hold, available, start.Add(holdTime), holdTime)
+	if eval.State != tc.DSHealthStateDegraded || eval.Why != "location cg-a recovering from over capacity" || !reflect.DeepEqual(eval.OverCapacityCacheGroups, []tc.CacheGroupName{"cg-a"}) {
+		t.Errorf("state recovered before cachegroup: expected degraded with cg-a recovering from over capacity, actual %+v", eval)
+	}
+	if eval := HoldDSEvaluation(&hold, available, start.Add(holdTime+holdTime/2), holdTime); eval.State != tc.DSHealthStateAvailable || len(eval.OverCapacityCacheGroups) != 0 {
+		t.Errorf("cachegroup recovered after state: expected available, actual %+v", eval)
+	}
+
+	hold = dsdata.DSHealthHold{}
+	HoldDSEvaluation(&hold, unavailable, start, 0)
+	if eval := HoldDSEvaluation(&hold, available, start, 0); eval.State != tc.DSHealthStateAvailable {
+		t.Errorf("no hold time: expected available immediately, actual '%s' (%s)", eval.State, eval.Why)
+	}
+}
+
+func TestGetDSHealthHold(t *testing.T) {
+	for _, test := range []struct {
+		config   map[string]interface{}
+		expected time.Duration
+	}{
+		{config: map[string]interface{}{}, expected: 0},
+		{config: map[string]interface{}{DSHealthHoldParam: float64(30000)}, expected: 30 * time.Second},
+		{config: map[string]interface{}{DSHealthHoldParam: "30000"}, expected: 0},
+		{config: map[string]interface{}{DSHealthHoldParam: float64(-1)}, expected: 0},
+	} {
+		if actual := GetDSHealthHold(tc.TrafficMonitorConfigMap{Config: test.config}); actual != test.expected {
+			t.Errorf("config %v: expected hold %v, actual %v", test.config, test.expected, actual)
+		}
+	}
+}
+
+func TestMergeDisabledLocations(t *testing.T) {
+	merged := MergeDisabledLocations([]tc.CacheGroupName{"cg-a", "cg-b"}, []tc.CacheGroupName{"cg-b", "cg-c"})
+	expected := []tc.CacheGroupName{"cg-a", "cg-b", "cg-c"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, actual %v", expected, merged)
+	}
+}
@@ -0,0 +1,124 @@
+package health
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sort"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/cache"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+)
+
+// CacheEvaluation is the availability of a cache server, as evaluated against
+// some monitoring configuration.
+type CacheEvaluation struct {
+	Available       bool   `json:"available"`
+	Why             string `json:"why"`
+	UnavailableStat string `json:"unavailableStat,omitempty"`
+}
+
+// CacheDryRun compares the availability of a cache server under the current
+// monitoring configuration with its availability under a candidate one.
+type CacheDryRun struct {
+	Current   CacheEvaluation `json:"current"`
+	Candidate CacheEvaluation `json:"candidate"`
+	Changed   bool            `json:"changed"`
+}
+
+// DryRun is the result of evaluating a candidate monitoring configuration
+// against the current stat history of every cache server.
+type DryRun struct {
+	// Caches is the evaluation of each cache server which has been polled and
+	// is in either monitoring configuration.
+	Caches map[tc.CacheName]CacheDryRun `json:"caches"`
+	// Changed is the sorted names of the cache servers whose availability
+	// would change under the candidate monitoring configuration.
+	Changed []tc.CacheName `json:"changed"`
+	// Unpolled is the sorted names of the cache servers in the candidate
+	// monitoring configuration which have no poll results to evaluate.
+	Unpolled []tc.CacheName `json:"unpolled"`
+}
+
+// EvalDryRun evaluates the most recent result of each cache server against
+// both the current and candidate monitoring configurations, using the same
+// logic as CalcAvailability, and reports which would change availability and
+// why. Because both configurations are evaluated against the same results,
+// any change is caused by the configuration alone. No state is modified.
+func EvalDryRun(results cache.ResultInfoHistory, statResultHistory threadsafe.ResultStatHistory, current *tc.TrafficMonitorConfigMap, candidate *tc.TrafficMonitorConfigMap) DryRun {
+	statHistories := map[string]threadsafe.CacheStatHistory{}
+	statResultHistory.Range(func(cacheName string, val threadsafe.CacheStatHistory) bool {
+		statHistories[cacheName] = val
+		return true
+	})
+
+	dryRun := DryRun{
+		Caches:   map[tc.CacheName]CacheDryRun{},
+		Changed:  []tc.CacheName{},
+		Unpolled: []tc.CacheName{},
+	}
+
+	cacheNames := map[string]struct{}{}
+	for name := range current.TrafficServer {
+		cacheNames[name] = struct{}{}
+	}
+	for name := range candidate.TrafficServer {
+		cacheNames[name] = struct{}{}
+	}
+
+	for name := range cacheNames {
+		cacheResults := results[tc.CacheName(name)]
+		if len(cacheResults) == 0 {
+			if _, ok := candidate.TrafficServer[name]; ok {
+				dryRun.Unpolled = append(dryRun.Unpolled, tc.CacheName(name))
+			}
+			continue
+		}
+		result := cacheResults[0]
+
+		var resultStats *threadsafe.ResultStatValHistory
+		if statHistory, ok := statHistories[name]; ok {
+			resultStats = &statHistory.Stats
+		}
+
+		cacheDryRun := CacheDryRun{
+			Current:   evalCacheDryRun(result, resultStats, current),
+			Candidate: evalCacheDryRun(result, resultStats, candidate),
+		}
+		cacheDryRun.Changed = cacheDryRun.Current.Available != cacheDryRun.Candidate.Available
+		dryRun.Caches[tc.CacheName(name)] = cacheDryRun
+		if cacheDryRun.Changed {
+			dryRun.Changed = append(dryRun.Changed, tc.CacheName(name))
+		}
+	}
+
+	sortCacheNames(dryRun.Changed)
+	sortCacheNames(dryRun.Unpolled)
+	return dryRun
+}
+
+func evalCacheDryRun(result cache.ResultInfo, resultStats *threadsafe.ResultStatValHistory, mc *tc.TrafficMonitorConfigMap) CacheEvaluation {
+	available, why, unavailableStat := EvalCache(result, resultStats, mc)
+	return CacheEvaluation{Available: available, Why: why, UnavailableStat: unavailableStat}
+}
+
+func sortCacheNames(names []tc.CacheName) {
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+}
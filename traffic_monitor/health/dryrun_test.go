@@ -0,0 +1,98 @@
+package health
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/cache"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+)
+
+func dryRunTestConfig(loadavgThreshold float64, servers ...string) *tc.TrafficMonitorConfigMap {
+	mc := tc.TrafficMonitorConfigMap{
+		TrafficServer: map[string]tc.TrafficServer{},
+		Profile: map[string]tc.TMProfile{
+			"myProfile": {
+				Name: "myProfile",
+				Parameters: tc.TMParameters{
+					Thresholds: map[string]tc.HealthThreshold{
+						"loadavg": {Val: loadavgThreshold, Comparator: "<"},
+					},
+				},
+			},
+		},
+	}
+	for _, server := range servers {
+		mc.TrafficServer[server] = tc.TrafficServer{
+			HostName:     server,
+			ServerStatus: string(tc.CacheStatusReported),
+			Profile:      "myProfile",
+		}
+	}
+	return &mc
+}
+
+func TestEvalDryRun(t *testing.T) {
+	results := cache.ResultInfoHistory{
+		"busy": []cache.ResultInfo{{ID: "busy", Available: true, Vitals: cache.Vitals{LoadAvg: 8}}},
+		"idle": []cache.ResultInfo{{ID: "idle", Available: true, Vitals: cache.Vitals{LoadAvg: 1}}},
+	}
+	statResultHistory := threadsafe.NewResultStatHistory()
+
+	current := dryRunTestConfig(10, "busy", "idle")
+	candidate := dryRunTestConfig(5, "busy", "idle", "new")
+
+	dryRun := EvalDryRun(results, statResultHistory, current, candidate)
+
+	if len(dryRun.Changed) != 1 || dryRun.Changed[0] != "busy" {
+		t.Fatalf("expected changed caches [busy], actual: %v", dryRun.Changed)
+	}
+	if len(dryRun.Unpolled) != 1 || dryRun.Unpolled[0] != "new" {
+		t.Errorf("expected unpolled caches [new], actual: %v", dryRun.Unpolled)
+	}
+
+	busy, ok := dryRun.Caches["busy"]
+	if !ok {
+		t.Fatal("expected dry run for cache 'busy', actual: missing")
+	}
+	if !busy.Current.Available {
+		t.Errorf("expected 'busy' currently available, actual: unavailable because '%s'", busy.Current.Why)
+	}
+	if busy.Candidate.Available {
+		t.Error("expected 'busy' unavailable with candidate config, actual: available")
+	}
+	if busy.Candidate.UnavailableStat != "loadavg" {
+		t.Errorf("expected 'busy' candidate unavailable stat 'loadavg', actual: '%s'", busy.Candidate.UnavailableStat)
+	}
+	if !strings.Contains(busy.Candidate.Why, "loadavg too high") {
+		t.Errorf("expected 'busy' candidate why to contain 'loadavg too high', actual: '%s'", busy.Candidate.Why)
+	}
+
+	idle, ok := dryRun.Caches["idle"]
+	if !ok {
+		t.Fatal("expected dry run for cache 'idle', actual: missing")
+	}
+	if idle.Changed || !idle.Current.Available || !idle.Candidate.Available {
+		t.Errorf("expected 'idle' available and unchanged, actual: %+v", idle)
+	}
+}
@@ -44,13 +44,20 @@ import (
 // Start starts the poller and handler goroutines
 //
 func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData, trafficMonitorConfigFileName string) error {
-	toSession := towrap.NewTrafficOpsSessionThreadsafe(nil, nil, cfg.CRConfigHistoryCount, cfg)
-
 	localStates := peer.NewCRStatesThreadsafe() // this is the local state as discoverer by this traffic_monitor
 	fetchCount := threadsafe.NewUint()          // note this is the number of individual caches fetched from, not the number of times all the caches were polled.
 	healthIteration := threadsafe.NewUint()
 	errorCount := threadsafe.NewUint()
 
+	toSession := towrap.NewTrafficOpsSessionThreadsafe(nil, nil, cfg.CRConfigHistoryCount, cfg)
+	if cfg.Standalone() {
+		log.Infof("Starting standalone, without Traffic Ops, from CRConfig '%s' and monitoring config '%s'\n", cfg.StandaloneCRConfigFile, cfg.StandaloneTMConfigFile)
+		toSession = towrap.NewStandaloneTrafficOpsSessionThreadsafe(cfg.CRConfigHistoryCount, cfg)
+		if err := startStandaloneConfig(toSession, cfg, errorCount); err != nil {
+			return fmt.Errorf("starting standalone: %v", err)
+		}
+	}
+
 	toData := todata.NewThreadsafe()
 
 	cacheHealthHandler := cache.NewHandler()
@@ -71,7 +78,7 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 	cachesChanged := make(chan struct{})
 	peerStates := peer.NewCRStatesPeersThreadsafe(cfg.PeerOptimisticQuorumMin) // each peer's last state is saved in this map
 
-	monitorConfig := StartMonitorConfigManager(
+	monitorConfig, cacheShards := StartMonitorConfigManager(
 		monitorConfigPoller.ConfigChannel,
 		localStates,
 		peerStates,
@@ -86,7 +93,7 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		toData,
 	)
 
-	combinedStates, combineStateFunc := StartStateCombiner(events, peerStates, localStates, toData)
+	combinedStates, combineStateFunc := StartStateCombiner(events, peerStates, localStates, cacheShards, toData)
 
 	StartPeerManager(
 		peerHandler.ResultChannel,
@@ -104,6 +111,7 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		errorCount,
 		cfg,
 		monitorConfig,
+		cacheShards,
 		events,
 		combineStateFunc,
 	)
@@ -146,6 +154,7 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		localCacheStatus,
 		unpolledCaches,
 		monitorConfig,
+		cacheShards,
 		cfg,
 	)
 
@@ -131,12 +131,14 @@ func StartMonitorConfigManager(
 	staticAppData config.StaticAppData,
 	toSession towrap.TrafficOpsSessionThreadsafe,
 	toData todata.TODataThreadsafe,
-) threadsafe.TrafficMonitorConfigMap {
+) (threadsafe.TrafficMonitorConfigMap, peer.CacheShardsThreadsafe) {
 	monitorConfig := threadsafe.NewTrafficMonitorConfigMap()
+	cacheShards := peer.NewCacheShardsThreadsafe()
 	go monitorConfigListen(monitorConfig,
 		monitorConfigPollChan,
 		localStates,
 		peerStates,
+		cacheShards,
 		statURLSubscriber,
 		healthURLSubscriber,
 		peerURLSubscriber,
@@ -147,7 +149,7 @@ func StartMonitorConfigManager(
 		toSession,
 		toData,
 	)
-	return monitorConfig
+	return monitorConfig, cacheShards
 }
 
 const DefaultHealthConnectionTimeout = time.Second * 2
@@ -192,6 +194,7 @@ func monitorConfigListen(
 	monitorConfigPollChan <-chan poller.MonitorCfg,
 	localStates peer.CRStatesThreadsafe,
 	peerStates peer.CRStatesPeersThreadsafe,
+	cacheShards peer.CacheShardsThreadsafe,
 	statURLSubscriber chan<- poller.CachePollerConfig,
 	healthURLSubscriber chan<- poller.CachePollerConfig,
 	peerURLSubscriber chan<- poller.CachePollerConfig,
@@ -233,6 +236,12 @@ func monitorConfigListen(
 			continue
 		}
 
+		shards := peer.CacheShards{}
+		if cfg.ShardCachePolling {
+			shards = shardCaches(monitorConfig, staticAppData.Hostname, cfg.ShardReplicas)
+		}
+		cacheShards.Set(shards)
+
 		for _, srv := range monitorConfig.TrafficServer {
 			caches[srv.HostName] = srv.ServerStatus
 
@@ -251,6 +260,10 @@ func monitorConfigListen(
 				localStates.AddCache(cacheName, tc.IsAvailable{IsAvailable: false})
 			}
 
+			if !shards.IsLocal(cacheName) {
+				continue // polled by the peer which owns the cache's shard
+			}
+
 			pollURLStr := monitorConfig.Profile[srv.Profile].Parameters.HealthPollingURL
 			if pollURLStr == "" {
 				log.Errorf("monitor config server %v profile %v has no polling URL; can't poll", srv.HostName, srv.Profile)
@@ -334,6 +347,27 @@ func monitorConfigListen(
 	}
 }
 
+// shardCaches assigns the polled caches in the given monitor config to replicas of this monitor and its ONLINE peers.
+// Shards are computed from the configured peers alone, never from which peers are currently reachable, so every monitor with the same monitor config computes the same assignment. A peer which becomes unreachable is handled when states are combined, by taking its caches' states from their other owners.
+func shardCaches(monitorConfig tc.TrafficMonitorConfigMap, hostname string, replicas int) peer.CacheShards {
+	monitors := []tc.TrafficMonitorName{}
+	for _, srv := range monitorConfig.TrafficMonitor {
+		if srv.HostName == hostname || tc.CacheStatusFromString(srv.ServerStatus) != tc.CacheStatusOnline {
+			continue
+		}
+		monitors = append(monitors, tc.TrafficMonitorName(srv.HostName))
+	}
+
+	caches := []tc.CacheName{}
+	for _, srv := range monitorConfig.TrafficServer {
+		if status := tc.CacheStatusFromString(srv.ServerStatus); status == tc.CacheStatusOnline || status == tc.CacheStatusOffline {
+			continue // ONLINE and OFFLINE caches are not polled
+		}
+		caches = append(caches, tc.CacheName(srv.HostName))
+	}
+	return peer.ShardCaches(tc.TrafficMonitorName(hostname), monitors, caches, replicas)
+}
+
 // createServerHealthPollURLs takes the template pollingURLStr, and replaces
 // variables with data from srv, and returns the polling URL for srv.
 //
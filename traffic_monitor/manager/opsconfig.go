@@ -69,6 +69,7 @@ func StartOpsConfigManager(
 	localCacheStatus threadsafe.CacheAvailableStatus,
 	unpolledCaches threadsafe.UnpolledCaches,
 	monitorConfig threadsafe.TrafficMonitorConfigMap,
+	cacheShards peer.CacheShardsThreadsafe,
 	cfg config.Config,
 ) (threadsafe.OpsConfig, error) {
 
@@ -126,6 +127,7 @@ func StartOpsConfigManager(
 			lastStats,
 			unpolledCaches,
 			monitorConfig,
+			cacheShards,
 		)
 
 		// If the HTTPS Listener is defined in the traffic_ops.cfg file then it creates the HTTPS endpoint and the corresponding HTTP endpoint as a redirect
@@ -163,7 +165,7 @@ func StartOpsConfigManager(
 			// use a fallback constant duration.
 			backoff = util.NewConstantBackoff(util.ConstantBackoffDuration)
 		}
-		for {
+		for !toSession.Standalone() {
 			err = toSession.Update(newOpsConfig.Url, newOpsConfig.Username, newOpsConfig.Password, newOpsConfig.Insecure, staticAppData.UserAgent, useCache, trafficOpsRequestTimeout)
 			if err != nil {
 				handleErr(fmt.Errorf("MonitorConfigPoller: error instantiating Session with traffic_ops (%v): %s\n", toAddr, err))
@@ -184,6 +186,10 @@ func StartOpsConfigManager(
 				break
 			}
 		}
+		if toSession.Standalone() {
+			// there is no Traffic Ops to log in to; the standalone config files serve in its place.
+			newOpsConfig.UsingDummyTO = true
+		}
 		opsConfig.Set(newOpsConfig)
 
 		if cdn, err := toSession.MonitorCDN(staticAppData.Hostname); err != nil {
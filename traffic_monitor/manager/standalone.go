@@ -0,0 +1,75 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+	"github.com/apache/trafficcontrol/traffic_monitor/poller"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+	"github.com/apache/trafficcontrol/traffic_monitor/towrap"
+)
+
+// startStandaloneConfig loads the standalone CRConfig and monitoring config files into the given standalone session, and starts watching them for changes.
+// The files are read once synchronously, so the session has a complete config before anything requests it, and it's an error if either can't be read. Later changes are picked up by the monitor config poller on its next poll.
+func startStandaloneConfig(toSession towrap.TrafficOpsSessionThreadsafe, cfg config.Config, errorCount threadsafe.Uint) error {
+	files := []struct {
+		name string
+		set  func([]byte) error
+	}{
+		{name: cfg.StandaloneCRConfigFile, set: toSession.SetStandaloneCRConfig},
+		{name: cfg.StandaloneTMConfigFile, set: toSession.SetStandaloneMonitoringConfig},
+	}
+
+	for _, file := range files {
+		contents, err := ioutil.ReadFile(file.name)
+		if err != nil {
+			return fmt.Errorf("reading standalone config file '%s': %v", file.name, err)
+		}
+		if err := file.set(contents); err != nil {
+			return fmt.Errorf("setting standalone config file '%s': %v", file.name, err)
+		}
+	}
+
+	for _, file := range files {
+		fileName := file.name
+		set := file.set
+		onChange := func(contents []byte, err error) {
+			if err != nil {
+				errorCount.Inc()
+				log.Errorf("reading standalone config file '%s': %v", fileName, err)
+				return
+			}
+			if err := set(contents); err != nil {
+				errorCount.Inc()
+				log.Errorf("setting standalone config file '%s': %v", fileName, err)
+				return
+			}
+			log.Infof("loaded standalone config file '%s'", fileName)
+		}
+		if _, err := poller.File(fileName, onChange); err != nil {
+			return fmt.Errorf("watching standalone config file '%s': %v", fileName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+	"github.com/apache/trafficcontrol/traffic_monitor/towrap"
+)
+
+const testCRConfig = `{"stats": {"CDN_name": "cdn0", "date": 1600000000}}`
+const testNewerCRConfig = `{"stats": {"CDN_name": "cdn0", "date": 1600000060}}`
+const testMonitoringConfig = `{"trafficServers": []}`
+
+func writeStandaloneConfig(t *testing.T, dir string) config.Config {
+	cfg := config.Config{
+		StandaloneCRConfigFile: filepath.Join(dir, config.StandaloneCRConfigFileName),
+		StandaloneTMConfigFile: filepath.Join(dir, config.StandaloneTMConfigFileName),
+	}
+	if err := ioutil.WriteFile(cfg.StandaloneCRConfigFile, []byte(testCRConfig), 0644); err != nil {
+		t.Fatalf("writing CRConfig file: %v", err)
+	}
+	if err := ioutil.WriteFile(cfg.StandaloneTMConfigFile, []byte(testMonitoringConfig), 0644); err != nil {
+		t.Fatalf("writing monitoring config file: %v", err)
+	}
+	return cfg
+}
+
+func TestStartStandaloneConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "standalone-test")
+	if err != nil {
+		t.Fatalf("creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := writeStandaloneConfig(t, dir)
+
+	toSession := towrap.NewStandaloneTrafficOpsSessionThreadsafe(5, cfg)
+	errorCount := threadsafe.NewUint()
+	if err := startStandaloneConfig(toSession, cfg, errorCount); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the files must be loaded by the time startStandaloneConfig returns
+	if crConfig, err := toSession.CRConfigRaw("cdn0"); err != nil {
+		t.Errorf("getting the loaded CRConfig: unexpected error: %v", err)
+	} else if string(crConfig) != testCRConfig {
+		t.Errorf("expected the loaded CRConfig '%s', actual: '%s'", testCRConfig, crConfig)
+	}
+
+	if err := ioutil.WriteFile(cfg.StandaloneCRConfigFile, []byte(testNewerCRConfig), 0644); err != nil {
+		t.Fatalf("rewriting CRConfig file: %v", err)
+	}
+	crConfig := []byte(nil)
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if crConfig, _ = toSession.CRConfigRaw("cdn0"); string(crConfig) == testNewerCRConfig {
+			break
+		}
+	}
+	if string(crConfig) != testNewerCRConfig {
+		t.Errorf("expected the changed CRConfig '%s' to be loaded, actual: '%s'", testNewerCRConfig, crConfig)
+	}
+	if count := errorCount.Get(); count != 0 {
+		t.Errorf("expected no errors, actual: %d", count)
+	}
+}
+
+func TestStartStandaloneConfigErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "standalone-test")
+	if err != nil {
+		t.Fatalf("creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := writeStandaloneConfig(t, dir)
+
+	notStandalone := towrap.NewTrafficOpsSessionThreadsafe(nil, nil, 5, cfg)
+	if err := startStandaloneConfig(notStandalone, cfg, threadsafe.NewUint()); err == nil {
+		t.Error("loading config into a session which isn't standalone: expected error, actual: nil")
+	}
+
+	if err := os.Remove(cfg.StandaloneTMConfigFile); err != nil {
+		t.Fatalf("removing monitoring config file: %v", err)
+	}
+	toSession := towrap.NewStandaloneTrafficOpsSessionThreadsafe(5, cfg)
+	if err := startStandaloneConfig(toSession, cfg, threadsafe.NewUint()); err == nil {
+		t.Error("loading a missing monitoring config file: expected error, actual: nil")
+	}
+	if _, err := toSession.TrafficMonitorConfigMap("cdn0"); err == nil {
+		t.Error("getting the monitoring config after a failed load: expected error, actual: nil")
+	}
+}
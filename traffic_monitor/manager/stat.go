@@ -42,10 +42,15 @@ func pruneHistory(history []cache.Result, limit uint64) []cache.Result {
 	return history
 }
 
-func getNewCaches(localStates peer.CRStatesThreadsafe, monitorConfigTS threadsafe.TrafficMonitorConfigMap) map[tc.CacheName]struct{} {
+func getNewCaches(localStates peer.CRStatesThreadsafe, monitorConfigTS threadsafe.TrafficMonitorConfigMap, cacheShardsTS peer.CacheShardsThreadsafe) map[tc.CacheName]struct{} {
 	monitorConfig := monitorConfigTS.Get()
+	cacheShards := cacheShardsTS.Get()
 	caches := map[tc.CacheName]struct{}{}
 	for cacheName := range localStates.GetCaches() {
+		// Caches polled by a peer will never be polled by us, so waiting for them would prevent ever serving.
+		if !cacheShards.IsLocal(cacheName) {
+			continue
+		}
 		// ONLINE and OFFLINE caches are not polled.
 		if ts, ok := monitorConfig.TrafficServer[string(cacheName)]; !ok || ts.ServerStatus == string(tc.CacheStatusOnline) || ts.ServerStatus == string(tc.CacheStatusOffline) {
 			continue
@@ -67,6 +72,7 @@ func StartStatHistoryManager(
 	errorCount threadsafe.Uint,
 	cfg config.Config,
 	monitorConfig threadsafe.TrafficMonitorConfigMap,
+	cacheShards peer.CacheShardsThreadsafe,
 	events health.ThreadsafeEvents,
 	combineState func(),
 ) (threadsafe.ResultInfoHistory, threadsafe.ResultStatHistory, threadsafe.CacheKbpses, threadsafe.DurationMap, threadsafe.LastStats, threadsafe.DSStatsReader, threadsafe.UnpolledCaches, threadsafe.CacheAvailableStatus) {
@@ -96,7 +102,7 @@ func StartStatHistoryManager(
 
 	process := func(results []cache.Result) {
 		if haveCachesChanged() {
-			unpolledCaches.SetNewCaches(getNewCaches(localStates, monitorConfig))
+			unpolledCaches.SetNewCaches(getNewCaches(localStates, monitorConfig, cacheShards))
 		}
 		processStatResults(results, statInfoHistory, statResultHistory, statMaxKbpses, combinedStates, lastStats, toData.Get(), errorCount, dsStats, lastStatEndTimes, lastStatDurations, unpolledCaches, monitorConfig.Get(), precomputedData, lastResults, localStates, events, localCacheStatus, overrideMap, combineState, cfg.CachePollingProtocol)
 	}
@@ -33,7 +33,7 @@ import (
 )
 
 // StartStateCombiner starts the State Combiner goroutine, and returns the threadsafe CombinedStates, and a func to signal to combine states.
-func StartStateCombiner(events health.ThreadsafeEvents, peerStates peer.CRStatesPeersThreadsafe, localStates peer.CRStatesThreadsafe, toData todata.TODataThreadsafe) (peer.CRStatesThreadsafe, func()) {
+func StartStateCombiner(events health.ThreadsafeEvents, peerStates peer.CRStatesPeersThreadsafe, localStates peer.CRStatesThreadsafe, cacheShards peer.CacheShardsThreadsafe, toData todata.TODataThreadsafe) (peer.CRStatesThreadsafe, func()) {
 	combinedStates := peer.NewCRStatesThreadsafe()
 
 	// the chan buffer just reduces the number of goroutines on our infinite buffer hack in combineState(), no real writer will block, since combineState() writes in a goroutine.
@@ -58,7 +58,7 @@ func StartStateCombiner(events health.ThreadsafeEvents, peerStates peer.CRStates
 		overrideMap := map[tc.CacheName]bool{}
 		for range combineStateChan {
 			drain(combineStateChan)
-			combineCrStates(events, true, peerStates, localStates.Get(), combinedStates, overrideMap, toData.Get())
+			combineCrStates(events, true, peerStates, localStates.Get(), combinedStates, overrideMap, toData.Get(), cacheShards.Get())
 		}
 	}()
 
@@ -138,6 +138,54 @@ func combineCacheState(
 	combinedStates.AddCache(cacheName, tc.IsAvailable{IsAvailable: available, Ipv4Available: ipv4Available, Ipv6Available: ipv6Available})
 }
 
+// combineShardedCacheState sets the combined state of a cache which is polled by peers, when cache polling is sharded.
+// Like the unsharded peer merge, it is optimistic: the cache is available if any of its owners which are available reports it available. If none of its owners are available, their last known states are used, until one of them is polled again.
+func combineShardedCacheState(
+	cacheName tc.CacheName,
+	owners []tc.TrafficMonitorName,
+	localCacheState tc.IsAvailable,
+	peerStates peer.CRStatesPeersThreadsafe,
+	peerCrStates map[tc.TrafficMonitorName]tc.CRStates,
+	events health.ThreadsafeEvents,
+	combinedStates peer.CRStatesThreadsafe,
+	toData todata.TOData,
+) {
+	reporters := []tc.TrafficMonitorName{}
+	for _, owner := range owners {
+		if _, ok := peerCrStates[owner].Caches[cacheName]; ok && peerStates.GetPeerAvailability(owner) {
+			reporters = append(reporters, owner)
+		}
+	}
+	if len(reporters) == 0 {
+		for _, owner := range owners {
+			if _, ok := peerCrStates[owner].Caches[cacheName]; ok {
+				reporters = append(reporters, owner)
+			}
+		}
+	}
+
+	available := localCacheState // none of the owners have been polled yet, or know about the cache yet
+	if len(reporters) > 0 {
+		available = tc.IsAvailable{}
+		for _, reporter := range reporters {
+			reported := peerCrStates[reporter].Caches[cacheName]
+			available.IsAvailable = available.IsAvailable || reported.IsAvailable
+			available.Ipv4Available = available.Ipv4Available || reported.Ipv4Available
+			available.Ipv6Available = available.Ipv6Available || reported.Ipv6Available
+		}
+	}
+
+	if prevAvailable, ok := combinedStates.GetCache(cacheName); ok && prevAvailable.IsAvailable != available.IsAvailable {
+		reporterNames := make([]string, 0, len(reporters))
+		for _, reporter := range reporters {
+			reporterNames = append(reporterNames, reporter.String())
+		}
+		events.Add(health.Event{Time: health.Time(time.Now()), Description: fmt.Sprintf("Availability reported by shard owners %s", strings.Join(reporterNames, ", ")), Name: cacheName.String(), Hostname: cacheName.String(), Type: toData.ServerTypes[cacheName].String(), Available: available.IsAvailable, IPv4Available: available.Ipv4Available, IPv6Available: available.Ipv6Available})
+	}
+
+	combinedStates.AddCache(cacheName, available)
+}
+
 func combineDSState(
 	deliveryServiceName tc.DeliveryServiceName,
 	localDeliveryService tc.CRStatesDeliveryService,
@@ -149,6 +197,8 @@ func combineDSState(
 		deliveryService.IsAvailable = true
 	}
 	deliveryService.DisabledLocations = localDeliveryService.DisabledLocations
+	deliveryService.State = dsHealthState(localDeliveryService)
+	deliveryService.OverCapacityLocations = localDeliveryService.OverCapacityLocations
 
 	for peerName, iPeerStates := range peerStates.GetCrstates() {
 		peerDeliveryService, ok := iPeerStates.DeliveryService[deliveryServiceName]
@@ -159,11 +209,30 @@ func combineDSState(
 		if peerDeliveryService.IsAvailable {
 			deliveryService.IsAvailable = true
 		}
+		// like availability, the combined state is optimistic: the healthiest state any monitor reports.
+		if peerState := dsHealthState(peerDeliveryService); peerState.Rank() < deliveryService.State.Rank() {
+			deliveryService.State = peerState
+		}
 		deliveryService.DisabledLocations = intersection(deliveryService.DisabledLocations, peerDeliveryService.DisabledLocations)
+		deliveryService.OverCapacityLocations = intersection(deliveryService.OverCapacityLocations, peerDeliveryService.OverCapacityLocations)
+	}
+	if len(deliveryService.OverCapacityLocations) == 0 {
+		deliveryService.OverCapacityLocations = nil
 	}
 	combinedStates.SetDeliveryService(deliveryServiceName, deliveryService)
 }
 
+// dsHealthState returns the health state of the given delivery service state. Peers which don't evaluate delivery service health thresholds don't report a state, so it is derived from their availability.
+func dsHealthState(deliveryService tc.CRStatesDeliveryService) tc.DSHealthState {
+	if deliveryService.State != "" {
+		return deliveryService.State
+	}
+	if deliveryService.IsAvailable {
+		return tc.DSHealthStateAvailable
+	}
+	return tc.DSHealthStateUnavailable
+}
+
 // pruneCombinedDSState deletes delivery services in combined states which have been removed from localStates and peerStates
 func pruneCombinedDSState(combinedStates peer.CRStatesThreadsafe, localStates tc.CRStates, peerStates peer.CRStatesPeersThreadsafe) {
 	combinedCRStates := combinedStates.Get()
@@ -199,8 +268,17 @@ func pruneCombinedCaches(combinedStates peer.CRStatesThreadsafe, localStates tc.
 	}
 }
 
-func combineCrStates(events health.ThreadsafeEvents, peerOptimistic bool, peerStates peer.CRStatesPeersThreadsafe, localStates tc.CRStates, combinedStates peer.CRStatesThreadsafe, overrideMap map[tc.CacheName]bool, toData todata.TOData) {
+func combineCrStates(events health.ThreadsafeEvents, peerOptimistic bool, peerStates peer.CRStatesPeersThreadsafe, localStates tc.CRStates, combinedStates peer.CRStatesThreadsafe, overrideMap map[tc.CacheName]bool, toData todata.TOData, cacheShards peer.CacheShards) {
+	peerCrStates := map[tc.TrafficMonitorName]tc.CRStates(nil)
 	for cacheName, localCacheState := range localStates.Caches { // localStates gets pruned when servers are disabled, it's the source of truth
+		if !cacheShards.IsLocal(cacheName) {
+			if peerCrStates == nil {
+				peerCrStates = peerStates.GetCrstates()
+			}
+			owners, _ := cacheShards.CacheOwners(cacheName)
+			combineShardedCacheState(cacheName, owners, localCacheState, peerStates, peerCrStates, events, combinedStates, toData)
+			continue
+		}
 		combineCacheState(cacheName, localCacheState, events, peerOptimistic, peerStates, combinedStates, overrideMap, toData)
 	}
 
@@ -154,3 +154,44 @@ func TestCombineCacheStateCacheDown(t *testing.T) {
 		t.Fatalf("cache IPv6 is unavailable and should be available")
 	}
 }
+
+func TestCombineShardedCacheState(t *testing.T) {
+	cacheName := tc.CacheName("testCache")
+	owners := []tc.TrafficMonitorName{"TestTM-01", "TestTM-02"}
+	toData := todata.TOData{ServerTypes: map[tc.CacheName]tc.CacheType{cacheName: tc.CacheTypeEdge}}
+
+	newPeerStates := func(tm01Available bool, tm01Cache bool, tm02Available bool, tm02Cache bool) peer.CRStatesPeersThreadsafe {
+		peerStates := peer.NewCRStatesPeersThreadsafe(0)
+		peerStates.SetTimeout(time.Hour)
+		peerStates.Set(peer.Result{ID: "TestTM-01", Available: tm01Available, PeerStates: tc.CRStates{Caches: map[tc.CacheName]tc.IsAvailable{cacheName: {IsAvailable: tm01Cache, Ipv4Available: tm01Cache}}}, Time: time.Now()})
+		peerStates.Set(peer.Result{ID: "TestTM-02", Available: tm02Available, PeerStates: tc.CRStates{Caches: map[tc.CacheName]tc.IsAvailable{cacheName: {IsAvailable: tm02Cache, Ipv4Available: tm02Cache}}}, Time: time.Now()})
+		peerStates.SetPeers(map[tc.TrafficMonitorName]struct{}{"TestTM-01": struct{}{}, "TestTM-02": struct{}{}})
+		return peerStates
+	}
+
+	tests := []struct {
+		name       string
+		peerStates peer.CRStatesPeersThreadsafe
+		available  bool
+	}{
+		{"any available owner reports available", newPeerStates(true, false, true, true), true},
+		{"unavailable owner is ignored", newPeerStates(false, true, true, false), false},
+		{"last known states used when no owner is available", newPeerStates(false, false, false, true), true},
+	}
+
+	for _, test := range tests {
+		combinedStates := peer.NewCRStatesThreadsafe()
+		combineShardedCacheState(cacheName, owners, tc.IsAvailable{}, test.peerStates, test.peerStates.GetCrstates(), health.NewThreadsafeEvents(1), combinedStates, toData)
+		if actual := combinedStates.Get().Caches[cacheName]; actual.IsAvailable != test.available || actual.Ipv4Available != test.available {
+			t.Errorf("%s: expected cache availability %v, actual %+v", test.name, test.available, actual)
+		}
+	}
+
+	combinedStates := peer.NewCRStatesThreadsafe()
+	local := tc.IsAvailable{IsAvailable: true, Ipv6Available: true}
+	peerStates := newPeerStates(true, false, true, false)
+	combineShardedCacheState(cacheName, []tc.TrafficMonitorName{"TestTM-03"}, local, peerStates, peerStates.GetCrstates(), health.NewThreadsafeEvents(1), combinedStates, toData)
+	if actual := combinedStates.Get().Caches[cacheName]; actual != local {
+		t.Errorf("expected local state %+v when no owner has reported the cache, actual %+v", local, actual)
+	}
+}
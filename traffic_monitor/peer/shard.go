@@ -0,0 +1,139 @@
+package peer
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// CacheShards is the assignment of caches to the Traffic Monitors responsible for polling them, when cache polling is sharded across peers.
+// The zero value has no assignments, and considers every cache local, which is the unsharded behavior.
+type CacheShards struct {
+	Self     tc.TrafficMonitorName                    `json:"self"`
+	Monitors []tc.TrafficMonitorName                  `json:"monitors"`
+	Owners   map[tc.CacheName][]tc.TrafficMonitorName `json:"owners"`
+}
+
+// ShardCaches assigns each of the given caches to replicas of the given monitors, plus self. Replicas less than 1 are treated as 1, and replicas greater than the number of monitors assigns every cache to every monitor.
+//
+// The assignment uses rendezvous (highest random weight) hashing, so every monitor with the same configured monitors computes the same assignment, and when a monitor is removed only the caches it owned are reassigned.
+func ShardCaches(self tc.TrafficMonitorName, monitors []tc.TrafficMonitorName, caches []tc.CacheName, replicas int) CacheShards {
+	allMonitors := []tc.TrafficMonitorName{self}
+	for _, monitor := range monitors {
+		if monitor != self {
+			allMonitors = append(allMonitors, monitor)
+		}
+	}
+
+	owners := make(map[tc.CacheName][]tc.TrafficMonitorName, len(caches))
+	for _, cache := range caches {
+		owners[cache] = ShardOwners(cache, allMonitors, replicas)
+	}
+	return CacheShards{Self: self, Monitors: allMonitors, Owners: owners}
+}
+
+// ShardOwners returns the n monitors in monitors which are responsible for polling the given cache, in order of preference. Returns fewer than n if there are fewer monitors.
+func ShardOwners(cache tc.CacheName, monitors []tc.TrafficMonitorName, n int) []tc.TrafficMonitorName {
+	if n < 1 {
+		n = 1
+	}
+	weights := make(map[tc.TrafficMonitorName]uint64, len(monitors))
+	owners := make([]tc.TrafficMonitorName, 0, len(monitors))
+	for _, monitor := range monitors {
+		weights[monitor] = shardWeight(cache, monitor)
+		owners = append(owners, monitor)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		// ties are broken by name, so the result doesn't depend on the order of monitors.
+		if weights[owners[i]] != weights[owners[j]] {
+			return weights[owners[i]] > weights[owners[j]]
+		}
+		return owners[i] < owners[j]
+	})
+	if len(owners) > n {
+		owners = owners[:n]
+	}
+	return owners
+}
+
+// shardWeight returns the rendezvous hashing weight of the given cache on the given monitor.
+// FNV alone distributes similar names (like sequentially numbered caches) poorly, so the hash is finalized with the MurmurHash3 64-bit mixer.
+func shardWeight(cache tc.CacheName, monitor tc.TrafficMonitorName) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(monitor))
+	h.Write([]byte{0})
+	h.Write([]byte(cache))
+	k := h.Sum64()
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
+
+// CacheOwners returns the monitors responsible for polling the given cache, and whether the cache has been assigned at all.
+func (s CacheShards) CacheOwners(cache tc.CacheName) ([]tc.TrafficMonitorName, bool) {
+	owners, ok := s.Owners[cache]
+	return owners, ok
+}
+
+// IsLocal returns whether this monitor is one of those responsible for polling the given cache. Caches which have not been assigned are always local, so a monitor never stops polling a cache it doesn't know the owners of.
+func (s CacheShards) IsLocal(cache tc.CacheName) bool {
+	owners, ok := s.Owners[cache]
+	if !ok {
+		return true
+	}
+	for _, owner := range owners {
+		if owner == s.Self {
+			return true
+		}
+	}
+	return false
+}
+
+// CacheShardsThreadsafe provides safe access for multiple goroutines to read a single CacheShards object, with a single goroutine writer.
+type CacheShardsThreadsafe struct {
+	shards *CacheShards
+	m      *sync.RWMutex
+}
+
+// NewCacheShardsThreadsafe returns a new CacheShardsThreadsafe with no assignments.
+func NewCacheShardsThreadsafe() CacheShardsThreadsafe {
+	return CacheShardsThreadsafe{shards: &CacheShards{}, m: &sync.RWMutex{}}
+}
+
+// Get returns the current cache shards. Callers MUST NOT modify the returned object.
+func (t CacheShardsThreadsafe) Get() CacheShards {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	return *t.shards
+}
+
+// Set sets the current cache shards. This MUST NOT be called by multiple goroutines.
+func (t CacheShardsThreadsafe) Set(shards CacheShards) {
+	t.m.Lock()
+	*t.shards = shards
+	t.m.Unlock()
+}
@@ -0,0 +1,137 @@
+package peer
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func testShardCaches(n int) []tc.CacheName {
+	caches := make([]tc.CacheName, 0, n)
+	for i := 0; i < n; i++ {
+		caches = append(caches, tc.CacheName(fmt.Sprintf("edge-%d", i)))
+	}
+	return caches
+}
+
+func TestShardCachesDeterministic(t *testing.T) {
+	caches := testShardCaches(500)
+	a := ShardCaches("tm-a", []tc.TrafficMonitorName{"tm-b", "tm-c"}, caches, 1)
+	b := ShardCaches("tm-b", []tc.TrafficMonitorName{"tm-c", "tm-a"}, caches, 1)
+
+	for _, cache := range caches {
+		if !reflect.DeepEqual(a.Owners[cache], b.Owners[cache]) {
+			t.Errorf("expected cache %s to have the same owners regardless of view, actual %v and %v", cache, a.Owners[cache], b.Owners[cache])
+		}
+		if a.IsLocal(cache) && b.IsLocal(cache) {
+			t.Errorf("expected cache %s to be local to at most one monitor", cache)
+		}
+	}
+}
+
+func TestShardCachesBalanced(t *testing.T) {
+	caches := testShardCaches(3000)
+	shards := ShardCaches("tm-a", []tc.TrafficMonitorName{"tm-b", "tm-c"}, caches, 1)
+
+	counts := map[tc.TrafficMonitorName]int{}
+	for _, owners := range shards.Owners {
+		counts[owners[0]]++
+	}
+	if len(counts) != 3 {
+		t.Fatalf("expected caches to be assigned to 3 monitors, actual %d: %+v", len(counts), counts)
+	}
+	for monitor, count := range counts {
+		if count < 800 || count > 1200 {
+			t.Errorf("expected monitor %s to own roughly a third of 3000 caches, actual %d", monitor, count)
+		}
+	}
+}
+
+func TestShardCachesRebalance(t *testing.T) {
+	caches := testShardCaches(500)
+	before := ShardCaches("tm-a", []tc.TrafficMonitorName{"tm-b", "tm-c"}, caches, 1)
+	after := ShardCaches("tm-a", []tc.TrafficMonitorName{"tm-b"}, caches, 1)
+
+	for _, cache := range caches {
+		if before.Owners[cache][0] == "tm-c" {
+			if after.Owners[cache][0] == "tm-c" {
+				t.Errorf("expected cache %s to be reassigned after its owner was removed", cache)
+			}
+			continue
+		}
+		if before.Owners[cache][0] != after.Owners[cache][0] {
+			t.Errorf("expected cache %s not owned by the removed monitor to keep its owner '%s', actual '%s'", cache, before.Owners[cache][0], after.Owners[cache][0])
+		}
+	}
+}
+
+func TestShardCachesReplicas(t *testing.T) {
+	caches := testShardCaches(500)
+	monitors := []tc.TrafficMonitorName{"tm-a", "tm-b", "tm-c", "tm-d"}
+	primaries := ShardCaches("tm-a", monitors, caches, 1)
+	views := []CacheShards{}
+	for _, monitor := range monitors {
+		views = append(views, ShardCaches(monitor, monitors, caches, 2))
+	}
+
+	for _, cache := range caches {
+		owners := views[0].Owners[cache]
+		if len(owners) != 2 || owners[0] == owners[1] {
+			t.Fatalf("expected cache %s to have 2 distinct owners, actual %v", cache, owners)
+		}
+		if owners[0] != primaries.Owners[cache][0] {
+			t.Errorf("expected cache %s to be owned first by its unreplicated owner '%s', actual %v", cache, primaries.Owners[cache][0], owners)
+		}
+		local := 0
+		for _, view := range views {
+			if view.IsLocal(cache) {
+				local++
+			}
+		}
+		if local != 2 {
+			t.Errorf("expected cache %s to be local to exactly 2 monitors, actual %d", cache, local)
+		}
+	}
+
+	if owners := ShardOwners("edge-0", monitors, 10); len(owners) != len(monitors) {
+		t.Errorf("expected more replicas than monitors to assign every monitor, actual %v", owners)
+	}
+	if owners := ShardOwners("edge-0", monitors, 0); len(owners) != 1 {
+		t.Errorf("expected 0 replicas to be treated as 1, actual %v", owners)
+	}
+}
+
+func TestCacheShardsUnassignedIsLocal(t *testing.T) {
+	shards := CacheShards{}
+	if !shards.IsLocal("edge-0") {
+		t.Error("expected unsharded monitor to consider every cache local")
+	}
+
+	shards = ShardCaches("tm-a", nil, testShardCaches(10), 2)
+	for cache := range shards.Owners {
+		if !shards.IsLocal(cache) {
+			t.Errorf("expected monitor with no peers to own cache %s", cache)
+		}
+	}
+}
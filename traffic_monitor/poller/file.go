@@ -29,18 +29,24 @@ import (
 func File(filename string, result func([]byte, error)) (chan<- struct{}, error) {
 	die := make(chan struct{})
 
+	// Watch before the initial read, so changes made as soon as this returns aren't missed.
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filename); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("watching file '%v': %v", filename, err)
+	}
+
 	contents, err := ioutil.ReadFile(filename)
 	if err != nil {
+		watcher.Close()
 		return nil, fmt.Errorf("reading file '%v': %v", filename, err)
 	}
 	go result(contents, nil)
 
-	watcher, err := fsnotify.NewWatcher()
-	if err != nil {
-		return nil, err
-	}
 	go func() {
-		watcher.Add(filename)
 		defer watcher.Close()
 		for {
 			select {
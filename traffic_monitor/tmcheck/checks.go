@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tmcheck
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	to "github.com/apache/trafficcontrol/traffic_ops/v2-client"
+)
+
+// AllMonitorsValidateFunc validates all monitors in the given Traffic Ops, returning the validation error of each monitor, and any error not associated with a single monitor.
+type AllMonitorsValidateFunc func(toClient *to.Session, includeOffline bool) (map[tc.TrafficMonitorName]error, error)
+
+// Check is a named validation of all monitors, which may be run by AllValidator, for example by a validator service.
+type Check struct {
+	// Name is the unique name of the check.
+	Name string
+	// Description is a human-readable description of what the check validates.
+	Description string
+	// Validate performs the check.
+	Validate AllMonitorsValidateFunc
+}
+
+// The names of the checks in this package.
+const (
+	CheckCRStatesConsistency = "crstates-consistency"
+	CheckOfflineStates       = "offline-states"
+	CheckDSStats             = "deliveryservices"
+	CheckQueryInterval       = "query-interval"
+	CheckPeerPoller          = "peer-poller"
+)
+
+// Checks returns all the checks in this package, in the order they should be reported.
+func Checks() []Check {
+	return []Check{
+		{
+			Name:        CheckCRStatesConsistency,
+			Description: "validates all caches and Delivery Services in the CRConfig exist in CRStates, and all caches in CRStates exist in the CRConfig",
+			Validate:    ValidateAllMonitorsCRStatesConsistency,
+		},
+		{
+			Name:        CheckOfflineStates,
+			Description: "validates all OFFLINE and ADMIN_DOWN caches in the CRConfig are Unavailable",
+			Validate:    ValidateAllMonitorsOfflineStates,
+		},
+		{
+			Name:        CheckDSStats,
+			Description: "validates all Delivery Services in the CRConfig exist in DsStats",
+			Validate:    ValidateAllMonitorsDSStats,
+		},
+		{
+			Name:        CheckQueryInterval,
+			Description: "validates all Monitors' Query Interval (95th percentile) is less than " + QueryIntervalMax.String(),
+			Validate:    ValidateAllMonitorsQueryInterval,
+		},
+		{
+			Name:        CheckPeerPoller,
+			Description: "validates all peers in the CRConfig have been polled within the last " + PeerPollMax.String(),
+			Validate:    ValidateAllPeerPollers,
+		},
+	}
+}
@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tmcheck
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	to "github.com/apache/trafficcontrol/traffic_ops/v2-client"
+)
+
+// ValidateCRStatesConsistencyWithCRConfig validates that the given Traffic Monitor's CRStates is consistent with the given CRConfig, per ValidateCRStatesConsistency.
+func ValidateCRStatesConsistencyWithCRConfig(tmURI string, crConfig *tc.CRConfig) error {
+	crStates, err := GetCRStates(tmURI + TrafficMonitorCRStatesPath)
+	if err != nil {
+		return fmt.Errorf("getting CRStates: %v", err)
+	}
+	return ValidateCRStatesConsistency(crStates, crConfig)
+}
+
+// ValidateCRStatesConsistency validates that every cache in the given CRConfig exists in the given CRStates, that every cache in the CRStates exists in the CRConfig, and that every Delivery Service in the CRConfig with caches assigned exists in the CRStates.
+func ValidateCRStatesConsistency(crStates *tc.CRStates, crConfig *tc.CRConfig) error {
+	for cacheName, _ := range crConfig.ContentServers {
+		if _, ok := crStates.Caches[tc.CacheName(cacheName)]; !ok {
+			return fmt.Errorf("Cache %v in CRConfig but not CRStates", cacheName)
+		}
+	}
+	for cacheName, _ := range crStates.Caches {
+		if _, ok := crConfig.ContentServers[string(cacheName)]; !ok {
+			return fmt.Errorf("Cache %v in CRStates but not CRConfig", cacheName)
+		}
+	}
+	for dsName, _ := range crConfig.DeliveryServices {
+		if !hasCaches(dsName, crConfig) {
+			continue
+		}
+		if _, ok := crStates.DeliveryService[tc.DeliveryServiceName(dsName)]; !ok {
+			return fmt.Errorf("Delivery Service %v in CRConfig but not CRStates", dsName)
+		}
+	}
+	return nil
+}
+
+// AllMonitorsCRStatesConsistencyValidator is designed to be run as a goroutine, and does not return. It continously validates every `interval`, and calls `onErr` on failure, `onResumeSuccess` when a failure ceases, and `onCheck` on every poll. Note the error passed to `onErr` may be a general validation error not associated with any monitor, in which case the passed `tc.TrafficMonitorName` will be empty.
+func AllMonitorsCRStatesConsistencyValidator(
+	toClient *to.Session,
+	interval time.Duration,
+	includeOffline bool,
+	grace time.Duration,
+	onErr func(tc.TrafficMonitorName, error),
+	onResumeSuccess func(tc.TrafficMonitorName),
+	onCheck func(tc.TrafficMonitorName, error),
+) {
+	AllValidator(toClient, interval, includeOffline, grace, onErr, onResumeSuccess, onCheck, ValidateAllMonitorsCRStatesConsistency)
+}
+
+// ValidateAllMonitorsCRStatesConsistency validates, for all monitors in the given Traffic Ops, the CRStates is consistent with the CRConfig.
+func ValidateAllMonitorsCRStatesConsistency(toClient *to.Session, includeOffline bool) (map[tc.TrafficMonitorName]error, error) {
+	servers, err := GetMonitors(toClient, includeOffline)
+	if err != nil {
+		return nil, err
+	}
+
+	crConfigs := GetCRConfigs(GetCDNs(servers), toClient)
+
+	errs := map[tc.TrafficMonitorName]error{}
+	for _, server := range servers {
+		crConfig := crConfigs[tc.CDNName(server.CDNName)]
+		if err := crConfig.Err; err != nil {
+			errs[tc.TrafficMonitorName(server.HostName)] = fmt.Errorf("getting CRConfig: %v", err)
+			continue
+		}
+
+		uri := fmt.Sprintf("http://%s.%s", server.HostName, server.DomainName)
+		errs[tc.TrafficMonitorName(server.HostName)] = ValidateCRStatesConsistencyWithCRConfig(uri, crConfig.CRConfig)
+	}
+	return errs, nil
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tmcheck
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestValidateCRStatesConsistency(t *testing.T) {
+	crConfig := &tc.CRConfig{
+		ContentServers: map[string]tc.CRConfigTrafficOpsServer{
+			"edge0": {DeliveryServices: map[string][]string{"ds0": {"edge0.ds0.example.net"}}},
+			"edge1": {},
+		},
+		DeliveryServices: map[string]tc.CRConfigDeliveryService{
+			"ds0":         {},
+			"ds-no-cache": {},
+		},
+	}
+	newCRStates := func() *tc.CRStates {
+		return &tc.CRStates{
+			Caches: map[tc.CacheName]tc.IsAvailable{
+				"edge0": {IsAvailable: true},
+				"edge1": {IsAvailable: false},
+			},
+			DeliveryService: map[tc.DeliveryServiceName]tc.CRStatesDeliveryService{
+				"ds0": {IsAvailable: true},
+			},
+		}
+	}
+
+	if err := ValidateCRStatesConsistency(newCRStates(), crConfig); err != nil {
+		t.Errorf("consistent CRStates: expected nil error, actual: %v", err)
+	}
+
+	missingCache := newCRStates()
+	delete(missingCache.Caches, "edge1")
+	if err := ValidateCRStatesConsistency(missingCache, crConfig); err == nil {
+		t.Error("CRStates missing a CRConfig cache: expected error, actual: nil")
+	}
+
+	extraCache := newCRStates()
+	extraCache.Caches["edge2"] = tc.IsAvailable{}
+	if err := ValidateCRStatesConsistency(extraCache, crConfig); err == nil {
+		t.Error("CRStates with a cache not in the CRConfig: expected error, actual: nil")
+	}
+
+	missingDS := newCRStates()
+	delete(missingDS.DeliveryService, "ds0")
+	if err := ValidateCRStatesConsistency(missingDS, crConfig); err == nil {
+		t.Error("CRStates missing a CRConfig Delivery Service: expected error, actual: nil")
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+)
+
+const (
+	standaloneCDN        = "testcaches"
+	standaloneCacheGroup = "testcaches-cg"
+	standaloneProfile    = "TESTCACHES_EDGE"
+	standaloneInterface  = "bond0" // the interface served by fakesrvr
+	standaloneIP         = "127.0.0.1"
+)
+
+// writeStandaloneConfig writes a CRConfig and monitoring config describing the fake caches to dir, in the files a standalone Traffic Monitor with standalone_config_dir set to dir reads.
+// Each port is an EDGE cache named "testcache-{port}" serving every remap, and each remap is a Delivery Service named for its first label. The only Traffic Monitor is this host, which a Traffic Monitor running here recognizes as itself.
+func writeStandaloneConfig(dir string, portStart int, numPorts int, remaps []string) error {
+	monitorHostName, err := os.Hostname()
+	if err != nil {
+		return errors.New("getting hostname for the Traffic Monitor: " + err.Error())
+	}
+	crConfig, tmConfig := makeStandaloneConfig(monitorHostName, portStart, numPorts, remaps)
+
+	crConfigBytes, err := json.MarshalIndent(crConfig, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, config.StandaloneCRConfigFileName), crConfigBytes, 0644); err != nil {
+		return err
+	}
+
+	tmConfigBytes, err := json.MarshalIndent(tmConfig, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, config.StandaloneTMConfigFileName), tmConfigBytes, 0644)
+}
+
+func makeStandaloneConfig(monitorHostName string, portStart int, numPorts int, remaps []string) (tc.CRConfig, tc.TrafficMonitorConfig) {
+	cdn := standaloneCDN
+	date := time.Now().Unix()
+	monitorIP := standaloneIP
+	monitorPort := 80
+	monitorStatus := tc.CRConfigServerStatus(tc.CacheStatusOnline)
+	crConfig := tc.CRConfig{
+		ContentServers:   map[string]tc.CRConfigTrafficOpsServer{},
+		DeliveryServices: map[string]tc.CRConfigDeliveryService{},
+		Monitors: map[string]tc.CRConfigMonitor{
+			monitorHostName: {IP: &monitorIP, Port: &monitorPort, ServerStatus: &monitorStatus},
+		},
+		Stats: tc.CRConfigStats{CDNName: &cdn, DateUnixSeconds: &date},
+	}
+	tmConfig := tc.TrafficMonitorConfig{
+		TrafficMonitors: []tc.TrafficMonitor{{
+			HostName:     monitorHostName,
+			IP:           monitorIP,
+			Port:         monitorPort,
+			ServerStatus: string(tc.CacheStatusOnline),
+		}},
+		CacheGroups: []tc.TMCacheGroup{{Name: standaloneCacheGroup}},
+		Config: map[string]interface{}{
+			"peers.polling.interval":     1000,
+			"health.polling.interval":    6000,
+			"heartbeat.polling.interval": 3000,
+			"tm.polling.interval":        5000,
+		},
+		Profiles: []tc.TMProfile{{
+			Name: standaloneProfile,
+			Type: tc.CacheTypeEdge.String(),
+			Parameters: tc.TMParameters{
+				HealthConnectionTimeout: 2000,
+				HealthPollingURL:        "http://${hostname}/_astats?application=&inf.name=${interface_name}",
+				HistoryCount:            30,
+			},
+		}},
+	}
+
+	dsServers := map[string][]string{}
+	for _, remap := range remaps {
+		dsName := strings.SplitN(remap, ".", 2)[0]
+		dsServers[dsName] = []string{remap}
+		crConfig.DeliveryServices[dsName] = tc.CRConfigDeliveryService{
+			MatchSets: []*tc.MatchSet{{
+				Protocol:  "HTTP",
+				MatchList: []tc.MatchList{{Regex: remap, MatchType: "HOST"}},
+			}},
+		}
+		tmConfig.DeliveryServices = append(tmConfig.DeliveryServices, tc.TMDeliveryService{XMLID: dsName, ServerStatus: "REPORTED"})
+	}
+
+	for i := 0; i < numPorts; i++ {
+		port := portStart + i
+		hostName := "testcache-" + strconv.Itoa(port)
+		fqdn := hostName + ".example.net"
+		cacheGroup := standaloneCacheGroup
+		profile := standaloneProfile
+		cacheType := tc.CacheTypeEdge.String()
+		status := tc.CRConfigServerStatus(tc.CacheStatusReported)
+		ip := standaloneIP
+		infName := standaloneInterface
+		crConfig.ContentServers[hostName] = tc.CRConfigTrafficOpsServer{
+			CacheGroup:       &cacheGroup,
+			Fqdn:             &fqdn,
+			InterfaceName:    &infName,
+			Ip:               &ip,
+			Port:             &port,
+			Profile:          &profile,
+			ServerStatus:     &status,
+			ServerType:       &cacheType,
+			DeliveryServices: dsServers,
+		}
+		tmConfig.TrafficServers = append(tmConfig.TrafficServers, tc.TrafficServer{
+			CacheGroup:   cacheGroup,
+			FQDN:         fqdn,
+			HashID:       hostName,
+			HostName:     hostName,
+			Port:         port,
+			Profile:      profile,
+			ServerStatus: string(tc.CacheStatusReported),
+			Type:         cacheType,
+			Interfaces: []tc.ServerInterfaceInfo{{
+				Name:        infName,
+				Monitor:     true,
+				IPAddresses: []tc.ServerIPAddress{{Address: ip, ServiceAddress: true}},
+			}},
+		})
+	}
+	return crConfig, tmConfig
+}
@@ -40,6 +40,7 @@ func main() {
 	portStart := flag.Int("portStart", 40000, "Starting port in range")
 	numPorts := flag.Int("numPorts", 1000, "Number of ports to serve")
 	numRemaps := flag.Int("numRemaps", 1000, "Number of remaps to serve")
+	configDir := flag.String("configDir", "", "If set, write a CRConfig and monitoring config for the fake caches to this directory, for a standalone Traffic Monitor")
 	flag.Parse()
 	if *portStart < 0 || *portStart > 65535 {
 		fmt.Println("portStart must be 0-65535")
@@ -53,6 +54,13 @@ func main() {
 	}
 
 	remaps := makeFakeRemaps(*numRemaps)
+	if *configDir != "" {
+		if err := writeStandaloneConfig(*configDir, *portStart, *numPorts, remaps); err != nil {
+			fmt.Println("Error writing standalone Traffic Monitor config: " + err.Error())
+			return
+		}
+		fmt.Println("Wrote standalone Traffic Monitor config to " + *configDir)
+	}
 	_, err := fakesrvr.News(*portStart, *numPorts, remaps)
 	if err != nil {
 		fmt.Println("Error making FakeServers: " + err.Error())
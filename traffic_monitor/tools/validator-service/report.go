@@ -0,0 +1,177 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-nagios"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Report is the JSON status report of all checks.
+type Report struct {
+	TrafficOps string        `json:"trafficOps"`
+	Time       time.Time     `json:"time"`
+	Checks     []CheckStatus `json:"checks"`
+}
+
+// sortedMonitors returns the names of the monitors of the given check, sorted, so they're always reported in the same order.
+func sortedMonitors(status CheckStatus) []tc.TrafficMonitorName {
+	monitors := make([]tc.TrafficMonitorName, 0, len(status.Monitors))
+	for name := range status.Monitors {
+		monitors = append(monitors, name)
+	}
+	sort.Slice(monitors, func(i, j int) bool { return monitors[i] < monitors[j] })
+	return monitors
+}
+
+// nagiosReport returns the Nagios status of all checks, and the plugin output describing it. Any check error or monitor invalid for longer than the grace period is Critical, and any monitor invalid within the grace period is a Warning.
+func nagiosReport(statuses []CheckStatus) (nagios.Status, string) {
+	status := nagios.Ok
+	msgs := []string{}
+	monitorNames := map[tc.TrafficMonitorName]struct{}{}
+	for _, check := range statuses {
+		if check.Error != "" {
+			status = nagios.Critical
+			msgs = append(msgs, fmt.Sprintf("%s: %s", check.Name, check.Error))
+		}
+		for _, name := range sortedMonitors(check) {
+			monitorNames[name] = struct{}{}
+			monitor := check.Monitors[name]
+			if monitor.Valid {
+				continue
+			}
+			if monitor.Critical {
+				status = nagios.Critical
+			} else if status == nagios.Ok {
+				status = nagios.Warning
+			}
+			msgs = append(msgs, fmt.Sprintf("%s %s: %s", check.Name, name, monitor.Error))
+		}
+	}
+
+	switch status {
+	case nagios.Ok:
+		return status, fmt.Sprintf("OK - %d checks valid for %d monitors\n", len(statuses), len(monitorNames))
+	case nagios.Warning:
+		return status, fmt.Sprintf("WARNING - %d invalid within grace period\n%s\n", len(msgs), strings.Join(msgs, "\n"))
+	default:
+		return status, fmt.Sprintf("CRITICAL - %d invalid\n%s\n", len(msgs), strings.Join(msgs, "\n"))
+	}
+}
+
+// writePrometheus writes the status of all checks in the Prometheus text exposition format.
+func writePrometheus(w io.Writer, statuses []CheckStatus) {
+	boolVal := func(b bool) int {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(w, "# HELP tm_validator_check_error Whether the check failed to run, for a reason not associated with a single monitor.\n")
+	fmt.Fprintf(w, "# TYPE tm_validator_check_error gauge\n")
+	for _, check := range statuses {
+		fmt.Fprintf(w, "tm_validator_check_error{check=\"%s\"} %d\n", escapeLabel(check.Name), boolVal(check.Error != ""))
+	}
+
+	type monitorMetric struct {
+		name string
+		help string
+		val  func(MonitorStatus) string
+	}
+	metrics := []monitorMetric{
+		{
+			name: "tm_validator_monitor_valid",
+			help: "Whether the monitor passed the check the last time it was run.",
+			val:  func(m MonitorStatus) string { return fmt.Sprintf("%d", boolVal(m.Valid)) },
+		},
+		{
+			name: "tm_validator_monitor_critical",
+			help: "Whether the monitor has failed the check for longer than the grace period.",
+			val:  func(m MonitorStatus) string { return fmt.Sprintf("%d", boolVal(m.Critical)) },
+		},
+		{
+			name: "tm_validator_monitor_last_check_timestamp_seconds",
+			help: "The time the check was last run against the monitor.",
+			val:  func(m MonitorStatus) string { return fmt.Sprintf("%d", m.LastCheck.Unix()) },
+		},
+		{
+			name: "tm_validator_monitor_history_failures",
+			help: "The number of failures in the retained history of the check of the monitor.",
+			val: func(m MonitorStatus) string {
+				failures := 0
+				for _, result := range m.History {
+					if !result.Valid {
+						failures++
+					}
+				}
+				return fmt.Sprintf("%d", failures)
+			},
+		},
+	}
+	for _, metric := range metrics {
+		fmt.Fprintf(w, "# HELP %s %s\n", metric.name, metric.help)
+		fmt.Fprintf(w, "# TYPE %s gauge\n", metric.name)
+		for _, check := range statuses {
+			for _, name := range sortedMonitors(check) {
+				fmt.Fprintf(w, "%s{check=\"%s\",monitor=\"%s\"} %s\n", metric.name, escapeLabel(check.Name), escapeLabel(string(name)), metric.val(check.Monitors[name]))
+			}
+		}
+	}
+}
+
+// escapeLabel escapes the given string for use as a Prometheus label value.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// printStatus writes the given check status as an HTML table.
+func printStatus(status CheckStatus, w io.Writer) {
+	if status.Error != "" {
+		fmt.Fprintf(w, `<p><span style="color:red">Error: %s</span></p>`, html.EscapeString(status.Error))
+	}
+
+	fmt.Fprintf(w, `<table style="width:100%%">`)
+	for _, name := range sortedMonitors(status) {
+		monitor := status.Monitors[name]
+		fmt.Fprintf(w, `<tr>`)
+		fmt.Fprintf(w, `<td><span>%s</span></td>`, html.EscapeString(string(name)))
+		if monitor.Valid {
+			fmt.Fprintf(w, `<td><span style="color:limegreen">Valid</span></td>`)
+		} else if monitor.Critical {
+			fmt.Fprintf(w, `<td><span style="color:red">Invalid</span></td>`)
+		} else {
+			fmt.Fprintf(w, `<td><span style="color:orange">Invalid (within grace period)</span></td>`)
+		}
+		fmt.Fprintf(w, `<td><span>as of %v</span></td>`, monitor.LastCheck)
+		if !monitor.Valid {
+			fmt.Fprintf(w, `<td><span style="font-family:monospace">%s</span></td>`, html.EscapeString(monitor.Error))
+		}
+		fmt.Fprintf(w, `</tr>`)
+	}
+	fmt.Fprintf(w, `</table>`)
+}
@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/tmcheck"
+)
+
+// CheckResult is the result of running a check against a single monitor once.
+type CheckResult struct {
+	Time  time.Time `json:"time"`
+	Valid bool      `json:"valid"`
+	Error string    `json:"error,omitempty"`
+}
+
+// MonitorStatus is the status of a single check of a single monitor.
+type MonitorStatus struct {
+	// Valid is whether the monitor passed the check the last time it was run.
+	Valid bool `json:"valid"`
+	// Critical is whether the monitor has been invalid for longer than the grace period.
+	Critical bool `json:"critical"`
+	// LastCheck is the last time the check was run against the monitor.
+	LastCheck time.Time `json:"lastCheck"`
+	// InvalidSince is the time the monitor became invalid, or nil if it's valid.
+	InvalidSince *time.Time `json:"invalidSince,omitempty"`
+	// Error is the error of the last check, if it was invalid.
+	Error string `json:"error,omitempty"`
+	// History is the results of the most recent checks, newest first.
+	History []CheckResult `json:"history"`
+}
+
+// CheckStatus is the status of a single check, for all monitors.
+type CheckStatus struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Interval    string `json:"interval"`
+	// Error is the error running the check which isn't associated with any single monitor, such as failing to get the list of monitors from Traffic Ops.
+	Error    string                                  `json:"error,omitempty"`
+	Monitors map[tc.TrafficMonitorName]MonitorStatus `json:"monitors"`
+}
+
+// Status is the status of a check, safe for multiple goroutines. Its On* methods are the callbacks for tmcheck.AllValidator.
+type Status struct {
+	status       *CheckStatus
+	historyLimit int
+	m            *sync.RWMutex
+}
+
+// NewStatus returns a new Status for the given check, which keeps up to historyLimit results of each monitor.
+func NewStatus(check tmcheck.Check, interval time.Duration, historyLimit int) Status {
+	return Status{
+		status: &CheckStatus{
+			Name:        check.Name,
+			Description: check.Description,
+			Interval:    interval.String(),
+			Monitors:    map[tc.TrafficMonitorName]MonitorStatus{},
+		},
+		historyLimit: historyLimit,
+		m:            &sync.RWMutex{},
+	}
+}
+
+// OnErr is called when a monitor has been invalid for longer than the grace period, or with an empty name when the check itself fails.
+func (s Status) OnErr(name tc.TrafficMonitorName, err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if name == "" {
+		s.status.Error = strings.TrimSpace(err.Error())
+		return
+	}
+	monitor := s.status.Monitors[name]
+	monitor.Critical = true
+	s.status.Monitors[name] = monitor
+}
+
+// OnResumeSuccess is called when a monitor which was invalid becomes valid, or with an empty name when the check itself stops failing.
+func (s Status) OnResumeSuccess(name tc.TrafficMonitorName) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if name == "" {
+		s.status.Error = ""
+		return
+	}
+	monitor, ok := s.status.Monitors[name]
+	if !ok {
+		return
+	}
+	monitor.Critical = false
+	s.status.Monitors[name] = monitor
+}
+
+// OnCheck is called every time the check is run against a monitor.
+func (s Status) OnCheck(name tc.TrafficMonitorName, err error) {
+	now := time.Now()
+	result := CheckResult{Time: now, Valid: err == nil}
+	if err != nil {
+		result.Error = strings.TrimSpace(err.Error())
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	monitor := s.status.Monitors[name]
+	monitor.Valid = result.Valid
+	monitor.LastCheck = now
+	monitor.Error = result.Error
+	if result.Valid {
+		monitor.InvalidSince = nil
+		monitor.Critical = false
+	} else if monitor.InvalidSince == nil {
+		monitor.InvalidSince = &now
+	}
+
+	history := make([]CheckResult, 0, s.historyLimit+1)
+	history = append(history, result)
+	history = append(history, monitor.History...)
+	if len(history) > s.historyLimit {
+		history = history[:s.historyLimit]
+	}
+	monitor.History = history
+	s.status.Monitors[name] = monitor
+}
+
+// Prune removes the statuses of monitors which aren't in the given validation results, so monitors removed from Traffic Ops or taken offline stop being reported.
+func (s Status) Prune(results map[tc.TrafficMonitorName]error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for name := range s.status.Monitors {
+		if _, ok := results[name]; !ok {
+			delete(s.status.Monitors, name)
+		}
+	}
+}
+
+// Get returns a copy of the check status.
+func (s Status) Get() CheckStatus {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	status := *s.status
+	status.Monitors = make(map[tc.TrafficMonitorName]MonitorStatus, len(s.status.Monitors))
+	for name, monitor := range s.status.Monitors {
+		// History is never modified in place, so it's safe to share
+		status.Monitors[name] = monitor
+	}
+	return status
+}
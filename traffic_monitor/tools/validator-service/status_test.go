@@ -0,0 +1,144 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-nagios"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/tmcheck"
+)
+
+func TestStatus(t *testing.T) {
+	status := NewStatus(tmcheck.Check{Name: "test-check"}, time.Second, 2)
+
+	status.OnCheck("tm0", nil)
+	status.OnCheck("tm1", errors.New("bad things\n"))
+	if nagiosStatus, msg := nagiosReport([]CheckStatus{status.Get()}); nagiosStatus != nagios.Warning {
+		t.Errorf("monitor invalid within grace period: expected Nagios Warning, actual: %v '%s'", nagiosStatus, msg)
+	}
+
+	status.OnErr("tm1", errors.New("invalid state for 1m: bad things"))
+	status.OnCheck("tm1", errors.New("bad things"))
+	checkStatus := status.Get()
+	tm1 := checkStatus.Monitors["tm1"]
+	if tm1.Valid || !tm1.Critical || tm1.InvalidSince == nil {
+		t.Errorf("monitor invalid past grace period: expected invalid, critical, with invalidSince, actual: %+v", tm1)
+	}
+	if tm1.Error != "bad things" {
+		t.Errorf("expected error 'bad things', actual: '%s'", tm1.Error)
+	}
+	if len(tm1.History) != 2 {
+		t.Errorf("expected history limited to 2, actual: %d", len(tm1.History))
+	}
+	if nagiosStatus, msg := nagiosReport([]CheckStatus{checkStatus}); nagiosStatus != nagios.Critical || !strings.Contains(msg, "test-check tm1: bad things") {
+		t.Errorf("monitor invalid past grace period: expected Nagios Critical with message, actual: %v '%s'", nagiosStatus, msg)
+	}
+
+	buf := &bytes.Buffer{}
+	writePrometheus(buf, []CheckStatus{checkStatus})
+	for _, expected := range []string{
+		`tm_validator_check_error{check="test-check"} 0`,
+		`tm_validator_monitor_valid{check="test-check",monitor="tm0"} 1`,
+		`tm_validator_monitor_valid{check="test-check",monitor="tm1"} 0`,
+		`tm_validator_monitor_critical{check="test-check",monitor="tm1"} 1`,
+		`tm_validator_monitor_history_failures{check="test-check",monitor="tm1"} 2`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("expected Prometheus metrics to contain '%s', actual:\n%s", expected, buf.String())
+		}
+	}
+
+	status.OnResumeSuccess("tm1")
+	status.OnCheck("tm1", nil)
+	tm1 = status.Get().Monitors["tm1"]
+	if !tm1.Valid || tm1.Critical || tm1.InvalidSince != nil {
+		t.Errorf("monitor resumed success: expected valid, not critical, without invalidSince, actual: %+v", tm1)
+	}
+
+	status.OnErr("", errors.New("getting monitors from Traffic Ops: timeout"))
+	if nagiosStatus, _ := nagiosReport([]CheckStatus{status.Get()}); nagiosStatus != nagios.Critical {
+		t.Errorf("check error: expected Nagios Critical, actual: %v", nagiosStatus)
+	}
+	status.OnResumeSuccess("")
+	if nagiosStatus, msg := nagiosReport([]CheckStatus{status.Get()}); nagiosStatus != nagios.Ok {
+		t.Errorf("all valid: expected Nagios Ok, actual: %v '%s'", nagiosStatus, msg)
+	}
+}
+
+func TestStatusPrune(t *testing.T) {
+	status := NewStatus(tmcheck.Check{Name: "test-check"}, time.Second, 2)
+	status.OnCheck("tm0", nil)
+	status.OnCheck("tm1", errors.New("bad things"))
+	status.OnErr("tm1", errors.New("invalid state for 1m: bad things"))
+
+	status.Prune(map[tc.TrafficMonitorName]error{"tm0": nil})
+	checkStatus := status.Get()
+	if _, ok := checkStatus.Monitors["tm1"]; ok {
+		t.Errorf("expected monitor no longer in Traffic Ops to be pruned, actual: %+v", checkStatus.Monitors)
+	}
+	if _, ok := checkStatus.Monitors["tm0"]; !ok {
+		t.Errorf("expected monitor still in Traffic Ops to be kept, actual: %+v", checkStatus.Monitors)
+	}
+	if nagiosStatus, msg := nagiosReport([]CheckStatus{checkStatus}); nagiosStatus != nagios.Ok {
+		t.Errorf("pruned critical monitor: expected Nagios Ok, actual: %v '%s'", nagiosStatus, msg)
+	}
+}
+
+func TestSelectChecks(t *testing.T) {
+	intervals, err := parseIntervals("peer-poller=10s, query-interval=1m")
+	if err != nil {
+		t.Fatalf("parsing intervals: unexpected error: %v", err)
+	}
+	if intervals[tmcheck.CheckPeerPoller] != 10*time.Second || intervals[tmcheck.CheckQueryInterval] != time.Minute {
+		t.Errorf("expected peer-poller 10s and query-interval 1m, actual: %v", intervals)
+	}
+	if _, err := parseIntervals("peer-poller"); err == nil {
+		t.Error("parsing interval without duration: expected error, actual: nil")
+	}
+
+	checks, err := selectChecks("", intervals)
+	if err != nil {
+		t.Fatalf("selecting all checks: unexpected error: %v", err)
+	}
+	if len(checks) != len(tmcheck.Checks()) {
+		t.Errorf("selecting all checks: expected %d, actual: %d", len(tmcheck.Checks()), len(checks))
+	}
+
+	checks, err = selectChecks(tmcheck.CheckPeerPoller+","+tmcheck.CheckOfflineStates, nil)
+	if err != nil {
+		t.Fatalf("selecting checks: unexpected error: %v", err)
+	}
+	if len(checks) != 2 || checks[0].Name != tmcheck.CheckOfflineStates || checks[1].Name != tmcheck.CheckPeerPoller {
+		t.Errorf("selecting checks: expected [%s %s] in report order, actual: %v", tmcheck.CheckOfflineStates, tmcheck.CheckPeerPoller, checks)
+	}
+
+	if _, err := selectChecks("nonexistent", nil); err == nil {
+		t.Error("selecting unknown check: expected error, actual: nil")
+	}
+	if _, err := selectChecks("", map[string]time.Duration{"nonexistent": time.Second}); err == nil {
+		t.Error("interval for unknown check: expected error, actual: nil")
+	}
+}
@@ -17,137 +17,142 @@
  * under the License.
  */
 
-// validate-offline is a utility HTTP service which polls the given Traffic Monitor and validates that no OFFLINE or ADMIN_DOWN caches in the Traffic Ops CRConfig are marked Available in Traffic Monitor's CRstates endpoint.
+// validator-service is a utility HTTP service which continuously runs all the tmcheck checks against all the Traffic Monitors in the given Traffic Ops, each on its own schedule, and serves the current status and recent history of each as an HTML page, JSON, Prometheus metrics, and Nagios plugin output.
 
 package main
 
 import (
 	"flag"
 	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-nagios"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_monitor/tmcheck"
 	to "github.com/apache/trafficcontrol/traffic_ops/v2-client"
-	"io"
-	"net/http"
-	"sort"
-	"sync"
-	"time"
+
+	"github.com/json-iterator/go"
 )
 
 const UserAgent = "tm-offline-validator/0.1"
 
-const LogLimit = 10
-
-type Log struct {
-	log       *[]string
-	limit     int
-	errored   *bool
-	lastCheck *time.Time
-	m         *sync.RWMutex
-}
+// DefaultHistoryLimit is the default number of results of each check of each monitor to keep.
+const DefaultHistoryLimit = 10
 
-func (l *Log) Add(msg string) {
-	l.m.Lock()
-	defer l.m.Unlock()
-	*l.log = append([]string{msg}, *l.log...)
-	if len(*l.log) > l.limit {
-		*l.log = (*l.log)[:l.limit]
+func startValidator(check tmcheck.Check, toClient *to.Session, interval time.Duration, includeOffline bool, grace time.Duration, historyLimit int) Status {
+	status := NewStatus(check, interval, historyLimit)
+	validate := func(toClient *to.Session, includeOffline bool) (map[tc.TrafficMonitorName]error, error) {
+		results, err := check.Validate(toClient, includeOffline)
+		if err == nil {
+			// results has an entry for every monitor from Traffic Ops, so anything else is stale
+			status.Prune(results)
+		}
+		return results, err
 	}
+	go tmcheck.AllValidator(toClient, interval, includeOffline, grace, status.OnErr, status.OnResumeSuccess, status.OnCheck, validate)
+	return status
 }
 
-func (l *Log) Get() []string {
-	l.m.RLock()
-	defer l.m.RUnlock()
-	return *l.log
-}
-
-func (l *Log) GetErrored() (bool, time.Time) {
-	l.m.RLock()
-	defer l.m.RUnlock()
-	return *l.errored, *l.lastCheck
-}
-
-func (l *Log) SetErrored(e bool) {
-	l.m.Lock()
-	defer l.m.Unlock()
-	*l.errored = e
-	*l.lastCheck = time.Now()
-}
-
-func NewLog() Log {
-	log := make([]string, 0, LogLimit+1)
-	errored := false
-	limit := LogLimit
-	lastCheck := time.Time{}
-	return Log{log: &log, errored: &errored, m: &sync.RWMutex{}, limit: limit, lastCheck: &lastCheck}
-}
-
-type Logs struct {
-	logs map[tc.TrafficMonitorName]Log
-	m    *sync.RWMutex
-}
-
-func NewLogs() Logs {
-	return Logs{logs: map[tc.TrafficMonitorName]Log{}, m: &sync.RWMutex{}}
-}
-
-func (l Logs) Get(name tc.TrafficMonitorName) Log {
-	l.m.Lock()
-	defer l.m.Unlock()
-	if _, ok := l.logs[name]; !ok {
-		l.logs[name] = NewLog()
+// parseIntervals parses a comma-delimited list of check=duration pairs, e.g. "peer-poller=10s,query-interval=1m".
+func parseIntervals(s string) (map[string]time.Duration, error) {
+	intervals := map[string]time.Duration{}
+	if s == "" {
+		return intervals, nil
 	}
-	return l.logs[name]
-}
-
-func (l Logs) GetMonitors() []string {
-	l.m.RLock()
-	defer l.m.RUnlock()
-	monitors := []string{}
-	for name, _ := range l.logs {
-		monitors = append(monitors, string(name))
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("malformed interval '%s', expected check=duration", pair)
+		}
+		interval, err := time.ParseDuration(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("malformed interval for check '%s': %v", kv[0], err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("interval for check '%s' must be positive", kv[0])
+		}
+		intervals[kv[0]] = interval
 	}
-	return monitors
+	return intervals, nil
 }
 
-func startValidator(validator tmcheck.AllValidatorFunc, toClient *to.Session, interval time.Duration, includeOffline bool, grace time.Duration) Logs {
-	logs := NewLogs()
-
-	onErr := func(name tc.TrafficMonitorName, err error) {
-		log := logs.Get(name)
-		log.Add(fmt.Sprintf("%v ERROR %v\n", time.Now(), err))
-		log.SetErrored(true)
+// selectChecks returns the checks with the given comma-delimited names, in report order, or all checks if names is empty. It returns an error if any name or interval name isn't a known check.
+func selectChecks(names string, intervals map[string]time.Duration) ([]tmcheck.Check, error) {
+	allChecks := tmcheck.Checks()
+	known := map[string]struct{}{}
+	for _, check := range allChecks {
+		known[check.Name] = struct{}{}
 	}
-
-	onResumeSuccess := func(name tc.TrafficMonitorName) {
-		log := logs.Get(name)
-		log.Add(fmt.Sprintf("%v INFO State Valid\n", time.Now()))
-		log.SetErrored(false)
+	for name := range intervals {
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("interval given for unknown check '%s'", name)
+		}
 	}
-
-	onCheck := func(name tc.TrafficMonitorName, err error) {
-		log := logs.Get(name)
-		log.SetErrored(err != nil)
+	if names == "" {
+		return allChecks, nil
 	}
 
-	go validator(toClient, interval, includeOffline, grace, onErr, onResumeSuccess, onCheck)
-	return logs
+	selected := map[string]struct{}{}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("unknown check '%s'", name)
+		}
+		selected[name] = struct{}{}
+	}
+	checks := []tmcheck.Check{}
+	for _, check := range allChecks {
+		if _, ok := selected[check.Name]; ok {
+			checks = append(checks, check)
+		}
+	}
+	return checks, nil
 }
 
 func main() {
 	toURI := flag.String("to", "", "The Traffic Ops URI, whose CRConfig to validate")
 	toUser := flag.String("touser", "", "The Traffic Ops user")
 	toPass := flag.String("topass", "", "The Traffic Ops password")
-	interval := flag.Duration("interval", time.Second*time.Duration(5), "The interval to validate")
+	interval := flag.Duration("interval", time.Second*time.Duration(5), "The default interval to validate")
+	intervalsStr := flag.String("intervals", "", "Comma-delimited check=interval pairs to override the default interval of individual checks, e.g. peer-poller=10s,query-interval=1m")
+	checksStr := flag.String("checks", "", "Comma-delimited names of the checks to run; all checks if empty")
 	grace := flag.Duration("grace", time.Second*time.Duration(30), "The grace period before invalid states are reported")
+	historyLimit := flag.Int("history", DefaultHistoryLimit, "The number of results of each check of each monitor to keep")
 	includeOffline := flag.Bool("includeOffline", false, "Whether to include Offline Monitors")
+	addr := flag.String("addr", ":80", "The address to serve on")
+	listChecks := flag.Bool("list", false, "List the available checks, and exit")
 	help := flag.Bool("help", false, "Usage info")
 	helpBrief := flag.Bool("h", false, "Usage info")
 	flag.Parse()
 	if *help || *helpBrief {
-		fmt.Printf("Usage: go run validate-offline -to https://traffic-ops.example.net -touser bill -topass thelizard -tm http://traffic-monitor.example.net -interval 5s -grace 30s -includeOffline true\n")
+		fmt.Printf("Usage: go run validator-service -to https://traffic-ops.example.net -touser bill -topass thelizard -interval 5s -intervals query-interval=1m -grace 30s -includeOffline true\n")
 		return
 	}
+	if *listChecks {
+		for _, check := range tmcheck.Checks() {
+			fmt.Printf("%s\t%s\n", check.Name, check.Description)
+		}
+		return
+	}
+	if *historyLimit < 1 {
+		fmt.Printf("Error: history must be at least 1\n")
+		os.Exit(1)
+	}
+
+	intervals, err := parseIntervals(*intervalsStr)
+	if err != nil {
+		fmt.Printf("Error parsing intervals: %v\n", err)
+		os.Exit(1)
+	}
+	checks, err := selectChecks(*checksStr, intervals)
+	if err != nil {
+		fmt.Printf("Error selecting checks: %v\n", err)
+		os.Exit(1)
+	}
 
 	toClient, _, err := to.LoginWithAgent(*toURI, *toUser, *toPass, true, UserAgent, false, tmcheck.RequestTimeout)
 	if err != nil {
@@ -155,52 +160,29 @@ func main() {
 		return
 	}
 
-	crStatesOfflineLogs := startValidator(tmcheck.AllMonitorsCRStatesOfflineValidator, toClient, *interval, *includeOffline, *grace)
-	peerPollerLogs := startValidator(tmcheck.PeerPollersAllValidator, toClient, *interval, *includeOffline, *grace)
-	dsStatsLogs := startValidator(tmcheck.AllMonitorsDSStatsValidator, toClient, *interval, *includeOffline, *grace)
-	queryIntervalLogs := startValidator(tmcheck.AllMonitorsQueryIntervalValidator, toClient, *interval, *includeOffline, *grace)
+	statuses := []Status{}
+	for _, check := range checks {
+		checkInterval, ok := intervals[check.Name]
+		if !ok {
+			checkInterval = *interval
+		}
+		statuses = append(statuses, startValidator(check, toClient, checkInterval, *includeOffline, *grace, *historyLimit))
+	}
 
-	if err := serve(*toURI, crStatesOfflineLogs, peerPollerLogs, dsStatsLogs, queryIntervalLogs); err != nil {
+	if err := serve(*addr, *toURI, statuses); err != nil {
 		fmt.Printf("Serve error: %v\n", err)
 	}
 }
 
-func printLogs(logs Logs, w io.Writer) {
-	fmt.Fprintf(w, `<table style="width:100%%">`)
-
-	monitors := logs.GetMonitors()
-	sort.Strings(monitors) // sort, so they're always in the same order in the webpage
-	for _, monitor := range monitors {
-		fmt.Fprintf(w, `</tr>`)
-
-		log := logs.Get(tc.TrafficMonitorName(monitor))
-
-		fmt.Fprintf(w, `<td><span>%s</span></td>`, monitor)
-		errored, lastCheck := log.GetErrored()
-		if errored {
-			fmt.Fprintf(w, `<td><span style="color:red">Invalid</span></td>`)
-		} else {
-			fmt.Fprintf(w, `<td><span style="color:limegreen">Valid</span></td>`)
-		}
-		fmt.Fprintf(w, `<td><span>as of %v</span></td>`, lastCheck)
-
-		if errored {
-			fmt.Fprintf(w, `<td><span style="font-family:monospace">`)
-			logCopy := log.Get()
-			firstMsg := ""
-			if len(logCopy) > 0 {
-				firstMsg = logCopy[0]
-			}
-			fmt.Fprintf(w, "%s\n", firstMsg)
-			fmt.Fprintf(w, `</span></td>`)
-		}
-
-		fmt.Fprintf(w, `</tr>`)
+func getStatuses(statuses []Status) []CheckStatus {
+	checkStatuses := make([]CheckStatus, 0, len(statuses))
+	for _, status := range statuses {
+		checkStatuses = append(checkStatuses, status.Get())
 	}
-	fmt.Fprintf(w, `</table>`)
+	return checkStatuses
 }
 
-func serve(toURI string, crStatesOfflineLogs Logs, peerPollerLogs Logs, dsStatsLogs Logs, queryIntervalLogs Logs) error {
+func serve(addr string, toURI string, statuses []Status) error {
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Content-Type", "text/html")
@@ -208,7 +190,7 @@ func serve(toURI string, crStatesOfflineLogs Logs, peerPollerLogs Logs, dsStatsL
 <meta http-equiv="refresh" content="5">
 <meta charset="utf-8">
 <meta name="viewport" content="width=device-width, initial-scale=1">
-<title>Traffic Monitor Offline Validator</title>
+<title>Traffic Monitor Validator</title>
 <style type="text/css">body{margin:40px auto;line-height:1.6;font-size:18px;color:#444;padding:0 8px 0 8px}h1,h2,h3{line-height:1.2}span{padding:0px 4px 0px 4px;}</style>`)
 
 		fmt.Fprintf(w, `<h1>Traffic Monitor Validator</h1>`)
@@ -216,21 +198,39 @@ func serve(toURI string, crStatesOfflineLogs Logs, peerPollerLogs Logs, dsStatsL
 		fmt.Fprintf(w, `<p>%s`, toURI)
 		fmt.Fprintf(w, `<p>%s`, time.Now())
 
-		fmt.Fprintf(w, `<h2>CRStates Offline</h2>`)
-		fmt.Fprintf(w, `<h3>validates all OFFLINE and ADMIN_DOWN caches in the CRConfig are Unavailable</h3>`)
-		printLogs(crStatesOfflineLogs, w)
+		for _, status := range getStatuses(statuses) {
+			fmt.Fprintf(w, `<h2>%s</h2>`, status.Name)
+			fmt.Fprintf(w, `<h3>%s, every %s</h3>`, status.Description, status.Interval)
+			printStatus(status, w)
+		}
+	})
 
-		fmt.Fprintf(w, `<h2>Peer Poller</h2>`)
-		fmt.Fprintf(w, `<h3>validates all peers in the CRConfig have been polled within the last %v</h3>`, tmcheck.PeerPollMax)
-		printLogs(peerPollerLogs, w)
+	http.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
+		report := Report{TrafficOps: toURI, Time: time.Now(), Checks: getStatuses(statuses)}
+		bts, err := jsoniter.ConfigFastest.Marshal(report)
+		if err != nil {
+			fmt.Printf("Error marshalling status report: %v\n", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Content-Type", rfc.ApplicationJSON)
+		w.Write(bts)
+	})
 
-		fmt.Fprintf(w, `<h2>Delivery Services</h2>`)
-		fmt.Fprintf(w, `<h3>validates all Delivery Services in the CRConfig exist in DsStats</h3>`)
-		printLogs(dsStatsLogs, w)
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writePrometheus(w, getStatuses(statuses))
+	})
 
-		fmt.Fprintf(w, `<h2>Query Interval</h2>`)
-		fmt.Fprintf(w, `<h3>validates all Monitors' Query Interval (95th percentile) is less than %v</h3>`, tmcheck.QueryIntervalMax)
-		printLogs(queryIntervalLogs, w)
+	http.HandleFunc("/nagios", func(w http.ResponseWriter, r *http.Request) {
+		status, msg := nagiosReport(getStatuses(statuses))
+		w.Header().Set("Content-Type", rfc.ContentTypeTextPlain)
+		if status == nagios.Critical {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		fmt.Fprint(w, msg)
 	})
-	return http.ListenAndServe(":80", nil)
+
+	return http.ListenAndServe(addr, nil)
 }
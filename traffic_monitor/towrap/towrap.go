@@ -194,10 +194,25 @@ type TrafficOpsSessionThreadsafe struct {
 	lastCRConfig       ByteMapCache
 	crConfigHist       CRConfigHistoryThreadsafe
 	useLegacy          bool
+	standalone         *standaloneConfig
 	CRConfigBackupFile string
 	TMConfigBackupFile string
 }
 
+// standaloneConfig holds the CRConfig and monitoring config served by a
+// standalone session, in place of Traffic Ops.
+type standaloneConfig struct {
+	crConfig []byte
+	tmConfig []byte
+	m        *sync.RWMutex
+}
+
+func (c *standaloneConfig) get() ([]byte, []byte) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.crConfig, c.tmConfig
+}
+
 // NewTrafficOpsSessionThreadsafe returns a new threadsafe
 // TrafficOpsSessionThreadsafe wrapping the given `Session`.
 func NewTrafficOpsSessionThreadsafe(s *client.Session, ls *legacyClient.Session, histLimit uint64, cfg config.Config) TrafficOpsSessionThreadsafe {
@@ -213,9 +228,54 @@ func NewTrafficOpsSessionThreadsafe(s *client.Session, ls *legacyClient.Session,
 	}
 }
 
+// NewStandaloneTrafficOpsSessionThreadsafe returns a new
+// TrafficOpsSessionThreadsafe which never contacts Traffic Ops. Instead, it
+// serves the CRConfig and monitoring config given to SetStandaloneCRConfig and
+// SetStandaloneMonitoringConfig.
+func NewStandaloneTrafficOpsSessionThreadsafe(histLimit uint64, cfg config.Config) TrafficOpsSessionThreadsafe {
+	s := NewTrafficOpsSessionThreadsafe(nil, nil, histLimit, cfg)
+	s.standalone = &standaloneConfig{m: &sync.RWMutex{}}
+	return s
+}
+
+// Standalone tells whether or not the TrafficOpsSessionThreadsafe serves its
+// configuration from local files, rather than from Traffic Ops.
+func (s TrafficOpsSessionThreadsafe) Standalone() bool {
+	return s.standalone != nil
+}
+
+// SetStandaloneCRConfig sets the raw CRConfig served by a standalone session.
+// It is an error to call this on a session which is not standalone.
+func (s TrafficOpsSessionThreadsafe) SetStandaloneCRConfig(crConfig []byte) error {
+	if s.standalone == nil {
+		return errors.New("setting CRConfig on a session which is not standalone")
+	}
+	s.standalone.m.Lock()
+	s.standalone.crConfig = crConfig
+	s.standalone.m.Unlock()
+	return nil
+}
+
+// SetStandaloneMonitoringConfig sets the raw monitoring config served by a
+// standalone session. It is an error to call this on a session which is not
+// standalone.
+func (s TrafficOpsSessionThreadsafe) SetStandaloneMonitoringConfig(tmConfig []byte) error {
+	if s.standalone == nil {
+		return errors.New("setting monitoring config on a session which is not standalone")
+	}
+	s.standalone.m.Lock()
+	s.standalone.tmConfig = tmConfig
+	s.standalone.m.Unlock()
+	return nil
+}
+
 // Initialized tells whether or not the TrafficOpsSessionThreadsafe has been
-// properly initialized (by calling 'Update').
+// properly initialized (by calling 'Update'). Standalone sessions are always
+// initialized.
 func (s TrafficOpsSessionThreadsafe) Initialized() bool {
+	if s.standalone != nil {
+		return true
+	}
 	if s.useLegacy {
 		return s.legacySession != nil && *s.legacySession != nil
 	}
@@ -224,7 +284,8 @@ func (s TrafficOpsSessionThreadsafe) Initialized() bool {
 
 // Update updates the TrafficOpsSessionThreadsafe's connection information with
 // the provided information. It's safe for calling by multiple goroutines, being
-// aware that they will race.
+// aware that they will race. Standalone sessions never log in, so this does
+// nothing for them.
 func (s *TrafficOpsSessionThreadsafe) Update(
 	url string,
 	username string,
@@ -237,6 +298,9 @@ func (s *TrafficOpsSessionThreadsafe) Update(
 	if s == nil {
 		return errors.New("cannot update nil session")
 	}
+	if s.standalone != nil {
+		return nil
+	}
 	s.m.Lock()
 	defer s.m.Unlock()
 
@@ -335,7 +399,13 @@ func (s TrafficOpsSessionThreadsafe) CRConfigRaw(cdn string) ([]byte, error) {
 	var err error
 	var data []byte
 
-	if s.useLegacy {
+	if s.standalone != nil {
+		data, _ = s.standalone.get()
+		if data == nil {
+			return nil, errors.New("no standalone CRConfig has been loaded")
+		}
+		remoteAddr = localHostIP
+	} else if s.useLegacy {
 		ss := s.getLegacy()
 		if ss == nil {
 			return nil, ErrNilSession
@@ -359,7 +429,9 @@ func (s TrafficOpsSessionThreadsafe) CRConfigRaw(cdn string) ([]byte, error) {
 		}
 	}
 
-	if err == nil {
+	if s.standalone != nil {
+		// the standalone config files are the source of truth; there's nothing to back up or fall back to.
+	} else if err == nil {
 		ioutil.WriteFile(s.CRConfigBackupFile, data, 0644)
 	} else {
 		if s.BackupFileExists() {
@@ -451,6 +523,10 @@ func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc
 	var configMap *tc.TrafficMonitorConfigMap
 	var err error
 
+	if s.standalone != nil {
+		return s.standaloneTrafficMonitorConfigMapRaw()
+	}
+
 	if s.useLegacy {
 		config, err = s.fetchLegacyTMConfig(cdn)
 	} else {
@@ -497,6 +573,21 @@ func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc
 	return configMap, err
 }
 
+// standaloneTrafficMonitorConfigMapRaw returns the Traffic Monitor config map
+// from the monitoring config of a standalone session.
+func (s TrafficOpsSessionThreadsafe) standaloneTrafficMonitorConfigMapRaw() (*tc.TrafficMonitorConfigMap, error) {
+	_, data := s.standalone.get()
+	if data == nil {
+		return nil, errors.New("no standalone monitoring config has been loaded")
+	}
+	json := jsoniter.ConfigFastest
+	var tmConfig tc.TrafficMonitorConfig
+	if err := json.Unmarshal(data, &tmConfig); err != nil {
+		return nil, errors.New("unmarshalling standalone monitoring config: " + err.Error())
+	}
+	return tc.TrafficMonitorTransformToMap(&tmConfig)
+}
+
 // TrafficMonitorConfigMap returns the Traffic Monitor config map from the
 // Traffic Ops. This is safe for multiple goroutines.
 func (s TrafficOpsSessionThreadsafe) TrafficMonitorConfigMap(cdn string) (*tc.TrafficMonitorConfigMap, error) {
@@ -609,11 +700,16 @@ func (s TrafficOpsSessionThreadsafe) fetchLegacyServerByHostname(hostName string
 
 // MonitorCDN returns the name of the CDN of a Traffic Monitor with the given
 // hostName.
+//
+// Standalone sessions return the CDN of their CRConfig, since they have no
+// Traffic Ops to look up the server in.
 func (s TrafficOpsSessionThreadsafe) MonitorCDN(hostName string) (string, error) {
 	var server tc.ServerV30
 	var err error
 
-	if s.useLegacy {
+	if s.standalone != nil {
+		return s.standaloneCDN()
+	} else if s.useLegacy {
 		server, err = s.fetchLegacyServerByHostname(hostName)
 	} else {
 		server, err = s.fetchServerByHostname(hostName)
@@ -628,6 +724,24 @@ func (s TrafficOpsSessionThreadsafe) MonitorCDN(hostName string) (string, error)
 	return *server.CDNName, nil
 }
 
+// standaloneCDN returns the name of the CDN of the CRConfig of a standalone
+// session.
+func (s TrafficOpsSessionThreadsafe) standaloneCDN() (string, error) {
+	data, _ := s.standalone.get()
+	if data == nil {
+		return "", errors.New("getting monitor CDN: no standalone CRConfig has been loaded")
+	}
+	crc := tc.CRConfig{}
+	json := jsoniter.ConfigFastest
+	if err := json.Unmarshal(data, &crc); err != nil {
+		return "", errors.New("getting monitor CDN: unmarshalling standalone CRConfig: " + err.Error())
+	}
+	if crc.Stats.CDNName == nil {
+		return "", errors.New("getting monitor CDN: standalone CRConfig.Stats.CDN missing")
+	}
+	return *crc.Stats.CDNName, nil
+}
+
 // CreateMonitorConfig modifies the passed TrafficMonitorConfigMap to add the
 // Traffic Monitors and Delivery Services found in a CDN Snapshot, and wipe out
 // all of those that already existed in the configuration map.
@@ -0,0 +1,134 @@
+package towrap
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+)
+
+const testStandaloneCRConfig = `{
+	"stats": {"CDN_name": "cdn0", "date": 1600000000},
+	"monitors": {"tm0": {"fqdn": "tm0.test", "ip": "192.0.2.1", "port": 80, "profile": "TM", "location": "cg0", "status": "ONLINE"}},
+	"deliveryServices": {"ds0": {}, "ds1": {}}
+}`
+
+const testStandaloneMonitoringConfig = `{
+	"trafficServers": [{"hostName": "edge0", "cacheGroup": "cg0", "profile": "EDGE", "type": "EDGE", "status": "REPORTED", "interfaces": []}],
+	"cacheGroups": [{"name": "cg0", "coordinates": {"latitude": 0, "longitude": 0}}],
+	"config": {"peers.polling.interval": 1000, "health.polling.interval": 1000},
+	"trafficMonitors": [{"hostName": "tm-stale", "status": "ONLINE"}],
+	"deliveryServices": [{"xmlId": "ds0", "status": "REPORTED", "TotalKbpsThreshold": 1000}],
+	"profiles": [{"name": "EDGE", "type": "EDGE", "parameters": {}}]
+}`
+
+func TestStandaloneSessionNotStandalone(t *testing.T) {
+	s := NewTrafficOpsSessionThreadsafe(nil, nil, 5, config.Config{})
+	if s.Standalone() {
+		t.Error("expected a Traffic Ops session not to be standalone")
+	}
+	if err := s.SetStandaloneCRConfig([]byte(testStandaloneCRConfig)); err == nil {
+		t.Error("setting the standalone CRConfig of a Traffic Ops session: expected error, actual: nil")
+	}
+	if err := s.SetStandaloneMonitoringConfig([]byte(testStandaloneMonitoringConfig)); err == nil {
+		t.Error("setting the standalone monitoring config of a Traffic Ops session: expected error, actual: nil")
+	}
+}
+
+func TestStandaloneSession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "towrap-test")
+	if err != nil {
+		t.Fatalf("creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	backupFile := filepath.Join(dir, "crconfig.backup")
+
+	s := NewStandaloneTrafficOpsSessionThreadsafe(5, config.Config{CRConfigBackupFile: backupFile})
+	if !s.Standalone() || !s.Initialized() {
+		t.Error("expected a standalone session to be standalone and initialized")
+	}
+	if err := s.Update("https://to.test", "user", "pass", true, "ua", false, 0); err != nil {
+		t.Errorf("updating a standalone session: unexpected error: %v", err)
+	}
+	if _, err := s.CRConfigRaw("cdn0"); err == nil {
+		t.Error("getting the CRConfig before it's loaded: expected error, actual: nil")
+	}
+	if _, err := s.TrafficMonitorConfigMap("cdn0"); err == nil {
+		t.Error("getting the monitoring config before it's loaded: expected error, actual: nil")
+	}
+
+	if err := s.SetStandaloneCRConfig([]byte(testStandaloneCRConfig)); err != nil {
+		t.Fatalf("setting the standalone CRConfig: unexpected error: %v", err)
+	}
+	if err := s.SetStandaloneMonitoringConfig([]byte(testStandaloneMonitoringConfig)); err != nil {
+		t.Fatalf("setting the standalone monitoring config: unexpected error: %v", err)
+	}
+
+	crConfig, err := s.CRConfigRaw("cdn0")
+	if err != nil {
+		t.Fatalf("getting the CRConfig: unexpected error: %v", err)
+	}
+	if string(crConfig) != testStandaloneCRConfig {
+		t.Errorf("expected the CRConfig to be the standalone CRConfig, actual: %s", crConfig)
+	}
+	if _, err := os.Stat(backupFile); !os.IsNotExist(err) {
+		t.Errorf("expected a standalone session not to write a CRConfig backup file, actual stat error: %v", err)
+	}
+	if hist := s.CRConfigHistory(); len(hist) != 1 || hist[0].ReqAddr != localHostIP || hist[0].Err != nil {
+		t.Errorf("expected one successful CRConfig history entry from %s, actual: %+v", localHostIP, hist)
+	}
+
+	if cdn, err := s.MonitorCDN("tm0"); err != nil {
+		t.Errorf("getting the monitor CDN: unexpected error: %v", err)
+	} else if cdn != "cdn0" {
+		t.Errorf("expected the monitor CDN to be the standalone CRConfig's CDN 'cdn0', actual: '%s'", cdn)
+	}
+
+	mc, err := s.TrafficMonitorConfigMap("cdn0")
+	if err != nil {
+		t.Fatalf("getting the monitoring config: unexpected error: %v", err)
+	}
+	if _, ok := mc.TrafficServer["edge0"]; !ok || len(mc.TrafficServer) != 1 {
+		t.Errorf("expected the monitoring config's cache server edge0, actual: %+v", mc.TrafficServer)
+	}
+	if _, ok := mc.TrafficMonitor["tm0"]; !ok || len(mc.TrafficMonitor) != 1 {
+		t.Errorf("expected the CRConfig's monitor tm0 to replace the monitoring config's, actual: %+v", mc.TrafficMonitor)
+	}
+	if len(mc.DeliveryService) != 2 || mc.DeliveryService["ds0"].TotalKbpsThreshold != 1000 || mc.DeliveryService["ds1"].XMLID != "ds1" {
+		t.Errorf("expected the CRConfig's Delivery Services ds0 with its monitoring config threshold and ds1, actual: %+v", mc.DeliveryService)
+	}
+
+	if err := s.SetStandaloneCRConfig([]byte(`{"stats": {"CDN_name": "cdn0", "date": 1500000000}}`)); err != nil {
+		t.Fatalf("setting the standalone CRConfig: unexpected error: %v", err)
+	}
+	if _, err := s.CRConfigRaw("cdn0"); err == nil {
+		t.Error("getting a CRConfig older than the last one: expected error, actual: nil")
+	}
+	if err := s.SetStandaloneMonitoringConfig([]byte(`{"trafficServers": `)); err != nil {
+		t.Fatalf("setting the standalone monitoring config: unexpected error: %v", err)
+	}
+	if _, err := s.TrafficMonitorConfigMap("cdn0"); err == nil {
+		t.Error("getting a malformed monitoring config: expected error, actual: nil")
+	}
+}
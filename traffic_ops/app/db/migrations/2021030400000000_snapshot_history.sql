@@ -0,0 +1,61 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+/*
+This migration adds the snapshot_history table, which retains previous CDN
+Snapshots (both the CRConfig and monitoring configuration) so they can be
+compared and rolled back to. The current Snapshot of each CDN is copied into
+it, so the most recent entry for a CDN is always its current Snapshot.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE SEQUENCE IF NOT EXISTS snapshot_history_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+CREATE TABLE IF NOT EXISTS snapshot_history (
+    id bigint NOT NULL DEFAULT nextval('snapshot_history_id_seq'::regclass),
+    cdn text NOT NULL,
+    crconfig json NOT NULL,
+    monitoring json NOT NULL,
+    author text,
+    log_id bigint,
+    rollback_of bigint,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (id),
+    CONSTRAINT fk_snapshot_history_cdn FOREIGN KEY (cdn) REFERENCES cdn(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_snapshot_history_rollback_of FOREIGN KEY (rollback_of) REFERENCES snapshot_history(id) ON DELETE SET NULL
+);
+
+ALTER SEQUENCE snapshot_history_id_seq OWNED BY snapshot_history.id;
+
+CREATE INDEX IF NOT EXISTS snapshot_history_cdn_idx ON snapshot_history (cdn, id DESC);
+
+INSERT INTO snapshot_history (cdn, crconfig, monitoring, author, created_at)
+SELECT s.cdn, s.crconfig, s.monitoring, s.crconfig::jsonb -> 'stats' ->> 'tm_user', s.last_updated
+FROM snapshot AS s
+JOIN cdn AS c ON c.name = s.cdn;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS snapshot_history;
+DROP SEQUENCE IF EXISTS snapshot_history_id_seq;
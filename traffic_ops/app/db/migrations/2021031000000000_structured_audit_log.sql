@@ -0,0 +1,51 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+/*
+This migration adds structured audit columns to the change log, so that each
+entry can record which object changed, its state before and after the change,
+and the tenant, request ID, and API version of the request that changed it.
+Existing entries, and entries which only have a message, leave them NULL.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+ALTER TABLE log
+    ADD COLUMN IF NOT EXISTS action text,
+    ADD COLUMN IF NOT EXISTS object_type text,
+    ADD COLUMN IF NOT EXISTS object_id text,
+    ADD COLUMN IF NOT EXISTS tenant_id bigint,
+    ADD COLUMN IF NOT EXISTS request_id bigint,
+    ADD COLUMN IF NOT EXISTS api_version text,
+    ADD COLUMN IF NOT EXISTS before jsonb,
+    ADD COLUMN IF NOT EXISTS after jsonb;
+
+CREATE INDEX IF NOT EXISTS idx_log_object ON log USING btree (object_type, object_id);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP INDEX IF EXISTS idx_log_object;
+
+ALTER TABLE log
+    DROP COLUMN IF EXISTS after,
+    DROP COLUMN IF EXISTS before,
+    DROP COLUMN IF EXISTS api_version,
+    DROP COLUMN IF EXISTS request_id,
+    DROP COLUMN IF EXISTS tenant_id,
+    DROP COLUMN IF EXISTS object_id,
+    DROP COLUMN IF EXISTS object_type,
+    DROP COLUMN IF EXISTS action;
@@ -0,0 +1,82 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds webhooks - subscriptions to notifications of changes made
+through Traffic Ops - along with the queue of notifications to be delivered to
+them, and a log of each attempt to deliver them.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE IF NOT EXISTS webhook (
+    id bigserial NOT NULL,
+    name text NOT NULL,
+    url text NOT NULL,
+    secret text,
+    object_types text[] NOT NULL DEFAULT '{}',
+    actions text[] NOT NULL DEFAULT '{}',
+    cdn text,
+    tenant_id bigint,
+    active boolean NOT NULL DEFAULT TRUE,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (id),
+    CONSTRAINT webhook_name_unique UNIQUE (name),
+    CONSTRAINT fk_webhook_cdn FOREIGN KEY (cdn) REFERENCES cdn(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT fk_webhook_tenant FOREIGN KEY (tenant_id) REFERENCES tenant(id) ON DELETE CASCADE
+);
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON webhook;
+CREATE TRIGGER on_update_current_timestamp BEFORE UPDATE ON webhook FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id bigserial NOT NULL,
+    webhook_id bigint NOT NULL,
+    event text NOT NULL,
+    payload jsonb NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt timestamp with time zone NOT NULL DEFAULT now(),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (id),
+    CONSTRAINT webhook_delivery_status_check CHECK (status IN ('pending', 'delivered', 'failed')),
+    CONSTRAINT fk_webhook_delivery_webhook FOREIGN KEY (webhook_id) REFERENCES webhook(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_webhook_idx ON webhook_delivery (webhook_id, id DESC);
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON webhook_delivery (next_attempt) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempt (
+    id bigserial NOT NULL,
+    delivery_id bigint NOT NULL,
+    attempt integer NOT NULL,
+    attempted_at timestamp with time zone NOT NULL DEFAULT now(),
+    status_code integer,
+    error text,
+    duration_ms bigint NOT NULL,
+
+    PRIMARY KEY (id),
+    CONSTRAINT fk_webhook_delivery_attempt_delivery FOREIGN KEY (delivery_id) REFERENCES webhook_delivery(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_attempt_delivery_idx ON webhook_delivery_attempt (delivery_id);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS webhook_delivery_attempt;
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS webhook;
@@ -0,0 +1,46 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds personal API tokens, which users create to authenticate
+automation with an "Authorization: Bearer" header instead of a password. Only
+a hash of each token is stored. Each token is scoped to a Role and a set of
+Capabilities, which may be less than those of its user.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE IF NOT EXISTS api_token (
+    id bigserial NOT NULL,
+    tm_user bigint NOT NULL,
+    name text NOT NULL,
+    token_hash text NOT NULL,
+    role bigint NOT NULL,
+    capabilities text[] NOT NULL DEFAULT '{}',
+    expires_at timestamp with time zone NOT NULL,
+    last_used timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (id),
+    CONSTRAINT api_token_hash_unique UNIQUE (token_hash),
+    CONSTRAINT api_token_user_name_unique UNIQUE (tm_user, name),
+    CONSTRAINT fk_api_token_user FOREIGN KEY (tm_user) REFERENCES tm_user(id) ON DELETE CASCADE,
+    CONSTRAINT fk_api_token_role FOREIGN KEY (role) REFERENCES role(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS api_token;
@@ -0,0 +1,152 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration brings the api_capability table in line with the Capabilities
+that routes now declare, and gives the built-in Roles the Capabilities
+equivalent to their privilege levels.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+-- Routes now declare the Capabilities they require; make api_capability match them.
+DELETE FROM api_capability WHERE (http_method, route, capability) IN (
+    ('DELETE', 'deliveryservices/*/urisignkeys', 'delivery-service-security-keys-write'),
+    ('DELETE', 'federations', 'federations-write'),
+    ('DELETE', 'regions/name/*', 'regions-write'),
+    ('GET', 'cdns/*/snapshot', 'cdns-snapshot'),
+    ('GET', 'cdns/*/snapshot/new', 'cdns-snapshot'),
+    ('GET', 'deliveryservices/*/urisignkeys', 'delivery-service-security-keys-read'),
+    ('GET', 'deliveryservices/xmlId/*/sslkeys', 'delivery-service-security-keys-read'),
+    ('GET', 'federations', 'federations-read'),
+    ('GET', 'osversions', 'iso-generate'),
+    ('GET', 'servers/totals', 'servers-read'),
+    ('GET', 'vault/ping', 'vault'),
+    ('POST', 'deliveryservices/*/urisignkeys', 'delivery-service-security-keys-write'),
+    ('POST', 'deliveryservices/sslkeys/add', 'delivery-service-security-keys-write'),
+    ('POST', 'federations', 'federations-write'),
+    ('POST', 'servercheck', 'servers-write'),
+    ('PUT', 'deliveryservice_requests/*/status', 'delivery-services-write'),
+    ('PUT', 'deliveryservices/*/urisignkeys', 'delivery-service-security-keys-write'),
+    ('PUT', 'federations', 'federations-write')
+);
+INSERT INTO api_capability (http_method, route, capability) VALUES
+    ('DELETE', 'asns', 'asns-write'),
+    ('DELETE', 'deliveryserviceserver/*/*', 'delivery-service-servers-write'),
+    ('DELETE', 'jobs', 'jobs-write'),
+    ('DELETE', 'regions', 'regions-write'),
+    ('DELETE', 'user/tokens/*', 'auth'),
+    ('GET', 'cdns/*/snapshot', 'cdns-read'),
+    ('GET', 'cdns/*/snapshot/diff', 'cdns-read'),
+    ('GET', 'cdns/*/snapshot/history', 'cdns-read'),
+    ('GET', 'cdns/*/snapshot/history/*', 'cdns-read'),
+    ('GET', 'cdns/*/snapshot/new', 'cdns-read'),
+    ('GET', 'logs/*', 'change-logs-read'),
+    ('GET', 'logs/*/diff', 'change-logs-read'),
+    ('GET', 'servers/*/update_status', 'servers-read'),
+    ('GET', 'user/current/permissions', 'auth'),
+    ('GET', 'user/tokens', 'auth'),
+    ('POST', 'cdns/*/snapshot/history/*/rollback', 'cdns-snapshot'),
+    ('POST', 'jobs', 'jobs-write'),
+    ('POST', 'servers/*/update', 'servers-write'),
+    ('POST', 'user/tokens', 'auth'),
+    ('PUT', 'asns', 'asns-write'),
+    ('PUT', 'deliveryservice_requests/*/status', 'delivery-service-requests-write'),
+    ('PUT', 'jobs', 'jobs-write'),
+    ('PUT', 'server_capabilities', 'server-capabilities-write')
+ON CONFLICT (http_method, route, capability) DO NOTHING;
+
+-- Give the built-in Roles exactly the Capabilities equivalent to their privilege levels, so that they're
+-- authorized the same way whether or not Capability authorization is enabled.
+DELETE FROM role_capability WHERE (role_id, cap_name) IN (
+    ((SELECT id FROM role WHERE name = 'read-only'), 'cache-config-files-read'),
+    ((SELECT id FROM role WHERE name = 'read-only'), 'cdn-security-keys-read'),
+    ((SELECT id FROM role WHERE name = 'operations'), 'cdn-security-keys-read'),
+    ((SELECT id FROM role WHERE name = 'operations'), 'federations-write'),
+    ((SELECT id FROM role WHERE name = 'operations'), 'roles-write')
+);
+INSERT INTO role_capability (role_id, cap_name)
+SELECT r.id, v.cap_name FROM (VALUES
+    ('read-only', 'to-extensions-write'),
+    ('operations', 'origins-write'),
+    ('operations', 'server-capabilities-write')
+) AS v (role_name, cap_name)
+JOIN role AS r ON r.name = v.role_name
+ON CONFLICT DO NOTHING;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DELETE FROM role_capability WHERE (role_id, cap_name) IN (
+    ((SELECT id FROM role WHERE name = 'read-only'), 'to-extensions-write'),
+    ((SELECT id FROM role WHERE name = 'operations'), 'origins-write'),
+    ((SELECT id FROM role WHERE name = 'operations'), 'server-capabilities-write')
+);
+INSERT INTO role_capability (role_id, cap_name)
+SELECT r.id, v.cap_name FROM (VALUES
+    ('read-only', 'cache-config-files-read'),
+    ('read-only', 'cdn-security-keys-read'),
+    ('operations', 'cdn-security-keys-read'),
+    ('operations', 'federations-write'),
+    ('operations', 'roles-write')
+) AS v (role_name, cap_name)
+JOIN role AS r ON r.name = v.role_name
+ON CONFLICT DO NOTHING;
+
+DELETE FROM api_capability WHERE (http_method, route, capability) IN (
+    ('DELETE', 'asns', 'asns-write'),
+    ('DELETE', 'deliveryserviceserver/*/*', 'delivery-service-servers-write'),
+    ('DELETE', 'jobs', 'jobs-write'),
+    ('DELETE', 'regions', 'regions-write'),
+    ('DELETE', 'user/tokens/*', 'auth'),
+    ('GET', 'cdns/*/snapshot', 'cdns-read'),
+    ('GET', 'cdns/*/snapshot/diff', 'cdns-read'),
+    ('GET', 'cdns/*/snapshot/history', 'cdns-read'),
+    ('GET', 'cdns/*/snapshot/history/*', 'cdns-read'),
+    ('GET', 'cdns/*/snapshot/new', 'cdns-read'),
+    ('GET', 'logs/*', 'change-logs-read'),
+    ('GET', 'logs/*/diff', 'change-logs-read'),
+    ('GET', 'servers/*/update_status', 'servers-read'),
+    ('GET', 'user/current/permissions', 'auth'),
+    ('GET', 'user/tokens', 'auth'),
+    ('POST', 'cdns/*/snapshot/history/*/rollback', 'cdns-snapshot'),
+    ('POST', 'jobs', 'jobs-write'),
+    ('POST', 'servers/*/update', 'servers-write'),
+    ('POST', 'user/tokens', 'auth'),
+    ('PUT', 'asns', 'asns-write'),
+    ('PUT', 'deliveryservice_requests/*/status', 'delivery-service-requests-write'),
+    ('PUT', 'jobs', 'jobs-write'),
+    ('PUT', 'server_capabilities', 'server-capabilities-write')
+);
+INSERT INTO api_capability (http_method, route, capability) VALUES
+    ('DELETE', 'deliveryservices/*/urisignkeys', 'delivery-service-security-keys-write'),
+    ('DELETE', 'federations', 'federations-write'),
+    ('DELETE', 'regions/name/*', 'regions-write'),
+    ('GET', 'cdns/*/snapshot', 'cdns-snapshot'),
+    ('GET', 'cdns/*/snapshot/new', 'cdns-snapshot'),
+    ('GET', 'deliveryservices/*/urisignkeys', 'delivery-service-security-keys-read'),
+    ('GET', 'deliveryservices/xmlId/*/sslkeys', 'delivery-service-security-keys-read'),
+    ('GET', 'federations', 'federations-read'),
+    ('GET', 'osversions', 'iso-generate'),
+    ('GET', 'servers/totals', 'servers-read'),
+    ('GET', 'vault/ping', 'vault'),
+    ('POST', 'deliveryservices/*/urisignkeys', 'delivery-service-security-keys-write'),
+    ('POST', 'deliveryservices/sslkeys/add', 'delivery-service-security-keys-write'),
+    ('POST', 'federations', 'federations-write'),
+    ('POST', 'servercheck', 'servers-write'),
+    ('PUT', 'deliveryservice_requests/*/status', 'delivery-services-write'),
+    ('PUT', 'deliveryservices/*/urisignkeys', 'delivery-service-security-keys-write'),
+    ('PUT', 'federations', 'federations-write')
+ON CONFLICT (http_method, route, capability) DO NOTHING;
@@ -0,0 +1,60 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds a persistent queue of asynchronous jobs, which are run by
+Traffic Ops's job workers and report their progress, results and cancellation
+through async_status.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+ALTER TABLE async_status
+    ADD COLUMN IF NOT EXISTS kind text,
+    ADD COLUMN IF NOT EXISTS username text,
+    ADD COLUMN IF NOT EXISTS progress smallint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS cancel_requested boolean NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS result jsonb,
+    ADD CONSTRAINT async_status_progress_check CHECK (progress >= 0 AND progress <= 100);
+
+CREATE TABLE IF NOT EXISTS async_job (
+    id bigserial NOT NULL,
+    async_status_id bigint NOT NULL,
+    kind text NOT NULL,
+    payload jsonb NOT NULL,
+    username text NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    lease_until timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (id),
+    CONSTRAINT async_job_async_status_unique UNIQUE (async_status_id),
+    CONSTRAINT fk_async_job_async_status FOREIGN KEY (async_status_id) REFERENCES async_status(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS async_job_lease_idx ON async_job (lease_until);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS async_job;
+
+ALTER TABLE async_status
+    DROP CONSTRAINT IF EXISTS async_status_progress_check,
+    DROP COLUMN IF EXISTS result,
+    DROP COLUMN IF EXISTS cancel_requested,
+    DROP COLUMN IF EXISTS progress,
+    DROP COLUMN IF EXISTS username,
+    DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,88 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds maintenance windows - periods during which Traffic Ops sets
+the status of a set of servers, or of every server in a set of Cache Groups,
+and after which it restores their original statuses.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE IF NOT EXISTS maintenance_window (
+    id bigserial NOT NULL,
+    reason text NOT NULL,
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    status bigint NOT NULL,
+    snapshot boolean NOT NULL DEFAULT FALSE,
+    state text NOT NULL DEFAULT 'scheduled',
+    username text NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (id),
+    CONSTRAINT maintenance_window_times_check CHECK (end_time > start_time),
+    CONSTRAINT maintenance_window_state_check CHECK (state IN ('scheduled', 'active', 'completed', 'skipped')),
+    CONSTRAINT fk_maintenance_window_status FOREIGN KEY (status) REFERENCES status(id) ON DELETE RESTRICT
+);
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON maintenance_window;
+CREATE TRIGGER on_update_current_timestamp BEFORE UPDATE ON maintenance_window FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE INDEX IF NOT EXISTS maintenance_window_due_idx ON maintenance_window (state, start_time, end_time);
+
+CREATE TABLE IF NOT EXISTS maintenance_window_server (
+    maintenance_window bigint NOT NULL,
+    server bigint NOT NULL,
+
+    PRIMARY KEY (maintenance_window, server),
+    CONSTRAINT fk_maintenance_window_server_window FOREIGN KEY (maintenance_window) REFERENCES maintenance_window(id) ON DELETE CASCADE,
+    CONSTRAINT fk_maintenance_window_server_server FOREIGN KEY (server) REFERENCES server(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS maintenance_window_cachegroup (
+    maintenance_window bigint NOT NULL,
+    cachegroup bigint NOT NULL,
+
+    PRIMARY KEY (maintenance_window, cachegroup),
+    CONSTRAINT fk_maintenance_window_cachegroup_window FOREIGN KEY (maintenance_window) REFERENCES maintenance_window(id) ON DELETE CASCADE,
+    CONSTRAINT fk_maintenance_window_cachegroup_cachegroup FOREIGN KEY (cachegroup) REFERENCES cachegroup(id) ON DELETE CASCADE
+);
+
+/*
+maintenance_window_applied holds the statuses of the servers whose statuses were
+set when their maintenance window started, from before it started, so they can
+be restored when it ends.
+*/
+CREATE TABLE IF NOT EXISTS maintenance_window_applied (
+    maintenance_window bigint NOT NULL,
+    server bigint NOT NULL,
+    original_status bigint NOT NULL,
+    original_offline_reason text,
+
+    PRIMARY KEY (maintenance_window, server),
+    CONSTRAINT fk_maintenance_window_applied_window FOREIGN KEY (maintenance_window) REFERENCES maintenance_window(id) ON DELETE CASCADE,
+    CONSTRAINT fk_maintenance_window_applied_server FOREIGN KEY (server) REFERENCES server(id) ON DELETE CASCADE,
+    CONSTRAINT fk_maintenance_window_applied_status FOREIGN KEY (original_status) REFERENCES status(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS maintenance_window_applied_server_idx ON maintenance_window_applied (server);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS maintenance_window_applied;
+DROP TABLE IF EXISTS maintenance_window_cachegroup;
+DROP TABLE IF EXISTS maintenance_window_server;
+DROP TABLE IF EXISTS maintenance_window;
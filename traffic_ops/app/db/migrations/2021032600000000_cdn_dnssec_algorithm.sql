@@ -0,0 +1,30 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds the algorithm of the DNSSEC keys generated for each CDN.
+Existing keys are unaffected, and keep their algorithm through rollovers; the
+CDN's algorithm is used the next time its keys are generated.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+ALTER TABLE cdn ADD COLUMN dnssec_algorithm text NOT NULL DEFAULT 'RSASHA256'
+	CHECK (dnssec_algorithm IN ('RSASHA256', 'ECDSAP256SHA256', 'ED25519'));
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+ALTER TABLE cdn DROP COLUMN IF EXISTS dnssec_algorithm;
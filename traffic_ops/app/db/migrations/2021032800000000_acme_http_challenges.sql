@@ -0,0 +1,38 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds pending ACME HTTP-01 challenges, which Traffic Ops stores
+while obtaining a certificate so that Traffic Routers and caches can answer
+requests for /.well-known/acme-challenge/<token> on the challenged domain.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE IF NOT EXISTS acme_http_challenge (
+    domain text NOT NULL,
+    token text NOT NULL,
+    key_authorization text NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (domain, token)
+);
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON acme_http_challenge;
+CREATE TRIGGER on_update_current_timestamp BEFORE UPDATE ON acme_http_challenge FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS acme_http_challenge;
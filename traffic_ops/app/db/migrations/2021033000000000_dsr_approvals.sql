@@ -0,0 +1,70 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds approval policies for Delivery Service Requests - the
+number of distinct users, and their Roles, who must approve the requests of the
+Delivery Services in a Tenant and/or CDN before they're applied - and the
+approvals of requests.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE IF NOT EXISTS dsr_approval_policy (
+    id bigserial NOT NULL,
+    tenant_id bigint,
+    cdn_id bigint,
+    required_approvals integer NOT NULL DEFAULT 1,
+    allow_self_approval boolean NOT NULL DEFAULT FALSE,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (id),
+    CONSTRAINT dsr_approval_policy_required_approvals_check CHECK (required_approvals > 0),
+    CONSTRAINT fk_dsr_approval_policy_tenant FOREIGN KEY (tenant_id) REFERENCES tenant(id) ON DELETE CASCADE,
+    CONSTRAINT fk_dsr_approval_policy_cdn FOREIGN KEY (cdn_id) REFERENCES cdn(id) ON DELETE CASCADE
+);
+DROP TRIGGER IF EXISTS on_update_current_timestamp ON dsr_approval_policy;
+CREATE TRIGGER on_update_current_timestamp BEFORE UPDATE ON dsr_approval_policy FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+-- There's at most one policy for each combination of Tenant and CDN, including
+-- "any Tenant" and "any CDN".
+CREATE UNIQUE INDEX IF NOT EXISTS dsr_approval_policy_scope_idx ON dsr_approval_policy (COALESCE(tenant_id, 0), COALESCE(cdn_id, 0));
+
+CREATE TABLE IF NOT EXISTS dsr_approval_policy_role (
+    policy bigint NOT NULL,
+    role bigint NOT NULL,
+
+    PRIMARY KEY (policy, role),
+    CONSTRAINT fk_dsr_approval_policy_role_policy FOREIGN KEY (policy) REFERENCES dsr_approval_policy(id) ON DELETE CASCADE,
+    CONSTRAINT fk_dsr_approval_policy_role_role FOREIGN KEY (role) REFERENCES role(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS deliveryservice_request_approval (
+    deliveryservice_request bigint NOT NULL,
+    approver_id bigint NOT NULL,
+    comment text,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (deliveryservice_request, approver_id),
+    CONSTRAINT fk_deliveryservice_request_approval_request FOREIGN KEY (deliveryservice_request) REFERENCES deliveryservice_request(id) ON DELETE CASCADE,
+    CONSTRAINT fk_deliveryservice_request_approval_approver FOREIGN KEY (approver_id) REFERENCES tm_user(id) ON DELETE CASCADE
+);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS deliveryservice_request_approval;
+DROP TABLE IF EXISTS dsr_approval_policy_role;
+DROP TABLE IF EXISTS dsr_approval_policy;
@@ -0,0 +1,36 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds the resource quotas of Tenants. A null limit means the
+Tenant has no quota for that resource, although the quotas of its ancestors
+still apply.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE IF NOT EXISTS tenant_quota (
+	tenant_id bigint PRIMARY KEY REFERENCES tenant (id) ON DELETE CASCADE,
+	max_delivery_services bigint CHECK (max_delivery_services >= 0),
+	max_jobs_per_day bigint CHECK (max_jobs_per_day >= 0),
+	max_regexes_per_ds bigint CHECK (max_regexes_per_ds >= 0),
+	max_bandwidth_mbps bigint CHECK (max_bandwidth_mbps >= 0),
+	last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE IF EXISTS tenant_quota;
@@ -0,0 +1,35 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+/*
+This migration adds the OpenID Connect identity - issuer and subject - to which
+a user is linked. Only linked users may log in with OpenID Connect, so a local
+user can't be taken over by an identity provider user of the same name.
+*/
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+ALTER TABLE tm_user ADD COLUMN oidc_issuer text;
+ALTER TABLE tm_user ADD COLUMN oidc_subject text;
+ALTER TABLE tm_user ADD CONSTRAINT tm_user_oidc_identity_check CHECK ((oidc_issuer IS NULL) = (oidc_subject IS NULL));
+CREATE UNIQUE INDEX IF NOT EXISTS tm_user_oidc_identity_idx ON tm_user (oidc_issuer, oidc_subject);
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP INDEX IF EXISTS tm_user_oidc_identity_idx;
+ALTER TABLE tm_user DROP CONSTRAINT IF EXISTS tm_user_oidc_identity_check;
+ALTER TABLE tm_user DROP COLUMN IF EXISTS oidc_subject;
+ALTER TABLE tm_user DROP COLUMN IF EXISTS oidc_issuer;
@@ -0,0 +1,54 @@
+/*
+
+    Licensed under the Apache License, Version 2.0 (the "License");
+    you may not use this file except in compliance with the License.
+    You may obtain a copy of the License at
+
+        http://www.apache.org/licenses/LICENSE-2.0
+
+    Unless required by applicable law or agreed to in writing, software
+    distributed under the License is distributed on an "AS IS" BASIS,
+    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+    See the License for the specific language governing permissions and
+    limitations under the License.
+*/
+
+-- Schema of the Traffic Vault database used by the "postgres" Traffic Vault
+-- backend. This database must be separate from the Traffic Ops database.
+-- All key data is encrypted by Traffic Ops with AES-GCM before it is stored.
+
+CREATE TABLE IF NOT EXISTS sslkey (
+    id bigserial PRIMARY KEY,
+    deliveryservice text NOT NULL,
+    cdn text NOT NULL,
+    version text NOT NULL,
+    data bytea NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (deliveryservice, version)
+);
+
+CREATE INDEX IF NOT EXISTS sslkey_cdn_idx ON sslkey (cdn);
+
+CREATE TABLE IF NOT EXISTS dnssec (
+    cdn text PRIMARY KEY,
+    data bytea NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS url_sig_key (
+    deliveryservice text PRIMARY KEY,
+    data bytea NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS uri_signing_key (
+    deliveryservice text PRIMARY KEY,
+    data bytea NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS sslkey_csr (
+    deliveryservice text PRIMARY KEY,
+    data bytea NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
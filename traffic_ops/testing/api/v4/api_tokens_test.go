@@ -0,0 +1,83 @@
+package v4
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v4-client"
+)
+
+func TestAPITokens(t *testing.T) {
+	CreateUseRevokeTestAPIToken(t)
+}
+
+func CreateUseRevokeTestAPIToken(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	tok := tc.APIToken{
+		Name:      util.StrPtr("api-token-test"),
+		ExpiresAt: &expires,
+	}
+	created, _, err := TOSession.CreateAPIToken(tok)
+	if err != nil {
+		t.Fatalf("cannot CREATE API token: %v", err)
+	}
+	if created.Response.ID == nil || created.Response.Token == nil {
+		t.Fatalf("CREATE API token: expected an ID and token, actual: %+v", created.Response)
+	}
+	id := *created.Response.ID
+
+	past := time.Now().Add(-time.Hour)
+	if _, _, err := TOSession.CreateAPIToken(tc.APIToken{Name: util.StrPtr("api-token-test-expired"), ExpiresAt: &past}); err == nil {
+		t.Error("CREATE API token with an expiry in the past: expected an error, actual: nil")
+	}
+
+	tokSession := toclient.NewSessionWithAPIToken(TOSession.URL, *created.Response.Token, true, "to-api-v4-client-tests/apitoken", toReqTimeout)
+	if _, reqInf, err := tokSession.GetCDNs(); err != nil {
+		t.Errorf("cannot GET CDNs with API token: %v - status %d", err, reqInf.StatusCode)
+	}
+
+	tokens, _, err := TOSession.GetAPITokens(nil)
+	if err != nil {
+		t.Fatalf("cannot GET API tokens: %v", err)
+	}
+	found := false
+	for _, tk := range tokens {
+		if tk.ID != nil && *tk.ID == id {
+			found = true
+			if tk.Token != nil {
+				t.Error("GET API tokens: expected the token to be omitted")
+			}
+			if tk.LastUsed == nil {
+				t.Error("GET API tokens: expected lastUsed to be set after the token was used")
+			}
+		}
+	}
+	if !found {
+		t.Errorf("GET API tokens: expected to find token %d", id)
+	}
+
+	if _, _, err := TOSession.DeleteAPIToken(id); err != nil {
+		t.Fatalf("cannot DELETE API token: %v", err)
+	}
+	if _, reqInf, err := tokSession.GetCDNs(); err == nil || reqInf.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET CDNs with a revoked API token: expected a %d error, actual: %d %v", http.StatusUnauthorized, reqInf.StatusCode, err)
+	}
+}
@@ -23,6 +23,7 @@ func TestLogs(t *testing.T) {
 	WithObjs(t, []TCObj{Roles, Tenants, Users}, func() { // Objs added to create logs when this test is run alone
 		GetTestLogs(t)
 		GetTestLogsByLimit(t)
+		GetTestLogsByObject(t)
 	})
 }
 
@@ -42,3 +43,41 @@ func GetTestLogsByLimit(t *testing.T) {
 		t.Fatalf("GET logs by limit: incorrect number of logs returned (%v)", len(toLogs))
 	}
 }
+
+func GetTestLogsByObject(t *testing.T) {
+	toLogs, _, err := TOSession.GetLogsByQueryParams("?objectType=tenant&action=Created&limit=1")
+	if err != nil {
+		t.Fatalf("error getting logs by object type: %v", err)
+	}
+	if len(toLogs) != 1 {
+		t.Fatalf("GET logs by object type: expected 1 log, actual: %d", len(toLogs))
+	}
+	l := toLogs[0]
+	if l.ObjectType == nil || *l.ObjectType != "tenant" || l.ObjectID == nil || l.ID == nil {
+		t.Fatalf("GET logs by object type: expected a tenant log with an object ID, actual: %+v", l)
+	}
+
+	detail, _, err := TOSession.GetLogWithHdr(*l.ID, nil)
+	if err != nil {
+		t.Fatalf("error getting log #%d: %v", *l.ID, err)
+	}
+	if detail.Before != nil {
+		t.Errorf("log #%d of a created tenant: expected null before, actual: %s", *l.ID, string(detail.Before))
+	}
+	if detail.After == nil {
+		t.Errorf("log #%d of a created tenant: expected the created tenant, actual: null", *l.ID)
+	}
+
+	diff, _, err := TOSession.GetLogDiffWithHdr(*l.ID, nil)
+	if err != nil {
+		t.Fatalf("error getting diff of log #%d: %v", *l.ID, err)
+	}
+	if len(diff.Changes) == 0 {
+		t.Errorf("diff of log #%d of a created tenant: expected every field to be added, actual: no changes", *l.ID)
+	}
+	for _, change := range diff.Changes {
+		if change.Old != nil {
+			t.Errorf("diff of log #%d of a created tenant: expected field '%s' to have no old value, actual: %v", *l.ID, change.Field, change.Old)
+		}
+	}
+}
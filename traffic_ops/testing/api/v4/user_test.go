@@ -47,6 +47,7 @@ func TestUsers(t *testing.T) {
 		UserUpdateOwnRoleTest(t)
 		GetTestUsers(t)
 		GetTestUserCurrent(t)
+		GetTestUserCurrentPermissions(t)
 		UserTenancyTest(t)
 		if includeSystemTests {
 			// UserRegistrationTest deletes test users before registering new users, so it must come after the other user tests.
@@ -404,6 +405,24 @@ func GetTestUserCurrent(t *testing.T) {
 	}
 }
 
+func GetTestUserCurrentPermissions(t *testing.T) {
+	perms, _, err := TOSession.GetUserCurrentPermissions(nil)
+	if err != nil {
+		t.Fatalf("cannot GET current user permissions: %v", err)
+	}
+	if perms.Username != SessionUserName {
+		t.Errorf("current user permissions: expected username %s, actual: %s", SessionUserName, perms.Username)
+	}
+	if len(perms.Endpoints) == 0 {
+		t.Fatal("current user permissions: expected endpoints, actual: none")
+	}
+	for _, e := range perms.Endpoints {
+		if !e.Allowed {
+			t.Errorf("current user permissions: expected the admin user to be allowed to %s %s", e.Method, e.Path)
+		}
+	}
+}
+
 func UserTenancyTest(t *testing.T) {
 	users, _, err := TOSession.GetUsers()
 	if err != nil {
@@ -0,0 +1,95 @@
+package v4
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestWebhooks(t *testing.T) {
+	WithObjs(t, []TCObj{CDNs}, func() {
+		CreateGetUpdateDeleteTestWebhook(t)
+	})
+}
+
+func CreateGetUpdateDeleteTestWebhook(t *testing.T) {
+	if len(testData.CDNs) < 1 {
+		t.Fatal("need at least one CDN to test webhooks")
+	}
+	wh := tc.Webhook{
+		Name:        util.StrPtr("webhook-test"),
+		URL:         util.StrPtr("http://localhost:1/webhook"),
+		Secret:      util.StrPtr("secret"),
+		ObjectTypes: []string{"cdn"},
+		CDNName:     util.StrPtr(testData.CDNs[0].Name),
+	}
+	created, _, err := TOSession.CreateWebhook(wh)
+	if err != nil {
+		t.Fatalf("cannot CREATE webhook: %v", err)
+	}
+	if created.Response.ID == nil {
+		t.Fatal("CREATE webhook: expected an ID, actual: nil")
+	}
+	id := *created.Response.ID
+	if created.Response.Secret != nil {
+		t.Error("CREATE webhook: expected the secret to be omitted from the response")
+	}
+	if !created.Response.HasSecret {
+		t.Error("CREATE webhook: expected hasSecret to be true, actual: false")
+	}
+
+	webhooks, _, err := TOSession.GetWebhookByName(*wh.Name, nil)
+	if err != nil {
+		t.Fatalf("cannot GET webhook by name: %v", err)
+	}
+	if len(webhooks) != 1 || webhooks[0].ID == nil || *webhooks[0].ID != id {
+		t.Fatalf("GET webhook by name: expected webhook %d, actual: %+v", id, webhooks)
+	}
+
+	wh.Secret = nil
+	wh.Active = util.BoolPtr(false)
+	updated, _, err := TOSession.UpdateWebhook(id, wh, nil)
+	if err != nil {
+		t.Fatalf("cannot UPDATE webhook: %v", err)
+	}
+	if updated.Response.Active == nil || *updated.Response.Active {
+		t.Error("UPDATE webhook: expected it to be inactive")
+	}
+	if !updated.Response.HasSecret {
+		t.Error("UPDATE webhook: expected the secret to be left unchanged when omitted")
+	}
+
+	if _, _, err := TOSession.GetWebhookDeliveries(id, tc.WebhookDeliveryStatusPending, nil); err != nil {
+		t.Errorf("cannot GET webhook deliveries: %v", err)
+	}
+	if _, _, err := TOSession.GetWebhookDeliveries(id, "bogus", nil); err == nil {
+		t.Error("GET webhook deliveries with an invalid status: expected an error, actual: nil")
+	}
+
+	if _, _, err := TOSession.DeleteWebhook(id); err != nil {
+		t.Fatalf("cannot DELETE webhook: %v", err)
+	}
+	webhooks, _, err = TOSession.GetWebhookByName(*wh.Name, nil)
+	if err != nil {
+		t.Fatalf("cannot GET webhook by name after deletion: %v", err)
+	}
+	if len(webhooks) != 0 {
+		t.Errorf("GET webhook by name after deletion: expected none, actual: %d", len(webhooks))
+	}
+}
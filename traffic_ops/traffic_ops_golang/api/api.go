@@ -991,8 +991,25 @@ func ParseDBError(ierr error) (error, error, int) {
 }
 
 // GetUserFromReq returns the current user, any user error, any system error, and an error code to be returned if either error was not nil.
-// This also uses the given ResponseWriter to refresh the cookie, if it was valid.
+// Requests bearing an API token in an "Authorization: Bearer" header are authenticated with it; otherwise,
+// this uses the given ResponseWriter to refresh the cookie, if it was valid.
 func GetUserFromReq(w http.ResponseWriter, r *http.Request, secret string) (auth.CurrentUser, error, error, int) {
+	if token, ok := getBearerToken(r); ok {
+		db, timeout, sysErr := getReqDBAndTimeout(r)
+		if sysErr != nil {
+			return auth.CurrentUser{}, nil, sysErr, http.StatusInternalServerError
+		}
+		capabilityPrivLevels := map[string]int(nil)
+		if cfg, err := GetConfig(r.Context()); err == nil {
+			capabilityPrivLevels = cfg.CapabilityPrivLevels
+		}
+		user, userErr, sysErr, code := auth.GetCurrentUserFromAPIToken(db, token, timeout, capabilityPrivLevels)
+		if userErr != nil || sysErr != nil {
+			return auth.CurrentUser{}, userErr, sysErr, code
+		}
+		return user, nil, nil, http.StatusOK
+	}
+
 	cookie, err := r.Cookie(tocookie.Name)
 	if err != nil {
 		return auth.CurrentUser{}, errors.New("Unauthorized, please log in."), errors.New("error getting cookie: " + err.Error()), http.StatusUnauthorized
@@ -1011,24 +1028,12 @@ func GetUserFromReq(w http.ResponseWriter, r *http.Request, secret string) (auth
 	if username == "" {
 		return auth.CurrentUser{}, errors.New("Unauthorized, please log in."), nil, http.StatusUnauthorized
 	}
-	db := (*sqlx.DB)(nil)
-	val := r.Context().Value(DBContextKey)
-	if val == nil {
-		return auth.CurrentUser{}, nil, errors.New("request context db missing"), http.StatusInternalServerError
-	}
-	switch v := val.(type) {
-	case *sqlx.DB:
-		db = v
-	default:
-		return auth.CurrentUser{}, nil, fmt.Errorf("request context db unknown type %T", val), http.StatusInternalServerError
-	}
-
-	cfg, err := GetConfig(r.Context())
-	if err != nil {
-		return auth.CurrentUser{}, nil, errors.New("request context config missing"), http.StatusInternalServerError
+	db, timeout, sysErr := getReqDBAndTimeout(r)
+	if sysErr != nil {
+		return auth.CurrentUser{}, nil, sysErr, http.StatusInternalServerError
 	}
 
-	user, userErr, sysErr, code := auth.GetCurrentUserFromDB(db, username, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	user, userErr, sysErr, code := auth.GetCurrentUserFromDB(db, username, timeout)
 	if userErr != nil || sysErr != nil {
 		return auth.CurrentUser{}, userErr, sysErr, code
 	}
@@ -1039,6 +1044,40 @@ func GetUserFromReq(w http.ResponseWriter, r *http.Request, secret string) (auth
 	return user, nil, nil, http.StatusOK
 }
 
+// getBearerToken returns the API token from the request's "Authorization: Bearer" header, and whether there was one.
+// Bearer credentials which aren't Traffic Ops API tokens - e.g. those meant for a proxy in front of Traffic Ops - are
+// ignored, so that the request is authenticated by its cookie instead.
+func getBearerToken(r *http.Request) (string, bool) {
+	const prefix = "bearer "
+	hdr := strings.TrimSpace(r.Header.Get("Authorization"))
+	if len(hdr) <= len(prefix) || !strings.EqualFold(hdr[:len(prefix)], prefix) {
+		return "", false
+	}
+	token := strings.TrimSpace(hdr[len(prefix):])
+	if !strings.HasPrefix(token, auth.APITokenPrefix) {
+		return "", false
+	}
+	return token, true
+}
+
+// getReqDBAndTimeout returns the database and the configured query timeout from the request context.
+func getReqDBAndTimeout(r *http.Request) (*sqlx.DB, time.Duration, error) {
+	val := r.Context().Value(DBContextKey)
+	if val == nil {
+		return nil, 0, errors.New("request context db missing")
+	}
+	db, ok := val.(*sqlx.DB)
+	if !ok {
+		return nil, 0, fmt.Errorf("request context db unknown type %T", val)
+	}
+
+	cfg, err := GetConfig(r.Context())
+	if err != nil {
+		return nil, 0, errors.New("request context config missing")
+	}
+	return db, time.Duration(cfg.DBQueryTimeoutSeconds) * time.Second, nil
+}
+
 func AddUserToReq(r *http.Request, u auth.CurrentUser) {
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, auth.CurrentUserKey, u)
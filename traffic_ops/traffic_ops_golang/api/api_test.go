@@ -33,6 +33,31 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-tc"
 )
 
+func TestGetBearerToken(t *testing.T) {
+	for hdr, expected := range map[string]string{
+		"":                       "",
+		"Bearer":                 "",
+		"Basic dXNlcjpwYXNz":     "",
+		"Bearer some.jwt.value":  "",
+		"Bearer totk_abc":        "totk_abc",
+		"bearer   totk_abc  ":    "totk_abc",
+		"BEARER totk_abc":        "totk_abc",
+		"Bearer not-totk_prefix": "",
+	} {
+		r, err := http.NewRequest(http.MethodGet, "https://to.test/api/4.0/cdns", nil)
+		if err != nil {
+			t.Fatalf("creating request: %v", err)
+		}
+		if hdr != "" {
+			r.Header.Set("Authorization", hdr)
+		}
+		token, ok := getBearerToken(r)
+		if token != expected || ok != (expected != "") {
+			t.Errorf("Authorization '%s': expected token '%s', actual: '%s' (ok: %t)", hdr, expected, token, ok)
+		}
+	}
+}
+
 func TestCamelCase(t *testing.T) {
 	testStrings := []string{"hello_world", "trailing_underscore_", "w_h_a_t____"}
 	expected := []string{"helloWorld", "trailingUnderscore", "wHAT"}
@@ -21,6 +21,7 @@ package api
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"time"
@@ -32,6 +33,7 @@ const (
 	AsyncSucceeded = "SUCCEEDED"
 	AsyncFailed    = "FAILED"
 	AsyncPending   = "PENDING"
+	AsyncCanceled  = "CANCELED"
 )
 
 const CurrentAsyncEndpoint = "/api/4.0/async_status/"
@@ -42,9 +44,17 @@ type AsyncStatus struct {
 	StartTime time.Time  `json:"start_time, omitempty" db:"start_time"`
 	EndTime   *time.Time `json:"end_time, omitempty" db:"end_time"`
 	Message   *string    `json:"message, omitempty" db:"message"`
+	// Kind is the kind of job queued through the asyncjob package, or nil for other asynchronous operations.
+	Kind *string `json:"kind,omitempty" db:"kind"`
+	// Progress is the percentage of the job which is complete, as reported by the job.
+	Progress int `json:"progress" db:"progress"`
+	// CancelRequested is whether cancellation of the job has been requested. A job which is already running may finish before it notices.
+	CancelRequested bool `json:"cancelRequested" db:"cancel_requested"`
+	// Result is the result of a succeeded job, if it has one.
+	Result *json.RawMessage `json:"result,omitempty" db:"result"`
 }
 
-const selectAsyncStatusQuery = `SELECT id, status, message, start_time, end_time from async_status WHERE id = $1`
+const selectAsyncStatusQuery = `SELECT id, status, message, start_time, end_time, kind, progress, cancel_requested, result from async_status WHERE id = $1`
 const insertAsyncStatusQuery = `INSERT INTO async_status (status, message) VALUES ($1, $2) RETURNING id`
 const updateAsyncStatusEndTimeQuery = `UPDATE async_status SET status = $1, message = $2, end_time = now() WHERE id = $3`
 const updateAsyncStatusQuery = `UPDATE async_status SET status = $1, message = $2 WHERE id = $3`
@@ -71,7 +81,7 @@ func GetAsyncStatus(w http.ResponseWriter, r *http.Request) {
 	rowCount := 0
 	for rows.Next() {
 		rowCount++
-		err := rows.Scan(&asyncStatus.Id, &asyncStatus.Status, &asyncStatus.Message, &asyncStatus.StartTime, &asyncStatus.EndTime)
+		err := rows.Scan(&asyncStatus.Id, &asyncStatus.Status, &asyncStatus.Message, &asyncStatus.StartTime, &asyncStatus.EndTime, &asyncStatus.Kind, &asyncStatus.Progress, &asyncStatus.CancelRequested, &asyncStatus.Result)
 		if err != nil {
 			HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
 			return
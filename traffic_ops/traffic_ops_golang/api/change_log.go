@@ -20,9 +20,12 @@ package api
  */
 
 import (
+	"bytes"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
@@ -51,21 +54,27 @@ const (
 )
 
 func CreateChangeLog(level string, action string, i Identifier, user *auth.CurrentUser, tx *sql.Tx) error {
-	t, ok := i.(ChangeLogger)
-	if !ok {
-		keys, _ := i.GetKeys()
-		return CreateChangeLogBuildMsg(level, action, user, tx, i.GetType(), i.GetAuditName(), keys)
-	}
-	msg, err := t.ChangeLogMessage(action)
-	if err != nil {
+	return CreateChangeLogRawErr(level, changeLogMessage(action, i), user, tx)
+}
+
+// changeLogMessage returns the change log message for the given action on the given object, from its ChangeLogMessage if it's a ChangeLogger.
+func changeLogMessage(action string, i Identifier) string {
+	if t, ok := i.(ChangeLogger); ok {
+		msg, err := t.ChangeLogMessage(action)
+		if err == nil {
+			return msg
+		}
 		log.Errorf("%++v creating log message for %++v", err, t)
-		keys, _ := i.GetKeys()
-		return CreateChangeLogBuildMsg(level, action, user, tx, i.GetType(), i.GetAuditName(), keys)
 	}
-	return CreateChangeLogRawErr(level, msg, user, tx)
+	keys, _ := i.GetKeys()
+	return buildChangeLogMsg(action, i.GetType(), i.GetAuditName(), keys)
 }
 
 func CreateChangeLogBuildMsg(level string, action string, user *auth.CurrentUser, tx *sql.Tx, objType string, auditName string, keys map[string]interface{}) error {
+	return CreateChangeLogRawErr(level, buildChangeLogMsg(action, objType, auditName, keys), user, tx)
+}
+
+func buildChangeLogMsg(action string, objType string, auditName string, keys map[string]interface{}) string {
 	keyStr := "{ "
 	for key, value := range keys {
 		keyStr += key + ":" + fmt.Sprintf("%v", value) + " "
@@ -75,8 +84,7 @@ func CreateChangeLogBuildMsg(level string, action string, user *auth.CurrentUser
 	if !ok {
 		id = "N/A"
 	}
-	msg := fmt.Sprintf("%v: %v, ID: %v, ACTION: %v %v, keys: %v", strings.ToTitle(objType), auditName, id, strings.Title(action), objType, keyStr)
-	return CreateChangeLogRawErr(level, msg, user, tx)
+	return fmt.Sprintf("%v: %v, ID: %v, ACTION: %v %v, keys: %v", strings.ToTitle(objType), auditName, id, strings.Title(action), objType, keyStr)
 }
 
 func CreateChangeLogRawErr(level string, msg string, user *auth.CurrentUser, tx *sql.Tx) error {
@@ -86,8 +94,220 @@ func CreateChangeLogRawErr(level string, msg string, user *auth.CurrentUser, tx
 	return nil
 }
 
+// CreateChangeLogRawID creates a change log entry with the given level and message, returning its ID.
+func CreateChangeLogRawID(level string, msg string, user *auth.CurrentUser, tx *sql.Tx) (int64, error) {
+	id := int64(0)
+	if err := tx.QueryRow(`INSERT INTO log (level, message, tm_user) VALUES ($1, $2, $3) RETURNING id`, level, msg, user.ID).Scan(&id); err != nil {
+		return 0, errors.New("Inserting change log level '" + level + "' message '" + msg + "' user '" + user.UserName + "': " + err.Error())
+	}
+	return id, nil
+}
+
 func CreateChangeLogRawTx(level string, msg string, user *auth.CurrentUser, tx *sql.Tx) {
 	if _, err := tx.Exec(`INSERT INTO log (level, message, tm_user) VALUES ($1, $2, $3)`, level, msg, user.ID); err != nil {
 		log.Errorln("Inserting change log level '" + level + "' message '" + msg + "' user '" + user.UserName + "': " + err.Error())
 	}
 }
+
+// Audit is the structured record of a change to a single object, which is stored in the change log along with its message.
+type Audit struct {
+	// Action is the change which was made, typically Created, Updated, or Deleted.
+	Action     string
+	ObjectType string
+	ObjectID   string
+	// CDNName is the name of the CDN of the object, which is used to match webhooks. If nil, it's taken from the "cdnName" of the object, if it has one.
+	CDNName *string
+	// TenantID is the ID of the Tenant of the object, which restricts who may read the audit record, and is used to match webhooks. If nil, it's taken from the "tenantId" of the object, if it has one; objects with no Tenant are visible to all Tenants.
+	TenantID *int
+	// Before is the object before the change, or nil if it didn't exist or couldn't be read.
+	Before interface{}
+	// After is the object after the change, or nil if it was deleted.
+	After interface{}
+}
+
+// CreateAuditLog creates a change log entry with the given message and structured audit record, along with the user, request ID, and API version of the given request, and the Tenant of the changed object.
+// The change is also queued for delivery to any webhooks whose filters match it.
+func CreateAuditLog(level string, msg string, audit Audit, inf *APIInfo) error {
+	apiVersion := interface{}(nil)
+	if inf.Version != nil {
+		apiVersion = fmt.Sprintf("%d.%d", inf.Version.Major, inf.Version.Minor)
+	}
+	qry := `
+INSERT INTO log (level, message, tm_user, action, object_type, object_id, tenant_id, request_id, api_version, before, after)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+	before := auditJSON(audit.Before)
+	after := auditJSON(audit.After)
+	tenantID := audit.TenantID
+	if tenantID == nil {
+		tenantID = auditObjectTenant(auditRawJSON(after))
+	}
+	if tenantID == nil {
+		tenantID = auditObjectTenant(auditRawJSON(before))
+	}
+	if _, err := inf.Tx.Tx.Exec(qry, level, msg, inf.User.ID, audit.Action, audit.ObjectType, audit.ObjectID, tenantID, int64(inf.ReqID), apiVersion, before, after); err != nil {
+		return errors.New("Inserting audit log level '" + level + "' message '" + msg + "' user '" + inf.User.UserName + "': " + err.Error())
+	}
+	ev := tc.WebhookEvent{
+		Action:     audit.Action,
+		ObjectType: audit.ObjectType,
+		ObjectID:   audit.ObjectID,
+		CDNName:    audit.CDNName,
+		TenantID:   tenantID,
+		Before:     auditRawJSON(before),
+		After:      auditRawJSON(after),
+	}
+	return NotifyWebhooks(inf, ev)
+}
+
+// CreateAuditLogTx is like CreateAuditLog, but logs any error rather than returning it.
+func CreateAuditLogTx(level string, msg string, audit Audit, inf *APIInfo) {
+	if err := CreateAuditLog(level, msg, audit, inf); err != nil {
+		log.Errorln(err.Error())
+	}
+}
+
+// AuditTenanter is implemented by objects whose Tenant, for the audit log, isn't their "tenantId".
+type AuditTenanter interface {
+	// AuditTenantID returns the ID of the Tenant of the object, or nil if it has none.
+	AuditTenantID() *int
+}
+
+// CreateChangeLogAudit is like CreateChangeLog, but also records the given object's state before the change, and its state after the change unless it was deleted.
+func CreateChangeLogAudit(level string, action string, i Identifier, before interface{}, inf *APIInfo) error {
+	audit := Audit{
+		Action:     action,
+		ObjectType: i.GetType(),
+		ObjectID:   auditObjectID(i),
+		Before:     before,
+	}
+	if t, ok := i.(AuditTenanter); ok {
+		audit.TenantID = t.AuditTenantID()
+	}
+	if action != Deleted {
+		audit.After = i
+	}
+	return CreateAuditLog(level, changeLogMessage(action, i), audit, inf)
+}
+
+// auditObjectID returns the ID of the given object, or if it has no "id" key, its keys in the form "key:value", sorted and comma-delimited.
+func auditObjectID(i Identifier) string {
+	keys, _ := i.GetKeys()
+	if id, ok := keys["id"]; ok {
+		return fmt.Sprintf("%v", id)
+	}
+	strs := make([]string, 0, len(keys))
+	for key, val := range keys {
+		strs = append(strs, key+":"+fmt.Sprintf("%v", val))
+	}
+	sort.Strings(strs)
+	return strings.Join(strs, ",")
+}
+
+// auditRedacted replaces the values of secret properties in audit records and webhook payloads, which are readable by
+// any user who can read the change log.
+const auditRedacted = "********"
+
+// auditSecretKeys are the (lower-cased) names of object properties whose values are secrets, in addition to any whose
+// names contain one of auditSecretKeyParts.
+var auditSecretKeys = map[string]struct{}{
+	"key":        {},
+	"keystore":   {},
+	"private":    {},
+	"privatekey": {},
+	"token":      {},
+}
+
+var auditSecretKeyParts = []string{"passw", "secret"}
+
+// isAuditSecretKey returns whether the given object property name is that of a secret, which must not be recorded in
+// the change log.
+func isAuditSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := auditSecretKeys[key]; ok {
+		return true
+	}
+	for _, part := range auditSecretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactAuditSecrets replaces the values of the secret properties of the given decoded JSON value, at any depth, with
+// auditRedacted. Null, empty, and boolean values - e.g. "hasSecret" - are left as they are, since they reveal nothing.
+// The "value" of an object whose "secure" is true, i.e. a secure Parameter, is also a secret.
+func redactAuditSecrets(val interface{}) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		secure, _ := v["secure"].(bool)
+		for key, child := range v {
+			if !isAuditSecretKey(key) && !(secure && key == "value") {
+				redactAuditSecrets(child)
+				continue
+			}
+			switch child.(type) {
+			case nil, bool:
+			default:
+				if child != "" {
+					v[key] = auditRedacted
+				}
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			redactAuditSecrets(child)
+		}
+	}
+}
+
+// auditJSON returns the JSON of the given object, with its secrets redacted, to be inserted into a JSON column, or nil if the object is nil or can't be serialized.
+func auditJSON(obj interface{}) interface{} {
+	if obj == nil {
+		return nil
+	}
+	bts, err := json.Marshal(obj)
+	if err != nil {
+		log.Errorf("serializing audit log object %T: %v\n", obj, err)
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(bts))
+	dec.UseNumber()
+	val := interface{}(nil)
+	if err := dec.Decode(&val); err != nil {
+		log.Errorf("decoding audit log object %T: %v\n", obj, err)
+		return nil
+	}
+	if val == nil {
+		return nil
+	}
+	redactAuditSecrets(val)
+	if bts, err = json.Marshal(val); err != nil {
+		log.Errorf("serializing redacted audit log object %T: %v\n", obj, err)
+		return nil
+	}
+	return string(bts)
+}
+
+// auditObjectTenant returns the "tenantId" of the given JSON object, or nil if it isn't an object, or doesn't have a numeric "tenantId".
+func auditObjectTenant(obj json.RawMessage) *int {
+	if len(obj) == 0 {
+		return nil
+	}
+	tenant := struct {
+		TenantID *int `json:"tenantId"`
+	}{}
+	if err := json.Unmarshal(obj, &tenant); err != nil {
+		return nil
+	}
+	return tenant.TenantID
+}
+
+// auditRawJSON returns the JSON returned by auditJSON as a json.RawMessage, which is nil if auditJSON returned nil.
+func auditRawJSON(obj interface{}) json.RawMessage {
+	if str, ok := obj.(string); ok {
+		return json.RawMessage(str)
+	}
+	return nil
+}
@@ -77,3 +77,146 @@ func TestCreateChangeLog(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+type testMultiKeyIdentifier struct {
+	testIdentifier
+	Name string
+}
+
+func (i *testMultiKeyIdentifier) GetKeys() (map[string]interface{}, bool) {
+	return map[string]interface{}{"name": i.Name, "serverId": i.ID}, true
+}
+
+func TestCreateChangeLogAudit(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	inf := APIInfo{
+		User:    &auth.CurrentUser{ID: 1, UserName: "admin", TenantID: 2},
+		ReqID:   42,
+		Version: &Version{Major: 4, Minor: 0},
+		Tx:      db.MustBegin(),
+	}
+
+	i := testMultiKeyIdentifier{testIdentifier: testIdentifier{ID: 3}, Name: "foo"}
+	before := map[string]interface{}{"name": "bar"}
+	expectedMessage := "TESTER: testerInstance, ID: N/A, ACTION: " + Updated + " tester, keys: { "
+	mock.ExpectExec("INSERT").WithArgs(ApiChange, sqlmock.AnyArg(), 1, Updated, "tester", "name:foo,serverId:3", nil, 42, "4.0", `{"name":"bar"}`, `{"ID":3,"Name":"foo"}`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs("tester.Updated", sqlmock.AnyArg(), "tester", Updated, nil, nil).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := CreateChangeLogAudit(ApiChange, Updated, &i, before, &inf); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	mock.ExpectExec("INSERT").WithArgs(ApiChange, sqlmock.AnyArg(), 1, Deleted, "tester", "name:foo,serverId:3", nil, 42, "4.0", `{"name":"bar"}`, nil).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs("tester.Deleted", sqlmock.AnyArg(), "tester", Deleted, nil, nil).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := CreateChangeLogAudit(ApiChange, Deleted, &i, before, &inf); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	if msg := changeLogMessage(Updated, &i); !strings.HasPrefix(msg, expectedMessage) {
+		t.Errorf("expected message to start with '%s', actual: '%s'", expectedMessage, msg)
+	}
+}
+
+type testTenantedIdentifier struct {
+	testIdentifier
+	TenantID int `json:"tenantId"`
+}
+
+type testAuditTenanter struct {
+	testIdentifier
+}
+
+func (i *testAuditTenanter) AuditTenantID() *int {
+	return &i.ID
+}
+
+func TestCreateChangeLogAuditTenant(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	inf := APIInfo{
+		User:    &auth.CurrentUser{ID: 1, UserName: "admin", TenantID: 2},
+		ReqID:   42,
+		Version: &Version{Major: 4, Minor: 0},
+		Tx:      db.MustBegin(),
+	}
+
+	tenanted := testTenantedIdentifier{testIdentifier: testIdentifier{ID: 3}, TenantID: 5}
+	mock.ExpectExec("INSERT INTO log").WithArgs(ApiChange, sqlmock.AnyArg(), 1, Created, "tester", "3", 5, 42, "4.0", nil, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs("tester.Created", sqlmock.AnyArg(), "tester", Created, nil, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := CreateChangeLogAudit(ApiChange, Created, &tenanted, nil, &inf); err != nil {
+		t.Fatalf("object tenant: %v", err)
+	}
+
+	mock.ExpectExec("INSERT INTO log").WithArgs(ApiChange, sqlmock.AnyArg(), 1, Deleted, "tester", "3", 5, 42, "4.0", sqlmock.AnyArg(), nil).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs("tester.Deleted", sqlmock.AnyArg(), "tester", Deleted, nil, 5).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := CreateChangeLogAudit(ApiChange, Deleted, &tenanted, tenanted, &inf); err != nil {
+		t.Fatalf("deleted object tenant: %v", err)
+	}
+
+	tenanter := testAuditTenanter{testIdentifier: testIdentifier{ID: 7}}
+	mock.ExpectExec("INSERT INTO log").WithArgs(ApiChange, sqlmock.AnyArg(), 1, Updated, "tester", "7", 7, 42, "4.0", nil, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs("tester.Updated", sqlmock.AnyArg(), "tester", Updated, nil, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := CreateChangeLogAudit(ApiChange, Updated, &tenanter, nil, &inf); err != nil {
+		t.Fatalf("audit tenanter: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAuditJSONRedactsSecrets(t *testing.T) {
+	obj := map[string]interface{}{
+		"username":           "admin",
+		"localPasswd":        "hunter2",
+		"confirmLocalPasswd": "hunter2",
+		"iloPassword":        "ilo",
+		"xmppPasswd":         "xmpp",
+		"hasSecret":          true,
+		"secret":             "",
+		"tcpPort":            80,
+		"interfaces":         []interface{}{map[string]interface{}{"name": "eth0", "password": "p"}},
+		"certificate":        map[string]interface{}{"crt": "public", "key": "private"},
+		"privateKey":         nil,
+	}
+	expected := `{"certificate":{"crt":"public","key":"********"},"confirmLocalPasswd":"********","hasSecret":true,"iloPassword":"********","interfaces":[{"name":"eth0","password":"********"}],"localPasswd":"********","privateKey":null,"secret":"","tcpPort":80,"username":"admin","xmppPasswd":"********"}`
+	if actual := auditJSON(obj); actual != expected {
+		t.Errorf("expected: %s, actual: %v", expected, actual)
+	}
+
+	params := []interface{}{
+		map[string]interface{}{"name": "url_sig_key", "secure": true, "value": "sig-key"},
+		map[string]interface{}{"name": "location", "secure": false, "value": "/etc"},
+		map[string]interface{}{"name": "empty", "secure": true, "value": ""},
+	}
+	expected = `[{"name":"url_sig_key","secure":true,"value":"********"},{"name":"location","secure":false,"value":"/etc"},{"name":"empty","secure":true,"value":""}]`
+	if actual := auditJSON(params); actual != expected {
+		t.Errorf("secure parameters: expected: %s, actual: %v", expected, actual)
+	}
+
+	if actual := auditJSON(nil); actual != nil {
+		t.Errorf("expected nil for a nil object, actual: %v", actual)
+	}
+	if actual := auditJSON((*testIdentifier)(nil)); actual != nil {
+		t.Errorf("expected nil for a nil pointer, actual: %v", actual)
+	}
+}
@@ -298,13 +298,14 @@ func UpdateHandler(updater Updater) http.HandlerFunc {
 			}
 		}
 
+		before := readAuditBefore(objectType, obj, inf)
 		userErr, sysErr, errCode = obj.Update(r.Header)
 		if userErr != nil || sysErr != nil {
 			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
 			return
 		}
 
-		if err := CreateChangeLog(ApiChange, Updated, obj, inf.User, inf.Tx.Tx); err != nil {
+		if err := CreateChangeLogAudit(ApiChange, Updated, obj, before, inf); err != nil {
 			HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, tc.DBError, errors.New("inserting changelog: "+err.Error()))
 			return
 		}
@@ -401,6 +402,7 @@ func DeleteHandler(deleter Deleter) http.HandlerFunc {
 			}
 		}
 
+		before := readAuditBefore(objectType, obj, inf)
 		if isOptionsDeleter {
 			obj := reflect.New(objectType).Interface().(OptionsDeleter)
 			obj.SetInfo(inf)
@@ -414,7 +416,7 @@ func DeleteHandler(deleter Deleter) http.HandlerFunc {
 		}
 
 		log.Debugf("changelog for delete on object")
-		if err := CreateChangeLog(ApiChange, Deleted, obj, inf.User, inf.Tx.Tx); err != nil {
+		if err := CreateChangeLogAudit(ApiChange, Deleted, obj, before, inf); err != nil {
 			HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("inserting changelog: "+err.Error()))
 			return
 		}
@@ -507,6 +509,7 @@ func DeprecatedDeleteHandler(deleter Deleter, alternative *string) http.HandlerF
 			}
 		}
 
+		before := readAuditBefore(objectType, obj, inf)
 		if isOptionsDeleter {
 			obj := reflect.New(objectType).Interface().(OptionsDeleter)
 			obj.SetInfo(inf)
@@ -521,7 +524,7 @@ func DeprecatedDeleteHandler(deleter Deleter, alternative *string) http.HandlerF
 		}
 
 		log.Debugf("changelog for delete on object")
-		if err := CreateChangeLog(ApiChange, Deleted, obj, inf.User, inf.Tx.Tx); err != nil {
+		if err := CreateChangeLogAudit(ApiChange, Deleted, obj, before, inf); err != nil {
 			HandleDeprecatedErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("inserting changelog: "+err.Error()), alternative)
 			return
 		}
@@ -596,7 +599,7 @@ func CreateHandler(creator Creator) http.HandlerFunc {
 					return
 				}
 
-				if err = CreateChangeLog(ApiChange, Created, objElem, inf.User, inf.Tx.Tx); err != nil {
+				if err = CreateChangeLogAudit(ApiChange, Created, objElem, nil, inf); err != nil {
 					HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, tc.DBError, errors.New("inserting changelog: "+err.Error()))
 					return
 				}
@@ -648,7 +651,7 @@ func CreateHandler(creator Creator) http.HandlerFunc {
 				return
 			}
 
-			if err = CreateChangeLog(ApiChange, Created, obj, inf.User, inf.Tx.Tx); err != nil {
+			if err = CreateChangeLogAudit(ApiChange, Created, obj, nil, inf); err != nil {
 				HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, tc.DBError, errors.New("inserting changelog: "+err.Error()))
 				return
 			}
@@ -661,6 +664,38 @@ func CreateHandler(creator Creator) http.HandlerFunc {
 	}
 }
 
+// readAuditBefore returns the current state of the object of the given type with the keys of the given object, to be recorded in the audit log as the object before it was changed.
+// The object is read by its keys alone, not the request's parameters, which may match other objects. It returns nil if the type isn't a Reader, the object has no keys, or the keys don't match exactly one object.
+func readAuditBefore(objectType reflect.Type, obj Identifier, inf *APIInfo) interface{} {
+	reader, ok := reflect.New(objectType).Interface().(Reader)
+	if !ok {
+		return nil
+	}
+	keys, ok := obj.GetKeys()
+	if !ok || len(keys) == 0 {
+		return nil
+	}
+	keyInf := *inf
+	keyInf.Params = make(map[string]string, len(keys))
+	keyInf.IntParams = map[string]int{}
+	for key, val := range keys {
+		keyInf.Params[key] = fmt.Sprint(val)
+		if intVal, ok := val.(int); ok {
+			keyInf.IntParams[key] = intVal
+		}
+	}
+	reader.SetInfo(&keyInf)
+	objs, userErr, sysErr, _, _ := reader.Read(http.Header{}, false)
+	if userErr != nil || sysErr != nil {
+		log.Warnf("reading %s for audit log: user error: %v, system error: %v\n", objectType.Name(), userErr, sysErr)
+		return nil
+	}
+	if len(objs) != 1 {
+		return nil
+	}
+	return objs[0]
+}
+
 func parseMultipleCreates(data []byte, desiredType reflect.Type, inf *APIInfo) ([]Creator, error) {
 	buf := ioutil.NopCloser(bytes.NewReader(data))
 
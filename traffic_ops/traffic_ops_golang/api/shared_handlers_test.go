@@ -24,6 +24,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
@@ -147,7 +148,8 @@ func TestCreateHandler(t *testing.T) {
 	keys, _ := typeRef.GetKeys()
 	expectedMessage := strings.ToUpper(typeRef.GetType()) + ": " + typeRef.GetAuditName() + ", ID: " + strconv.Itoa(keys["id"].(int)) + ", ACTION: " + Created + " " + typeRef.GetType() + ", keys: { id:" + strconv.Itoa(keys["id"].(int)) + " }"
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT").WithArgs(ApiChange, expectedMessage, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT").WithArgs(ApiChange, expectedMessage, 1, Created, "tester", "1", nil, 0, nil, nil, `{"ID":1}`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs("tester.Created", sqlmock.AnyArg(), "tester", Created, nil, nil).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectCommit()
 
 	createFunc(w, r)
@@ -279,7 +281,8 @@ func TestUpdateHandler(t *testing.T) {
 	keys, _ := typeRef.GetKeys()
 	expectedMessage := strings.ToUpper(typeRef.GetType()) + ": " + typeRef.GetAuditName() + ", ID: " + strconv.Itoa(keys["id"].(int)) + ", ACTION: " + Updated + " " + typeRef.GetType() + ", keys: { id:" + strconv.Itoa(keys["id"].(int)) + " }"
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT").WithArgs(ApiChange, expectedMessage, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT").WithArgs(ApiChange, expectedMessage, 1, Updated, "tester", "1", nil, 0, nil, `{"ID":1}`, `{"ID":1}`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs("tester.Updated", sqlmock.AnyArg(), "tester", Updated, nil, nil).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectCommit()
 
 	updateFunc(w, r)
@@ -325,7 +328,8 @@ func TestDeleteHandler(t *testing.T) {
 	keys, _ := typeRef.GetKeys()
 	expectedMessage := strings.ToUpper(typeRef.GetType()) + ": " + typeRef.GetAuditName() + ", ID: " + strconv.Itoa(keys["id"].(int)) + ", ACTION: " + Deleted + " " + typeRef.GetType() + ", keys: { id:" + strconv.Itoa(keys["id"].(int)) + " }"
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT").WithArgs(ApiChange, expectedMessage, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT").WithArgs(ApiChange, expectedMessage, 1, Deleted, "tester", "1", nil, 0, nil, `{"ID":1}`, nil).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs("tester.Deleted", sqlmock.AnyArg(), "tester", Deleted, nil, nil).WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectCommit()
 	deleteFunc(w, r)
 
@@ -335,3 +339,44 @@ func TestDeleteHandler(t *testing.T) {
 		t.Error("Expected body", body, "got", w.Body.String())
 	}
 }
+
+// auditBeforeTester reads the testers with the requested ID, or every tester if no ID is requested, so tests can check
+// which parameters readAuditBefore reads by.
+type auditBeforeTester struct {
+	tester
+}
+
+var auditBeforeTesters = []tester{{ID: 1}, {ID: 2}, {ID: 2}}
+
+func (i *auditBeforeTester) Read(h http.Header, useIMS bool) ([]interface{}, error, error, int, *time.Time) {
+	objs := []interface{}{}
+	for _, obj := range auditBeforeTesters {
+		if id, ok := i.APIInfo().IntParams["id"]; !ok || id == obj.ID {
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil, nil, http.StatusOK, nil
+}
+
+func TestReadAuditBefore(t *testing.T) {
+	objectType := reflect.TypeOf(auditBeforeTester{})
+	inf := APIInfo{
+		Params:    map[string]string{"id": "1", "cachegroupID": "5"},
+		IntParams: map[string]int{"id": 1, "cachegroupID": 5},
+		User:      &auth.CurrentUser{ID: 1},
+	}
+
+	before := readAuditBefore(objectType, &tester{ID: 1}, &inf)
+	if obj, ok := before.(tester); !ok || obj.ID != 1 {
+		t.Errorf("expected the tester with ID 1, actual: %+v", before)
+	}
+	if before := readAuditBefore(objectType, &tester{ID: 2}, &inf); before != nil {
+		t.Errorf("expected no object when the keys match multiple objects, actual: %+v", before)
+	}
+	if before := readAuditBefore(objectType, &tester{ID: 3}, &inf); before != nil {
+		t.Errorf("expected no object when the keys match no objects, actual: %+v", before)
+	}
+	if inf.Params["cachegroupID"] != "5" || inf.IntParams["id"] != 1 {
+		t.Errorf("expected the request parameters to be unchanged, actual: %+v, %+v", inf.Params, inf.IntParams)
+	}
+}
@@ -0,0 +1,84 @@
+package api
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+const notifyWebhooksQuery = `
+INSERT INTO webhook_delivery (webhook_id, event, payload)
+SELECT w.id, $1, $2
+FROM webhook AS w
+WHERE w.active
+AND (cardinality(w.object_types) = 0 OR $3 = ANY(w.object_types))
+AND (cardinality(w.actions) = 0 OR $4 = ANY(w.actions))
+AND (w.cdn IS NULL OR w.cdn = $5)
+AND (w.tenant_id IS NULL OR $6::bigint IS NULL OR w.tenant_id = $6)
+`
+
+// NotifyWebhooks queues the given event for delivery to every active webhook whose filters match it.
+//
+// The event's user, request ID, API version and time are set from the request. If its CDN isn't set, it's taken from the "cdnName" of the changed object, if it has one. Its tenant must be that of the changed object - the same as its audit log record - so tenant-scoped webhooks receive the changes to their own Tenants' objects, by whomever they're made, and the changes to objects with no Tenant.
+//
+// The deliveries are inserted in the request's transaction, so an event is only delivered if the change is committed. The delivery itself is done by the webhook package's worker.
+func NotifyWebhooks(inf *APIInfo, ev tc.WebhookEvent) error {
+	ev.Event = ev.ObjectType + "." + ev.Action
+	ev.User = inf.User.UserName
+	ev.RequestID = inf.ReqID
+	if inf.Version != nil {
+		ev.APIVersion = fmt.Sprintf("%d.%d", inf.Version.Major, inf.Version.Minor)
+	}
+	ev.Time = time.Now()
+	if ev.CDNName == nil {
+		ev.CDNName = webhookEventCDN(ev.After)
+	}
+	if ev.CDNName == nil {
+		ev.CDNName = webhookEventCDN(ev.Before)
+	}
+
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return errors.New("serializing webhook event: " + err.Error())
+	}
+	if _, err := inf.Tx.Tx.Exec(notifyWebhooksQuery, ev.Event, payload, ev.ObjectType, ev.Action, ev.CDNName, ev.TenantID); err != nil {
+		return errors.New("queueing webhook deliveries of event '" + ev.Event + "': " + err.Error())
+	}
+	return nil
+}
+
+// webhookEventCDN returns the "cdnName" of the given JSON object, or nil if it isn't an object, or doesn't have a non-empty string "cdnName".
+func webhookEventCDN(obj json.RawMessage) *string {
+	if len(obj) == 0 {
+		return nil
+	}
+	cdn := struct {
+		CDNName *string `json:"cdnName"`
+	}{}
+	if err := json.Unmarshal(obj, &cdn); err != nil || cdn.CDNName == nil || *cdn.CDNName == "" {
+		return nil
+	}
+	return cdn.CDNName
+}
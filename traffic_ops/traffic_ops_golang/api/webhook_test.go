@@ -0,0 +1,109 @@
+package api
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+)
+
+func TestWebhookEventCDN(t *testing.T) {
+	if cdn := webhookEventCDN(json.RawMessage(`{"xmlId":"demo1","cdnName":"CDN-in-a-Box"}`)); cdn == nil || *cdn != "CDN-in-a-Box" {
+		t.Errorf("webhookEventCDN expected: CDN-in-a-Box, actual: %v", cdn)
+	}
+	for _, obj := range []string{``, `null`, `[1,2]`, `{"cdnName":""}`, `{"cdnName":null}`, `{"name":"foo"}`} {
+		if cdn := webhookEventCDN(json.RawMessage(obj)); cdn != nil {
+			t.Errorf("webhookEventCDN(%s) expected: nil, actual: %s", obj, *cdn)
+		}
+	}
+}
+
+// noSecretsPayload matches a webhook payload which contains none of its secrets.
+type noSecretsPayload struct {
+	t       *testing.T
+	secrets []string
+}
+
+func (p noSecretsPayload) Match(v driver.Value) bool {
+	payload, ok := v.([]byte)
+	if !ok {
+		p.t.Errorf("expected a []byte webhook payload, actual: %T", v)
+		return false
+	}
+	for _, secret := range p.secrets {
+		if strings.Contains(string(payload), secret) {
+			p.t.Errorf("webhook payload contains the secret '%s': %s", secret, payload)
+		}
+	}
+	return true
+}
+
+func TestWebhookPayloadsRedactSecrets(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	inf := APIInfo{
+		User:    &auth.CurrentUser{ID: 1, UserName: "admin", TenantID: 1},
+		Version: &Version{Major: 4, Minor: 0},
+		Tx:      db.MustBegin(),
+	}
+
+	user := tc.User{
+		Username:             util.StrPtr("automation"),
+		LocalPassword:        util.StrPtr("user-password-1"),
+		ConfirmLocalPassword: util.StrPtr("user-password-1"),
+	}
+	server := tc.ServerV40{}
+	server.HostName = util.StrPtr("edge")
+	server.CDNName = util.StrPtr("cdn")
+	server.ILOPassword = util.StrPtr("ilo-password-2")
+	server.XMPPPasswd = util.StrPtr("xmpp-password-3")
+	secrets := noSecretsPayload{t: t, secrets: []string{"user-password-1", "ilo-password-2", "xmpp-password-3"}}
+
+	for _, audit := range []Audit{
+		{Action: Created, ObjectType: "user", ObjectID: "2", After: user},
+		{Action: Updated, ObjectType: "server", ObjectID: "3", Before: server, After: server},
+	} {
+		mock.ExpectExec("INSERT INTO log").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO webhook_delivery").WithArgs(audit.ObjectType+"."+audit.Action, secrets, audit.ObjectType, audit.Action, sqlmock.AnyArg(), nil).WillReturnResult(sqlmock.NewResult(0, 0))
+		if err := CreateAuditLog(ApiChange, "changed", audit, &inf); err != nil {
+			t.Fatalf("%s %s: %v", audit.Action, audit.ObjectType, err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,133 @@
+package apitenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// quota.go defines the handlers of the api/.../tenants/{id}/quota endpoints
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+// quotaAuditObjectType is the object type of tenant quotas in the audit log.
+const quotaAuditObjectType = "tenantQuota"
+
+const upsertQuotaQuery = `
+INSERT INTO tenant_quota (tenant_id, max_delivery_services, max_jobs_per_day, max_regexes_per_ds, max_bandwidth_mbps)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (tenant_id) DO UPDATE SET
+  max_delivery_services = EXCLUDED.max_delivery_services,
+  max_jobs_per_day = EXCLUDED.max_jobs_per_day,
+  max_regexes_per_ds = EXCLUDED.max_regexes_per_ds,
+  max_bandwidth_mbps = EXCLUDED.max_bandwidth_mbps,
+  last_updated = now()
+`
+
+// checkQuotaTenant returns the quota report of the tenant with the given ID, or an error if it doesn't exist or the
+// user isn't authorized on it.
+func checkQuotaTenant(inf *api.APIInfo, id int) (tc.TenantQuotaReport, error, error, int) {
+	if ok, err := tenant.IsResourceAuthorizedToUserTx(id, inf.User, inf.Tx.Tx); err != nil {
+		return tc.TenantQuotaReport{}, nil, errors.New("checking tenant authorization: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return tc.TenantQuotaReport{}, errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+	}
+	report, ok, err := tenant.GetQuotaReport(inf.Tx.Tx, id)
+	if err != nil {
+		return report, nil, errors.New("getting tenant quota report: " + err.Error()), http.StatusInternalServerError
+	}
+	if !ok {
+		return report, errors.New("no such tenant"), nil, http.StatusNotFound
+	}
+	return report, nil, nil, http.StatusOK
+}
+
+// GetQuota is the handler for GET requests to /tenants/{id}/quota.
+func GetQuota(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	report, userErr, sysErr, errCode := checkQuotaTenant(inf, inf.IntParams["id"])
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, report)
+}
+
+// UpdateQuota is the handler for PUT requests to /tenants/{id}/quota.
+// Users can't change the quota of their own tenant, unless it's the root tenant, so a tenant's quota is always set
+// by the users of its ancestors.
+func UpdateQuota(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	id := inf.IntParams["id"]
+	before, userErr, sysErr, errCode := checkQuotaTenant(inf, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if id == inf.User.TenantID && before.TenantName != rootName {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("cannot change the quota of your own tenant"), nil)
+		return
+	}
+
+	quota := tc.TenantQuota{}
+	if userErr = api.Parse(r.Body, tx, &quota); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if _, err := tx.Exec(upsertQuotaQuery, id, quota.MaxDeliveryServices, quota.MaxJobsPerDay, quota.MaxRegexesPerDeliveryService, quota.MaxBandwidthMbps); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	report, ok, err := tenant.GetQuotaReport(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting updated tenant quota report: "+err.Error()))
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting updated tenant quota report: tenant not found"))
+		return
+	}
+
+	audit := api.Audit{Action: api.Updated, ObjectType: quotaAuditObjectType, ObjectID: strconv.Itoa(id), TenantID: &id, Before: before.Quota, After: report.Quota}
+	if err := api.CreateAuditLog(api.ApiChange, "TENANT: "+report.TenantName+", ID: "+strconv.Itoa(id)+", ACTION: Updated quota", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "tenant quota was updated.", report)
+}
@@ -134,6 +134,9 @@ func (ten TOTenant) Validate() error {
 
 func (ten *TOTenant) Create() (error, error, int) { return api.GenericCreate(ten) }
 
+// AuditTenantID implements api.AuditTenanter; a Tenant's changes are visible to the users who can access the Tenant itself.
+func (ten *TOTenant) AuditTenantID() *int { return ten.ID }
+
 func (ten *TOTenant) Read(h http.Header, useIMS bool) ([]interface{}, error, error, int, *time.Time) {
 	if ten.APIInfo().User.TenantID == auth.TenantIDInvalid {
 		return nil, nil, nil, http.StatusOK, nil
@@ -0,0 +1,230 @@
+// Package asyncjob runs long-running operations outside of the requests that start them.
+//
+// Jobs are queued in the async_job table, in the same transaction as the request that queues them, and run by a pool of workers in each Traffic Ops instance. Their status, progress and result are reported through async_status, and they may be canceled by a DELETE request to async_status/{id}.
+//
+// Handlers offer to run an operation asynchronously when a client sends the "Prefer: respond-async" header (RFC 7240), by calling RespondAsync, which responds with 202 Accepted and the location of the job's async_status.
+package asyncjob
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PreferRespondAsync is the preference (RFC 7240 §4.1) with which clients ask for an operation to be run asynchronously.
+const PreferRespondAsync = "respond-async"
+
+// Func runs a job of some kind. Its changes must be made in job.Tx, which is committed only if it returns no errors and the job isn't canceled.
+// It returns the result of the job, which is stored as JSON in its async_status and may be nil, or else a user error, which is shown as the job's message, or a system error, which is logged.
+type Func func(job *Job) (interface{}, error, error)
+
+var (
+	funcs   = map[string]Func{}
+	funcsMu sync.RWMutex
+)
+
+// Register registers the function which runs jobs of the given kind. It is meant to be called from the init function of the package which queues them, and panics if a kind is registered twice.
+func Register(kind string, f Func) {
+	funcsMu.Lock()
+	defer funcsMu.Unlock()
+	if _, ok := funcs[kind]; ok {
+		panic("asyncjob: Register called twice for kind " + kind)
+	}
+	funcs[kind] = f
+}
+
+func getFunc(kind string) (Func, bool) {
+	funcsMu.RLock()
+	defer funcsMu.RUnlock()
+	f, ok := funcs[kind]
+	return f, ok
+}
+
+// Job is a job being run by a worker.
+type Job struct {
+	// ID is the ID of the job's async_status.
+	ID      int
+	Kind    string
+	Payload json.RawMessage
+	// User is the user who queued the job, as of when it started running.
+	User   *auth.CurrentUser
+	Tx     *sqlx.Tx
+	DB     *sqlx.DB
+	Config *config.Config
+	// Context is canceled when cancellation of the job is requested. Database operations in Tx fail once it is.
+	Context context.Context
+}
+
+// APIInfo returns an APIInfo with the transaction, user, and configuration of the job, for calling the functions shared with API handlers.
+func (j *Job) APIInfo() *api.APIInfo {
+	return &api.APIInfo{
+		Params:    map[string]string{},
+		IntParams: map[string]int{},
+		User:      j.User,
+		Version:   &api.Version{Major: 4, Minor: 0},
+		Tx:        j.Tx,
+		Config:    j.Config,
+	}
+}
+
+// DecodePayload decodes the payload the job was queued with into v.
+func (j *Job) DecodePayload(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Progress reports the percentage of the job which is complete, along with a message describing what it is doing. It is visible immediately, rather than when the job's transaction is committed.
+func (j *Job) Progress(percent int, message string) error {
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	if _, err := j.DB.Exec(`UPDATE async_status SET progress = $1, message = $2 WHERE id = $3`, percent, message, j.ID); err != nil {
+		return fmt.Errorf("updating progress of job %d: %v", j.ID, err)
+	}
+	return nil
+}
+
+// Canceled returns whether cancellation of the job has been requested. Jobs which run for a long time outside of the database should check it periodically, and return when it is true.
+func (j *Job) Canceled() bool {
+	return j.Context.Err() != nil
+}
+
+// PrefersAsync returns whether the request asks for its operation to be run asynchronously, with the respond-async preference of a Prefer header.
+func PrefersAsync(r *http.Request) bool {
+	for _, header := range r.Header.Values("Prefer") {
+		for _, pref := range strings.Split(header, ",") {
+			token := pref
+			if i := strings.IndexAny(token, "=;"); i >= 0 {
+				token = token[:i]
+			}
+			if strings.EqualFold(strings.TrimSpace(token), PreferRespondAsync) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Enqueue queues a job of the given kind, to be run with the given payload on behalf of the given user once tx is committed, and returns its async_status.
+func Enqueue(tx *sql.Tx, user *auth.CurrentUser, kind string, message string, payload interface{}) (api.AsyncStatus, error) {
+	status := api.AsyncStatus{Status: api.AsyncPending, Message: &message, Kind: &kind}
+	if _, ok := getFunc(kind); !ok {
+		return status, errors.New("no function is registered for jobs of kind " + kind)
+	}
+	bts, err := json.Marshal(payload)
+	if err != nil {
+		return status, errors.New("marshalling job payload: " + err.Error())
+	}
+	if err := tx.QueryRow(`INSERT INTO async_status (status, message, kind, username) VALUES ($1, $2, $3, $4) RETURNING id, start_time`, api.AsyncPending, message, kind, user.UserName).Scan(&status.Id, &status.StartTime); err != nil {
+		return status, errors.New("inserting async status: " + err.Error())
+	}
+	if _, err := tx.Exec(`INSERT INTO async_job (async_status_id, kind, payload, username) VALUES ($1, $2, $3, $4)`, status.Id, kind, bts, user.UserName); err != nil {
+		return status, errors.New("inserting async job: " + err.Error())
+	}
+	return status, nil
+}
+
+// RespondAsync queues a job of the given kind on behalf of the user of inf, and responds with 202 Accepted, the location of the job's async_status, and the given message.
+// The job only runs if the transaction of inf is committed, so handlers should validate the request before calling it.
+func RespondAsync(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, kind string, message string, payload interface{}) {
+	status, err := Enqueue(inf.Tx.Tx, inf.User, kind, message, payload)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	w.Header().Set("Preference-Applied", PreferRespondAsync)
+	w.Header().Set("Location", api.CurrentAsyncEndpoint+strconv.Itoa(status.Id))
+	api.WriteAlertsObj(w, r, http.StatusAccepted, tc.CreateAlerts(tc.InfoLevel, message), status)
+}
+
+// Cancel is the handler for DELETE requests to async_status/{id}, which cancels a queued job. Jobs which haven't started are canceled immediately; running jobs are canceled by their worker, which may take a few seconds, and some may finish before they notice.
+func Cancel(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	status := ""
+	kind := sql.NullString{}
+	username := sql.NullString{}
+	if err := inf.Tx.Tx.QueryRow(`SELECT status, kind, username FROM async_status WHERE id = $1`, id).Scan(&status, &kind, &username); err != nil {
+		if err == sql.ErrNoRows {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("async status not found"), nil)
+			return
+		}
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting async status: "+err.Error()))
+		return
+	}
+	if !kind.Valid {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("only queued jobs can be canceled"), nil)
+		return
+	}
+	if status != api.AsyncPending {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("job has already finished"), nil)
+		return
+	}
+	if username.String != inf.User.UserName && inf.User.PrivLevel < auth.PrivLevelAdmin {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusForbidden, errors.New("only the user who queued a job, or an admin, may cancel it"), nil)
+		return
+	}
+
+	if _, err := inf.Tx.Tx.Exec(`UPDATE async_status SET cancel_requested = TRUE WHERE id = $1`, id); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("requesting job cancellation: "+err.Error()))
+		return
+	}
+	// jobs which aren't leased by a worker aren't running, so they can be canceled now
+	res, err := inf.Tx.Tx.Exec(`DELETE FROM async_job WHERE async_status_id = $1 AND (lease_until IS NULL OR lease_until < now())`, id)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting async job: "+err.Error()))
+		return
+	}
+	msg := "Cancellation of the running job was requested"
+	if deleted, err := res.RowsAffected(); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting deleted async jobs: "+err.Error()))
+		return
+	} else if deleted > 0 {
+		msg = "Job canceled"
+		if _, err := inf.Tx.Tx.Exec(`UPDATE async_status SET status = $1, message = $2, end_time = now() WHERE id = $3`, api.AsyncCanceled, "Canceled before it started.", id); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("updating async status: "+err.Error()))
+			return
+		}
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "ASYNC JOB: "+kind.String+", ID: "+strconv.Itoa(id)+", ACTION: Canceled", inf.User, inf.Tx.Tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, msg)
+}
@@ -0,0 +1,259 @@
+package asyncjob
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const testKind = "test"
+
+type testPayload struct {
+	Value string `json:"value"`
+}
+
+var testFunc Func = func(job *Job) (interface{}, error, error) {
+	return nil, nil, nil
+}
+
+func init() {
+	Register(testKind, func(job *Job) (interface{}, error, error) {
+		return testFunc(job)
+	})
+}
+
+func TestPrefersAsync(t *testing.T) {
+	tests := []struct {
+		headers  []string
+		expected bool
+	}{
+		{nil, false},
+		{[]string{"respond-async"}, true},
+		{[]string{"Respond-Async"}, true},
+		{[]string{"return=minimal, respond-async, wait=10"}, true},
+		{[]string{"return=minimal", "respond-async"}, true},
+		{[]string{"respond-async; foo=bar"}, true},
+		{[]string{"return=minimal"}, false},
+		{[]string{"respond-asynchronously"}, false},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		for _, h := range test.headers {
+			r.Header.Add("Prefer", h)
+		}
+		if actual := PrefersAsync(r); actual != test.expected {
+			t.Errorf("Prefer %v: expected %v, actual %v", test.headers, test.expected, actual)
+		}
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO async_status").WithArgs(api.AsyncPending, "queued", testKind, "bob").WillReturnRows(sqlmock.NewRows([]string{"id", "start_time"}).AddRow(7, time.Now()))
+	mock.ExpectExec("INSERT INTO async_job").WithArgs(7, testKind, []byte(`{"value":"x"}`), "bob").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	tx := db.MustBegin()
+	status, err := Enqueue(tx.Tx, &auth.CurrentUser{UserName: "bob"}, testKind, "queued", testPayload{Value: "x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Id != 7 || status.Status != api.AsyncPending {
+		t.Errorf("expected pending status 7, actual: %+v", status)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestEnqueueUnregisteredKind(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	mock.ExpectBegin()
+
+	if _, err := Enqueue(db.MustBegin().Tx, &auth.CurrentUser{UserName: "bob"}, "no-such-kind", "queued", nil); err == nil {
+		t.Error("expected an error queueing a job of an unregistered kind")
+	}
+}
+
+func TestRespondAsync(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO async_status").WillReturnRows(sqlmock.NewRows([]string{"id", "start_time"}).AddRow(12, time.Now()))
+	mock.ExpectExec("INSERT INTO async_job").WillReturnResult(sqlmock.NewResult(1, 1))
+
+	inf := &api.APIInfo{Tx: db.MustBegin(), User: &auth.CurrentUser{UserName: "bob"}}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/api/4.0/test", nil)
+	RespondAsync(w, r, inf, testKind, "queued", nil)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected status %d, actual: %d", http.StatusAccepted, w.Code)
+	}
+	if location := w.Header().Get("Location"); location != api.CurrentAsyncEndpoint+"12" {
+		t.Errorf("expected location %s12, actual: %s", api.CurrentAsyncEndpoint, location)
+	}
+	if applied := w.Header().Get("Preference-Applied"); applied != PreferRespondAsync {
+		t.Errorf("expected Preference-Applied %s, actual: %s", PreferRespondAsync, applied)
+	}
+	resp := struct {
+		Response api.AsyncStatus `json:"response"`
+	}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshalling response: %v", err)
+	}
+	if resp.Response.Id != 12 {
+		t.Errorf("expected async status 12 in the response, actual: %+v", resp.Response)
+	}
+}
+
+func TestRunSucceeded(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	testFunc = func(job *Job) (interface{}, error, error) {
+		payload := testPayload{}
+		if err := job.DecodePayload(&payload); err != nil {
+			return nil, nil, err
+		}
+		if _, err := job.Tx.Exec("UPDATE thing SET value = $1", payload.Value); err != nil {
+			return nil, nil, err
+		}
+		return payload, nil, nil
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE thing").WithArgs("x").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE async_status").WithArgs(api.AsyncSucceeded, sqlmock.AnyArg(), []byte(`{"value":"x"}`), 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM async_job").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	f, _ := getFunc(testKind)
+	q := queued{ID: 2, AsyncStatusID: 3, Kind: testKind, Payload: []byte(`{"value":"x"}`), Username: "bob", Attempts: 1}
+	userErr, sysErr := run(context.Background(), db, &config.Config{}, q, &auth.CurrentUser{UserName: "bob"}, f)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", userErr, sysErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestRunFailedRollsBack(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	testFunc = func(job *Job) (interface{}, error, error) {
+		return nil, errors.New("bad request"), nil
+	}
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	f, _ := getFunc(testKind)
+	userErr, sysErr := run(context.Background(), db, &config.Config{}, queued{ID: 2, AsyncStatusID: 3, Kind: testKind}, &auth.CurrentUser{}, f)
+	if userErr == nil || userErr.Error() != "bad request" || sysErr != nil {
+		t.Errorf("expected user error 'bad request', actual: %v, %v", userErr, sysErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestRunPanic(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	testFunc = func(job *Job) (interface{}, error, error) {
+		panic("oops")
+	}
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	f, _ := getFunc(testKind)
+	userErr, sysErr := run(context.Background(), db, &config.Config{}, queued{ID: 2, AsyncStatusID: 3, Kind: testKind}, &auth.CurrentUser{}, f)
+	if userErr != nil || sysErr == nil {
+		t.Errorf("expected a system error from a panicking job, actual: %v, %v", userErr, sysErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestFinish(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE async_status").WithArgs(api.AsyncCanceled, "Canceled.", nil, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM async_job").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	if err := finish(db, queued{ID: 2, AsyncStatusID: 3}, api.AsyncCanceled, "Canceled."); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
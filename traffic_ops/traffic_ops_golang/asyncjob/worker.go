@@ -0,0 +1,245 @@
+package asyncjob
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LeaseDuration is how long a worker holds a job without renewing its lease. Jobs whose lease expires, because the Traffic Ops instance running them stopped, are run again.
+const LeaseDuration = time.Minute
+
+// MaxAttempts is the number of times a job is started before it's considered to have failed, for jobs which keep stopping the instance running them.
+const MaxAttempts = 3
+
+// internalErrMessage is the message of jobs which fail with a system error, whose details are only logged.
+const internalErrMessage = "Internal error; see the Traffic Ops log for details."
+
+const claimQuery = `
+UPDATE async_job SET
+  lease_until = now() + ($1 * interval '1 second'),
+  attempts = attempts + 1
+WHERE id = (
+  SELECT id FROM async_job
+  WHERE lease_until IS NULL OR lease_until < now()
+  ORDER BY id
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING id, async_status_id, kind, payload, username, attempts
+`
+
+const renewQuery = `
+UPDATE async_job AS j SET lease_until = now() + ($1 * interval '1 second')
+FROM async_status AS s
+WHERE j.id = $2 AND s.id = j.async_status_id
+RETURNING s.cancel_requested
+`
+
+const finishQuery = `
+UPDATE async_status SET
+  status = $1,
+  message = $2,
+  result = $3,
+  progress = CASE WHEN $1 = '` + api.AsyncSucceeded + `' THEN 100 ELSE progress END,
+  end_time = now()
+WHERE id = $4
+`
+
+// queued is a job claimed from the queue.
+type queued struct {
+	ID            int
+	AsyncStatusID int
+	Kind          string
+	Payload       []byte
+	Username      string
+	Attempts      int
+}
+
+// Start starts the configured number of workers, which run queued jobs until Traffic Ops stops.
+func Start(db *sqlx.DB, cfg *config.Config) {
+	interval := time.Duration(cfg.AsyncJobPollIntervalSeconds) * time.Second
+	for i := 0; i < cfg.AsyncJobWorkers; i++ {
+		go func() {
+			for {
+				ran, err := runNext(db, cfg)
+				if err != nil {
+					log.Errorln("running async job: " + err.Error())
+				}
+				if !ran || err != nil {
+					time.Sleep(interval)
+				}
+			}
+		}()
+	}
+}
+
+// runNext claims and runs the next queued job. It returns false if there were no jobs to run.
+func runNext(db *sqlx.DB, cfg *config.Config) (bool, error) {
+	q := queued{}
+	if err := db.QueryRow(claimQuery, int64(LeaseDuration/time.Second)).Scan(&q.ID, &q.AsyncStatusID, &q.Kind, &q.Payload, &q.Username, &q.Attempts); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.New("claiming async job: " + err.Error())
+	}
+	log.Infof("running async job %d of kind %s, attempt %d", q.AsyncStatusID, q.Kind, q.Attempts)
+
+	if q.Attempts > MaxAttempts {
+		return true, finish(db, q, api.AsyncFailed, fmt.Sprintf("Abandoned after %d attempts.", MaxAttempts))
+	}
+	f, ok := getFunc(q.Kind)
+	if !ok {
+		return true, finish(db, q, api.AsyncFailed, "This version of Traffic Ops can't run jobs of kind "+q.Kind+".")
+	}
+	user, userErr, sysErr, _ := auth.GetCurrentUserFromDB(db, q.Username, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	if userErr != nil || sysErr != nil {
+		log.Errorf("getting user '%s' of async job %d: %v", q.Username, q.AsyncStatusID, sysErr)
+		return true, finish(db, q, api.AsyncFailed, "The user who queued the job no longer exists.")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stop := make(chan struct{})
+	go renewLease(db, q, cancel, stop)
+	userErr, sysErr = run(ctx, db, cfg, q, &user, f)
+	close(stop)
+
+	switch {
+	case ctx.Err() != nil:
+		return true, finish(db, q, api.AsyncCanceled, "Canceled.")
+	case userErr != nil:
+		return true, finish(db, q, api.AsyncFailed, userErr.Error())
+	case sysErr != nil:
+		log.Errorf("async job %d of kind %s: %v", q.AsyncStatusID, q.Kind, sysErr)
+		return true, finish(db, q, api.AsyncFailed, internalErrMessage)
+	}
+	return true, nil
+}
+
+// run runs a job in a transaction. If it succeeds, its async_status is updated and it's removed from the queue in the same transaction, so that it isn't run again once its changes are committed.
+func run(ctx context.Context, db *sqlx.DB, cfg *config.Config, q queued, user *auth.CurrentUser, f Func) (userErr error, sysErr error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.New("beginning transaction: " + err.Error())
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+				log.Errorf("rolling back async job %d: %v", q.AsyncStatusID, err)
+			}
+		}
+	}()
+	defer func() {
+		if err := recover(); err != nil {
+			userErr = nil
+			sysErr = fmt.Errorf("panic: %v\n%s", err, debug.Stack())
+		}
+	}()
+
+	job := &Job{
+		ID:      q.AsyncStatusID,
+		Kind:    q.Kind,
+		Payload: q.Payload,
+		User:    user,
+		Tx:      tx,
+		DB:      db,
+		Config:  cfg,
+		Context: ctx,
+	}
+	result, userErr, sysErr := f(job)
+	if userErr != nil || sysErr != nil || ctx.Err() != nil {
+		return userErr, sysErr
+	}
+
+	var resultJSON interface{}
+	if result != nil {
+		bts, err := json.Marshal(result)
+		if err != nil {
+			return nil, errors.New("marshalling result: " + err.Error())
+		}
+		resultJSON = bts
+	}
+	if _, err := tx.Exec(finishQuery, api.AsyncSucceeded, "Succeeded.", resultJSON, q.AsyncStatusID); err != nil {
+		return nil, errors.New("updating async status: " + err.Error())
+	}
+	if _, err := tx.Exec(`DELETE FROM async_job WHERE id = $1`, q.ID); err != nil {
+		return nil, errors.New("deleting async job: " + err.Error())
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, errors.New("committing transaction: " + err.Error())
+	}
+	committed = true
+	return nil, nil
+}
+
+// renewLease renews the lease of a running job until stop is closed, and calls cancel if cancellation of the job is requested.
+func renewLease(db *sqlx.DB, q queued, cancel context.CancelFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(LeaseDuration / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			cancelRequested := false
+			if err := db.QueryRow(renewQuery, int64(LeaseDuration/time.Second), q.ID).Scan(&cancelRequested); err != nil {
+				if err != sql.ErrNoRows {
+					log.Errorf("renewing lease of async job %d: %v", q.AsyncStatusID, err)
+				}
+				continue
+			}
+			if cancelRequested {
+				log.Infof("canceling async job %d", q.AsyncStatusID)
+				cancel()
+			}
+		}
+	}
+}
+
+// finish records the outcome of a job which didn't succeed, and removes it from the queue.
+func finish(db *sqlx.DB, q queued, status string, message string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.New("beginning transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec(finishQuery, status, message, nil, q.AsyncStatusID); err != nil {
+		return fmt.Errorf("updating async status %d: %v", q.AsyncStatusID, err)
+	}
+	if _, err := tx.Exec(`DELETE FROM async_job WHERE id = $1`, q.ID); err != nil {
+		return fmt.Errorf("deleting async job %d: %v", q.ID, err)
+	}
+	return tx.Commit()
+}
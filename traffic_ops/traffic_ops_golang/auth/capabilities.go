@@ -0,0 +1,82 @@
+package auth
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sort"
+)
+
+// IsAuthorized returns whether the given user may use a route which requires the given privilege level and
+// Capabilities.
+//
+// If useCapabilities is true, the route requires Capabilities, and the user's Role has any Capabilities at all, the
+// user is authorized if and only if they have every required Capability, regardless of their privilege level.
+// Otherwise - for routes which don't declare Capabilities, and for Roles which haven't been given any, which is how
+// Roles created before Capabilities were enforced continue to work - the user is authorized by privilege level alone.
+//
+// Scoped users, who authenticated with an API token, are never authorized by privilege level: they may only use
+// routes which require Capabilities, and only if they have every one of them, whether or not useCapabilities is set.
+func IsAuthorized(user CurrentUser, privLevelRequired int, capabilitiesRequired []string, useCapabilities bool) bool {
+	if user.Scoped {
+		return len(capabilitiesRequired) > 0 && HasCapabilities(user, capabilitiesRequired)
+	}
+	if !useCapabilities || len(capabilitiesRequired) == 0 || len(user.Capabilities) == 0 {
+		return user.PrivLevel >= privLevelRequired
+	}
+	return HasCapabilities(user, capabilitiesRequired)
+}
+
+// HasCapabilities returns whether the given user has every one of the given Capabilities.
+func HasCapabilities(user CurrentUser, capabilities []string) bool {
+	have := make(map[string]struct{}, len(user.Capabilities))
+	for _, c := range user.Capabilities {
+		have[c] = struct{}{}
+	}
+	for _, c := range capabilities {
+		if _, ok := have[c]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// PrivLevelCapabilities returns the Capabilities equivalent to the given privilege level: those whose required
+// privilege level, as given by capabilityPrivLevels, is no greater than it. This is the mapping by which Roles without
+// Capabilities are authorized.
+func PrivLevelCapabilities(privLevel int, capabilityPrivLevels map[string]int) []string {
+	caps := []string{}
+	for c, level := range capabilityPrivLevels {
+		if level <= privLevel {
+			caps = append(caps, c)
+		}
+	}
+	sort.Strings(caps)
+	return caps
+}
+
+// RoleCapabilities returns the given Capabilities of a Role or, if it has none, the Capabilities equivalent to its
+// privilege level, as given by PrivLevelCapabilities. These are the Capabilities by which a Role is effectively
+// authorized, whether or not Capability authorization is enabled.
+func RoleCapabilities(capabilities []string, privLevel int, capabilityPrivLevels map[string]int) []string {
+	if len(capabilities) == 0 {
+		return PrivLevelCapabilities(privLevel, capabilityPrivLevels)
+	}
+	return capabilities
+}
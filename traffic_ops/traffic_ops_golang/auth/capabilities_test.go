@@ -0,0 +1,90 @@
+package auth
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsAuthorized(t *testing.T) {
+	invalidator := CurrentUser{UserName: "invalidator", PrivLevel: PrivLevelReadOnly, Capabilities: []string{"auth", "jobs-read", "jobs-write"}}
+	legacy := CurrentUser{UserName: "legacy", PrivLevel: PrivLevelOperations, Capabilities: []string{}}
+	token := CurrentUser{UserName: "token", PrivLevel: PrivLevelAdmin, Capabilities: []string{"jobs-read"}, Scoped: true}
+	emptyToken := CurrentUser{UserName: "empty-token", PrivLevel: PrivLevelAdmin, Capabilities: []string{}, Scoped: true}
+
+	tests := []struct {
+		name            string
+		user            CurrentUser
+		privLevel       int
+		capabilities    []string
+		useCapabilities bool
+		expected        bool
+	}{
+		{"capabilities disabled uses privilege level", invalidator, PrivLevelPortal, []string{"jobs-write"}, false, false},
+		{"capabilities grant access above privilege level", invalidator, PrivLevelPortal, []string{"jobs-write"}, true, true},
+		{"missing capability denies access", invalidator, PrivLevelReadOnly, []string{"servers-read"}, true, false},
+		{"all capabilities required", invalidator, PrivLevelReadOnly, []string{"jobs-read", "servers-read"}, true, false},
+		{"routes without capabilities use privilege level", invalidator, PrivLevelOperations, nil, true, false},
+		{"roles without capabilities use privilege level", legacy, PrivLevelOperations, []string{"servers-write"}, true, true},
+		{"roles without capabilities are limited by privilege level", legacy, PrivLevelAdmin, []string{"roles-write"}, true, false},
+		{"tokens are authorized by their capabilities", token, PrivLevelReadOnly, []string{"jobs-read"}, true, true},
+		{"tokens are authorized by their capabilities with capabilities disabled", token, PrivLevelReadOnly, []string{"jobs-read"}, false, true},
+		{"tokens don't fall back to privilege level with capabilities disabled", token, PrivLevelReadOnly, []string{"servers-read"}, false, false},
+		{"tokens can't use routes without capabilities", token, PrivLevelReadOnly, nil, true, false},
+		{"tokens can't use routes without capabilities with capabilities disabled", token, PrivLevelReadOnly, nil, false, false},
+		{"tokens with an empty scope don't fall back to privilege level", emptyToken, PrivLevelReadOnly, []string{"jobs-read"}, true, false},
+		{"tokens with an empty scope can't use routes without capabilities", emptyToken, PrivLevelReadOnly, nil, false, false},
+	}
+	for _, test := range tests {
+		if actual := IsAuthorized(test.user, test.privLevel, test.capabilities, test.useCapabilities); actual != test.expected {
+			t.Errorf("%s: expected %t, actual: %t", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestPrivLevelCapabilities(t *testing.T) {
+	levels := map[string]int{
+		"servers-read":  PrivLevelReadOnly,
+		"jobs-write":    PrivLevelPortal,
+		"servers-write": PrivLevelOperations,
+		"roles-write":   PrivLevelAdmin,
+	}
+	expected := []string{"jobs-write", "servers-read", "servers-write"}
+	if actual := PrivLevelCapabilities(PrivLevelOperations, levels); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, actual: %v", expected, actual)
+	}
+	if actual := PrivLevelCapabilities(PrivLevelInvalid, levels); len(actual) != 0 {
+		t.Errorf("expected no capabilities, actual: %v", actual)
+	}
+}
+
+func TestRoleCapabilities(t *testing.T) {
+	levels := map[string]int{
+		"servers-read":  PrivLevelReadOnly,
+		"servers-write": PrivLevelOperations,
+	}
+	if actual := RoleCapabilities([]string{"jobs-read"}, PrivLevelAdmin, levels); !reflect.DeepEqual(actual, []string{"jobs-read"}) {
+		t.Errorf("expected the role's own capabilities, actual: %v", actual)
+	}
+	if actual := RoleCapabilities(nil, PrivLevelReadOnly, levels); !reflect.DeepEqual(actual, []string{"servers-read"}) {
+		t.Errorf("expected the capabilities of the role's privilege level, actual: %v", actual)
+	}
+}
@@ -0,0 +1,157 @@
+package auth
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// APITokenPrefix is prepended to every generated API token, so that tokens are
+// recognizable in configuration files and secret scanners.
+const APITokenPrefix = "totk_"
+
+// apiTokenBytes is the number of random bytes in a generated API token.
+const apiTokenBytes = 32
+
+// GenerateAPIToken returns a new random API token. Only its hash, as given by
+// HashAPIToken, should ever be stored.
+func GenerateAPIToken() (string, error) {
+	b := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("reading random bytes: " + err.Error())
+	}
+	return APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashAPIToken returns the hex-encoded SHA-256 hash of the given API token.
+// Tokens are long random strings, so an unsalted fast hash is sufficient.
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetCurrentUserFromAPIToken returns the user identified by the given API
+// token, restricted to the Role and Capabilities the token was scoped to. The
+// user's privilege level is the lesser of their own Role's and the token's
+// Role's, and their Capabilities are those common to both Roles and the
+// token's own Capability list. A Role without Capabilities has those
+// equivalent to its privilege level, as given by RoleCapabilities with the
+// given capabilityPrivLevels. The returned user is Scoped, so they may only
+// use routes which require Capabilities they have. The token's last-used time is updated as a
+// side effect.
+func GetCurrentUserFromAPIToken(DB *sqlx.DB, token string, timeout time.Duration, capabilityPrivLevels map[string]int) (CurrentUser, error, error, int) {
+	qry := `
+WITH t AS (
+  UPDATE api_token
+  SET last_used = now()
+  WHERE token_hash = $1
+  AND expires_at > now()
+  RETURNING tm_user, role, capabilities
+)
+SELECT
+  LEAST(ur.priv_level, tr.priv_level) AS priv_level,
+  tr.id AS role,
+  u.id,
+  u.username,
+  COALESCE(u.tenant_id, -1) AS tenant_id,
+  ur.priv_level AS user_role_priv_level,
+  ARRAY(SELECT rc.cap_name FROM role_capability AS rc WHERE rc.role_id = ur.id) AS user_role_capabilities,
+  tr.priv_level AS token_role_priv_level,
+  ARRAY(SELECT rc.cap_name FROM role_capability AS rc WHERE rc.role_id = tr.id) AS token_role_capabilities,
+  t.capabilities AS token_capabilities
+FROM t
+JOIN tm_user AS u ON u.id = t.tm_user
+JOIN role AS ur ON u.role = ur.id
+JOIN role AS tr ON t.role = tr.id
+WHERE ur.name != $2
+`
+	invalid := CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, []string{}, true}
+	if DB == nil {
+		return invalid, nil, errors.New("no db provided to GetCurrentUserFromAPIToken"), http.StatusInternalServerError
+	}
+	if !strings.HasPrefix(token, APITokenPrefix) {
+		return invalid, errors.New("invalid API token"), nil, http.StatusUnauthorized
+	}
+	dbCtx, dbClose := context.WithTimeout(context.Background(), timeout)
+	defer dbClose()
+
+	tokenUser := struct {
+		CurrentUser
+		UserRolePrivLevel     int            `db:"user_role_priv_level"`
+		UserRoleCapabilities  pq.StringArray `db:"user_role_capabilities"`
+		TokenRolePrivLevel    int            `db:"token_role_priv_level"`
+		TokenRoleCapabilities pq.StringArray `db:"token_role_capabilities"`
+		TokenCapabilities     pq.StringArray `db:"token_capabilities"`
+	}{}
+	err := DB.GetContext(dbCtx, &tokenUser, qry, HashAPIToken(token), disallowed)
+	switch {
+	case err == sql.ErrNoRows:
+		return invalid, errors.New("invalid or expired API token"), nil, http.StatusUnauthorized
+	case err == context.DeadlineExceeded || err == context.Canceled:
+		return invalid, nil, fmt.Errorf("db access timed out: %s number of open connections: %d", err, DB.Stats().OpenConnections), http.StatusServiceUnavailable
+	case err != nil:
+		return invalid, nil, errors.New("checking API token: " + err.Error()), http.StatusInternalServerError
+	default:
+		currentUserInfo := tokenUser.CurrentUser
+		currentUserInfo.Capabilities = intersectCapabilities(
+			RoleCapabilities(tokenUser.UserRoleCapabilities, tokenUser.UserRolePrivLevel, capabilityPrivLevels),
+			RoleCapabilities(tokenUser.TokenRoleCapabilities, tokenUser.TokenRolePrivLevel, capabilityPrivLevels),
+			tokenUser.TokenCapabilities,
+		)
+		currentUserInfo.Scoped = true
+		return currentUserInfo, nil, nil, http.StatusOK
+	}
+}
+
+// intersectCapabilities returns the Capabilities in every one of the given lists, sorted.
+func intersectCapabilities(lists ...[]string) []string {
+	counts := map[string]int{}
+	for _, list := range lists {
+		seen := make(map[string]struct{}, len(list))
+		for _, c := range list {
+			if _, ok := seen[c]; !ok {
+				seen[c] = struct{}{}
+				counts[c]++
+			}
+		}
+	}
+	caps := []string{}
+	for c, count := range counts {
+		if count == len(lists) {
+			caps = append(caps, c)
+		}
+	}
+	sort.Strings(caps)
+	return caps
+}
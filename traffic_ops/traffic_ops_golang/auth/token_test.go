@@ -0,0 +1,106 @@
+package auth
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestGenerateAPIToken(t *testing.T) {
+	a, err := GenerateAPIToken()
+	if err != nil {
+		t.Fatalf("unexpected error generating token: %v", err)
+	}
+	b, err := GenerateAPIToken()
+	if err != nil {
+		t.Fatalf("unexpected error generating token: %v", err)
+	}
+	if !strings.HasPrefix(a, APITokenPrefix) {
+		t.Errorf("expected token to start with '%s', got '%s'", APITokenPrefix, a)
+	}
+	if a == b {
+		t.Errorf("expected two generated tokens to differ, both were '%s'", a)
+	}
+}
+
+func TestHashAPIToken(t *testing.T) {
+	tok := APITokenPrefix + "abc"
+	if HashAPIToken(tok) != HashAPIToken(tok) {
+		t.Error("expected hashing the same token twice to give the same result")
+	}
+	if HashAPIToken(tok) == HashAPIToken(tok+"d") {
+		t.Error("expected hashes of different tokens to differ")
+	}
+	if h := HashAPIToken(tok); len(h) != 64 || strings.Contains(h, tok) {
+		t.Errorf("expected a 64 character hex hash not containing the token, got '%s'", h)
+	}
+}
+
+func TestGetCurrentUserFromAPIToken(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	levels := map[string]int{
+		"servers-read":  PrivLevelReadOnly,
+		"jobs-read":     PrivLevelReadOnly,
+		"jobs-write":    PrivLevelPortal,
+		"servers-write": PrivLevelOperations,
+		"roles-write":   PrivLevelAdmin,
+	}
+	cols := []string{"priv_level", "role", "id", "username", "tenant_id", "user_role_priv_level", "user_role_capabilities", "token_role_priv_level", "token_role_capabilities", "token_capabilities"}
+	tok := APITokenPrefix + "abc"
+
+	// the user's Role has no Capabilities, so it has those of its privilege level
+	mock.ExpectQuery("UPDATE api_token").WithArgs(HashAPIToken(tok), disallowed).WillReturnRows(sqlmock.NewRows(cols).AddRow(PrivLevelOperations, 3, 42, "automation", 2, PrivLevelOperations, "{}", PrivLevelOperations, "{servers-read,servers-write,jobs-read}", "{servers-read,servers-write,roles-write}"))
+	user, userErr, sysErr, _ := GetCurrentUserFromAPIToken(db, tok, time.Minute, levels)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("expected no errors, actual: %v, %v", userErr, sysErr)
+	}
+	if expected := []string{"servers-read", "servers-write"}; !reflect.DeepEqual([]string(user.Capabilities), expected) {
+		t.Errorf("expected capabilities %v, actual: %v", expected, user.Capabilities)
+	}
+	if !user.Scoped || user.UserName != "automation" || user.TenantID != 2 {
+		t.Errorf("expected scoped user 'automation' of tenant 2, actual: %+v", user)
+	}
+
+	// neither Role has Capabilities, so the lesser privilege level limits them
+	mock.ExpectQuery("UPDATE api_token").WithArgs(HashAPIToken(tok), disallowed).WillReturnRows(sqlmock.NewRows(cols).AddRow(PrivLevelReadOnly, 4, 42, "automation", 2, PrivLevelAdmin, "{}", PrivLevelReadOnly, "{}", "{servers-read,jobs-write}"))
+	user, userErr, sysErr, _ = GetCurrentUserFromAPIToken(db, tok, time.Minute, levels)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("expected no errors, actual: %v, %v", userErr, sysErr)
+	}
+	if expected := []string{"servers-read"}; !reflect.DeepEqual([]string(user.Capabilities), expected) {
+		t.Errorf("expected capabilities %v, actual: %v", expected, user.Capabilities)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
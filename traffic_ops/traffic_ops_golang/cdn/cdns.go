@@ -20,6 +20,8 @@ package cdn
  */
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -35,7 +37,7 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
-//we need a type alias to define functions on
+// we need a type alias to define functions on
 type TOCDN struct {
 	api.APIInfoImpl `json:"-"`
 	tc.CDNNullable
@@ -71,7 +73,7 @@ func (cdn TOCDN) GetKeyFieldsInfo() []api.KeyFieldInfo {
 	return []api.KeyFieldInfo{{"id", api.GetIntKey}}
 }
 
-//Implementation of the Identifier, Validator interface functions
+// Implementation of the Identifier, Validator interface functions
 func (cdn TOCDN) GetKeys() (map[string]interface{}, bool) {
 	if cdn.ID == nil {
 		return map[string]interface{}{"id": 0}, false
@@ -127,12 +129,21 @@ func (cdn TOCDN) Validate() error {
 	errs := validation.Errors{
 		"name":       validation.Validate(cdn.Name, validation.Required, validName),
 		"domainName": validation.Validate(cdn.DomainName, validation.Required, validDomainName),
+		"dnssecAlgorithm": validation.Validate(cdn.DNSSECAlgorithm, validation.By(func(v interface{}) error {
+			if alg, ok := v.(*string); ok && alg != nil && *alg != "" && !tc.IsValidDNSSECAlgorithm(*alg) {
+				return errors.New("must be one of " + strings.Join(tc.DNSSECAlgorithms, ", "))
+			}
+			return nil
+		})),
 	}
 	return util.JoinErrs(tovalidate.ToErrors(errs))
 }
 
 func (cdn *TOCDN) Create() (error, error, int) {
 	*cdn.DomainName = strings.ToLower(*cdn.DomainName)
+	if cdn.DNSSECAlgorithm == nil || *cdn.DNSSECAlgorithm == "" {
+		cdn.DNSSECAlgorithm = util.StrPtr(tc.DefaultDNSSECAlgorithm)
+	}
 	return api.GenericCreate(cdn)
 }
 
@@ -143,6 +154,14 @@ func (cdn *TOCDN) Read(h http.Header, useIMS bool) ([]interface{}, error, error,
 
 func (cdn *TOCDN) Update(h http.Header) (error, error, int) {
 	*cdn.DomainName = strings.ToLower(*cdn.DomainName)
+	if cdn.DNSSECAlgorithm == nil || *cdn.DNSSECAlgorithm == "" {
+		// an omitted algorithm is unchanged; if the CDN doesn't exist, GenericUpdate reports it
+		alg := ""
+		if err := cdn.APIInfo().Tx.Tx.QueryRow(`SELECT dnssec_algorithm FROM cdn WHERE id = $1`, *cdn.ID).Scan(&alg); err != nil && err != sql.ErrNoRows {
+			return nil, errors.New("getting cdn DNSSEC algorithm: " + err.Error()), http.StatusInternalServerError
+		}
+		cdn.DNSSECAlgorithm = &alg
+	}
 	return api.GenericUpdate(h, cdn)
 }
 
@@ -150,6 +169,7 @@ func (cdn *TOCDN) Delete() (error, error, int) { return api.GenericDelete(cdn) }
 
 func selectQuery() string {
 	query := `SELECT
+dnssec_algorithm,
 dnssec_enabled,
 domain_name,
 id,
@@ -163,6 +183,7 @@ FROM cdn c`
 func updateQuery() string {
 	query := `UPDATE
 cdn SET
+dnssec_algorithm=:dnssec_algorithm,
 dnssec_enabled=:dnssec_enabled,
 domain_name=:domain_name,
 name=:name
@@ -172,9 +193,11 @@ WHERE id=:id RETURNING last_updated`
 
 func insertQuery() string {
 	query := `INSERT INTO cdn (
+dnssec_algorithm,
 dnssec_enabled,
 domain_name,
 name) VALUES (
+:dnssec_algorithm,
 :dnssec_enabled,
 :domain_name,
 :name) RETURNING id,last_updated`
@@ -37,11 +37,12 @@ import (
 func getTestCDNs() []tc.CDN {
 	cdns := []tc.CDN{}
 	testCDN := tc.CDN{
-		DNSSECEnabled: false,
-		DomainName:    "domainName",
-		ID:            1,
-		Name:          "cdn1",
-		LastUpdated:   tc.TimeNoMod{Time: time.Now()},
+		DNSSECAlgorithm: tc.DefaultDNSSECAlgorithm,
+		DNSSECEnabled:   false,
+		DomainName:      "domainName",
+		ID:              1,
+		Name:            "cdn1",
+		LastUpdated:     tc.TimeNoMod{Time: time.Now()},
 	}
 	cdns = append(cdns, testCDN)
 
@@ -71,6 +72,7 @@ func TestReadCDNs(t *testing.T) {
 	for _, ts := range testCDNs {
 		rows = rows.AddRow(
 			ts.DNSSECEnabled,
+			ts.DNSSECAlgorithm,
 			ts.DomainName,
 			ts.ID,
 			ts.LastUpdated,
@@ -155,4 +157,15 @@ func TestValidate(t *testing.T) {
 	if err != nil {
 		t.Errorf("expected nil, got %s", err)
 	}
+
+	alg := "RSASHA1"
+	c = TOCDN{CDNNullable: tc.CDNNullable{Name: &n, DomainName: &d, DNSSECAlgorithm: &alg}}
+	if err := c.Validate(); err == nil {
+		t.Errorf("expected an error for DNSSEC algorithm %s, got nil", alg)
+	}
+
+	alg = tc.DNSSECAlgorithmED25519
+	if err := c.Validate(); err != nil {
+		t.Errorf("expected nil for DNSSEC algorithm %s, got %s", alg, err)
+	}
 }
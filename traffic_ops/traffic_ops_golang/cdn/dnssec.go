@@ -31,10 +31,10 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/asyncjob"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
 )
 
 const CDNDNSSECKeyType = "dnssec"
@@ -77,14 +77,63 @@ func CreateDNSSECKeys(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := generateStoreDNSSECKeys(inf.Tx.Tx, inf.Config, cdnName, cdnDomain, uint64(*req.TTL), uint64(*req.KSKExpirationDays), uint64(*req.ZSKExpirationDays), int64(*req.EffectiveDateUnix)); err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("generating and storing DNSSEC CDN keys: "+err.Error()))
+	payload := dnssecJobPayload{
+		CDN:               cdnName,
+		CDNID:             cdnID,
+		CDNDomain:         cdnDomain,
+		TTL:               uint64(*req.TTL),
+		KSKExpirationDays: uint64(*req.KSKExpirationDays),
+		ZSKExpirationDays: uint64(*req.ZSKExpirationDays),
+		EffectiveDateUnix: int64(*req.EffectiveDateUnix),
+	}
+	if asyncjob.PrefersAsync(r) {
+		asyncjob.RespondAsync(w, r, inf, DNSSECJobKind, "Generation of DNSSEC keys for CDN "+cdnName+" queued.", payload)
+		return
+	}
+	if err := generateDNSSECKeys(inf, payload); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
 		return
 	}
-	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+string(cdnName)+", ID: "+strconv.Itoa(cdnID)+", ACTION: Generated DNSSEC keys", inf.User, inf.Tx.Tx)
 	api.WriteResp(w, r, "Successfully created dnssec keys for "+cdnName)
 }
 
+// DNSSECJobKind is the kind of asynchronous job which generates the DNSSEC keys of a CDN.
+const DNSSECJobKind = "dnssec-keys-generate"
+
+type dnssecJobPayload struct {
+	CDN               string `json:"cdn"`
+	CDNID             int    `json:"cdnId"`
+	CDNDomain         string `json:"cdnDomain"`
+	TTL               uint64 `json:"ttl"`
+	KSKExpirationDays uint64 `json:"kskExpirationDays"`
+	ZSKExpirationDays uint64 `json:"zskExpirationDays"`
+	EffectiveDateUnix int64  `json:"effectiveDateUnix"`
+}
+
+func init() {
+	asyncjob.Register(DNSSECJobKind, dnssecJob)
+}
+
+// dnssecJob generates DNSSEC keys requested with a preference for an asynchronous response.
+func dnssecJob(job *asyncjob.Job) (interface{}, error, error) {
+	payload := dnssecJobPayload{}
+	if err := job.DecodePayload(&payload); err != nil {
+		return nil, nil, errors.New("decoding DNSSEC job payload: " + err.Error())
+	}
+	if err := generateDNSSECKeys(job.APIInfo(), payload); err != nil {
+		return nil, nil, err
+	}
+	return "Successfully created dnssec keys for " + payload.CDN, nil, nil
+}
+
+func generateDNSSECKeys(inf *api.APIInfo, p dnssecJobPayload) error {
+	if err := generateStoreDNSSECKeys(inf.Tx.Tx, inf.Config, p.CDN, p.CDNDomain, p.TTL, p.KSKExpirationDays, p.ZSKExpirationDays, p.EffectiveDateUnix); err != nil {
+		return errors.New("generating and storing DNSSEC CDN keys: " + err.Error())
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+p.CDN+", ID: "+strconv.Itoa(p.CDNID)+", ACTION: Generated DNSSEC keys", inf.User, inf.Tx.Tx)
+	return nil
+}
+
 // DefaultDSTTL is the default DS Record TTL to use, if no CDN Snapshot exists, or if no tld.ttls.DS parameter exists.
 // This MUST be the same value as Traffic Router's default. Currently:
 // traffic_router/core/src/main/java/com/comcast/cdn/traffic_control/traffic_router/core/dns/SignatureManager.java:476
@@ -104,7 +153,7 @@ func GetDNSSECKeys(w http.ResponseWriter, r *http.Request) {
 
 	cdnName := inf.Params["name"]
 
-	riakKeys, keysExist, err := riaksvc.GetDNSSECKeys(cdnName, inf.Tx.Tx, inf.Config.RiakAuthOptions, inf.Config.RiakPort)
+	riakKeys, keysExist, err := inf.Config.TrafficVault.GetDNSSECKeys(cdnName, inf.Tx.Tx)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting DNSSEC CDN keys: "+err.Error()))
 		return
@@ -139,7 +188,7 @@ func GetDNSSECKeysV11(w http.ResponseWriter, r *http.Request) {
 	defer inf.Close()
 
 	cdnName := inf.Params["name"]
-	riakKeys, keysExist, err := riaksvc.GetDNSSECKeys(cdnName, inf.Tx.Tx, inf.Config.RiakAuthOptions, inf.Config.RiakPort)
+	riakKeys, keysExist, err := inf.Config.TrafficVault.GetDNSSECKeys(cdnName, inf.Tx.Tx)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting DNSSEC CDN keys: "+err.Error()))
 		return
@@ -175,7 +224,7 @@ func generateStoreDNSSECKeys(
 	kExp := time.Duration(kExpDays) * time.Hour * 24
 	ttl := time.Duration(ttlSeconds) * time.Second
 
-	oldKeys, oldKeysExist, err := riaksvc.GetDNSSECKeys(cdnName, tx, cfg.RiakAuthOptions, cfg.RiakPort)
+	oldKeys, oldKeysExist, err := cfg.TrafficVault.GetDNSSECKeys(cdnName, tx)
 	if err != nil {
 		return errors.New("getting old dnssec keys: " + err.Error())
 	}
@@ -191,17 +240,29 @@ func generateStoreDNSSECKeys(
 	}
 	cdnDNSDomain = strings.ToLower(cdnDNSDomain)
 
+	algorithm, err := getCDNDNSSECAlgorithm(tx, tc.CDNName(cdnName))
+	if err != nil {
+		return errors.New("getting cdn dnssec algorithm: " + err.Error())
+	}
+
 	inception := time.Now()
-	newCDNZSK, err := deliveryservice.GetDNSSECKeysV11(tc.DNSSECZSKType, cdnDNSDomain, ttl, inception, inception.Add(zExp), tc.DNSSECKeyStatusNew, time.Unix(effectiveDateUnix, 0), false)
+	newCDNZSK, err := deliveryservice.GetDNSSECKeysV11(tc.DNSSECZSKType, cdnDNSDomain, ttl, inception, inception.Add(zExp), tc.DNSSECKeyStatusNew, time.Unix(effectiveDateUnix, 0), false, algorithm)
 	if err != nil {
 		return errors.New("creating zsk for cdn: " + err.Error())
 	}
 
-	newCDNKSK, err := deliveryservice.GetDNSSECKeysV11(tc.DNSSECKSKType, cdnDNSDomain, ttl, inception, inception.Add(kExp), tc.DNSSECKeyStatusNew, time.Unix(effectiveDateUnix, 0), true)
+	newCDNKSK, err := deliveryservice.GetDNSSECKeysV11(tc.DNSSECKSKType, cdnDNSDomain, ttl, inception, inception.Add(kExp), tc.DNSSECKeyStatusNew, time.Unix(effectiveDateUnix, 0), true, algorithm)
 	if err != nil {
 		return errors.New("creating ksk for cdn: " + err.Error())
 	}
 
+	phase := tc.DNSSECRolloverPhaseActive
+	if time.Unix(effectiveDateUnix, 0).After(inception) {
+		phase = tc.DNSSECRolloverPhasePublished
+	}
+	newCDNZSK.RolloverPhase = phase
+	newCDNKSK.RolloverPhase = phase
+
 	newCDNZSKs := []tc.DNSSECKeyV11{newCDNZSK}
 	newCDNKSKs := []tc.DNSSECKeyV11{newCDNKSK}
 
@@ -212,6 +273,7 @@ func generateStoreDNSSECKeys(
 			ksk.Status = DNSSECStatusExisting
 			ksk.TTLSeconds = uint64(ttl / time.Second)
 			ksk.ExpirationDateUnix = effectiveDateUnix
+			ksk.RolloverPhase = tc.DNSSECRolloverPhaseRetiring
 			newCDNKSKs = append(newCDNKSKs, ksk)
 		}
 		if oldKeyCDNExists && len(oldKeyCDN.ZSK) > 0 {
@@ -219,6 +281,7 @@ func generateStoreDNSSECKeys(
 			zsk.Status = DNSSECStatusExisting
 			zsk.TTLSeconds = uint64(ttl / time.Second)
 			zsk.ExpirationDateUnix = effectiveDateUnix
+			zsk.RolloverPhase = tc.DNSSECRolloverPhaseRetiring
 			newCDNZSKs = append(newCDNZSKs, zsk)
 		}
 	}
@@ -255,8 +318,8 @@ func generateStoreDNSSECKeys(
 		}
 		newKeys[ds.Name] = dsKeys
 	}
-	if err := riaksvc.PutDNSSECKeys(tc.DNSSECKeysRiak(newKeys), cdnName, tx, cfg.RiakAuthOptions, cfg.RiakPort); err != nil {
-		return errors.New("putting Riak DNSSEC CDN keys: " + err.Error())
+	if err := cfg.TrafficVault.PutDNSSECKeys(cdnName, tc.DNSSECKeysRiak(newKeys), tx); err != nil {
+		return errors.New("putting Traffic Vault DNSSEC CDN keys: " + err.Error())
 	}
 	return nil
 }
@@ -325,12 +388,6 @@ func deleteDNSSECKeys(w http.ResponseWriter, r *http.Request, deprecated bool) {
 	}
 	defer inf.Close()
 
-	cluster, err := riaksvc.GetPooledCluster(inf.Tx.Tx, inf.Config.RiakAuthOptions, inf.Config.RiakPort)
-	if err != nil {
-		writeError(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting riak cluster: "+err.Error()), deprecated)
-		return
-	}
-
 	key := inf.Params["name"]
 	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(key))
 	if err != nil {
@@ -341,7 +398,7 @@ func deleteDNSSECKeys(w http.ResponseWriter, r *http.Request, deprecated bool) {
 		return
 	}
 
-	if err := riaksvc.DeleteObject(key, CDNDNSSECKeyType, cluster); err != nil {
+	if err := inf.Config.TrafficVault.DeleteDNSSECKeys(key, inf.Tx.Tx); err != nil {
 		writeError(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting cdn dnssec keys: "+err.Error()), deprecated)
 		return
 	}
@@ -34,7 +34,6 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
 
 	"github.com/lib/pq"
 )
@@ -89,6 +88,15 @@ func doDNSSECKeyRefresh(tx *sql.Tx, cfg *config.Config) {
 	}()
 	defer unsetInDNSSECKeyRefresh()
 
+	if locked, err := lockDNSSECKeyRefresh(tx); err != nil {
+		log.Errorln("refreshing DNSSEC Keys: " + err.Error())
+		doCommit = false
+		return
+	} else if !locked {
+		log.Infoln("refreshing DNSSEC Keys: another Traffic Ops instance is refreshing DNSSEC keys, doing nothing")
+		return
+	}
+
 	updatedAny := false
 
 	cdnDNSSECKeyParams, err := getDNSSECKeyRefreshParams(tx)
@@ -127,13 +135,13 @@ func doDNSSECKeyRefresh(tx *sql.Tx, cfg *config.Config) {
 	}
 
 	for _, cdnInf := range cdnDNSSECKeyParams {
-		keys, ok, err := riaksvc.GetDNSSECKeys(string(cdnInf.CDNName), tx, cfg.RiakAuthOptions, cfg.RiakPort) // TODO get all in a map beforehand
+		keys, ok, err := cfg.TrafficVault.GetDNSSECKeys(string(cdnInf.CDNName), tx) // TODO get all in a map beforehand
 		if err != nil {
-			log.Warnln("refreshing DNSSEC Keys: getting cdn '" + string(cdnInf.CDNName) + "' keys from Riak, skipping: " + err.Error())
+			log.Warnln("refreshing DNSSEC Keys: getting cdn '" + string(cdnInf.CDNName) + "' keys from Traffic Vault, skipping: " + err.Error())
 			continue
 		}
 		if !ok {
-			log.Warnln("refreshing DNSSEC Keys: cdn '" + string(cdnInf.CDNName) + "' has no keys in Riak, skipping")
+			log.Warnln("refreshing DNSSEC Keys: cdn '" + string(cdnInf.CDNName) + "' has no keys in Traffic Vault, skipping")
 			continue
 		}
 
@@ -154,6 +162,12 @@ func doDNSSECKeyRefresh(tx *sql.Tx, cfg *config.Config) {
 
 		nowPlusTTL := time.Now().Add(ttl * time.Duration(genMultiplier)) // "key_expiration" in the Perl this was transliterated from
 
+		algorithm, err := deliveryservice.DNSSECAlgorithmNumber(cdnInf.DNSSECAlgorithm)
+		if err != nil {
+			log.Warnln("refreshing DNSSEC Keys: cdn '" + string(cdnInf.CDNName) + "', skipping: " + err.Error())
+			continue
+		}
+
 		defaultKSKExpiration := DNSSECKeyRefreshDefaultKSKExpiration
 		for _, key := range keys[string(cdnInf.CDNName)].KSK {
 			if key.Status != tc.DNSSECKeyStatusNew {
@@ -179,7 +193,7 @@ func doDNSSECKeyRefresh(tx *sql.Tx, cfg *config.Config) {
 			effectiveDate := expiration.Add(ttl * time.Duration(effectiveMultiplier) * -1) // -1 to subtract
 			isKSK := false
 			cdnDNSDomain := cdnInf.CDNDomain + "."
-			newKeys, err := regenExpiredKeys(isKSK, cdnDNSDomain, keys[string(cdnInf.CDNName)], effectiveDate, false, false)
+			newKeys, err := regenExpiredKeys(isKSK, cdnDNSDomain, keys[string(cdnInf.CDNName)], effectiveDate, false, false, algorithm)
 			if err != nil {
 				log.Errorln("refreshing DNSSEC Keys: regenerating expired ZSK keys: " + err.Error())
 			} else {
@@ -188,6 +202,28 @@ func doDNSSECKeyRefresh(tx *sql.Tx, cfg *config.Config) {
 			}
 		}
 
+		for _, key := range keys[string(cdnInf.CDNName)].KSK {
+			if key.Status != tc.DNSSECKeyStatusNew {
+				continue
+			}
+			expiration := time.Unix(key.ExpirationDateUnix, 0)
+			if expiration.After(nowPlusTTL) {
+				continue
+			}
+			log.Infoln("The KSK keys for '" + string(cdnInf.CDNName) + "' are expired!")
+			effectiveDate := expiration.Add(ttl * time.Duration(effectiveMultiplier) * -1) // -1 to subtract
+			isKSK := true
+			cdnDNSDomain := cdnInf.CDNDomain + "."
+			newKeys, err := regenExpiredKeys(isKSK, cdnDNSDomain, keys[string(cdnInf.CDNName)], effectiveDate, true, false, algorithm)
+			if err != nil {
+				log.Errorln("refreshing DNSSEC Keys: regenerating expired KSK keys: " + err.Error())
+			} else {
+				keys[string(cdnInf.CDNName)] = newKeys
+				log.Warnln("refreshing DNSSEC Keys: cdn '" + string(cdnInf.CDNName) + "' has a new KSK, whose DS record must be published in the parent zone, and confirmed with POST cdns/name/" + string(cdnInf.CDNName) + "/dnsseckeys/ds")
+				updatedAny = true
+			}
+		}
+
 		for _, ds := range dsInfo {
 			if ds.CDNName != cdnInf.CDNName {
 				continue
@@ -227,7 +263,7 @@ func doDNSSECKeyRefresh(tx *sql.Tx, cfg *config.Config) {
 				log.Infoln("The KSK keys for '" + ds.DSName + "' are expired!")
 				effectiveDate := expiration.Add(ttl * time.Duration(effectiveMultiplier) * -1) // -1 to subtract
 				isKSK := true
-				newKeys, err := regenExpiredKeys(isKSK, string(ds.DSName), dsKeys, effectiveDate, false, false)
+				newKeys, err := regenExpiredKeys(isKSK, string(ds.DSName), dsKeys, effectiveDate, false, false, algorithm)
 				if err != nil {
 					log.Errorln("refreshing DNSSEC Keys: regenerating expired KSK keys for ds '" + string(ds.DSName) + "': " + err.Error())
 				} else {
@@ -247,7 +283,7 @@ func doDNSSECKeyRefresh(tx *sql.Tx, cfg *config.Config) {
 				log.Infoln("The ZSK keys for '" + ds.DSName + "' are expired!")
 				effectiveDate := expiration.Add(ttl * time.Duration(effectiveMultiplier) * -1) // -1 to subtract
 				isKSK := false
-				newKeys, err := regenExpiredKeys(isKSK, string(ds.DSName), dsKeys, effectiveDate, false, false)
+				newKeys, err := regenExpiredKeys(isKSK, string(ds.DSName), dsKeys, effectiveDate, false, false, algorithm)
 				if err != nil {
 					log.Errorln("refreshing DNSSEC Keys: regenerating expired ZSK keys for ds '" + string(ds.DSName) + "': " + err.Error())
 				} else {
@@ -260,9 +296,18 @@ func doDNSSECKeyRefresh(tx *sql.Tx, cfg *config.Config) {
 				}
 			}
 		}
+		now := time.Now()
+		signatureValidity := ttl * dnssecSignatureValidityTTLMultiplier
+		for name, keySet := range keys {
+			if advanced, ok := advanceDNSSECRollovers(keySet, now, signatureValidity); ok {
+				keys[name] = advanced
+				updatedAny = true
+			}
+		}
+
 		if updatedAny {
-			if err := riaksvc.PutDNSSECKeys(keys, string(cdnInf.CDNName), tx, cfg.RiakAuthOptions, cfg.RiakPort); err != nil {
-				log.Errorln("refreshing DNSSEC Keys: putting keys into Riak for cdn '" + string(cdnInf.CDNName) + "': " + err.Error())
+			if err := cfg.TrafficVault.PutDNSSECKeys(string(cdnInf.CDNName), keys, tx); err != nil {
+				log.Errorln("refreshing DNSSEC Keys: putting keys into Traffic Vault for cdn '" + string(cdnInf.CDNName) + "': " + err.Error())
 			}
 		}
 	}
@@ -273,12 +318,13 @@ type DNSSECKeyRefreshCDNInfo struct {
 	CDNName                    tc.CDNName
 	CDNDomain                  string
 	DNSSECEnabled              bool
+	DNSSECAlgorithm            string
 	TLDTTLsDNSKEY              *uint64
 	DNSKEYEffectiveMultiplier  *uint64
 	DNSKEYGenerationMultiplier *uint64
 }
 
-// getDNSSECKeyRefreshParams returns returns the CDN's profile's tld.ttls.DNSKEY, DNSKEY.effective.multiplier, and DNSKEY.generation.multiplier parameters. If either parameter doesn't exist, nil is returned.
+// getDNSSECKeyRefreshParams returns returns the CDN's DNSSEC algorithm, and its profile's tld.ttls.DNSKEY, DNSKEY.effective.multiplier, and DNSKEY.generation.multiplier parameters. If either parameter doesn't exist, nil is returned.
 // If a CDN exists, but has no parameters, it is returned as a key in the map with a nil value.
 func getDNSSECKeyRefreshParams(tx *sql.Tx) (map[tc.CDNName]DNSSECKeyRefreshCDNInfo, error) {
 	qry := `
@@ -287,16 +333,18 @@ WITH cdn_profile_ids AS (
     DISTINCT(c.name) as cdn_name,
     c.domain_name as cdn_domain,
     c.dnssec_enabled as cdn_dnssec_enabled,
+    c.dnssec_algorithm as cdn_dnssec_algorithm,
     MAX(p.id) as profile_id -- We only want 1 profile, so get the probably-newest if there's more than one.
   FROM
     cdn c
     LEFT JOIN profile p ON c.id = p.cdn AND (p.name like 'CCR%' OR p.name like 'TR%')
-    GROUP BY c.name, c.dnssec_enabled, c.domain_name
+    GROUP BY c.name, c.dnssec_enabled, c.dnssec_algorithm, c.domain_name
 )
 SELECT
   DISTINCT(pi.cdn_name),
   pi.cdn_domain,
   pi.cdn_dnssec_enabled,
+  pi.cdn_dnssec_algorithm,
   MAX(pa.name) as parameter_name,
   MAX(pa.value) as parameter_value
 FROM
@@ -308,7 +356,7 @@ FROM
     OR pa.name = 'DNSKEY.effective.multiplier'
     OR pa.name = 'DNSKEY.generation.multiplier'
   )
-GROUP BY pi.cdn_name, pi.cdn_domain, pi.cdn_dnssec_enabled
+GROUP BY pi.cdn_name, pi.cdn_domain, pi.cdn_dnssec_enabled, pi.cdn_dnssec_algorithm
 `
 	rows, err := tx.Query(qry)
 	if err != nil {
@@ -321,9 +369,10 @@ GROUP BY pi.cdn_name, pi.cdn_domain, pi.cdn_dnssec_enabled
 		cdnName := tc.CDNName("")
 		cdnDomain := ""
 		dnssecEnabled := false
+		dnssecAlgorithm := ""
 		name := util.StrPtr("")
 		valStr := util.StrPtr("")
-		if err := rows.Scan(&cdnName, &cdnDomain, &dnssecEnabled, &name, &valStr); err != nil {
+		if err := rows.Scan(&cdnName, &cdnDomain, &dnssecEnabled, &dnssecAlgorithm, &name, &valStr); err != nil {
 			return nil, errors.New("scanning cdn dnssec key refresh parameters: " + err.Error())
 		}
 
@@ -331,6 +380,7 @@ GROUP BY pi.cdn_name, pi.cdn_domain, pi.cdn_dnssec_enabled
 		inf.CDNName = cdnName
 		inf.CDNDomain = cdnDomain
 		inf.DNSSECEnabled = dnssecEnabled
+		inf.DNSSECAlgorithm = dnssecAlgorithm
 
 		if name == nil || valStr == nil {
 			// no DNSKEY parameters, but the CDN still exists.
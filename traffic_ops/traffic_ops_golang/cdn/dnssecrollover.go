@@ -0,0 +1,296 @@
+package cdn
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// dnssecKeyRefreshLockID is the ID of the Postgres advisory lock held while refreshing DNSSEC keys, so only one
+// Traffic Ops instance does so at a time.
+const dnssecKeyRefreshLockID = 4741041
+
+// dsRetireTTLMultiplier is how many DS record TTLs a CDN's old KSK keeps signing after the DS record of the KSK which
+// replaces it is confirmed published, so resolvers which cached the old DS record stop using it first.
+const dsRetireTTLMultiplier = 2
+
+// dnssecSignatureValidityTTLMultiplier is how many TTLs the signatures Traffic Router makes remain valid for. Retiring
+// keys stay published that long after they expire, so that resolvers can still validate the signatures they made
+// which they have cached. This MUST be at least Traffic Router's signaturemanager.expiration.multiplier, whose
+// default is 5.
+const dnssecSignatureValidityTTLMultiplier = 5
+
+// StartDNSSECKeyRefresh periodically refreshes the DNSSEC keys of every CDN with DNSSEC enabled, as requests to
+// cdns/dnsseckeys/refresh do, rolling over expiring keys and advancing the rollovers in progress.
+func StartDNSSECKeyRefresh(db *sqlx.DB, cfg *config.Config) {
+	if !cfg.TrafficVaultEnabled || cfg.DNSSECKeyRefreshIntervalSeconds < 0 {
+		log.Infoln("DNSSEC keys will only be refreshed by requests to cdns/dnsseckeys/refresh")
+		return
+	}
+	interval := time.Duration(cfg.DNSSECKeyRefreshIntervalSeconds) * time.Second
+	go func() {
+		for {
+			time.Sleep(interval)
+			if !setInDNSSECKeyRefresh() {
+				continue
+			}
+			tx, err := db.Begin()
+			if err != nil {
+				log.Errorln("refreshing DNSSEC keys: beginning transaction: " + err.Error())
+				unsetInDNSSECKeyRefresh()
+				continue
+			}
+			doDNSSECKeyRefresh(tx, cfg) // doDNSSECKeyRefresh takes ownership of tx.
+		}
+	}()
+}
+
+// lockDNSSECKeyRefresh returns whether the transaction obtained the lock held while refreshing DNSSEC keys. It's
+// released when the transaction ends.
+func lockDNSSECKeyRefresh(tx *sql.Tx) (bool, error) {
+	locked := false
+	if err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, dnssecKeyRefreshLockID).Scan(&locked); err != nil {
+		return false, errors.New("obtaining DNSSEC key refresh lock: " + err.Error())
+	}
+	return locked, nil
+}
+
+// advanceDNSSECRollovers moves the keys of a key set through the phases of their rollovers, as of now. Published keys
+// whose effective dates have passed become active, and retiring keys are removed once the signatures they made before
+// they expired can no longer be valid: signatureValidity after their expiration, or dnssecSignatureValidityTTLMultiplier
+// times their own TTL, if that's longer.
+// Returns the advanced key set, and whether anything changed.
+func advanceDNSSECRollovers(keySet tc.DNSSECKeySetV11, now time.Time, signatureValidity time.Duration) (tc.DNSSECKeySetV11, bool) {
+	zsks, zsksChanged := advanceDNSSECKeys(keySet.ZSK, now, signatureValidity)
+	ksks, ksksChanged := advanceDNSSECKeys(keySet.KSK, now, signatureValidity)
+	return tc.DNSSECKeySetV11{ZSK: zsks, KSK: ksks}, zsksChanged || ksksChanged
+}
+
+func advanceDNSSECKeys(keys []tc.DNSSECKeyV11, now time.Time, signatureValidity time.Duration) ([]tc.DNSSECKeyV11, bool) {
+	advanced := make([]tc.DNSSECKeyV11, 0, len(keys))
+	changed := false
+	for _, key := range keys {
+		switch {
+		case key.RolloverPhase == tc.DNSSECRolloverPhasePublished && !time.Unix(key.EffectiveDateUnix, 0).After(now):
+			key.RolloverPhase = tc.DNSSECRolloverPhaseActive
+			changed = true
+		case key.RolloverPhase == tc.DNSSECRolloverPhaseRetiring && !retiredKeyRemovalTime(key, signatureValidity).After(now):
+			changed = true
+			continue
+		}
+		advanced = append(advanced, key)
+	}
+	return advanced, changed
+}
+
+// retiredKeyRemovalTime returns the time after which a retiring key may be removed, because no signature it made can
+// still be valid.
+func retiredKeyRemovalTime(key tc.DNSSECKeyV11, signatureValidity time.Duration) time.Time {
+	if keyValidity := time.Duration(key.TTLSeconds) * time.Second * dnssecSignatureValidityTTLMultiplier; keyValidity > signatureValidity {
+		signatureValidity = keyValidity
+	}
+	return time.Unix(key.ExpirationDateUnix, 0).Add(signatureValidity)
+}
+
+// confirmDSPublished finishes the rollover of the KSK with the given key tag, whose DS record was published in the
+// parent zone. The KSK becomes active, and the KSKs it replaces are retiring, expiring at retireAt.
+// Returns a user error if no KSK with the key tag is waiting for its DS record to be published.
+func confirmDSPublished(keySet tc.DNSSECKeySetV11, keyTag uint16, retireAt time.Time) (tc.DNSSECKeySetV11, error, error) {
+	ksks := make([]tc.DNSSECKeyV11, len(keySet.KSK))
+	copy(ksks, keySet.KSK)
+
+	pending := -1
+	for i, ksk := range ksks {
+		if ksk.RolloverPhase != tc.DNSSECRolloverPhaseDSPending {
+			continue
+		}
+		dnskey, err := deliveryservice.ParseDNSKEY(ksk)
+		if err != nil {
+			return tc.DNSSECKeySetV11{}, nil, errors.New("parsing pending KSK: " + err.Error())
+		}
+		if dnskey.KeyTag() == keyTag {
+			pending = i
+			break
+		}
+	}
+	if pending < 0 {
+		return tc.DNSSECKeySetV11{}, fmt.Errorf("no KSK with key tag %d is waiting for its DS record to be published", keyTag), nil
+	}
+
+	for i := range ksks {
+		if i == pending {
+			ksks[i].RolloverPhase = tc.DNSSECRolloverPhaseActive
+			continue
+		}
+		if ksks[i].RolloverPhase != tc.DNSSECRolloverPhaseActive {
+			continue
+		}
+		ksks[i].RolloverPhase = tc.DNSSECRolloverPhaseRetiring
+		ksks[i].Status = tc.DNSSECKeyStatusExpired
+		if ksks[i].ExpirationDateUnix > retireAt.Unix() {
+			ksks[i].ExpirationDateUnix = retireAt.Unix()
+		}
+	}
+	return tc.DNSSECKeySetV11{ZSK: keySet.ZSK, KSK: ksks}, nil, nil
+}
+
+// makeDSRecords returns the DS records of the given KSKs which have them.
+func makeDSRecords(ksks []tc.DNSSECKeyV11, dsTTL time.Duration) ([]tc.DNSSECDSRecord, error) {
+	records := []tc.DNSSECDSRecord{}
+	for _, ksk := range ksks {
+		if ksk.DSRecord == nil {
+			continue
+		}
+		dnskey, err := deliveryservice.ParseDNSKEY(ksk)
+		if err != nil {
+			return nil, errors.New("parsing KSK: " + err.Error())
+		}
+		text, err := deliveryservice.MakeDSRecordText(ksk, dsTTL)
+		if err != nil {
+			return nil, errors.New("making DS record text: " + err.Error())
+		}
+		records = append(records, tc.DNSSECDSRecord{
+			KeyTag:         dnskey.KeyTag(),
+			Algorithm:      ksk.DSRecord.Algorithm,
+			DigestType:     ksk.DSRecord.DigestType,
+			Digest:         ksk.DSRecord.Digest,
+			Text:           text,
+			RolloverPhase:  ksk.RolloverPhase,
+			EffectiveDate:  time.Unix(ksk.EffectiveDateUnix, 0),
+			ExpirationDate: time.Unix(ksk.ExpirationDateUnix, 0),
+			Pending:        ksk.RolloverPhase == tc.DNSSECRolloverPhaseDSPending,
+		})
+	}
+	return records, nil
+}
+
+// getDSRecordTTLOrDefault returns the CDN's DS record TTL, or DefaultDSTTL if it has none.
+func getDSRecordTTLOrDefault(tx *sql.Tx, cdn string) time.Duration {
+	dsTTL, err := GetDSRecordTTL(tx, cdn)
+	if err != nil {
+		log.Errorf("getting DS Record TTL failed, using default %v. It is STRONGLY ADVISED to ensure a CRConfig Snapshot exists for the CDN, and a tld.ttls.DS CRConfig.json Parameter exists on a Router Profile on the CDN: %v\n", DefaultDSTTL, err)
+		return DefaultDSTTL
+	}
+	return dsTTL
+}
+
+// GetDSRecords is the handler for GET requests to cdns/name/{name}/dnsseckeys/ds, which returns the DS records of the
+// CDN's KSKs, including those which must be published in the parent zone for a KSK rollover to finish.
+func GetDSRecords(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	if _, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName)); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("cdn '"+cdnName+"' not found"), nil)
+		return
+	}
+
+	keys, ok, err := inf.Config.TrafficVault.GetDNSSECKeys(cdnName, inf.Tx.Tx)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting DNSSEC CDN keys: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("cdn '"+cdnName+"' has no DNSSEC keys"), nil)
+		return
+	}
+
+	records, err := makeDSRecords(keys[cdnName].KSK, getDSRecordTTLOrDefault(inf.Tx.Tx, cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("making DS records: "+err.Error()))
+		return
+	}
+	api.WriteResp(w, r, records)
+}
+
+// ConfirmDSPublished is the handler for POST requests to cdns/name/{name}/dnsseckeys/ds, which confirms the DS record
+// of the CDN's pending KSK was published in the parent zone, finishing its rollover.
+func ConfirmDSPublished(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	req := tc.DNSSECDSPublishedRequest{}
+	if err := api.Parse(r.Body, inf.Tx.Tx, &req); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("parsing request: "+err.Error()), nil)
+		return
+	}
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("cdn '"+cdnName+"' not found"), nil)
+		return
+	}
+
+	keys, ok, err := inf.Config.TrafficVault.GetDNSSECKeys(cdnName, inf.Tx.Tx)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting DNSSEC CDN keys: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("cdn '"+cdnName+"' has no DNSSEC keys"), nil)
+		return
+	}
+
+	retireAt := time.Now().Add(getDSRecordTTLOrDefault(inf.Tx.Tx, cdnName) * dsRetireTTLMultiplier)
+	keySet, userErr, sysErr := confirmDSPublished(keys[cdnName], *req.KeyTag, retireAt)
+	if sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, sysErr)
+		return
+	} else if userErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	keys[cdnName] = keySet
+	if err := inf.Config.TrafficVault.PutDNSSECKeys(cdnName, keys, inf.Tx.Tx); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("putting CDN DNSSEC keys: "+err.Error()))
+		return
+	}
+
+	keyTag := strconv.Itoa(int(*req.KeyTag))
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Confirmed DS record of KSK "+keyTag+" published", inf.User, inf.Tx.Tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "KSK "+keyTag+" of cdn "+cdnName+" is active")
+}
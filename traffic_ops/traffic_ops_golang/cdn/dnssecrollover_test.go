@@ -0,0 +1,168 @@
+package cdn
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+
+	"github.com/miekg/dns"
+)
+
+func makeTestCDNKeys(t *testing.T) tc.DNSSECKeySetV11 {
+	now := time.Now()
+	ksk, err := deliveryservice.GetDNSSECKeysV11(tc.DNSSECKSKType, "cdn.example.net.", time.Minute, now, now.Add(30*24*time.Hour), tc.DNSSECKeyStatusNew, now, true, dns.ECDSAP256SHA256)
+	if err != nil {
+		t.Fatalf("generating KSK: %v", err)
+	}
+	zsk, err := deliveryservice.GetDNSSECKeysV11(tc.DNSSECZSKType, "cdn.example.net.", time.Minute, now, now.Add(30*24*time.Hour), tc.DNSSECKeyStatusNew, now, false, dns.ECDSAP256SHA256)
+	if err != nil {
+		t.Fatalf("generating ZSK: %v", err)
+	}
+	ksk.RolloverPhase = tc.DNSSECRolloverPhaseActive
+	zsk.RolloverPhase = tc.DNSSECRolloverPhaseActive
+	return tc.DNSSECKeySetV11{ZSK: []tc.DNSSECKeyV11{zsk}, KSK: []tc.DNSSECKeyV11{ksk}}
+}
+
+func TestRegenExpiredKeysZSKPrePublish(t *testing.T) {
+	keys := makeTestCDNKeys(t)
+	effective := time.Now().Add(10 * time.Minute)
+	regen, err := regenExpiredKeys(false, "cdn.example.net.", keys, effective, false, false, dns.ED25519)
+	if err != nil {
+		t.Fatalf("regenerating ZSK: %v", err)
+	}
+	if len(regen.ZSK) != 2 {
+		t.Fatalf("expected 2 ZSKs, actual %d", len(regen.ZSK))
+	}
+	if regen.ZSK[0].RolloverPhase != tc.DNSSECRolloverPhasePublished {
+		t.Errorf("expected new ZSK phase %s, actual %s", tc.DNSSECRolloverPhasePublished, regen.ZSK[0].RolloverPhase)
+	}
+	if regen.ZSK[1].RolloverPhase != tc.DNSSECRolloverPhaseRetiring || regen.ZSK[1].Status != tc.DNSSECKeyStatusExpired {
+		t.Errorf("expected old ZSK phase %s and status %s, actual %s and %s", tc.DNSSECRolloverPhaseRetiring, tc.DNSSECKeyStatusExpired, regen.ZSK[1].RolloverPhase, regen.ZSK[1].Status)
+	}
+	if alg, err := deliveryservice.GetKeySetAlgorithm(regen.ZSK); err != nil || alg != dns.ECDSAP256SHA256 {
+		t.Errorf("expected the rollover to keep algorithm %d, actual %d (error: %v)", dns.ECDSAP256SHA256, alg, err)
+	}
+
+	advanced, changed := advanceDNSSECRollovers(regen, time.Now(), time.Minute)
+	if changed {
+		t.Errorf("expected nothing to change before the new ZSK's effective date, actual %+v", advanced.ZSK)
+	}
+	advanced, changed = advanceDNSSECRollovers(regen, effective, time.Minute)
+	if !changed || advanced.ZSK[0].RolloverPhase != tc.DNSSECRolloverPhaseActive {
+		t.Errorf("expected the new ZSK to be active at its effective date, actual %s", advanced.ZSK[0].RolloverPhase)
+	}
+	if retired, _ := advanceDNSSECRollovers(advanced, time.Unix(regen.ZSK[1].ExpirationDateUnix, 0), time.Minute); len(retired.ZSK) != 2 {
+		t.Errorf("expected the old ZSK to be kept when it expired, actual %d ZSKs", len(retired.ZSK))
+	}
+}
+
+func TestRetiredKeyRemovalTime(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).Truncate(time.Second)
+	key := tc.DNSSECKeyV11{ExpirationDateUnix: expiration.Unix(), TTLSeconds: 60, RolloverPhase: tc.DNSSECRolloverPhaseRetiring}
+	keys := tc.DNSSECKeySetV11{ZSK: []tc.DNSSECKeyV11{key}, KSK: []tc.DNSSECKeyV11{key}}
+
+	// The key's TTL of a minute gives signatures 5 minutes of validity, longer than the CDN's.
+	removeAt := expiration.Add(5 * time.Minute)
+	if actual := retiredKeyRemovalTime(key, time.Minute); !actual.Equal(removeAt) {
+		t.Errorf("expected the key to be removable at %v, actual %v", removeAt, actual)
+	}
+	for _, now := range []time.Time{expiration, removeAt.Add(-time.Second)} {
+		if advanced, changed := advanceDNSSECRollovers(keys, now, time.Minute); changed || len(advanced.ZSK) != 1 || len(advanced.KSK) != 1 {
+			t.Errorf("expected the retiring keys to be kept at %v, while their signatures may be valid, actual %d ZSKs and %d KSKs", now, len(advanced.ZSK), len(advanced.KSK))
+		}
+	}
+	if advanced, changed := advanceDNSSECRollovers(keys, removeAt, time.Minute); !changed || len(advanced.ZSK) != 0 || len(advanced.KSK) != 0 {
+		t.Errorf("expected the retiring keys to be removed once their signatures can't be valid, actual %d ZSKs and %d KSKs", len(advanced.ZSK), len(advanced.KSK))
+	}
+
+	// A longer signature validity for the CDN keeps the key for longer.
+	if actual := retiredKeyRemovalTime(key, time.Hour); !actual.Equal(expiration.Add(time.Hour)) {
+		t.Errorf("expected the key to be removable at %v, actual %v", expiration.Add(time.Hour), actual)
+	}
+	if advanced, _ := advanceDNSSECRollovers(keys, removeAt, time.Hour); len(advanced.ZSK) != 1 || len(advanced.KSK) != 1 {
+		t.Errorf("expected the retiring keys to be kept within the CDN's signature validity, actual %d ZSKs and %d KSKs", len(advanced.ZSK), len(advanced.KSK))
+	}
+}
+
+func TestKSKDoubleSignatureRollover(t *testing.T) {
+	keys := makeTestCDNKeys(t)
+	regen, err := regenExpiredKeys(true, "cdn.example.net.", keys, time.Now(), true, false, dns.ECDSAP256SHA256)
+	if err != nil {
+		t.Fatalf("regenerating KSK: %v", err)
+	}
+	if len(regen.KSK) != 2 {
+		t.Fatalf("expected 2 KSKs, actual %d", len(regen.KSK))
+	}
+	if regen.KSK[0].RolloverPhase != tc.DNSSECRolloverPhaseDSPending {
+		t.Errorf("expected new KSK phase %s, actual %s", tc.DNSSECRolloverPhaseDSPending, regen.KSK[0].RolloverPhase)
+	}
+	if regen.KSK[1].RolloverPhase != tc.DNSSECRolloverPhaseActive || regen.KSK[1].ExpirationDateUnix != regen.KSK[0].ExpirationDateUnix {
+		t.Errorf("expected old KSK to stay active until the new KSK expires, actual phase %s expiration %d", regen.KSK[1].RolloverPhase, regen.KSK[1].ExpirationDateUnix)
+	}
+
+	records, err := makeDSRecords(regen.KSK, time.Minute)
+	if err != nil {
+		t.Fatalf("making DS records: %v", err)
+	}
+	if len(records) != 2 || !records[0].Pending || records[1].Pending {
+		t.Fatalf("expected the new KSK's DS record to be the only pending one, actual %+v", records)
+	}
+
+	if _, userErr, sysErr := confirmDSPublished(regen, records[0].KeyTag+1, time.Now()); userErr == nil || sysErr != nil {
+		t.Errorf("expected a user error confirming an unknown key tag, actual %v %v", userErr, sysErr)
+	}
+	if _, userErr, sysErr := confirmDSPublished(regen, records[1].KeyTag, time.Now()); userErr == nil || sysErr != nil {
+		t.Errorf("expected a user error confirming a KSK which isn't pending, actual %v %v", userErr, sysErr)
+	}
+
+	retireAt := time.Now().Add(2 * time.Minute)
+	confirmed, userErr, sysErr := confirmDSPublished(regen, records[0].KeyTag, retireAt)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("confirming DS record published: %v %v", userErr, sysErr)
+	}
+	if confirmed.KSK[0].RolloverPhase != tc.DNSSECRolloverPhaseActive {
+		t.Errorf("expected the new KSK to be active, actual %s", confirmed.KSK[0].RolloverPhase)
+	}
+	if confirmed.KSK[1].RolloverPhase != tc.DNSSECRolloverPhaseRetiring || confirmed.KSK[1].ExpirationDateUnix != retireAt.Unix() {
+		t.Errorf("expected the old KSK to be retiring at %d, actual phase %s expiration %d", retireAt.Unix(), confirmed.KSK[1].RolloverPhase, confirmed.KSK[1].ExpirationDateUnix)
+	}
+	if regen.KSK[0].RolloverPhase != tc.DNSSECRolloverPhaseDSPending {
+		t.Error("expected confirming a DS record not to modify the given key set")
+	}
+}
+
+func TestGenerateKSKResetsExpiration(t *testing.T) {
+	keys := makeTestCDNKeys(t)
+	effective := time.Now().Add(time.Minute)
+	regen, err := regenExpiredKeys(true, "cdn.example.net.", keys, effective, true, true, dns.ECDSAP256SHA256)
+	if err != nil {
+		t.Fatalf("regenerating KSK: %v", err)
+	}
+	if regen.KSK[0].RolloverPhase != tc.DNSSECRolloverPhasePublished {
+		t.Errorf("expected new KSK phase %s, actual %s", tc.DNSSECRolloverPhasePublished, regen.KSK[0].RolloverPhase)
+	}
+	if regen.KSK[1].RolloverPhase != tc.DNSSECRolloverPhaseRetiring || regen.KSK[1].ExpirationDateUnix != effective.Unix() {
+		t.Errorf("expected old KSK to retire at %d, actual phase %s expiration %d", effective.Unix(), regen.KSK[1].RolloverPhase, regen.KSK[1].ExpirationDateUnix)
+	}
+}
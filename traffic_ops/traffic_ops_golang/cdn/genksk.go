@@ -31,7 +31,6 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
 )
 
 const DefaultKSKTTLSeconds = 60
@@ -87,25 +86,31 @@ func GenerateKSK(w http.ResponseWriter, r *http.Request) {
 		multiplier = &mult
 	}
 
-	dnssecKeys, ok, err := riaksvc.GetDNSSECKeys(string(cdnName), inf.Tx.Tx, inf.Config.RiakAuthOptions, inf.Config.RiakPort)
+	dnssecKeys, ok, err := inf.Config.TrafficVault.GetDNSSECKeys(string(cdnName), inf.Tx.Tx)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting CDN DNSSEC keys: "+err.Error()))
 		return
 	}
 	if !ok {
-		log.Warnln("Generating CDN '" + string(cdnName) + "' KSK: no keys found in Riak, generating and inserting new key anyway")
+		log.Warnln("Generating CDN '" + string(cdnName) + "' KSK: no keys found in Traffic Vault, generating and inserting new key anyway")
+	}
+
+	algorithm, err := getCDNDNSSECAlgorithm(inf.Tx.Tx, cdnName)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting CDN DNSSEC algorithm: "+err.Error()))
+		return
 	}
 
 	isKSK := true
 	cdnDNSDomain := cdnDomain + "."
-	newKey, err := regenExpiredKeys(isKSK, cdnDNSDomain, dnssecKeys[string(cdnName)], *req.EffectiveDate, true, true)
+	newKey, err := regenExpiredKeys(isKSK, cdnDNSDomain, dnssecKeys[string(cdnName)], *req.EffectiveDate, true, true, algorithm)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("regenerating CDN DNSSEC keys: "+err.Error()))
 		return
 	}
 	dnssecKeys[string(cdnName)] = newKey
 
-	if err := riaksvc.PutDNSSECKeys(dnssecKeys, string(cdnName), inf.Tx.Tx, inf.Config.RiakAuthOptions, inf.Config.RiakPort); err != nil {
+	if err := inf.Config.TrafficVault.PutDNSSECKeys(string(cdnName), dnssecKeys, inf.Tx.Tx); err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("putting CDN DNSSEC keys: "+err.Error()))
 		return
 	}
@@ -168,9 +173,20 @@ WHERE
 
 const DefaultDNSSECKeyTTL = 60 * time.Second
 
+// getCDNDNSSECAlgorithm returns the IANA number of the algorithm of the DNSSEC keys generated for the given CDN.
+func getCDNDNSSECAlgorithm(tx *sql.Tx, cdn tc.CDNName) (uint8, error) {
+	name := ""
+	if err := tx.QueryRow(`SELECT dnssec_algorithm FROM cdn WHERE name = $1`, cdn).Scan(&name); err != nil {
+		return 0, errors.New("querying cdn DNSSEC algorithm: " + err.Error())
+	}
+	return deliveryservice.DNSSECAlgorithmNumber(name)
+}
+
 // regenExpiredKeys regenerates expired keys. The key is the map key into the keys object, which may be a CDN name or a delivery service name.
 // The name is the name of the key, either the CDN name or the Delivery Service name. If existingKeys contains any keys marked "new", the name argument is not used, but the name of the previously-new key is used instead. These should match, and a warning is logged if they differ.
-func regenExpiredKeys(typeKSK bool, name string, existingKeys tc.DNSSECKeySetV11, effectiveDate time.Time, tld bool, resetExp bool) (tc.DNSSECKeySetV11, error) {
+// The new key uses the algorithm of the previously-new key, so a rollover never changes the algorithm; the algorithm argument is only used if there is no such key.
+// The new key and the key it replaces are given the phases of their rollover; see tc.DNSSECRolloverPhasePublished.
+func regenExpiredKeys(typeKSK bool, name string, existingKeys tc.DNSSECKeySetV11, effectiveDate time.Time, tld bool, resetExp bool, algorithm uint8) (tc.DNSSECKeySetV11, error) {
 	existingKey := ([]tc.DNSSECKeyV11)(nil)
 	if typeKSK {
 		existingKey = existingKeys.KSK
@@ -209,23 +225,47 @@ func regenExpiredKeys(typeKSK bool, name string, existingKeys tc.DNSSECKeySetV11
 		name = oldKey.Name
 		ttl = time.Duration(oldKey.TTLSeconds) * time.Second
 		newExpiration = time.Now().Add(time.Duration(expirationDays) * time.Hour * 24)
+
+		oldAlgorithm, err := deliveryservice.GetKeySetAlgorithm([]tc.DNSSECKeyV11{oldKey})
+		if err != nil {
+			return tc.DNSSECKeySetV11{}, errors.New("getting algorithm of existing key: " + err.Error())
+		}
+		algorithm = oldAlgorithm
 	}
 
 	keyType := tc.DNSSECKSKType
 	if !typeKSK {
 		keyType = tc.DNSSECZSKType
 	}
-	newKey, err := deliveryservice.GetDNSSECKeysV11(keyType, name, ttl, newInception, newExpiration, tc.DNSSECKeyStatusNew, effectiveDate, tld)
+	newKey, err := deliveryservice.GetDNSSECKeysV11(keyType, name, ttl, newInception, newExpiration, tc.DNSSECKeyStatusNew, effectiveDate, tld, algorithm)
 	if err != nil {
 		return tc.DNSSECKeySetV11{}, errors.New("getting and generating DNSSEC keys: " + err.Error())
 	}
 
+	// Only a CDN's KSK has a DS record in the parent zone; the DS records of Delivery Services' KSKs are served by Traffic Router.
+	doubleSigned := typeKSK && tld && oldKeyFound && !resetExp
+	switch {
+	case doubleSigned:
+		newKey.RolloverPhase = tc.DNSSECRolloverPhaseDSPending
+	case effectiveDate.After(newInception):
+		newKey.RolloverPhase = tc.DNSSECRolloverPhasePublished
+	default:
+		newKey.RolloverPhase = tc.DNSSECRolloverPhaseActive
+	}
+
 	newKeys := []tc.DNSSECKeyV11{newKey}
 	if oldKeyFound {
 		oldKey.Status = tc.DNSSECKeyStatusExpired
 		if resetExp {
 			oldKey.ExpirationDateUnix = effectiveDate.Unix()
 		}
+		if doubleSigned {
+			// The old KSK keeps signing until the new KSK's DS record is confirmed published, see confirmDSPublished.
+			oldKey.RolloverPhase = tc.DNSSECRolloverPhaseActive
+			oldKey.ExpirationDateUnix = newKey.ExpirationDateUnix
+		} else {
+			oldKey.RolloverPhase = tc.DNSSECRolloverPhaseRetiring
+		}
 
 		newKeys = append(newKeys, oldKey)
 	}
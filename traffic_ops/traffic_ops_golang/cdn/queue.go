@@ -62,6 +62,21 @@ func Queue(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+string(cdnName)+", ID: "+strconv.Itoa(inf.IntParams["id"])+", ACTION: CDN server updates "+reqObj.Action+"d", inf.User, inf.Tx.Tx)
+
+	cdn := string(cdnName)
+	ev := tc.WebhookEvent{
+		Action:     tc.WebhookActionDequeued,
+		ObjectType: "cdn",
+		ObjectID:   strconv.Itoa(inf.IntParams["id"]),
+		CDNName:    &cdn,
+	}
+	if reqObj.Action == "queue" {
+		ev.Action = tc.WebhookActionQueued
+	}
+	if err := api.NotifyWebhooks(inf, ev); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
 	api.WriteResp(w, r, tc.CDNQueueUpdateResponse{Action: reqObj.Action, CDNID: int64(inf.IntParams["id"])})
 }
 
@@ -26,9 +26,7 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
-
-	"github.com/basho/riak-go-client"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
 )
 
 func GetSSLKeys(w http.ResponseWriter, r *http.Request) {
@@ -38,7 +36,7 @@ func GetSSLKeys(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer inf.Close()
-	keys, err := getSSLKeys(inf.Tx.Tx, inf.Config.RiakAuthOptions, inf.Config.RiakPort, inf.Params["name"])
+	keys, err := getSSLKeys(inf.Tx.Tx, inf.Config.TrafficVault, inf.Params["name"])
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn ssl keys: "+err.Error()))
 		return
@@ -46,10 +44,10 @@ func GetSSLKeys(w http.ResponseWriter, r *http.Request) {
 	api.WriteResp(w, r, keys)
 }
 
-func getSSLKeys(tx *sql.Tx, authOpts *riak.AuthOptions, riakPort *uint, cdnName string) ([]tc.CDNSSLKey, error) {
-	keys, err := riaksvc.GetCDNSSLKeysObj(tx, authOpts, riakPort, cdnName)
+func getSSLKeys(tx *sql.Tx, tv trafficvault.TrafficVault, cdnName string) ([]tc.CDNSSLKey, error) {
+	keys, err := tv.GetCDNSSLKeys(cdnName, tx)
 	if err != nil {
-		return nil, errors.New("getting cdn ssl keys from Riak: " + err.Error())
+		return nil, errors.New("getting cdn ssl keys from Traffic Vault: " + err.Error())
 	}
 	return keys, nil
 }
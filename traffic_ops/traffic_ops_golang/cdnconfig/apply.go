@@ -0,0 +1,710 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cachegroup"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cdn"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	dsserver "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice/servers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/division"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/physlocation"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/profile"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/region"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/server"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/servercapability"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/topology"
+
+	"github.com/lib/pq"
+)
+
+// applier makes the changes of a plan in the transaction of an APIInfo, using the same validation and change logging as the API endpoints for each type of object.
+type applier struct {
+	inf     *api.APIInfo
+	st      *state
+	desired tc.CDNConfiguration
+}
+
+// apply makes the changes of p, which must have been planned from st to desired, in the transaction of inf. The transaction is left for the caller to commit.
+func apply(inf *api.APIInfo, st *state, desired tc.CDNConfiguration, p tc.CDNConfigurationPlan) (error, error, int) {
+	a := applier{inf: inf, st: st, desired: desired}
+
+	steps := []struct {
+		objType string
+		action  string
+		apply   func([]tc.CDNConfigurationChange) (error, error, int)
+	}{
+		{typeCDN, tc.CDNConfigurationUpdate, a.updateCDN},
+		{typeDivisions, "", a.applyDivisions},
+		{typeRegions, "", a.applyRegions},
+		{typePhysLocations, "", a.applyPhysLocations},
+		{typeCacheGroups, "", a.applyCacheGroups},
+		{typeServerCapabilities, "", a.applyServerCapabilities},
+		{typeProfiles, "", a.applyProfiles},
+		{typeTopologies, "", a.applyTopologies},
+		{typeServers, "", a.applyServers},
+		{typeDeliveryServices, "", a.applyDeliveryServices},
+		{typeDeliveryServices, tc.CDNConfigurationDelete, a.deleteDeliveryServices},
+		{typeServers, tc.CDNConfigurationDelete, a.deleteServers},
+		{typeProfiles, tc.CDNConfigurationDelete, a.deleteProfiles},
+	}
+	for _, step := range steps {
+		changes := []tc.CDNConfigurationChange{}
+		for _, change := range p.Changes {
+			if change.Type != step.objType {
+				continue
+			}
+			if (step.action == "" && change.Action != tc.CDNConfigurationDelete) || change.Action == step.action {
+				changes = append(changes, change)
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if userErr, sysErr, errCode := step.apply(changes); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+// wrapErrs prefixes any errors with the type and name of the object being changed, so users can tell which part of a document failed.
+func wrapErrs(change tc.CDNConfigurationChange, userErr error, sysErr error, errCode int) (error, error, int) {
+	if userErr != nil {
+		userErr = fmt.Errorf("%s %s '%s': %v", change.Action, change.Type, change.Name, userErr)
+	}
+	if sysErr != nil {
+		sysErr = fmt.Errorf("%s %s '%s': %v", change.Action, change.Type, change.Name, sysErr)
+	}
+	return userErr, sysErr, errCode
+}
+
+func hasField(change tc.CDNConfigurationChange, fields ...string) bool {
+	for _, changed := range change.Fields {
+		for _, field := range fields {
+			if changed == field {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasOtherField returns whether the change is to any field but the given ones.
+func hasOtherField(change tc.CDNConfigurationChange, fields ...string) bool {
+	for _, changed := range change.Fields {
+		if !hasField(tc.CDNConfigurationChange{Fields: fields}, changed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *applier) idByName(table string, objType string, name string) (int, error, error, int) {
+	if id, ok := a.st.ids[objType][name]; ok && id != 0 {
+		return id, nil, nil, http.StatusOK
+	}
+	id, userErr, sysErr, errCode := getIDByName(a.inf.Tx.Tx, table, objType, name)
+	if userErr == nil && sysErr == nil {
+		a.st.setID(objType, name, id)
+	}
+	return id, userErr, sysErr, errCode
+}
+
+func (a *applier) typeID(name string) (int, error, error, int) {
+	id, ok, err := dbhelpers.GetTypeIDByName(name, a.inf.Tx.Tx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("getting type '%s': %v", name, err), http.StatusInternalServerError
+	}
+	if !ok {
+		return 0, fmt.Errorf("no type named '%s' exists", name), nil, http.StatusBadRequest
+	}
+	return id, nil, nil, http.StatusOK
+}
+
+func (a *applier) updateCDN(changes []tc.CDNConfigurationChange) (error, error, int) {
+	obj := cdn.TOCDN{}
+	obj.SetInfo(a.inf)
+	obj.ID = util.IntPtr(a.st.cdnID)
+	obj.Name = util.StrPtr(a.desired.CDN.Name)
+	obj.DomainName = util.StrPtr(a.desired.CDN.DomainName)
+	obj.DNSSECEnabled = util.BoolPtr(a.desired.CDN.DNSSECEnabled)
+	if err := obj.Validate(); err != nil {
+		return wrapErrs(changes[0], err, nil, http.StatusBadRequest)
+	}
+	if userErr, sysErr, errCode := obj.Update(http.Header{}); userErr != nil || sysErr != nil {
+		return wrapErrs(changes[0], userErr, sysErr, errCode)
+	}
+	if err := api.CreateChangeLogAudit(api.ApiChange, api.Updated, &obj, a.st.CDN, a.inf); err != nil {
+		return nil, errors.New("writing change log entry: " + err.Error()), http.StatusInternalServerError
+	}
+	return nil, nil, http.StatusOK
+}
+
+// createOrUpdate validates the given object, then creates it or updates it according to the action of the change, and logs it.
+func (a *applier) createOrUpdate(change tc.CDNConfigurationChange, obj api.CRUDer, validate func() error, before interface{}) (error, error, int) {
+	if err := validate(); err != nil {
+		return wrapErrs(change, err, nil, http.StatusBadRequest)
+	}
+	userErr, sysErr, errCode := error(nil), error(nil), http.StatusOK
+	action := api.Created
+	if change.Action == tc.CDNConfigurationCreate {
+		userErr, sysErr, errCode = obj.Create()
+	} else {
+		action = api.Updated
+		userErr, sysErr, errCode = obj.Update(http.Header{})
+	}
+	if userErr != nil || sysErr != nil {
+		return wrapErrs(change, userErr, sysErr, errCode)
+	}
+	identifier, ok := obj.(api.Identifier)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an identifier", change.Type), http.StatusInternalServerError
+	}
+	if err := api.CreateChangeLogAudit(api.ApiChange, action, identifier, before, a.inf); err != nil {
+		return nil, errors.New("writing change log entry: " + err.Error()), http.StatusInternalServerError
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) currentObject(objType string, name string) interface{} {
+	for _, o := range namedObjects(a.st.CDNConfiguration, objType) {
+		if o.name == name {
+			return o.value
+		}
+	}
+	return nil
+}
+
+func (a *applier) desiredObject(objType string, name string) interface{} {
+	for _, o := range namedObjects(a.desired, objType) {
+		if o.name == name {
+			return o.value
+		}
+	}
+	return nil
+}
+
+func (a *applier) applyDivisions(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		obj := division.TODivision{}
+		obj.SetInfo(a.inf)
+		obj.Name = util.StrPtr(change.Name)
+		if userErr, sysErr, errCode := a.createOrUpdate(change, &obj, obj.Validate, nil); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		a.st.setID(typeDivisions, change.Name, *obj.ID)
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) applyRegions(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		desired := a.desiredObject(typeRegions, change.Name).(tc.CDNConfigurationRegion)
+		divisionID, userErr, sysErr, errCode := a.idByName("division", typeDivisions, desired.Division)
+		if userErr != nil || sysErr != nil {
+			return wrapErrs(change, userErr, sysErr, errCode)
+		}
+		obj := region.TORegion{}
+		obj.SetInfo(a.inf)
+		obj.ID = a.st.ids[typeRegions][change.Name]
+		obj.Name = desired.Name
+		obj.Division = divisionID
+		obj.DivisionName = desired.Division
+		if userErr, sysErr, errCode := a.createOrUpdate(change, &obj, obj.Validate, a.currentObject(typeRegions, change.Name)); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		a.st.setID(typeRegions, change.Name, obj.ID)
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) applyPhysLocations(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		desired := a.desiredObject(typePhysLocations, change.Name).(tc.CDNConfigurationPhysLocation)
+		regionID, userErr, sysErr, errCode := a.idByName("region", typeRegions, desired.Region)
+		if userErr != nil || sysErr != nil {
+			return wrapErrs(change, userErr, sysErr, errCode)
+		}
+		obj := physlocation.TOPhysLocation{}
+		obj.SetInfo(a.inf)
+		if id, ok := a.st.ids[typePhysLocations][change.Name]; ok {
+			obj.ID = util.IntPtr(id)
+		}
+		obj.Name = util.StrPtr(desired.Name)
+		obj.ShortName = util.StrPtr(desired.ShortName)
+		obj.RegionID = util.IntPtr(regionID)
+		obj.RegionName = util.StrPtr(desired.Region)
+		obj.Address = util.StrPtr(desired.Address)
+		obj.City = util.StrPtr(desired.City)
+		obj.State = util.StrPtr(desired.State)
+		obj.Zip = util.StrPtr(desired.Zip)
+		obj.Comments = desired.Comments
+		obj.Email = desired.Email
+		obj.Phone = desired.Phone
+		obj.POC = desired.POC
+		if userErr, sysErr, errCode := a.createOrUpdate(change, &obj, obj.Validate, a.currentObject(typePhysLocations, change.Name)); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		a.st.setID(typePhysLocations, change.Name, *obj.ID)
+	}
+	return nil, nil, http.StatusOK
+}
+
+// applyCacheGroups creates and updates Cache Groups after their parents. Because fallbacks may refer to each other, fallbacks which don't exist yet are set in a second update, once every Cache Group exists.
+func (a *applier) applyCacheGroups(changes []tc.CDNConfigurationChange) (error, error, int) {
+	pending := map[string]tc.CDNConfigurationChange{}
+	for _, change := range changes {
+		pending[change.Name] = change
+	}
+	ordered := []tc.CDNConfigurationChange{}
+	for len(pending) > 0 {
+		progressed := false
+		for _, change := range changes {
+			if _, ok := pending[change.Name]; !ok {
+				continue
+			}
+			desired := a.desiredObject(typeCacheGroups, change.Name).(tc.CDNConfigurationCacheGroup)
+			ready := true
+			for _, parent := range []*string{desired.ParentCacheGroup, desired.SecondaryParentCacheGroup} {
+				if parent == nil {
+					continue
+				}
+				if _, ok := pending[*parent]; ok && *parent != change.Name {
+					ready = false
+				}
+			}
+			if ready {
+				ordered = append(ordered, change)
+				delete(pending, change.Name)
+				progressed = true
+			}
+		}
+		if !progressed {
+			names := []string{}
+			for name := range pending {
+				names = append(names, name)
+			}
+			return fmt.Errorf("cacheGroups: parents form a cycle among %v", names), nil, http.StatusBadRequest
+		}
+	}
+
+	deferred := []tc.CDNConfigurationChange{}
+	for _, change := range ordered {
+		desired := a.desiredObject(typeCacheGroups, change.Name).(tc.CDNConfigurationCacheGroup)
+		fallbacks := []string{}
+		for _, fallback := range desired.Fallbacks {
+			if _, ok := a.st.ids[typeCacheGroups][fallback]; ok {
+				fallbacks = append(fallbacks, fallback)
+			}
+		}
+		if len(fallbacks) != len(desired.Fallbacks) {
+			deferred = append(deferred, change)
+		}
+		if userErr, sysErr, errCode := a.applyCacheGroup(change, desired, fallbacks); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+	}
+	for _, change := range deferred {
+		desired := a.desiredObject(typeCacheGroups, change.Name).(tc.CDNConfigurationCacheGroup)
+		change.Action = tc.CDNConfigurationUpdate
+		if userErr, sysErr, errCode := a.applyCacheGroup(change, desired, desired.Fallbacks); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) applyCacheGroup(change tc.CDNConfigurationChange, desired tc.CDNConfigurationCacheGroup, fallbacks []string) (error, error, int) {
+	typeID, userErr, sysErr, errCode := a.typeID(desired.Type)
+	if userErr != nil || sysErr != nil {
+		return wrapErrs(change, userErr, sysErr, errCode)
+	}
+	obj := cachegroup.TOCacheGroup{}
+	obj.SetInfo(a.inf)
+	if id, ok := a.st.ids[typeCacheGroups][change.Name]; ok {
+		obj.ID = util.IntPtr(id)
+	}
+	obj.Name = util.StrPtr(desired.Name)
+	obj.ShortName = util.StrPtr(desired.ShortName)
+	obj.Latitude = desired.Latitude
+	obj.Longitude = desired.Longitude
+	obj.TypeID = util.IntPtr(typeID)
+	obj.Type = util.StrPtr(desired.Type)
+	obj.FallbackToClosest = desired.FallbackToClosest
+	localizationMethods := append([]tc.LocalizationMethod{}, desired.LocalizationMethods...)
+	obj.LocalizationMethods = &localizationMethods
+	obj.Fallbacks = &fallbacks
+	if desired.ParentCacheGroup != nil {
+		id, userErr, sysErr, errCode := a.idByName("cachegroup", typeCacheGroups, *desired.ParentCacheGroup)
+		if userErr != nil || sysErr != nil {
+			return wrapErrs(change, userErr, sysErr, errCode)
+		}
+		obj.ParentCachegroupID = util.IntPtr(id)
+	}
+	if desired.SecondaryParentCacheGroup != nil {
+		id, userErr, sysErr, errCode := a.idByName("cachegroup", typeCacheGroups, *desired.SecondaryParentCacheGroup)
+		if userErr != nil || sysErr != nil {
+			return wrapErrs(change, userErr, sysErr, errCode)
+		}
+		obj.SecondaryParentCachegroupID = util.IntPtr(id)
+	}
+	if userErr, sysErr, errCode := a.createOrUpdate(change, &obj, obj.Validate, a.currentObject(typeCacheGroups, change.Name)); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	a.st.setID(typeCacheGroups, change.Name, *obj.ID)
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) applyServerCapabilities(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		obj := servercapability.TOServerCapability{}
+		obj.SetInfo(a.inf)
+		obj.Name = change.Name
+		if userErr, sysErr, errCode := a.createOrUpdate(change, &obj, obj.Validate, nil); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) applyProfiles(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		desired := a.desiredObject(typeProfiles, change.Name).(tc.CDNConfigurationProfile)
+		if change.Action == tc.CDNConfigurationCreate || hasOtherField(change, "parameters") {
+			obj := profile.TOProfile{}
+			obj.SetInfo(a.inf)
+			if id, ok := a.st.ids[typeProfiles][change.Name]; ok {
+				obj.ID = util.IntPtr(id)
+			}
+			obj.Name = util.StrPtr(desired.Name)
+			obj.Description = util.StrPtr(desired.Description)
+			obj.CDNID = util.IntPtr(a.st.cdnID)
+			obj.CDNName = util.StrPtr(a.desired.CDN.Name)
+			obj.Type = util.StrPtr(desired.Type)
+			obj.RoutingDisabled = util.BoolPtr(desired.RoutingDisabled)
+			if userErr, sysErr, errCode := a.createOrUpdate(change, &obj, obj.Validate, a.currentObject(typeProfiles, change.Name)); userErr != nil || sysErr != nil {
+				return userErr, sysErr, errCode
+			}
+			a.st.setID(typeProfiles, change.Name, *obj.ID)
+		}
+		if change.Action == tc.CDNConfigurationCreate || hasField(change, "parameters") {
+			if err := a.replaceProfileParameters(a.st.ids[typeProfiles][change.Name], desired); err != nil {
+				return wrapErrs(change, nil, err, http.StatusInternalServerError)
+			}
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+// replaceProfileParameters replaces the Parameters of the Profile with the given ID with those of the desired Profile. Parameters may be shared between Profiles, so existing Parameters with the same name, config file, and value are used rather than creating new ones.
+func (a *applier) replaceProfileParameters(profileID int, desired tc.CDNConfigurationProfile) error {
+	tx := a.inf.Tx.Tx
+	ids := []int{}
+	for _, param := range desired.Parameters {
+		id := 0
+		err := tx.QueryRow(`SELECT id FROM parameter WHERE name = $1 AND config_file = $2 AND value = $3`, param.Name, param.ConfigFile, param.Value).Scan(&id)
+		if err == sql.ErrNoRows {
+			err = tx.QueryRow(`INSERT INTO parameter (name, config_file, value, secure) VALUES ($1, $2, $3, $4) RETURNING id`, param.Name, param.ConfigFile, param.Value, param.Secure).Scan(&id)
+		}
+		if err != nil {
+			return fmt.Errorf("getting parameter '%s' in '%s': %v", param.Name, param.ConfigFile, err)
+		}
+		ids = append(ids, id)
+	}
+	if _, err := tx.Exec(`DELETE FROM profile_parameter WHERE profile = $1`, profileID); err != nil {
+		return errors.New("deleting profile parameters: " + err.Error())
+	}
+	if _, err := tx.Exec(`INSERT INTO profile_parameter (profile, parameter) SELECT $1, UNNEST($2::bigint[]) ON CONFLICT DO NOTHING`, profileID, pq.Array(ids)); err != nil {
+		return errors.New("inserting profile parameters: " + err.Error())
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "PROFILE: "+desired.Name+", ID: "+strconv.Itoa(profileID)+", ACTION: Replaced parameters with "+strconv.Itoa(len(ids))+" parameters", a.inf.User, tx)
+	return nil
+}
+
+func (a *applier) applyTopologies(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		desired := a.desiredObject(typeTopologies, change.Name).(tc.CDNConfigurationTopology)
+		obj := topology.TOTopology{}
+		obj.Name = desired.Name
+		obj.Description = desired.Description
+		obj.Nodes = make([]tc.TopologyNode, 0, len(desired.Nodes))
+		for _, node := range desired.Nodes {
+			obj.Nodes = append(obj.Nodes, tc.TopologyNode{Cachegroup: node.Cachegroup, Parents: node.Parents})
+		}
+		if change.Action == tc.CDNConfigurationCreate {
+			obj.SetInfo(a.inf)
+		} else {
+			obj.SetInfo(infWithParams(a.inf, map[string]string{"name": change.Name}))
+			obj.SetKeys(map[string]interface{}{"name": change.Name})
+		}
+		if userErr, sysErr, errCode := a.createOrUpdate(change, &obj, obj.Validate, a.currentObject(typeTopologies, change.Name)); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+// resolveServer sets the IDs of the objects the given server refers to by name.
+func (a *applier) resolveServer(srv *tc.ServerV40) (error, error, int) {
+	srv.CDNID = util.IntPtr(a.st.cdnID)
+	srv.CDNName = util.StrPtr(a.desired.CDN.Name)
+	refs := []struct {
+		table   string
+		objType string
+		name    *string
+		id      **int
+	}{
+		{"cachegroup", typeCacheGroups, srv.Cachegroup, &srv.CachegroupID},
+		{"phys_location", typePhysLocations, srv.PhysLocation, &srv.PhysLocationID},
+		{"profile", typeProfiles, srv.Profile, &srv.ProfileID},
+		{"status", "status", srv.Status, &srv.StatusID},
+	}
+	for _, ref := range refs {
+		if ref.name == nil {
+			return fmt.Errorf("%s is required", ref.objType), nil, http.StatusBadRequest
+		}
+		id, userErr, sysErr, errCode := a.idByName(ref.table, ref.objType, *ref.name)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		*ref.id = util.IntPtr(id)
+	}
+	typeID, userErr, sysErr, errCode := a.typeID(srv.Type)
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	srv.TypeID = util.IntPtr(typeID)
+	return nil, nil, http.StatusOK
+}
+
+// overlay sets the fields of stored which are present in the JSON representation of desired, leaving the rest, such as IDs and the fields maintained by Traffic Ops, as they are.
+func overlay(stored interface{}, desired interface{}) error {
+	bts, err := json.Marshal(desired)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bts, stored)
+}
+
+func (a *applier) applyServers(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		desired := a.desiredObject(typeServers, change.Name).(tc.CDNConfigurationServer)
+		srv := tc.ServerV40{}
+		if change.Action == tc.CDNConfigurationUpdate {
+			srv = a.st.servers[change.Name]
+		}
+		if err := overlay(&srv, desired); err != nil {
+			return wrapErrs(change, nil, err, http.StatusInternalServerError)
+		}
+		if change.Action == tc.CDNConfigurationCreate || hasOtherField(change, "capabilities") {
+			if userErr, sysErr, errCode := a.resolveServer(&srv); userErr != nil || sysErr != nil {
+				return wrapErrs(change, userErr, sysErr, errCode)
+			}
+			userErr, sysErr, errCode := error(nil), error(nil), http.StatusOK
+			if change.Action == tc.CDNConfigurationCreate {
+				srv.ID = nil
+				if srv.UpdPending == nil {
+					srv.UpdPending = util.BoolPtr(false)
+				}
+				userErr, sysErr, errCode = server.CreateServer(a.inf, &srv)
+			} else {
+				userErr, sysErr, errCode = server.UpdateServer(a.inf, &srv)
+			}
+			if userErr != nil || sysErr != nil {
+				return wrapErrs(change, userErr, sysErr, errCode)
+			}
+			a.st.setID(typeServers, change.Name, *srv.ID)
+		}
+		if change.Action == tc.CDNConfigurationCreate || hasField(change, "capabilities") {
+			if err := a.replaceServerCapabilities(a.st.ids[typeServers][change.Name], change.Name, desired.Capabilities); err != nil {
+				return wrapErrs(change, nil, err, http.StatusInternalServerError)
+			}
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) replaceServerCapabilities(serverID int, hostName string, capabilities []string) error {
+	tx := a.inf.Tx.Tx
+	if _, err := tx.Exec(`DELETE FROM server_server_capability WHERE server = $1 AND NOT (server_capability = ANY($2))`, serverID, pq.Array(capabilities)); err != nil {
+		return errors.New("deleting server capabilities: " + err.Error())
+	}
+	if _, err := tx.Exec(`INSERT INTO server_server_capability (server, server_capability) SELECT $1, UNNEST($2::text[]) ON CONFLICT DO NOTHING`, serverID, pq.Array(capabilities)); err != nil {
+		return errors.New("inserting server capabilities: " + err.Error())
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "SERVER: "+hostName+", ID: "+strconv.Itoa(serverID)+", ACTION: Set server capabilities", a.inf.User, tx)
+	return nil
+}
+
+// resolveDeliveryService sets the IDs of the objects the given Delivery Service refers to by name.
+func (a *applier) resolveDeliveryService(ds *tc.DeliveryServiceV4) (error, error, int) {
+	ds.CDNID = util.IntPtr(a.st.cdnID)
+	ds.CDNName = util.StrPtr(a.desired.CDN.Name)
+	if ds.Type == nil {
+		return errors.New("type is required"), nil, http.StatusBadRequest
+	}
+	typeID, userErr, sysErr, errCode := a.typeID(ds.Type.String())
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	ds.TypeID = util.IntPtr(typeID)
+	ds.ProfileID = nil
+	if ds.ProfileName != nil && *ds.ProfileName != "" {
+		id, userErr, sysErr, errCode := a.idByName("profile", typeProfiles, *ds.ProfileName)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		ds.ProfileID = util.IntPtr(id)
+	}
+	if ds.Tenant != nil {
+		id, userErr, sysErr, errCode := a.idByName("tenant", "tenant", *ds.Tenant)
+		if userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		ds.TenantID = util.IntPtr(id)
+	} else if ds.TenantID == nil {
+		ds.TenantID = util.IntPtr(a.inf.User.TenantID)
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) applyDeliveryServices(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		desired := a.desiredObject(typeDeliveryServices, change.Name).(tc.CDNConfigurationDeliveryService)
+		ds := tc.DeliveryServiceV4{}
+		if change.Action == tc.CDNConfigurationUpdate {
+			ds = a.st.deliveryServices[change.Name]
+		}
+		if err := overlay(&ds, desired); err != nil {
+			return wrapErrs(change, nil, err, http.StatusInternalServerError)
+		}
+		if change.Action == tc.CDNConfigurationCreate || hasOtherField(change, "requiredCapabilities", "servers") {
+			if userErr, sysErr, errCode := a.resolveDeliveryService(&ds); userErr != nil || sysErr != nil {
+				return wrapErrs(change, userErr, sysErr, errCode)
+			}
+			var (
+				res     *tc.DeliveryServiceV40
+				errCode int
+				userErr error
+				sysErr  error
+			)
+			if change.Action == tc.CDNConfigurationCreate {
+				ds.ID = nil
+				res, errCode, userErr, sysErr = deliveryservice.CreateDeliveryService(a.inf, tc.DeliveryServiceV40(ds))
+			} else {
+				dsV40 := tc.DeliveryServiceV40(ds)
+				res, errCode, userErr, sysErr = deliveryservice.UpdateDeliveryService(a.inf, &dsV40)
+			}
+			if userErr != nil || sysErr != nil {
+				return wrapErrs(change, userErr, sysErr, errCode)
+			}
+			a.st.setID(typeDeliveryServices, change.Name, *res.ID)
+		}
+		dsID := a.st.ids[typeDeliveryServices][change.Name]
+		if change.Action == tc.CDNConfigurationCreate || hasField(change, "requiredCapabilities") {
+			if err := a.replaceRequiredCapabilities(dsID, change.Name, desired.RequiredCapabilities); err != nil {
+				return wrapErrs(change, nil, err, http.StatusInternalServerError)
+			}
+		}
+		if (change.Action == tc.CDNConfigurationCreate && len(desired.Servers) > 0) || hasField(change, "servers") {
+			serverIDs := make([]int, 0, len(desired.Servers))
+			for _, hostName := range desired.Servers {
+				id, ok := a.st.ids[typeServers][hostName]
+				if !ok {
+					return wrapErrs(change, fmt.Errorf("server '%s' is not in the CDN", hostName), nil, http.StatusBadRequest)
+				}
+				serverIDs = append(serverIDs, id)
+			}
+			if userErr, sysErr, errCode := dsserver.ReplaceServers(a.inf, dsID, serverIDs); userErr != nil || sysErr != nil {
+				return wrapErrs(change, userErr, sysErr, errCode)
+			}
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) replaceRequiredCapabilities(dsID int, xmlID string, capabilities []string) error {
+	tx := a.inf.Tx.Tx
+	if _, err := tx.Exec(`DELETE FROM deliveryservices_required_capability WHERE deliveryservice_id = $1 AND NOT (required_capability = ANY($2))`, dsID, pq.Array(capabilities)); err != nil {
+		return errors.New("deleting required capabilities: " + err.Error())
+	}
+	if _, err := tx.Exec(`INSERT INTO deliveryservices_required_capability (deliveryservice_id, required_capability) SELECT $1, UNNEST($2::text[]) ON CONFLICT DO NOTHING`, dsID, pq.Array(capabilities)); err != nil {
+		return errors.New("inserting required capabilities: " + err.Error())
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+xmlID+", ID: "+strconv.Itoa(dsID)+", ACTION: Set required capabilities", a.inf.User, tx)
+	return nil
+}
+
+func (a *applier) deleteDeliveryServices(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		obj := deliveryservice.TODeliveryService{}
+		obj.SetInfo(a.inf)
+		obj.ID = util.IntPtr(a.st.ids[typeDeliveryServices][change.Name])
+		if authorized, err := obj.IsTenantAuthorized(a.inf.User); err != nil {
+			return wrapErrs(change, nil, errors.New("checking tenant: "+err.Error()), http.StatusInternalServerError)
+		} else if !authorized {
+			return wrapErrs(change, errors.New("not authorized on this tenant"), nil, http.StatusForbidden)
+		}
+		if userErr, sysErr, errCode := obj.Delete(); userErr != nil || sysErr != nil {
+			return wrapErrs(change, userErr, sysErr, errCode)
+		}
+		if err := api.CreateChangeLogAudit(api.ApiChange, api.Deleted, &obj, a.st.deliveryServices[change.Name], a.inf); err != nil {
+			return nil, errors.New("writing change log entry: " + err.Error()), http.StatusInternalServerError
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) deleteServers(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		if _, userErr, sysErr, errCode := server.DeleteServer(a.inf, a.st.ids[typeServers][change.Name]); userErr != nil || sysErr != nil {
+			return wrapErrs(change, userErr, sysErr, errCode)
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+func (a *applier) deleteProfiles(changes []tc.CDNConfigurationChange) (error, error, int) {
+	for _, change := range changes {
+		obj := profile.TOProfile{}
+		obj.SetInfo(a.inf)
+		obj.ID = util.IntPtr(a.st.ids[typeProfiles][change.Name])
+		obj.Name = util.StrPtr(change.Name)
+		if userErr, sysErr, errCode := obj.Delete(); userErr != nil || sysErr != nil {
+			return wrapErrs(change, userErr, sysErr, errCode)
+		}
+		if err := api.CreateChangeLogAudit(api.ApiChange, api.Deleted, &obj, a.currentObject(typeProfiles, change.Name), a.inf); err != nil {
+			return nil, errors.New("writing change log entry: " + err.Error()), http.StatusInternalServerError
+		}
+	}
+	return nil, nil, http.StatusOK
+}
@@ -0,0 +1,234 @@
+// Package cdnconfig exports the configuration of an entire CDN as a single versioned document, and applies such documents, optionally only planning the changes that applying them would make.
+//
+// Objects which belong to a CDN - its Profiles, servers, and Delivery Services - are declarative: applying a document deletes those which it doesn't contain. The global objects a CDN uses, such as Cache Groups and Topologies, may be shared with other CDNs, so they are only ever created or updated.
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ApplicationYAML is the media type of configuration documents in YAML.
+const ApplicationYAML = "application/yaml"
+
+// FormatQueryParam selects the format of an exported configuration document, either "json" (the default) or "yaml".
+const FormatQueryParam = "format"
+
+// Get is the handler for GET requests to /cdns/{name}/configuration, which exports the configuration of the CDN.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	format := strings.ToLower(inf.Params[FormatQueryParam])
+	if format == "" && strings.Contains(r.Header.Get("Accept"), "yaml") {
+		format = "yaml"
+	}
+	if format != "" && format != "json" && format != "yaml" {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("format must be 'json' or 'yaml'"), nil)
+		return
+	}
+
+	cdnName := inf.Params["name"]
+	st, ok, err := exportCDN(inf, cdnName)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("reading CDN configuration: "+err.Error()))
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, fmt.Errorf("no CDN named '%s' exists", cdnName), nil)
+		return
+	}
+	normalize(&st.CDNConfiguration)
+
+	if format != "yaml" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.json\"", cdnName))
+		api.WriteRespRaw(w, r, st.CDNConfiguration)
+		return
+	}
+	bts, err := marshalYAML(st.CDNConfiguration)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("marshalling CDN configuration: "+err.Error()))
+		return
+	}
+	w.Header().Set(rfc.ContentType, ApplicationYAML)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.yaml\"", cdnName))
+	w.Write(bts)
+}
+
+// Plan is the handler for POST requests to /cdns/{name}/configuration/plan, which returns the changes that applying the requested configuration would make, without making them.
+func Plan(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	_, _, p, userErr, sysErr, errCode := planRequest(inf, r)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteRespAlertObj(w, r, tc.InfoLevel, fmt.Sprintf("Applying this configuration would make %d changes", len(p.Changes)), p)
+}
+
+// Apply is the handler for PUT requests to /cdns/{name}/configuration, which makes the changes needed for the CDN to have the requested configuration, in a single transaction.
+func Apply(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	st, desired, p, userErr, sysErr, errCode := planRequest(inf, r)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	if userErr, sysErr, errCode := apply(inf, st, desired, p); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("CDN: %s, ID: %d, ACTION: Applied configuration with %d changes", desired.CDN.Name, st.cdnID, len(p.Changes)), inf.User, inf.Tx.Tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Applied %d changes to CDN '%s'", len(p.Changes), desired.CDN.Name), p)
+}
+
+// planRequest reads the configuration document in the body of the request, and plans the changes applying it would make to the CDN named in the request path.
+func planRequest(inf *api.APIInfo, r *http.Request) (*state, tc.CDNConfiguration, tc.CDNConfigurationPlan, error, error, int) {
+	desired := tc.CDNConfiguration{}
+	p := tc.CDNConfigurationPlan{}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, desired, p, errors.New("reading request body: " + err.Error()), nil, http.StatusBadRequest
+	}
+	if err := unmarshalDocument(body, strings.Contains(r.Header.Get(rfc.ContentType), "yaml"), &desired); err != nil {
+		return nil, desired, p, errors.New("parsing configuration: " + err.Error()), nil, http.StatusBadRequest
+	}
+	if err := desired.Validate(); err != nil {
+		return nil, desired, p, err, nil, http.StatusBadRequest
+	}
+	cdnName := inf.Params["name"]
+	if desired.CDN.Name != cdnName {
+		return nil, desired, p, fmt.Errorf("configuration is for CDN '%s', not '%s'", desired.CDN.Name, cdnName), nil, http.StatusBadRequest
+	}
+
+	st, ok, err := readCDN(inf, cdnName, documentNames(desired))
+	if err != nil {
+		return nil, desired, p, nil, errors.New("reading CDN configuration: " + err.Error()), http.StatusInternalServerError
+	}
+	if !ok {
+		return nil, desired, p, fmt.Errorf("no CDN named '%s' exists", cdnName), nil, http.StatusNotFound
+	}
+	normalize(&st.CDNConfiguration)
+	normalize(&desired)
+
+	p, err = plan(st.CDNConfiguration, desired)
+	if err != nil {
+		return nil, desired, p, nil, errors.New("planning changes: " + err.Error()), http.StatusInternalServerError
+	}
+	return st, desired, p, nil, nil, http.StatusOK
+}
+
+// unmarshalDocument decodes a configuration document from JSON, or from YAML if isYAML is true.
+func unmarshalDocument(bts []byte, isYAML bool, cfg *tc.CDNConfiguration) error {
+	if isYAML {
+		var err error
+		if bts, err = yamlToJSON(bts); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(bts, cfg)
+}
+
+// yamlToJSON converts a YAML document to JSON, so that it can be decoded with the JSON field names and (un)marshallers of the tc structures.
+func yamlToJSON(bts []byte) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(bts, &doc); err != nil {
+		return nil, err
+	}
+	doc, err := jsonCompatible(doc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// jsonCompatible replaces the maps with arbitrary keys that YAML decodes into with maps that can be marshalled as JSON.
+func jsonCompatible(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			keyStr, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v is not a string", key)
+			}
+			compatible, err := jsonCompatible(val)
+			if err != nil {
+				return nil, err
+			}
+			obj[keyStr] = compatible
+		}
+		return obj, nil
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, val := range v {
+			compatible, err := jsonCompatible(val)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = compatible
+		}
+		return arr, nil
+	}
+	return v, nil
+}
+
+// marshalYAML marshals a configuration document as YAML, keeping its keys in the same order as in JSON.
+func marshalYAML(cfg tc.CDNConfiguration) ([]byte, error) {
+	bts, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is YAML, and decoding it into a MapSlice preserves the order of its keys.
+	doc := yaml.MapSlice{}
+	if err := yaml.Unmarshal(bts, &doc); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(doc)
+}
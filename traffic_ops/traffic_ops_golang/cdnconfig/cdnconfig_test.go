@@ -0,0 +1,83 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestYAMLRoundTrip(t *testing.T) {
+	cfg := testConfiguration()
+	bts, err := marshalYAML(cfg)
+	if err != nil {
+		t.Fatalf("marshalling YAML: %v", err)
+	}
+	actual := tc.CDNConfiguration{}
+	if err := unmarshalDocument(bts, true, &actual); err != nil {
+		t.Fatalf("unmarshalling YAML: %v\n%s", err, bts)
+	}
+	fields, err := changedFields(cfg, actual)
+	if err != nil {
+		t.Fatalf("comparing configurations: %v", err)
+	}
+	if len(fields) != 0 {
+		t.Errorf("expected YAML to round-trip, changed fields: %v\n%s", fields, bts)
+	}
+}
+
+func TestUnmarshalDocumentYAML(t *testing.T) {
+	doc := `
+version: 1
+cdn:
+  name: cdn1
+  domainName: example.test
+divisions:
+- name: div1
+servers:
+- hostName: edge1
+  capabilities: [disk]
+  tcpPort: 80
+`
+	actual := tc.CDNConfiguration{}
+	if err := unmarshalDocument([]byte(doc), true, &actual); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual.CDN.Name != "cdn1" || len(actual.Divisions) != 1 || actual.Divisions[0].Name != "div1" {
+		t.Errorf("unexpected configuration: %+v", actual)
+	}
+	if len(actual.Servers) != 1 || actual.Servers[0].HostName == nil || *actual.Servers[0].HostName != "edge1" {
+		t.Fatalf("expected server 'edge1', actual: %+v", actual.Servers)
+	}
+	if actual.Servers[0].TCPPort == nil || *actual.Servers[0].TCPPort != 80 {
+		t.Errorf("expected tcpPort 80, actual: %v", actual.Servers[0].TCPPort)
+	}
+	if !reflect.DeepEqual(actual.Servers[0].Capabilities, []string{"disk"}) {
+		t.Errorf("expected capabilities [disk], actual: %v", actual.Servers[0].Capabilities)
+	}
+}
+
+func TestUnmarshalDocumentYAMLNonStringKey(t *testing.T) {
+	if err := unmarshalDocument([]byte("1: a\n"), true, &tc.CDNConfiguration{}); err == nil {
+		t.Error("expected an error for a non-string key")
+	}
+}
@@ -0,0 +1,258 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// normalize sorts the lists of objects in cfg by name, along with the lists within its objects whose order has no meaning, so that configurations can be compared and exported deterministically.
+func normalize(cfg *tc.CDNConfiguration) {
+	sort.Slice(cfg.Divisions, func(i, j int) bool { return cfg.Divisions[i].Name < cfg.Divisions[j].Name })
+	sort.Slice(cfg.Regions, func(i, j int) bool { return cfg.Regions[i].Name < cfg.Regions[j].Name })
+	sort.Slice(cfg.PhysLocations, func(i, j int) bool { return cfg.PhysLocations[i].Name < cfg.PhysLocations[j].Name })
+	sort.Slice(cfg.CacheGroups, func(i, j int) bool { return cfg.CacheGroups[i].Name < cfg.CacheGroups[j].Name })
+	for _, cg := range cfg.CacheGroups {
+		sort.Slice(cg.LocalizationMethods, func(i, j int) bool { return cg.LocalizationMethods[i] < cg.LocalizationMethods[j] })
+	}
+	sort.Slice(cfg.Topologies, func(i, j int) bool { return cfg.Topologies[i].Name < cfg.Topologies[j].Name })
+	sort.Strings(cfg.ServerCapabilities)
+	sort.Slice(cfg.Profiles, func(i, j int) bool { return cfg.Profiles[i].Name < cfg.Profiles[j].Name })
+	for _, profile := range cfg.Profiles {
+		params := profile.Parameters
+		sort.Slice(params, func(i, j int) bool {
+			if params[i].Name != params[j].Name {
+				return params[i].Name < params[j].Name
+			}
+			if params[i].ConfigFile != params[j].ConfigFile {
+				return params[i].ConfigFile < params[j].ConfigFile
+			}
+			return params[i].Value < params[j].Value
+		})
+	}
+	sort.Slice(cfg.Servers, func(i, j int) bool { return serverName(cfg.Servers[i]) < serverName(cfg.Servers[j]) })
+	for _, srv := range cfg.Servers {
+		sort.Strings(srv.Capabilities)
+	}
+	sort.Slice(cfg.DeliveryServices, func(i, j int) bool {
+		return dsName(cfg.DeliveryServices[i]) < dsName(cfg.DeliveryServices[j])
+	})
+	for _, ds := range cfg.DeliveryServices {
+		sort.Strings(ds.RequiredCapabilities)
+		sort.Strings(ds.Servers)
+	}
+}
+
+func serverName(srv tc.CDNConfigurationServer) string {
+	if srv.HostName == nil {
+		return ""
+	}
+	return *srv.HostName
+}
+
+func dsName(ds tc.CDNConfigurationDeliveryService) string {
+	if ds.XMLID == nil {
+		return ""
+	}
+	return *ds.XMLID
+}
+
+// plan returns the changes which applying the desired configuration to the current one makes. Both must be normalized.
+//
+// Creates and updates are listed first, in the order they're applied, followed by deletes. Only Profiles, servers, and Delivery Services are deleted, because the other objects can be shared between CDNs.
+func plan(current tc.CDNConfiguration, desired tc.CDNConfiguration) (tc.CDNConfigurationPlan, error) {
+	p := planner{changes: []tc.CDNConfigurationChange{}}
+
+	if fields, err := changedFields(current.CDN, desired.CDN); err != nil {
+		return tc.CDNConfigurationPlan{}, err
+	} else if len(fields) > 0 {
+		p.add(tc.CDNConfigurationUpdate, typeCDN, desired.CDN.Name, fields)
+	}
+
+	sections := []struct {
+		objType   string
+		deletable bool
+	}{
+		{objType: typeDivisions},
+		{objType: typeRegions},
+		{objType: typePhysLocations},
+		{objType: typeCacheGroups},
+		{objType: typeServerCapabilities},
+		{objType: typeProfiles, deletable: true},
+		{objType: typeTopologies},
+		{objType: typeServers, deletable: true},
+		{objType: typeDeliveryServices, deletable: true},
+	}
+	for _, s := range sections {
+		currentByName := map[string]interface{}{}
+		for _, o := range namedObjects(current, s.objType) {
+			currentByName[o.name] = o.value
+		}
+		for _, o := range namedObjects(desired, s.objType) {
+			cur, ok := currentByName[o.name]
+			if !ok {
+				p.add(tc.CDNConfigurationCreate, s.objType, o.name, nil)
+				continue
+			}
+			fields, err := changedFields(cur, o.value)
+			if err != nil {
+				return tc.CDNConfigurationPlan{}, err
+			}
+			if len(fields) > 0 {
+				p.add(tc.CDNConfigurationUpdate, s.objType, o.name, fields)
+			}
+		}
+	}
+
+	// Delete in the reverse order of creation, so that nothing is deleted while something else still uses it.
+	for i := len(sections) - 1; i >= 0; i-- {
+		s := sections[i]
+		if !s.deletable {
+			continue
+		}
+		desiredNames := map[string]struct{}{}
+		for _, o := range namedObjects(desired, s.objType) {
+			desiredNames[o.name] = struct{}{}
+		}
+		for _, o := range namedObjects(current, s.objType) {
+			if _, ok := desiredNames[o.name]; !ok {
+				p.add(tc.CDNConfigurationDelete, s.objType, o.name, nil)
+			}
+		}
+	}
+	return tc.CDNConfigurationPlan{Changes: p.changes}, nil
+}
+
+type namedObject struct {
+	name  string
+	value interface{}
+}
+
+// namedObjects returns the objects of the given type in cfg, along with their names.
+func namedObjects(cfg tc.CDNConfiguration, objType string) []namedObject {
+	objs := []namedObject{}
+	switch objType {
+	case typeDivisions:
+		for _, o := range cfg.Divisions {
+			objs = append(objs, namedObject{o.Name, o})
+		}
+	case typeRegions:
+		for _, o := range cfg.Regions {
+			objs = append(objs, namedObject{o.Name, o})
+		}
+	case typePhysLocations:
+		for _, o := range cfg.PhysLocations {
+			objs = append(objs, namedObject{o.Name, o})
+		}
+	case typeCacheGroups:
+		for _, o := range cfg.CacheGroups {
+			objs = append(objs, namedObject{o.Name, o})
+		}
+	case typeServerCapabilities:
+		for _, o := range cfg.ServerCapabilities {
+			objs = append(objs, namedObject{o, struct{}{}})
+		}
+	case typeProfiles:
+		for _, o := range cfg.Profiles {
+			objs = append(objs, namedObject{o.Name, o})
+		}
+	case typeTopologies:
+		for _, o := range cfg.Topologies {
+			objs = append(objs, namedObject{o.Name, o})
+		}
+	case typeServers:
+		for _, o := range cfg.Servers {
+			objs = append(objs, namedObject{serverName(o), o})
+		}
+	case typeDeliveryServices:
+		for _, o := range cfg.DeliveryServices {
+			objs = append(objs, namedObject{dsName(o), o})
+		}
+	}
+	return objs
+}
+
+type planner struct {
+	changes []tc.CDNConfigurationChange
+}
+
+func (p *planner) add(action string, objType string, name string, fields []string) {
+	p.changes = append(p.changes, tc.CDNConfigurationChange{Action: action, Type: objType, Name: name, Fields: fields})
+}
+
+// changedFields returns the sorted names of the fields of the JSON objects representing current and desired whose values differ. Null and empty values are considered equal.
+func changedFields(current interface{}, desired interface{}) ([]string, error) {
+	cur, err := toJSONObject(current)
+	if err != nil {
+		return nil, err
+	}
+	des, err := toJSONObject(desired)
+	if err != nil {
+		return nil, err
+	}
+	fields := []string{}
+	for field, desVal := range des {
+		if !jsonEqual(cur[field], desVal) {
+			fields = append(fields, field)
+		}
+	}
+	for field, curVal := range cur {
+		if _, ok := des[field]; !ok && !jsonEqual(curVal, nil) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func toJSONObject(v interface{}) (map[string]interface{}, error) {
+	bts, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(bts, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func jsonEqual(a interface{}, b interface{}) bool {
+	if isEmptyJSON(a) && isEmptyJSON(b) {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func isEmptyJSON(v interface{}) bool {
+	switch val := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(val) == 0
+	case map[string]interface{}:
+		return len(val) == 0
+	}
+	return false
+}
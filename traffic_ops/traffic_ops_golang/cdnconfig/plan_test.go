@@ -0,0 +1,147 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func testConfiguration() tc.CDNConfiguration {
+	srv := tc.CDNConfigurationServer{Capabilities: []string{"disk"}}
+	srv.HostName = util.StrPtr("edge1")
+	srv.Cachegroup = util.StrPtr("cg1")
+	ds := tc.CDNConfigurationDeliveryService{Servers: []string{"edge1"}}
+	ds.XMLID = util.StrPtr("ds1")
+	ds.Active = util.BoolPtr(true)
+	return tc.CDNConfiguration{
+		Version:            tc.CDNConfigurationVersion,
+		CDN:                tc.CDNConfigurationCDN{Name: "cdn1", DomainName: "example.test"},
+		Divisions:          []tc.CDNConfigurationDivision{{Name: "div1"}},
+		Regions:            []tc.CDNConfigurationRegion{{Name: "reg1", Division: "div1"}},
+		CacheGroups:        []tc.CDNConfigurationCacheGroup{{Name: "cg1", ShortName: "cg1", Type: "EDGE_LOC"}},
+		ServerCapabilities: []string{"disk"},
+		Profiles: []tc.CDNConfigurationProfile{{
+			Name:       "EDGE1",
+			Type:       "ATS_PROFILE",
+			Parameters: []tc.CDNConfigurationParameter{{Name: "location", ConfigFile: "remap.config", Value: "/etc"}},
+		}},
+		Servers:          []tc.CDNConfigurationServer{srv},
+		DeliveryServices: []tc.CDNConfigurationDeliveryService{ds},
+	}
+}
+
+func TestPlanNoChanges(t *testing.T) {
+	current := testConfiguration()
+	desired := testConfiguration()
+	// empty and missing lists are equivalent
+	desired.Topologies = []tc.CDNConfigurationTopology{}
+	p, err := plan(current, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Changes) != 0 {
+		t.Errorf("expected no changes, actual: %+v", p.Changes)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	current := testConfiguration()
+	current.Profiles = append(current.Profiles, tc.CDNConfigurationProfile{Name: "OLD", Type: "ATS_PROFILE"})
+
+	desired := testConfiguration()
+	desired.CDN.DNSSECEnabled = true
+	desired.Divisions = append(desired.Divisions, tc.CDNConfigurationDivision{Name: "div2"})
+	desired.Regions[0].Division = "div2"
+	desired.Profiles[0].Parameters[0].Value = "/opt"
+	desired.Servers[0].Capabilities = nil
+	desired.Servers[0].Cachegroup = util.StrPtr("cg2")
+	desired.DeliveryServices = nil
+
+	p, err := plan(current, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []tc.CDNConfigurationChange{
+		{Action: tc.CDNConfigurationUpdate, Type: typeCDN, Name: "cdn1", Fields: []string{"dnssecEnabled"}},
+		{Action: tc.CDNConfigurationCreate, Type: typeDivisions, Name: "div2"},
+		{Action: tc.CDNConfigurationUpdate, Type: typeRegions, Name: "reg1", Fields: []string{"division"}},
+		{Action: tc.CDNConfigurationUpdate, Type: typeProfiles, Name: "EDGE1", Fields: []string{"parameters"}},
+		{Action: tc.CDNConfigurationUpdate, Type: typeServers, Name: "edge1", Fields: []string{"cachegroup", "capabilities"}},
+		{Action: tc.CDNConfigurationDelete, Type: typeDeliveryServices, Name: "ds1"},
+		{Action: tc.CDNConfigurationDelete, Type: typeProfiles, Name: "OLD"},
+	}
+	if !reflect.DeepEqual(p.Changes, expected) {
+		t.Errorf("expected changes %+v, actual: %+v", expected, p.Changes)
+	}
+}
+
+func TestPlanDoesNotDeleteGlobalObjects(t *testing.T) {
+	current := testConfiguration()
+	desired := testConfiguration()
+	desired.Divisions = nil
+	desired.Regions = nil
+	desired.CacheGroups = nil
+	desired.ServerCapabilities = nil
+
+	p, err := plan(current, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Changes) != 0 {
+		t.Errorf("expected global objects missing from the document to be left alone, actual changes: %+v", p.Changes)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cfg := testConfiguration()
+	cfg.Divisions = []tc.CDNConfigurationDivision{{Name: "b"}, {Name: "a"}}
+	cfg.ServerCapabilities = []string{"ram", "disk"}
+	cfg.DeliveryServices[0].Servers = []string{"edge2", "edge1"}
+	normalize(&cfg)
+
+	if cfg.Divisions[0].Name != "a" || cfg.Divisions[1].Name != "b" {
+		t.Errorf("expected divisions sorted by name, actual: %+v", cfg.Divisions)
+	}
+	if !reflect.DeepEqual(cfg.ServerCapabilities, []string{"disk", "ram"}) {
+		t.Errorf("expected server capabilities sorted, actual: %v", cfg.ServerCapabilities)
+	}
+	if !reflect.DeepEqual(cfg.DeliveryServices[0].Servers, []string{"edge1", "edge2"}) {
+		t.Errorf("expected delivery service servers sorted, actual: %v", cfg.DeliveryServices[0].Servers)
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	type obj struct {
+		A *string  `json:"a"`
+		B []string `json:"b"`
+		C int      `json:"c"`
+	}
+	fields, err := changedFields(obj{B: []string{}}, obj{C: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(fields, []string{"c"}) {
+		t.Errorf("expected only 'c' to change, actual: %v", fields)
+	}
+}
@@ -0,0 +1,622 @@
+package cdnconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cachegroup"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/server"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/topology"
+
+	"github.com/lib/pq"
+)
+
+// These are the types of the objects in a CDN configuration, which are the names of their lists in a tc.CDNConfiguration.
+const (
+	typeCDN                = "cdn"
+	typeDivisions          = "divisions"
+	typeRegions            = "regions"
+	typePhysLocations      = "physLocations"
+	typeCacheGroups        = "cacheGroups"
+	typeTopologies         = "topologies"
+	typeServerCapabilities = "serverCapabilities"
+	typeProfiles           = "profiles"
+	typeServers            = "servers"
+	typeDeliveryServices   = "deliveryServices"
+)
+
+// state is the configuration of a CDN as it is stored in Traffic Ops, along with what is needed to change it.
+type state struct {
+	tc.CDNConfiguration
+	cdnID int
+	// ids are the IDs of the stored objects, by type and then by name.
+	ids map[string]map[string]int
+	// servers and deliveryServices are the complete stored objects, by host name and XMLID respectively, including the fields which aren't part of a configuration document.
+	servers          map[string]tc.ServerV40
+	deliveryServices map[string]tc.DeliveryServiceV4
+}
+
+func (s *state) setID(objType string, name string, id int) {
+	if s.ids[objType] == nil {
+		s.ids[objType] = map[string]int{}
+	}
+	s.ids[objType][name] = id
+}
+
+// nameSet is a set of the names of objects of some type.
+type nameSet map[string]struct{}
+
+func (n nameSet) add(names ...string) {
+	for _, name := range names {
+		if name != "" {
+			n[name] = struct{}{}
+		}
+	}
+}
+
+func (n nameSet) addPtr(name *string) {
+	if name != nil {
+		n.add(*name)
+	}
+}
+
+func (n nameSet) list() []string {
+	names := make([]string, 0, len(n))
+	for name := range n {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// globalNames are the names of the objects not belonging to any CDN which are to be read along with a CDN's configuration.
+type globalNames struct {
+	divisions          nameSet
+	regions            nameSet
+	physLocations      nameSet
+	cacheGroups        nameSet
+	topologies         nameSet
+	serverCapabilities nameSet
+}
+
+func newGlobalNames() globalNames {
+	return globalNames{
+		divisions:          nameSet{},
+		regions:            nameSet{},
+		physLocations:      nameSet{},
+		cacheGroups:        nameSet{},
+		topologies:         nameSet{},
+		serverCapabilities: nameSet{},
+	}
+}
+
+// documentNames returns the names of the global objects in the given configuration document.
+func documentNames(cfg tc.CDNConfiguration) globalNames {
+	names := newGlobalNames()
+	for _, division := range cfg.Divisions {
+		names.divisions.add(division.Name)
+	}
+	for _, region := range cfg.Regions {
+		names.regions.add(region.Name)
+	}
+	for _, physLocation := range cfg.PhysLocations {
+		names.physLocations.add(physLocation.Name)
+	}
+	for _, cg := range cfg.CacheGroups {
+		names.cacheGroups.add(cg.Name)
+	}
+	for _, topology := range cfg.Topologies {
+		names.topologies.add(topology.Name)
+	}
+	names.serverCapabilities.add(cfg.ServerCapabilities...)
+	return names
+}
+
+// readCDN reads the stored configuration of the CDN with the given name, but only those global objects with the given names. It returns false if no such CDN exists.
+func readCDN(inf *api.APIInfo, cdnName string, names globalNames) (*state, bool, error) {
+	st, ok, err := readCDNObjects(inf, cdnName)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	if err := readGlobals(inf, st, names); err != nil {
+		return nil, false, err
+	}
+	return st, true, nil
+}
+
+// exportCDN reads the stored configuration of the CDN with the given name, along with all the global objects its objects use. It returns false if no such CDN exists.
+func exportCDN(inf *api.APIInfo, cdnName string) (*state, bool, error) {
+	st, ok, err := readCDNObjects(inf, cdnName)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+
+	names := newGlobalNames()
+	for _, srv := range st.Servers {
+		names.cacheGroups.addPtr(srv.Cachegroup)
+		names.physLocations.addPtr(srv.PhysLocation)
+		names.serverCapabilities.add(srv.Capabilities...)
+	}
+	for _, ds := range st.DeliveryServices {
+		names.topologies.addPtr(ds.Topology)
+		names.serverCapabilities.add(ds.RequiredCapabilities...)
+	}
+	if err := readGlobals(inf, st, names); err != nil {
+		return nil, false, err
+	}
+
+	// Cache Groups are also used by Topologies and by other Cache Groups, so read any which weren't already, until none are left.
+	for {
+		missing := newGlobalNames()
+		for _, topology := range st.Topologies {
+			for _, node := range topology.Nodes {
+				missing.cacheGroups.add(node.Cachegroup)
+			}
+		}
+		for _, cg := range st.CacheGroups {
+			missing.cacheGroups.addPtr(cg.ParentCacheGroup)
+			missing.cacheGroups.addPtr(cg.SecondaryParentCacheGroup)
+			missing.cacheGroups.add(cg.Fallbacks...)
+		}
+		for name := range missing.cacheGroups {
+			if _, ok := st.ids[typeCacheGroups][name]; ok {
+				delete(missing.cacheGroups, name)
+			}
+		}
+		if len(missing.cacheGroups) == 0 {
+			break
+		}
+		if err := readCacheGroups(inf, st, missing.cacheGroups); err != nil {
+			return nil, false, err
+		}
+	}
+
+	// Physical Locations are in Regions, which are in Divisions.
+	for _, physLocation := range st.PhysLocations {
+		names.regions.add(physLocation.Region)
+	}
+	if err := readRegions(inf.Tx.Tx, st, names.regions); err != nil {
+		return nil, false, err
+	}
+	for _, region := range st.Regions {
+		names.divisions.add(region.Division)
+	}
+	if err := readDivisions(inf.Tx.Tx, st, names.divisions); err != nil {
+		return nil, false, err
+	}
+	return st, true, nil
+}
+
+// readCDNObjects reads the CDN with the given name, along with its Profiles, servers, and Delivery Services. It returns false if no such CDN exists.
+func readCDNObjects(inf *api.APIInfo, cdnName string) (*state, bool, error) {
+	tx := inf.Tx.Tx
+	st := &state{
+		CDNConfiguration: tc.CDNConfiguration{
+			Version:            tc.CDNConfigurationVersion,
+			Divisions:          []tc.CDNConfigurationDivision{},
+			Regions:            []tc.CDNConfigurationRegion{},
+			PhysLocations:      []tc.CDNConfigurationPhysLocation{},
+			CacheGroups:        []tc.CDNConfigurationCacheGroup{},
+			Topologies:         []tc.CDNConfigurationTopology{},
+			ServerCapabilities: []string{},
+			Profiles:           []tc.CDNConfigurationProfile{},
+			Servers:            []tc.CDNConfigurationServer{},
+			DeliveryServices:   []tc.CDNConfigurationDeliveryService{},
+		},
+		ids:              map[string]map[string]int{},
+		servers:          map[string]tc.ServerV40{},
+		deliveryServices: map[string]tc.DeliveryServiceV4{},
+	}
+
+	st.CDN.Name = cdnName
+	if err := tx.QueryRow(`SELECT id, domain_name, dnssec_enabled FROM cdn WHERE name = $1`, cdnName).Scan(&st.cdnID, &st.CDN.DomainName, &st.CDN.DNSSECEnabled); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, errors.New("querying cdn: " + err.Error())
+	}
+
+	if err := readProfiles(tx, st); err != nil {
+		return nil, false, err
+	}
+	if err := readServers(inf, st); err != nil {
+		return nil, false, err
+	}
+	if err := readDeliveryServices(inf, st); err != nil {
+		return nil, false, err
+	}
+	return st, true, nil
+}
+
+func readProfiles(tx *sql.Tx, st *state) error {
+	rows, err := tx.Query(`SELECT id, name, description, type, routing_disabled FROM profile WHERE cdn = $1 ORDER BY name`, st.cdnID)
+	if err != nil {
+		return errors.New("querying profiles: " + err.Error())
+	}
+	defer log.Close(rows, "closing profile rows")
+	profileIndices := map[int]int{}
+	for rows.Next() {
+		id := 0
+		profile := tc.CDNConfigurationProfile{Parameters: []tc.CDNConfigurationParameter{}}
+		if err := rows.Scan(&id, &profile.Name, &profile.Description, &profile.Type, &profile.RoutingDisabled); err != nil {
+			return errors.New("scanning profiles: " + err.Error())
+		}
+		st.setID(typeProfiles, profile.Name, id)
+		profileIndices[id] = len(st.Profiles)
+		st.Profiles = append(st.Profiles, profile)
+	}
+	if err := rows.Err(); err != nil {
+		return errors.New("iterating over profiles: " + err.Error())
+	}
+
+	paramRows, err := tx.Query(`
+SELECT pp.profile, pa.name, pa.config_file, pa.value, pa.secure
+FROM profile_parameter pp
+JOIN parameter pa ON pa.id = pp.parameter
+JOIN profile p ON p.id = pp.profile
+WHERE p.cdn = $1
+ORDER BY pa.name, pa.config_file, pa.value`, st.cdnID)
+	if err != nil {
+		return errors.New("querying profile parameters: " + err.Error())
+	}
+	defer log.Close(paramRows, "closing profile parameter rows")
+	for paramRows.Next() {
+		profileID := 0
+		param := tc.CDNConfigurationParameter{}
+		if err := paramRows.Scan(&profileID, &param.Name, &param.ConfigFile, &param.Value, &param.Secure); err != nil {
+			return errors.New("scanning profile parameters: " + err.Error())
+		}
+		if i, ok := profileIndices[profileID]; ok {
+			st.Profiles[i].Parameters = append(st.Profiles[i].Parameters, param)
+		}
+	}
+	if err := paramRows.Err(); err != nil {
+		return errors.New("iterating over profile parameters: " + err.Error())
+	}
+	return nil
+}
+
+func readServers(inf *api.APIInfo, st *state) error {
+	servers, userErr, sysErr, _ := server.GetCDNServers(inf, st.cdnID)
+	if userErr != nil || sysErr != nil {
+		return fmt.Errorf("reading servers: %v", util.JoinErrs([]error{userErr, sysErr}))
+	}
+	serverIndices := map[int]int{}
+	for _, srv := range servers {
+		if srv.ID == nil || srv.HostName == nil {
+			return errors.New("reading servers: server with no id or host name")
+		}
+		st.setID(typeServers, *srv.HostName, *srv.ID)
+		st.servers[*srv.HostName] = srv
+		serverIndices[*srv.ID] = len(st.Servers)
+		st.Servers = append(st.Servers, tc.CDNConfigurationServer{ServerV40: srv, Capabilities: []string{}})
+	}
+
+	rows, err := inf.Tx.Tx.Query(`
+SELECT ssc.server, ssc.server_capability
+FROM server_server_capability ssc
+JOIN server s ON s.id = ssc.server
+WHERE s.cdn_id = $1
+ORDER BY ssc.server_capability`, st.cdnID)
+	if err != nil {
+		return errors.New("querying server capabilities: " + err.Error())
+	}
+	defer log.Close(rows, "closing server capability rows")
+	for rows.Next() {
+		serverID := 0
+		capability := ""
+		if err := rows.Scan(&serverID, &capability); err != nil {
+			return errors.New("scanning server capabilities: " + err.Error())
+		}
+		if i, ok := serverIndices[serverID]; ok {
+			st.Servers[i].Capabilities = append(st.Servers[i].Capabilities, capability)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.New("iterating over server capabilities: " + err.Error())
+	}
+	return nil
+}
+
+func readDeliveryServices(inf *api.APIInfo, st *state) error {
+	dses, userErr, sysErr, _ := deliveryservice.GetCDNDeliveryServices(inf, st.cdnID)
+	if userErr != nil || sysErr != nil {
+		return fmt.Errorf("reading delivery services: %v", util.JoinErrs([]error{userErr, sysErr}))
+	}
+	dsIndices := map[int]int{}
+	for _, ds := range dses {
+		if ds.ID == nil || ds.XMLID == nil {
+			return errors.New("reading delivery services: delivery service with no id or xmlId")
+		}
+		st.setID(typeDeliveryServices, *ds.XMLID, *ds.ID)
+		st.deliveryServices[*ds.XMLID] = ds
+		dsIndices[*ds.ID] = len(st.DeliveryServices)
+		st.DeliveryServices = append(st.DeliveryServices, tc.CDNConfigurationDeliveryService{
+			DeliveryServiceV4:    ds,
+			RequiredCapabilities: []string{},
+			Servers:              []string{},
+		})
+	}
+
+	rows, err := inf.Tx.Tx.Query(`
+SELECT rc.deliveryservice_id, rc.required_capability
+FROM deliveryservices_required_capability rc
+JOIN deliveryservice ds ON ds.id = rc.deliveryservice_id
+WHERE ds.cdn_id = $1
+ORDER BY rc.required_capability`, st.cdnID)
+	if err != nil {
+		return errors.New("querying delivery service required capabilities: " + err.Error())
+	}
+	defer log.Close(rows, "closing delivery service required capability rows")
+	for rows.Next() {
+		dsID := 0
+		capability := ""
+		if err := rows.Scan(&dsID, &capability); err != nil {
+			return errors.New("scanning delivery service required capabilities: " + err.Error())
+		}
+		if i, ok := dsIndices[dsID]; ok {
+			st.DeliveryServices[i].RequiredCapabilities = append(st.DeliveryServices[i].RequiredCapabilities, capability)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.New("iterating over delivery service required capabilities: " + err.Error())
+	}
+
+	serverRows, err := inf.Tx.Tx.Query(`
+SELECT dss.deliveryservice, s.host_name
+FROM deliveryservice_server dss
+JOIN deliveryservice ds ON ds.id = dss.deliveryservice
+JOIN server s ON s.id = dss.server
+WHERE ds.cdn_id = $1
+ORDER BY s.host_name`, st.cdnID)
+	if err != nil {
+		return errors.New("querying delivery service servers: " + err.Error())
+	}
+	defer log.Close(serverRows, "closing delivery service server rows")
+	for serverRows.Next() {
+		dsID := 0
+		hostName := ""
+		if err := serverRows.Scan(&dsID, &hostName); err != nil {
+			return errors.New("scanning delivery service servers: " + err.Error())
+		}
+		if i, ok := dsIndices[dsID]; ok {
+			st.DeliveryServices[i].Servers = append(st.DeliveryServices[i].Servers, hostName)
+		}
+	}
+	if err := serverRows.Err(); err != nil {
+		return errors.New("iterating over delivery service servers: " + err.Error())
+	}
+	return nil
+}
+
+// readGlobals reads the global objects with the given names into st. Names of objects which don't exist are ignored.
+func readGlobals(inf *api.APIInfo, st *state, names globalNames) error {
+	tx := inf.Tx.Tx
+	if err := readDivisions(tx, st, names.divisions); err != nil {
+		return err
+	}
+	if err := readRegions(tx, st, names.regions); err != nil {
+		return err
+	}
+	if err := readPhysLocations(tx, st, names.physLocations); err != nil {
+		return err
+	}
+	if err := readCacheGroups(inf, st, names.cacheGroups); err != nil {
+		return err
+	}
+	if err := readTopologies(inf, st, names.topologies); err != nil {
+		return err
+	}
+	return readServerCapabilities(tx, st, names.serverCapabilities)
+}
+
+func readDivisions(tx *sql.Tx, st *state, names nameSet) error {
+	if len(names) == 0 {
+		return nil
+	}
+	rows, err := tx.Query(`SELECT id, name FROM division WHERE name = ANY($1) ORDER BY name`, pq.Array(names.list()))
+	if err != nil {
+		return errors.New("querying divisions: " + err.Error())
+	}
+	defer log.Close(rows, "closing division rows")
+	for rows.Next() {
+		id := 0
+		division := tc.CDNConfigurationDivision{}
+		if err := rows.Scan(&id, &division.Name); err != nil {
+			return errors.New("scanning divisions: " + err.Error())
+		}
+		if _, ok := st.ids[typeDivisions][division.Name]; ok {
+			continue
+		}
+		st.setID(typeDivisions, division.Name, id)
+		st.Divisions = append(st.Divisions, division)
+	}
+	return rows.Err()
+}
+
+func readRegions(tx *sql.Tx, st *state, names nameSet) error {
+	if len(names) == 0 {
+		return nil
+	}
+	rows, err := tx.Query(`
+SELECT r.id, r.name, d.name
+FROM region r
+JOIN division d ON d.id = r.division
+WHERE r.name = ANY($1)
+ORDER BY r.name`, pq.Array(names.list()))
+	if err != nil {
+		return errors.New("querying regions: " + err.Error())
+	}
+	defer log.Close(rows, "closing region rows")
+	for rows.Next() {
+		id := 0
+		region := tc.CDNConfigurationRegion{}
+		if err := rows.Scan(&id, &region.Name, &region.Division); err != nil {
+			return errors.New("scanning regions: " + err.Error())
+		}
+		if _, ok := st.ids[typeRegions][region.Name]; ok {
+			continue
+		}
+		st.setID(typeRegions, region.Name, id)
+		st.Regions = append(st.Regions, region)
+	}
+	return rows.Err()
+}
+
+func readPhysLocations(tx *sql.Tx, st *state, names nameSet) error {
+	if len(names) == 0 {
+		return nil
+	}
+	rows, err := tx.Query(`
+SELECT pl.id, pl.name, pl.short_name, r.name, pl.address, pl.city, pl.state, pl.zip, pl.comments, pl.email, pl.phone, pl.poc
+FROM phys_location pl
+JOIN region r ON r.id = pl.region
+WHERE pl.name = ANY($1)
+ORDER BY pl.name`, pq.Array(names.list()))
+	if err != nil {
+		return errors.New("querying physical locations: " + err.Error())
+	}
+	defer log.Close(rows, "closing physical location rows")
+	for rows.Next() {
+		id := 0
+		pl := tc.CDNConfigurationPhysLocation{}
+		if err := rows.Scan(&id, &pl.Name, &pl.ShortName, &pl.Region, &pl.Address, &pl.City, &pl.State, &pl.Zip, &pl.Comments, &pl.Email, &pl.Phone, &pl.POC); err != nil {
+			return errors.New("scanning physical locations: " + err.Error())
+		}
+		st.setID(typePhysLocations, pl.Name, id)
+		st.PhysLocations = append(st.PhysLocations, pl)
+	}
+	return rows.Err()
+}
+
+func readCacheGroups(inf *api.APIInfo, st *state, names nameSet) error {
+	if len(names) == 0 {
+		return nil
+	}
+	cgs, userErr, sysErr, _ := cachegroup.GetCacheGroupsByName(names.list(), inf.Tx)
+	if userErr != nil || sysErr != nil {
+		return fmt.Errorf("reading cache groups: %v", util.JoinErrs([]error{userErr, sysErr}))
+	}
+	for _, name := range names.list() {
+		cg, ok := cgs[name]
+		if !ok || cg.ID == nil {
+			continue
+		}
+		st.setID(typeCacheGroups, name, *cg.ID)
+		exported := tc.CDNConfigurationCacheGroup{
+			Name:                      name,
+			Latitude:                  cg.Latitude,
+			Longitude:                 cg.Longitude,
+			ParentCacheGroup:          cg.ParentName,
+			SecondaryParentCacheGroup: cg.SecondaryParentName,
+			FallbackToClosest:         cg.FallbackToClosest,
+			LocalizationMethods:       []tc.LocalizationMethod{},
+			Fallbacks:                 []string{},
+		}
+		if cg.ShortName != nil {
+			exported.ShortName = *cg.ShortName
+		}
+		if cg.Type != nil {
+			exported.Type = *cg.Type
+		}
+		if cg.LocalizationMethods != nil {
+			exported.LocalizationMethods = *cg.LocalizationMethods
+		}
+		if cg.Fallbacks != nil {
+			exported.Fallbacks = *cg.Fallbacks
+		}
+		st.CacheGroups = append(st.CacheGroups, exported)
+	}
+	return nil
+}
+
+func readTopologies(inf *api.APIInfo, st *state, names nameSet) error {
+	for _, name := range names.list() {
+		topo := topology.TOTopology{}
+		topo.SetInfo(infWithParams(inf, map[string]string{"name": name}))
+		topologies, userErr, sysErr, _, _ := topo.Read(nil, false)
+		if userErr != nil || sysErr != nil {
+			return fmt.Errorf("reading topology '%s': %v", name, util.JoinErrs([]error{userErr, sysErr}))
+		}
+		for _, t := range topologies {
+			topo, ok := t.(tc.Topology)
+			if !ok {
+				return fmt.Errorf("reading topology '%s': unexpected type %T", name, t)
+			}
+			st.setID(typeTopologies, topo.Name, 0)
+			st.Topologies = append(st.Topologies, tc.CDNConfigurationTopology{
+				Name:        topo.Name,
+				Description: topo.Description,
+				Nodes:       topo.Nodes,
+			})
+		}
+	}
+	return nil
+}
+
+func readServerCapabilities(tx *sql.Tx, st *state, names nameSet) error {
+	if len(names) == 0 {
+		return nil
+	}
+	rows, err := tx.Query(`SELECT name FROM server_capability WHERE name = ANY($1) ORDER BY name`, pq.Array(names.list()))
+	if err != nil {
+		return errors.New("querying server capabilities: " + err.Error())
+	}
+	defer log.Close(rows, "closing server capability rows")
+	for rows.Next() {
+		name := ""
+		if err := rows.Scan(&name); err != nil {
+			return errors.New("scanning server capabilities: " + err.Error())
+		}
+		st.setID(typeServerCapabilities, name, 0)
+		st.ServerCapabilities = append(st.ServerCapabilities, name)
+	}
+	return rows.Err()
+}
+
+// infWithParams returns a copy of inf with the given request parameters, for the CRUDers which read their keys from them.
+func infWithParams(inf *api.APIInfo, params map[string]string) *api.APIInfo {
+	cp := *inf
+	cp.Params = params
+	cp.IntParams = map[string]int{}
+	return &cp
+}
+
+// getIDByName returns the ID of the object with the given name in the given table, which must be a constant. It returns a user error if no such object exists.
+func getIDByName(tx *sql.Tx, table string, objType string, name string) (int, error, error, int) {
+	id := 0
+	if err := tx.QueryRow(`SELECT id FROM `+table+` WHERE name = $1`, name).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("no %s named '%s' exists", objType, name), nil, http.StatusBadRequest
+		}
+		return 0, nil, fmt.Errorf("querying %s '%s': %v", objType, name, err), http.StatusInternalServerError
+	}
+	return id, nil, nil, http.StatusOK
+}
@@ -34,6 +34,8 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+	tvpostgres "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/postgres"
 	"github.com/basho/riak-go-client"
 )
 
@@ -50,6 +52,7 @@ type Config struct {
 	ConfigLetsEncrypt      `json:"lets_encrypt"`
 	ConfigAcmeRenewal      `json:"acme_renewal"`
 	AcmeAccounts           []ConfigAcmeAccount `json:"acme_accounts"`
+	OIDC                   *ConfigOIDC         `json:"oidc"`
 	DB                     ConfigDatabase      `json:"db"`
 	Secrets                []string            `json:"secrets"`
 	// NOTE: don't care about any other fields for now..
@@ -63,6 +66,12 @@ type Config struct {
 	InfluxDBConfPath string `json:"influxdb_conf_path"`
 	Version          string
 	UseIMS           bool `json:"use_ims"`
+	// TrafficVault is the configured Traffic Vault backend. If TrafficVaultEnabled is false, this is a trafficvault.Disabled, and every operation fails.
+	TrafficVault        trafficvault.TrafficVault `json:"-"`
+	TrafficVaultEnabled bool                      `json:"-"`
+	// CapabilityPrivLevels maps each Capability required by a route to the privilege level required along with it,
+	// by which Roles without Capabilities are given equivalent Capabilities. It's set from the routes at startup.
+	CapabilityPrivLevels map[string]int `json:"-"`
 }
 
 // ConfigHypnotoad carries http setting for hypnotoad (mojolicious) server
@@ -116,6 +125,34 @@ type ConfigTrafficOpsGolang struct {
 	// CRConfigEmulateOldPath is whether to emulate the legacy CRConfig request path when generating a new CRConfig. This primarily exists in the event a tool relies on the legacy path '/tools/write_crconfig'.
 	// Deprecated: will be removed in the next major version.
 	CRConfigEmulateOldPath bool `json:"crconfig_emulate_old_path"`
+	// TrafficVaultBackend is the name of the Traffic Vault backend, "riak" or "postgres". If empty, Riak is used if it is configured.
+	TrafficVaultBackend string `json:"traffic_vault_backend"`
+	// TrafficVaultConfig is the configuration of the Traffic Vault backend, for backends other than Riak, which is configured by the riak config file.
+	TrafficVaultConfig json.RawMessage `json:"traffic_vault_config"`
+	// SnapshotHistoryLimit is the number of Snapshots of each CDN to retain, including the current one. If 0, DefaultSnapshotHistoryLimit is used. If negative, all Snapshots are retained.
+	SnapshotHistoryLimit int `json:"snapshot_history_limit"`
+	// WebhookMaxAttempts is the number of attempts to deliver an event to a webhook before giving up. If 0, DefaultWebhookMaxAttempts is used.
+	WebhookMaxAttempts int `json:"webhook_max_attempts"`
+	// WebhookTimeoutSeconds is the timeout of each attempt to deliver an event to a webhook. If 0, DefaultWebhookTimeoutSecs is used.
+	WebhookTimeoutSeconds int `json:"webhook_timeout_seconds"`
+	// WebhookPollIntervalSeconds is how often to check for events to deliver to webhooks. If 0, DefaultWebhookPollIntervalSecs is used.
+	WebhookPollIntervalSeconds int `json:"webhook_poll_interval_seconds"`
+	// AsyncJobWorkers is the number of asynchronous jobs each Traffic Ops instance runs at once. If 0, DefaultAsyncJobWorkers is used.
+	AsyncJobWorkers int `json:"async_job_workers"`
+	// AsyncJobPollIntervalSeconds is how often idle workers check for asynchronous jobs to run. If 0, DefaultAsyncJobPollIntervalSecs is used.
+	AsyncJobPollIntervalSeconds int `json:"async_job_poll_interval_seconds"`
+	// MaintenanceWindowPollIntervalSeconds is how often to check for maintenance windows to start or end. If 0, DefaultMaintenanceWindowPollIntervalSecs is used.
+	MaintenanceWindowPollIntervalSeconds int `json:"maintenance_window_poll_interval_seconds"`
+	// DNSSECKeyRefreshIntervalSeconds is how often to refresh the DNSSEC keys of CDNs, rolling over expiring keys. If 0, DefaultDNSSECKeyRefreshIntervalSecs is used. If negative, keys are only refreshed by requests to cdns/dnsseckeys/refresh.
+	DNSSECKeyRefreshIntervalSeconds int `json:"dnssec_key_refresh_interval_seconds"`
+	// SSLKeyExpiryCheckIntervalSeconds is how often to check Delivery Service certificates for expiry. If 0, DefaultSSLKeyExpiryCheckIntervalSecs is used. If negative, certificates are not checked.
+	SSLKeyExpiryCheckIntervalSeconds int `json:"ssl_key_expiry_check_interval_seconds"`
+	// SSLKeyExpiryNotificationDays is how many days before their expiry certificates raise CDN notifications. If 0, DefaultSSLKeyExpiryNotificationDays is used.
+	SSLKeyExpiryNotificationDays int `json:"ssl_key_expiry_notification_days"`
+	// SSLKeyExpiryNotificationUser is the user on behalf of whom CDN notifications of expiring certificates are raised. If empty, certificates are not checked.
+	SSLKeyExpiryNotificationUser string `json:"ssl_key_expiry_notification_user"`
+	// UseCapabilities is whether routes which declare required Capabilities authorize users by their Roles' Capabilities, rather than their privilege levels. Users whose Roles have no Capabilities are always authorized by privilege level.
+	UseCapabilities bool `json:"use_capabilities"`
 }
 
 // RoutingBlacklist contains a list of route IDs that are disabled,
@@ -174,6 +211,33 @@ type ConfigAcmeAccount struct {
 	HmacEncoded  string `json:"hmac_encoded"`
 }
 
+// ConfigOIDC contains configuration information for authenticating users with an OpenID Connect identity provider.
+type ConfigOIDC struct {
+	// Issuer is the Issuer Identifier of the identity provider. Its discovery document is fetched from Issuer + "/.well-known/openid-configuration", and it must match the "iss" claim of ID tokens.
+	Issuer       string `json:"issuer"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	// UsernameClaim is the ID token claim used as the Traffic Ops username. If empty, DefaultOIDCUsernameClaim is used.
+	UsernameClaim string `json:"username_claim"`
+	// GroupsClaim is the ID token claim containing the user's groups. If empty, DefaultOIDCGroupsClaim is used.
+	GroupsClaim string `json:"groups_claim"`
+	// AutoProvision is whether to create users who don't exist in Traffic Ops on their first login, with the Role and Tenant of their first mapped group.
+	AutoProvision bool `json:"auto_provision"`
+	// SyncRoles is whether to update the Role and Tenant of existing users from their mapped groups on every login.
+	SyncRoles bool `json:"sync_roles"`
+	// GroupMappings maps identity provider groups to Roles and Tenants. The first mapping whose group the user is in is used.
+	GroupMappings []ConfigOIDCGroupMapping `json:"group_mappings"`
+	// JWKSCacheSeconds is how long the identity provider's JSON Web Key Set is cached. If 0, DefaultOIDCJWKSCacheSecs is used.
+	JWKSCacheSeconds int `json:"jwks_cache_seconds"`
+}
+
+// ConfigOIDCGroupMapping maps an OpenID Connect identity provider group to a Traffic Ops Role and Tenant, by name.
+type ConfigOIDCGroupMapping struct {
+	Group  string `json:"group"`
+	Role   string `json:"role"`
+	Tenant string `json:"tenant"`
+}
+
 // ConfigDatabase reflects the structure of the database.conf file
 type ConfigDatabase struct {
 	Description string `json:"description"`
@@ -216,6 +280,19 @@ func NewFakeConfig() Config {
 
 const DefaultLDAPTimeoutSecs = 60
 const DefaultDBQueryTimeoutSecs = 20
+const DefaultSnapshotHistoryLimit = 10
+const DefaultWebhookMaxAttempts = 8
+const DefaultWebhookTimeoutSecs = 10
+const DefaultWebhookPollIntervalSecs = 5
+const DefaultAsyncJobWorkers = 4
+const DefaultAsyncJobPollIntervalSecs = 2
+const DefaultMaintenanceWindowPollIntervalSecs = 30
+const DefaultDNSSECKeyRefreshIntervalSecs = 3600
+const DefaultSSLKeyExpiryCheckIntervalSecs = 3600
+const DefaultSSLKeyExpiryNotificationDays = 30
+const DefaultOIDCUsernameClaim = "sub"
+const DefaultOIDCGroupsClaim = "groups"
+const DefaultOIDCJWKSCacheSecs = 3600
 
 // ErrorLog - critical messages
 func (c Config) ErrorLog() log.LogLocation {
@@ -291,6 +368,9 @@ func LoadConfig(cdnConfPath string, dbConfPath string, riakConfPath string, appV
 			return Config{}, []error{fmt.Errorf("parsing config '%s': %v", riakConfPath, err)}, BlockStartup
 		}
 	}
+	if err := loadTrafficVault(&cfg); err != nil {
+		return Config{}, []error{fmt.Errorf("loading Traffic Vault backend '%s': %v", cfg.TrafficVaultBackend, err)}, BlockStartup
+	}
 	// check for and load ldap.conf
 	if cfg.LDAPConfPath != "" {
 		cfg.LDAPEnabled, cfg.ConfigLDAP, err = GetLDAPConfig(cfg.LDAPConfPath)
@@ -336,6 +416,37 @@ func LoadConfig(cdnConfPath string, dbConfPath string, riakConfPath string, appV
 	return cfg, []error{}, AllowStartup
 }
 
+// loadTrafficVault sets the config's Traffic Vault to its configured backend.
+// If no backend is configured, Riak is used if its config file was given, and otherwise Traffic Vault is disabled.
+func loadTrafficVault(cfg *Config) error {
+	cfg.TrafficVault = trafficvault.Disabled{}
+	cfg.TrafficVaultEnabled = false
+	switch cfg.TrafficVaultBackend {
+	case "":
+		if cfg.RiakEnabled {
+			cfg.TrafficVaultBackend = trafficvault.BackendRiak
+			cfg.TrafficVault = riaksvc.NewTrafficVault(cfg.RiakAuthOptions, cfg.RiakPort)
+			cfg.TrafficVaultEnabled = true
+		}
+	case trafficvault.BackendRiak:
+		if !cfg.RiakEnabled {
+			return errors.New("riak backend requires a riak config file")
+		}
+		cfg.TrafficVault = riaksvc.NewTrafficVault(cfg.RiakAuthOptions, cfg.RiakPort)
+		cfg.TrafficVaultEnabled = true
+	case trafficvault.BackendPostgres:
+		tv, err := tvpostgres.New(cfg.TrafficVaultConfig)
+		if err != nil {
+			return err
+		}
+		cfg.TrafficVault = tv
+		cfg.TrafficVaultEnabled = true
+	default:
+		return errors.New("unknown backend, must be one of: " + trafficvault.BackendRiak + ", " + trafficvault.BackendPostgres)
+	}
+	return nil
+}
+
 // GetCertPath - extracts path to cert .cert file
 func (c Config) GetCertPath() string {
 	v, ok := c.URL.Query()["cert"]
@@ -399,6 +510,62 @@ func ParseConfig(cfg Config) (Config, error) {
 	if cfg.DBQueryTimeoutSeconds == 0 {
 		cfg.DBQueryTimeoutSeconds = DefaultDBQueryTimeoutSecs
 	}
+	if cfg.SnapshotHistoryLimit == 0 {
+		cfg.SnapshotHistoryLimit = DefaultSnapshotHistoryLimit
+	}
+	if cfg.WebhookMaxAttempts == 0 {
+		cfg.WebhookMaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if cfg.WebhookTimeoutSeconds == 0 {
+		cfg.WebhookTimeoutSeconds = DefaultWebhookTimeoutSecs
+	}
+	if cfg.WebhookPollIntervalSeconds == 0 {
+		cfg.WebhookPollIntervalSeconds = DefaultWebhookPollIntervalSecs
+	}
+	if cfg.AsyncJobWorkers == 0 {
+		cfg.AsyncJobWorkers = DefaultAsyncJobWorkers
+	}
+	if cfg.AsyncJobPollIntervalSeconds == 0 {
+		cfg.AsyncJobPollIntervalSeconds = DefaultAsyncJobPollIntervalSecs
+	}
+	if cfg.MaintenanceWindowPollIntervalSeconds == 0 {
+		cfg.MaintenanceWindowPollIntervalSeconds = DefaultMaintenanceWindowPollIntervalSecs
+	}
+	if cfg.DNSSECKeyRefreshIntervalSeconds == 0 {
+		cfg.DNSSECKeyRefreshIntervalSeconds = DefaultDNSSECKeyRefreshIntervalSecs
+	}
+	if cfg.SSLKeyExpiryCheckIntervalSeconds == 0 {
+		cfg.SSLKeyExpiryCheckIntervalSeconds = DefaultSSLKeyExpiryCheckIntervalSecs
+	}
+	if cfg.SSLKeyExpiryNotificationDays == 0 {
+		cfg.SSLKeyExpiryNotificationDays = DefaultSSLKeyExpiryNotificationDays
+	}
+
+	if cfg.OIDC != nil {
+		if cfg.OIDC.Issuer == "" {
+			missings += "oidc.issuer, "
+		}
+		if cfg.OIDC.ClientID == "" {
+			missings += "oidc.client_id, "
+		}
+		if cfg.OIDC.UsernameClaim == "" {
+			cfg.OIDC.UsernameClaim = DefaultOIDCUsernameClaim
+		}
+		if cfg.OIDC.GroupsClaim == "" {
+			cfg.OIDC.GroupsClaim = DefaultOIDCGroupsClaim
+		}
+		if cfg.OIDC.JWKSCacheSeconds == 0 {
+			cfg.OIDC.JWKSCacheSeconds = DefaultOIDCJWKSCacheSecs
+		}
+		if (cfg.OIDC.AutoProvision || cfg.OIDC.SyncRoles) && len(cfg.OIDC.GroupMappings) == 0 {
+			missings += "oidc.group_mappings, "
+		}
+		for i, mapping := range cfg.OIDC.GroupMappings {
+			if mapping.Group == "" || mapping.Role == "" || mapping.Tenant == "" {
+				missings += fmt.Sprintf("oidc.group_mappings[%d] group, role, and tenant, ", i)
+			}
+		}
+	}
 
 	invalidTOURLStr := ""
 	var err error
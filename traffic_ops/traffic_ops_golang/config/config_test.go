@@ -30,6 +30,7 @@ import (
 	"testing"
 
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
 	"github.com/basho/riak-go-client"
 )
 
@@ -320,3 +321,39 @@ func TestValidateRoutingBlacklist(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadTrafficVault(t *testing.T) {
+	cfg := Config{}
+	if err := loadTrafficVault(&cfg); err != nil {
+		t.Fatalf("no backend: expected no error, actual: %v", err)
+	}
+	if cfg.TrafficVaultEnabled {
+		t.Errorf("no backend without riak: expected Traffic Vault to be disabled")
+	}
+	if _, ok := cfg.TrafficVault.(trafficvault.Disabled); !ok {
+		t.Errorf("no backend without riak: expected disabled Traffic Vault, actual: %T", cfg.TrafficVault)
+	}
+
+	cfg = Config{RiakEnabled: true, RiakAuthOptions: &riak.AuthOptions{User: "riakuser", Password: "password"}}
+	if err := loadTrafficVault(&cfg); err != nil {
+		t.Fatalf("no backend with riak: expected no error, actual: %v", err)
+	}
+	if !cfg.TrafficVaultEnabled || cfg.TrafficVault.Name() != trafficvault.BackendRiak {
+		t.Errorf("no backend with riak: expected riak Traffic Vault, actual: %T", cfg.TrafficVault)
+	}
+
+	cfg = Config{ConfigTrafficOpsGolang: ConfigTrafficOpsGolang{TrafficVaultBackend: trafficvault.BackendRiak}}
+	if err := loadTrafficVault(&cfg); err == nil {
+		t.Errorf("riak backend without riak config: expected error, actual: nil")
+	}
+
+	cfg = Config{ConfigTrafficOpsGolang: ConfigTrafficOpsGolang{TrafficVaultBackend: trafficvault.BackendPostgres}}
+	if err := loadTrafficVault(&cfg); err == nil {
+		t.Errorf("postgres backend without traffic_vault_config: expected error, actual: nil")
+	}
+
+	cfg = Config{ConfigTrafficOpsGolang: ConfigTrafficOpsGolang{TrafficVaultBackend: "foo"}}
+	if err := loadTrafficVault(&cfg); err == nil {
+		t.Errorf("unknown backend: expected error, actual: nil")
+	}
+}
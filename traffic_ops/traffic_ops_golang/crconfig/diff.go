@@ -0,0 +1,180 @@
+package crconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// snapshotDiffArrayKeys are the keys which identify the objects in arrays in Snapshots, in order of preference. Arrays whose objects all have unique values for one of these keys are compared by that key, rather than by index, so reordering isn't reported as a change.
+var snapshotDiffArrayKeys = []string{"hostName", "xmlId", "name", "id"}
+
+// DiffSnapshotJSON returns the structured difference between the two given JSON Snapshots (either CRConfigs or monitoring configurations).
+func DiffSnapshotJSON(from []byte, to []byte) ([]tc.SnapshotDiffChange, error) {
+	fromVal, err := decodeSnapshotJSON(from)
+	if err != nil {
+		return nil, fmt.Errorf("decoding from: %v", err)
+	}
+	toVal, err := decodeSnapshotJSON(to)
+	if err != nil {
+		return nil, fmt.Errorf("decoding to: %v", err)
+	}
+	changes := []tc.SnapshotDiffChange{}
+	diffSnapshotVals([]string{}, fromVal, toVal, &changes)
+	return changes, nil
+}
+
+func decodeSnapshotJSON(bts []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(bts))
+	dec.UseNumber() // preserve numbers exactly, so large integers aren't reported as changed
+	val := interface{}(nil)
+	if err := dec.Decode(&val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
+
+func diffSnapshotVals(path []string, from interface{}, to interface{}, changes *[]tc.SnapshotDiffChange) {
+	fromObj, fromIsObj := from.(map[string]interface{})
+	toObj, toIsObj := to.(map[string]interface{})
+	if fromIsObj && toIsObj {
+		diffSnapshotObjects(path, fromObj, toObj, changes)
+		return
+	}
+
+	fromArr, fromIsArr := from.([]interface{})
+	toArr, toIsArr := to.([]interface{})
+	if fromIsArr && toIsArr {
+		if key := snapshotArrayKey(fromArr, toArr); key != "" {
+			diffSnapshotObjects(path, keySnapshotArray(fromArr, key), keySnapshotArray(toArr, key), changes)
+			return
+		}
+		for i := 0; i < len(fromArr) || i < len(toArr); i++ {
+			elemPath := appendPath(path, strconv.Itoa(i))
+			switch {
+			case i >= len(toArr):
+				*changes = append(*changes, tc.SnapshotDiffChange{Path: elemPath, Op: tc.SnapshotDiffOpRemoved, Old: fromArr[i]})
+			case i >= len(fromArr):
+				*changes = append(*changes, tc.SnapshotDiffChange{Path: elemPath, Op: tc.SnapshotDiffOpAdded, New: toArr[i]})
+			default:
+				diffSnapshotVals(elemPath, fromArr[i], toArr[i], changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, tc.SnapshotDiffChange{Path: path, Op: tc.SnapshotDiffOpChanged, Old: from, New: to})
+	}
+}
+
+func diffSnapshotObjects(path []string, from map[string]interface{}, to map[string]interface{}, changes *[]tc.SnapshotDiffChange) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fromVal, inFrom := from[key]
+		toVal, inTo := to[key]
+		keyPath := appendPath(path, key)
+		switch {
+		case !inTo:
+			*changes = append(*changes, tc.SnapshotDiffChange{Path: keyPath, Op: tc.SnapshotDiffOpRemoved, Old: fromVal})
+		case !inFrom:
+			*changes = append(*changes, tc.SnapshotDiffChange{Path: keyPath, Op: tc.SnapshotDiffOpAdded, New: toVal})
+		default:
+			diffSnapshotVals(keyPath, fromVal, toVal, changes)
+		}
+	}
+}
+
+// snapshotArrayKey returns the first of snapshotDiffArrayKeys which identifies every object in both arrays, or the empty string if none does.
+func snapshotArrayKey(from []interface{}, to []interface{}) string {
+	if len(from) == 0 && len(to) == 0 {
+		return ""
+	}
+	for _, key := range snapshotDiffArrayKeys {
+		if identifiesSnapshotArray(from, key) && identifiesSnapshotArray(to, key) {
+			return key
+		}
+	}
+	return ""
+}
+
+func identifiesSnapshotArray(arr []interface{}, key string) bool {
+	seen := make(map[string]struct{}, len(arr))
+	for _, elem := range arr {
+		obj, ok := elem.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		id, ok := snapshotArrayID(obj, key)
+		if !ok {
+			return false
+		}
+		if _, ok := seen[id]; ok {
+			return false
+		}
+		seen[id] = struct{}{}
+	}
+	return true
+}
+
+func snapshotArrayID(obj map[string]interface{}, key string) (string, bool) {
+	switch id := obj[key].(type) {
+	case string:
+		return id, true
+	case json.Number:
+		return id.String(), true
+	default:
+		return "", false
+	}
+}
+
+func keySnapshotArray(arr []interface{}, key string) map[string]interface{} {
+	keyed := make(map[string]interface{}, len(arr))
+	for _, elem := range arr {
+		obj := elem.(map[string]interface{}) // the key was checked by snapshotArrayKey
+		id, _ := snapshotArrayID(obj, key)
+		keyed[id] = obj
+	}
+	return keyed
+}
+
+// appendPath returns a new path of the given path and key, which doesn't share memory with the given path, so sibling paths don't overwrite each other.
+func appendPath(path []string, key string) []string {
+	newPath := make([]string, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, key)
+}
@@ -0,0 +1,100 @@
+package crconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestDiffSnapshotJSON(t *testing.T) {
+	from := []byte(`{
+		"config": {"domain_name": "example.net", "ttl": 60},
+		"trafficServers": [
+			{"hostName": "edge0", "status": "REPORTED", "port": 80},
+			{"hostName": "edge1", "status": "REPORTED", "port": 80}
+		],
+		"resolvers": ["192.0.2.1", "192.0.2.2"],
+		"stats": {"date": 1614816000}
+	}`)
+	to := []byte(`{
+		"config": {"domain_name": "example.net", "ttl": 30},
+		"trafficServers": [
+			{"hostName": "edge2", "status": "ONLINE", "port": 80},
+			{"hostName": "edge1", "status": "ADMIN_DOWN", "port": 80}
+		],
+		"resolvers": ["192.0.2.1"],
+		"stats": {"date": 1614816000},
+		"topologies": {}
+	}`)
+
+	changes, err := DiffSnapshotJSON(from, to)
+	if err != nil {
+		t.Fatalf("DiffSnapshotJSON expected: nil error, actual: %v", err)
+	}
+
+	expected := []tc.SnapshotDiffChange{
+		{Path: []string{"config", "ttl"}, Op: tc.SnapshotDiffOpChanged, Old: json.Number("60"), New: json.Number("30")},
+		{Path: []string{"resolvers", "1"}, Op: tc.SnapshotDiffOpRemoved, Old: "192.0.2.2"},
+		{Path: []string{"topologies"}, Op: tc.SnapshotDiffOpAdded, New: map[string]interface{}{}},
+		{Path: []string{"trafficServers", "edge0"}, Op: tc.SnapshotDiffOpRemoved, Old: map[string]interface{}{"hostName": "edge0", "status": "REPORTED", "port": json.Number("80")}},
+		{Path: []string{"trafficServers", "edge1", "status"}, Op: tc.SnapshotDiffOpChanged, Old: "REPORTED", New: "ADMIN_DOWN"},
+		{Path: []string{"trafficServers", "edge2"}, Op: tc.SnapshotDiffOpAdded, New: map[string]interface{}{"hostName": "edge2", "status": "ONLINE", "port": json.Number("80")}},
+	}
+	if !reflect.DeepEqual(expected, changes) {
+		t.Errorf("DiffSnapshotJSON expected: %+v, actual: %+v", expected, changes)
+	}
+}
+
+func TestDiffSnapshotJSONIdentical(t *testing.T) {
+	snapshot := []byte(`{"contentServers": {"edge0": {"port": 80}}, "deliveryServices": {"ds0": {"ttl": 3600}}}`)
+	changes, err := DiffSnapshotJSON(snapshot, snapshot)
+	if err != nil {
+		t.Fatalf("DiffSnapshotJSON expected: nil error, actual: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("DiffSnapshotJSON of identical snapshots expected: no changes, actual: %+v", changes)
+	}
+}
+
+func TestDiffSnapshotJSONDuplicateKeys(t *testing.T) {
+	// Elements without a unique identifying key are compared by index.
+	from := []byte(`[{"name": "a", "v": 1}, {"name": "a", "v": 2}]`)
+	to := []byte(`[{"name": "a", "v": 1}, {"name": "a", "v": 3}]`)
+	changes, err := DiffSnapshotJSON(from, to)
+	if err != nil {
+		t.Fatalf("DiffSnapshotJSON expected: nil error, actual: %v", err)
+	}
+	expected := []tc.SnapshotDiffChange{
+		{Path: []string{"1", "v"}, Op: tc.SnapshotDiffOpChanged, Old: json.Number("2"), New: json.Number("3")},
+	}
+	if !reflect.DeepEqual(expected, changes) {
+		t.Errorf("DiffSnapshotJSON expected: %+v, actual: %+v", expected, changes)
+	}
+}
+
+func TestDiffSnapshotJSONInvalid(t *testing.T) {
+	if _, err := DiffSnapshotJSON([]byte(`{`), []byte(`{}`)); err == nil {
+		t.Error("DiffSnapshotJSON with invalid JSON expected: error, actual: nil")
+	}
+}
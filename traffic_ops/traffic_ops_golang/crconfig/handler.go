@@ -20,6 +20,7 @@ package crconfig
  */
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,6 +32,8 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/asyncjob"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/monitoring"
@@ -224,34 +227,81 @@ func snapshotHandler(w http.ResponseWriter, r *http.Request, deprecated bool) {
 		}
 	}
 
+	if !deprecated && asyncjob.PrefersAsync(r) {
+		asyncjob.RespondAsync(w, r, inf, SnapshotJobKind, "Snapshot of CDN "+cdn+" queued.", snapshotJobPayload{CDN: cdn, CDNID: id, Host: r.Host})
+		return
+	}
+
+	if err := takeSnapshot(inf, db.DB, cdn, id, r.Host); err != nil {
+		api.HandleErrOptionalDeprecation(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" "+err.Error()), deprecated, &alt)
+		return
+	}
+	if deprecated {
+		api.WriteAlertsObj(w, r, http.StatusOK, api.CreateDeprecationAlerts(&alt), "SUCCESS")
+		return
+	}
+	api.WriteResp(w, r, "SUCCESS")
+}
+
+// takeSnapshot creates the CRConfig and monitoring configuration of the CDN with the given name and ID, and writes them to the snapshot table in the transaction of inf, as its user.
+func takeSnapshot(inf *api.APIInfo, db *sql.DB, cdn string, id int, host string) error {
 	// We never store tm_path, even though low API versions show it in responses.
-	crConfig, err := Make(inf.Tx.Tx, cdn, inf.User.UserName, r.Host, inf.Config.Version, inf.Config.CRConfigUseRequestHost, false)
+	crConfig, err := Make(inf.Tx.Tx, cdn, inf.User.UserName, host, inf.Config.Version, inf.Config.CRConfigUseRequestHost, false)
 	if err != nil {
-		api.HandleErrOptionalDeprecation(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err, deprecated, &alt)
-		return
+		return err
 	}
 	monitoringJSON, err := monitoring.GetMonitoringJSON(inf.Tx.Tx, cdn)
 	if err != nil {
-		api.HandleErrOptionalDeprecation(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" getting monitoring.json data: "+err.Error()), deprecated, &alt)
-		return
+		return errors.New("getting monitoring.json data: " + err.Error())
 	}
 
 	if err := Snapshot(inf.Tx.Tx, crConfig, monitoringJSON); err != nil {
-		api.HandleErrOptionalDeprecation(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" snaphsotting CRConfig and Monitoring: "+err.Error()), deprecated, &alt)
-		return
+		return errors.New("snaphsotting CRConfig and Monitoring: " + err.Error())
 	}
 
-	if err := deliveryservice.DeleteOldCerts(db.DB, inf.Tx.Tx, inf.Config, tc.CDNName(cdn)); err != nil {
-		api.HandleErrOptionalDeprecation(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" snapshotting CRConfig and Monitoring: starting old certificate deletion job: "+err.Error()), deprecated, &alt)
-		return
+	if err := deliveryservice.DeleteOldCerts(db, inf.Tx.Tx, inf.Config, tc.CDNName(cdn)); err != nil {
+		return errors.New("snapshotting CRConfig and Monitoring: starting old certificate deletion job: " + err.Error())
 	}
 
-	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdn+", ID: "+strconv.Itoa(id)+", ACTION: Snapshot of CRConfig and Monitor", inf.User, inf.Tx.Tx)
-	if deprecated {
-		api.WriteAlertsObj(w, r, http.StatusOK, api.CreateDeprecationAlerts(&alt), "SUCCESS")
-		return
+	logID, err := api.CreateChangeLogRawID(api.ApiChange, "CDN: "+cdn+", ID: "+strconv.Itoa(id)+", ACTION: Snapshot of CRConfig and Monitor", inf.User, inf.Tx.Tx)
+	if err != nil {
+		log.Errorln(err.Error())
 	}
-	api.WriteResp(w, r, "SUCCESS")
+	if _, err := addSnapshotHistory(inf, cdn, nilIfZero(logID), nil); err != nil {
+		return errors.New("snapshotting CRConfig and Monitoring: " + err.Error())
+	}
+	return nil
+}
+
+// SnapshotJobKind is the kind of asynchronous job which takes a Snapshot.
+const SnapshotJobKind = "snapshot"
+
+type snapshotJobPayload struct {
+	CDN   string `json:"cdn"`
+	CDNID int    `json:"cdnId"`
+	Host  string `json:"host"`
+}
+
+func init() {
+	asyncjob.Register(SnapshotJobKind, snapshotJob)
+}
+
+// EnqueueSnapshot queues a job to take a Snapshot of the CDN with the given name and ID on behalf of the given user, for changes made outside of a request to snapshot it.
+// It's taken as if it were requested from the given Traffic Ops host, which only matters if crconfig_use_request_host is set.
+func EnqueueSnapshot(tx *sql.Tx, user *auth.CurrentUser, cdn string, id int, host string) (api.AsyncStatus, error) {
+	return asyncjob.Enqueue(tx, user, SnapshotJobKind, "Snapshot of CDN "+cdn+" queued.", snapshotJobPayload{CDN: cdn, CDNID: id, Host: host})
+}
+
+// snapshotJob takes a Snapshot requested with a preference for an asynchronous response.
+func snapshotJob(job *asyncjob.Job) (interface{}, error, error) {
+	payload := snapshotJobPayload{}
+	if err := job.DecodePayload(&payload); err != nil {
+		return nil, nil, errors.New("decoding snapshot job payload: " + err.Error())
+	}
+	if err := takeSnapshot(job.APIInfo(), job.DB.DB, payload.CDN, payload.CDNID, payload.Host); err != nil {
+		return nil, nil, err
+	}
+	return "SUCCESS", nil, nil
 }
 
 // SnapshotOldGUIHandler creates the CRConfig JSON and writes it to the snapshot table in the database. The response emulates the old Perl UI function. This should go away when the old Perl UI ceases to exist.
@@ -298,6 +348,13 @@ func SnapshotOldGUIHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	api.CreateChangeLogRawTx(api.ApiChange, "Snapshot of CRConfig performed for "+cdn, inf.User, inf.Tx.Tx)
+	logID, err := api.CreateChangeLogRawID(api.ApiChange, "Snapshot of CRConfig performed for "+cdn, inf.User, inf.Tx.Tx)
+	if err != nil {
+		log.Errorln(err.Error())
+	}
+	if _, err := addSnapshotHistory(inf, cdn, nilIfZero(logID), nil); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" old snapshotting CRConfig and Monitoring: "+err.Error()))
+		return
+	}
 	http.Redirect(w, r, client.API_v13_CDNs+"/"+cdn+"/snapshot", http.StatusFound)
 }
@@ -0,0 +1,393 @@
+package crconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/monitoring"
+)
+
+// AddSnapshotHistory copies the current Snapshot of the given CDN into the Snapshot history, and returns the ID of the new history entry.
+// The logID and rollbackOf may be nil.
+// After adding the entry, all but the newest limit entries of the CDN are removed. If limit is not positive, no entries are removed.
+func AddSnapshotHistory(tx *sql.Tx, cdn string, author string, logID *int64, rollbackOf *int64, limit int) (int64, error) {
+	q := `
+INSERT INTO snapshot_history (cdn, crconfig, monitoring, author, log_id, rollback_of)
+SELECT s.cdn, s.crconfig, s.monitoring, $2, $3, $4
+FROM snapshot AS s
+WHERE s.cdn = $1
+RETURNING id
+`
+	id := int64(0)
+	if err := tx.QueryRow(q, cdn, author, logID, rollbackOf).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("CDN '" + cdn + "' has no snapshot to add to history")
+		}
+		return 0, errors.New("inserting snapshot history: " + err.Error())
+	}
+	if limit <= 0 {
+		return id, nil
+	}
+	q = `
+DELETE FROM snapshot_history
+WHERE cdn = $1
+AND id NOT IN (
+  SELECT sh.id
+  FROM snapshot_history AS sh
+  WHERE sh.cdn = $1
+  ORDER BY sh.id DESC
+  LIMIT $2
+)
+`
+	if _, err := tx.Exec(q, cdn, limit); err != nil {
+		return 0, errors.New("pruning snapshot history: " + err.Error())
+	}
+	return id, nil
+}
+
+// GetSnapshotHistory returns the retained Snapshots of the given CDN, newest first.
+// The newest entry is the CDN's current Snapshot.
+func GetSnapshotHistory(tx *sql.Tx, cdn string) ([]tc.SnapshotHistoryEntry, error) {
+	q := `
+SELECT id, cdn, author, log_id, rollback_of, created_at
+FROM snapshot_history
+WHERE cdn = $1
+ORDER BY id DESC
+`
+	rows, err := tx.Query(q, cdn)
+	if err != nil {
+		return nil, errors.New("querying snapshot history: " + err.Error())
+	}
+	defer rows.Close()
+
+	entries := []tc.SnapshotHistoryEntry{}
+	for rows.Next() {
+		e := tc.SnapshotHistoryEntry{}
+		if err := rows.Scan(&e.ID, &e.CDN, &e.Author, &e.ChangeLogID, &e.RollbackOf, &e.CreatedAt); err != nil {
+			return nil, errors.New("scanning snapshot history: " + err.Error())
+		}
+		e.Current = len(entries) == 0
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating snapshot history: " + err.Error())
+	}
+	return entries, nil
+}
+
+// GetSnapshotHistoryContent returns the retained Snapshot of the given CDN with the given ID, including its CRConfig and monitoring configuration.
+// If no such Snapshot exists, false is returned.
+func GetSnapshotHistoryContent(tx *sql.Tx, cdn string, id int64) (tc.SnapshotHistoryContent, bool, error) {
+	q := `
+SELECT sh.id, sh.cdn, sh.author, sh.log_id, sh.rollback_of, sh.created_at, sh.crconfig, sh.monitoring,
+  sh.id = (SELECT MAX(c.id) FROM snapshot_history AS c WHERE c.cdn = sh.cdn) AS current
+FROM snapshot_history AS sh
+WHERE sh.cdn = $1
+AND sh.id = $2
+`
+	s := tc.SnapshotHistoryContent{}
+	crconfig := []byte{}
+	tm := []byte{}
+	if err := tx.QueryRow(q, cdn, id).Scan(&s.ID, &s.CDN, &s.Author, &s.ChangeLogID, &s.RollbackOf, &s.CreatedAt, &crconfig, &tm, &s.Current); err != nil {
+		if err == sql.ErrNoRows {
+			return tc.SnapshotHistoryContent{}, false, nil
+		}
+		return tc.SnapshotHistoryContent{}, false, errors.New("querying snapshot history entry: " + err.Error())
+	}
+	s.CRConfig = json.RawMessage(crconfig)
+	s.Monitoring = json.RawMessage(tm)
+	return s, true, nil
+}
+
+// writeSnapshot writes the given serialized CRConfig and monitoring configuration to the snapshot table, as the current Snapshot of the CDN.
+func writeSnapshot(tx *sql.Tx, cdn string, crconfig []byte, date time.Time, monitoringJSON []byte) error {
+	q := `insert into snapshot (cdn, crconfig, last_updated, monitoring) values ($1, $2, $3, $4) on conflict(cdn) do update set crconfig=$2, last_updated=$3, monitoring=$4`
+	if _, err := tx.Exec(q, cdn, crconfig, date, monitoringJSON); err != nil {
+		return errors.New("Error inserting the crconfig and monitoring snapshot into database: " + err.Error())
+	}
+	return nil
+}
+
+// addSnapshotHistory records the Snapshot just taken by the given request's user in the history, retaining as many Snapshots as configured, and queues it for delivery to webhooks.
+func addSnapshotHistory(inf *api.APIInfo, cdn string, logID *int64, rollbackOf *int64) (int64, error) {
+	id, err := AddSnapshotHistory(inf.Tx.Tx, cdn, inf.User.UserName, logID, rollbackOf, inf.Config.SnapshotHistoryLimit)
+	if err != nil {
+		return 0, err
+	}
+	ev := tc.WebhookEvent{
+		Action:     api.Created,
+		ObjectType: tc.WebhookObjectTypeSnapshot,
+		ObjectID:   strconv.FormatInt(id, 10),
+		CDNName:    &cdn,
+	}
+	if rollbackOf != nil {
+		ev.Action = tc.WebhookActionRolledBack
+	}
+	if err := api.NotifyWebhooks(inf, ev); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// nilIfZero returns a pointer to the given change log ID, or nil if it is zero, as when creating the change log entry failed.
+func nilIfZero(logID int64) *int64 {
+	if logID == 0 {
+		return nil
+	}
+	return &logID
+}
+
+// getComparableSnapshot returns the serialized CRConfig and monitoring configuration of the given CDN identified by which.
+// The which may be tc.SnapshotCurrent, tc.SnapshotNew, or the ID of a retained Snapshot.
+func getComparableSnapshot(inf *api.APIInfo, r *http.Request, cdn string, which string) ([]byte, []byte, error, error, int) {
+	switch which {
+	case tc.SnapshotCurrent:
+		crconfig, _, err := GetSnapshot(inf.Tx.Tx, cdn)
+		if err != nil {
+			return nil, nil, nil, errors.New("getting snapshot: " + err.Error()), http.StatusInternalServerError
+		}
+		tm, _, err := GetSnapshotMonitoring(inf.Tx.Tx, cdn)
+		if err != nil {
+			return nil, nil, nil, errors.New("getting monitoring snapshot: " + err.Error()), http.StatusInternalServerError
+		}
+		return []byte(crconfig), []byte(tm), nil, nil, http.StatusOK
+	case tc.SnapshotNew:
+		crConfig, err := Make(inf.Tx.Tx, cdn, inf.User.UserName, r.Host, inf.Config.Version, inf.Config.CRConfigUseRequestHost, false)
+		if err != nil {
+			return nil, nil, nil, errors.New("making CRConfig: " + err.Error()), http.StatusInternalServerError
+		}
+		crconfig, err := json.Marshal(crConfig)
+		if err != nil {
+			return nil, nil, nil, errors.New("marshalling CRConfig: " + err.Error()), http.StatusInternalServerError
+		}
+		monitoringJSON, err := monitoring.GetMonitoringJSON(inf.Tx.Tx, cdn)
+		if err != nil {
+			return nil, nil, nil, errors.New("getting monitoring.json data: " + err.Error()), http.StatusInternalServerError
+		}
+		tm, err := json.Marshal(monitoringJSON)
+		if err != nil {
+			return nil, nil, nil, errors.New("marshalling monitoring.json data: " + err.Error()), http.StatusInternalServerError
+		}
+		return crconfig, tm, nil, nil, http.StatusOK
+	}
+
+	id, err := strconv.ParseInt(which, 10, 64)
+	if err != nil {
+		return nil, nil, errors.New("snapshot must be '" + tc.SnapshotCurrent + "', '" + tc.SnapshotNew + "', or the ID of a snapshot history entry"), nil, http.StatusBadRequest
+	}
+	s, ok, err := GetSnapshotHistoryContent(inf.Tx.Tx, cdn, id)
+	if err != nil {
+		return nil, nil, nil, err, http.StatusInternalServerError
+	}
+	if !ok {
+		return nil, nil, errors.New("snapshot history entry " + which + " not found"), nil, http.StatusNotFound
+	}
+	return s.CRConfig, s.Monitoring, nil, nil, http.StatusOK
+}
+
+// checkCDNExists returns an error code and user-facing error if the given CDN doesn't exist.
+func checkCDNExists(inf *api.APIInfo, cdn string) (int, error, error) {
+	_, ok, err := dbhelpers.GetCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdn))
+	if err != nil {
+		return http.StatusInternalServerError, nil, errors.New("getting CDN ID from name: " + err.Error())
+	}
+	if !ok {
+		return http.StatusNotFound, errors.New("CDN not found"), nil
+	}
+	return http.StatusOK, nil, nil
+}
+
+// SnapshotHistoryHandler serves the retained Snapshots of a CDN, without their content.
+func SnapshotHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"cdn"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdn := inf.Params["cdn"]
+	if errCode, userErr, sysErr := checkCDNExists(inf, cdn); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	entries, err := GetSnapshotHistory(inf.Tx.Tx, cdn)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, entries)
+}
+
+// SnapshotHistoryEntryHandler serves a single retained Snapshot of a CDN, with its content.
+func SnapshotHistoryEntryHandler(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"cdn", "id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdn := inf.Params["cdn"]
+	if errCode, userErr, sysErr := checkCDNExists(inf, cdn); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	s, ok, err := GetSnapshotHistoryContent(inf.Tx.Tx, cdn, int64(inf.IntParams["id"]))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("snapshot history entry not found"), nil)
+		return
+	}
+	api.WriteResp(w, r, s)
+}
+
+// SnapshotDiffHandler serves the structured difference between two Snapshots of a CDN.
+// The Snapshots are given by the 'from' and 'to' query parameters, which default to the current Snapshot and the Snapshot which would be created now, respectively.
+func SnapshotDiffHandler(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"cdn"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdn := inf.Params["cdn"]
+	if errCode, userErr, sysErr := checkCDNExists(inf, cdn); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	diff := tc.SnapshotDiff{From: tc.SnapshotCurrent, To: tc.SnapshotNew}
+	if from, ok := inf.Params["from"]; ok && from != "" {
+		diff.From = from
+	}
+	if to, ok := inf.Params["to"]; ok && to != "" {
+		diff.To = to
+	}
+
+	fromCRConfig, fromMonitoring, userErr, sysErr, errCode := getComparableSnapshot(inf, r, cdn, diff.From)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	toCRConfig, toMonitoring, userErr, sysErr, errCode := getComparableSnapshot(inf, r, cdn, diff.To)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var err error
+	if diff.CRConfig, err = DiffSnapshotJSON(fromCRConfig, toCRConfig); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("diffing CRConfig snapshots: "+err.Error()))
+		return
+	}
+	if diff.Monitoring, err = DiffSnapshotJSON(fromMonitoring, toMonitoring); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("diffing monitoring snapshots: "+err.Error()))
+		return
+	}
+	api.WriteResp(w, r, diff)
+}
+
+// SnapshotRollbackHandler makes a retained Snapshot the current Snapshot of its CDN.
+// The rolled-back CRConfig is given the current time and requesting user, so Traffic Monitors and Traffic Routers treat it as newer than the Snapshot it replaces.
+func SnapshotRollbackHandler(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"cdn", "id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdn := inf.Params["cdn"]
+	cdnID, ok, err := dbhelpers.GetCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdn))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting CDN ID from name: "+err.Error()))
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN not found"), nil)
+		return
+	}
+
+	id := int64(inf.IntParams["id"])
+	s, ok, err := GetSnapshotHistoryContent(inf.Tx.Tx, cdn, id)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("snapshot history entry not found"), nil)
+		return
+	}
+
+	crConfig := tc.CRConfig{}
+	if err := json.Unmarshal(s.CRConfig, &crConfig); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("unmarshalling snapshot history CRConfig: "+err.Error()))
+		return
+	}
+	now := time.Now()
+	date := now.Unix()
+	crConfig.Stats.DateUnixSeconds = &date
+	crConfig.Stats.TMUser = &inf.User.UserName
+	bts, err := json.Marshal(crConfig)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("marshalling CRConfig: "+err.Error()))
+		return
+	}
+	if err := writeSnapshot(inf.Tx.Tx, cdn, bts, now, s.Monitoring); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	msg := "CDN: " + cdn + ", ID: " + strconv.Itoa(cdnID) + ", ACTION: Rolled back Snapshot of CRConfig and Monitor to snapshot history entry " + strconv.FormatInt(id, 10)
+	logID, err := api.CreateChangeLogRawID(api.ApiChange, msg, inf.User, inf.Tx.Tx)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	historyID, err := addSnapshotHistory(inf, cdn, &logID, &id)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	entry := tc.SnapshotHistoryEntry{
+		ID:          historyID,
+		CDN:         cdn,
+		Author:      &inf.User.UserName,
+		ChangeLogID: &logID,
+		RollbackOf:  &id,
+		CreatedAt:   now,
+		Current:     true,
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "CDN '"+cdn+"' rolled back to snapshot "+strconv.FormatInt(id, 10), entry)
+}
@@ -34,6 +34,9 @@ import (
 // It also takes the monitoring config JSON and writes it to the snapshot table.
 func Snapshot(tx *sql.Tx, crc *tc.CRConfig, monitoringJSON *monitoring.Monitoring) error {
 	log.Debugln("calling Snapshot")
+	if crc.Stats.CDNName == nil {
+		return errors.New("CRConfig has no CDN name")
+	}
 	bts, err := json.Marshal(crc)
 	if err != nil {
 		return errors.New("marshalling JSON: " + err.Error())
@@ -49,11 +52,7 @@ func Snapshot(tx *sql.Tx, crc *tc.CRConfig, monitoringJSON *monitoring.Monitorin
 	}
 
 	log.Debugf("calling Snapshot, writing %+v\n", date)
-	q := `insert into snapshot (cdn, crconfig, last_updated, monitoring) values ($1, $2, $3, $4) on conflict(cdn) do update set crconfig=$2, last_updated=$3, monitoring=$4`
-	if _, err := tx.Exec(q, crc.Stats.CDNName, bts, date, btstm); err != nil {
-		return errors.New("Error inserting the crconfig and monitoring snapshot into database: " + err.Error())
-	}
-	return nil
+	return writeSnapshot(tx, *crc.Stats.CDNName, bts, date, btstm)
 }
 
 // GetSnapshot gets the snapshot for the given CDN.
@@ -36,7 +36,6 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 
 	"github.com/go-acme/lego/certcrypto"
@@ -57,8 +56,8 @@ func RenewAcmeCertificate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer inf.Close()
-	if inf.Config.RiakEnabled == false {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, userErr, errors.New("deliveryservice.DeleteSSLKeys: Riak is not configured"))
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, userErr, errors.New("deliveryservice.DeleteSSLKeys: Traffic Vault is not configured"))
 		return
 	}
 	xmlID := inf.Params["xmlid"]
@@ -120,7 +119,7 @@ func renewAcmeCerts(cfg *config.Config, dsName string, ctx context.Context, curr
 	if cfg == nil {
 		return nil, errors.New("acme: config was nil"), http.StatusInternalServerError
 	}
-	keyObj, ok, err := riaksvc.GetDeliveryServiceSSLKeysObjV15(dsName, strconv.Itoa(int(*certVersion)), tx, cfg.RiakAuthOptions, cfg.RiakPort)
+	keyObj, ok, err := cfg.TrafficVault.GetDeliveryServiceSSLKeys(dsName, strconv.Itoa(int(*certVersion)), tx)
 	if err != nil {
 		return nil, errors.New("getting ssl keys for xmlId: " + dsName + " and version: " + strconv.Itoa(int(*certVersion)) + " : " + err.Error()), http.StatusInternalServerError
 	}
@@ -138,7 +137,7 @@ func renewAcmeCerts(cfg *config.Config, dsName string, ctx context.Context, curr
 		return nil, errors.New("No acme account information in cdn.conf for " + keyObj.AuthType), http.StatusInternalServerError
 	}
 
-	client, err := GetAcmeClient(acmeAccount, userTx, db)
+	client, err := GetAcmeClient(acmeAccount, keyObj.AcmeChallengeType, userTx, db)
 	if err != nil {
 		api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(*dsID)+", ACTION: FAILED to add SSL keys with "+acmeAccount.AcmeProvider, currentUser, logTx)
 		return nil, errors.New("getting acme client: " + err.Error()), http.StatusInternalServerError
@@ -155,12 +154,13 @@ func renewAcmeCerts(cfg *config.Config, dsName string, ctx context.Context, curr
 	}
 
 	newCertObj := tc.DeliveryServiceSSLKeys{
-		AuthType:        keyObj.AuthType,
-		CDN:             keyObj.CDN,
-		DeliveryService: keyObj.DeliveryService,
-		Key:             keyObj.DeliveryService,
-		Hostname:        keyObj.Hostname,
-		Version:         keyObj.Version + 1,
+		AuthType:          keyObj.AuthType,
+		CDN:               keyObj.CDN,
+		DeliveryService:   keyObj.DeliveryService,
+		Key:               keyObj.DeliveryService,
+		Hostname:          keyObj.Hostname,
+		Version:           keyObj.Version + 1,
+		AcmeChallengeType: keyObj.AcmeChallengeType,
 	}
 
 	newCertObj.Certificate = tc.DeliveryServiceSSLKeysCertificate{
@@ -169,10 +169,10 @@ func renewAcmeCerts(cfg *config.Config, dsName string, ctx context.Context, curr
 		CSR: string(EncodePEMToLegacyPerlRiakFormat([]byte("ACME Generated"))),
 	}
 
-	if err := riaksvc.PutDeliveryServiceSSLKeysObj(newCertObj, tx, cfg.RiakAuthOptions, cfg.RiakPort); err != nil {
-		log.Errorf("Error posting acme certificate to riak: %s", err.Error())
+	if err := cfg.TrafficVault.PutDeliveryServiceSSLKeys(newCertObj, tx); err != nil {
+		log.Errorf("Error posting acme certificate to Traffic Vault: %s", err.Error())
 		api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(*dsID)+", ACTION: FAILED to add SSL keys with "+acmeAccount.AcmeProvider, currentUser, logTx)
-		return nil, errors.New(dsName + ": putting riak keys: " + err.Error()), http.StatusInternalServerError
+		return nil, errors.New(dsName + ": putting Traffic Vault keys: " + err.Error()), http.StatusInternalServerError
 	}
 
 	tx2, err := db.Begin()
@@ -214,7 +214,8 @@ func getDSIdAndVersionFromName(db *sqlx.DB, xmlId string) (*int, *int64, error)
 }
 
 // GetAcmeClient uses the ACME account information in either cdn.conf or the database to create and register an ACME client.
-func GetAcmeClient(acmeAccount *config.ConfigAcmeAccount, userTx *sql.Tx, db *sqlx.DB) (*lego.Client, error) {
+// If challengeType is tc.AcmeChallengeTypeHTTP01, the client solves only HTTP-01 challenges, answered by Traffic Routers.
+func GetAcmeClient(acmeAccount *config.ConfigAcmeAccount, challengeType string, userTx *sql.Tx, db *sqlx.DB) (*lego.Client, error) {
 	if acmeAccount.UserEmail == "" {
 		log.Errorf("An email address must be provided to use ACME with %v", acmeAccount.AcmeProvider)
 		return nil, errors.New("An email address must be provided to use ACME with " + acmeAccount.AcmeProvider)
@@ -259,7 +260,14 @@ func GetAcmeClient(acmeAccount *config.ConfigAcmeAccount, userTx *sql.Tx, db *sq
 		return nil, err
 	}
 
-	if acmeAccount.AcmeProvider == tc.LetsEncryptAuthType {
+	if challengeType == tc.AcmeChallengeTypeHTTP01 {
+		client.Challenge.Remove(challenge.DNS01)
+		client.Challenge.Remove(challenge.TLSALPN01)
+		if err := client.Challenge.SetHTTP01Provider(NewHTTPProviderTrafficRouter(db)); err != nil {
+			log.Errorf("Error setting Traffic Router HTTP provider: %s", err.Error())
+			return nil, err
+		}
+	} else if acmeAccount.AcmeProvider == tc.LetsEncryptAuthType {
 		client.Challenge.Remove(challenge.HTTP01)
 		client.Challenge.Remove(challenge.TLSALPN01)
 		trafficRouterDns := NewDNSProviderTrafficRouter()
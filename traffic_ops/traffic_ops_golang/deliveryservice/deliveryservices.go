@@ -101,8 +101,11 @@ func (ds *TODeliveryService) GetAuditName() string {
 	return ""
 }
 
+// auditObjectType is the object type of delivery services in the audit log, which is the same as their change log type.
+const auditObjectType = "ds"
+
 func (ds *TODeliveryService) GetType() string {
-	return "ds"
+	return auditObjectType
 }
 
 // IsTenantAuthorized checks that the user is authorized for both the delivery service's existing tenant, and the new tenant they're changing it to (if different).
@@ -344,6 +347,12 @@ func createV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 t
 		return nil, http.StatusForbidden, errors.New("not authorized on this tenant"), nil
 	}
 
+	if ds.TenantID != nil {
+		if _, userErr, sysErr, errCode := tenant.CheckDeliveryServiceQuotas(tx, *ds.TenantID, nil, ds.GlobalMaxMBPS); userErr != nil || sysErr != nil {
+			return nil, errCode, userErr, sysErr
+		}
+	}
+
 	// TODO change DeepCachingType to implement sql.Valuer and sql.Scanner, so sqlx struct scan can be used.
 	deepCachingType := tc.DeepCachingType("").String()
 	if ds.DeepCachingType != nil {
@@ -490,7 +499,8 @@ func createV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 t
 	}
 
 	ds.LastUpdated = &lastUpdated
-	if err := api.CreateChangeLogRawErr(api.ApiChange, "DS: "+*ds.XMLID+", ID: "+strconv.Itoa(*ds.ID)+", ACTION: Created delivery service", user, tx); err != nil {
+	audit := api.Audit{Action: api.Created, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*ds.ID), After: ds}
+	if err := api.CreateAuditLog(api.ApiChange, "DS: "+*ds.XMLID+", ID: "+strconv.Itoa(*ds.ID)+", ACTION: Created delivery service", audit, inf); err != nil {
 		return nil, http.StatusInternalServerError, nil, errors.New("error writing to audit log: " + err.Error())
 	}
 
@@ -498,6 +508,11 @@ func createV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 t
 	return &dsV40, http.StatusOK, nil, nil
 }
 
+// CreateDeliveryService creates the given Delivery Service in the transaction of inf, exactly as a request to create it through the latest API version would, and returns the created Delivery Service.
+func CreateDeliveryService(inf *api.APIInfo, ds tc.DeliveryServiceV40) (*tc.DeliveryServiceV40, int, error, error) {
+	return createV40(nil, nil, inf, ds)
+}
+
 func createDefaultRegex(tx *sql.Tx, dsID int, xmlID string) error {
 	regexStr := `.*\.` + xmlID + `\..*`
 	regexID := 0
@@ -931,6 +946,14 @@ func updateV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 *
 		return nil, http.StatusBadRequest, errors.New("missing id"), nil
 	}
 
+	if ds.TenantID != nil {
+		globalMaxMBPS, userErr, sysErr, errCode := tenant.CheckDeliveryServiceQuotas(tx, *ds.TenantID, ds.ID, ds.GlobalMaxMBPS)
+		if userErr != nil || sysErr != nil {
+			return nil, errCode, userErr, sysErr
+		}
+		ds.GlobalMaxMBPS = globalMaxMBPS
+	}
+
 	dsType, ok, err := getDSType(tx, *ds.XMLID)
 	if !ok {
 		return nil, http.StatusNotFound, errors.New("delivery service '" + *ds.XMLID + "' not found"), nil
@@ -938,6 +961,7 @@ func updateV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 *
 	if err != nil {
 		return nil, http.StatusInternalServerError, nil, errors.New("getting delivery service type during update: " + err.Error())
 	}
+	before := getAuditDS(inf, *ds.ID)
 
 	errCode := http.StatusOK
 	var userErr error
@@ -1144,13 +1168,19 @@ func updateV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 *
 		return nil, code, usrErr, sysErr
 	}
 
-	if err := api.CreateChangeLogRawErr(api.ApiChange, "Updated ds: "+*ds.XMLID+" id: "+strconv.Itoa(*ds.ID), user, tx); err != nil {
+	audit := api.Audit{Action: api.Updated, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*ds.ID), Before: before, After: ds}
+	if err := api.CreateAuditLog(api.ApiChange, "Updated ds: "+*ds.XMLID+" id: "+strconv.Itoa(*ds.ID), audit, inf); err != nil {
 		return nil, http.StatusInternalServerError, nil, errors.New("writing change log entry: " + err.Error())
 	}
 	dsV40 = (*tc.DeliveryServiceV40)(&ds)
 	return dsV40, http.StatusOK, nil, nil
 }
 
+// UpdateDeliveryService updates the Delivery Service with the ID of the given Delivery Service in the transaction of inf, exactly as a request to update it through the latest API version would, and returns the updated Delivery Service.
+func UpdateDeliveryService(inf *api.APIInfo, ds *tc.DeliveryServiceV40) (*tc.DeliveryServiceV40, int, error, error) {
+	return updateV40(nil, &http.Request{Header: http.Header{}}, inf, ds)
+}
+
 //Delete is the DeliveryService implementation of the Deleter interface.
 func (ds *TODeliveryService) Delete() (error, error, int) {
 	if ds.ID == nil {
@@ -1197,6 +1227,19 @@ func (v *TODeliveryService) DeleteQuery() string {
 	return `DELETE FROM deliveryservice WHERE id = :id`
 }
 
+// getAuditDS returns the delivery service with the given ID as it is currently in the database, to be recorded in the audit log as it was before a change. It returns nil if the delivery service can't be read.
+func getAuditDS(inf *api.APIInfo, id int) interface{} {
+	dses, userErr, sysErr, _, _ := readGetDeliveryServices(http.Header{}, map[string]string{"id": strconv.Itoa(id)}, inf.Tx, inf.User, false)
+	if userErr != nil || sysErr != nil {
+		log.Warnf("reading delivery service %d for audit log: user error: %v, system error: %v\n", id, userErr, sysErr)
+		return nil
+	}
+	if len(dses) != 1 {
+		return nil
+	}
+	return dses[0]
+}
+
 func readGetDeliveryServices(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser, useIMS bool) ([]tc.DeliveryServiceV4, error, error, int, *time.Time) {
 	var maxTime time.Time
 	var runSecond bool
@@ -1269,6 +1312,12 @@ func readGetDeliveryServices(h http.Header, params map[string]string, tx *sqlx.T
 	return r, e1, e2, code, &maxTime
 }
 
+// GetCDNDeliveryServices returns the Delivery Services of the CDN with the given ID which are in the Tenancy of the user of inf.
+func GetCDNDeliveryServices(inf *api.APIInfo, cdnID int) ([]tc.DeliveryServiceV4, error, error, int) {
+	dses, userErr, sysErr, errCode, _ := readGetDeliveryServices(http.Header{}, map[string]string{"cdn": strconv.Itoa(cdnID)}, inf.Tx, inf.User, false)
+	return dses, userErr, sysErr, errCode
+}
+
 func requiredIfMatchesTypeName(patterns []string, typeName string) func(interface{}) error {
 	return func(value interface{}) error {
 		switch v := value.(type) {
@@ -31,15 +31,14 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
 
 	"github.com/miekg/dns"
 )
 
 func PutDNSSecKeys(tx *sql.Tx, cfg *config.Config, xmlID string, cdnName string, exampleURLs []string) (error, error, int) {
-	keys, ok, err := riaksvc.GetDNSSECKeys(cdnName, tx, cfg.RiakAuthOptions, cfg.RiakPort)
+	keys, ok, err := cfg.TrafficVault.GetDNSSECKeys(cdnName, tx)
 	if err != nil {
-		return nil, errors.New("getting DNSSec keys from Riak: " + err.Error()), http.StatusInternalServerError
+		return nil, errors.New("getting DNSSec keys from Traffic Vault: " + err.Error()), http.StatusInternalServerError
 	} else if !ok {
 		return fmt.Errorf("there are no DNSSec keys for the CDN %s which is required to create keys for the deliveryservice", cdnName), nil, http.StatusBadRequest
 	}
@@ -56,13 +55,14 @@ func PutDNSSecKeys(tx *sql.Tx, cfg *config.Config, xmlID string, cdnName string,
 		return nil, errors.New("creating DNSSEC keys for delivery service '" + xmlID + "': " + err.Error()), http.StatusInternalServerError
 	}
 	keys[xmlID] = dsKeys
-	if err := riaksvc.PutDNSSECKeys(keys, cdnName, tx, cfg.RiakAuthOptions, cfg.RiakPort); err != nil {
-		return nil, errors.New("putting Riak DNSSEC keys: " + err.Error()), http.StatusInternalServerError
+	if err := cfg.TrafficVault.PutDNSSECKeys(cdnName, keys, tx); err != nil {
+		return nil, errors.New("putting Traffic Vault DNSSEC keys: " + err.Error()), http.StatusInternalServerError
 	}
 	return nil, nil, http.StatusOK
 }
 
 // CreateDNSSECKeys creates DNSSEC keys for the given delivery service, updating existing keys if they exist. The overrideTTL parameter determines whether to reuse existing key TTLs if they exist, or to override existing TTLs with the ttl parameter's value.
+// The keys use the algorithm of the CDN's KSK.
 func CreateDNSSECKeys(tx *sql.Tx, cfg *config.Config, xmlID string, exampleURLs []string, cdnKeys tc.DNSSECKeySetV11, kskExpiration time.Duration, zskExpiration time.Duration, ttl time.Duration, overrideTTL bool) (tc.DNSSECKeySetV11, error) {
 	if len(cdnKeys.ZSK) == 0 {
 		return tc.DNSSECKeySetV11{}, errors.New("getting DNSSec keys from Riak: no DNSSec ZSK keys for CDN")
@@ -81,20 +81,27 @@ func CreateDNSSECKeys(tx *sql.Tx, cfg *config.Config, xmlID string, exampleURLs
 	zExpiration := inception.Add(zskExpiration)
 	kExpiration := inception.Add(kskExpiration)
 
+	algorithm, err := GetKeySetAlgorithm(cdnKeys.KSK)
+	if err != nil {
+		return tc.DNSSECKeySetV11{}, errors.New("getting CDN KSK algorithm: " + err.Error())
+	}
+
 	tld := false
 	effectiveDate := inception
-	zsk, err := GetDNSSECKeysV11(tc.DNSSECZSKType, dsName, ttl, inception, zExpiration, tc.DNSSECKeyStatusNew, effectiveDate, tld)
+	zsk, err := GetDNSSECKeysV11(tc.DNSSECZSKType, dsName, ttl, inception, zExpiration, tc.DNSSECKeyStatusNew, effectiveDate, tld, algorithm)
 	if err != nil {
 		return tc.DNSSECKeySetV11{}, errors.New("getting DNSSEC keys for ZSK: " + err.Error())
 	}
-	ksk, err := GetDNSSECKeysV11(tc.DNSSECKSKType, dsName, ttl, inception, kExpiration, tc.DNSSECKeyStatusNew, effectiveDate, tld)
+	ksk, err := GetDNSSECKeysV11(tc.DNSSECKSKType, dsName, ttl, inception, kExpiration, tc.DNSSECKeyStatusNew, effectiveDate, tld, algorithm)
 	if err != nil {
 		return tc.DNSSECKeySetV11{}, errors.New("getting DNSSEC keys for KSK: " + err.Error())
 	}
+	zsk.RolloverPhase = tc.DNSSECRolloverPhaseActive
+	ksk.RolloverPhase = tc.DNSSECRolloverPhaseActive
 	return tc.DNSSECKeySetV11{ZSK: []tc.DNSSECKeyV11{zsk}, KSK: []tc.DNSSECKeyV11{ksk}}, nil
 }
 
-func GetDNSSECKeysV11(keyType string, dsName string, ttl time.Duration, inception time.Time, expiration time.Time, status string, effectiveDate time.Time, tld bool) (tc.DNSSECKeyV11, error) {
+func GetDNSSECKeysV11(keyType string, dsName string, ttl time.Duration, inception time.Time, expiration time.Time, status string, effectiveDate time.Time, tld bool, algorithm uint8) (tc.DNSSECKeyV11, error) {
 	key := tc.DNSSECKeyV11{
 		InceptionDateUnix:  inception.Unix(),
 		ExpirationDateUnix: expiration.Unix(),
@@ -105,21 +112,32 @@ func GetDNSSECKeysV11(keyType string, dsName string, ttl time.Duration, inceptio
 	}
 	isKSK := keyType != tc.DNSSECZSKType
 	err := error(nil)
-	key.Public, key.Private, key.DSRecord, err = genKeys(dsName, isKSK, ttl, tld)
+	key.Public, key.Private, key.DSRecord, err = genKeys(dsName, isKSK, ttl, tld, algorithm)
 	return key, err
 }
 
 // genKeys generates keys for DNSSEC for a delivery service. Returns the public key, private key, and DS record (which will be nil if ksk or tld is false).
 // This emulates the old Perl Traffic Ops behavior: the public key is of the RFC1035 single-line zone file format, base64 encoded; the private key is of the BIND private-key-file format, base64 encoded; the DSRecord contains the algorithm, digest type, and digest.
-func genKeys(dsName string, ksk bool, ttl time.Duration, tld bool) (string, string, *tc.DNSSECKeyDSRecordV11, error) {
-	bits := 1024
+// The algorithm is the IANA number of the DNSSEC algorithm, see http://www.iana.org/assignments/dns-sec-alg-numbers/dns-sec-alg-numbers.xhtml
+func genKeys(dsName string, ksk bool, ttl time.Duration, tld bool, algorithm uint8) (string, string, *tc.DNSSECKeyDSRecordV11, error) {
+	bits := 0
 	flags := 256
-	algorithm := dns.RSASHA1 // 5 - http://www.iana.org/assignments/dns-sec-alg-numbers/dns-sec-alg-numbers.xhtml
 	protocol := 3
 
 	if ksk {
 		flags |= 1
-		bits *= 2
+	}
+
+	switch algorithm {
+	case dns.RSASHA1, dns.RSASHA256:
+		bits = 1024
+		if ksk {
+			bits *= 2
+		}
+	case dns.ECDSAP256SHA256, dns.ED25519:
+		bits = 256 // the key size is fixed by the curve
+	default:
+		return "", "", nil, fmt.Errorf("unsupported DNSSEC algorithm %d", algorithm)
 	}
 
 	// Note: currently, the Router appears to hard-code this in what it generates for the DS record (or at least the "Publish this" log message).
@@ -161,6 +179,49 @@ func genKeys(dsName string, ksk bool, ttl time.Duration, tld bool) (string, stri
 	return pubKeyStrBase64, priKeyStrBase64, keyDS, nil
 }
 
+// DNSSECAlgorithmNumber returns the IANA number of the DNSSEC algorithm with the given mnemonic, which must be one of the algorithms which may be selected for a CDN.
+func DNSSECAlgorithmNumber(name string) (uint8, error) {
+	if !tc.IsValidDNSSECAlgorithm(name) {
+		return 0, errors.New("unsupported DNSSEC algorithm '" + name + "'")
+	}
+	return dns.StringToAlgorithm[name], nil
+}
+
+// ParseDNSKEY parses the DNSKEY record of a key's public key, as stored in Traffic Vault.
+func ParseDNSKEY(key tc.DNSSECKeyV11) (*dns.DNSKEY, error) {
+	public := strings.Replace(key.Public, `\n`, "", -1) // the string slash-n, not a newline, see MakeDSRecordText
+	public = strings.Replace(public, "\n", "", -1)
+	pubKeyBts, err := base64.StdEncoding.DecodeString(public)
+	if err != nil {
+		return nil, errors.New("decoding public key base64: " + err.Error())
+	}
+	rr, err := dns.NewRR(string(pubKeyBts))
+	if err != nil {
+		return nil, errors.New("parsing public key: " + err.Error())
+	}
+	dnskey, ok := rr.(*dns.DNSKEY)
+	if !ok {
+		return nil, fmt.Errorf("public key is a %s record, not a DNSKEY", dns.TypeToString[rr.Header().Rrtype])
+	}
+	return dnskey, nil
+}
+
+// GetKeySetAlgorithm returns the algorithm of the "new" key among the given keys, which is the algorithm keys
+// generated to replace or accompany them must use.
+func GetKeySetAlgorithm(keys []tc.DNSSECKeyV11) (uint8, error) {
+	for _, key := range keys {
+		if key.Status != tc.DNSSECKeyStatusNew {
+			continue
+		}
+		dnskey, err := ParseDNSKEY(key)
+		if err != nil {
+			return 0, err
+		}
+		return dnskey.Algorithm, nil
+	}
+	return 0, errors.New("no new key")
+}
+
 // TODO change ttl to time.Duration
 
 func GetDSDomainName(dsExampleURLs []string) (string, error) {
@@ -0,0 +1,98 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/miekg/dns"
+)
+
+func TestGenKeysAlgorithms(t *testing.T) {
+	for _, name := range tc.DNSSECAlgorithms {
+		algorithm, err := DNSSECAlgorithmNumber(name)
+		if err != nil {
+			t.Fatalf("getting number of algorithm %s: %v", name, err)
+		}
+		for _, ksk := range []bool{false, true} {
+			keyType := tc.DNSSECZSKType
+			if ksk {
+				keyType = tc.DNSSECKSKType
+			}
+			key, err := GetDNSSECKeysV11(keyType, "cdn.example.net.", time.Minute, time.Now(), time.Now().Add(time.Hour), tc.DNSSECKeyStatusNew, time.Now(), true, algorithm)
+			if err != nil {
+				t.Fatalf("generating %s key (KSK: %t): %v", name, ksk, err)
+			}
+			dnskey, err := ParseDNSKEY(key)
+			if err != nil {
+				t.Fatalf("parsing %s key (KSK: %t): %v", name, ksk, err)
+			}
+			if dnskey.Algorithm != algorithm {
+				t.Errorf("%s key (KSK: %t): expected algorithm %d, actual %d", name, ksk, algorithm, dnskey.Algorithm)
+			}
+			if ksk != (dnskey.Flags&dns.SEP != 0) {
+				t.Errorf("%s key (KSK: %t): unexpected flags %d", name, ksk, dnskey.Flags)
+			}
+			if ksk && (key.DSRecord == nil || key.DSRecord.Algorithm != int64(algorithm)) {
+				t.Errorf("%s KSK: expected a DS record with algorithm %d, actual %+v", name, algorithm, key.DSRecord)
+			}
+			if !ksk && key.DSRecord != nil {
+				t.Errorf("%s ZSK: expected no DS record, actual %+v", name, key.DSRecord)
+			}
+		}
+	}
+}
+
+func TestDNSSECAlgorithmNumber(t *testing.T) {
+	if alg, err := DNSSECAlgorithmNumber(tc.DNSSECAlgorithmECDSAP256SHA256); err != nil || alg != dns.ECDSAP256SHA256 {
+		t.Errorf("expected %d, actual %d (error: %v)", dns.ECDSAP256SHA256, alg, err)
+	}
+	if _, err := DNSSECAlgorithmNumber("RSASHA1"); err == nil {
+		t.Error("expected an error for RSASHA1, actual nil")
+	}
+}
+
+func TestCreateDNSSECKeysUsesCDNAlgorithm(t *testing.T) {
+	cdnKSK, err := GetDNSSECKeysV11(tc.DNSSECKSKType, "cdn.example.net.", time.Minute, time.Now(), time.Now().Add(time.Hour), tc.DNSSECKeyStatusNew, time.Now(), true, dns.ED25519)
+	if err != nil {
+		t.Fatalf("generating CDN KSK: %v", err)
+	}
+	cdnZSK, err := GetDNSSECKeysV11(tc.DNSSECZSKType, "cdn.example.net.", time.Minute, time.Now(), time.Now().Add(time.Hour), tc.DNSSECKeyStatusNew, time.Now(), false, dns.ED25519)
+	if err != nil {
+		t.Fatalf("generating CDN ZSK: %v", err)
+	}
+	cdnKeys := tc.DNSSECKeySetV11{ZSK: []tc.DNSSECKeyV11{cdnZSK}, KSK: []tc.DNSSECKeyV11{cdnKSK}}
+
+	dsKeys, err := CreateDNSSECKeys(nil, nil, "ds1", []string{"ds1.cdn.example.net"}, cdnKeys, time.Hour, time.Hour, time.Minute, true)
+	if err != nil {
+		t.Fatalf("creating delivery service keys: %v", err)
+	}
+	for _, key := range append(dsKeys.ZSK, dsKeys.KSK...) {
+		if alg, err := GetKeySetAlgorithm([]tc.DNSSECKeyV11{key}); err != nil || alg != dns.ED25519 {
+			t.Errorf("expected delivery service key algorithm %d, actual %d (error: %v)", dns.ED25519, alg, err)
+		}
+		if key.RolloverPhase != tc.DNSSECRolloverPhaseActive {
+			t.Errorf("expected delivery service key rollover phase %s, actual %s", tc.DNSSECRolloverPhaseActive, key.RolloverPhase)
+		}
+	}
+}
@@ -120,8 +120,30 @@ func GetServersEligible(w http.ResponseWriter, r *http.Request) {
 }
 
 func getEligibleServers(tx *sql.Tx, dsID int) ([]tc.DSServerV4, error) {
+	cdnID := 0
+	requiredCapabilities := []string{}
+	if err := tx.QueryRow(`
+SELECT ds.cdn_id,
+ARRAY(SELECT drc.required_capability FROM deliveryservices_required_capability drc WHERE drc.deliveryservice_id = ds.id ORDER BY drc.required_capability)
+FROM deliveryservice ds
+WHERE ds.id = $1
+`, dsID).Scan(&cdnID, pq.Array(&requiredCapabilities)); err != nil {
+		return nil, errors.New("querying delivery service CDN and required capabilities: " + err.Error())
+	}
+	return GetEligibleServers(tx, cdnID, requiredCapabilities, []string{tc.EdgeTypePrefix, tc.OriginTypeName})
+}
+
+// GetEligibleServers returns the servers in the CDN with the given ID whose
+// Types begin with one of the given prefixes, and that could serve a Delivery
+// Service requiring the given Server Capabilities. Origins are always
+// eligible, regardless of their capabilities.
+func GetEligibleServers(tx *sql.Tx, cdnID int, requiredCapabilities []string, typePrefixes []string) ([]tc.DSServerV4, error) {
+	typePatterns := make([]string, 0, len(typePrefixes))
+	for _, prefix := range typePrefixes {
+		typePatterns = append(typePatterns, prefix+"%")
+	}
 	queryFormatString := `
-WITH ds_id as (SELECT $1::bigint as v)
+WITH eligible as (SELECT $1::bigint as cdn_id, $2::text[] as required_capabilities, $3::text[] as type_patterns)
 SELECT
 s.id
 %s
@@ -134,8 +156,8 @@ JOIN status st ON s.status = st.id
 JOIN type t ON s.type = t.id
 %s`
 	queryWhereClause := `
-WHERE s.cdn_id = (SELECT cdn_id from deliveryservice where id = (select v from ds_id))
-	AND (t.name LIKE 'EDGE%' OR t.name LIKE 'ORG%')
+WHERE s.cdn_id = (SELECT cdn_id FROM eligible)
+	AND t.name LIKE ANY(SELECT unnest(type_patterns) FROM eligible)
 `
 	dataFetchQuery := `, 
 cg.name as cachegroup,
@@ -169,9 +191,9 @@ t.name as server_type,
 s.type as server_type_id,
 s.upd_pending as upd_pending,
 ARRAY(select ssc.server_capability from server_server_capability ssc where ssc.server = s.id order by ssc.server_capability) as server_capabilities,
-ARRAY(select drc.required_capability from deliveryservices_required_capability drc where drc.deliveryservice_id = (select v from ds_id) order by drc.required_capability) as deliveryservice_capabilities
+(SELECT required_capabilities FROM eligible) as deliveryservice_capabilities
 `
-	idRows, err := tx.Query(fmt.Sprintf(queryFormatString, "", queryWhereClause), dsID)
+	idRows, err := tx.Query(fmt.Sprintf(queryFormatString, "", queryWhereClause), cdnID, pq.Array(requiredCapabilities), pq.Array(typePatterns))
 	if err != nil {
 		return nil, errors.New("querying delivery service eligible servers: " + err.Error())
 	}
@@ -190,7 +212,7 @@ ARRAY(select drc.required_capability from deliveryservices_required_capability d
 		return nil, errors.New("unable to get server interfaces: " + err.Error())
 	}
 
-	rows, err := tx.Query(fmt.Sprintf(queryFormatString, dataFetchQuery, queryWhereClause), dsID)
+	rows, err := tx.Query(fmt.Sprintf(queryFormatString, dataFetchQuery, queryWhereClause), cdnID, pq.Array(requiredCapabilities), pq.Array(typePatterns))
 	if err != nil {
 		return nil, errors.New("querying delivery service eligible servers: " + err.Error())
 	}
@@ -249,7 +271,7 @@ ARRAY(select drc.required_capability from deliveryservices_required_capability d
 
 		eligible := true
 
-		if !strings.HasPrefix(s.Type, "ORG") {
+		if !strings.HasPrefix(s.Type, tc.OriginTypeName) {
 			for _, dsc := range s.DeliveryServiceCapabilities {
 				if !util.ContainsStr(s.ServerCapabilities, dsc) {
 					eligible = false
@@ -40,11 +40,14 @@ func TestGetEligibleServers(t *testing.T) {
 
 	mock.ExpectBegin()
 
+	dsRows := sqlmock.NewRows([]string{"cdn_id", "required_capabilities"}).AddRow(1, []byte(`{""}`))
+	mock.ExpectQuery("SELECT ds.cdn_id").WithArgs(1).WillReturnRows(dsRows)
+
 	idRows := sqlmock.NewRows([]string{"id"})
 	for _, s := range testServers {
 		idRows = idRows.AddRow(*s.ID)
 	}
-	mock.ExpectQuery("SELECT s.id FROM server s (.+)").WithArgs(1).WillReturnRows(idRows)
+	mock.ExpectQuery("SELECT s.id FROM server s (.+)").WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(idRows)
 
 	for _, s := range testServers {
 		testInterfaces := createServerIntefaces(*s.ID)
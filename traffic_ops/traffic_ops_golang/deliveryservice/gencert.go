@@ -21,6 +21,9 @@ package deliveryservice
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -28,19 +31,86 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
+	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-util"
 )
 
 const NewCertValidDuration = time.Hour * 24 * 365
 
-// GenerateCert generates a key and certificate for serving HTTPS. The generated key is 2048-bit RSA, to match the old Perl code.
+// GenerateKey generates a private key of the given algorithm, which must be one of the tc.SSLKeyAlgorithm constants.
+func GenerateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case tc.SSLKeyAlgorithmRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case tc.SSLKeyAlgorithmRSA3072:
+		return rsa.GenerateKey(rand.Reader, 3072)
+	case tc.SSLKeyAlgorithmRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	case tc.SSLKeyAlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case tc.SSLKeyAlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	}
+	return nil, errors.New("unknown key algorithm '" + algorithm + "'")
+}
+
+// EncodePrivateKey returns the PEM encoding of the given RSA or ECDSA private key. RSA keys are PKCS#1 and ECDSA keys are SEC 1, which are the formats accepted by the SSL keys validation.
+func EncodePrivateKey(priv crypto.Signer) ([]byte, error) {
+	block := &pem.Block{}
+	switch key := priv.(type) {
+	case *rsa.PrivateKey:
+		block.Type = "RSA PRIVATE KEY"
+		block.Bytes = x509.MarshalPKCS1PrivateKey(key)
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, errors.New("marshalling EC private key: " + err.Error())
+		}
+		block.Type = "EC PRIVATE KEY"
+		block.Bytes = der
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", priv)
+	}
+	if block.Bytes == nil {
+		return nil, errors.New("marshalling private key: nil der")
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+// GenerateCSR creates a PEM-encoded certificate signing request for the given subject and DNS names, signed by priv. The signature algorithm is chosen to match the key.
+func GenerateCSR(priv crypto.Signer, subj pkix.Name, dnsNames []string) ([]byte, error) {
+	crtReq := x509.CertificateRequest{
+		Subject:  subj,
+		DNSNames: dnsNames,
+	}
+	csrDer, err := x509.CreateCertificateRequest(rand.Reader, &crtReq, priv)
+	if err != nil {
+		return nil, errors.New("creating certificate request: " + err.Error())
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDer}), nil
+}
+
+// MakeCertSubject returns the subject of a Delivery Service certificate.
+func MakeCertSubject(host, country, city, state, org, unit string) pkix.Name {
+	return pkix.Name{
+		CommonName:         host,
+		Country:            []string{country},
+		Province:           []string{state},
+		Locality:           []string{city},
+		Organization:       []string{org},
+		OrganizationalUnit: []string{unit},
+	}
+}
+
+// GenerateCert generates a key and self-signed certificate for serving HTTPS. The key is of the given algorithm, which must be one of the tc.SSLKeyAlgorithm constants.
 // The certificate will be valid for NewCertValidDuration time after now.
 // Returns PEM-encoded certificate signing request (csr), certificate (crt), and key; or any error.
-func GenerateCert(host, country, city, state, org, unit string) ([]byte, []byte, []byte, error) {
-	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+func GenerateCert(host, country, city, state, org, unit, keyAlgorithm string) ([]byte, []byte, []byte, error) {
+	priv, err := GenerateKey(keyAlgorithm)
 	if err != nil {
 		return nil, nil, nil, errors.New("generating key: " + err.Error())
 	}
@@ -52,13 +122,12 @@ func GenerateCert(host, country, city, state, org, unit string) ([]byte, []byte,
 		return nil, nil, nil, errors.New("getting random int for serial number: " + err.Error())
 	}
 
-	subj := pkix.Name{
-		CommonName:         host,
-		Country:            []string{country},
-		Province:           []string{state},
-		Locality:           []string{city},
-		Organization:       []string{org},
-		OrganizationalUnit: []string{unit},
+	subj := MakeCertSubject(host, country, city, state, org, unit)
+
+	// ECDSA keys only sign, so only RSA certificates need key encipherment.
+	keyUsage := x509.KeyUsageDigitalSignature
+	if _, ok := priv.(*rsa.PrivateKey); ok {
+		keyUsage |= x509.KeyUsageKeyEncipherment
 	}
 
 	crt := x509.Certificate{
@@ -66,20 +135,14 @@ func GenerateCert(host, country, city, state, org, unit string) ([]byte, []byte,
 		Subject:               subj,
 		NotBefore:             now,
 		NotAfter:              expires,
-		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		KeyUsage:              keyUsage,
 		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 		BasicConstraintsValid: true,
 		DNSNames:              []string{host},
 		Version:               1,
 	}
 
-	crtReq := x509.CertificateRequest{
-		Subject:            subj,
-		SignatureAlgorithm: x509.SHA256WithRSA,
-		Version:            1,
-	}
-
-	crtDer, err := x509.CreateCertificate(rand.Reader, &crt, &crt, &priv.PublicKey, priv)
+	crtDer, err := x509.CreateCertificate(rand.Reader, &crt, &crt, priv.Public(), priv)
 	if err != nil {
 		return nil, nil, nil, errors.New("creating certificate: " + err.Error())
 	}
@@ -89,25 +152,15 @@ func GenerateCert(host, country, city, state, org, unit string) ([]byte, []byte,
 	}
 	crtPem := crtBuf.Bytes()
 
-	csrDer, err := x509.CreateCertificateRequest(rand.Reader, &crtReq, priv)
+	csrPem, err := GenerateCSR(priv, subj, nil)
 	if err != nil {
-		return nil, nil, nil, errors.New("creating certificate request: " + err.Error())
-	}
-	csrBuf := bytes.Buffer{}
-	if err := pem.Encode(&csrBuf, &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDer}); err != nil {
-		return nil, nil, nil, errors.New("pem-encoding certificate request: " + err.Error())
+		return nil, nil, nil, err
 	}
-	csrPem := csrBuf.Bytes()
 
-	keyDer := x509.MarshalPKCS1PrivateKey(priv)
-	if keyDer == nil {
-		return nil, nil, nil, errors.New("marshalling private key: nil der")
-	}
-	keyBuf := bytes.Buffer{}
-	if err := pem.Encode(&keyBuf, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: keyDer}); err != nil {
-		return nil, nil, nil, errors.New("pem-encoding private key: " + err.Error())
+	keyPem, err := EncodePrivateKey(priv)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	keyPem := keyBuf.Bytes()
 
 	return EncodePEMToLegacyPerlRiakFormat(csrPem), EncodePEMToLegacyPerlRiakFormat(crtPem), EncodePEMToLegacyPerlRiakFormat(keyPem), nil
 }
@@ -0,0 +1,117 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AcmeHTTPChallengeDelay is how long a newly presented ACME HTTP-01 challenge is given to propagate to Traffic Routers, before the ACME server is asked to validate it.
+// Traffic Routers answering HTTP-01 challenges must poll the ACME HTTP challenges endpoint more often than this.
+const AcmeHTTPChallengeDelay = time.Minute
+
+// HTTPProviderTrafficRouter is a lego challenge.Provider which stores ACME HTTP-01 challenges in the Traffic Ops database, from which Traffic Routers fetch and answer them.
+type HTTPProviderTrafficRouter struct {
+	db    *sqlx.DB
+	delay time.Duration
+}
+
+func NewHTTPProviderTrafficRouter(db *sqlx.DB) *HTTPProviderTrafficRouter {
+	return &HTTPProviderTrafficRouter{db: db, delay: AcmeHTTPChallengeDelay}
+}
+
+func (h *HTTPProviderTrafficRouter) Present(domain, token, keyAuth string) error {
+	q := `
+INSERT INTO acme_http_challenge (domain, token, key_authorization) VALUES ($1, $2, $3)
+ON CONFLICT (domain, token) DO UPDATE SET key_authorization = $3
+`
+	if _, err := h.db.Exec(q, domain, token, keyAuth); err != nil {
+		log.Errorf("inserting acme http challenge for domain '" + domain + "' token '" + token + "': " + err.Error())
+		return errors.New("inserting acme http challenge for domain '" + domain + "' token '" + token + "': " + err.Error())
+	}
+	time.Sleep(h.delay)
+	return nil
+}
+
+func (h *HTTPProviderTrafficRouter) CleanUp(domain, token, keyAuth string) error {
+	if _, err := h.db.Exec(`DELETE FROM acme_http_challenge WHERE domain = $1 AND token = $2`, domain, token); err != nil {
+		log.Errorf("deleting acme http challenge for domain '" + domain + "' token '" + token + "': " + err.Error())
+		return errors.New("deleting acme http challenge for domain '" + domain + "' token '" + token + "': " + err.Error())
+	}
+	return nil
+}
+
+// GetHTTPChallenges returns the pending ACME HTTP-01 challenges, which Traffic Routers answer at tc.AcmeHTTPChallengePathPrefix.
+func GetHTTPChallenges(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	getQuery := `SELECT domain, token, key_authorization, last_updated FROM acme_http_challenge`
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"domain": dbhelpers.WhereColumnInfo{Column: "domain"},
+		"token":  dbhelpers.WhereColumnInfo{Column: "token"},
+	}
+
+	where, _, _, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+	getQuery += where
+
+	challenges, err := getHTTPChallenges(inf.Tx, getQuery, queryValues)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting acme http challenges: "+err.Error()))
+		return
+	}
+	api.WriteResp(w, r, challenges)
+}
+
+func getHTTPChallenges(tx *sqlx.Tx, getQuery string, queryValues map[string]interface{}) ([]tc.AcmeHTTPChallenge, error) {
+	challenges := []tc.AcmeHTTPChallenge{}
+	rows, err := tx.NamedQuery(getQuery, queryValues)
+	if err != nil {
+		return nil, errors.New("querying acme http challenges: " + err.Error())
+	}
+	defer rows.Close()
+	for rows.Next() {
+		challenge := tc.AcmeHTTPChallenge{}
+		if err := rows.StructScan(&challenge); err != nil {
+			return nil, errors.New("scanning acme http challenges: " + err.Error())
+		}
+		challenges = append(challenges, challenge)
+	}
+	return challenges, nil
+}
@@ -0,0 +1,91 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/challenge"
+	"github.com/jmoiron/sqlx"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestHTTPProviderTrafficRouterPresentCleanUp(t *testing.T) {
+	var _ challenge.Provider = &HTTPProviderTrafficRouter{}
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	provider := NewHTTPProviderTrafficRouter(db)
+	provider.delay = 0
+
+	mock.ExpectExec("INSERT INTO acme_http_challenge").WithArgs("ds.example.net", "tok", "tok.thumbprint").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM acme_http_challenge").WithArgs("ds.example.net", "tok").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := provider.Present("ds.example.net", "tok", "tok.thumbprint"); err != nil {
+		t.Errorf("expected Present to succeed, actual: %v", err)
+	}
+	if err := provider.CleanUp("ds.example.net", "tok", "tok.thumbprint"); err != nil {
+		t.Errorf("expected CleanUp to succeed, actual: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestGetHTTPChallenges(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"domain", "token", "key_authorization", "last_updated"})
+	rows.AddRow("ds.example.net", "tok", "tok.thumbprint", now)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT domain, token, key_authorization, last_updated FROM acme_http_challenge").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin()
+	challenges, err := getHTTPChallenges(tx, `SELECT domain, token, key_authorization, last_updated FROM acme_http_challenge`, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("getting http challenges: %v", err)
+	}
+	tx.Commit()
+
+	if len(challenges) != 1 {
+		t.Fatalf("expected 1 challenge, actual: %d", len(challenges))
+	}
+	if challenges[0].Domain != "ds.example.net" || challenges[0].Token != "tok" || challenges[0].KeyAuthorization != "tok.thumbprint" {
+		t.Errorf("expected challenge ds.example.net/tok/tok.thumbprint, actual: %+v", challenges[0])
+	}
+}
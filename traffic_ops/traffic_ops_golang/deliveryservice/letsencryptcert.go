@@ -25,6 +25,7 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"database/sql"
 	"errors"
 	"net/http"
 	"strconv"
@@ -37,7 +38,6 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 
 	"github.com/go-acme/lego/certificate"
@@ -177,6 +177,15 @@ func GenerateLetsEncryptCertificates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.ChallengeType == nil {
+		challengeType := tc.DefaultAcmeChallengeType
+		req.ChallengeType = &challengeType
+	}
+	if userErr, sysErr, errCode := checkAcmeChallengeTypeAllowed(*req.DeliveryService, *req.ChallengeType, inf.Tx.Tx); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
 	go GetLetsEncryptCertificates(inf.Config, req, ctx, inf.User)
 
 	api.WriteRespAlert(w, r, tc.SuccessLevel, "Beginning async call to Let's Encrypt for "+*req.DeliveryService+". This may take a few minutes.")
@@ -241,7 +250,12 @@ func GetLetsEncryptCertificates(cfg *config.Config, req tc.DeliveryServiceLetsEn
 		letsEncryptAccount.AcmeUrl = lego.LEDirectoryProduction // provides certificate signed by valid LE authority
 	}
 
-	client, err := GetAcmeClient(&letsEncryptAccount, userTx, db)
+	challengeType := tc.DefaultAcmeChallengeType
+	if req.ChallengeType != nil {
+		challengeType = *req.ChallengeType
+	}
+
+	client, err := GetAcmeClient(&letsEncryptAccount, challengeType, userTx, db)
 	if err != nil {
 		log.Errorf(deliveryService+": Error getting acme client: %s", err.Error())
 		api.CreateChangeLogRawTx(api.ApiChange, "DS: "+deliveryService+", ID: "+strconv.Itoa(dsID)+", ACTION: FAILED to add SSL keys with "+letsEncryptAccount.AcmeProvider, currentUser, logTx)
@@ -267,14 +281,15 @@ func GetLetsEncryptCertificates(cfg *config.Config, req tc.DeliveryServiceLetsEn
 		return err
 	}
 
-	// Save certs into Riak
+	// Save certs into Traffic Vault
 	dsSSLKeys := tc.DeliveryServiceSSLKeys{
-		AuthType:        tc.LetsEncryptAuthType,
-		CDN:             *req.CDN,
-		DeliveryService: *req.DeliveryService,
-		Key:             *req.DeliveryService,
-		Hostname:        *req.HostName,
-		Version:         *req.Version,
+		AuthType:          tc.LetsEncryptAuthType,
+		CDN:               *req.CDN,
+		DeliveryService:   *req.DeliveryService,
+		Key:               *req.DeliveryService,
+		Hostname:          *req.HostName,
+		Version:           *req.Version,
+		AcmeChallengeType: challengeType,
 	}
 
 	keyPem, err := ConvertPrivateKeyToKeyPem(priv)
@@ -293,10 +308,10 @@ func GetLetsEncryptCertificates(cfg *config.Config, req tc.DeliveryServiceLetsEn
 		CSR: string(EncodePEMToLegacyPerlRiakFormat([]byte("Lets Encrypt Generated"))),
 	}
 
-	if err := riaksvc.PutDeliveryServiceSSLKeysObj(dsSSLKeys, tx, cfg.RiakAuthOptions, cfg.RiakPort); err != nil {
-		log.Errorf("Error posting lets encrypt certificate to riak: %s", err.Error())
+	if err := cfg.TrafficVault.PutDeliveryServiceSSLKeys(dsSSLKeys, tx); err != nil {
+		log.Errorf("Error posting lets encrypt certificate to Traffic Vault: %s", err.Error())
 		api.CreateChangeLogRawTx(api.ApiChange, "DS: "+*req.DeliveryService+", ID: "+strconv.Itoa(dsID)+", ACTION: FAILED to add SSL keys with Lets Encrypt", currentUser, logTx)
-		return errors.New(deliveryService + ": putting riak keys: " + err.Error())
+		return errors.New(deliveryService + ": putting Traffic Vault keys: " + err.Error())
 	}
 
 	tx2, err := db.Begin()
@@ -315,3 +330,21 @@ func GetLetsEncryptCertificates(cfg *config.Config, req tc.DeliveryServiceLetsEn
 
 	return nil
 }
+
+// checkAcmeChallengeTypeAllowed returns a user error if the given ACME challenge type may not be used by the given Delivery Service.
+// HTTP-01 challenges are only permitted for HTTP Delivery Services, because Traffic Router only answers HTTP requests for those.
+func checkAcmeChallengeTypeAllowed(xmlID string, challengeType string, tx *sql.Tx) (error, error, int) {
+	if challengeType != tc.AcmeChallengeTypeHTTP01 {
+		return nil, nil, http.StatusOK
+	}
+	dsType, ok, err := getDSType(tx, xmlID)
+	if err != nil {
+		return nil, errors.New("getting delivery service type: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return errors.New("no DS with name " + xmlID), nil, http.StatusNotFound
+	}
+	if !dsType.IsHTTP() {
+		return errors.New("challengeType " + challengeType + " is only supported for HTTP delivery services"), nil, http.StatusBadRequest
+	}
+	return nil, nil, http.StatusOK
+}
@@ -0,0 +1,380 @@
+package request
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+)
+
+const selectApprovalsQuery = `
+SELECT a.approver_id,
+	u.username,
+	a.comment,
+	a.created_at
+FROM deliveryservice_request_approval AS a
+JOIN tm_user AS u ON u.id = a.approver_id
+WHERE a.deliveryservice_request = $1
+ORDER BY a.created_at, u.username
+`
+
+const insertApprovalQuery = `
+INSERT INTO deliveryservice_request_approval (deliveryservice_request, approver_id, comment)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+`
+
+// GetApprovals is the handler for GET requests to /deliveryservice_requests/{{ID}}/approvals.
+func GetApprovals(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	dsr, userErr, sysErr, errCode := getAuthorizedDSR(inf, inf.IntParams["id"], false)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	policy, err := getApplicablePolicy(tx, dsr)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	approvals, err := getApprovals(tx, *dsr.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, makeApprovals(dsr, policy, approvals))
+}
+
+// PostApproval is the handler for POST requests to /deliveryservice_requests/{{ID}}/approvals, which record the
+// current user's approval of a submitted request. Once a request has the number of approvals its policy requires, the
+// change it requests is made, in the same transaction.
+func PostApproval(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.DeliveryServiceRequestApprovalRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+
+	dsr, userErr, sysErr, errCode := getAuthorizedDSR(inf, inf.IntParams["id"], true)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if dsr.Status != tc.RequestStatusSubmitted {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("only %s Delivery Service Requests can be approved", tc.RequestStatusSubmitted), nil)
+		return
+	}
+	policy, err := getApplicablePolicy(tx, dsr)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if policy == nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("no approval policy applies to this Delivery Service Request; its status can be changed directly"), nil)
+		return
+	}
+
+	roleName := ""
+	if err := tx.QueryRow(`SELECT name FROM role WHERE id = $1`, inf.User.Role).Scan(&roleName); err != nil && err != sql.ErrNoRows {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting role of approver: "+err.Error()))
+		return
+	}
+	if userErr := checkApprover(*policy, dsr, inf.User.ID, roleName); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusForbidden, userErr, nil)
+		return
+	}
+
+	if res, err := tx.Exec(insertApprovalQuery, *dsr.ID, inf.User.ID, req.Comment); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	} else if rows, err := res.RowsAffected(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking inserted approval: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, tx, http.StatusConflict, errors.New("you have already approved this Delivery Service Request"), nil)
+		return
+	}
+
+	approvals, err := getApprovals(tx, *dsr.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	var cdnName *string
+	var tenantID *int
+	if dsr.DeliveryService != nil {
+		cdnName = dsr.DeliveryService.CDNName
+		tenantID = dsr.DeliveryService.TenantID
+	}
+	message := fmt.Sprintf("Approved '%s' Delivery Service Request (%d of %d approvals)", dsr.XMLID, len(approvals), *policy.RequiredApprovals)
+	audit := api.Audit{Action: tc.DSRActionApproved, ObjectType: tc.DSRWebhookObjectType, ObjectID: strconv.Itoa(*dsr.ID), CDNName: cdnName, TenantID: tenantID, After: makeApprovals(dsr, policy, approvals)}
+	if err := api.CreateAuditLog(api.ApiChange, fmt.Sprintf("Delivery Service Request: %d, ID: %d, ACTION: %s", *dsr.ID, *dsr.ID, message), audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	if len(approvals) >= *policy.RequiredApprovals {
+		status, userErr, sysErr, errCode := applyDSR(inf, &dsr)
+		if userErr != nil || sysErr != nil {
+			if userErr != nil {
+				userErr = errors.New("applying approved Delivery Service Request: " + userErr.Error())
+			}
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		if err := tx.QueryRow(updateStatusQuery, status, inf.User.ID, *dsr.ID).Scan(&dsr.LastUpdated); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("updating DSR #%d status: %v", *dsr.ID, err))
+			return
+		}
+		dsr.Status = status
+		message = fmt.Sprintf("%s; it was applied, and its status is now '%s'", message, status)
+		audit := api.Audit{Action: tc.DSRActionApplied, ObjectType: tc.DSRWebhookObjectType, ObjectID: strconv.Itoa(*dsr.ID), CDNName: cdnName, TenantID: tenantID, After: dsr}
+		if err := api.CreateAuditLog(api.ApiChange, fmt.Sprintf("Delivery Service Request: %d, ID: %d, ACTION: Applied '%s' Delivery Service Request", *dsr.ID, *dsr.ID, dsr.XMLID), audit, inf); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+	}
+
+	notifyAssignee(inf, dsr, message)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, message, makeApprovals(dsr, policy, approvals))
+}
+
+// getAuthorizedDSR returns the Delivery Service Request with the given ID, if it exists and the user is authorized on
+// its Delivery Service's Tenant. If lock is true, the request is locked until the transaction ends, so concurrent
+// approvals don't both apply it.
+func getAuthorizedDSR(inf *api.APIInfo, id int, lock bool) (tc.DeliveryServiceRequestV40, error, error, int) {
+	qry := selectQuery + "WHERE r.id=$1"
+	if lock {
+		qry += " FOR UPDATE OF r"
+	}
+	var dsr tc.DeliveryServiceRequestV40
+	if err := inf.Tx.QueryRowx(qry, id).StructScan(&dsr); err == sql.ErrNoRows {
+		return dsr, fmt.Errorf("no such Delivery Service Request: %d", id), nil, http.StatusNotFound
+	} else if err != nil {
+		return dsr, nil, fmt.Errorf("looking for DSR: %v", err), http.StatusInternalServerError
+	}
+	dsr.SetXMLID()
+
+	authorized, err := isTenantAuthorized(dsr, inf)
+	if err != nil {
+		return dsr, nil, err, http.StatusInternalServerError
+	} else if !authorized {
+		return dsr, errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+	}
+	return dsr, nil, nil, http.StatusOK
+}
+
+// getApprovals returns the approvals of the Delivery Service Request with the given ID, in the order they were made.
+func getApprovals(tx *sql.Tx, dsrID int) ([]tc.DeliveryServiceRequestApproval, error) {
+	rows, err := tx.Query(selectApprovalsQuery, dsrID)
+	if err != nil {
+		return nil, errors.New("querying DSR approvals: " + err.Error())
+	}
+	defer rows.Close()
+	approvals := []tc.DeliveryServiceRequestApproval{}
+	for rows.Next() {
+		a := tc.DeliveryServiceRequestApproval{}
+		if err := rows.Scan(&a.ApproverID, &a.Approver, &a.Comment, &a.CreatedAt); err != nil {
+			return nil, errors.New("scanning DSR approvals: " + err.Error())
+		}
+		approvals = append(approvals, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("querying DSR approvals: " + err.Error())
+	}
+	return approvals, nil
+}
+
+// deleteApprovals deletes the approvals of the Delivery Service Request with the given ID, because it was changed after
+// they were made.
+func deleteApprovals(tx *sql.Tx, dsrID int) error {
+	if _, err := tx.Exec(`DELETE FROM deliveryservice_request_approval WHERE deliveryservice_request = $1`, dsrID); err != nil {
+		return errors.New("deleting DSR approvals: " + err.Error())
+	}
+	return nil
+}
+
+// makeApprovals returns the approval state of the given request, under the given policy, which may be nil.
+func makeApprovals(dsr tc.DeliveryServiceRequestV40, policy *tc.DSRApprovalPolicy, approvals []tc.DeliveryServiceRequestApproval) tc.DeliveryServiceRequestApprovals {
+	a := tc.DeliveryServiceRequestApprovals{
+		Status:    dsr.Status,
+		Approvals: approvals,
+	}
+	if dsr.ID != nil {
+		a.DeliveryServiceRequestID = *dsr.ID
+	}
+	if policy != nil {
+		a.PolicyID = policy.ID
+		if policy.RequiredApprovals != nil {
+			a.RequiredApprovals = *policy.RequiredApprovals
+		}
+	}
+	return a
+}
+
+// checkApprover returns an error suitable for the user if the user with the given ID and Role may not approve the given
+// request under the given policy. Unless the policy allows self-approval, neither the request's author nor its last
+// editor - who submitted it, or changed it since it was submitted - may approve it.
+func checkApprover(policy tc.DSRApprovalPolicy, dsr tc.DeliveryServiceRequestV40, userID int, roleName string) error {
+	if policy.AllowSelfApproval == nil || !*policy.AllowSelfApproval {
+		if dsr.AuthorID != nil && *dsr.AuthorID == userID {
+			return errors.New("the author of a Delivery Service Request can't approve it")
+		}
+		if dsr.LastEditedByID != nil && *dsr.LastEditedByID == userID {
+			return errors.New("the last editor of a Delivery Service Request can't approve it")
+		}
+	}
+	if len(policy.ApproverRoles) == 0 {
+		return nil
+	}
+	for _, role := range policy.ApproverRoles {
+		if role == roleName {
+			return nil
+		}
+	}
+	return fmt.Errorf("users with the role '%s' can't approve this Delivery Service Request", roleName)
+}
+
+// requiresApproval returns an error suitable for the user if a change of the given request's status to the given
+// status must be made by approving it instead, because an approval policy applies to it.
+func requiresApproval(tx *sql.Tx, dsr tc.DeliveryServiceRequestV40, to tc.RequestStatus) (error, error) {
+	if dsr.Status != tc.RequestStatusSubmitted || (to != tc.RequestStatusPending && to != tc.RequestStatusComplete) {
+		return nil, nil
+	}
+	policy, err := getApplicablePolicy(tx, dsr)
+	if err != nil {
+		return nil, err
+	} else if policy == nil {
+		return nil, nil
+	}
+	return fmt.Errorf("this Delivery Service Request needs %d approvals under approval policy #%d, and is applied once it has them", *policy.RequiredApprovals, *policy.ID), nil
+}
+
+// applyDSR makes the change requested by the given request, and returns the status the request moves to: complete for
+// deletions, which take effect immediately, or pending for creations and updates, which still need to be deployed to
+// the CDN. The Delivery Service of a creation request is replaced by the created Delivery Service, so the request
+// records its ID.
+func applyDSR(inf *api.APIInfo, dsr *tc.DeliveryServiceRequestV40) (tc.RequestStatus, error, error, int) {
+	if dsr.DeliveryService == nil {
+		return tc.RequestStatusInvalid, errors.New("request has no Delivery Service"), nil, http.StatusBadRequest
+	}
+	ds := tc.DeliveryServiceV40(*dsr.DeliveryService)
+
+	switch dsr.ChangeType {
+	case tc.DSRChangeTypeCreate:
+		ds.ID = nil
+		res, errCode, userErr, sysErr := deliveryservice.CreateDeliveryService(inf, ds)
+		if userErr != nil || sysErr != nil {
+			return tc.RequestStatusInvalid, userErr, sysErr, errCode
+		}
+		created := tc.DeliveryServiceV4(*res)
+		dsr.DeliveryService = &created
+		if _, err := inf.Tx.Tx.Exec(`UPDATE deliveryservice_request SET deliveryservice = $1 WHERE id = $2`, dsr.DeliveryService, *dsr.ID); err != nil {
+			return tc.RequestStatusInvalid, nil, errors.New("recording created Delivery Service in DSR: " + err.Error()), http.StatusInternalServerError
+		}
+		return tc.RequestStatusPending, nil, nil, http.StatusOK
+	case tc.DSRChangeTypeUpdate:
+		if _, errCode, userErr, sysErr := deliveryservice.UpdateDeliveryService(inf, &ds); userErr != nil || sysErr != nil {
+			return tc.RequestStatusInvalid, userErr, sysErr, errCode
+		}
+		return tc.RequestStatusPending, nil, nil, http.StatusOK
+	case tc.DSRChangeTypeDelete:
+		if ds.ID == nil {
+			return tc.RequestStatusInvalid, errors.New("missing id"), nil, http.StatusBadRequest
+		}
+		obj := deliveryservice.TODeliveryService{}
+		obj.SetInfo(inf)
+		obj.ID = ds.ID
+		if authorized, err := obj.IsTenantAuthorized(inf.User); err != nil {
+			return tc.RequestStatusInvalid, nil, errors.New("checking tenant: " + err.Error()), http.StatusInternalServerError
+		} else if !authorized {
+			return tc.RequestStatusInvalid, errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+		}
+		if userErr, sysErr, errCode := obj.Delete(); userErr != nil || sysErr != nil {
+			return tc.RequestStatusInvalid, userErr, sysErr, errCode
+		}
+		if err := api.CreateChangeLogAudit(api.ApiChange, api.Deleted, &obj, dsr.DeliveryService, inf); err != nil {
+			return tc.RequestStatusInvalid, nil, errors.New("writing change log entry: " + err.Error()), http.StatusInternalServerError
+		}
+		return tc.RequestStatusComplete, nil, nil, http.StatusOK
+	}
+	return tc.RequestStatusInvalid, fmt.Errorf("unknown change type '%s'", dsr.ChangeType), nil, http.StatusBadRequest
+}
+
+// notifyAssignee emails the given message about the given request to its assignee, if it has one other than the
+// current user, with an email address, and SMTP is enabled. Failures are logged, because they don't affect the change.
+func notifyAssignee(inf *api.APIInfo, dsr tc.DeliveryServiceRequestV40, message string) {
+	if dsr.AssigneeID == nil || *dsr.AssigneeID == inf.User.ID || inf.Config == nil || inf.Config.SMTP == nil || !inf.Config.SMTP.Enabled {
+		return
+	}
+	email := sql.NullString{}
+	if err := inf.Tx.Tx.QueryRow(`SELECT email FROM tm_user WHERE id = $1`, *dsr.AssigneeID).Scan(&email); err != nil {
+		log.Errorf("getting email of assignee of DSR #%d: %v\n", *dsr.ID, err)
+		return
+	}
+	if !email.Valid || email.String == "" {
+		return
+	}
+	from := ""
+	if inf.Config.ConfigTO != nil && inf.Config.ConfigTO.EmailFrom != nil {
+		from = inf.Config.ConfigTO.EmailFrom.String()
+	}
+	to := rfc.EmailAddress{Address: mail.Address{Address: email.String}}
+	msg := []byte(makeAssigneeNotification(from, email.String, *dsr.ID, message))
+	if _, userErr, sysErr := inf.SendMail(to, msg); userErr != nil || sysErr != nil {
+		log.Errorf("emailing assignee of DSR #%d: user error: %v, system error: %v\n", *dsr.ID, userErr, sysErr)
+	}
+}
+
+// makeAssigneeNotification returns the email notifying the assignee of a Delivery Service Request of the given change
+// to it.
+func makeAssigneeNotification(from string, to string, dsrID int, message string) string {
+	return "From: " + from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: Delivery Service Request #" + strconv.Itoa(dsrID) + "\r\n\r\n" +
+		message + ".\r\n"
+}
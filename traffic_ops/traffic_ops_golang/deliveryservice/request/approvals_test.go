@@ -0,0 +1,123 @@
+package request
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestCheckApprover(t *testing.T) {
+	dsr := tc.DeliveryServiceRequestV40{AuthorID: util.IntPtr(1), LastEditedByID: util.IntPtr(3)}
+	policy := tc.DSRApprovalPolicy{
+		RequiredApprovals: util.IntPtr(2),
+		ApproverRoles:     []string{"admin", "operations"},
+		AllowSelfApproval: util.BoolPtr(false),
+	}
+
+	if err := checkApprover(policy, dsr, 2, "operations"); err != nil {
+		t.Errorf("expected user with approver role to be allowed to approve, actual: %v", err)
+	}
+	if err := checkApprover(policy, dsr, 2, "portal"); err == nil {
+		t.Error("expected user without approver role to be forbidden to approve, actual: allowed")
+	}
+	if err := checkApprover(policy, dsr, 1, "admin"); err == nil {
+		t.Error("expected author to be forbidden to approve, actual: allowed")
+	}
+	if err := checkApprover(policy, dsr, 3, "operations"); err == nil {
+		t.Error("expected last editor to be forbidden to approve, actual: allowed")
+	}
+
+	policy.AllowSelfApproval = util.BoolPtr(true)
+	if err := checkApprover(policy, dsr, 1, "admin"); err != nil {
+		t.Errorf("expected author to be allowed to approve when self-approval is allowed, actual: %v", err)
+	}
+	if err := checkApprover(policy, dsr, 3, "operations"); err != nil {
+		t.Errorf("expected last editor to be allowed to approve when self-approval is allowed, actual: %v", err)
+	}
+
+	policy.ApproverRoles = nil
+	if err := checkApprover(policy, dsr, 2, "portal"); err != nil {
+		t.Errorf("expected any role to be allowed to approve when the policy has no approver roles, actual: %v", err)
+	}
+}
+
+func TestMakeApprovals(t *testing.T) {
+	dsr := tc.DeliveryServiceRequestV40{ID: util.IntPtr(5), Status: tc.RequestStatusSubmitted}
+	approvals := []tc.DeliveryServiceRequestApproval{{ApproverID: 2, Approver: "ops", CreatedAt: time.Now()}}
+
+	a := makeApprovals(dsr, nil, approvals)
+	if a.DeliveryServiceRequestID != 5 || a.Status != tc.RequestStatusSubmitted || a.PolicyID != nil || a.RequiredApprovals != 0 || len(a.Approvals) != 1 {
+		t.Errorf("unexpected approvals without policy: %+v", a)
+	}
+
+	policy := tc.DSRApprovalPolicy{ID: util.IntPtr(3), RequiredApprovals: util.IntPtr(2)}
+	a = makeApprovals(dsr, &policy, approvals)
+	if a.PolicyID == nil || *a.PolicyID != 3 || a.RequiredApprovals != 2 {
+		t.Errorf("unexpected approvals with policy: %+v", a)
+	}
+}
+
+func TestRequiresApproval(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("opening mock database: %v", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	dsr := tc.DeliveryServiceRequestV40{
+		Status:          tc.RequestStatusSubmitted,
+		DeliveryService: &tc.DeliveryServiceV4{},
+	}
+	dsr.DeliveryService.TenantID = util.IntPtr(4)
+	dsr.DeliveryService.CDNID = util.IntPtr(7)
+
+	mock.ExpectBegin()
+	tx := db.MustBegin().Tx
+
+	// Rejecting doesn't need approval, so no policy is looked up.
+	if userErr, sysErr := requiresApproval(tx, dsr, tc.RequestStatusRejected); userErr != nil || sysErr != nil {
+		t.Errorf("expected rejection not to require approval, actual: %v, %v", userErr, sysErr)
+	}
+
+	policyCols := []string{"id", "tenant_id", "tenant", "cdn_id", "cdn", "required_approvals", "approver_roles", "allow_self_approval", "last_updated"}
+	mock.ExpectQuery("SELECT p.id").WithArgs(4, 7).WillReturnRows(sqlmock.NewRows(policyCols))
+	if userErr, sysErr := requiresApproval(tx, dsr, tc.RequestStatusComplete); userErr != nil || sysErr != nil {
+		t.Errorf("expected completion not to require approval without a policy, actual: %v, %v", userErr, sysErr)
+	}
+
+	mock.ExpectQuery("SELECT p.id").WithArgs(4, 7).WillReturnRows(sqlmock.NewRows(policyCols).AddRow(1, 4, "tenant", nil, nil, 2, "{admin}", false, time.Now()))
+	if userErr, sysErr := requiresApproval(tx, dsr, tc.RequestStatusComplete); userErr == nil || sysErr != nil {
+		t.Errorf("expected completion to require approval with a policy, actual: %v, %v", userErr, sysErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
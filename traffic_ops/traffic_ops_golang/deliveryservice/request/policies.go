@@ -0,0 +1,417 @@
+package request
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+// policyAuditObjectType is the object type of approval policies in the audit log.
+const policyAuditObjectType = "dsrApprovalPolicy"
+
+const selectPolicyQuery = `
+SELECT p.id,
+	p.tenant_id,
+	t.name,
+	p.cdn_id,
+	c.name,
+	p.required_approvals,
+	ARRAY(SELECT ro.name FROM dsr_approval_policy_role AS pr JOIN role AS ro ON ro.id = pr.role WHERE pr.policy = p.id ORDER BY ro.name) AS approver_roles,
+	p.allow_self_approval,
+	p.last_updated
+FROM dsr_approval_policy AS p
+LEFT JOIN tenant AS t ON t.id = p.tenant_id
+LEFT JOIN cdn AS c ON c.id = p.cdn_id
+`
+
+// selectApplicablePolicyQuery selects the most specific policy matching the Tenant $1 and CDN $2 - one for both, then
+// one for the Tenant, then one for the CDN, then one for neither.
+const selectApplicablePolicyQuery = selectPolicyQuery + `
+WHERE (p.tenant_id IS NULL OR p.tenant_id = $1)
+AND (p.cdn_id IS NULL OR p.cdn_id = $2)
+ORDER BY p.tenant_id IS NULL, p.cdn_id IS NULL
+LIMIT 1
+`
+
+const insertPolicyQuery = `
+INSERT INTO dsr_approval_policy (tenant_id, cdn_id, required_approvals, allow_self_approval)
+VALUES ($1, $2, $3, $4)
+RETURNING id, last_updated
+`
+
+const updatePolicyQuery = `
+UPDATE dsr_approval_policy SET
+	tenant_id = $1,
+	cdn_id = $2,
+	required_approvals = $3,
+	allow_self_approval = $4
+WHERE id = $5
+RETURNING last_updated
+`
+
+// GetPolicies is the handler for GET requests to /deliveryservice_request_approval_policies.
+func GetPolicies(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"id":       {Column: "p.id", Checker: api.IsInt},
+		"tenantId": {Column: "p.tenant_id", Checker: api.IsInt},
+		"cdnId":    {Column: "p.cdn_id", Checker: api.IsInt},
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(inf.Tx.Tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting tenant list: "+err.Error()))
+		return
+	}
+	if where == "" {
+		where = "WHERE "
+	} else {
+		where += " AND "
+	}
+	where += "(p.tenant_id IS NULL OR p.tenant_id = ANY(CAST(:accessibleTenants AS BIGINT[])))"
+	queryValues["accessibleTenants"] = pq.Array(tenantIDs)
+	if orderBy == "" {
+		orderBy = "ORDER BY p.id"
+	}
+
+	rows, err := inf.Tx.NamedQuery(selectPolicyQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = errors.New("approval policy read query: " + sysErr.Error())
+		}
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	policies := []tc.DSRApprovalPolicy{}
+	for rows.Next() {
+		p, err := scanPolicy(rows)
+		if err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("scanning approval policy: "+err.Error()))
+			return
+		}
+		policies = append(policies, p)
+	}
+	api.WriteResp(w, r, policies)
+}
+
+// CreatePolicy is the handler for POST requests to /deliveryservice_request_approval_policies.
+func CreatePolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var p tc.DSRApprovalPolicy
+	if userErr = api.Parse(r.Body, tx, &p); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	roleIDs, userErr, sysErr, errCode := validatePolicy(inf, p, 0)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if p.AllowSelfApproval == nil {
+		p.AllowSelfApproval = util.BoolPtr(false)
+	}
+
+	p.ID = new(int)
+	p.LastUpdated = new(tc.TimeNoMod)
+	if err := tx.QueryRow(insertPolicyQuery, p.TenantID, p.CDNID, p.RequiredApprovals, p.AllowSelfApproval).Scan(p.ID, p.LastUpdated); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if err := insertPolicyRoles(tx, *p.ID, roleIDs); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	p, _, err := getPolicy(tx, *p.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	audit := api.Audit{Action: api.Created, ObjectType: policyAuditObjectType, ObjectID: strconv.Itoa(*p.ID), CDNName: p.CDNName, After: p}
+	if err := api.CreateAuditLog(api.ApiChange, "DSR APPROVAL POLICY: "+strconv.Itoa(*p.ID)+", ACTION: Created", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/deliveryservice_request_approval_policies?id=%d", inf.Version.Major, inf.Version.Minor, *p.ID))
+	api.WriteAlertsObj(w, r, http.StatusCreated, tc.CreateAlerts(tc.SuccessLevel, "approval policy was created."), p)
+}
+
+// UpdatePolicy is the handler for PUT requests to /deliveryservice_request_approval_policies/{id}. Approvals already
+// recorded aren't affected by changes to the policy; whether they're enough is evaluated when requests are next
+// approved.
+func UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	before, userErr, sysErr, errCode := getAuthorizedPolicy(inf, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if !api.IsUnmodified(r.Header, before.LastUpdated.Time) {
+		api.HandleErr(w, r, tx, http.StatusPreconditionFailed, errors.New("approval policy could not be modified because the precondition failed"), nil)
+		return
+	}
+
+	var p tc.DSRApprovalPolicy
+	if userErr = api.Parse(r.Body, tx, &p); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	roleIDs, userErr, sysErr, errCode := validatePolicy(inf, p, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if p.AllowSelfApproval == nil {
+		p.AllowSelfApproval = util.BoolPtr(false)
+	}
+
+	if _, err := tx.Exec(updatePolicyQuery, p.TenantID, p.CDNID, p.RequiredApprovals, p.AllowSelfApproval, id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if _, err := tx.Exec(`DELETE FROM dsr_approval_policy_role WHERE policy = $1`, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting approval policy roles: "+err.Error()))
+		return
+	}
+	if err := insertPolicyRoles(tx, id, roleIDs); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	p, _, err := getPolicy(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	audit := api.Audit{Action: api.Updated, ObjectType: policyAuditObjectType, ObjectID: strconv.Itoa(id), CDNName: p.CDNName, Before: before, After: p}
+	if err := api.CreateAuditLog(api.ApiChange, "DSR APPROVAL POLICY: "+strconv.Itoa(id)+", ACTION: Updated", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "approval policy was updated.", p)
+}
+
+// DeletePolicy is the handler for DELETE requests to /deliveryservice_request_approval_policies/{id}.
+func DeletePolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	before, userErr, sysErr, errCode := getAuthorizedPolicy(inf, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if _, err := tx.Exec(`DELETE FROM dsr_approval_policy WHERE id = $1`, id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	audit := api.Audit{Action: api.Deleted, ObjectType: policyAuditObjectType, ObjectID: strconv.Itoa(id), CDNName: before.CDNName, Before: before}
+	if err := api.CreateAuditLog(api.ApiChange, "DSR APPROVAL POLICY: "+strconv.Itoa(id)+", ACTION: Deleted", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "approval policy was deleted.")
+}
+
+// scanner is a *sql.Rows, *sql.Row, or *sqlx.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanPolicy scans an approval policy selected by selectPolicyQuery.
+func scanPolicy(row scanner) (tc.DSRApprovalPolicy, error) {
+	p := tc.DSRApprovalPolicy{}
+	roles := pq.StringArray{}
+	if err := row.Scan(&p.ID, &p.TenantID, &p.Tenant, &p.CDNID, &p.CDNName, &p.RequiredApprovals, &roles, &p.AllowSelfApproval, &p.LastUpdated); err != nil {
+		return p, err
+	}
+	p.ApproverRoles = []string(roles)
+	return p, nil
+}
+
+// getPolicy returns the approval policy with the given ID, and whether it exists.
+func getPolicy(tx *sql.Tx, id int) (tc.DSRApprovalPolicy, bool, error) {
+	p, err := scanPolicy(tx.QueryRow(selectPolicyQuery+`WHERE p.id = $1`, id))
+	if err == sql.ErrNoRows {
+		return p, false, nil
+	} else if err != nil {
+		return p, false, errors.New("querying approval policy: " + err.Error())
+	}
+	return p, true, nil
+}
+
+// getAuthorizedPolicy returns the approval policy with the given ID, if it exists and the user is authorized on its
+// Tenant.
+func getAuthorizedPolicy(inf *api.APIInfo, id int) (tc.DSRApprovalPolicy, error, error, int) {
+	p, ok, err := getPolicy(inf.Tx.Tx, id)
+	if err != nil {
+		return p, nil, err, http.StatusInternalServerError
+	} else if !ok {
+		return p, fmt.Errorf("no approval policy with id %d", id), nil, http.StatusNotFound
+	}
+	if p.TenantID != nil {
+		if ok, err := inf.IsResourceAuthorizedToCurrentUser(*p.TenantID); err != nil {
+			return p, nil, errors.New("checking approval policy tenant: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return p, errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+		}
+	}
+	return p, nil, nil, http.StatusOK
+}
+
+// getApplicablePolicy returns the approval policy which applies to the given request, or nil if none does.
+func getApplicablePolicy(tx *sql.Tx, dsr tc.DeliveryServiceRequestV40) (*tc.DSRApprovalPolicy, error) {
+	var tenantID, cdnID *int
+	if dsr.DeliveryService != nil {
+		tenantID = dsr.DeliveryService.TenantID
+		cdnID = dsr.DeliveryService.CDNID
+	}
+	p, err := scanPolicy(tx.QueryRow(selectApplicablePolicyQuery, tenantID, cdnID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.New("querying applicable approval policy: " + err.Error())
+	}
+	return &p, nil
+}
+
+// validatePolicy checks that the user is authorized on the Tenant of the given policy, that its CDN and Roles exist,
+// and that no other policy than the one with the given ID has the same Tenant and CDN. It returns the IDs of the
+// policy's Roles.
+func validatePolicy(inf *api.APIInfo, p tc.DSRApprovalPolicy, id int) ([]int64, error, error, int) {
+	tx := inf.Tx.Tx
+	if p.TenantID != nil {
+		if ok, err := inf.IsResourceAuthorizedToCurrentUser(*p.TenantID); err != nil {
+			return nil, nil, errors.New("checking approval policy tenant: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return nil, errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+		}
+	}
+	if p.CDNID != nil {
+		if _, ok, err := dbhelpers.GetCDNNameFromID(tx, int64(*p.CDNID)); err != nil {
+			return nil, nil, errors.New("checking approval policy CDN: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return nil, fmt.Errorf("no such CDN: %d", *p.CDNID), nil, http.StatusBadRequest
+		}
+	}
+
+	exists := false
+	if err := tx.QueryRow(`SELECT EXISTS(SELECT 1 FROM dsr_approval_policy WHERE tenant_id IS NOT DISTINCT FROM $1 AND cdn_id IS NOT DISTINCT FROM $2 AND id <> $3)`, p.TenantID, p.CDNID, id).Scan(&exists); err != nil {
+		return nil, nil, errors.New("checking for existing approval policy: " + err.Error()), http.StatusInternalServerError
+	} else if exists {
+		return nil, errors.New("an approval policy for this tenant and CDN already exists"), nil, http.StatusConflict
+	}
+
+	roleIDs := []int64{}
+	if len(p.ApproverRoles) == 0 {
+		return roleIDs, nil, nil, http.StatusOK
+	}
+	rows, err := tx.Query(`SELECT id, name FROM role WHERE name = ANY($1)`, pq.Array(p.ApproverRoles))
+	if err != nil {
+		return nil, nil, errors.New("querying approval policy roles: " + err.Error()), http.StatusInternalServerError
+	}
+	defer rows.Close()
+	found := map[string]struct{}{}
+	for rows.Next() {
+		roleID := int64(0)
+		name := ""
+		if err := rows.Scan(&roleID, &name); err != nil {
+			return nil, nil, errors.New("scanning approval policy roles: " + err.Error()), http.StatusInternalServerError
+		}
+		found[name] = struct{}{}
+		roleIDs = append(roleIDs, roleID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, errors.New("querying approval policy roles: " + err.Error()), http.StatusInternalServerError
+	}
+	missing := []string{}
+	for _, name := range p.ApproverRoles {
+		if _, ok := found[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, errors.New("no such roles: " + strings.Join(missing, ", ")), nil, http.StatusBadRequest
+	}
+	return roleIDs, nil, nil, http.StatusOK
+}
+
+// insertPolicyRoles inserts the Roles with the given IDs as approver Roles of the policy with the given ID.
+func insertPolicyRoles(tx *sql.Tx, id int, roleIDs []int64) error {
+	if _, err := tx.Exec(`INSERT INTO dsr_approval_policy_role (policy, role) SELECT $1, unnest($2::bigint[]) ON CONFLICT DO NOTHING`, id, pq.Array(roleIDs)); err != nil {
+		return errors.New("inserting approval policy roles: " + err.Error())
+	}
+	return nil
+}
@@ -739,6 +739,12 @@ func Put(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Approvals are of the request as it was when they were made, so they don't count towards the changed request.
+	if err := deleteApprovals(tx, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
 	var result dsrManipulationResult
 	if inf.Version.Major >= 4 {
 		result = putV40(w, r, inf)
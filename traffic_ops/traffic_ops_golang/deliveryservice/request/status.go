@@ -154,6 +154,15 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if userErr, sysErr := requiresApproval(tx, dsr, req.Status); userErr != nil || sysErr != nil {
+		errCode = http.StatusBadRequest
+		if sysErr != nil {
+			errCode = http.StatusInternalServerError
+		}
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
 	dsr.LastEditedBy = inf.User.UserName
 	dsr.LastEditedByID = new(int)
 	*dsr.LastEditedByID = inf.User.ID
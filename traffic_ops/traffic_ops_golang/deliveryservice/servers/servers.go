@@ -35,6 +35,7 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
 	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/asyncjob"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
@@ -442,48 +443,102 @@ func GetReplaceHandler(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
 		return
 	}
-	serverInfos, err := dbhelpers.GetServerInfosFromIDs(inf.Tx.Tx, servers)
-	if err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+	if asyncjob.PrefersAsync(r) {
+		asyncjob.RespondAsync(w, r, inf, AssignServersJobKind, "Assignment of servers to delivery service "+ds.Name+" queued.", payload)
 		return
 	}
-
-	userErr, sysErr, status := validateDSSAssignments(inf.Tx.Tx, ds, serverInfos, *payload.Replace)
+	respServers, userErr, sysErr, status := assignServers(inf, ds, servers, *payload.Replace)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, status, userErr, sysErr)
 		return
 	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "server assignments complete", tc.DSSMapResponse{*dsId, *payload.Replace, respServers})
+}
+
+// AssignServersJobKind is the kind of asynchronous job which assigns servers to a Delivery Service.
+const AssignServersJobKind = "deliveryservice-servers-assign"
+
+func init() {
+	asyncjob.Register(AssignServersJobKind, assignServersJob)
+}
+
+// assignServersJob assigns servers to a Delivery Service, as requested of the /deliveryserviceserver endpoint with a preference for an asynchronous response.
+func assignServersJob(job *asyncjob.Job) (interface{}, error, error) {
+	payload := DSServerIds{}
+	if err := job.DecodePayload(&payload); err != nil {
+		return nil, nil, errors.New("decoding server assignment job payload: " + err.Error())
+	}
+	ds, ok, err := GetDSInfo(job.Tx.Tx, *payload.DsId)
+	if err != nil {
+		return nil, nil, fmt.Errorf("deliveryserviceserver getting delivery service info for ID %d: %v", *payload.DsId, err)
+	}
+	if !ok {
+		return nil, errors.New("no delivery service with that ID exists"), nil
+	}
+	if userErr, sysErr, _ := tenant.Check(job.User, ds.Name, job.Tx.Tx); userErr != nil || sysErr != nil {
+		return nil, userErr, sysErr
+	}
+	respServers, userErr, sysErr, _ := assignServers(job.APIInfo(), ds, payload.Servers, *payload.Replace)
+	if userErr != nil || sysErr != nil {
+		return nil, userErr, sysErr
+	}
+	return tc.DSSMapResponse{DsId: *payload.DsId, Replace: *payload.Replace, Servers: respServers}, nil, nil
+}
 
-	if *payload.Replace {
+// ReplaceServers replaces the servers assigned to the Delivery Service with the given ID with the servers with the given IDs, in the transaction of inf.
+func ReplaceServers(inf *api.APIInfo, dsID int, serverIDs []int) (error, error, int) {
+	ds, ok, err := GetDSInfo(inf.Tx.Tx, dsID)
+	if err != nil {
+		return nil, fmt.Errorf("deliveryserviceserver getting delivery service info for ID %d: %v", dsID, err), http.StatusInternalServerError
+	}
+	if !ok {
+		return errors.New("no delivery service with that ID exists"), nil, http.StatusBadRequest
+	}
+	if userErr, sysErr, errCode := tenant.Check(inf.User, ds.Name, inf.Tx.Tx); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	_, userErr, sysErr, errCode := assignServers(inf, ds, serverIDs, true)
+	return userErr, sysErr, errCode
+}
+
+// assignServers assigns the servers with the given IDs to the given Delivery Service, first removing its existing assignments if replace is true. It returns the IDs of the assigned servers.
+func assignServers(inf *api.APIInfo, ds DSInfo, servers []int, replace bool) ([]int, error, error, int) {
+	serverInfos, err := dbhelpers.GetServerInfosFromIDs(inf.Tx.Tx, servers)
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError
+	}
+
+	userErr, sysErr, status := validateDSSAssignments(inf.Tx.Tx, ds, serverInfos, replace)
+	if userErr != nil || sysErr != nil {
+		return nil, userErr, sysErr, status
+	}
+
+	if replace {
 		// delete existing
-		_, err := inf.Tx.Tx.Exec("DELETE FROM deliveryservice_server WHERE deliveryservice = $1", *dsId)
+		_, err := inf.Tx.Tx.Exec("DELETE FROM deliveryservice_server WHERE deliveryservice = $1", ds.ID)
 		if err != nil {
-			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("unable to remove the existing servers assigned to the delivery service: "+err.Error()))
-			return
+			return nil, nil, errors.New("unable to remove the existing servers assigned to the delivery service: " + err.Error()), http.StatusInternalServerError
 		}
 	}
 
 	respServers := []int{}
 	for _, server := range servers {
-		dtos := map[string]interface{}{"id": dsId, "server": server}
+		dtos := map[string]interface{}{"id": ds.ID, "server": server}
 		if _, err := inf.Tx.NamedExec(insertIdsQuery(), dtos); err != nil {
 			usrErr, sysErr, code := api.ParseDBError(err)
-			api.HandleErr(w, r, inf.Tx.Tx, code, usrErr, sysErr)
-			return
+			return nil, usrErr, sysErr, code
 		}
 		respServers = append(respServers, server)
 	}
 
-	if err := deliveryservice.EnsureParams(inf.Tx.Tx, *dsId, ds.Name, ds.EdgeHeaderRewrite, ds.MidHeaderRewrite, ds.RegexRemap, ds.SigningAlgorithm, ds.Type, ds.MaxOriginConnections); err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice_server replace ensuring ds parameters: "+err.Error()))
-		return
+	if err := deliveryservice.EnsureParams(inf.Tx.Tx, ds.ID, ds.Name, ds.EdgeHeaderRewrite, ds.MidHeaderRewrite, ds.RegexRemap, ds.SigningAlgorithm, ds.Type, ds.MaxOriginConnections); err != nil {
+		return nil, nil, errors.New("deliveryservice_server replace ensuring ds parameters: " + err.Error()), http.StatusInternalServerError
 	}
 	if err := deliveryservice.EnsureCacheURLParams(inf.Tx.Tx, ds.ID, ds.Name, ds.CacheURL); err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice_server replace ensuring ds parameters: "+err.Error()))
-		return
+		return nil, nil, errors.New("deliveryservice_server replace ensuring ds parameters: " + err.Error()), http.StatusInternalServerError
 	}
-	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+ds.Name+", ID: "+strconv.Itoa(*dsId)+", ACTION: Replace existing servers assigned to delivery service", inf.User, inf.Tx.Tx)
-	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "server assignments complete", tc.DSSMapResponse{*dsId, *payload.Replace, respServers})
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+ds.Name+", ID: "+strconv.Itoa(ds.ID)+", ACTION: Replace existing servers assigned to delivery service", inf.User, inf.Tx.Tx)
+	return respServers, nil, nil, http.StatusOK
 }
 
 type TODeliveryServiceServers tc.DeliveryServiceServers
@@ -28,7 +28,6 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 )
 
@@ -60,6 +59,14 @@ func GenerateSSLKeys(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no DS with name "+*req.DeliveryService), nil)
 		return
 	}
+	if req.KeyAlgorithm == nil {
+		alg := tc.DefaultSSLKeyAlgorithm
+		req.KeyAlgorithm = &alg
+	}
+	if userErr, sysErr, errCode := checkKeyAlgorithmAllowed(*req.DeliveryService, *req.KeyAlgorithm, inf.Tx.Tx); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
 	if err := generatePutRiakKeys(req, inf.Tx.Tx, inf.Config); err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("generating and putting SSL keys: "+err.Error()))
 		return
@@ -73,7 +80,7 @@ func GenerateSSLKeys(w http.ResponseWriter, r *http.Request) {
 }
 
 // generatePutRiakKeys generates a certificate, csr, and key from the given request, and insert it into the Riak key database.
-// The req MUST be validated, ensuring required fields exist.
+// The req MUST be validated, ensuring required fields exist, and its KeyAlgorithm MUST NOT be nil.
 func generatePutRiakKeys(req tc.DeliveryServiceGenSSLKeysReq, tx *sql.Tx, cfg *config.Config) error {
 	dsSSLKeys := tc.DeliveryServiceSSLKeys{
 		CDN:             *req.CDN,
@@ -87,7 +94,7 @@ func generatePutRiakKeys(req tc.DeliveryServiceGenSSLKeysReq, tx *sql.Tx, cfg *c
 		Key:             *req.Key,
 		Version:         *req.Version,
 	}
-	csr, crt, key, err := GenerateCert(*req.HostName, *req.Country, *req.City, *req.State, *req.Organization, *req.BusinessUnit)
+	csr, crt, key, err := GenerateCert(*req.HostName, *req.Country, *req.City, *req.State, *req.Organization, *req.BusinessUnit, *req.KeyAlgorithm)
 	if err != nil {
 		return errors.New("generating certificate: " + err.Error())
 	}
@@ -95,8 +102,26 @@ func generatePutRiakKeys(req tc.DeliveryServiceGenSSLKeysReq, tx *sql.Tx, cfg *c
 
 	dsSSLKeys.AuthType = tc.SelfSignedCertAuthType
 
-	if err := riaksvc.PutDeliveryServiceSSLKeysObj(dsSSLKeys, tx, cfg.RiakAuthOptions, cfg.RiakPort); err != nil {
-		return errors.New("putting riak keys: " + err.Error())
+	if err := cfg.TrafficVault.PutDeliveryServiceSSLKeys(dsSSLKeys, tx); err != nil {
+		return errors.New("putting Traffic Vault keys: " + err.Error())
 	}
 	return nil
 }
+
+// checkKeyAlgorithmAllowed returns a user error if the given key algorithm may not be used by the given Delivery Service.
+// ECDSA keys are only permitted for DNS Delivery Services, because Traffic Router does not support them.
+func checkKeyAlgorithmAllowed(xmlID string, keyAlgorithm string, tx *sql.Tx) (error, error, int) {
+	if !tc.IsECDSASSLKeyAlgorithm(keyAlgorithm) {
+		return nil, nil, http.StatusOK
+	}
+	dsType, ok, err := getDSType(tx, xmlID)
+	if err != nil {
+		return nil, errors.New("getting delivery service type: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return errors.New("no DS with name " + xmlID), nil, http.StatusNotFound
+	}
+	if !dsType.IsDNS() {
+		return errors.New("keyAlgorithm " + keyAlgorithm + " is only supported for DNS delivery services"), nil, http.StatusBadRequest
+	}
+	return nil, nil, http.StatusOK
+}
@@ -0,0 +1,436 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+// dsCertInfo is the information about a Delivery Service needed to request and validate its certificates.
+type dsCertInfo struct {
+	ID          int
+	Protocol    *int
+	Type        tc.DSType
+	RoutingName string
+	CDN         string
+	CDNDomain   string
+	KeyVersion  int64
+}
+
+// getDSCertInfo returns the certificate information of the given Delivery Service, whether it exists, and any error.
+func getDSCertInfo(tx *sql.Tx, xmlID string) (dsCertInfo, bool, error) {
+	qry := `
+SELECT ds.id, ds.protocol, t.name, ds.routing_name, cdn.name, cdn.domain_name, COALESCE(ds.ssl_key_version, 0)
+FROM deliveryservice AS ds
+JOIN type AS t ON ds.type = t.id
+JOIN cdn ON ds.cdn_id = cdn.id
+WHERE ds.xml_id = $1
+`
+	inf := dsCertInfo{}
+	dsType := ""
+	if err := tx.QueryRow(qry, xmlID).Scan(&inf.ID, &inf.Protocol, &dsType, &inf.RoutingName, &inf.CDN, &inf.CDNDomain, &inf.KeyVersion); err != nil {
+		if err == sql.ErrNoRows {
+			return dsCertInfo{}, false, nil
+		}
+		return dsCertInfo{}, false, errors.New("querying delivery service certificate info: " + err.Error())
+	}
+	inf.Type = tc.DSTypeFromString(dsType)
+	return inf, true, nil
+}
+
+// getDSCertHosts returns the unique host names of the example URLs of the given Delivery Service, which its certificate must cover.
+func getDSCertHosts(tx *sql.Tx, xmlID string, inf dsCertInfo) ([]string, error) {
+	matchLists, err := GetDeliveryServicesMatchLists([]string{xmlID}, tx)
+	if err != nil {
+		return nil, errors.New("getting delivery service match list: " + err.Error())
+	}
+	return exampleURLHosts(MakeExampleURLs(inf.Protocol, inf.Type, inf.RoutingName, matchLists[xmlID], inf.CDNDomain)), nil
+}
+
+// exampleURLHosts returns the unique host names of the given example URLs, in order. Example URLs without a host, such as path regexes, are skipped.
+func exampleURLHosts(exampleURLs []string) []string {
+	hosts := []string{}
+	seen := map[string]struct{}{}
+	for _, exampleURL := range exampleURLs {
+		u, err := url.Parse(exampleURL)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		if _, ok := seen[host]; ok {
+			continue
+		}
+		seen[host] = struct{}{}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// verifyCertHosts returns an error listing every host which the given certificate is not valid for.
+func verifyCertHosts(cert *x509.Certificate, hosts []string) error {
+	missing := []string{}
+	for _, host := range hosts {
+		if err := cert.VerifyHostname(host); err != nil {
+			missing = append(missing, host)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.New("certificate is not valid for delivery service host names: " + strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkCSRHostName returns an error unless the given host name requested for a Delivery Service's certificate is one
+// of the given host names of the Delivery Service, or a wildcard which covers all of them, so that a CSR can't be
+// generated for hosts other than the Delivery Service's own.
+func checkCSRHostName(hostName string, hosts []string) error {
+	for _, host := range hosts {
+		if hostName == host {
+			return nil
+		}
+	}
+	if strings.HasPrefix(hostName, "*.") {
+		covered := true
+		for _, host := range hosts {
+			if i := strings.Index(host, "."); i < 0 || host[i:] != hostName[1:] {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return nil
+		}
+	}
+	return errors.New("hostname must be one of the delivery service's host names (" + strings.Join(hosts, ", ") + "), or a wildcard covering all of them")
+}
+
+// GenerateSSLKeysCSR generates a new private key and certificate signing request for a Delivery Service, to be signed by an external Certificate Authority.
+// The CSR covers every host name of the Delivery Service's example URLs. The private key and CSR are stored in Traffic Vault as pending, and do not replace the Delivery Service's current SSL keys until the signed certificate is submitted.
+func GenerateSSLKeysCSR(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"xmlid"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("generating SSL keys CSR for delivery service: Traffic Vault is not configured"))
+		return
+	}
+	xmlID := inf.Params["xmlid"]
+	req := tc.DeliveryServiceSSLKeysCSRReq{}
+	if err := api.Parse(r.Body, inf.Tx.Tx, &req); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("parsing request: "+err.Error()), nil)
+		return
+	}
+	if userErr, sysErr, errCode := tenant.Check(inf.User, xmlID, inf.Tx.Tx); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	dsInf, ok, err := getDSCertInfo(inf.Tx.Tx, xmlID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice.GenerateSSLKeysCSR: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no DS with name "+xmlID), nil)
+		return
+	}
+
+	keyAlgorithm := tc.DefaultSSLKeyAlgorithm
+	if req.KeyAlgorithm != nil {
+		keyAlgorithm = *req.KeyAlgorithm
+	}
+	if userErr, sysErr, errCode := checkKeyAlgorithmAllowed(xmlID, keyAlgorithm, inf.Tx.Tx); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	hosts, err := getDSCertHosts(inf.Tx.Tx, xmlID, dsInf)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice.GenerateSSLKeysCSR: "+err.Error()))
+		return
+	}
+	if len(hosts) == 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("delivery service '"+xmlID+"' has no host names to request a certificate for"), nil)
+		return
+	}
+	hostname := hosts[0]
+	if req.HostName != nil && *req.HostName != "" {
+		hostname = strings.ToLower(*req.HostName)
+		if err := checkCSRHostName(hostname, hosts); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+			return
+		}
+	}
+	sans := []string{hostname}
+	for _, host := range hosts {
+		if host != hostname {
+			sans = append(sans, host)
+		}
+	}
+
+	priv, err := GenerateKey(keyAlgorithm)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("generating key: "+err.Error()))
+		return
+	}
+	subj := MakeCertSubject(hostname, *req.Country, *req.City, *req.State, *req.Organization, *req.BusinessUnit)
+	csrPEM, err := GenerateCSR(priv, subj, sans)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("generating CSR: "+err.Error()))
+		return
+	}
+	keyPEM, err := EncodePrivateKey(priv)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("encoding key: "+err.Error()))
+		return
+	}
+
+	csr := tc.DeliveryServiceSSLKeysPendingCSR{
+		DeliveryServiceSSLKeysCSR: tc.DeliveryServiceSSLKeysCSR{
+			DeliveryService:         xmlID,
+			CDN:                     dsInf.CDN,
+			BusinessUnit:            *req.BusinessUnit,
+			City:                    *req.City,
+			Organization:            *req.Organization,
+			Hostname:                hostname,
+			Country:                 *req.Country,
+			State:                   *req.State,
+			KeyAlgorithm:            keyAlgorithm,
+			SubjectAlternativeNames: sans,
+			CSR:                     string(csrPEM),
+			Created:                 time.Now(),
+		},
+		Key: string(keyPEM),
+	}
+	if err := inf.Config.TrafficVault.PutDeliveryServiceSSLKeysCSR(csr, inf.Tx.Tx); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("putting SSL keys CSR in Traffic Vault for delivery service '"+xmlID+"': "+err.Error()))
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+xmlID+", ID: "+strconv.Itoa(dsInf.ID)+", ACTION: Generated SSL keys CSR", inf.User, inf.Tx.Tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Successfully generated SSL keys CSR for "+xmlID, csr.DeliveryServiceSSLKeysCSR)
+}
+
+// GetSSLKeysCSR returns the pending certificate signing request of a Delivery Service. Its private key is never returned.
+func GetSSLKeysCSR(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"xmlid"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting SSL keys CSR from Traffic Vault for delivery service: Traffic Vault is not configured"))
+		return
+	}
+	xmlID := inf.Params["xmlid"]
+	if userErr, sysErr, errCode := tenant.Check(inf.User, xmlID, inf.Tx.Tx); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	csr, ok, err := inf.Config.TrafficVault.GetDeliveryServiceSSLKeysCSR(xmlID, inf.Tx.Tx)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting SSL keys CSR from Traffic Vault for delivery service '"+xmlID+"': "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no pending SSL keys CSR for delivery service "+xmlID), nil)
+		return
+	}
+	api.WriteResp(w, r, csr.DeliveryServiceSSLKeysCSR)
+}
+
+// CompleteSSLKeysCSR accepts the certificate signed for the pending certificate signing request of a Delivery Service.
+// The certificate must match the pending private key, have a valid chain, and be valid for every host name of the Delivery Service's example URLs. It is then stored with the pending key as the Delivery Service's new SSL keys, and the pending CSR is removed.
+func CompleteSSLKeysCSR(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"xmlid"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("completing SSL keys CSR for delivery service: Traffic Vault is not configured"))
+		return
+	}
+	xmlID := inf.Params["xmlid"]
+	req := tc.DeliveryServiceSSLKeysCSRCertReq{}
+	if err := api.Parse(r.Body, inf.Tx.Tx, &req); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("parsing request: "+err.Error()), nil)
+		return
+	}
+	if userErr, sysErr, errCode := tenant.Check(inf.User, xmlID, inf.Tx.Tx); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	dsInf, ok, err := getDSCertInfo(inf.Tx.Tx, xmlID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice.CompleteSSLKeysCSR: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no DS with name "+xmlID), nil)
+		return
+	}
+	csr, ok, err := inf.Config.TrafficVault.GetDeliveryServiceSSLKeysCSR(xmlID, inf.Tx.Tx)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting SSL keys CSR from Traffic Vault for delivery service '"+xmlID+"': "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no pending SSL keys CSR for delivery service "+xmlID), nil)
+		return
+	}
+	hosts, err := getDSCertHosts(inf.Tx.Tx, xmlID, dsInf)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice.CompleteSSLKeysCSR: "+err.Error()))
+		return
+	}
+
+	pemCert := strings.TrimSpace(*req.Crt) + "\n"
+	if req.Chain != nil && strings.TrimSpace(*req.Chain) != "" {
+		pemCert += strings.TrimSpace(*req.Chain) + "\n"
+	}
+	certChain, certPrivateKey, isUnknownAuth, isVerifiedChainNotEqual, err := verifySignedCSRCert(pemCert, csr.Key, dsInf.Type.IsDNS(), hosts, time.Now())
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("verifying certificate: "+err.Error()), nil)
+		return
+	}
+
+	authType := tc.CertificateAuthorityCertAuthType
+	if req.AuthType != nil && *req.AuthType != "" {
+		authType = *req.AuthType
+	}
+	certificate := tc.DeliveryServiceSSLKeysCertificate{Crt: certChain, Key: certPrivateKey, CSR: csr.CSR}
+	base64EncodeCertificate(&certificate)
+	version := dsInf.KeyVersion + 1
+	dsSSLKeys := tc.DeliveryServiceSSLKeys{
+		AuthType:        authType,
+		CDN:             dsInf.CDN,
+		DeliveryService: xmlID,
+		BusinessUnit:    csr.BusinessUnit,
+		City:            csr.City,
+		Organization:    csr.Organization,
+		Hostname:        csr.Hostname,
+		Country:         csr.Country,
+		State:           csr.State,
+		Key:             xmlID,
+		Version:         util.JSONIntStr(version),
+		Certificate:     certificate,
+	}
+	if err := inf.Config.TrafficVault.PutDeliveryServiceSSLKeys(dsSSLKeys, inf.Tx.Tx); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("putting SSL keys in Traffic Vault for delivery service '"+xmlID+"': "+err.Error()))
+		return
+	}
+	if err := updateSSLKeyVersion(xmlID, version, inf.Tx.Tx); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("completing SSL keys CSR for delivery service '"+xmlID+"': "+err.Error()))
+		return
+	}
+	if err := inf.Config.TrafficVault.DeleteDeliveryServiceSSLKeysCSR(xmlID, inf.Tx.Tx); err != nil {
+		// the new keys are already stored, so a stale pending CSR is not worth failing the request over
+		log.Errorln("deleting completed SSL keys CSR from Traffic Vault for delivery service '" + xmlID + "': " + err.Error())
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+xmlID+", ID: "+strconv.Itoa(dsInf.ID)+", ACTION: Added SSL keys from signed CSR", inf.User, inf.Tx.Tx)
+
+	if isUnknownAuth {
+		api.WriteRespAlert(w, r, tc.WarnLevel, "WARNING: SSL keys were successfully added for '"+xmlID+"', but the input certificate may be invalid (certificate is signed by an unknown authority)")
+		return
+	}
+	if isVerifiedChainNotEqual {
+		api.WriteRespAlert(w, r, tc.WarnLevel, "WARNING: SSL keys were successfully added for '"+xmlID+"', but the input certificate may be invalid (certificate verification produced a different chain)")
+		return
+	}
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "Successfully added SSL keys from signed CSR for "+xmlID)
+}
+
+// verifySignedCSRCert verifies the signed certificate of a pending CSR against the pending private key and the host names it must be valid for, at the given time.
+// It returns the same values as verifyCertKeyPair.
+func verifySignedCSRCert(pemCert string, pemKey string, allowEC bool, hosts []string, now time.Time) (string, string, bool, bool, error) {
+	certChain, certPrivateKey, isUnknownAuth, isVerifiedChainNotEqual, err := verifyCertKeyPair(pemCert, pemKey, "", allowEC)
+	if err != nil {
+		return "", "", false, false, err
+	}
+	block, _ := pem.Decode([]byte(certChain))
+	if block == nil {
+		return "", "", false, false, errors.New("could not decode pem-encoded server certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", "", false, false, errors.New("could not parse the server certificate: " + err.Error())
+	}
+	if now.After(cert.NotAfter) {
+		return "", "", false, false, errors.New("certificate expired at " + cert.NotAfter.Format(time.RFC3339))
+	}
+	if err := verifyCertHosts(cert, hosts); err != nil {
+		return "", "", false, false, err
+	}
+	return certChain, certPrivateKey, isUnknownAuth, isVerifiedChainNotEqual, nil
+}
+
+// DeleteSSLKeysCSR discards the pending certificate signing request of a Delivery Service, and its private key.
+func DeleteSSLKeysCSR(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"xmlid"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting SSL keys CSR from Traffic Vault for delivery service: Traffic Vault is not configured"))
+		return
+	}
+	xmlID := inf.Params["xmlid"]
+	if userErr, sysErr, errCode := tenant.Check(inf.User, xmlID, inf.Tx.Tx); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	dsID, ok, err := getDSIDFromName(inf.Tx.Tx, xmlID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deliveryservice.DeleteSSLKeysCSR: getting DS ID from name "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no DS with name "+xmlID), nil)
+		return
+	}
+	if _, ok, err := inf.Config.TrafficVault.GetDeliveryServiceSSLKeysCSR(xmlID, inf.Tx.Tx); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting SSL keys CSR from Traffic Vault for delivery service '"+xmlID+"': "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no pending SSL keys CSR for delivery service "+xmlID), nil)
+		return
+	}
+	if err := inf.Config.TrafficVault.DeleteDeliveryServiceSSLKeysCSR(xmlID, inf.Tx.Tx); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting SSL keys CSR from Traffic Vault for delivery service '"+xmlID+"': "+err.Error()))
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+xmlID+", ID: "+strconv.Itoa(dsID)+", ACTION: Deleted SSL keys CSR", inf.User, inf.Tx.Tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "Successfully deleted SSL keys CSR for "+xmlID)
+}
@@ -0,0 +1,244 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestGenerateCertKeyAlgorithms(t *testing.T) {
+	for _, alg := range tc.SSLKeyAlgorithms {
+		csr, crt, key, err := GenerateCert("foo.example.net", "US", "Denver", "CO", "Org", "Unit", alg)
+		if err != nil {
+			t.Fatalf("%s: generating cert: %v", alg, err)
+		}
+		pemCSR := decodeLegacyPerlRiakFormat(t, csr)
+		pemCrt := decodeLegacyPerlRiakFormat(t, crt)
+		pemKey := decodeLegacyPerlRiakFormat(t, key)
+
+		if _, _, _, _, err := verifyCertKeyPair(pemCrt, pemKey, "", true); err != nil {
+			t.Errorf("%s: expected generated cert and key to verify, actual: %v", alg, err)
+		}
+
+		block, _ := pem.Decode([]byte(pemCSR))
+		if block == nil {
+			t.Fatalf("%s: could not decode CSR", alg)
+		}
+		req, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			t.Fatalf("%s: parsing CSR: %v", alg, err)
+		}
+		if err := req.CheckSignature(); err != nil {
+			t.Errorf("%s: expected valid CSR signature, actual: %v", alg, err)
+		}
+		if tc.IsECDSASSLKeyAlgorithm(alg) != (req.PublicKeyAlgorithm == x509.ECDSA) {
+			t.Errorf("%s: unexpected CSR public key algorithm %v", alg, req.PublicKeyAlgorithm)
+		}
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	expectedRSABits := map[string]int{
+		tc.SSLKeyAlgorithmRSA2048: 2048,
+		tc.SSLKeyAlgorithmRSA3072: 3072,
+		tc.SSLKeyAlgorithmRSA4096: 4096,
+	}
+	expectedCurves := map[string]string{
+		tc.SSLKeyAlgorithmECDSAP256: "P-256",
+		tc.SSLKeyAlgorithmECDSAP384: "P-384",
+	}
+	for _, alg := range tc.SSLKeyAlgorithms {
+		priv, err := GenerateKey(alg)
+		if err != nil {
+			t.Fatalf("%s: generating key: %v", alg, err)
+		}
+		switch key := priv.(type) {
+		case *rsa.PrivateKey:
+			if bits := key.N.BitLen(); bits != expectedRSABits[alg] {
+				t.Errorf("%s: expected %d bit key, actual %d", alg, expectedRSABits[alg], bits)
+			}
+		case *ecdsa.PrivateKey:
+			if curve := key.Params().Name; curve != expectedCurves[alg] {
+				t.Errorf("%s: expected curve %s, actual %s", alg, expectedCurves[alg], curve)
+			}
+		default:
+			t.Errorf("%s: unexpected key type %T", alg, priv)
+		}
+	}
+	if _, err := GenerateKey("DSA-1024"); err == nil {
+		t.Error("expected error generating key of unknown algorithm, actual nil")
+	}
+}
+
+func TestGenerateCSRSubjectAlternativeNames(t *testing.T) {
+	priv, err := GenerateKey(tc.SSLKeyAlgorithmECDSAP384)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	sans := []string{"foo.example.net", "bar.example.net"}
+	csrPEM, err := GenerateCSR(priv, MakeCertSubject("foo.example.net", "US", "Denver", "CO", "Org", "Unit"), sans)
+	if err != nil {
+		t.Fatalf("generating CSR: %v", err)
+	}
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		t.Fatalf("expected PEM certificate request, actual %q", string(csrPEM))
+	}
+	req, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatalf("parsing CSR: %v", err)
+	}
+	if !reflect.DeepEqual(req.DNSNames, sans) {
+		t.Errorf("expected CSR DNS names %v, actual %v", sans, req.DNSNames)
+	}
+	if req.Subject.CommonName != "foo.example.net" {
+		t.Errorf("expected CSR common name foo.example.net, actual %s", req.Subject.CommonName)
+	}
+	if req.SignatureAlgorithm != x509.ECDSAWithSHA384 {
+		t.Errorf("expected CSR signature algorithm %v, actual %v", x509.ECDSAWithSHA384, req.SignatureAlgorithm)
+	}
+}
+
+func TestExampleURLHosts(t *testing.T) {
+	exampleURLs := []string{
+		"http://cdn.ds.example.net",
+		"https://cdn.ds.example.net",
+		"https://Other.Example.Net",
+		"/path/.*",
+		"http://host.example.net:8080",
+	}
+	expected := []string{"cdn.ds.example.net", "other.example.net", "host.example.net"}
+	if actual := exampleURLHosts(exampleURLs); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected hosts %v, actual %v", expected, actual)
+	}
+}
+
+func TestCheckCSRHostName(t *testing.T) {
+	hosts := []string{"video.demo1.mycdn.example.net", "ccr.demo1.mycdn.example.net"}
+	for _, hostName := range []string{"video.demo1.mycdn.example.net", "ccr.demo1.mycdn.example.net", "*.demo1.mycdn.example.net"} {
+		if err := checkCSRHostName(hostName, hosts); err != nil {
+			t.Errorf("expected host name '%s' to be allowed, actual error: %v", hostName, err)
+		}
+	}
+	for _, hostName := range []string{"www.example.com", "*.mycdn.example.net", "*.example.net", "*video.demo1.mycdn.example.net", "demo1.mycdn.example.net", "*"} {
+		if err := checkCSRHostName(hostName, hosts); err == nil {
+			t.Errorf("expected host name '%s' to be rejected, actual: nil error", hostName)
+		}
+	}
+	if err := checkCSRHostName("*.demo1.mycdn.example.net", append(hosts, "www.example.com")); err == nil {
+		t.Error("expected a wildcard which doesn't cover every host name to be rejected, actual: nil error")
+	}
+}
+
+func TestVerifySignedCSRCert(t *testing.T) {
+	priv, err := GenerateKey(tc.SSLKeyAlgorithmECDSAP256)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	pemKey, err := EncodePrivateKey(priv)
+	if err != nil {
+		t.Fatalf("encoding key: %v", err)
+	}
+	now := time.Now()
+	hosts := []string{"cdn.ds.example.net", "other.example.net"}
+
+	pemCrt := signTestCert(t, priv, []string{"*.ds.example.net", "other.example.net"}, now.Add(time.Hour))
+	if _, key, _, _, err := verifySignedCSRCert(pemCrt, string(pemKey), true, hosts, now); err != nil {
+		t.Errorf("expected certificate covering all hosts to verify, actual: %v", err)
+	} else if key != strings.TrimSpace(string(pemKey)) {
+		t.Errorf("expected verified key to be the pending key")
+	}
+
+	if _, _, _, _, err := verifySignedCSRCert(pemCrt, string(pemKey), false, hosts, now); err == nil {
+		t.Error("expected ECDSA certificate to fail verification when EC is not allowed, actual nil error")
+	}
+
+	missing := signTestCert(t, priv, []string{"cdn.ds.example.net"}, now.Add(time.Hour))
+	if _, _, _, _, err := verifySignedCSRCert(missing, string(pemKey), true, hosts, now); err == nil || !strings.Contains(err.Error(), "other.example.net") {
+		t.Errorf("expected error naming the uncovered host, actual: %v", err)
+	}
+
+	expired := signTestCert(t, priv, hosts, now.Add(-time.Minute))
+	if _, _, _, _, err := verifySignedCSRCert(expired, string(pemKey), true, hosts, now); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected expired certificate error, actual: %v", err)
+	}
+
+	otherPriv, err := GenerateKey(tc.SSLKeyAlgorithmECDSAP256)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	wrongKey := signTestCert(t, otherPriv, hosts, now.Add(time.Hour))
+	if _, _, _, _, err := verifySignedCSRCert(wrongKey, string(pemKey), true, hosts, now); err == nil {
+		t.Error("expected certificate of a different key to fail verification, actual nil error")
+	}
+}
+
+// signTestCert returns the PEM-encoded server certificate of the given key for the given DNS names, signed by a new test Certificate Authority.
+func signTestCert(t *testing.T, priv crypto.Signer, dnsNames []string, notAfter time.Time) string {
+	caPriv, err := GenerateKey(tc.SSLKeyAlgorithmECDSAP256)
+	if err != nil {
+		t.Fatalf("generating CA key: %v", err)
+	}
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             notAfter.Add(-24 * time.Hour),
+		NotAfter:              notAfter.Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	crt := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     dnsNames,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, crt, ca, priv.Public(), caPriv)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func decodeLegacyPerlRiakFormat(t *testing.T, b []byte) string {
+	decoded, err := base64.StdEncoding.DecodeString(strings.Replace(string(b), "\n", "", -1))
+	if err != nil {
+		t.Fatalf("decoding legacy Riak format: %v", err)
+	}
+	return string(decoded)
+}
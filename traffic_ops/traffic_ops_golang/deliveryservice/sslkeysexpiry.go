@@ -0,0 +1,166 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// sslKeyExpiryCheckLockID is the ID of the Postgres advisory lock held while checking for expiring certificates, so only one
+// Traffic Ops instance does so at a time.
+const sslKeyExpiryCheckLockID = 4741044
+
+// sslKeyExpiryNotificationPrefix begins every CDN notification raised for expiring certificates. Notifications which don't
+// begin with it, or were created by another user, are never replaced or removed by the check.
+const sslKeyExpiryNotificationPrefix = "Expiring Delivery Service certificates: "
+
+// StartSSLKeyExpiryCheck periodically checks the certificates of every Delivery Service in Traffic Vault, and raises a CDN
+// notification on each CDN with certificates which expire within the configured number of days, on behalf of the configured
+// user. The notification is removed once none of the CDN's certificates are expiring.
+func StartSSLKeyExpiryCheck(db *sqlx.DB, cfg *config.Config) {
+	if !cfg.TrafficVaultEnabled || cfg.SSLKeyExpiryCheckIntervalSeconds < 0 || cfg.SSLKeyExpiryNotificationUser == "" {
+		log.Infoln("Delivery Service certificates will not be checked for expiry")
+		return
+	}
+	interval := time.Duration(cfg.SSLKeyExpiryCheckIntervalSeconds) * time.Second
+	go func() {
+		for {
+			time.Sleep(interval)
+			if err := checkSSLKeyExpiry(db, cfg, time.Now()); err != nil {
+				log.Errorln("checking Delivery Service certificates for expiry: " + err.Error())
+			}
+		}
+	}()
+}
+
+func checkSSLKeyExpiry(db *sqlx.DB, cfg *config.Config, now time.Time) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.New("beginning transaction: " + err.Error())
+	}
+	defer tx.Rollback()
+
+	locked := false
+	if err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, sslKeyExpiryCheckLockID).Scan(&locked); err != nil {
+		return errors.New("obtaining lock: " + err.Error())
+	}
+	if !locked {
+		log.Infoln("checking Delivery Service certificates for expiry: another Traffic Ops instance is checking, doing nothing")
+		return nil
+	}
+
+	dses, err := getSSLKeysInventoryDSes(tx, "", nil)
+	if err != nil {
+		return err
+	}
+	certs, err := getSSLKeysInventory(tx, cfg.TrafficVault, dses, now)
+	if err != nil {
+		return err
+	}
+	expiring := filterExpiringCertificates(certs, cfg.SSLKeyExpiryNotificationDays)
+
+	cdns, err := getCDNNames(tx)
+	if err != nil {
+		return err
+	}
+	notifications := makeSSLKeyExpiryNotifications(expiring, cfg.SSLKeyExpiryNotificationDays)
+	for _, cdn := range cdns {
+		if notification, ok := notifications[cdn]; ok {
+			err = raiseSSLKeyExpiryNotification(tx, cdn, cfg.SSLKeyExpiryNotificationUser, notification)
+		} else {
+			err = clearSSLKeyExpiryNotification(tx, cdn, cfg.SSLKeyExpiryNotificationUser)
+		}
+		if err != nil {
+			return errors.New("CDN '" + cdn + "': " + err.Error())
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.New("committing transaction: " + err.Error())
+	}
+	return nil
+}
+
+func getCDNNames(tx *sql.Tx) ([]string, error) {
+	rows, err := tx.Query(`SELECT name FROM cdn`)
+	if err != nil {
+		return nil, errors.New("querying CDNs: " + err.Error())
+	}
+	defer rows.Close()
+	cdns := []string{}
+	for rows.Next() {
+		cdn := ""
+		if err := rows.Scan(&cdn); err != nil {
+			return nil, errors.New("scanning CDNs: " + err.Error())
+		}
+		cdns = append(cdns, cdn)
+	}
+	return cdns, nil
+}
+
+// makeSSLKeyExpiryNotifications returns the CDN notification text for each CDN with any of the given expiring certificates.
+func makeSSLKeyExpiryNotifications(expiring []tc.DeliveryServiceCertificate, days int) map[string]string {
+	byCDN := map[string][]string{}
+	for _, cert := range expiring {
+		desc := cert.DeliveryService + " (expires " + cert.NotAfter.UTC().Format("2006-01-02") + ")"
+		if cert.DaysToExpiry < 0 {
+			desc = cert.DeliveryService + " (expired " + cert.NotAfter.UTC().Format("2006-01-02") + ")"
+		}
+		byCDN[cert.CDN] = append(byCDN[cert.CDN], desc)
+	}
+	notifications := map[string]string{}
+	for cdn, descs := range byCDN {
+		sort.Strings(descs)
+		notifications[cdn] = sslKeyExpiryNotificationPrefix + strconv.Itoa(len(descs)) + " expire within " + strconv.Itoa(days) + " days: " + strings.Join(descs, ", ")
+	}
+	return notifications
+}
+
+// raiseSSLKeyExpiryNotification sets the notification of the CDN, unless it already has a notification which wasn't raised by the expiry check.
+func raiseSSLKeyExpiryNotification(tx *sql.Tx, cdn string, user string, notification string) error {
+	qry := `
+INSERT INTO cdn_notification (cdn, "user", notification) VALUES ($1, $2, $3)
+ON CONFLICT (cdn) DO UPDATE SET notification = $3
+WHERE cdn_notification."user" = $2 AND cdn_notification.notification LIKE $4 || '%' AND cdn_notification.notification <> $3
+`
+	if _, err := tx.Exec(qry, cdn, user, notification, sslKeyExpiryNotificationPrefix); err != nil {
+		return errors.New("raising notification: " + err.Error())
+	}
+	return nil
+}
+
+// clearSSLKeyExpiryNotification removes the notification of the CDN, if it was raised by the expiry check.
+func clearSSLKeyExpiryNotification(tx *sql.Tx, cdn string, user string) error {
+	qry := `DELETE FROM cdn_notification WHERE cdn = $1 AND "user" = $2 AND notification LIKE $3 || '%'`
+	if _, err := tx.Exec(qry, cdn, user, sslKeyExpiryNotificationPrefix); err != nil {
+		return errors.New("clearing notification: " + err.Error())
+	}
+	return nil
+}
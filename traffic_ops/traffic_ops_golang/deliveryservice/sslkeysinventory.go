@@ -0,0 +1,283 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+
+	"github.com/lib/pq"
+)
+
+// dsInventoryInfo is the information about a Delivery Service with SSL keys needed to describe its certificate.
+type dsInventoryInfo struct {
+	dsCertInfo
+	XMLID    string
+	TenantID int
+	Tenant   string
+}
+
+// getSSLKeysInventoryDSes returns every Delivery Service with SSL keys, ordered by CDN and XMLID.
+// If cdn is not empty, only Delivery Services in that CDN are returned. If tenantIDs is not nil, only Delivery Services in those tenants are returned.
+func getSSLKeysInventoryDSes(tx *sql.Tx, cdn string, tenantIDs []int) ([]dsInventoryInfo, error) {
+	qry := `
+SELECT ds.xml_id, ds.id, ds.protocol, t.name, ds.routing_name, cdn.name, cdn.domain_name, ds.ssl_key_version, ds.tenant_id, tenant.name
+FROM deliveryservice AS ds
+JOIN type AS t ON ds.type = t.id
+JOIN cdn ON ds.cdn_id = cdn.id
+JOIN tenant ON ds.tenant_id = tenant.id
+WHERE ds.ssl_key_version >= 1
+AND ($1 = '' OR cdn.name = $1)
+AND ($2::bigint[] IS NULL OR ds.tenant_id = ANY($2::bigint[]))
+ORDER BY cdn.name, ds.xml_id
+`
+	var tenantIDArr interface{}
+	if tenantIDs != nil {
+		tenantIDArr = pq.Array(tenantIDs)
+	}
+	rows, err := tx.Query(qry, cdn, tenantIDArr)
+	if err != nil {
+		return nil, errors.New("querying delivery services with SSL keys: " + err.Error())
+	}
+	defer rows.Close()
+
+	dses := []dsInventoryInfo{}
+	for rows.Next() {
+		ds := dsInventoryInfo{}
+		dsType := ""
+		if err := rows.Scan(&ds.XMLID, &ds.ID, &ds.Protocol, &dsType, &ds.RoutingName, &ds.CDN, &ds.CDNDomain, &ds.KeyVersion, &ds.TenantID, &ds.Tenant); err != nil {
+			return nil, errors.New("scanning delivery services with SSL keys: " + err.Error())
+		}
+		ds.Type = tc.DSTypeFromString(dsType)
+		dses = append(dses, ds)
+	}
+	return dses, nil
+}
+
+// getSSLKeysInventory returns the certificates of the given Delivery Services in Traffic Vault, as of now.
+// Certificates which can't be read or parsed are included, with their Error set.
+func getSSLKeysInventory(tx *sql.Tx, tv trafficvault.TrafficVault, dses []dsInventoryInfo, now time.Time) ([]tc.DeliveryServiceCertificate, error) {
+	xmlIDs := make([]string, 0, len(dses))
+	for _, ds := range dses {
+		xmlIDs = append(xmlIDs, ds.XMLID)
+	}
+	matchLists, err := GetDeliveryServicesMatchLists(xmlIDs, tx)
+	if err != nil {
+		return nil, errors.New("getting delivery service match lists: " + err.Error())
+	}
+
+	certs := make([]tc.DeliveryServiceCertificate, 0, len(dses))
+	for _, ds := range dses {
+		cert := tc.DeliveryServiceCertificate{
+			DeliveryService:         ds.XMLID,
+			CDN:                     ds.CDN,
+			TenantID:                ds.TenantID,
+			Tenant:                  ds.Tenant,
+			Version:                 util.JSONIntStr(ds.KeyVersion),
+			SubjectAlternativeNames: []string{},
+			UnmatchedHostnames:      []string{},
+		}
+		keys, ok, err := tv.GetDeliveryServiceSSLKeys(ds.XMLID, strconv.FormatInt(ds.KeyVersion, 10), tx)
+		if err != nil {
+			log.Errorf("SSL keys inventory: getting SSL keys for delivery service '%s' version %d: %s", ds.XMLID, ds.KeyVersion, err.Error())
+			cert.Error = util.StrPtr("getting SSL keys from Traffic Vault failed")
+			certs = append(certs, cert)
+			continue
+		}
+		if !ok {
+			cert.Error = util.StrPtr("no SSL keys found in Traffic Vault")
+			certs = append(certs, cert)
+			continue
+		}
+		cert.AuthType = keys.AuthType
+		hosts := exampleURLHosts(MakeExampleURLs(ds.Protocol, ds.Type, ds.RoutingName, matchLists[ds.XMLID], ds.CDNDomain))
+		if err := describeCertificate(&cert, keys.Certificate.Crt, hosts, now); err != nil {
+			cert.Error = util.StrPtr(err.Error())
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// describeCertificate sets the fields of cert describing the given base64-encoded PEM certificate and chain, as stored in Traffic Vault, which must cover the given host names.
+func describeCertificate(cert *tc.DeliveryServiceCertificate, encodedCrt string, hosts []string, now time.Time) error {
+	pemCrt, err := base64.StdEncoding.DecodeString(encodedCrt)
+	if err != nil {
+		return errors.New("base64 decoding certificate: " + err.Error())
+	}
+	parsed := []*x509.Certificate{}
+	for block, rest := pem.Decode(pemCrt); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return errors.New("parsing certificate: " + err.Error())
+		}
+		parsed = append(parsed, c)
+	}
+	if len(parsed) == 0 {
+		return errors.New("no certificate found")
+	}
+
+	leaf := parsed[0]
+	cert.Subject = leaf.Subject.String()
+	cert.Issuer = leaf.Issuer.String()
+	cert.KeyType = certKeyType(leaf)
+	cert.SubjectAlternativeNames = append([]string{}, leaf.DNSNames...)
+	cert.NotBefore = leaf.NotBefore
+	cert.NotAfter = leaf.NotAfter
+	cert.DaysToExpiry = daysToExpiry(leaf.NotAfter, now)
+
+	intermediates := x509.NewCertPool()
+	for _, c := range parsed[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{Intermediates: intermediates, CurrentTime: now}); err != nil {
+		cert.ChainError = util.StrPtr(err.Error())
+	} else {
+		cert.ChainValid = true
+	}
+
+	for _, host := range hosts {
+		if err := leaf.VerifyHostname(host); err != nil {
+			cert.UnmatchedHostnames = append(cert.UnmatchedHostnames, host)
+		}
+	}
+	cert.HostnamesMatch = len(cert.UnmatchedHostnames) == 0
+	return nil
+}
+
+// certKeyType returns the algorithm of the certificate's public key, named as the tc.SSLKeyAlgorithm constants are.
+func certKeyType(cert *x509.Certificate) string {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return "RSA-" + strconv.Itoa(key.N.BitLen())
+	case *ecdsa.PublicKey:
+		switch key.Curve.Params().Name {
+		case "P-256":
+			return tc.SSLKeyAlgorithmECDSAP256
+		case "P-384":
+			return tc.SSLKeyAlgorithmECDSAP384
+		}
+		return "ECDSA-" + key.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	}
+	return cert.PublicKeyAlgorithm.String()
+}
+
+// daysToExpiry returns the number of whole days from now until notAfter, rounded down, so a certificate which has expired has a negative number of days.
+func daysToExpiry(notAfter time.Time, now time.Time) int {
+	left := notAfter.Sub(now)
+	days := int(left / (24 * time.Hour))
+	if left < 0 && left%(24*time.Hour) != 0 {
+		days--
+	}
+	return days
+}
+
+// GetSSLKeysInventory lists the latest certificate of every Delivery Service with SSL keys in Traffic Vault, in every CDN, which the user's tenant may see.
+// The list may be filtered by CDN name, by tenant ID, and to certificates which expire within a number of days.
+func GetSSLKeysInventory(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, errors.New("the Traffic Vault service is unavailable"), errors.New("getting SSL keys inventory: Traffic Vault is not configured"))
+		return
+	}
+
+	expiresWithinDays := -1
+	if days, ok := inf.Params["expiresWithinDays"]; ok {
+		d, err := strconv.Atoi(days)
+		if err != nil || d < 0 {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("expiresWithinDays must be a non-negative integer"), nil)
+			return
+		}
+		expiresWithinDays = d
+	}
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(inf.Tx.Tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting user tenants: "+err.Error()))
+		return
+	}
+	if tenantParam, ok := inf.Params["tenant"]; ok {
+		tenantID, err := strconv.Atoi(tenantParam)
+		if err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("tenant must be an integer"), nil)
+			return
+		}
+		visible := []int{}
+		for _, id := range tenantIDs {
+			if id == tenantID {
+				visible = append(visible, id)
+			}
+		}
+		tenantIDs = visible
+	}
+
+	dses, err := getSSLKeysInventoryDSes(inf.Tx.Tx, inf.Params["cdn"], tenantIDs)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting SSL keys inventory: "+err.Error()))
+		return
+	}
+	certs, err := getSSLKeysInventory(inf.Tx.Tx, inf.Config.TrafficVault, dses, time.Now())
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting SSL keys inventory: "+err.Error()))
+		return
+	}
+	if expiresWithinDays >= 0 {
+		certs = filterExpiringCertificates(certs, expiresWithinDays)
+	}
+	api.WriteResp(w, r, certs)
+}
+
+// filterExpiringCertificates returns the certificates which expire within the given number of days, including those which have expired. Certificates which couldn't be read are not included.
+func filterExpiringCertificates(certs []tc.DeliveryServiceCertificate, days int) []tc.DeliveryServiceCertificate {
+	expiring := []tc.DeliveryServiceCertificate{}
+	for _, cert := range certs {
+		if cert.Error == nil && cert.DaysToExpiry <= days {
+			expiring = append(expiring, cert)
+		}
+	}
+	return expiring
+}
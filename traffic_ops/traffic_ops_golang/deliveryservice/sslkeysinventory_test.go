@@ -0,0 +1,102 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestDescribeCertificate(t *testing.T) {
+	_, crt, _, err := GenerateCert("foo.example.net", "US", "Denver", "CO", "Org", "Unit", tc.SSLKeyAlgorithmECDSAP256)
+	if err != nil {
+		t.Fatalf("generating cert: %v", err)
+	}
+
+	now := time.Now()
+	cert := tc.DeliveryServiceCertificate{}
+	if err := describeCertificate(&cert, string(crt), []string{"foo.example.net", "bar.example.net"}, now); err != nil {
+		t.Fatalf("describing certificate: %v", err)
+	}
+	if cert.KeyType != tc.SSLKeyAlgorithmECDSAP256 {
+		t.Errorf("expected key type %s, actual: %s", tc.SSLKeyAlgorithmECDSAP256, cert.KeyType)
+	}
+	if len(cert.SubjectAlternativeNames) != 1 || cert.SubjectAlternativeNames[0] != "foo.example.net" {
+		t.Errorf("expected SANs [foo.example.net], actual: %v", cert.SubjectAlternativeNames)
+	}
+	if !strings.Contains(cert.Subject, "foo.example.net") || cert.Issuer != cert.Subject {
+		t.Errorf("expected self-signed certificate for foo.example.net, actual subject '%s' issuer '%s'", cert.Subject, cert.Issuer)
+	}
+	if cert.ChainValid || cert.ChainError == nil {
+		t.Error("expected self-signed certificate chain to be invalid")
+	}
+	if cert.HostnamesMatch || len(cert.UnmatchedHostnames) != 1 || cert.UnmatchedHostnames[0] != "bar.example.net" {
+		t.Errorf("expected only bar.example.net to be unmatched, actual: %v", cert.UnmatchedHostnames)
+	}
+	if cert.DaysToExpiry != daysToExpiry(cert.NotAfter, now) || cert.DaysToExpiry <= 0 {
+		t.Errorf("expected a positive number of days to expiry, actual: %d", cert.DaysToExpiry)
+	}
+
+	if err := describeCertificate(&tc.DeliveryServiceCertificate{}, "not base64!", nil, now); err == nil {
+		t.Error("expected describing an invalid certificate to return an error")
+	}
+}
+
+func TestDaysToExpiry(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		notAfter time.Time
+		expected int
+	}{
+		{now.Add(24*time.Hour*10 + time.Hour), 10},
+		{now.Add(time.Hour), 0},
+		{now, 0},
+		{now.Add(-time.Hour), -1},
+		{now.Add(-24 * time.Hour), -1},
+		{now.Add(-24*time.Hour - time.Hour), -2},
+	}
+	for _, test := range tests {
+		if actual := daysToExpiry(test.notAfter, now); actual != test.expected {
+			t.Errorf("expected %v to be %d days from %v, actual: %d", test.notAfter, test.expected, now, actual)
+		}
+	}
+}
+
+func TestMakeSSLKeyExpiryNotifications(t *testing.T) {
+	notAfter := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	certs := []tc.DeliveryServiceCertificate{
+		{DeliveryService: "ds2", CDN: "cdn1", NotAfter: notAfter, DaysToExpiry: 10},
+		{DeliveryService: "ds1", CDN: "cdn1", NotAfter: notAfter, DaysToExpiry: -1},
+		{DeliveryService: "ds3", CDN: "cdn2", NotAfter: notAfter, DaysToExpiry: 40},
+		{DeliveryService: "ds4", CDN: "cdn2", Error: util.StrPtr("no SSL keys found in Traffic Vault")},
+	}
+	notifications := makeSSLKeyExpiryNotifications(filterExpiringCertificates(certs, 30), 30)
+	if len(notifications) != 1 {
+		t.Fatalf("expected a notification for 1 CDN, actual: %v", notifications)
+	}
+	expected := sslKeyExpiryNotificationPrefix + "2 expire within 30 days: ds1 (expired 2021-04-01), ds2 (expires 2021-04-01)"
+	if notifications["cdn1"] != expected {
+		t.Errorf("expected notification '%s', actual: '%s'", expected, notifications["cdn1"])
+	}
+}
@@ -54,7 +54,8 @@ SELECT job.id,
        asset_url,
        start_time,
        u.username AS createdBy,
-       ds.xml_id AS dsId
+       ds.xml_id AS dsId,
+       job.invalidation_type
 FROM job
 JOIN tm_user u ON job.job_user = u.id
 JOIN deliveryservice ds  ON job.job_deliveryservice = ds.id
@@ -71,7 +72,8 @@ INSERT INTO job (
 	keyword,
 	parameters,
 	start_time,
-	status)
+	status,
+	invalidation_type)
 VALUES (
 	1::bigint,
 	'file',
@@ -87,7 +89,8 @@ VALUES (
 	'PURGE',
 	$6,
 	$7,
-	1::bigint
+	1::bigint,
+	$8
 )
 RETURNING
 	asset_url,
@@ -100,7 +103,8 @@ RETURNING
 	 WHERE tm_user.id=job_user) AS createdBy,
 	keyword,
 	parameters,
-	start_time
+	start_time,
+	invalidation_type
 `
 
 const revalQuery = `
@@ -148,7 +152,8 @@ RETURNING job.asset_url,
           job.id,
           job.keyword,
           job.parameters,
-          job.start_time
+          job.start_time,
+          job.invalidation_type
 `
 
 const putInfoQuery = `
@@ -160,6 +165,7 @@ SELECT job.id AS id,
        job.asset_url AS assetURL,
        job.parameters,
        job.start_time AS start_time,
+       job.invalidation_type,
        origin.protocol || '://' || origin.fqdn || rtrim(concat(':', origin.port), ':') AS OFQDN
 FROM job
 INNER JOIN origin ON origin.deliveryservice=job.job_deliveryservice AND origin.is_primary
@@ -186,7 +192,8 @@ RETURNING job.asset_url,
           job.id,
           job.keyword,
           job.parameters,
-          job.start_time
+          job.start_time,
+          job.invalidation_type
 `
 
 type apiResponse struct {
@@ -209,14 +216,18 @@ func (job *InvalidationJob) Read(h http.Header, useIMS bool) ([]interface{}, err
 	var maxTime time.Time
 	var runSecond bool
 	queryParamsToSQLCols := map[string]dbhelpers.WhereColumnInfo{
-		"id":              dbhelpers.WhereColumnInfo{"job.id", api.IsInt},
-		"keyword":         dbhelpers.WhereColumnInfo{"job.keyword", nil},
-		"assetUrl":        dbhelpers.WhereColumnInfo{"job.asset_url", nil},
-		"startTime":       dbhelpers.WhereColumnInfo{"job.start_time", nil},
-		"userId":          dbhelpers.WhereColumnInfo{"job.job_user", api.IsInt},
-		"createdBy":       dbhelpers.WhereColumnInfo{`(SELECT tm_user.username FROM tm_user WHERE tm_user.id=job.job_user)`, nil},
-		"deliveryService": dbhelpers.WhereColumnInfo{`(SELECT deliveryservice.xml_id FROM deliveryservice WHERE deliveryservice.id=job.job_deliveryservice)`, nil},
-		"dsId":            dbhelpers.WhereColumnInfo{"job.job_deliveryservice", api.IsInt},
+		"id":               dbhelpers.WhereColumnInfo{"job.id", api.IsInt},
+		"keyword":          dbhelpers.WhereColumnInfo{"job.keyword", nil},
+		"assetUrl":         dbhelpers.WhereColumnInfo{"job.asset_url", nil},
+		"startTime":        dbhelpers.WhereColumnInfo{"job.start_time", nil},
+		"userId":           dbhelpers.WhereColumnInfo{"job.job_user", api.IsInt},
+		"createdBy":        dbhelpers.WhereColumnInfo{`(SELECT tm_user.username FROM tm_user WHERE tm_user.id=job.job_user)`, nil},
+		"deliveryService":  dbhelpers.WhereColumnInfo{`(SELECT deliveryservice.xml_id FROM deliveryservice WHERE deliveryservice.id=job.job_deliveryservice)`, nil},
+		"dsId":             dbhelpers.WhereColumnInfo{"job.job_deliveryservice", api.IsInt},
+		"invalidationType": dbhelpers.WhereColumnInfo{"job.invalidation_type", nil},
+	}
+	if !supportsInvalidationTypes(job.APIInfo()) {
+		delete(queryParamsToSQLCols, "invalidationType")
 	}
 
 	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(job.APIInfo().Params, queryParamsToSQLCols)
@@ -265,12 +276,13 @@ func (job *InvalidationJob) Read(h http.Header, useIMS bool) ([]interface{}, err
 			&j.AssetURL,
 			&j.StartTime,
 			&j.CreatedBy,
-			&j.DeliveryService)
+			&j.DeliveryService,
+			&j.InvalidationType)
 		if err != nil {
 			return nil, nil, fmt.Errorf("parsing db response: %v", err), http.StatusInternalServerError, nil
 		}
 
-		returnable = append(returnable, j)
+		returnable = append(returnable, versionedJob(job.APIInfo(), j))
 	}
 
 	if err := rows.Err(); err != nil {
@@ -296,6 +308,10 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !supportsInvalidationTypes(inf) {
+		job.InvalidationType = nil
+	}
+
 	w.Header().Set(rfc.ContentType, rfc.ApplicationJSON)
 	if err := job.Validate(inf.Tx.Tx); err != nil {
 		response := tc.Alerts{
@@ -348,6 +364,11 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if userErr, sysErr, errCode = tenant.CheckJobQuota(inf.Tx.Tx, int(dsid)); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
 	row := inf.Tx.Tx.QueryRow(insertQuery,
 		dsid,
 		*job.Regex,
@@ -355,7 +376,8 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		dsid,
 		inf.User.ID,
 		fmt.Sprintf("TTL:%dh", ttl),
-		(*job.StartTime).Time)
+		(*job.StartTime).Time,
+		job.Type())
 
 	result := tc.InvalidationJob{}
 	err = row.Scan(&result.AssetURL,
@@ -364,7 +386,8 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		&result.CreatedBy,
 		&result.Keyword,
 		&result.Parameters,
-		&result.StartTime)
+		&result.StartTime,
+		&result.InvalidationType)
 	if err != nil {
 		userErr, sysErr, errCode = api.ParseDBError(err)
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
@@ -379,7 +402,7 @@ func Create(w http.ResponseWriter, r *http.Request) {
 	conflicts := tc.ValidateJobUniqueness(inf.Tx.Tx, dsid, job.StartTime.Time, *result.AssetURL, ttl)
 	response := apiResponse{
 		make([]tc.Alert, len(conflicts)+1),
-		result,
+		versionedJob(inf, result),
 	}
 	for i, conflict := range conflicts {
 		response.Alerts[i] = tc.Alert{
@@ -387,8 +410,12 @@ func Create(w http.ResponseWriter, r *http.Request) {
 			Level: tc.WarnLevel.String(),
 		}
 	}
+	created := "Invalidation request created"
+	if supportsInvalidationTypes(inf) {
+		created = *result.InvalidationType + " invalidation request created"
+	}
 	response.Alerts[len(conflicts)] = tc.Alert{
-		fmt.Sprintf("Invalidation request created for %v, start:%v end %v", *result.AssetURL, job.StartTime.Time,
+		fmt.Sprintf("%s for %v, start:%v end %v", created, *result.AssetURL, job.StartTime.Time,
 			job.StartTime.Add(time.Hour*time.Duration(ttl))),
 		tc.SuccessLevel.String(),
 	}
@@ -407,9 +434,9 @@ func Create(w http.ResponseWriter, r *http.Request) {
 	if len(conflicts) > 0 {
 		duplicate = "(duplicate) "
 	}
-	api.CreateChangeLogRawTx(api.ApiChange, api.Created+" content invalidation job "+duplicate+"- ID: "+
+	createAuditLog(inf, api.Created+" content invalidation job "+duplicate+"- ID: "+
 		strconv.FormatUint(*result.ID, 10)+" DS: "+*result.DeliveryService+" URL: '"+*result.AssetURL+
-		"' Params: '"+*result.Parameters+"'", inf.User, inf.Tx.Tx)
+		"' Params: '"+*result.Parameters+"' Type: "+*result.InvalidationType, api.Created, dsid, *result.ID, nil, &result)
 }
 
 // Used by PUT requests to `/jobs`, replaces an existing content invalidation job
@@ -435,6 +462,7 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		&job.AssetURL,
 		&job.Parameters,
 		&job.StartTime,
+		&job.InvalidationType,
 		&oFQDN)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -447,6 +475,7 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
 		return
 	}
+	before := job
 
 	if ok, err := IsUserAuthorizedToModifyDSID(inf, dsid); err != nil {
 		sysErr = fmt.Errorf("Checking user permissions on DS #%d: %v", dsid, err)
@@ -481,6 +510,9 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !supportsInvalidationTypes(inf) {
+		input.InvalidationType = nil
+	}
 	if err := input.Validate(); err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
 		return
@@ -522,6 +554,13 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if input.InvalidationType != nil && *job.InvalidationType != *input.InvalidationType {
+		userErr = errors.New("Cannot change 'invalidationType' of existing invalidation job!")
+		errCode = http.StatusConflict
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, nil)
+		return
+	}
+
 	row = inf.Tx.Tx.QueryRow(updateQuery,
 		input.AssetURL,
 		input.Keyword,
@@ -534,7 +573,8 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		&job.ID,
 		&job.Keyword,
 		&job.Parameters,
-		&job.StartTime)
+		&job.StartTime,
+		&job.InvalidationType)
 	if err != nil {
 		sysErr = fmt.Errorf("Updating a job: %v", err)
 		errCode = http.StatusInternalServerError
@@ -551,7 +591,7 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	conflicts := tc.ValidateJobUniqueness(inf.Tx.Tx, dsid, input.StartTime.Time, *input.AssetURL, ttlHours)
 	response := apiResponse{
 		make([]tc.Alert, len(conflicts)+1),
-		job,
+		versionedJob(inf, job),
 	}
 	for i, conflict := range conflicts {
 		response.Alerts[i] = tc.Alert{
@@ -576,7 +616,7 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(http.CanonicalHeaderKey("content-type"), rfc.ApplicationJSON)
 	w.Write(append(resp, '\n'))
 
-	api.CreateChangeLogRawTx(api.ApiChange, api.Updated+" content invalidation job - ID: "+strconv.FormatUint(*job.ID, 10)+" DS: "+*job.DeliveryService+" URL: '"+*job.AssetURL+"' Params: '"+*job.Parameters+"'", inf.User, inf.Tx.Tx)
+	createAuditLog(inf, api.Updated+" content invalidation job - ID: "+strconv.FormatUint(*job.ID, 10)+" DS: "+*job.DeliveryService+" URL: '"+*job.AssetURL+"' Params: '"+*job.Parameters+"'", api.Updated, dsid, *job.ID, &before, &job)
 }
 
 // Used by DELETE requests to `/jobs`, deletes an existing content invalidation job
@@ -635,7 +675,8 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 		&result.ID,
 		&result.Keyword,
 		&result.Parameters,
-		&result.StartTime)
+		&result.StartTime,
+		&result.InvalidationType)
 	if err != nil {
 		sysErr = fmt.Errorf("deleting job #%s: %v", inf.Params["id"], err)
 		errCode = http.StatusInternalServerError
@@ -650,7 +691,7 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := apiResponse{[]tc.Alert{tc.Alert{"Content invalidation job was deleted", tc.SuccessLevel.String()}}, result}
+	response := apiResponse{[]tc.Alert{tc.Alert{"Content invalidation job was deleted", tc.SuccessLevel.String()}}, versionedJob(inf, result)}
 	resp, err := json.Marshal(response)
 	if err != nil {
 		sysErr = fmt.Errorf("encoding response: %v", err)
@@ -662,7 +703,48 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set(http.CanonicalHeaderKey("content-type"), rfc.ApplicationJSON)
 	w.Write(append(resp, '\n'))
 
-	api.CreateChangeLogRawTx(api.ApiChange, api.Deleted+" content invalidation job - ID: "+strconv.FormatUint(*result.ID, 10)+" DS: "+*result.DeliveryService+" URL: '"+*result.AssetURL+"' Params: '"+*result.Parameters+"'", inf.User, inf.Tx.Tx)
+	createAuditLog(inf, api.Deleted+" content invalidation job - ID: "+strconv.FormatUint(*result.ID, 10)+" DS: "+*result.DeliveryService+" URL: '"+*result.AssetURL+"' Params: '"+*result.Parameters+"'", api.Deleted, dsid, *result.ID, &result, nil)
+}
+
+// supportsInvalidationTypes returns whether the API version of the request has content invalidation job types, which
+// were added in API 4.0. Earlier versions neither accept nor return them, and every job they create is a REFRESH job.
+func supportsInvalidationTypes(inf *api.APIInfo) bool {
+	return inf.Version != nil && inf.Version.Major >= 4
+}
+
+// versionedJob returns the given job as the API version of the request represents it.
+func versionedJob(inf *api.APIInfo, job tc.InvalidationJob) tc.InvalidationJob {
+	if !supportsInvalidationTypes(inf) {
+		job.InvalidationType = nil
+	}
+	return job
+}
+
+// auditObjectType is the object type of content invalidation jobs in the audit log.
+const auditObjectType = "job"
+
+// createAuditLog records a change to a content invalidation job of the Delivery Service with the given ID in the change log, and queues it for delivery to webhooks.
+// Errors are logged rather than returned, because the response has already been written by the time the change is logged.
+func createAuditLog(inf *api.APIInfo, msg string, action string, dsID uint, jobID uint64, before *tc.InvalidationJob, after *tc.InvalidationJob) {
+	audit := api.Audit{
+		Action:     action,
+		ObjectType: auditObjectType,
+		ObjectID:   strconv.FormatUint(jobID, 10),
+		Before:     before,
+		After:      after,
+	}
+	if _, cdn, ok, err := dbhelpers.GetDSNameAndCDNFromID(inf.Tx.Tx, int(dsID)); err != nil {
+		log.Errorf("getting the CDN of content invalidation job #%d: %v", jobID, err)
+	} else if ok {
+		cdnName := string(cdn)
+		audit.CDNName = &cdnName
+	}
+	if tenantID, ok, err := tenant.GetDSTenantIDByIDTx(inf.Tx.Tx, int(dsID)); err != nil {
+		log.Errorf("getting the Tenant of content invalidation job #%d: %v", jobID, err)
+	} else if ok {
+		audit.TenantID = tenantID
+	}
+	api.CreateAuditLogTx(api.ApiChange, msg, audit, inf)
 }
 
 func setRevalFlags(d interface{}, tx *sql.Tx) error {
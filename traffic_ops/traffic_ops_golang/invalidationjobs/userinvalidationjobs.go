@@ -29,6 +29,7 @@ import "time"
 import "github.com/apache/trafficcontrol/lib/go-tc"
 import "github.com/apache/trafficcontrol/lib/go-log"
 import "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+import "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 
 const userReadQuery = `
 SELECT job.agent,
@@ -95,6 +96,13 @@ func CreateUserJob(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if userErr, sysErr, errCode = tenant.CheckJobQuota(inf.Tx.Tx, int(*job.DSID)); userErr != nil || sysErr != nil {
+		userErr = api.LogErr(r, errCode, userErr, sysErr)
+		alerts.AddNewAlert(tc.ErrorLevel, userErr.Error())
+		api.WriteAlerts(w, r, errCode, alerts)
+		return
+	}
+
 	resultRow := inf.Tx.Tx.QueryRow(insertQuery,
 		job.DSID,
 		job.Regex,
@@ -102,7 +110,8 @@ func CreateUserJob(w http.ResponseWriter, r *http.Request) {
 		job.DSID,
 		inf.User.ID,
 		fmt.Sprintf("TTL:%dh", *job.TTL),
-		job.StartTime.Time)
+		job.StartTime.Time,
+		tc.InvalidationTypeRefresh)
 
 	result := tc.InvalidationJob{}
 	err := resultRow.Scan(&result.AssetURL,
@@ -111,7 +120,8 @@ func CreateUserJob(w http.ResponseWriter, r *http.Request) {
 		&result.CreatedBy,
 		&result.Keyword,
 		&result.Parameters,
-		&result.StartTime)
+		&result.StartTime,
+		&result.InvalidationType)
 	if err != nil {
 		userErr, sysErr, code := api.ParseDBError(err)
 		userErr = api.LogErr(r, code, userErr, sysErr)
@@ -136,7 +146,7 @@ func CreateUserJob(w http.ResponseWriter, r *http.Request) {
 	alerts.AddNewAlert(tc.SuccessLevel, "Invalidation Job creation was successful")
 	w.Header().Set(http.CanonicalHeaderKey("location"), inf.Config.URL.Scheme+"://"+r.Host+"/api/1.4/jobs?id="+strconv.FormatUint(uint64(*result.ID), 10))
 	api.WriteAlertsObj(w, r, http.StatusOK, alerts, result)
-	api.CreateChangeLogRawTx(api.ApiChange, api.Created+"content invalidation job: #"+strconv.FormatUint(*result.ID, 10), inf.User, inf.Tx.Tx)
+	createAuditLog(inf, api.Created+"content invalidation job: #"+strconv.FormatUint(*result.ID, 10), api.Created, *job.DSID, *result.ID, nil, &result)
 }
 
 // Gets all jobs that were created by the requesting user, and returns them in
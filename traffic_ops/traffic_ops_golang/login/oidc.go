@@ -0,0 +1,452 @@
+package login
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tocookie"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/jmoiron/sqlx"
+	"github.com/lestrrat-go/jwx/jwk"
+)
+
+// oidcDiscoveryPath is the path, relative to the Issuer Identifier, of an OpenID Connect provider's discovery document.
+const oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+// oidcRequestTimeout is the timeout of requests to the identity provider.
+const oidcRequestTimeout = 30 * time.Second
+
+// oidcMinJWKSRefresh is the minimum time between fetches of the JSON Web Key Set when a token is signed with an unknown key, to keep forged tokens from making Traffic Ops hammer the identity provider.
+const oidcMinJWKSRefresh = 30 * time.Second
+
+// oidcDiscovery is the subset of an OpenID Connect discovery document used by Traffic Ops.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcLoginRequest is the body of a request to log in with OpenID Connect. Either the Code of an authorization code flow, which Traffic Ops exchanges for an ID token, or the IDToken itself, must be given.
+type oidcLoginRequest struct {
+	Code        string `json:"code"`
+	RedirectURI string `json:"redirectUri"`
+	IDToken     string `json:"idToken"`
+	// Nonce must match the "nonce" claim of the ID token. It's required with an IDToken, so a token issued to another client can't be replayed; with a Code, it's optional, because the code can only be exchanged once, by Traffic Ops.
+	Nonce string `json:"nonce"`
+}
+
+// oidcProvider is an OpenID Connect identity provider. Its discovery document and JSON Web Key Set are fetched when first needed, and cached.
+type oidcProvider struct {
+	cfg        config.ConfigOIDC
+	client     *http.Client
+	jwksTTL    time.Duration
+	minRefresh time.Duration
+
+	m           sync.Mutex
+	discovery   *oidcDiscovery
+	keys        *jwk.Set
+	keysFetched time.Time
+}
+
+func newOIDCProvider(cfg config.ConfigOIDC) *oidcProvider {
+	return &oidcProvider{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: oidcRequestTimeout},
+		jwksTTL:    time.Duration(cfg.JWKSCacheSeconds) * time.Second,
+		minRefresh: oidcMinJWKSRefresh,
+	}
+}
+
+// getDiscovery returns the provider's discovery document, fetching it if it hasn't been yet. The caller must hold p.m.
+func (p *oidcProvider) getDiscovery() (*oidcDiscovery, error) {
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+	discoveryURL := strings.TrimSuffix(p.cfg.Issuer, "/") + oidcDiscoveryPath
+	resp, err := p.client.Get(discoveryURL)
+	if err != nil {
+		return nil, errors.New("fetching discovery document '" + discoveryURL + "': " + err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching discovery document '%s': status %d", discoveryURL, resp.StatusCode)
+	}
+	discovery := oidcDiscovery{}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, errors.New("decoding discovery document '" + discoveryURL + "': " + err.Error())
+	}
+	if discovery.Issuer != p.cfg.Issuer {
+		return nil, errors.New("discovery document issuer '" + discovery.Issuer + "' doesn't match configured issuer '" + p.cfg.Issuer + "'")
+	}
+	if discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("discovery document '" + discoveryURL + "' is missing token_endpoint or jwks_uri")
+	}
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+// fetchKeys fetches the provider's JSON Web Key Set. The caller must hold p.m.
+func (p *oidcProvider) fetchKeys() error {
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		return err
+	}
+	keys, err := jwk.FetchHTTP(discovery.JWKSURI, jwk.WithHTTPClient(p.client))
+	if err != nil {
+		return errors.New("fetching JSON web key set '" + discovery.JWKSURI + "': " + err.Error())
+	}
+	p.keys = keys
+	p.keysFetched = time.Now()
+	return nil
+}
+
+// getKey returns the public key with the given ID. The cached key set is refetched if it has expired, or if it doesn't contain the key and wasn't fetched recently, in case the provider has rotated its keys.
+func (p *oidcProvider) getKey(kid string) (interface{}, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if p.keys == nil || time.Since(p.keysFetched) > p.jwksTTL {
+		if err := p.fetchKeys(); err != nil {
+			return nil, err
+		}
+	}
+	found := p.keys.LookupKeyID(kid)
+	if len(found) == 0 && time.Since(p.keysFetched) >= p.minRefresh {
+		if err := p.fetchKeys(); err != nil {
+			return nil, err
+		}
+		found = p.keys.LookupKeyID(kid)
+	}
+	if len(found) == 0 {
+		return nil, errors.New("no key found with ID '" + kid + "'")
+	}
+	key, err := found[0].Materialize()
+	if err != nil {
+		return nil, errors.New("materializing key '" + kid + "': " + err.Error())
+	}
+	return key, nil
+}
+
+// exchangeCode exchanges an authorization code for an ID token at the provider's token endpoint, per OpenID Connect Core 1.0 section 3.1.3.
+func (p *oidcProvider) exchangeCode(code string, redirectURI string) (string, error) {
+	p.m.Lock()
+	discovery, err := p.getDiscovery()
+	p.m.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	data := url.Values{}
+	data.Add("grant_type", "authorization_code")
+	data.Add("code", code)
+	data.Add("redirect_uri", redirectURI)
+	req, err := http.NewRequest(http.MethodPost, discovery.TokenEndpoint, bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return "", errors.New("creating token request: " + err.Error())
+	}
+	req.Header.Set(rfc.ContentType, "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret)) // per RFC6749 section 2.3.1
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", errors.New("requesting token: " + err.Error())
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.New("reading token response: " + err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("requesting token: status %d: %s", resp.StatusCode, string(body))
+	}
+	tokens := struct {
+		IDToken string `json:"id_token"`
+	}{}
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return "", errors.New("decoding token response: " + err.Error())
+	}
+	if tokens.IDToken == "" {
+		return "", errors.New("token response has no id_token")
+	}
+	return tokens.IDToken, nil
+}
+
+// verify verifies the signature of the given ID token against the provider's keys, and validates its issuer, audience, expiration, and nonce, per OpenID Connect Core 1.0 section 3.1.3.7. It returns the token's claims.
+func (p *oidcProvider) verify(idToken string, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+		default:
+			return nil, errors.New("unsupported signing algorithm '" + token.Method.Alg() + "'")
+		}
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(kid)
+	})
+	if err != nil {
+		return nil, errors.New("verifying ID token: " + err.Error())
+	}
+
+	if iss, _ := claims["iss"].(string); iss != p.cfg.Issuer {
+		return nil, errors.New("ID token issuer '" + iss + "' doesn't match '" + p.cfg.Issuer + "'")
+	}
+	if !claimsHaveAudience(claims, p.cfg.ClientID) {
+		return nil, errors.New("ID token audience doesn't include client ID '" + p.cfg.ClientID + "'")
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("ID token is expired")
+	}
+	if nonce != "" {
+		if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+			return nil, errors.New("ID token nonce doesn't match")
+		}
+	}
+	return claims, nil
+}
+
+// claimsHaveAudience returns whether the "aud" claim, which may be a single string or an array, contains the given audience.
+func claimsHaveAudience(claims jwt.MapClaims, audience string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// claimStrings returns the named claim as a list of strings. The claim may be a single string or an array.
+func claimStrings(claims jwt.MapClaims, name string) []string {
+	switch val := claims[name].(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		strs := make([]string, 0, len(val))
+		for _, v := range val {
+			if s, ok := v.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	}
+	return nil
+}
+
+// matchGroupMapping returns the first of the given mappings whose group is in groups, or nil if there is none.
+func matchGroupMapping(mappings []config.ConfigOIDCGroupMapping, groups []string) *config.ConfigOIDCGroupMapping {
+	for i, mapping := range mappings {
+		for _, group := range groups {
+			if group == mapping.Group {
+				return &mappings[i]
+			}
+		}
+	}
+	return nil
+}
+
+// oidcUser is the identity of a user asserted by an ID token.
+type oidcUser struct {
+	// Issuer and Subject identify the identity provider user, to which the Traffic Ops user is linked.
+	Issuer   string
+	Subject  string
+	Username string
+	Email    *string
+	FullName *string
+	Groups   []string
+}
+
+func oidcUserFromClaims(claims jwt.MapClaims, cfg config.ConfigOIDC) (oidcUser, error) {
+	user := oidcUser{Groups: claimStrings(claims, cfg.GroupsClaim)}
+	user.Issuer, _ = claims["iss"].(string)
+	user.Subject, _ = claims["sub"].(string)
+	if user.Subject == "" {
+		return oidcUser{}, errors.New("ID token has no 'sub' claim")
+	}
+	user.Username, _ = claims[cfg.UsernameClaim].(string)
+	if user.Username == "" {
+		return oidcUser{}, errors.New("ID token has no '" + cfg.UsernameClaim + "' claim")
+	}
+	if email, ok := claims["email"].(string); ok && email != "" {
+		user.Email = &email
+	}
+	if name, ok := claims["name"].(string); ok && name != "" {
+		user.FullName = &name
+	}
+	return user, nil
+}
+
+// provisionOIDCUser makes the Traffic Ops user for the given identity provider user ready to log in, creating it or updating its Role and Tenant from its mapped groups as configured.
+// An existing user may only log in if it's linked to the identity provider user, i.e. it was created by an OpenID Connect login of the same issuer and subject; local users never may, whatever their names.
+func provisionOIDCUser(tx *sql.Tx, cfg config.ConfigOIDC, user oidcUser) (error, error, int) {
+	userID := 0
+	roleID := 0
+	roleName := ""
+	tenantID := 0
+	issuer := ""
+	subject := ""
+	err := tx.QueryRow(`
+SELECT u.id, COALESCE(r.id, 0), COALESCE(r.name, ''), u.tenant_id, COALESCE(u.oidc_issuer, ''), COALESCE(u.oidc_subject, '')
+FROM tm_user u
+LEFT JOIN role r ON r.id = u.role
+WHERE u.username = $1
+`, user.Username).Scan(&userID, &roleID, &roleName, &tenantID, &issuer, &subject)
+	exists := true
+	if err == sql.ErrNoRows {
+		exists = false
+	} else if err != nil {
+		return nil, errors.New("getting user '" + user.Username + "': " + err.Error()), http.StatusInternalServerError
+	}
+
+	if exists && roleName == "disallowed" { // relies on the same unchanging role name assumption as auth.CheckLocalUserIsAllowed
+		return errors.New("user '" + user.Username + "' is not allowed to log in"), nil, http.StatusForbidden
+	}
+	if exists && (issuer != user.Issuer || subject != user.Subject) {
+		log.Warnf("OpenID Connect login: user '%s' (issuer '%s', subject '%s') is not linked to ID token issuer '%s', subject '%s'\n", user.Username, issuer, subject, user.Issuer, user.Subject)
+		return errors.New("user '" + user.Username + "' is not linked to this OpenID Connect identity"), nil, http.StatusForbidden
+	}
+	if exists && !cfg.SyncRoles {
+		return nil, nil, http.StatusOK
+	}
+	if !exists && !cfg.AutoProvision {
+		return errors.New("no Traffic Ops user exists for '" + user.Username + "'"), nil, http.StatusForbidden
+	}
+
+	mapping := matchGroupMapping(cfg.GroupMappings, user.Groups)
+	if mapping == nil {
+		return errors.New("none of the groups of user '" + user.Username + "' are mapped to a Traffic Ops Role"), nil, http.StatusForbidden
+	}
+	mappedRoleID := 0
+	if err := tx.QueryRow(`SELECT id FROM role WHERE name = $1`, mapping.Role).Scan(&mappedRoleID); err != nil {
+		return nil, errors.New("getting role '" + mapping.Role + "' mapped from group '" + mapping.Group + "': " + err.Error()), http.StatusInternalServerError
+	}
+	mappedTenantID := 0
+	if err := tx.QueryRow(`SELECT id FROM tenant WHERE name = $1`, mapping.Tenant).Scan(&mappedTenantID); err != nil {
+		return nil, errors.New("getting tenant '" + mapping.Tenant + "' mapped from group '" + mapping.Group + "': " + err.Error()), http.StatusInternalServerError
+	}
+
+	msg := ""
+	if !exists {
+		if err := tx.QueryRow(`
+INSERT INTO tm_user (username, email, full_name, role, tenant_id, oidc_issuer, oidc_subject)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id
+`, user.Username, user.Email, user.FullName, mappedRoleID, mappedTenantID, user.Issuer, user.Subject).Scan(&userID); err != nil {
+			return nil, errors.New("creating user '" + user.Username + "': " + err.Error()), http.StatusInternalServerError
+		}
+		msg = "USER: " + user.Username + ", ID: " + fmt.Sprint(userID) + ", ACTION: Created by OpenID Connect login with role " + mapping.Role + " and tenant " + mapping.Tenant
+	} else if roleID != mappedRoleID || tenantID != mappedTenantID {
+		if _, err := tx.Exec(`UPDATE tm_user SET role = $1, tenant_id = $2 WHERE id = $3`, mappedRoleID, mappedTenantID, userID); err != nil {
+			return nil, errors.New("updating role and tenant of user '" + user.Username + "': " + err.Error()), http.StatusInternalServerError
+		}
+		msg = "USER: " + user.Username + ", ID: " + fmt.Sprint(userID) + ", ACTION: Updated by OpenID Connect login to role " + mapping.Role + " and tenant " + mapping.Tenant
+	}
+	if msg != "" {
+		api.CreateChangeLogRawTx(api.ApiChange, msg, &auth.CurrentUser{ID: userID, UserName: user.Username}, tx)
+	}
+	return nil, nil, http.StatusOK
+}
+
+// OIDCLoginHandler logs in a user with an ID token from the OpenID Connect identity provider configured in the "oidc" section of cdn.conf, either given directly or obtained by exchanging an authorization code. Users who don't exist in Traffic Ops are created if auto-provisioning is enabled, with the Role and Tenant mapped from their identity provider groups, and linked to their identity provider users.
+func OIDCLoginHandler(db *sqlx.DB, cfg config.Config) http.HandlerFunc {
+	var provider *oidcProvider
+	if cfg.OIDC != nil {
+		provider = newOIDCProvider(*cfg.OIDC)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if provider == nil {
+			api.HandleErr(w, r, nil, http.StatusServiceUnavailable, errors.New("OpenID Connect login is not configured"), nil)
+			return
+		}
+
+		req := oidcLoginRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.HandleErr(w, r, nil, http.StatusBadRequest, errors.New("malformed request: "+err.Error()), nil)
+			return
+		}
+		idToken := req.IDToken
+		if idToken != "" && req.Nonce == "" {
+			api.HandleErr(w, r, nil, http.StatusBadRequest, errors.New("nonce is required with idToken"), nil)
+			return
+		}
+		if idToken == "" {
+			if req.Code == "" {
+				api.HandleErr(w, r, nil, http.StatusBadRequest, errors.New("either code or idToken is required"), nil)
+				return
+			}
+			var err error
+			if idToken, err = provider.exchangeCode(req.Code, req.RedirectURI); err != nil {
+				api.HandleErr(w, r, nil, http.StatusBadGateway, errors.New("bad response from OpenID Connect provider"), errors.New("exchanging OpenID Connect authorization code: "+err.Error()))
+				return
+			}
+		}
+
+		claims, err := provider.verify(idToken, req.Nonce)
+		if err != nil {
+			log.Warnln("OpenID Connect login: " + err.Error())
+			api.HandleErr(w, r, nil, http.StatusUnauthorized, errors.New("invalid ID token"), nil)
+			return
+		}
+		user, err := oidcUserFromClaims(claims, provider.cfg)
+		if err != nil {
+			api.HandleErr(w, r, nil, http.StatusUnauthorized, err, nil)
+			return
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("beginning transaction: "+err.Error()))
+			return
+		}
+		if userErr, sysErr, errCode := provisionOIDCUser(tx, provider.cfg, user); userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("committing transaction: "+err.Error()))
+			return
+		}
+
+		http.SetCookie(w, tocookie.GetCookie(user.Username, defaultCookieDuration, cfg.Secrets[0]))
+		api.WriteRespAlert(w, r, tc.SuccessLevel, "Successfully logged in.")
+	}
+}
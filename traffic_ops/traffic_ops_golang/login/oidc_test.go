@@ -0,0 +1,429 @@
+package login
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tocookie"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/jmoiron/sqlx"
+	"github.com/lestrrat-go/jwx/jwk"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const testClientID = "traffic-ops"
+
+const testNonce = "n-0S6"
+
+// testIdP is a stand-in OpenID Connect identity provider.
+type testIdP struct {
+	server      *httptest.Server
+	key         *rsa.PrivateKey
+	kid         string
+	jwksFetches int
+	code        string
+	idToken     string
+}
+
+func newTestIdP(t *testing.T) *testIdP {
+	idp := &testIdP{kid: "key-1", code: "the-code"}
+	idp.rotateKey(t, "key-1")
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcDiscovery{
+			Issuer:                idp.server.URL,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+			JWKSURI:               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.jwksFetches++
+		key, err := jwk.New(&idp.key.PublicKey)
+		if err != nil {
+			t.Fatalf("creating JWK: %v", err)
+		}
+		key.Set("kid", idp.kid)
+		json.NewEncoder(w).Encode(jwk.Set{Keys: []jwk.Key{key}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if id, _, _ := r.BasicAuth(); id != testClientID || r.FormValue("code") != idp.code {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "id_token": idp.idToken})
+	})
+	idp.server = httptest.NewServer(mux)
+	return idp
+}
+
+func (idp *testIdP) rotateKey(t *testing.T, kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %v", err)
+	}
+	idp.key = key
+	idp.kid = kid
+}
+
+func (idp *testIdP) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = idp.kid
+	signed, err := token.SignedString(idp.key)
+	if err != nil {
+		t.Fatalf("signing token: %v", err)
+	}
+	return signed
+}
+
+func (idp *testIdP) claims(username string, groups ...string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                idp.server.URL,
+		"aud":                testClientID,
+		"sub":                "00u1",
+		"preferred_username": username,
+		"email":              username + "@example.com",
+		"groups":             groups,
+		"nonce":              testNonce,
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+	}
+}
+
+func (idp *testIdP) config() config.ConfigOIDC {
+	return config.ConfigOIDC{
+		Issuer:           idp.server.URL,
+		ClientID:         testClientID,
+		ClientSecret:     "secret",
+		UsernameClaim:    "preferred_username",
+		GroupsClaim:      config.DefaultOIDCGroupsClaim,
+		AutoProvision:    true,
+		GroupMappings:    []config.ConfigOIDCGroupMapping{{Group: "cdn-admins", Role: "admin", Tenant: "root"}, {Group: "cdn-ops", Role: "operations", Tenant: "ops"}},
+		JWKSCacheSeconds: config.DefaultOIDCJWKSCacheSecs,
+	}
+}
+
+// responseStatus returns the status of the response to r, which error handlers record in the request context for the routing middleware to write.
+func responseStatus(w *httptest.ResponseRecorder, r *http.Request) int {
+	if status, ok := r.Context().Value(tc.StatusKey).(int); ok {
+		return status
+	}
+	return w.Code
+}
+
+func TestOIDCProviderVerify(t *testing.T) {
+	idp := newTestIdP(t)
+	defer idp.server.Close()
+	provider := newOIDCProvider(idp.config())
+
+	if _, err := provider.verify(idp.sign(t, idp.claims("alice")), ""); err != nil {
+		t.Fatalf("expected valid token to verify, actual error: %v", err)
+	}
+	if _, err := provider.verify(idp.sign(t, idp.claims("bob")), ""); err != nil {
+		t.Fatalf("expected valid token to verify, actual error: %v", err)
+	}
+	if idp.jwksFetches != 1 {
+		t.Errorf("expected JWKS to be fetched once and cached, actual fetches: %d", idp.jwksFetches)
+	}
+
+	tests := map[string]func(jwt.MapClaims){
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = []string{"someone-else"} },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		"no expiration":  func(c jwt.MapClaims) { delete(c, "exp") },
+	}
+	for name, modify := range tests {
+		claims := idp.claims("alice")
+		modify(claims)
+		if _, err := provider.verify(idp.sign(t, claims), ""); err == nil {
+			t.Errorf("%s: expected error, actual: nil", name)
+		}
+	}
+
+	claims := idp.claims("alice")
+	claims["aud"] = []string{"someone-else", testClientID}
+	if _, err := provider.verify(idp.sign(t, claims), testNonce); err != nil {
+		t.Errorf("expected token with audience array and matching nonce to verify, actual error: %v", err)
+	}
+	if _, err := provider.verify(idp.sign(t, claims), "other"); err == nil {
+		t.Error("expected error for mismatched nonce, actual: nil")
+	}
+
+	hmacToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, idp.claims("alice")).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("signing HMAC token: %v", err)
+	}
+	if _, err := provider.verify(hmacToken, ""); err == nil {
+		t.Error("expected error for HMAC-signed token, actual: nil")
+	}
+
+	forger := newTestIdP(t)
+	defer forger.server.Close()
+	forger.kid = idp.kid
+	forged := idp.claims("alice")
+	if _, err := provider.verify(forger.sign(t, forged), ""); err == nil {
+		t.Error("expected error for token signed with another key, actual: nil")
+	}
+}
+
+func TestOIDCProviderKeyRotation(t *testing.T) {
+	idp := newTestIdP(t)
+	defer idp.server.Close()
+	provider := newOIDCProvider(idp.config())
+	provider.minRefresh = 0
+
+	if _, err := provider.verify(idp.sign(t, idp.claims("alice")), ""); err != nil {
+		t.Fatalf("expected valid token to verify, actual error: %v", err)
+	}
+	idp.rotateKey(t, "key-2")
+	if _, err := provider.verify(idp.sign(t, idp.claims("alice")), ""); err != nil {
+		t.Fatalf("expected token signed with rotated key to verify, actual error: %v", err)
+	}
+	if idp.jwksFetches != 2 {
+		t.Errorf("expected JWKS to be refetched for unknown key, actual fetches: %d", idp.jwksFetches)
+	}
+
+	provider.minRefresh = time.Hour
+	idp.rotateKey(t, "key-3")
+	if _, err := provider.verify(idp.sign(t, idp.claims("alice")), ""); err == nil {
+		t.Error("expected error for unknown key within the minimum refresh interval, actual: nil")
+	}
+	if idp.jwksFetches != 2 {
+		t.Errorf("expected JWKS not to be refetched within the minimum refresh interval, actual fetches: %d", idp.jwksFetches)
+	}
+}
+
+func TestMatchGroupMapping(t *testing.T) {
+	mappings := []config.ConfigOIDCGroupMapping{{Group: "a", Role: "admin"}, {Group: "b", Role: "operations"}}
+	if m := matchGroupMapping(mappings, []string{"c", "b", "a"}); m == nil || m.Role != "admin" {
+		t.Errorf("expected first matching mapping 'admin', actual: %+v", m)
+	}
+	if m := matchGroupMapping(mappings, []string{"b"}); m == nil || m.Role != "operations" {
+		t.Errorf("expected mapping 'operations', actual: %+v", m)
+	}
+	if m := matchGroupMapping(mappings, []string{"c"}); m != nil {
+		t.Errorf("expected no mapping, actual: %+v", m)
+	}
+}
+
+func TestOIDCLoginHandlerAutoProvision(t *testing.T) {
+	idp := newTestIdP(t)
+	defer idp.server.Close()
+	idp.idToken = idp.sign(t, idp.claims("alice", "staff", "cdn-ops"))
+	oidcCfg := idp.config()
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT u.id").WithArgs("alice").WillReturnRows(sqlmock.NewRows([]string{"id", "role_id", "role_name", "tenant_id", "oidc_issuer", "oidc_subject"}))
+	mock.ExpectQuery("SELECT id FROM role").WithArgs("operations").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery("SELECT id FROM tenant").WithArgs("ops").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery("INSERT INTO tm_user").WithArgs("alice", "alice@example.com", nil, 3, 2, idp.server.URL, "00u1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+	mock.ExpectExec("INSERT INTO log").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	handler := OIDCLoginHandler(db, config.Config{OIDC: &oidcCfg, Secrets: []string{"secret"}})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/api/4.0/user/login/oidc", bytes.NewBufferString(`{"code":"the-code","redirectUri":"https://to.example.com/sso"}`))
+	handler(w, r)
+
+	if status := responseStatus(w, r); status != http.StatusOK {
+		t.Fatalf("expected status %d, actual: %d: %s", http.StatusOK, status, w.Body.String())
+	}
+	if !strings.Contains(w.Header().Get("Set-Cookie"), tocookie.Name+"=") {
+		t.Errorf("expected login cookie to be set, actual Set-Cookie: %s", w.Header().Get("Set-Cookie"))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOIDCLoginHandlerRejections(t *testing.T) {
+	idp := newTestIdP(t)
+	defer idp.server.Close()
+
+	type testCase struct {
+		body   func() string
+		cfg    func(*config.ConfigOIDC)
+		mock   func(sqlmock.Sqlmock)
+		status int
+	}
+	userCols := []string{"id", "role_id", "role_name", "tenant_id", "oidc_issuer", "oidc_subject"}
+	withToken := func(claims jwt.MapClaims) func() string {
+		return func() string { return `{"idToken":"` + idp.sign(t, claims) + `","nonce":"` + testNonce + `"}` }
+	}
+	tests := map[string]testCase{
+		"bad code": {
+			body:   func() string { return `{"code":"wrong"}` },
+			status: http.StatusBadGateway,
+		},
+		"unsigned token": {
+			body:   func() string { return `{"idToken":"eyJhbGciOiJub25lIn0.eyJzdWIiOiJhbGljZSJ9.","nonce":"n-0S6"}` },
+			status: http.StatusUnauthorized,
+		},
+		"no mapped group": {
+			body: withToken(idp.claims("alice", "staff")),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT u.id").WithArgs("alice").WillReturnRows(sqlmock.NewRows(userCols))
+				mock.ExpectRollback()
+			},
+			status: http.StatusForbidden,
+		},
+		"no auto-provisioning": {
+			body: withToken(idp.claims("alice", "cdn-ops")),
+			cfg:  func(c *config.ConfigOIDC) { c.AutoProvision = false },
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT u.id").WithArgs("alice").WillReturnRows(sqlmock.NewRows(userCols))
+				mock.ExpectRollback()
+			},
+			status: http.StatusForbidden,
+		},
+		"disallowed user": {
+			body: withToken(idp.claims("alice", "cdn-ops")),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT u.id").WithArgs("alice").WillReturnRows(sqlmock.NewRows(userCols).AddRow(42, 4, "disallowed", 1, idp.server.URL, "00u1"))
+				mock.ExpectRollback()
+			},
+			status: http.StatusForbidden,
+		},
+		"local user": {
+			body: withToken(idp.claims("admin", "cdn-admins")),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT u.id").WithArgs("admin").WillReturnRows(sqlmock.NewRows(userCols).AddRow(1, 1, "admin", 1, "", ""))
+				mock.ExpectRollback()
+			},
+			status: http.StatusForbidden,
+		},
+		"user linked to another identity": {
+			body: withToken(idp.claims("alice", "cdn-ops")),
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT u.id").WithArgs("alice").WillReturnRows(sqlmock.NewRows(userCols).AddRow(42, 3, "operations", 2, idp.server.URL, "00u2"))
+				mock.ExpectRollback()
+			},
+			status: http.StatusForbidden,
+		},
+		"no subject": {
+			body: func() string {
+				claims := idp.claims("alice", "cdn-ops")
+				delete(claims, "sub")
+				return withToken(claims)()
+			},
+			status: http.StatusUnauthorized,
+		},
+		"idToken without nonce": {
+			body:   func() string { return `{"idToken":"` + idp.sign(t, idp.claims("alice", "cdn-ops")) + `"}` },
+			status: http.StatusBadRequest,
+		},
+		"idToken with wrong nonce": {
+			body: func() string {
+				return `{"idToken":"` + idp.sign(t, idp.claims("alice", "cdn-ops")) + `","nonce":"other"}`
+			},
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for name, test := range tests {
+		mockDB, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		db := sqlx.NewDb(mockDB, "sqlmock")
+		if test.mock != nil {
+			test.mock(mock)
+		}
+		oidcCfg := idp.config()
+		if test.cfg != nil {
+			test.cfg(&oidcCfg)
+		}
+
+		handler := OIDCLoginHandler(db, config.Config{OIDC: &oidcCfg, Secrets: []string{"secret"}})
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/4.0/user/login/oidc", bytes.NewBufferString(test.body()))
+		handler(w, r)
+
+		if status := responseStatus(w, r); status != test.status {
+			t.Errorf("%s: expected status %d, actual: %d: %s", name, test.status, status, w.Body.String())
+		}
+		if w.Header().Get("Set-Cookie") != "" {
+			t.Errorf("%s: expected no login cookie, actual: %s", name, w.Header().Get("Set-Cookie"))
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("%s: there were unfulfilled expectations: %s", name, err)
+		}
+		mockDB.Close()
+	}
+}
+
+func TestOIDCLoginHandlerSyncRoles(t *testing.T) {
+	idp := newTestIdP(t)
+	defer idp.server.Close()
+	oidcCfg := idp.config()
+	oidcCfg.SyncRoles = true
+
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT u.id").WithArgs("alice").WillReturnRows(sqlmock.NewRows([]string{"id", "role_id", "role_name", "tenant_id", "oidc_issuer", "oidc_subject"}).AddRow(42, 3, "operations", 2, idp.server.URL, "00u1"))
+	mock.ExpectQuery("SELECT id FROM role").WithArgs("admin").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT id FROM tenant").WithArgs("root").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("UPDATE tm_user").WithArgs(1, 1, 42).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO log").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	handler := OIDCLoginHandler(db, config.Config{OIDC: &oidcCfg, Secrets: []string{"secret"}})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/api/4.0/user/login/oidc", bytes.NewBufferString(`{"idToken":"`+idp.sign(t, idp.claims("alice", "cdn-admins"))+`","nonce":"`+testNonce+`"}`))
+	handler(w, r)
+
+	if status := responseStatus(w, r); status != http.StatusOK {
+		t.Fatalf("expected status %d, actual: %d: %s", http.StatusOK, status, w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,300 @@
+package logs
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+// logFilterParams maps the query parameters by which logs may be filtered to their columns.
+var logFilterParams = map[string]string{
+	"action":     "l.action",
+	"objectType": "l.object_type",
+	"objectId":   "l.object_id",
+	"username":   "u.username",
+	"requestId":  "l.request_id",
+	"tenantId":   "l.tenant_id",
+}
+
+const selectLogV4Query = `
+SELECT l.id, l.level, l.message, u.username as user, l.ticketnum, l.last_updated,
+       l.action, l.object_type, l.object_id, l.tenant_id, l.request_id, l.api_version
+FROM "log" as l JOIN tm_user as u ON l.tm_user = u.id
+`
+
+// GetV4 is the handler for GET requests to /logs in API version 4 and later, which includes the structured audit information of each log, and can filter logs by it.
+// Logs of changes to objects of Tenants inaccessible to the user are omitted.
+func GetV4(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, []string{"days", "limit", "requestId", "tenantId"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	limit := DefaultLogLimit
+	days := DefaultLogDays
+	if pDays, ok := inf.IntParams["days"]; ok {
+		days = pDays
+		limit = DefaultLogLimitForDays
+	}
+	if pLimit, ok := inf.IntParams["limit"]; ok {
+		limit = pLimit
+	}
+
+	filters := map[string]string{}
+	for param := range logFilterParams {
+		if val, ok := inf.Params[param]; ok {
+			filters[param] = val
+		}
+	}
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(inf.Tx.Tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting user tenants: "+err.Error()))
+		return
+	}
+
+	setLastSeenCookie(w)
+	logs, err := getLogV4(inf.Tx.Tx, days, limit, tenantIDs, filters)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, logs)
+}
+
+// getLogV4 returns the logs of the last given number of days, matching the given filters, which are keys of logFilterParams.
+// Only logs of objects with no Tenant, or of one of the given Tenants, are returned.
+func getLogV4(tx *sql.Tx, days int, limit int, tenantIDs []int, filters map[string]string) ([]tc.LogV4, error) {
+	qry := selectLogV4Query + `WHERE l.last_updated > now() - ($1 || ' DAY')::INTERVAL
+AND (l.tenant_id IS NULL OR l.tenant_id = ANY($3::bigint[]))`
+	args := []interface{}{days, limit, pq.Array(tenantIDs)}
+
+	params := make([]string, 0, len(filters))
+	for param := range filters {
+		params = append(params, param)
+	}
+	sort.Strings(params) // for a deterministic query
+	for _, param := range params {
+		args = append(args, filters[param])
+		qry += ` AND ` + logFilterParams[param] + `::text = $` + strconv.Itoa(len(args))
+	}
+	qry += `
+ORDER BY l.last_updated DESC
+LIMIT $2
+`
+	rows, err := tx.Query(qry, args...)
+	if err != nil {
+		return nil, errors.New("querying logs: " + err.Error())
+	}
+	defer rows.Close()
+
+	ls := []tc.LogV4{}
+	for rows.Next() {
+		l := tc.LogV4{}
+		if err = rows.Scan(&l.ID, &l.Level, &l.Message, &l.User, &l.TicketNum, &l.LastUpdated, &l.Action, &l.ObjectType, &l.ObjectID, &l.TenantID, &l.RequestID, &l.APIVersion); err != nil {
+			return nil, errors.New("scanning logs: " + err.Error())
+		}
+		ls = append(ls, l)
+	}
+	return ls, nil
+}
+
+// GetByID is the handler for GET requests to /logs/{id}, which returns the log, along with the changed object as it was before and after the change.
+func GetByID(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	l, ok, err := getLogDetail(inf.Tx.Tx, inf.IntParams["id"])
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("log not found"), nil)
+		return
+	}
+	if userErr, sysErr, errCode := checkLogTenant(inf, l.TenantID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, l)
+}
+
+// GetDiff is the handler for GET requests to /logs/{id}/diff, which returns the field-level difference between the changed object before and after the change.
+func GetDiff(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	l, ok, err := getLogDetail(inf.Tx.Tx, inf.IntParams["id"])
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("log not found"), nil)
+		return
+	}
+	if userErr, sysErr, errCode := checkLogTenant(inf, l.TenantID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	if l.Before == nil && l.After == nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("log has no recorded object to compare"), nil)
+		return
+	}
+	changes, err := DiffFields(l.Before, l.After)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("diffing log "+strconv.Itoa(*l.ID)+": "+err.Error()))
+		return
+	}
+	api.WriteResp(w, r, tc.LogDiffV4{
+		ID:         *l.ID,
+		Action:     l.Action,
+		ObjectType: l.ObjectType,
+		ObjectID:   l.ObjectID,
+		Changes:    changes,
+	})
+}
+
+// checkLogTenant returns an error if the given log tenant ID isn't nil, and the tenant isn't accessible to the requesting user.
+func checkLogTenant(inf *api.APIInfo, tenantID *int) (error, error, int) {
+	if tenantID == nil {
+		return nil, nil, http.StatusOK
+	}
+	ok, err := tenant.IsResourceAuthorizedToUserTx(*tenantID, inf.User, inf.Tx.Tx)
+	if err != nil {
+		return nil, errors.New("checking log tenant: " + err.Error()), http.StatusInternalServerError
+	}
+	if !ok {
+		return errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+	}
+	return nil, nil, http.StatusOK
+}
+
+// getLogDetail returns the log with the given ID, and whether it exists.
+func getLogDetail(tx *sql.Tx, id int) (tc.LogDetailV4, bool, error) {
+	l := tc.LogDetailV4{}
+	before := []byte(nil)
+	after := []byte(nil)
+	qry := `
+SELECT l.id, l.level, l.message, u.username as user, l.ticketnum, l.last_updated,
+       l.action, l.object_type, l.object_id, l.tenant_id, l.request_id, l.api_version,
+       l.before, l.after
+FROM "log" as l JOIN tm_user as u ON l.tm_user = u.id
+WHERE l.id = $1
+`
+	if err := tx.QueryRow(qry, id).Scan(&l.ID, &l.Level, &l.Message, &l.User, &l.TicketNum, &l.LastUpdated, &l.Action, &l.ObjectType, &l.ObjectID, &l.TenantID, &l.RequestID, &l.APIVersion, &before, &after); err != nil {
+		if err == sql.ErrNoRows {
+			return tc.LogDetailV4{}, false, nil
+		}
+		return tc.LogDetailV4{}, false, errors.New("querying log: " + err.Error())
+	}
+	if before != nil {
+		l.Before = json.RawMessage(before)
+	}
+	if after != nil {
+		l.After = json.RawMessage(after)
+	}
+	return l, true, nil
+}
+
+// DiffFields returns the changes to the top-level fields between the given JSON objects, sorted by field. Either may be nil, if the object was created or deleted, in which case every field is reported as added or removed.
+// If either isn't a JSON object, the whole value is reported as a single change to the field "".
+func DiffFields(before json.RawMessage, after json.RawMessage) ([]tc.LogFieldChange, error) {
+	beforeVal, err := decodeAuditJSON(before)
+	if err != nil {
+		return nil, errors.New("decoding before: " + err.Error())
+	}
+	afterVal, err := decodeAuditJSON(after)
+	if err != nil {
+		return nil, errors.New("decoding after: " + err.Error())
+	}
+
+	beforeObj, beforeIsObj := beforeVal.(map[string]interface{})
+	afterObj, afterIsObj := afterVal.(map[string]interface{})
+	if beforeVal == nil && afterIsObj {
+		beforeObj, beforeIsObj = map[string]interface{}{}, true
+	}
+	if afterVal == nil && beforeIsObj {
+		afterObj, afterIsObj = map[string]interface{}{}, true
+	}
+	if !beforeIsObj || !afterIsObj {
+		if reflect.DeepEqual(beforeVal, afterVal) {
+			return []tc.LogFieldChange{}, nil
+		}
+		return []tc.LogFieldChange{{Field: "", Old: beforeVal, New: afterVal}}, nil
+	}
+
+	fields := make([]string, 0, len(beforeObj)+len(afterObj))
+	for field := range beforeObj {
+		fields = append(fields, field)
+	}
+	for field := range afterObj {
+		if _, ok := beforeObj[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []tc.LogFieldChange{}
+	for _, field := range fields {
+		oldVal := beforeObj[field]
+		newVal := afterObj[field]
+		if reflect.DeepEqual(oldVal, newVal) {
+			continue
+		}
+		changes = append(changes, tc.LogFieldChange{Field: field, Old: oldVal, New: newVal})
+	}
+	return changes, nil
+}
+
+func decodeAuditJSON(bts json.RawMessage) (interface{}, error) {
+	if len(bts) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(bts))
+	dec.UseNumber() // preserve numbers exactly, so large integers aren't reported as changed
+	val := interface{}(nil)
+	if err := dec.Decode(&val); err != nil {
+		return nil, err
+	}
+	return val, nil
+}
@@ -0,0 +1,110 @@
+package logs
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestDiffFields(t *testing.T) {
+	before := json.RawMessage(`{"id":3,"name":"foo","active":true,"tags":["a","b"],"bigNum":12345678901234567890}`)
+	after := json.RawMessage(`{"id":3,"name":"bar","tags":["a","c"],"bigNum":12345678901234567890,"new":null,"added":1}`)
+
+	changes, err := DiffFields(before, after)
+	if err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+	expected := []string{"active", "added", "name", "tags"}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %d changes, actual %d: %+v", len(expected), len(changes), changes)
+	}
+	for i, field := range expected {
+		if changes[i].Field != field {
+			t.Errorf("expected change %d to be field '%s', actual '%s'", i, field, changes[i].Field)
+		}
+	}
+	if changes[0].New != nil {
+		t.Errorf("expected removed field to have null new value, actual: %v", changes[0].New)
+	}
+	if changes[1].Old != nil {
+		t.Errorf("expected added field to have null old value, actual: %v", changes[1].Old)
+	}
+	if changes[2].Old != "foo" || changes[2].New != "bar" {
+		t.Errorf("expected name change foo -> bar, actual: %v -> %v", changes[2].Old, changes[2].New)
+	}
+
+	created, err := DiffFields(nil, json.RawMessage(`{"id":1,"name":"foo"}`))
+	if err != nil {
+		t.Fatalf("created: expected nil error, actual: %v", err)
+	}
+	if len(created) != 2 {
+		t.Errorf("created: expected every field to be added, actual: %+v", created)
+	}
+
+	scalar, err := DiffFields(json.RawMessage(`1`), json.RawMessage(`2`))
+	if err != nil {
+		t.Fatalf("scalar: expected nil error, actual: %v", err)
+	}
+	if len(scalar) != 1 || scalar[0].Field != "" {
+		t.Errorf("scalar: expected a single change to the whole value, actual: %+v", scalar)
+	}
+
+	if _, err := DiffFields(json.RawMessage(`{`), nil); err == nil {
+		t.Errorf("invalid JSON: expected error, actual: nil")
+	}
+}
+
+func TestGetLogV4Filters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	cols := []string{"id", "level", "message", "user", "ticketnum", "last_updated", "action", "object_type", "object_id", "tenant_id", "request_id", "api_version"}
+	rows := sqlmock.NewRows(cols).AddRow(1, "APICHANGE", "Updated ds: foo id: 3", "admin", nil, nil, "Updated", "ds", "3", 1, 42, "4.0")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`l.tenant_id IS NULL OR l.tenant_id = ANY\(\$3::bigint\[\]\)\) AND l.object_id::text = \$4 AND l.object_type::text = \$5`).WithArgs(30, 1000, "{1,2}", "3", "ds").WillReturnRows(rows)
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("beginning transaction: %v", err)
+	}
+	logs, err := getLogV4(tx, 30, 1000, []int{1, 2}, map[string]string{"objectType": "ds", "objectId": "3"})
+	if err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, actual %d", len(logs))
+	}
+	if logs[0].ObjectType == nil || *logs[0].ObjectType != "ds" {
+		t.Errorf("expected object type 'ds', actual: %v", logs[0].ObjectType)
+	}
+	if logs[0].RequestID == nil || *logs[0].RequestID != 42 {
+		t.Errorf("expected request ID 42, actual: %v", logs[0].RequestID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
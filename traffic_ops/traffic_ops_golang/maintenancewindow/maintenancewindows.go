@@ -0,0 +1,507 @@
+// Package maintenancewindow manages maintenance windows - periods of time
+// during which Traffic Ops sets the status of a set of servers, and after which
+// it restores their original statuses.
+package maintenancewindow
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/lib/pq"
+)
+
+// auditObjectType is the object type of maintenance windows in the audit log.
+const auditObjectType = "maintenanceWindow"
+
+const selectQuery = `
+SELECT mw.id,
+	mw.reason,
+	mw.start_time,
+	mw.end_time,
+	st.name,
+	mw.snapshot,
+	mw.state,
+	mw.username,
+	mw.last_updated,
+	ARRAY(SELECT mws.server FROM maintenance_window_server AS mws WHERE mws.maintenance_window = mw.id ORDER BY mws.server) AS server_ids,
+	ARRAY(SELECT mwc.cachegroup FROM maintenance_window_cachegroup AS mwc WHERE mwc.maintenance_window = mw.id ORDER BY mwc.cachegroup) AS cachegroup_ids
+FROM maintenance_window AS mw
+JOIN status AS st ON st.id = mw.status
+`
+
+const selectAppliedQuery = `
+SELECT mwa.maintenance_window,
+	s.id,
+	s.host_name,
+	st.name,
+	mwa.original_offline_reason
+FROM maintenance_window_applied AS mwa
+JOIN server AS s ON s.id = mwa.server
+JOIN status AS st ON st.id = mwa.original_status
+WHERE mwa.maintenance_window = ANY($1)
+ORDER BY s.host_name
+`
+
+const insertQuery = `
+INSERT INTO maintenance_window (reason, start_time, end_time, status, snapshot, username)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, state, last_updated
+`
+
+const updateQuery = `
+UPDATE maintenance_window SET
+	reason = $1,
+	start_time = $2,
+	end_time = $3,
+	status = $4,
+	snapshot = $5
+WHERE id = $6
+RETURNING last_updated
+`
+
+// Get is the handler for GET requests to /maintenance_windows.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"id":        {Column: "mw.id", Checker: api.IsInt},
+		"state":     {Column: "mw.state"},
+		"status":    {Column: "st.name"},
+		"startTime": {Column: "mw.start_time"},
+	}
+	if _, ok := inf.Params["orderby"]; !ok {
+		inf.Params["orderby"] = "startTime"
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+
+	rows, err := inf.Tx.NamedQuery(selectQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = errors.New("maintenance window read query: " + sysErr.Error())
+		}
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	windows := []tc.MaintenanceWindow{}
+	for rows.Next() {
+		mw, err := scanMaintenanceWindow(rows)
+		if err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		windows = append(windows, mw)
+	}
+	rows.Close()
+	if err := addAppliedServers(inf.Tx.Tx, windows); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, windows)
+}
+
+// Create is the handler for POST requests to /maintenance_windows.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var mw tc.MaintenanceWindow
+	if userErr = api.Parse(r.Body, tx, &mw); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if !mw.EndTime.After(time.Now()) {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("endTime must be in the future"), nil)
+		return
+	}
+	statusID, userErr, sysErr, errCode := validateTargets(tx, mw)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if mw.Snapshot == nil {
+		mw.Snapshot = util.BoolPtr(false)
+	}
+
+	mw.ID = new(int)
+	mw.LastUpdated = new(tc.TimeNoMod)
+	mw.Username = inf.User.UserName
+	if err := tx.QueryRow(insertQuery, mw.Reason, mw.StartTime, mw.EndTime, statusID, mw.Snapshot, mw.Username).Scan(mw.ID, &mw.State, mw.LastUpdated); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if err := insertTargets(tx, *mw.ID, mw); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	setDefaults(&mw)
+
+	audit := api.Audit{Action: api.Created, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*mw.ID), After: mw}
+	if err := api.CreateAuditLog(api.ApiChange, "MAINTENANCE WINDOW: "+strconv.Itoa(*mw.ID)+", ACTION: Created", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/maintenance_windows?id=%d", inf.Version.Major, inf.Version.Minor, *mw.ID))
+	api.WriteAlertsObj(w, r, http.StatusCreated, tc.CreateAlerts(tc.SuccessLevel, "maintenance window was created."), mw)
+}
+
+// Update is the handler for PUT requests to /maintenance_windows/{id}. Only the reason and end time of an active window can
+// be changed, and completed windows can't be changed at all.
+func Update(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	before, ok, err := getMaintenanceWindow(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no maintenance window with id %d", id), nil)
+		return
+	}
+	if !api.IsUnmodified(r.Header, before.LastUpdated.Time) {
+		api.HandleErr(w, r, tx, http.StatusPreconditionFailed, errors.New("maintenance window could not be modified because the precondition failed"), nil)
+		return
+	}
+
+	var mw tc.MaintenanceWindow
+	if userErr = api.Parse(r.Body, tx, &mw); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if mw.Snapshot == nil {
+		mw.Snapshot = util.BoolPtr(false)
+	}
+
+	switch before.State {
+	case tc.MaintenanceWindowStateScheduled:
+		if !mw.EndTime.After(time.Now()) {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("endTime must be in the future"), nil)
+			return
+		}
+	case tc.MaintenanceWindowStateActive:
+		if !activeChangeAllowed(before, mw) {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("only the reason and endTime of an active maintenance window can be changed"), nil)
+			return
+		}
+	default:
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("maintenance windows which are %s can't be changed", before.State), nil)
+		return
+	}
+	statusID, userErr, sysErr, errCode := validateTargets(tx, mw)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	mw.ID = &id
+	mw.State = before.State
+	mw.Username = before.Username
+	mw.AppliedServers = before.AppliedServers
+	mw.LastUpdated = new(tc.TimeNoMod)
+	if err := tx.QueryRow(updateQuery, mw.Reason, mw.StartTime, mw.EndTime, statusID, mw.Snapshot, id).Scan(mw.LastUpdated); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if before.State == tc.MaintenanceWindowStateScheduled {
+		if err := deleteTargets(tx, id); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		if err := insertTargets(tx, id, mw); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+	}
+	setDefaults(&mw)
+
+	audit := api.Audit{Action: api.Updated, ObjectType: auditObjectType, ObjectID: strconv.Itoa(id), Before: before, After: mw}
+	if err := api.CreateAuditLog(api.ApiChange, "MAINTENANCE WINDOW: "+strconv.Itoa(id)+", ACTION: Updated", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "maintenance window was updated.", mw)
+}
+
+// Delete is the handler for DELETE requests to /maintenance_windows/{id}. Active windows can't be deleted, because the
+// original statuses of their servers would be lost; they're ended early by setting their end time instead.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	before, ok, err := getMaintenanceWindow(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no maintenance window with id %d", id), nil)
+		return
+	}
+	if before.State == tc.MaintenanceWindowStateActive {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("active maintenance windows can't be deleted; set the endTime of the window to end it early"), nil)
+		return
+	}
+
+	if _, err := tx.Exec(`DELETE FROM maintenance_window WHERE id = $1`, id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	audit := api.Audit{Action: api.Deleted, ObjectType: auditObjectType, ObjectID: strconv.Itoa(id), Before: before}
+	if err := api.CreateAuditLog(api.ApiChange, "MAINTENANCE WINDOW: "+strconv.Itoa(id)+", ACTION: Deleted", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "maintenance window was deleted.")
+}
+
+// scanner is a *sql.Rows, *sql.Row, or *sqlx.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanMaintenanceWindow scans a maintenance window selected by selectQuery, without its applied servers.
+func scanMaintenanceWindow(row scanner) (tc.MaintenanceWindow, error) {
+	mw := tc.MaintenanceWindow{}
+	serverIDs := pq.Int64Array{}
+	cacheGroupIDs := pq.Int64Array{}
+	if err := row.Scan(&mw.ID, &mw.Reason, &mw.StartTime, &mw.EndTime, &mw.Status, &mw.Snapshot, &mw.State, &mw.Username, &mw.LastUpdated, &serverIDs, &cacheGroupIDs); err != nil {
+		return mw, err
+	}
+	mw.ServerIDs = toInts(serverIDs)
+	mw.CacheGroupIDs = toInts(cacheGroupIDs)
+	mw.AppliedServers = []tc.MaintenanceWindowServer{}
+	return mw, nil
+}
+
+// getMaintenanceWindow returns the maintenance window with the given ID, and whether it exists. The window is locked
+// until the transaction ends, so that it doesn't start or end while it's being changed.
+func getMaintenanceWindow(tx *sql.Tx, id int) (tc.MaintenanceWindow, bool, error) {
+	mw, err := scanMaintenanceWindow(tx.QueryRow(selectQuery+`WHERE mw.id = $1 FOR UPDATE OF mw`, id))
+	if err == sql.ErrNoRows {
+		return mw, false, nil
+	} else if err != nil {
+		return mw, false, errors.New("querying maintenance window: " + err.Error())
+	}
+	windows := []tc.MaintenanceWindow{mw}
+	if err := addAppliedServers(tx, windows); err != nil {
+		return mw, false, err
+	}
+	return windows[0], true, nil
+}
+
+// addAppliedServers sets the servers whose statuses were set by each of the given windows.
+func addAppliedServers(tx *sql.Tx, windows []tc.MaintenanceWindow) error {
+	if len(windows) == 0 {
+		return nil
+	}
+	idxs := map[int]int{}
+	ids := []int64{}
+	for i, mw := range windows {
+		idxs[*mw.ID] = i
+		ids = append(ids, int64(*mw.ID))
+	}
+	rows, err := tx.Query(selectAppliedQuery, pq.Array(ids))
+	if err != nil {
+		return errors.New("querying maintenance window applied servers: " + err.Error())
+	}
+	defer rows.Close()
+	for rows.Next() {
+		windowID := 0
+		srv := tc.MaintenanceWindowServer{}
+		if err := rows.Scan(&windowID, &srv.ID, &srv.HostName, &srv.OriginalStatus, &srv.OriginalOfflineReason); err != nil {
+			return errors.New("scanning maintenance window applied servers: " + err.Error())
+		}
+		if i, ok := idxs[windowID]; ok {
+			windows[i].AppliedServers = append(windows[i].AppliedServers, srv)
+		}
+	}
+	return rows.Err()
+}
+
+// validateTargets checks that the status, servers and Cache Groups of the given window exist, and returns the ID of its
+// status.
+func validateTargets(tx *sql.Tx, mw tc.MaintenanceWindow) (int, error, error, int) {
+	if *mw.Status == tc.CacheStatusOnline.String() || *mw.Status == tc.CacheStatusReported.String() {
+		return 0, fmt.Errorf("status can't be %s or %s", tc.CacheStatusOnline, tc.CacheStatusReported), nil, http.StatusBadRequest
+	}
+	status, ok, err := dbhelpers.GetStatusByName(*mw.Status, tx)
+	if err != nil {
+		return 0, nil, err, http.StatusInternalServerError
+	} else if !ok {
+		return 0, fmt.Errorf("no such status: %s", *mw.Status), nil, http.StatusBadRequest
+	}
+
+	if missing, err := missingIDs(tx, `SELECT id FROM server WHERE id = ANY($1)`, mw.ServerIDs); err != nil {
+		return 0, nil, errors.New("checking maintenance window servers: " + err.Error()), http.StatusInternalServerError
+	} else if len(missing) > 0 {
+		return 0, errors.New("no such servers: " + joinInts(missing)), nil, http.StatusBadRequest
+	}
+	if missing, err := missingIDs(tx, `SELECT id FROM cachegroup WHERE id = ANY($1)`, mw.CacheGroupIDs); err != nil {
+		return 0, nil, errors.New("checking maintenance window cache groups: " + err.Error()), http.StatusInternalServerError
+	} else if len(missing) > 0 {
+		return 0, errors.New("no such cachegroups: " + joinInts(missing)), nil, http.StatusBadRequest
+	}
+	return *status.ID, nil, nil, http.StatusOK
+}
+
+// missingIDs returns the given IDs which aren't returned by the given query of the IDs which exist in the array $1.
+func missingIDs(tx *sql.Tx, qry string, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	existing := pq.Int64Array{}
+	if err := tx.QueryRow(`SELECT ARRAY(`+qry+`)`, pq.Array(ids)).Scan(&existing); err != nil {
+		return nil, err
+	}
+	found := map[int]struct{}{}
+	for _, id := range existing {
+		found[int(id)] = struct{}{}
+	}
+	missing := []int{}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing, nil
+}
+
+// activeChangeAllowed returns whether the given change to an active maintenance window only changes its reason and end
+// time.
+func activeChangeAllowed(before tc.MaintenanceWindow, after tc.MaintenanceWindow) bool {
+	return before.StartTime.Equal(*after.StartTime) &&
+		*before.Status == *after.Status &&
+		*before.Snapshot == *after.Snapshot &&
+		sameIDs(before.ServerIDs, after.ServerIDs) &&
+		sameIDs(before.CacheGroupIDs, after.CacheGroupIDs)
+}
+
+// sameIDs returns whether a and b contain the same IDs, in any order.
+func sameIDs(a []int, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]int{}, a...)
+	b = append([]int{}, b...)
+	sort.Ints(a)
+	sort.Ints(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// insertTargets inserts the servers and Cache Groups of the given window.
+func insertTargets(tx *sql.Tx, id int, mw tc.MaintenanceWindow) error {
+	if _, err := tx.Exec(`INSERT INTO maintenance_window_server (maintenance_window, server) SELECT $1, unnest($2::bigint[]) ON CONFLICT DO NOTHING`, id, pq.Array(mw.ServerIDs)); err != nil {
+		return errors.New("inserting maintenance window servers: " + err.Error())
+	}
+	if _, err := tx.Exec(`INSERT INTO maintenance_window_cachegroup (maintenance_window, cachegroup) SELECT $1, unnest($2::bigint[]) ON CONFLICT DO NOTHING`, id, pq.Array(mw.CacheGroupIDs)); err != nil {
+		return errors.New("inserting maintenance window cache groups: " + err.Error())
+	}
+	return nil
+}
+
+// deleteTargets deletes the servers and Cache Groups of the window with the given ID.
+func deleteTargets(tx *sql.Tx, id int) error {
+	if _, err := tx.Exec(`DELETE FROM maintenance_window_server WHERE maintenance_window = $1`, id); err != nil {
+		return errors.New("deleting maintenance window servers: " + err.Error())
+	}
+	if _, err := tx.Exec(`DELETE FROM maintenance_window_cachegroup WHERE maintenance_window = $1`, id); err != nil {
+		return errors.New("deleting maintenance window cache groups: " + err.Error())
+	}
+	return nil
+}
+
+// setDefaults sets the lists of the given window that may be omitted from requests, but are never null in responses.
+func setDefaults(mw *tc.MaintenanceWindow) {
+	if mw.ServerIDs == nil {
+		mw.ServerIDs = []int{}
+	}
+	if mw.CacheGroupIDs == nil {
+		mw.CacheGroupIDs = []int{}
+	}
+	if mw.AppliedServers == nil {
+		mw.AppliedServers = []tc.MaintenanceWindowServer{}
+	}
+}
+
+func toInts(ids pq.Int64Array) []int {
+	ints := make([]int, 0, len(ids))
+	for _, id := range ids {
+		ints = append(ints, int(id))
+	}
+	return ints
+}
+
+func joinInts(ids []int) string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, strconv.Itoa(id))
+	}
+	return strings.Join(strs, ", ")
+}
@@ -0,0 +1,108 @@
+package maintenancewindow
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestActiveChangeAllowed(t *testing.T) {
+	start := time.Date(2021, 3, 24, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	before := tc.MaintenanceWindow{
+		Reason:        util.StrPtr("reboot"),
+		StartTime:     &start,
+		EndTime:       &end,
+		Status:        util.StrPtr(tc.CacheStatusAdminDown.String()),
+		Snapshot:      util.BoolPtr(false),
+		ServerIDs:     []int{1, 2},
+		CacheGroupIDs: []int{3},
+	}
+
+	later := end.Add(time.Hour)
+	after := before
+	after.Reason = util.StrPtr("reboot and upgrade")
+	after.EndTime = &later
+	after.ServerIDs = []int{2, 1}
+	if !activeChangeAllowed(before, after) {
+		t.Error("expected changing the reason and end time, and reordering the servers, to be allowed")
+	}
+
+	after = before
+	after.Status = util.StrPtr(tc.CacheStatusOffline.String())
+	if activeChangeAllowed(before, after) {
+		t.Error("expected changing the status to not be allowed")
+	}
+
+	after = before
+	after.ServerIDs = []int{1}
+	if activeChangeAllowed(before, after) {
+		t.Error("expected changing the servers to not be allowed")
+	}
+
+	after = before
+	after.CacheGroupIDs = nil
+	if activeChangeAllowed(before, after) {
+		t.Error("expected changing the cache groups to not be allowed")
+	}
+
+	earlier := start.Add(-time.Hour)
+	after = before
+	after.StartTime = &earlier
+	if activeChangeAllowed(before, after) {
+		t.Error("expected changing the start time to not be allowed")
+	}
+}
+
+func TestMissingIDs(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT ARRAY").WillReturnRows(sqlmock.NewRows([]string{"array"}).AddRow("{1,3}"))
+	tx := db.MustBegin().Tx
+
+	missing, err := missingIDs(tx, `SELECT id FROM server WHERE id = ANY($1)`, []int{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(missing) != 2 || missing[0] != 2 || missing[1] != 4 {
+		t.Errorf("expected missing IDs [2 4], actual %v", missing)
+	}
+
+	if missing, err := missingIDs(tx, `SELECT id FROM server WHERE id = ANY($1)`, nil); err != nil || len(missing) != 0 {
+		t.Errorf("expected no missing IDs and no error without querying for no IDs, actual %v, %v", missing, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,489 @@
+package maintenancewindow
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/crconfig"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/server"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// selectDueQuery selects the IDs of the windows which are due to start or end.
+const selectDueQuery = `
+SELECT id
+FROM maintenance_window
+WHERE (state = '` + tc.MaintenanceWindowStateScheduled + `' AND start_time <= now())
+OR (state = '` + tc.MaintenanceWindowStateActive + `' AND end_time <= now())
+ORDER BY id
+`
+
+// lockQuery locks a window to start or end it, unless another Traffic Ops instance already has.
+const lockQuery = `
+SELECT mw.id,
+	mw.reason,
+	mw.status,
+	st.name,
+	mw.snapshot,
+	mw.state,
+	mw.username,
+	mw.start_time <= now(),
+	mw.end_time <= now()
+FROM maintenance_window AS mw
+JOIN status AS st ON st.id = mw.status
+WHERE mw.id = $1
+FOR UPDATE OF mw SKIP LOCKED
+`
+
+// selectTargetsQuery selects the servers in a window which is starting, along with their statuses, and the statuses
+// they had before any other active window which includes them started.
+const selectTargetsQuery = `
+SELECT s.id,
+	s.status,
+	s.offline_reason,
+	held.original_status,
+	held.original_offline_reason
+FROM server AS s
+LEFT JOIN LATERAL (
+	SELECT mwa.original_status, mwa.original_offline_reason
+	FROM maintenance_window_applied AS mwa
+	JOIN maintenance_window AS o ON o.id = mwa.maintenance_window
+	WHERE mwa.server = s.id
+	AND o.state = '` + tc.MaintenanceWindowStateActive + `'
+	ORDER BY o.start_time
+	LIMIT 1
+) AS held ON TRUE
+WHERE s.id IN (SELECT mws.server FROM maintenance_window_server AS mws WHERE mws.maintenance_window = $1)
+OR s.cachegroup IN (SELECT mwc.cachegroup FROM maintenance_window_cachegroup AS mwc WHERE mwc.maintenance_window = $1)
+`
+
+// selectAppliedForEndQuery selects the servers whose statuses were set by a window which is ending, along with their
+// statuses, and whether another active window includes them.
+const selectAppliedForEndQuery = `
+SELECT mwa.server,
+	s.status,
+	mwa.original_status,
+	ost.name,
+	mwa.original_offline_reason,
+	EXISTS (
+		SELECT 1
+		FROM maintenance_window_applied AS o
+		JOIN maintenance_window AS ow ON ow.id = o.maintenance_window
+		WHERE o.server = mwa.server
+		AND o.maintenance_window <> mwa.maintenance_window
+		AND ow.state = '` + tc.MaintenanceWindowStateActive + `'
+	)
+FROM maintenance_window_applied AS mwa
+JOIN server AS s ON s.id = mwa.server
+JOIN status AS ost ON ost.id = mwa.original_status
+WHERE mwa.maintenance_window = $1
+`
+
+// window is a maintenance window locked to be started or ended.
+type window struct {
+	ID       int
+	Reason   string
+	StatusID int
+	Status   string
+	Snapshot bool
+	State    string
+	Username string
+	Started  bool
+	Ended    bool
+}
+
+// Start starts and ends maintenance windows when they're due, checking for them at the configured interval until the
+// process exits.
+func Start(db *sqlx.DB, cfg *config.Config) {
+	interval := time.Duration(cfg.MaintenanceWindowPollIntervalSeconds) * time.Second
+	go func() {
+		for {
+			if err := runDue(db, cfg); err != nil {
+				log.Errorln("running maintenance windows: " + err.Error())
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// runDue starts or ends every window which is due. A window which fails to start or end is tried again the next time.
+func runDue(db *sqlx.DB, cfg *config.Config) error {
+	ids := []int{}
+	if err := db.Select(&ids, selectDueQuery); err != nil {
+		return errors.New("querying due maintenance windows: " + err.Error())
+	}
+	for _, id := range ids {
+		if err := run(db, cfg, id); err != nil {
+			log.Errorf("running maintenance window %d: %v\n", id, err)
+		}
+	}
+	return nil
+}
+
+// run starts or ends the window with the given ID, if it's still due and no other Traffic Ops instance is doing so.
+func run(db *sqlx.DB, cfg *config.Config, id int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.New("beginning transaction: " + err.Error())
+	}
+	commitTx := false
+	defer func() {
+		if !commitTx {
+			tx.Rollback()
+		}
+	}()
+
+	w := window{}
+	if err := tx.QueryRow(lockQuery, id).Scan(&w.ID, &w.Reason, &w.StatusID, &w.Status, &w.Snapshot, &w.State, &w.Username, &w.Started, &w.Ended); err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return errors.New("locking maintenance window: " + err.Error())
+	}
+
+	// Windows are always started and ended, even if the user who created them no longer exists, but their changes can
+	// only be logged, and Snapshots only taken, on behalf of an existing user.
+	inf := (*api.APIInfo)(nil)
+	user, userErr, sysErr, _ := auth.GetCurrentUserFromDB(db, w.Username, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	if userErr != nil || sysErr != nil {
+		log.Warnf("getting user '%s' of maintenance window %d, its changes won't be logged: %v %v\n", w.Username, w.ID, userErr, sysErr)
+	} else {
+		inf = &api.APIInfo{
+			Params:    map[string]string{},
+			IntParams: map[string]int{},
+			User:      &user,
+			Version:   &api.Version{Major: 4, Minor: 0},
+			Tx:        &sqlx.Tx{Tx: tx},
+			Config:    cfg,
+		}
+	}
+
+	switch {
+	case w.State == tc.MaintenanceWindowStateScheduled && w.Ended:
+		err = skip(tx, w, inf)
+	case w.State == tc.MaintenanceWindowStateScheduled && w.Started:
+		err = start(tx, w, inf)
+	case w.State == tc.MaintenanceWindowStateActive && w.Ended:
+		err = end(tx, w, inf)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.New("committing transaction: " + err.Error())
+	}
+	commitTx = true
+	return nil
+}
+
+// skip marks a window which ended before it could be started as skipped.
+func skip(tx *sql.Tx, w window, inf *api.APIInfo) error {
+	if err := setState(tx, w.ID, tc.MaintenanceWindowStateSkipped); err != nil {
+		return err
+	}
+	log.Warnf("maintenance window %d ended before it could be started\n", w.ID)
+	return logWindow(tx, w, inf, tc.MaintenanceWindowActionEnded, "Skipped, because it ended before it could be started")
+}
+
+// start sets the status of the servers in the window, recording the statuses they had before, and queues updates on
+// their child caches.
+func start(tx *sql.Tx, w window, inf *api.APIInfo) error {
+	rows, err := tx.Query(selectTargetsQuery, w.ID)
+	if err != nil {
+		return errors.New("querying maintenance window servers: " + err.Error())
+	}
+	type target struct {
+		statusID              int
+		offlineReason         *string
+		originalStatusID      *int
+		originalOfflineReason *string
+	}
+	targets := map[int]target{}
+	ids := []int{}
+	for rows.Next() {
+		id := 0
+		t := target{}
+		if err := rows.Scan(&id, &t.statusID, &t.offlineReason, &t.originalStatusID, &t.originalOfflineReason); err != nil {
+			rows.Close()
+			return errors.New("scanning maintenance window servers: " + err.Error())
+		}
+		targets[id] = t
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.New("reading maintenance window servers: " + err.Error())
+	}
+
+	infos, err := dbhelpers.GetServerInfosFromIDs(tx, ids)
+	if err != nil {
+		return err
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].HostName < infos[j].HostName })
+
+	offlineReason := (*string)(nil)
+	if w.Status == tc.CacheStatusAdminDown.String() || w.Status == tc.CacheStatusOffline.String() {
+		reason := w.Username + ": maintenance window " + strconv.Itoa(w.ID) + ": " + w.Reason
+		offlineReason = &reason
+	}
+
+	set := []string{}
+	notSet := []string{}
+	cdns := map[int]struct{}{}
+	for _, info := range infos {
+		t := targets[info.ID]
+		held := t.originalStatusID != nil
+		if !held && t.statusID == w.StatusID {
+			// the server already has the window's status, so the window doesn't own it
+			continue
+		}
+		if t.statusID != w.StatusID {
+			dsIDs, err := server.GetActiveDeliveryServicesThatOnlyHaveThisServerAssigned(info.ID, tx)
+			if err != nil {
+				return errors.New("getting Delivery Services which only have server " + info.HostName + " assigned: " + err.Error())
+			}
+			if len(dsIDs) > 0 {
+				notSet = append(notSet, info.HostName+" ("+server.InvalidStatusForDeliveryServicesAlertText(w.Status, dsIDs)+")")
+				continue
+			}
+			queued, err := server.SetStatus(tx, info, w.StatusID, offlineReason)
+			if err != nil {
+				return err
+			}
+			if err := logServer(inf, w, info, t.statusID, w.StatusID, w.Status, offlineReason, queued); err != nil {
+				return err
+			}
+			cdns[info.CDNID] = struct{}{}
+		}
+
+		originalStatusID, originalOfflineReason := t.statusID, t.offlineReason
+		if held {
+			originalStatusID, originalOfflineReason = *t.originalStatusID, t.originalOfflineReason
+		}
+		if _, err := tx.Exec(`INSERT INTO maintenance_window_applied (maintenance_window, server, original_status, original_offline_reason) VALUES ($1, $2, $3, $4)`, w.ID, info.ID, originalStatusID, originalOfflineReason); err != nil {
+			return errors.New("inserting maintenance window applied server: " + err.Error())
+		}
+		set = append(set, info.HostName)
+	}
+
+	if err := setState(tx, w.ID, tc.MaintenanceWindowStateActive); err != nil {
+		return err
+	}
+	msg := "Set status " + w.Status + " on " + describeHosts(set)
+	if len(notSet) > 0 {
+		msg += "; not set on " + strings.Join(notSet, ", ")
+	}
+	if err := logWindow(tx, w, inf, tc.MaintenanceWindowActionStarted, msg); err != nil {
+		return err
+	}
+	return snapshot(tx, w, inf, cdns)
+}
+
+// end restores the original statuses of the servers whose statuses were set by the window, unless they've been changed
+// since, or another active window includes them, and queues updates on their child caches.
+func end(tx *sql.Tx, w window, inf *api.APIInfo) error {
+	rows, err := tx.Query(selectAppliedForEndQuery, w.ID)
+	if err != nil {
+		return errors.New("querying maintenance window applied servers: " + err.Error())
+	}
+	type applied struct {
+		statusID              int
+		originalStatusID      int
+		originalStatus        string
+		originalOfflineReason *string
+		held                  bool
+	}
+	appliedServers := map[int]applied{}
+	ids := []int{}
+	for rows.Next() {
+		id := 0
+		a := applied{}
+		if err := rows.Scan(&id, &a.statusID, &a.originalStatusID, &a.originalStatus, &a.originalOfflineReason, &a.held); err != nil {
+			rows.Close()
+			return errors.New("scanning maintenance window applied servers: " + err.Error())
+		}
+		appliedServers[id] = a
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return errors.New("reading maintenance window applied servers: " + err.Error())
+	}
+
+	infos, err := dbhelpers.GetServerInfosFromIDs(tx, ids)
+	if err != nil {
+		return err
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].HostName < infos[j].HostName })
+
+	restored := []string{}
+	notRestored := []string{}
+	cdns := map[int]struct{}{}
+	for _, info := range infos {
+		a := appliedServers[info.ID]
+		if a.held {
+			notRestored = append(notRestored, info.HostName+" (in another active maintenance window)")
+			continue
+		}
+		if a.statusID != w.StatusID {
+			notRestored = append(notRestored, info.HostName+" (status changed during the window)")
+			continue
+		}
+		if a.originalStatusID == a.statusID {
+			continue
+		}
+		queued, err := server.SetStatus(tx, info, a.originalStatusID, a.originalOfflineReason)
+		if err != nil {
+			return err
+		}
+		if err := logServer(inf, w, info, a.statusID, a.originalStatusID, a.originalStatus, a.originalOfflineReason, queued); err != nil {
+			return err
+		}
+		cdns[info.CDNID] = struct{}{}
+		restored = append(restored, info.HostName)
+	}
+
+	if err := setState(tx, w.ID, tc.MaintenanceWindowStateCompleted); err != nil {
+		return err
+	}
+	msg := "Restored the original statuses of " + describeHosts(restored)
+	if len(notRestored) > 0 {
+		msg += "; not restored on " + strings.Join(notRestored, ", ")
+	}
+	if err := logWindow(tx, w, inf, tc.MaintenanceWindowActionEnded, msg); err != nil {
+		return err
+	}
+	return snapshot(tx, w, inf, cdns)
+}
+
+func setState(tx *sql.Tx, id int, state string) error {
+	if _, err := tx.Exec(`UPDATE maintenance_window SET state = $1 WHERE id = $2`, state, id); err != nil {
+		return errors.New("updating maintenance window state: " + err.Error())
+	}
+	return nil
+}
+
+func describeHosts(hosts []string) string {
+	if len(hosts) == 0 {
+		return "no servers"
+	}
+	return strings.Join(hosts, ", ")
+}
+
+// logWindow creates the change log entry of the window starting or ending, if its user exists.
+func logWindow(tx *sql.Tx, w window, inf *api.APIInfo, action string, msg string) error {
+	if inf == nil {
+		return nil
+	}
+	after, ok, err := getMaintenanceWindow(tx, w.ID)
+	if err != nil {
+		return err
+	}
+	audit := api.Audit{Action: action, ObjectType: auditObjectType, ObjectID: strconv.Itoa(w.ID)}
+	if ok {
+		audit.After = after
+	}
+	return api.CreateAuditLog(api.ApiChange, "MAINTENANCE WINDOW: "+strconv.Itoa(w.ID)+", ACTION: "+action+": "+msg, audit, inf)
+}
+
+// logServer creates the change log entry of the window changing the status of a server, like the one created when its
+// status is changed through the API, if the window's user exists.
+func logServer(inf *api.APIInfo, w window, info tc.ServerInfo, beforeStatusID int, statusID int, statusName string, offlineReason *string, queued bool) error {
+	if inf == nil {
+		return nil
+	}
+	reason := ""
+	if offlineReason != nil {
+		reason = *offlineReason
+	}
+	msg := "Updated status [ " + statusName + " ] for " + info.HostName + "." + info.DomainName + " [ " + reason + " ] by maintenance window " + strconv.Itoa(w.ID)
+	if queued {
+		msg += " and queued updates on all child caches"
+	}
+	audit := api.Audit{
+		Action:     api.Updated,
+		ObjectType: "server",
+		ObjectID:   strconv.Itoa(info.ID),
+		Before:     map[string]interface{}{"statusId": beforeStatusID},
+		After:      map[string]interface{}{"statusId": statusID, "status": statusName, "offlineReason": offlineReason},
+	}
+	if cdnName, ok, err := dbhelpers.GetCDNNameFromID(inf.Tx.Tx, int64(info.CDNID)); err != nil {
+		return err
+	} else if ok {
+		cdn := string(cdnName)
+		audit.CDNName = &cdn
+	}
+	return api.CreateAuditLog(api.ApiChange, msg, audit, inf)
+}
+
+// snapshot queues Snapshots of the given CDNs, if the window takes them and its user exists.
+func snapshot(tx *sql.Tx, w window, inf *api.APIInfo, cdns map[int]struct{}) error {
+	if !w.Snapshot || len(cdns) == 0 {
+		return nil
+	}
+	if inf == nil {
+		log.Warnf("not snapshotting the CDNs of maintenance window %d, because its user doesn't exist\n", w.ID)
+		return nil
+	}
+	ids := []int64{}
+	for id := range cdns {
+		ids = append(ids, int64(id))
+	}
+	rows, err := tx.Query(`SELECT id, name FROM cdn WHERE id = ANY($1) ORDER BY name`, pq.Array(ids))
+	if err != nil {
+		return errors.New("querying maintenance window CDNs: " + err.Error())
+	}
+	type cdn struct {
+		id   int
+		name string
+	}
+	toSnapshot := []cdn{}
+	for rows.Next() {
+		c := cdn{}
+		if err := rows.Scan(&c.id, &c.name); err != nil {
+			rows.Close()
+			return errors.New("scanning maintenance window CDNs: " + err.Error())
+		}
+		toSnapshot = append(toSnapshot, c)
+	}
+	rows.Close()
+	for _, c := range toSnapshot {
+		if _, err := crconfig.EnqueueSnapshot(tx, inf.User, c.name, c.id, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
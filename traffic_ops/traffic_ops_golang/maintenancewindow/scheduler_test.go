@@ -0,0 +1,84 @@
+package maintenancewindow
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestSkip(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE maintenance_window SET state").WithArgs(tc.MaintenanceWindowStateSkipped, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	tx := db.MustBegin().Tx
+
+	// without a user, the change isn't logged
+	if err := skip(tx, window{ID: 7, State: tc.MaintenanceWindowStateScheduled, Started: true, Ended: true}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestSnapshotWithoutChanges(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	tx := db.MustBegin().Tx
+
+	// windows which don't take snapshots, or didn't change any servers, don't queue any
+	if err := snapshot(tx, window{ID: 7}, nil, map[int]struct{}{1: {}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := snapshot(tx, window{ID: 7, Snapshot: true}, nil, map[int]struct{}{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDescribeHosts(t *testing.T) {
+	if actual := describeHosts(nil); actual != "no servers" {
+		t.Errorf("expected 'no servers', actual '%s'", actual)
+	}
+	if actual := describeHosts([]string{"edge1", "edge2"}); actual != "edge1, edge2" {
+		t.Errorf("expected 'edge1, edge2', actual '%s'", actual)
+	}
+}
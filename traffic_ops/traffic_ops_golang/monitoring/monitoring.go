@@ -133,6 +133,10 @@ type DeliveryService struct {
 	TotalTPSThreshold  float64 `json:"totalTpsThreshold"`
 	Status             string  `json:"status"`
 	TotalKBPSThreshold float64 `json:"totalKbpsThreshold"`
+	// Thresholds and DegradedThresholds are the Delivery Service health
+	// thresholds from the Delivery Service's Profile.
+	Thresholds         tc.DSHealthThresholds `json:"thresholds,omitempty"`
+	DegradedThresholds tc.DSHealthThresholds `json:"degradedThresholds,omitempty"`
 }
 
 func Get(w http.ResponseWriter, r *http.Request) {
@@ -482,9 +486,69 @@ func getDeliveryServices(tx *sql.Tx) ([]DeliveryService, error) {
 			TotalKBPSThreshold: mbps.Float64 * KilobitsPerMegabit,
 		})
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	thresholds, degradedThresholds, err := getDeliveryServiceThresholds(tx)
+	if err != nil {
+		return nil, fmt.Errorf("getting delivery service thresholds: %v", err)
+	}
+	for i, ds := range dses {
+		dses[i].Thresholds = thresholds[ds.XMLID]
+		dses[i].DegradedThresholds = degradedThresholds[ds.XMLID]
+	}
 	return dses, nil
 }
 
+// getDeliveryServiceThresholds returns the health thresholds and degraded health thresholds of all active Delivery Services, keyed by XMLID, from the CacheMonitorConfigFile Parameters of their Profiles.
+// Parameters with invalid threshold values are logged and skipped, rather than failing the whole monitoring config.
+func getDeliveryServiceThresholds(tx *sql.Tx) (map[string]tc.DSHealthThresholds, map[string]tc.DSHealthThresholds, error) {
+	query := `
+SELECT ds.xml_id, pr.name, pr.value
+FROM deliveryservice ds
+JOIN profile_parameter pp ON pp.profile = ds.profile
+JOIN parameter pr ON pr.id = pp.parameter
+WHERE ds.active = true
+AND pr.config_file = $1
+AND (pr.name LIKE $2 OR pr.name LIKE $3)
+`
+	rows, err := tx.Query(query, CacheMonitorConfigFile, tc.DSThresholdPrefix+"%", tc.DSDegradedThresholdPrefix+"%")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	thresholds := map[string]tc.DSHealthThresholds{}
+	degradedThresholds := map[string]tc.DSHealthThresholds{}
+	for rows.Next() {
+		var xmlID string
+		var name string
+		var value string
+		if err := rows.Scan(&xmlID, &name, &value); err != nil {
+			return nil, nil, err
+		}
+
+		dsThresholds := thresholds
+		stat := strings.TrimPrefix(name, tc.DSThresholdPrefix)
+		if strings.HasPrefix(name, tc.DSDegradedThresholdPrefix) {
+			dsThresholds = degradedThresholds
+			stat = strings.TrimPrefix(name, tc.DSDegradedThresholdPrefix)
+		}
+
+		threshold, err := tc.ParseHealthThreshold(value)
+		if err != nil {
+			log.Warnf("delivery service '%s' parameter '%s' value '%s' is not a valid threshold, skipping: %v", xmlID, name, value, err)
+			continue
+		}
+		if dsThresholds[xmlID] == nil {
+			dsThresholds[xmlID] = tc.DSHealthThresholds{}
+		}
+		dsThresholds[xmlID][stat] = threshold
+	}
+	return thresholds, degradedThresholds, rows.Err()
+}
+
 func getConfig(tx *sql.Tx, cdnName string) (map[string]interface{}, error) {
 	// TODO remove 'like' in query? Slow?
 	query := `
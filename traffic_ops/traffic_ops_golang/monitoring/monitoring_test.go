@@ -310,6 +310,12 @@ func TestGetDeliveryServices(t *testing.T) {
 		TotalTPSThreshold:  42.42,
 		Status:             DeliveryServiceStatus,
 		TotalKBPSThreshold: 24.24,
+		Thresholds: tc.DSHealthThresholds{
+			tc.DSStatErrorRate: {Val: 0.05, Comparator: "<"},
+		},
+		DegradedThresholds: tc.DSHealthThresholds{
+			tc.DSStatCacheGroupKbps: {Val: 1000, Comparator: "<="},
+		},
 	}
 
 	deliveryservices := []DeliveryService{deliveryservice}
@@ -322,6 +328,12 @@ func TestGetDeliveryServices(t *testing.T) {
 
 	mock.ExpectQuery("SELECT").WillReturnRows(rows)
 
+	thresholdRows := sqlmock.NewRows([]string{"xml_id", "name", "value"})
+	thresholdRows = thresholdRows.AddRow("myDsid", tc.DSThresholdPrefix+tc.DSStatErrorRate, "<0.05")
+	thresholdRows = thresholdRows.AddRow("myDsid", tc.DSDegradedThresholdPrefix+tc.DSStatCacheGroupKbps, "<=1000")
+	thresholdRows = thresholdRows.AddRow("myDsid", tc.DSThresholdPrefix+tc.DSStatTotalKbps, "not a threshold")
+	mock.ExpectQuery("SELECT").WithArgs(CacheMonitorConfigFile, tc.DSThresholdPrefix+"%", tc.DSDegradedThresholdPrefix+"%").WillReturnRows(thresholdRows)
+
 	dbCtx, f := context.WithTimeout(context.TODO(), time.Duration(10)*time.Second)
 	defer f()
 	tx, err := db.BeginTx(dbCtx, nil)
@@ -340,7 +352,7 @@ func TestGetDeliveryServices(t *testing.T) {
 
 	for i, sqlDeliveryservice := range sqlDeliveryservices {
 		deliveryservice := deliveryservices[i]
-		if deliveryservice != sqlDeliveryservice {
+		if !reflect.DeepEqual(deliveryservice, sqlDeliveryservice) {
 			t.Errorf("getDeliveryServices expected: %v, actual: %v", deliveryservice, sqlDeliveryservice)
 		}
 	}
@@ -497,6 +509,7 @@ func TestGetMonitoringJSON(t *testing.T) {
 		}
 
 		mock.ExpectQuery("SELECT").WillReturnRows(rows)
+		mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"xml_id", "name", "value"}))
 		resp.Response.DeliveryServices = deliveryservices
 	}
 	{
@@ -26,7 +26,6 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
 )
 
 func Riak(w http.ResponseWriter, r *http.Request) {
@@ -42,10 +41,10 @@ func Riak(w http.ResponseWriter, r *http.Request) {
 
 	defer inf.Close()
 
-	pingResp, err := riaksvc.Ping(inf.Tx.Tx, inf.Config.RiakAuthOptions, inf.Config.RiakPort)
+	pingResp, err := inf.Config.TrafficVault.Ping(inf.Tx.Tx)
 
 	if err != nil {
-		userErr = api.LogErr(r, http.StatusInternalServerError, nil, errors.New("error pinging Riak: "+err.Error()))
+		userErr = api.LogErr(r, http.StatusInternalServerError, nil, errors.New("error pinging Traffic Vault: "+err.Error()))
 		alerts.AddAlerts(tc.CreateErrorAlerts(userErr))
 		api.WriteAlerts(w, r, http.StatusInternalServerError, alerts)
 		return
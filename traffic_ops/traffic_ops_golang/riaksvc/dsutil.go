@@ -37,6 +37,9 @@ const DefaultDSSSLKeyVersion = DSSSLKeyVersionLatest
 const URLSigKeysBucket = "url_sig_keys"
 const URISigningKeysBucket = "cdn_uri_sig_keys"
 
+// DeliveryServiceSSLKeysCSRBucket is the bucket of pending Delivery Service certificate signing requests. It is separate from the SSL keys bucket, so pending CSRs are never served as SSL keys.
+const DeliveryServiceSSLKeysCSRBucket = "ssl_csr"
+
 func MakeDSSSLKeyKey(dsName, version string) string {
 	if version == "" {
 		version = DefaultDSSSLKeyVersion
@@ -0,0 +1,209 @@
+package riaksvc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+
+	"github.com/basho/riak-go-client"
+)
+
+// TrafficVault is the Riak Traffic Vault backend. The Riak servers are the servers of type RIAK in the Traffic Ops database.
+type TrafficVault struct {
+	AuthOptions *riak.AuthOptions
+	Port        *uint
+}
+
+var _ trafficvault.TrafficVault = &TrafficVault{}
+
+// NewTrafficVault returns a Riak Traffic Vault backend using the given authentication options, and the given port (or the default port, if nil).
+func NewTrafficVault(authOpts *riak.AuthOptions, port *uint) *TrafficVault {
+	return &TrafficVault{AuthOptions: authOpts, Port: port}
+}
+
+func (tv *TrafficVault) Name() string {
+	return trafficvault.BackendRiak
+}
+
+func (tv *TrafficVault) GetDeliveryServiceSSLKeys(xmlID string, version string, tx *sql.Tx) (tc.DeliveryServiceSSLKeysV15, bool, error) {
+	return GetDeliveryServiceSSLKeysObjV15(xmlID, version, tx, tv.AuthOptions, tv.Port)
+}
+
+func (tv *TrafficVault) PutDeliveryServiceSSLKeys(keys tc.DeliveryServiceSSLKeys, tx *sql.Tx) error {
+	return PutDeliveryServiceSSLKeysObj(keys, tx, tv.AuthOptions, tv.Port)
+}
+
+func (tv *TrafficVault) DeleteDeliveryServiceSSLKeys(xmlID string, version string, tx *sql.Tx) error {
+	return DeleteDSSSLKeys(tx, tv.AuthOptions, tv.Port, xmlID, version)
+}
+
+func (tv *TrafficVault) DeleteOldDeliveryServiceSSLKeys(existingXMLIDs map[tc.DeliveryServiceName]struct{}, cdn tc.CDNName, tx *sql.Tx) error {
+	dsKeys, err := GetCDNSSLKeysDSNames(tx, tv.AuthOptions, tv.Port, cdn)
+	if err != nil {
+		return errors.New("getting riak ds keys: " + err.Error())
+	}
+
+	successes := []string{}
+	failures := []string{}
+	for ds, riakKeys := range dsKeys {
+		if _, ok := existingXMLIDs[ds]; ok {
+			continue
+		}
+		for _, riakKey := range riakKeys {
+			err := DeleteDeliveryServicesSSLKey(tx, tv.AuthOptions, tv.Port, riakKey)
+			if err != nil {
+				log.Errorln("deleting Riak SSL keys for Delivery Service '" + string(ds) + "' key '" + riakKey + "': " + err.Error())
+				failures = append(failures, string(ds))
+			} else {
+				log.Infoln("Deleted Riak SSL keys for delivery service which has been deleted in the database '" + string(ds) + "' key '" + riakKey + "'")
+				successes = append(successes, string(ds))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New("successfully deleted Riak SSL keys for deleted dses [" + strings.Join(successes, ", ") + "], but failed to delete Riak SSL keys for [" + strings.Join(failures, ", ") + "]; see the error log for details")
+	}
+	return nil
+}
+
+func (tv *TrafficVault) GetCDNSSLKeys(cdn string, tx *sql.Tx) ([]tc.CDNSSLKey, error) {
+	return GetCDNSSLKeysObj(tx, tv.AuthOptions, tv.Port, cdn)
+}
+
+func (tv *TrafficVault) GetDeliveryServiceSSLKeysCSR(xmlID string, tx *sql.Tx) (tc.DeliveryServiceSSLKeysPendingCSR, bool, error) {
+	csr := tc.DeliveryServiceSSLKeysPendingCSR{}
+	found := false
+	err := WithCluster(tx, tv.AuthOptions, tv.Port, func(cluster StorageCluster) error {
+		ro, err := FetchObjectValues(xmlID, DeliveryServiceSSLKeysCSRBucket, cluster)
+		if err != nil {
+			return err
+		}
+		if len(ro) == 0 {
+			return nil // not found
+		}
+		if err := json.Unmarshal(ro[0].Value, &csr); err != nil {
+			return errors.New("unmarshalling Riak result: " + err.Error())
+		}
+		found = true
+		return nil
+	})
+	if err != nil {
+		return tc.DeliveryServiceSSLKeysPendingCSR{}, false, err
+	}
+	return csr, found, nil
+}
+
+func (tv *TrafficVault) PutDeliveryServiceSSLKeysCSR(csr tc.DeliveryServiceSSLKeysPendingCSR, tx *sql.Tx) error {
+	csrJSON, err := json.Marshal(&csr)
+	if err != nil {
+		return errors.New("marshalling CSR: " + err.Error())
+	}
+	return WithCluster(tx, tv.AuthOptions, tv.Port, func(cluster StorageCluster) error {
+		obj := &riak.Object{
+			ContentType:     "application/json",
+			Charset:         "utf-8",
+			ContentEncoding: "utf-8",
+			Key:             csr.DeliveryService,
+			Value:           csrJSON,
+		}
+		if err := SaveObject(obj, DeliveryServiceSSLKeysCSRBucket, cluster); err != nil {
+			return errors.New("saving Riak object: " + err.Error())
+		}
+		return nil
+	})
+}
+
+func (tv *TrafficVault) DeleteDeliveryServiceSSLKeysCSR(xmlID string, tx *sql.Tx) error {
+	return WithCluster(tx, tv.AuthOptions, tv.Port, func(cluster StorageCluster) error {
+		if err := DeleteObject(xmlID, DeliveryServiceSSLKeysCSRBucket, cluster); err != nil {
+			return errors.New("deleting SSL keys CSR: " + err.Error())
+		}
+		return nil
+	})
+}
+
+func (tv *TrafficVault) GetDNSSECKeys(cdn string, tx *sql.Tx) (tc.DNSSECKeysRiak, bool, error) {
+	return GetDNSSECKeys(cdn, tx, tv.AuthOptions, tv.Port)
+}
+
+func (tv *TrafficVault) PutDNSSECKeys(cdn string, keys tc.DNSSECKeysRiak, tx *sql.Tx) error {
+	return PutDNSSECKeys(keys, cdn, tx, tv.AuthOptions, tv.Port)
+}
+
+func (tv *TrafficVault) DeleteDNSSECKeys(cdn string, tx *sql.Tx) error {
+	return WithCluster(tx, tv.AuthOptions, tv.Port, func(cluster StorageCluster) error {
+		if err := DeleteObject(cdn, DNSSECKeysBucket, cluster); err != nil {
+			return errors.New("deleting DNSSEC keys: " + err.Error())
+		}
+		return nil
+	})
+}
+
+func (tv *TrafficVault) GetURLSigKeys(ds tc.DeliveryServiceName, tx *sql.Tx) (tc.URLSigKeys, bool, error) {
+	return GetURLSigKeys(tx, tv.AuthOptions, tv.Port, ds)
+}
+
+func (tv *TrafficVault) PutURLSigKeys(ds tc.DeliveryServiceName, keys tc.URLSigKeys, tx *sql.Tx) error {
+	return PutURLSigKeys(tx, tv.AuthOptions, tv.Port, ds, keys)
+}
+
+func (tv *TrafficVault) GetURISigningKeys(xmlID string, tx *sql.Tx) ([]byte, bool, error) {
+	return GetURISigningKeysRaw(tx, tv.AuthOptions, tv.Port, xmlID)
+}
+
+func (tv *TrafficVault) PutURISigningKeys(xmlID string, keys []byte, tx *sql.Tx) error {
+	return WithCluster(tx, tv.AuthOptions, tv.Port, func(cluster StorageCluster) error {
+		obj := &riak.Object{
+			ContentType:     "text/json",
+			Charset:         "utf-8",
+			ContentEncoding: "utf-8",
+			Key:             xmlID,
+			Value:           keys,
+		}
+		if err := SaveObject(obj, URISigningKeysBucket, cluster); err != nil {
+			return errors.New("saving Riak object: " + err.Error())
+		}
+		return nil
+	})
+}
+
+func (tv *TrafficVault) DeleteURISigningKeys(xmlID string, tx *sql.Tx) error {
+	return WithCluster(tx, tv.AuthOptions, tv.Port, func(cluster StorageCluster) error {
+		if err := DeleteObject(xmlID, URISigningKeysBucket, cluster); err != nil {
+			return errors.New("deleting URI Signing keys: " + err.Error())
+		}
+		return nil
+	})
+}
+
+func (tv *TrafficVault) GetBucketKey(bucket string, key string, tx *sql.Tx) ([]byte, bool, error) {
+	return GetBucketKey(tx, tv.AuthOptions, tv.Port, bucket, key)
+}
+
+func (tv *TrafficVault) Ping(tx *sql.Tx) (tc.RiakPingResp, error) {
+	return Ping(tx, tv.AuthOptions, tv.Port)
+}
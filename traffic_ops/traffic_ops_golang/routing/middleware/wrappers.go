@@ -36,6 +36,7 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/about"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tocookie"
 )
 
@@ -89,6 +90,14 @@ type AuthBase struct {
 // GetWrapper returns a Middleware which performs authentication of the current user at the given privilege level.
 // The returned Middleware also adds the auth.CurrentUser object to the request context, which may be retrieved by a handler via api.NewInfo or auth.GetCurrentUser.
 func (a AuthBase) GetWrapper(privLevelRequired int) Middleware {
+	return a.GetCapabilityWrapper(privLevelRequired, nil)
+}
+
+// GetCapabilityWrapper returns a Middleware which performs authentication of the current user, and authorizes them
+// with the given Capabilities if Capability authorization is enabled, or else the given privilege level, as decided
+// by auth.IsAuthorized.
+// The returned Middleware also adds the auth.CurrentUser object to the request context, which may be retrieved by a handler via api.NewInfo or auth.GetCurrentUser.
+func (a AuthBase) GetCapabilityWrapper(privLevelRequired int, capabilitiesRequired []string) Middleware {
 	if a.Override != nil {
 		return a.Override
 	}
@@ -99,7 +108,11 @@ func (a AuthBase) GetWrapper(privLevelRequired int) Middleware {
 				api.HandleErr(w, r, nil, errCode, userErr, sysErr)
 				return
 			}
-			if user.PrivLevel < privLevelRequired {
+			useCapabilities := false
+			if cfg, err := api.GetConfig(r.Context()); err == nil {
+				useCapabilities = cfg.UseCapabilities
+			}
+			if !auth.IsAuthorized(user, privLevelRequired, capabilitiesRequired, useCapabilities) {
 				api.HandleErr(w, r, nil, http.StatusForbidden, errors.New("Forbidden."), nil)
 				return
 			}
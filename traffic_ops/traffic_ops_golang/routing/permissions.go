@@ -0,0 +1,114 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+)
+
+// CapabilityPrivLevels returns the privilege level of the Routes which require each Capability. It returns an error if
+// any Capability is required by Routes of different privilege levels, because then the privilege levels of Roles
+// without Capabilities couldn't be mapped onto Capabilities.
+func CapabilityPrivLevels(routes []Route) (map[string]int, error) {
+	levels := map[string]int{}
+	for _, r := range routes {
+		for _, c := range r.RequiredCapabilities {
+			if level, ok := levels[c]; ok && level != r.RequiredPrivLevel {
+				return nil, fmt.Errorf("route %d requires capability '%s' at privilege level %d, but other routes require it at privilege level %d", r.ID, c, r.RequiredPrivLevel, level)
+			}
+			levels[c] = r.RequiredPrivLevel
+		}
+	}
+	return levels, nil
+}
+
+// CurrentUserPermissions returns the handler for GET requests to /user/current/permissions, which lists the
+// authenticated user's effective Capabilities, and which of the given Routes of the requested API major version they
+// may use.
+func CurrentUserPermissions(routes []Route, capabilityPrivLevels map[string]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		defer inf.Close()
+
+		roleName := ""
+		if err := inf.Tx.Tx.QueryRow(`SELECT name FROM role WHERE id = $1`, inf.User.Role).Scan(&roleName); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("querying role name: "+err.Error()))
+			return
+		}
+
+		useCapabilities := inf.Config != nil && inf.Config.UseCapabilities
+		perms := tc.UserPermissions{
+			Username:                inf.User.UserName,
+			Role:                    inf.User.Role,
+			RoleName:                roleName,
+			PrivLevel:               inf.User.PrivLevel,
+			CapabilityAuthorization: useCapabilities && len(inf.User.Capabilities) > 0,
+			Endpoints:               []tc.EndpointPermission{},
+		}
+		if perms.CapabilityAuthorization {
+			perms.Capabilities = append([]string{}, inf.User.Capabilities...)
+			sort.Strings(perms.Capabilities)
+		} else {
+			perms.Capabilities = auth.PrivLevelCapabilities(inf.User.PrivLevel, capabilityPrivLevels)
+		}
+
+		for _, rt := range routes {
+			if !rt.Authenticated || rt.Version.Major != inf.Version.Major || rt.Version.Minor > inf.Version.Minor {
+				continue
+			}
+			required := rt.RequiredCapabilities
+			if required == nil {
+				required = []string{}
+			}
+			perms.Endpoints = append(perms.Endpoints, tc.EndpointPermission{
+				Method:               rt.Method,
+				Path:                 routeTemplate(rt.Path),
+				RequiredCapabilities: required,
+				RequiredPrivLevel:    rt.RequiredPrivLevel,
+				Allowed:              auth.IsAuthorized(*inf.User, rt.RequiredPrivLevel, rt.RequiredCapabilities, useCapabilities),
+			})
+		}
+		api.WriteResp(w, r, perms)
+	}
+}
+
+// routeTemplate returns the given Route path without the regular expression syntax at its end, e.g. "servers/{id}"
+// for `servers/{id}/?$`.
+func routeTemplate(path string) string {
+	for {
+		trimmed := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(path, "$"), "?"), "/")
+		if trimmed == path {
+			return path
+		}
+		path = trimmed
+	}
+}
@@ -0,0 +1,117 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+func TestCapabilityPrivLevels(t *testing.T) {
+	routes := []Route{
+		{api.Version{4, 0}, http.MethodGet, `things/?$`, nil, auth.PrivLevelReadOnly, []string{"things-read"}, true, nil, 1},
+		{api.Version{4, 0}, http.MethodPost, `things/?$`, nil, auth.PrivLevelOperations, []string{"things-write"}, true, nil, 2},
+		{api.Version{4, 0}, http.MethodPut, `things/{id}/?$`, nil, auth.PrivLevelOperations, []string{"things-read", "things-write"}, true, nil, 3},
+	}
+	if _, err := CapabilityPrivLevels(routes); err == nil {
+		t.Error("expected an error for a capability required at two privilege levels, actual: nil")
+	}
+
+	routes[2].RequiredCapabilities = []string{"things-write"}
+	levels, err := CapabilityPrivLevels(routes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(levels) != 2 || levels["things-read"] != auth.PrivLevelReadOnly || levels["things-write"] != auth.PrivLevelOperations {
+		t.Errorf("expected things-read at %d and things-write at %d, actual: %v", auth.PrivLevelReadOnly, auth.PrivLevelOperations, levels)
+	}
+}
+
+func TestRouteCapabilitiesAreConsistent(t *testing.T) {
+	u, err := url.Parse("https://to.test")
+	if err != nil {
+		t.Fatal("error parsing test url")
+	}
+	routes, _, _, err := Routes(ServerData{Config: config.Config{URL: u, Secrets: []string{"n0SeCr3t$"}}})
+	if err != nil {
+		t.Fatalf("error fetching routes: %v", err)
+	}
+	if _, err := CapabilityPrivLevels(routes); err != nil {
+		t.Error(err.Error())
+	}
+	for _, r := range routes {
+		if !r.Authenticated && len(r.RequiredCapabilities) > 0 {
+			t.Errorf("route %d is unauthenticated, but requires capabilities %v", r.ID, r.RequiredCapabilities)
+		}
+	}
+}
+
+// routesWithoutCapabilities are the IDs of the authenticated API 4.x routes which deliberately don't require
+// Capabilities, because they authorize users by who they are rather than by their Roles.
+var routesWithoutCapabilities = map[int]string{
+	4434348253:  "user/logout: any authenticated user may log out",
+	47642815683: "POST servercheck: check results are submitted by users of any privilege level",
+	4549549943:  "GET federations: the current user's own federation resolver mappings",
+	48940647423: "POST federations: the current user's own federation resolver mappings",
+	420983233:   "DELETE federations: the current user's own federation resolver mappings",
+	42831825163: "PUT federations: the current user's own federation resolver mappings",
+	41748524573: "GET steering: the Steering Delivery Services of the current steering user",
+}
+
+func TestV4RoutesRequireCapabilities(t *testing.T) {
+	u, err := url.Parse("https://to.test")
+	if err != nil {
+		t.Fatal("error parsing test url")
+	}
+	routes, _, _, err := Routes(ServerData{Config: config.Config{URL: u, Secrets: []string{"n0SeCr3t$"}}})
+	if err != nil {
+		t.Fatalf("error fetching routes: %v", err)
+	}
+	for _, r := range routes {
+		if r.Version.Major != 4 || !r.Authenticated {
+			continue
+		}
+		_, allowed := routesWithoutCapabilities[r.ID]
+		if len(r.RequiredCapabilities) == 0 && !allowed {
+			t.Errorf("route %d (%s %s) requires no capabilities", r.ID, r.Method, r.Path)
+		} else if len(r.RequiredCapabilities) > 0 && allowed {
+			t.Errorf("route %d (%s %s) requires capabilities %v, but is listed as requiring none", r.ID, r.Method, r.Path, r.RequiredCapabilities)
+		}
+	}
+}
+
+func TestRouteTemplate(t *testing.T) {
+	for path, expected := range map[string]string{
+		`servers/{id}/?$`:           "servers/{id}",
+		`jobs/?`:                    "jobs",
+		`to_extensions$`:            "to_extensions",
+		`user/current/permissions`:  "user/current/permissions",
+		`cdns/{name}/snapshot/new$`: "cdns/{name}/snapshot/new",
+	} {
+		if actual := routeTemplate(path); actual != expected {
+			t.Errorf("expected the template of `%s` to be '%s', actual: '%s'", path, expected, actual)
+		}
+	}
+}
@@ -53,9 +53,14 @@ type Route struct {
 	Path              string
 	Handler           http.HandlerFunc
 	RequiredPrivLevel int
-	Authenticated     bool
-	Middlewares       []middleware.Middleware
-	ID                int // unique ID for referencing this Route
+	// RequiredCapabilities are the Capabilities a user's Role must have to use the Route, when Capability authorization
+	// is enabled. If nil, the Route is authorized by RequiredPrivLevel alone. Every Route which requires a given
+	// Capability must have the same RequiredPrivLevel, so that the privilege levels of Roles without Capabilities map
+	// onto Capabilities exactly.
+	RequiredCapabilities []string
+	Authenticated        bool
+	Middlewares          []middleware.Middleware
+	ID                   int // unique ID for referencing this Route
 }
 
 func (r Route) String() string {
@@ -166,7 +171,7 @@ func CreateRouteMap(rs []Route, rawRoutes []RawRoute, disabledRouteIDs []int, pe
 			}
 			vstr := strconv.FormatUint(version.Major, 10) + "." + strconv.FormatUint(version.Minor, 10)
 			path := RoutePrefix + "/" + vstr + "/" + r.Path
-			middlewares := getRouteMiddleware(r.Middlewares, authBase, r.Authenticated, r.RequiredPrivLevel, requestTimeout)
+			middlewares := getRouteMiddleware(r.Middlewares, authBase, r.Authenticated, r.RequiredPrivLevel, r.RequiredCapabilities, requestTimeout)
 
 			if isDisabledRoute {
 				m[r.Method] = append(m[r.Method], PathHandler{Path: path, Handler: middleware.WrapAccessLog(authBase.Secret, middleware.DisabledRouteHandler()), ID: r.ID})
@@ -177,7 +182,7 @@ func CreateRouteMap(rs []Route, rawRoutes []RawRoute, disabledRouteIDs []int, pe
 		}
 	}
 	for _, r := range rawRoutes {
-		middlewares := getRouteMiddleware(r.Middlewares, authBase, r.Authenticated, r.RequiredPrivLevel, requestTimeout)
+		middlewares := getRouteMiddleware(r.Middlewares, authBase, r.Authenticated, r.RequiredPrivLevel, nil, requestTimeout)
 		m[r.Method] = append(m[r.Method], PathHandler{Path: r.Path, Handler: middleware.Use(r.Handler, middlewares)})
 		log.Infof("adding raw route %v %v\n", r.Method, r.Path)
 	}
@@ -189,12 +194,12 @@ func CreateRouteMap(rs []Route, rawRoutes []RawRoute, disabledRouteIDs []int, pe
 	return m, versionSet
 }
 
-func getRouteMiddleware(middlewares []middleware.Middleware, authBase middleware.AuthBase, authenticated bool, privLevel int, requestTimeout time.Duration) []middleware.Middleware {
+func getRouteMiddleware(middlewares []middleware.Middleware, authBase middleware.AuthBase, authenticated bool, privLevel int, capabilities []string, requestTimeout time.Duration) []middleware.Middleware {
 	if middlewares == nil {
 		middlewares = middleware.GetDefault(authBase.Secret, requestTimeout)
 	}
 	if authenticated { // a privLevel of zero is an unauthenticated endpoint.
-		authWrapper := authBase.GetWrapper(privLevel)
+		authWrapper := authBase.GetCapabilityWrapper(privLevel, capabilities)
 		middlewares = append(middlewares, authWrapper)
 	}
 	return middlewares
@@ -337,6 +342,9 @@ func RegisterRoutes(d ServerData) error {
 	if err != nil {
 		return err
 	}
+	if d.Config.CapabilityPrivLevels, err = CapabilityPrivLevels(routeSlice); err != nil {
+		return err
+	}
 
 	authBase := middleware.AuthBase{Secret: d.Config.Secrets[0], Override: nil} //we know d.Config.Secrets is a slice of at least one or start up would fail.
 	routes, versions := CreateRouteMap(routeSlice, rawRoutes, d.DisabledRoutes, handlerToFunc(catchall), authBase, d.RequestTimeout)
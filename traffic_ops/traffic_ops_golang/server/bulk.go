@@ -0,0 +1,441 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+// bulkSavepoint is the name of the savepoint to which the transaction of a
+// bulk server operation is rolled back when it fails on a server.
+const bulkSavepoint = "bulk_server"
+
+// csvContentType is the media type of bulk server creations and updates
+// uploaded as CSV.
+const csvContentType = "text/csv"
+
+// bulkServerFunc performs a bulk server operation on the server at the given
+// index of the request. It returns the server's ID and host name, if known,
+// whether or not it failed.
+type bulkServerFunc func(i int) (*int, *string, error, error)
+
+// doBulk performs a bulk server operation on each of count servers. Each is
+// done in its own savepoint, so that the operation failing on a server undoes
+// only its changes to that server. A system error on any server aborts the
+// whole operation, and is returned.
+func doBulk(tx *sql.Tx, operation string, allOrNothing bool, count int, f bulkServerFunc) (tc.ServerBulkResult, error) {
+	result := tc.ServerBulkResult{
+		Operation:    operation,
+		AllOrNothing: allOrNothing,
+		Results:      make([]tc.ServerBulkItemResult, 0, count),
+	}
+	for i := 0; i < count; i++ {
+		if _, err := tx.Exec(`SAVEPOINT ` + bulkSavepoint); err != nil {
+			return result, fmt.Errorf("creating savepoint for server #%d of the request: %v", i, err)
+		}
+		id, hostName, userErr, sysErr := f(i)
+		if sysErr != nil {
+			return result, fmt.Errorf("server #%d of the request: %v", i, sysErr)
+		}
+		item := tc.ServerBulkItemResult{Index: i, ID: id, HostName: hostName}
+		if userErr != nil {
+			if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT ` + bulkSavepoint); err != nil {
+				return result, fmt.Errorf("rolling back to savepoint for server #%d of the request: %v", i, err)
+			}
+			item.Error = util.StrPtr(userErr.Error())
+			result.Failed++
+		} else {
+			if _, err := tx.Exec(`RELEASE SAVEPOINT ` + bulkSavepoint); err != nil {
+				return result, fmt.Errorf("releasing savepoint for server #%d of the request: %v", i, err)
+			}
+			result.Succeeded++
+		}
+		result.Results = append(result.Results, item)
+	}
+	result.Committed = !allOrNothing || result.Failed == 0
+	return result, nil
+}
+
+// writeBulkResult writes the response to a bulk server operation, described
+// by the given past participle, e.g. "updated". If the operation is
+// all-or-nothing and failed on any server, the transaction is rolled back and
+// the response is a 400; otherwise a single change log entry summarizing the
+// operation is created.
+func writeBulkResult(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, result tc.ServerBulkResult, done string) {
+	tx := inf.Tx.Tx
+	total := result.Succeeded + result.Failed
+	if !result.Committed {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Errorf("rolling back all-or-nothing bulk server %s: %v", result.Operation, err)
+		}
+		alerts := tc.CreateAlerts(tc.ErrorLevel, fmt.Sprintf("no servers were %s because %d of %d failed", done, result.Failed, total))
+		api.WriteAlertsObj(w, r, http.StatusBadRequest, alerts, result)
+		return
+	}
+
+	msg := fmt.Sprintf("SERVERS: bulk %s of %d servers, ACTION: %d %s, %d failed", result.Operation, total, result.Succeeded, done, result.Failed)
+	audit := api.Audit{
+		Action:     tc.WebhookActionBulk,
+		ObjectType: auditObjectType,
+		After:      result,
+	}
+	if err := api.CreateAuditLog(api.ApiChange, msg, audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, fmt.Sprintf("%d of %d servers %s", result.Succeeded, total, done))
+	if result.Failed > 0 {
+		alerts.AddNewAlert(tc.WarnLevel, fmt.Sprintf("%d of %d servers failed", result.Failed, total))
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, result)
+}
+
+// parseBulkServers parses the request body of a bulk server creation or
+// update, which is either JSON or, if its Content-Type is text/csv, CSV, in
+// which case whether it's all-or-nothing is given by the allOrNothing query
+// parameter.
+func parseBulkServers(r *http.Request, params map[string]string) (tc.ServerBulkRequest, error) {
+	req := tc.ServerBulkRequest{}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != csvContentType {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return req, errors.New("malformed JSON: " + err.Error())
+		}
+		return req, req.Validate()
+	}
+
+	if allOrNothing, ok := params["allOrNothing"]; ok {
+		var err error
+		if req.AllOrNothing, err = strconv.ParseBool(allOrNothing); err != nil {
+			return req, errors.New("allOrNothing must be a boolean")
+		}
+	}
+	servers, err := parseServersCSV(r.Body)
+	if err != nil {
+		return req, err
+	}
+	req.Servers = servers
+	return req, req.Validate()
+}
+
+// serverCSVColumns returns whether each property of a server, by its JSON
+// name, is a string.
+func serverCSVColumns() map[string]bool {
+	columns := map[string]bool{}
+	var addFields func(t reflect.Type)
+	addFields = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.Anonymous {
+				addFields(field.Type)
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			columns[name] = fieldType.Kind() == reflect.String
+		}
+	}
+	addFields(reflect.TypeOf(tc.ServerV40{}))
+	return columns
+}
+
+// parseServersCSV parses servers from CSV whose first row is a header of the
+// JSON names of the server properties given in the columns of the other rows.
+// String properties are given as they are, the others as JSON, e.g. the
+// "interfaces" of a server as a JSON array. Empty cells are null.
+func parseServersCSV(r io.Reader) ([]tc.ServerV40, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("CSV has no header row")
+	} else if err != nil {
+		return nil, errors.New("malformed CSV: " + err.Error())
+	}
+	columns := serverCSVColumns()
+	for _, name := range header {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.New("CSV has unknown server property column: " + name)
+		}
+	}
+
+	servers := []tc.ServerV40{}
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.New("malformed CSV: " + err.Error())
+		}
+		obj := map[string]json.RawMessage{}
+		for i, value := range record {
+			if value == "" {
+				continue
+			}
+			if columns[header[i]] {
+				// Marshalling a string can't fail.
+				quoted, _ := json.Marshal(value)
+				obj[header[i]] = quoted
+			} else {
+				obj[header[i]] = json.RawMessage(value)
+			}
+		}
+		bts, err := json.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("CSV row %d has invalid JSON: %v", row, err)
+		}
+		server := tc.ServerV40{}
+		if err := json.Unmarshal(bts, &server); err != nil {
+			return nil, fmt.Errorf("CSV row %d: %v", row, err)
+		}
+		servers = append(servers, server)
+	}
+	return servers, nil
+}
+
+// CreateBulk is the handler for POST requests to /servers/bulk.
+func CreateBulk(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	req, err := parseBulkServers(r, inf.Params)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	result, err := doBulk(tx, tc.ServerBulkOperationCreate, req.AllOrNothing, len(req.Servers), func(i int) (*int, *string, error, error) {
+		server := req.Servers[i]
+		userErr, sysErr, _ := createServer(inf, &server)
+		return server.ID, server.HostName, userErr, sysErr
+	})
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	writeBulkResult(w, r, inf, result, "created")
+}
+
+// UpdateBulk is the handler for PUT requests to /servers/bulk.
+func UpdateBulk(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	req, err := parseBulkServers(r, inf.Params)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	result, err := doBulk(tx, tc.ServerBulkOperationUpdate, req.AllOrNothing, len(req.Servers), func(i int) (*int, *string, error, error) {
+		server := req.Servers[i]
+		_, userErr, sysErr, _ := updateServerByID(inf, &server)
+		return server.ID, server.HostName, userErr, sysErr
+	})
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	writeBulkResult(w, r, inf, result, "updated")
+}
+
+// UpdateStatusBulk is the handler for PUT requests to /servers/bulk/status.
+func UpdateStatusBulk(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	req := tc.ServerBulkStatusRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("malformed JSON: "+err.Error()), nil)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	status, userErr, sysErr, errCode := getRequestedStatus(tx, req.Status)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	offlineReason, err := getOfflineReason(status, req.OfflineReason, inf.User.UserName)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	result, err := doBulk(tx, tc.ServerBulkOperationStatus, req.AllOrNothing, len(req.ServerIDs), func(i int) (*int, *string, error, error) {
+		id := req.ServerIDs[i]
+		serverInfo, _, _, userErr, sysErr, _ := updateStatus(tx, id, status, offlineReason)
+		var hostName *string
+		if serverInfo.HostName != "" {
+			hostName = util.StrPtr(serverInfo.HostName)
+		}
+		return util.IntPtr(id), hostName, userErr, sysErr
+	})
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	writeBulkResult(w, r, inf, result, "set to "+*status.Name)
+}
+
+// getRequestedProfile returns the ID and name of the Profile with the given
+// name or ID.
+func getRequestedProfile(tx *sql.Tx, nameOrID util.JSONNameOrIDStr) (int, string, error, error, int) {
+	if nameOrID.ID != nil {
+		name, ok, err := dbhelpers.GetProfileNameFromID(*nameOrID.ID, tx)
+		if err != nil {
+			return 0, "", nil, fmt.Errorf("getting Profile #%d: %v", *nameOrID.ID, err), http.StatusInternalServerError
+		} else if !ok {
+			return 0, "", fmt.Errorf("no such Profile: #%d", *nameOrID.ID), nil, http.StatusBadRequest
+		}
+		return *nameOrID.ID, name, nil, nil, http.StatusOK
+	}
+	id, ok, err := dbhelpers.GetProfileIDFromName(*nameOrID.Name, tx)
+	if err != nil {
+		return 0, "", nil, fmt.Errorf("getting Profile '%s': %v", *nameOrID.Name, err), http.StatusInternalServerError
+	} else if !ok {
+		return 0, "", errors.New("no such Profile: " + *nameOrID.Name), nil, http.StatusBadRequest
+	}
+	return id, *nameOrID.Name, nil, nil, http.StatusOK
+}
+
+// UpdateProfileBulk is the handler for PUT requests to /servers/bulk/profile.
+func UpdateProfileBulk(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	req := tc.ServerBulkProfileRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("malformed JSON: "+err.Error()), nil)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	profileID, profileName, userErr, sysErr, errCode := getRequestedProfile(tx, req.Profile)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	result, err := doBulk(tx, tc.ServerBulkOperationProfile, req.AllOrNothing, len(req.ServerIDs), func(i int) (*int, *string, error, error) {
+		id := req.ServerIDs[i]
+		server, userErr, sysErr, _ := getOriginalServer(http.Header{}, inf, id, api.Version{Major: 4, Minor: 0})
+		if userErr != nil || sysErr != nil {
+			return util.IntPtr(id), server.HostName, userErr, sysErr
+		}
+		server.ProfileID = util.IntPtr(profileID)
+		server.Profile = util.StrPtr(profileName)
+		_, userErr, sysErr, _ = updateServerByID(inf, &server)
+		return util.IntPtr(id), server.HostName, userErr, sysErr
+	})
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	writeBulkResult(w, r, inf, result, "given Profile "+profileName)
+}
+
+// AssignDeliveryServicesBulk is the handler for POST requests to
+// /servers/bulk/deliveryservices.
+func AssignDeliveryServicesBulk(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	req := tc.ServerBulkAssignRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("malformed JSON: "+err.Error()), nil)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if req.DeliveryServiceIDs == nil {
+		req.DeliveryServiceIDs = []int{}
+	}
+
+	result, err := doBulk(tx, tc.ServerBulkOperationAssign, req.AllOrNothing, len(req.ServerIDs), func(i int) (*int, *string, error, error) {
+		id := req.ServerIDs[i]
+		serverInfo, _, userErr, sysErr, _ := assignDeliveryServices(tx, inf.User, id, req.DeliveryServiceIDs, req.Replace)
+		var hostName *string
+		if serverInfo.HostName != "" {
+			hostName = util.StrPtr(serverInfo.HostName)
+		}
+		return util.IntPtr(id), hostName, userErr, sysErr
+	})
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	writeBulkResult(w, r, inf, result, "assigned Delivery Services")
+}
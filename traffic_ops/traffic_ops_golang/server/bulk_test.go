@@ -0,0 +1,108 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestParseServersCSV(t *testing.T) {
+	csv := `hostName,domainName,cdnId,tcpPort,interfaces
+001,example.test,1,,"[{""name"":""eth0"",""monitor"":true,""ipAddresses"":[{""address"":""192.0.2.1"",""serviceAddress"":true}]}]"
+edge2,example.test,2,8080,
+`
+	servers, err := parseServersCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("unexpected error parsing CSV: %v", err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("expected 2 servers, actual: %d", len(servers))
+	}
+	if servers[0].HostName == nil || *servers[0].HostName != "001" {
+		t.Errorf("expected numeric host name to be parsed as a string, actual: %v", servers[0].HostName)
+	}
+	if servers[0].CDNID == nil || *servers[0].CDNID != 1 {
+		t.Errorf("expected cdnId 1, actual: %v", servers[0].CDNID)
+	}
+	if servers[0].TCPPort != nil {
+		t.Errorf("expected empty tcpPort to be null, actual: %d", *servers[0].TCPPort)
+	}
+	if len(servers[0].Interfaces) != 1 || servers[0].Interfaces[0].Name != "eth0" || len(servers[0].Interfaces[0].IPAddresses) != 1 {
+		t.Errorf("expected one interface eth0 with one IP address, actual: %+v", servers[0].Interfaces)
+	}
+	if servers[1].TCPPort == nil || *servers[1].TCPPort != 8080 {
+		t.Errorf("expected tcpPort 8080, actual: %v", servers[1].TCPPort)
+	}
+
+	if _, err := parseServersCSV(strings.NewReader("hostName,notAProperty\nedge,x\n")); err == nil {
+		t.Error("expected an error for an unknown column, actual: nil")
+	}
+	if _, err := parseServersCSV(strings.NewReader("hostName,cdnId\nedge,one\n")); err == nil || !strings.Contains(err.Error(), "row 2") {
+		t.Errorf("expected an error for row 2 with an invalid cdnId, actual: %v", err)
+	}
+}
+
+func TestDoBulk(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT " + bulkSavepoint).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT " + bulkSavepoint).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT " + bulkSavepoint).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT " + bulkSavepoint).WillReturnResult(sqlmock.NewResult(0, 0))
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatalf("beginning transaction: %v", err)
+	}
+
+	hostNames := []string{"edge1", "edge2"}
+	result, err := doBulk(tx, tc.ServerBulkOperationStatus, true, len(hostNames), func(i int) (*int, *string, error, error) {
+		if i == 1 {
+			return util.IntPtr(i), util.StrPtr(hostNames[i]), errors.New("bad server"), nil
+		}
+		return util.IntPtr(i), util.StrPtr(hostNames[i]), nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+	if result.Succeeded != 1 || result.Failed != 1 {
+		t.Errorf("expected 1 success and 1 failure, actual: %d and %d", result.Succeeded, result.Failed)
+	}
+	if result.Committed {
+		t.Error("expected an all-or-nothing operation with a failure not to be committed")
+	}
+	if len(result.Results) != 2 || result.Results[0].Error != nil || result.Results[1].Error == nil || *result.Results[1].Error != "bad server" {
+		t.Errorf("expected only the second server to have an error, actual: %+v", result.Results)
+	}
+}
@@ -394,5 +394,62 @@ JOIN type t ON server.type = t.id
 		server.HardwareInfo = hw
 		servers[i] = server
 	}
+
+	if reqVersion.Major < 4 {
+		return servers, nil
+	}
+	windows, err := getServerMaintenanceWindows(tx, sIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i, server := range servers {
+		server.MaintenanceWindows = windows[*server.ID]
+		if server.MaintenanceWindows == nil {
+			server.MaintenanceWindows = []tc.ServerMaintenanceWindow{}
+		}
+		servers[i] = server
+	}
 	return servers, nil
 }
+
+// getServerMaintenanceWindows returns the scheduled and active maintenance windows of the servers with the given IDs. Scheduled windows include the servers in their Cache Groups, but active windows only include the servers whose statuses they set.
+func getServerMaintenanceWindows(tx *sql.Tx, serverIDs []int) (map[int][]tc.ServerMaintenanceWindow, error) {
+	qry := `
+SELECT s.id,
+	mw.id,
+	mw.reason,
+	mw.start_time,
+	mw.end_time,
+	st.name,
+	mw.state
+FROM maintenance_window AS mw
+JOIN status AS st ON st.id = mw.status
+JOIN server AS s ON (
+	mw.state = '` + tc.MaintenanceWindowStateScheduled + `'
+	AND (
+		s.id IN (SELECT mws.server FROM maintenance_window_server AS mws WHERE mws.maintenance_window = mw.id)
+		OR s.cachegroup IN (SELECT mwc.cachegroup FROM maintenance_window_cachegroup AS mwc WHERE mwc.maintenance_window = mw.id)
+	)
+) OR (
+	mw.state = '` + tc.MaintenanceWindowStateActive + `'
+	AND s.id IN (SELECT mwa.server FROM maintenance_window_applied AS mwa WHERE mwa.maintenance_window = mw.id)
+)
+WHERE s.id = ANY($1)
+ORDER BY mw.start_time, mw.id
+`
+	rows, err := tx.Query(qry, pq.Array(serverIDs))
+	if err != nil {
+		return nil, errors.New("querying detail server maintenance windows: " + err.Error())
+	}
+	defer rows.Close()
+	windows := map[int][]tc.ServerMaintenanceWindow{}
+	for rows.Next() {
+		serverID := 0
+		mw := tc.ServerMaintenanceWindow{}
+		if err := rows.Scan(&serverID, &mw.ID, &mw.Reason, &mw.StartTime, &mw.EndTime, &mw.Status, &mw.State); err != nil {
+			return nil, errors.New("scanning detail server maintenance windows: " + err.Error())
+		}
+		windows[serverID] = append(windows[serverID], mw)
+	}
+	return windows, rows.Err()
+}
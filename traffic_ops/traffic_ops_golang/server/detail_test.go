@@ -15,6 +15,7 @@ package server
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-util"
@@ -168,12 +169,48 @@ func TestGetDetailServers(t *testing.T) {
 	}
 }
 
+func TestGetServerMaintenanceWindows(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	start := time.Date(2021, 3, 24, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	rows := sqlmock.NewRows([]string{"server", "id", "reason", "start_time", "end_time", "status", "state"})
+	rows = rows.AddRow(1, 10, "reboot", start, end, "ADMIN_DOWN", tc.MaintenanceWindowStateActive)
+	rows = rows.AddRow(1, 11, "upgrade", end, end.Add(time.Hour), "OFFLINE", tc.MaintenanceWindowStateScheduled)
+	rows = rows.AddRow(2, 11, "upgrade", end, end.Add(time.Hour), "OFFLINE", tc.MaintenanceWindowStateScheduled)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM maintenance_window").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	windows, err := getServerMaintenanceWindows(db.MustBegin().Tx, []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("an error '%s' occurred getting server maintenance windows", err)
+	}
+	if len(windows[1]) != 2 || windows[1][0].ID != 10 || windows[1][1].ID != 11 {
+		t.Errorf("expected server 1 to be in windows 10 and 11, actual %+v", windows[1])
+	}
+	if len(windows[2]) != 1 || windows[2][0].State != tc.MaintenanceWindowStateScheduled || !windows[2][0].StartTime.Equal(end) {
+		t.Errorf("expected server 2 to be in scheduled window 11, actual %+v", windows[2])
+	}
+	if len(windows[3]) != 0 {
+		t.Errorf("expected server 3 to be in no windows, actual %+v", windows[3])
+	}
+}
+
 func getMockServerDetails() []tc.ServerDetailV40 {
 	srvData := tc.ServerDetailV40{
-		tc.ServerDetail{
+		ServerDetail: tc.ServerDetail{
 			ID: util.IntPtr(1),
 		},
-		[]tc.ServerInterfaceInfoV40{}, // left empty because it must be written as json above since sqlmock does not support nested arrays
+		ServerInterfaces: []tc.ServerInterfaceInfoV40{}, // left empty because it must be written as json above since sqlmock does not support nested arrays
 	}
 	return []tc.ServerDetailV40{srvData}
 }
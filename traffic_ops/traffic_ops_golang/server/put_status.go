@@ -31,6 +31,7 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 )
@@ -76,79 +77,135 @@ func UpdateStatusHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	id := inf.IntParams["id"]
-	serverInfo, exists, err := dbhelpers.GetServerInfo(id, tx)
-	if err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+	status, userErr, sysErr, errCode := getRequestedStatus(tx, reqObj.Status)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
 		return
 	}
-	if !exists {
-		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("server ID %d not found", id), nil)
+	if reqObj.OfflineReason, userErr = getOfflineReason(status, reqObj.OfflineReason, inf.User.UserName); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
 		return
 	}
 
-	status := tc.StatusNullable{}
-	statusExists := false
-	if reqObj.Status.Name != nil {
-		status, statusExists, err = dbhelpers.GetStatusByName(*reqObj.Status.Name, tx)
-	} else if reqObj.Status.ID != nil {
-		status, statusExists, err = dbhelpers.GetStatusByID(*reqObj.Status.ID, tx)
-	} else {
-		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("status is required"), nil)
+	serverInfo, existingStatus, queued, userErr, sysErr, errCode := updateStatus(tx, id, status, reqObj.OfflineReason)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
 		return
 	}
-	if err != nil {
+	offlineReason := ""
+	if reqObj.OfflineReason != nil {
+		offlineReason = *reqObj.OfflineReason
+	}
+	msg := "Updated status [ " + *status.Name + " ] for " + serverInfo.HostName + "." + serverInfo.DomainName + " [ " + offlineReason + " ]"
+	if queued {
+		msg += " and queued updates on all child caches"
+	}
+	audit := api.Audit{
+		Action:     api.Updated,
+		ObjectType: auditObjectType,
+		ObjectID:   strconv.Itoa(id),
+		Before:     map[string]interface{}{"statusId": existingStatus},
+		After:      map[string]interface{}{"statusId": *status.ID, "status": *status.Name, "offlineReason": reqObj.OfflineReason},
+	}
+	if cdnName, ok, err := dbhelpers.GetCDNNameFromID(tx, int64(serverInfo.CDNID)); err != nil {
 		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
 		return
+	} else if ok {
+		cdn := string(cdnName)
+		audit.CDNName = &cdn
 	}
-	if !statusExists {
-		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("invalid status (does not exist)"), nil)
+	if err := api.CreateAuditLog(api.ApiChange, msg, audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
 		return
 	}
+	api.WriteRespAlert(w, r, tc.SuccessLevel, msg)
+}
 
-	if *status.Name == tc.CacheStatusAdminDown.String() || *status.Name == tc.CacheStatusOffline.String() {
-		if reqObj.OfflineReason == nil {
-			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("offlineReason is required for "+tc.CacheStatusAdminDown.String()+" or "+tc.CacheStatusOffline.String()+" status"), nil)
-			return
-		}
-		*reqObj.OfflineReason = inf.User.UserName + ": " + *reqObj.OfflineReason
+// getRequestedStatus returns the status with the given name or ID.
+func getRequestedStatus(tx *sql.Tx, nameOrID util.JSONNameOrIDStr) (tc.StatusNullable, error, error, int) {
+	status := tc.StatusNullable{}
+	exists := false
+	var err error
+	if nameOrID.Name != nil {
+		status, exists, err = dbhelpers.GetStatusByName(*nameOrID.Name, tx)
+	} else if nameOrID.ID != nil {
+		status, exists, err = dbhelpers.GetStatusByID(*nameOrID.ID, tx)
 	} else {
-		reqObj.OfflineReason = nil
+		return status, errors.New("status is required"), nil, http.StatusBadRequest
+	}
+	if err != nil {
+		return status, nil, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return status, errors.New("invalid status (does not exist)"), nil, http.StatusBadRequest
+	}
+	return status, nil, nil, http.StatusOK
+}
+
+// getOfflineReason returns the offline reason to store for a server being
+// given the status by the user with the given name: the given reason,
+// prefixed by the user's name, for ADMIN_DOWN and OFFLINE, for which it's
+// required, and none otherwise.
+func getOfflineReason(status tc.StatusNullable, offlineReason *string, userName string) (*string, error) {
+	if *status.Name != tc.CacheStatusAdminDown.String() && *status.Name != tc.CacheStatusOffline.String() {
+		return nil, nil
+	}
+	if offlineReason == nil {
+		return nil, errors.New("offlineReason is required for " + tc.CacheStatusAdminDown.String() + " or " + tc.CacheStatusOffline.String() + " status")
+	}
+	reason := userName + ": " + *offlineReason
+	return &reason, nil
+}
+
+// updateStatus sets the status of the server with the given ID, unless that
+// would leave any Active Delivery Service without ONLINE or REPORTED servers.
+// It returns the server's information, its previous status, and whether
+// updates were queued on its child caches.
+func updateStatus(tx *sql.Tx, id int, status tc.StatusNullable, offlineReason *string) (tc.ServerInfo, int, bool, error, error, int) {
+	serverInfo, exists, err := dbhelpers.GetServerInfo(id, tx)
+	if err != nil {
+		return serverInfo, 0, false, nil, err, http.StatusInternalServerError
+	}
+	if !exists {
+		return serverInfo, 0, false, fmt.Errorf("server ID %d not found", id), nil, http.StatusNotFound
 	}
 
 	existingStatus, existingStatusUpdatedTime := checkExistingStatusInfo(id, tx)
 	if *status.Name != string(tc.CacheStatusOnline) && *status.Name != string(tc.CacheStatusReported) && *status.ID != existingStatus {
-		dsIDs, err := getActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id, tx)
+		dsIDs, err := GetActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id, tx)
 		if err != nil {
-			sysErr = fmt.Errorf("getting Delivery Services to which server #%d is assigned that have no other servers: %v", id, err)
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-			return
+			return serverInfo, existingStatus, false, nil, fmt.Errorf("getting Delivery Services to which server #%d is assigned that have no other servers: %v", id, err), http.StatusInternalServerError
 		}
 		if len(dsIDs) > 0 {
-			alertText := InvalidStatusForDeliveryServicesAlertText(*status.Name, dsIDs)
-			api.WriteAlerts(w, r, http.StatusConflict, tc.CreateAlerts(tc.ErrorLevel, alertText))
-			return
+			return serverInfo, existingStatus, false, errors.New(InvalidStatusForDeliveryServicesAlertText(*status.Name, dsIDs)), nil, http.StatusConflict
 		}
 	}
-	if err := updateServerStatusAndOfflineReason(existingStatus, *status.ID, id, existingStatusUpdatedTime, reqObj.OfflineReason, tx); err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-		return
-	}
-	offlineReason := ""
-	if reqObj.OfflineReason != nil {
-		offlineReason = *reqObj.OfflineReason
+	queued, err := setStatus(tx, serverInfo, existingStatus, existingStatusUpdatedTime, *status.ID, offlineReason)
+	if err != nil {
+		return serverInfo, existingStatus, false, nil, err, http.StatusInternalServerError
 	}
-	msg := "Updated status [ " + *status.Name + " ] for " + serverInfo.HostName + "." + serverInfo.DomainName + " [ " + offlineReason + " ]"
+	return serverInfo, existingStatus, queued, nil, nil, http.StatusOK
+}
 
+// SetStatus sets the status and offline reason of the given server, and queues updates on its child caches if it's an EDGE or MID. It returns whether updates were queued.
+func SetStatus(tx *sql.Tx, serverInfo tc.ServerInfo, statusID int, offlineReason *string) (bool, error) {
+	existingStatus, existingStatusUpdatedTime := checkExistingStatusInfo(serverInfo.ID, tx)
+	return setStatus(tx, serverInfo, existingStatus, existingStatusUpdatedTime, statusID, offlineReason)
+}
+
+// setStatus is SetStatus for a server whose existing status and status_last_updated values are known.
+func setStatus(tx *sql.Tx, serverInfo tc.ServerInfo, existingStatus int, existingStatusUpdatedTime time.Time, statusID int, offlineReason *string) (bool, error) {
+	if err := updateServerStatusAndOfflineReason(existingStatus, statusID, serverInfo.ID, existingStatusUpdatedTime, offlineReason, tx); err != nil {
+		return false, err
+	}
 	// queue updates on child servers if server is ^EDGE or ^MID
-	if strings.HasPrefix(serverInfo.Type, tc.CacheTypeEdge.String()) || strings.HasPrefix(serverInfo.Type, tc.CacheTypeMid.String()) {
-		if err := queueUpdatesOnChildCaches(tx, serverInfo.CDNID, serverInfo.CachegroupID); err != nil {
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-			return
-		}
-		msg += " and queued updates on all child caches"
+	if !strings.HasPrefix(serverInfo.Type, tc.CacheTypeEdge.String()) && !strings.HasPrefix(serverInfo.Type, tc.CacheTypeMid.String()) {
+		return false, nil
 	}
-	api.CreateChangeLogRawTx(api.ApiChange, msg, inf.User, tx)
-	api.WriteRespAlert(w, r, tc.SuccessLevel, msg)
+	if err := queueUpdatesOnChildCaches(tx, serverInfo.CDNID, serverInfo.CachegroupID); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // queueUpdatesOnChildCaches queues updates on child caches of the given cdnID and parentCachegroupID and returns an error (if one occurs).
@@ -25,10 +25,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 )
 
 // QueueUpdateHandler implements an http handler that updates a server's
@@ -78,6 +80,31 @@ func QueueUpdateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ev := tc.WebhookEvent{
+		Action:     tc.WebhookActionDequeued,
+		ObjectType: auditObjectType,
+		ObjectID:   strconv.FormatInt(serverID, 10),
+	}
+	if queue {
+		ev.Action = tc.WebhookActionQueued
+	}
+	if serverInfo, ok, err := dbhelpers.GetServerInfo(int(serverID), inf.Tx.Tx); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("getting server info: %v", err))
+		return
+	} else if ok {
+		if cdnName, ok, err := dbhelpers.GetCDNNameFromID(inf.Tx.Tx, int64(serverInfo.CDNID)); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("getting server CDN: %v", err))
+			return
+		} else if ok {
+			cdn := string(cdnName)
+			ev.CDNName = &cdn
+		}
+	}
+	if err := api.NotifyWebhooks(inf, ev); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
 	api.WriteResp(w, r, tc.ServerQueueUpdate{
 		ServerID: util.JSONIntStr(serverID),
 		Action:   reqObj.Action,
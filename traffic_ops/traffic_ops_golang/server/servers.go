@@ -52,6 +52,9 @@ import (
 	"github.com/lib/pq"
 )
 
+// auditObjectType is the object type of servers in the audit log.
+const auditObjectType = "server"
+
 const serversFromAndJoin = `
 FROM server AS s
 JOIN cachegroup cg ON s.cachegroup = cg.id
@@ -1446,53 +1449,12 @@ func Update(w http.ResponseWriter, r *http.Request) {
 
 	id := inf.IntParams["id"]
 
-	// Get original server
-	originals, _, userErr, sysErr, errCode, _ := getServers(r.Header, inf.Params, inf.Tx, inf.User, false, *version)
+	original, userErr, sysErr, errCode := getOriginalServer(r.Header, inf, id, *version)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
 		return
 	}
-	if len(originals) < 1 {
-		api.HandleErr(w, r, tx, http.StatusNotFound, errors.New("the server doesn't exist, cannot update"), nil)
-		return
-	}
-	if len(originals) > 1 {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("too many servers by ID %d: %d", id, len(originals)))
-		return
-	}
-
-	original := originals[0]
-	if original.XMPPID == nil {
-		sysErr = errors.New("original server had no XMPPID")
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-		return
-	}
-	if original.StatusID == nil {
-		sysErr = errors.New("original server had no status ID")
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-		return
-	}
-	if original.Status == nil {
-		sysErr = errors.New("original server had no status name")
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-		return
-	}
-	if original.CachegroupID == nil {
-		sysErr = errors.New("original server had no Cache Group ID")
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-		return
-	}
-	if original.StatusLastUpdated == nil {
-		log.Warnln("original server had no Status Last Updated time")
-		if original.LastUpdated == nil {
-			sysErr = errors.New("original server had no Last Updated time")
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-			return
-		}
-		original.StatusLastUpdated = &original.LastUpdated.Time
-	}
 
-	originalXMPPID := *original.XMPPID
 	originalStatusID := *original.StatusID
 
 	var server tc.ServerV40
@@ -1585,11 +1547,105 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if userErr, sysErr, errCode = updateServer(r.Header, inf, original, &server, statusLastUpdatedTime); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	auditServerChange(inf, api.Updated, original, server)
+
+	if inf.Version.Major >= 3 {
+		api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Server updated", server)
+	} else {
+		v2Server, err := server.ToServerV2FromV4()
+		if err != nil {
+			sysErr = fmt.Errorf("converting valid v3 server to a v2 structure: %v", err)
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
+			return
+		}
+		if inf.Version.Major <= 1 {
+			api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Server updated", v2Server.ServerNullableV11)
+		} else {
+			api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Server updated", v2Server)
+		}
+	}
+}
+
+// getOriginalServer returns the server with the given ID, as it is before an update.
+func getOriginalServer(h http.Header, inf *api.APIInfo, id int, version api.Version) (tc.ServerV40, error, error, int) {
+	originals, _, userErr, sysErr, errCode, _ := getServers(h, map[string]string{"id": strconv.Itoa(id)}, inf.Tx, inf.User, false, version)
+	if userErr != nil || sysErr != nil {
+		return tc.ServerV40{}, userErr, sysErr, errCode
+	}
+	if len(originals) < 1 {
+		return tc.ServerV40{}, errors.New("the server doesn't exist, cannot update"), nil, http.StatusNotFound
+	}
+	if len(originals) > 1 {
+		return tc.ServerV40{}, nil, fmt.Errorf("too many servers by ID %d: %d", id, len(originals)), http.StatusInternalServerError
+	}
+
+	original := originals[0]
+	if original.XMPPID == nil {
+		return original, nil, errors.New("original server had no XMPPID"), http.StatusInternalServerError
+	}
+	if original.StatusID == nil {
+		return original, nil, errors.New("original server had no status ID"), http.StatusInternalServerError
+	}
+	if original.Status == nil {
+		return original, nil, errors.New("original server had no status name"), http.StatusInternalServerError
+	}
+	if original.CachegroupID == nil {
+		return original, nil, errors.New("original server had no Cache Group ID"), http.StatusInternalServerError
+	}
+	if original.StatusLastUpdated == nil {
+		log.Warnln("original server had no Status Last Updated time")
+		if original.LastUpdated == nil {
+			return original, nil, errors.New("original server had no Last Updated time"), http.StatusInternalServerError
+		}
+		original.StatusLastUpdated = &original.LastUpdated.Time
+	}
+	return original, nil, nil, http.StatusOK
+}
+
+// UpdateServer updates the server with the ID of the given server in the transaction of inf, exactly as a request to update it through the latest API version would. On success, the given server is updated with the values stored in the database.
+func UpdateServer(inf *api.APIInfo, server *tc.ServerV40) (error, error, int) {
+	original, userErr, sysErr, errCode := updateServerByID(inf, server)
+	if userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	auditServerChange(inf, api.Updated, original, *server)
+	return nil, nil, http.StatusOK
+}
+
+// updateServerByID is UpdateServer without the change log entry. It returns the server as it was before the update.
+func updateServerByID(inf *api.APIInfo, server *tc.ServerV40) (tc.ServerV40, error, error, int) {
+	if server.ID == nil {
+		return tc.ServerV40{}, errors.New("missing id"), nil, http.StatusBadRequest
+	}
+	original, userErr, sysErr, errCode := getOriginalServer(http.Header{}, inf, *server.ID, api.Version{Major: 4, Minor: 0})
+	if userErr != nil || sysErr != nil {
+		return original, userErr, sysErr, errCode
+	}
+
+	statusLastUpdatedTime := *original.StatusLastUpdated
+	if server.StatusID != nil && *server.StatusID != *original.StatusID {
+		statusLastUpdatedTime = time.Now()
+	}
+	server.StatusLastUpdated = &statusLastUpdatedTime
+	if _, err := validateV4(server, inf.Tx.Tx); err != nil {
+		return original, err, nil, http.StatusBadRequest
+	}
+	userErr, sysErr, errCode = updateServer(http.Header{}, inf, original, server, statusLastUpdatedTime)
+	return original, userErr, sysErr, errCode
+}
+
+// updateServer stores the changes to the given, validated server, whose state before the update is original.
+func updateServer(h http.Header, inf *api.APIInfo, original tc.ServerV40, server *tc.ServerV40, statusLastUpdatedTime time.Time) (error, error, int) {
+	tx := inf.Tx.Tx
+	id := *original.ID
 	if *original.CachegroupID != *server.CachegroupID || *original.CDNID != *server.CDNID {
 		hasDSOnCDN, err := dbhelpers.CachegroupHasTopologyBasedDeliveryServicesOnCDN(tx, *original.CachegroupID, *original.CDNID)
 		if err != nil {
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-			return
+			return nil, err, http.StatusInternalServerError
 		}
 		CDNIDs := []int{}
 		if hasDSOnCDN {
@@ -1598,118 +1654,92 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		cacheGroupIds := []int{*original.CachegroupID}
 		serverIds := []int{*original.ID}
 		if err = topology_validation.CheckForEmptyCacheGroups(inf.Tx, cacheGroupIds, CDNIDs, true, serverIds); err != nil {
-			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("server is the last one in its cachegroup, which is used by a topology, so it cannot be moved to another cachegroup: "+err.Error()), nil)
-			return
+			return errors.New("server is the last one in its cachegroup, which is used by a topology, so it cannot be moved to another cachegroup: " + err.Error()), nil, http.StatusBadRequest
 		}
 	}
 
 	server.ID = new(int)
-	*server.ID = inf.IntParams["id"]
+	*server.ID = id
 	status, ok, err := dbhelpers.GetStatusByID(*server.StatusID, tx)
 	if err != nil {
-		sysErr = fmt.Errorf("getting server #%d status (#%d): %v", id, *server.StatusID, err)
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-		return
+		return nil, fmt.Errorf("getting server #%d status (#%d): %v", id, *server.StatusID, err), http.StatusInternalServerError
 	}
 	if !ok {
 		log.Warnf("previously existent status #%d not found when fetching later", *server.StatusID)
-		userErr = fmt.Errorf("no such Status: #%d", *server.StatusID)
-		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
-		return
+		return fmt.Errorf("no such Status: #%d", *server.StatusID), nil, http.StatusBadRequest
 	}
 	if status.Name == nil {
-		sysErr = fmt.Errorf("status #%d had no name", *server.StatusID)
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-		return
+		return nil, fmt.Errorf("status #%d had no name", *server.StatusID), http.StatusInternalServerError
 	}
 	if *status.Name != string(tc.CacheStatusOnline) && *status.Name != string(tc.CacheStatusReported) {
-		dsIDs, err := getActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id, tx)
+		dsIDs, err := GetActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id, tx)
 		if err != nil {
-			sysErr = fmt.Errorf("getting Delivery Services to which server #%d is assigned that have no other servers: %v", id, err)
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-			return
+			return nil, fmt.Errorf("getting Delivery Services to which server #%d is assigned that have no other servers: %v", id, err), http.StatusInternalServerError
 		}
 		if len(dsIDs) > 0 {
-			alertText := InvalidStatusForDeliveryServicesAlertText(*status.Name, dsIDs)
-			api.WriteAlerts(w, r, http.StatusConflict, tc.CreateAlerts(tc.ErrorLevel, alertText))
-			return
+			return errors.New(InvalidStatusForDeliveryServicesAlertText(*status.Name, dsIDs)), nil, http.StatusConflict
 		}
 	}
 
-	if userErr, sysErr, errCode = checkTypeChangeSafety(server.CommonServerProperties, inf.Tx); userErr != nil || sysErr != nil {
-		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-		return
+	if userErr, sysErr, errCode := checkTypeChangeSafety(server.CommonServerProperties, inf.Tx); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
 	}
 
-	if server.XMPPID != nil && *server.XMPPID != originalXMPPID {
-		api.WriteAlerts(w, r, http.StatusBadRequest, tc.CreateAlerts(tc.ErrorLevel, fmt.Sprintf("server cannot be updated due to requested XMPPID change. XMPIDD is immutable")))
-		return
+	if server.XMPPID != nil && *server.XMPPID != *original.XMPPID {
+		return errors.New("server cannot be updated due to requested XMPPID change. XMPIDD is immutable"), nil, http.StatusBadRequest
 	}
 
-	userErr, sysErr, statusCode := api.CheckIfUnModified(r.Header, inf.Tx, *server.ID, "server")
+	userErr, sysErr, statusCode := api.CheckIfUnModified(h, inf.Tx, *server.ID, "server")
 	if userErr != nil || sysErr != nil {
-		api.HandleErr(w, r, tx, statusCode, userErr, sysErr)
-		return
+		return userErr, sysErr, statusCode
 	}
 
 	rows, err := inf.Tx.NamedQuery(updateQuery, server)
 	if err != nil {
-		userErr, sysErr, errCode = api.ParseDBError(err)
-		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-		return
+		return api.ParseDBError(err)
 	}
 	defer rows.Close()
 
 	rowsAffected := 0
 	for rows.Next() {
-		if err := rows.StructScan(&server); err != nil {
-			api.HandleErr(w, r, tx, http.StatusNotFound, nil, fmt.Errorf("scanning lastUpdated from server insert: %v", err))
-			return
+		if err := rows.StructScan(server); err != nil {
+			return nil, fmt.Errorf("scanning lastUpdated from server insert: %v", err), http.StatusNotFound
 		}
 		rowsAffected++
 	}
 
 	if rowsAffected < 1 {
-		api.HandleErr(w, r, tx, http.StatusNotFound, errors.New("no server found with this id"), nil)
-		return
+		return errors.New("no server found with this id"), nil, http.StatusNotFound
 	}
 	if rowsAffected > 1 {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("update for server #%d affected too many rows (%d)", *server.ID, rowsAffected))
-		return
+		return nil, fmt.Errorf("update for server #%d affected too many rows (%d)", *server.ID, rowsAffected), http.StatusInternalServerError
 	}
 
-	if userErr, sysErr, errCode = deleteInterfaces(id, tx); userErr != nil || sysErr != nil {
-		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-		return
+	if userErr, sysErr, errCode := deleteInterfaces(id, tx); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
 	}
 
-	if userErr, sysErr, errCode = createInterfaces(id, server.Interfaces, tx); userErr != nil || sysErr != nil {
-		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-		return
+	if userErr, sysErr, errCode := createInterfaces(id, server.Interfaces, tx); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
 	}
 
 	if inf.Version.Major >= 3 {
-		if userErr, sysErr, errCode = updateStatusLastUpdatedTime(id, &statusLastUpdatedTime, tx); userErr != nil || sysErr != nil {
-			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-			return
-		}
-		api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Server updated", server)
-	} else {
-		v2Server, err := server.ToServerV2FromV4()
-		if err != nil {
-			sysErr = fmt.Errorf("converting valid v3 server to a v2 structure: %v", err)
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-			return
-		}
-		if inf.Version.Major <= 1 {
-			api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Server updated", v2Server.ServerNullableV11)
-		} else {
-			api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Server updated", v2Server)
+		if userErr, sysErr, errCode := updateStatusLastUpdatedTime(id, &statusLastUpdatedTime, tx); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
 		}
 	}
 
-	changeLogMsg := fmt.Sprintf("SERVER: %s.%s, ID: %d, ACTION: updated", *server.HostName, *server.DomainName, *server.ID)
-	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	return nil, nil, http.StatusOK
+}
+
+// auditServerChange creates the change log entry of the creation or update of a server through the latest API version. The original of a created server is ignored.
+func auditServerChange(inf *api.APIInfo, action string, original tc.ServerV40, server tc.ServerV40) {
+	changeLogMsg := fmt.Sprintf("SERVER: %s.%s, ID: %d, ACTION: %s", *server.HostName, *server.DomainName, *server.ID, strings.ToLower(action))
+	audit := api.Audit{Action: action, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*server.ID), After: server}
+	if action != api.Created {
+		audit.Before = original
+	}
+	api.CreateAuditLogTx(api.ApiChange, changeLogMsg, audit, inf)
 }
 
 func createV1(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
@@ -1778,7 +1808,8 @@ func createV1(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 	api.WriteAlertsObj(w, r, http.StatusOK, alerts, server)
 
 	changeLogMsg := fmt.Sprintf("SERVER: %s.%s, ID: %d, ACTION: created", *server.HostName, *server.DomainName, *server.ID)
-	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	audit := api.Audit{Action: api.Created, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*server.ID), After: server}
+	api.CreateAuditLogTx(api.ApiChange, changeLogMsg, audit, inf)
 }
 
 func createV2(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
@@ -1849,7 +1880,8 @@ func createV2(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 	api.WriteAlertsObj(w, r, http.StatusOK, alerts, server)
 
 	changeLogMsg := fmt.Sprintf("SERVER: %s.%s, ID: %d, ACTION: created", *server.HostName, *server.DomainName, *server.ID)
-	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	audit := api.Audit{Action: api.Created, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*server.ID), After: server}
+	api.CreateAuditLogTx(api.ApiChange, changeLogMsg, audit, inf)
 }
 
 func createV3(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
@@ -1924,7 +1956,8 @@ func createV3(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, server)
 
 	changeLogMsg := fmt.Sprintf("SERVER: %s.%s, ID: %d, ACTION: created", *server.HostName, *server.DomainName, *server.ID)
-	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	audit := api.Audit{Action: api.Created, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*server.ID), After: server}
+	api.CreateAuditLogTx(api.ApiChange, changeLogMsg, audit, inf)
 }
 
 func createV4(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
@@ -1936,25 +1969,43 @@ func createV4(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
 		return
 	}
+	if userErr, sysErr, errCode := CreateServer(inf, &server); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Server created")
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, server)
+}
+
+// CreateServer creates the given server in the transaction of inf, exactly as a request to create it through the latest API version would. On success, the given server is updated with the values stored in the database, including its ID.
+func CreateServer(inf *api.APIInfo, server *tc.ServerV40) (error, error, int) {
+	if userErr, sysErr, errCode := createServer(inf, server); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+	auditServerChange(inf, api.Created, tc.ServerV40{}, *server)
+	return nil, nil, http.StatusOK
+}
+
+// createServer is CreateServer without the change log entry.
+func createServer(inf *api.APIInfo, server *tc.ServerV40) (error, error, int) {
+	tx := inf.Tx.Tx
 	if server.ID != nil {
 		var prevID int
 		err := tx.QueryRow("SELECT id from server where id = $1", server.ID).Scan(&prevID)
 		if err != nil && err != sql.ErrNoRows {
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking if server with id %d exists", *server.ID))
-			return
+			return nil, fmt.Errorf("checking if server with id %d exists", *server.ID), http.StatusInternalServerError
 		}
 		if prevID != 0 {
-			api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("server with id %d already exists. Please do not provide an id", *server.ID), nil)
-			return
+			return fmt.Errorf("server with id %d already exists. Please do not provide an id", *server.ID), nil, http.StatusBadRequest
 		}
 	}
 
 	str := uuid.New().String()
 	server.XMPPID = &str
-	_, err := validateV4(&server, tx)
+	_, err := validateV4(server, tx)
 	if err != nil {
-		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
-		return
+		return err, nil, http.StatusBadRequest
 	}
 
 	currentTime := time.Now()
@@ -1962,9 +2013,7 @@ func createV4(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 
 	resultRows, err := inf.Tx.NamedQuery(insertQueryV4, server)
 	if err != nil {
-		userErr, sysErr, errCode := api.ParseDBError(err)
-		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-		return
+		return api.ParseDBError(err)
 	}
 	defer resultRows.Close()
 
@@ -1972,29 +2021,16 @@ func createV4(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 	for resultRows.Next() {
 		rowsAffected++
 		if err := resultRows.StructScan(&server.CommonServerProperties); err != nil {
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("server create scanning: %v", err))
-			return
+			return nil, fmt.Errorf("server create scanning: %v", err), http.StatusInternalServerError
 		}
 	}
 	if rowsAffected == 0 {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("server create: no server was inserted, no id was returned"))
-		return
+		return nil, errors.New("server create: no server was inserted, no id was returned"), http.StatusInternalServerError
 	} else if rowsAffected > 1 {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("too many ids returned from server insert"))
-		return
-	}
-
-	userErr, sysErr, errCode := createInterfaces(*server.ID, server.Interfaces, tx)
-	if userErr != nil || sysErr != nil {
-		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-		return
+		return nil, errors.New("too many ids returned from server insert"), http.StatusInternalServerError
 	}
 
-	alerts := tc.CreateAlerts(tc.SuccessLevel, "Server created")
-	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, server)
-
-	changeLogMsg := fmt.Sprintf("SERVER: %s.%s, ID: %d, ACTION: created", *server.HostName, *server.DomainName, *server.ID)
-	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	return createInterfaces(*server.ID, server.Interfaces, tx)
 }
 
 // Create is the handler for POST requests to /servers.
@@ -2033,7 +2069,8 @@ GROUP BY "deliveryservice"
 HAVING COUNT("server") = 1;
 `
 
-func getActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id int, tx *sql.Tx) ([]int, error) {
+// GetActiveDeliveryServicesThatOnlyHaveThisServerAssigned returns the IDs of the active Delivery Services to which the server with the given ID is the only ONLINE or REPORTED server assigned.
+func GetActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id int, tx *sql.Tx) ([]int, error) {
 	var ids []int
 	if tx == nil {
 		return ids, errors.New("nil transaction")
@@ -2074,12 +2111,39 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id := inf.IntParams["id"]
-
-	if dsIDs, err := getActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id, tx); err != nil {
-		sysErr = fmt.Errorf("checking if server #%d is the last server assigned to any Delivery Services: %v", id, err)
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
+	server, userErr, sysErr, errCode := deleteServer(r.Header, inf, inf.IntParams["id"], *version)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
 		return
+	}
+
+	if inf.Version.Major >= 3 {
+		api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Server deleted", server)
+	} else {
+
+		serverV2, err := server.ToServerV2FromV4()
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+
+		if inf.Version.Major <= 1 {
+			api.WriteRespAlertObj(w, r, tc.SuccessLevel, "server was deleted.", serverV2.ServerNullableV11)
+		} else {
+			api.WriteRespAlertObj(w, r, tc.SuccessLevel, "server was deleted.", serverV2)
+		}
+	}
+}
+
+// DeleteServer deletes the server with the given ID in the transaction of inf, exactly as a request to delete it through the latest API version would, and returns the deleted server.
+func DeleteServer(inf *api.APIInfo, id int) (tc.ServerV40, error, error, int) {
+	return deleteServer(http.Header{}, inf, id, api.Version{Major: 4, Minor: 0})
+}
+
+func deleteServer(h http.Header, inf *api.APIInfo, id int, version api.Version) (tc.ServerV40, error, error, int) {
+	tx := inf.Tx.Tx
+	if dsIDs, err := GetActiveDeliveryServicesThatOnlyHaveThisServerAssigned(id, tx); err != nil {
+		return tc.ServerV40{}, nil, fmt.Errorf("checking if server #%d is the last server assigned to any Delivery Services: %v", id, err), http.StatusInternalServerError
 	} else if len(dsIDs) > 0 {
 		alertText := fmt.Sprintf("deleting server #%d would leave Active Delivery Service", id)
 		if len(dsIDs) == 1 {
@@ -2094,72 +2158,53 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 			alertText += fmt.Sprintf("s %s, and #%d", strings.Join(dsNums, ", "), dsIDs[len(dsIDs)-1])
 		}
 		alertText += fmt.Sprintf("  with no '%s' or '%s' servers", tc.CacheStatusOnline, tc.CacheStatusReported)
-
-		api.WriteAlerts(w, r, http.StatusConflict, tc.CreateAlerts(tc.ErrorLevel, alertText))
-		return
+		return tc.ServerV40{}, errors.New(alertText), nil, http.StatusConflict
 	}
 
-	var servers []tc.ServerV40
-	servers, _, userErr, sysErr, errCode, _ = getServers(r.Header, map[string]string{"id": inf.Params["id"]}, inf.Tx, inf.User, false, *version)
+	servers, _, userErr, sysErr, errCode, _ := getServers(h, map[string]string{"id": strconv.Itoa(id)}, inf.Tx, inf.User, false, version)
 	if userErr != nil || sysErr != nil {
-		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-		return
+		return tc.ServerV40{}, userErr, sysErr, errCode
 	}
 
 	if len(servers) < 1 {
-		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no server exists by id #%d", id), nil)
-		return
+		return tc.ServerV40{}, fmt.Errorf("no server exists by id #%d", id), nil, http.StatusNotFound
 	}
 	if len(servers) > 1 {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("there are somehow two servers with id %d - cannot delete", id))
-		return
+		return tc.ServerV40{}, nil, fmt.Errorf("there are somehow two servers with id %d - cannot delete", id), http.StatusInternalServerError
 	}
 	server := servers[0]
 	cacheGroupIds := []int{*server.CachegroupID}
 	serverIds := []int{*server.ID}
-	hasDSOnCDN, err := dbhelpers.CachegroupHasTopologyBasedDeliveryServicesOnCDN(inf.Tx.Tx, *server.CachegroupID, *server.CDNID)
+	hasDSOnCDN, err := dbhelpers.CachegroupHasTopologyBasedDeliveryServicesOnCDN(tx, *server.CachegroupID, *server.CDNID)
 	if err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-		return
+		return server, nil, err, http.StatusInternalServerError
 	}
 	CDNIDs := []int{}
 	if hasDSOnCDN {
 		CDNIDs = append(CDNIDs, *server.CDNID)
 	}
 	if err := topology_validation.CheckForEmptyCacheGroups(inf.Tx, cacheGroupIds, CDNIDs, true, serverIds); err != nil {
-		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("server is the last one in its cachegroup, which is used by a topology: "+err.Error()), nil)
-		return
+		return server, errors.New("server is the last one in its cachegroup, which is used by a topology: " + err.Error()), nil, http.StatusBadRequest
 	}
 
 	if result, err := tx.Exec(deleteServerQuery, id); err != nil {
 		log.Errorf("Raw error: %v", err)
 		userErr, sysErr, errCode = api.ParseDBError(err)
-		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-		return
+		return server, userErr, sysErr, errCode
 	} else if rowsAffected, err := result.RowsAffected(); err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting rows affected by server delete: %v", err))
-		return
+		return server, nil, fmt.Errorf("getting rows affected by server delete: %v", err), http.StatusInternalServerError
 	} else if rowsAffected != 1 {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("incorrect number of rows affected: %d", rowsAffected))
-		return
+		return server, nil, fmt.Errorf("incorrect number of rows affected: %d", rowsAffected), http.StatusInternalServerError
 	}
 
-	if inf.Version.Major >= 3 {
-		api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Server deleted", server)
-	} else {
-
-		serverV2, err := server.ToServerV2FromV4()
-		if err != nil {
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
-			return
-		}
-
-		if inf.Version.Major <= 1 {
-			api.WriteRespAlertObj(w, r, tc.SuccessLevel, "server was deleted.", serverV2.ServerNullableV11)
-		} else {
-			api.WriteRespAlertObj(w, r, tc.SuccessLevel, "server was deleted.", serverV2)
-		}
-	}
 	changeLogMsg := fmt.Sprintf("SERVER: %s.%s, ID: %d, ACTION: deleted", *server.HostName, *server.DomainName, *server.ID)
-	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	audit := api.Audit{Action: api.Deleted, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*server.ID), Before: server}
+	api.CreateAuditLogTx(api.ApiChange, changeLogMsg, audit, inf)
+	return server, nil, nil, http.StatusOK
+}
+
+// GetCDNServers returns the servers of the CDN with the given ID.
+func GetCDNServers(inf *api.APIInfo, cdnID int) ([]tc.ServerV40, error, error, int) {
+	servers, _, userErr, sysErr, errCode, _ := getServers(http.Header{}, map[string]string{"cdn": strconv.Itoa(cdnID)}, inf.Tx, inf.User, false, api.Version{Major: 4, Minor: 0})
+	return servers, userErr, sysErr, errCode
 }
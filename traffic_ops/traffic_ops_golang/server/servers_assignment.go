@@ -123,42 +123,47 @@ func AssignDeliveryServicesToServerHandler(w http.ResponseWriter, r *http.Reques
 	}
 
 	server := inf.IntParams["id"]
+	serverInfo, assignedDSes, userErr, sysErr, errCode := assignDeliveryServices(tx, inf.User, server, dsList, replace)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "SERVER: "+serverInfo.HostName+", ID: "+strconv.Itoa(server)+", ACTION: Assigned "+strconv.Itoa(len(assignedDSes))+" DSes to server", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "successfully assigned dses to server", tc.AssignedDsResponse{server, assignedDSes, replace})
+}
 
+// assignDeliveryServices assigns the Delivery Services with the given IDs to
+// the server with the given ID, on behalf of the given user, replacing those
+// already assigned to it if replace is true. It returns the server's
+// information and the IDs of the Delivery Services assigned to it.
+func assignDeliveryServices(tx *sql.Tx, user *auth.CurrentUser, server int, dsList []int, replace bool) (tc.ServerInfo, []int, error, error, int) {
 	serverInfo, ok, err := dbhelpers.GetServerInfo(server, tx)
 	if err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting server name from ID: "+err.Error()))
-		return
+		return serverInfo, nil, nil, errors.New("getting server name from ID: " + err.Error()), http.StatusInternalServerError
 	} else if !ok {
-		api.HandleErr(w, r, tx, http.StatusNotFound, errors.New("no server with that ID found"), nil)
-		return
+		return serverInfo, nil, errors.New("no server with that ID found"), nil, http.StatusNotFound
 	}
 
 	if !strings.HasPrefix(serverInfo.Type, tc.OriginTypeName) {
-		usrErr, sysErr, status := ValidateDSCapabilities(dsList, serverInfo.HostName, tx)
-		if usrErr != nil || sysErr != nil {
-			api.HandleErr(w, r, tx, status, usrErr, sysErr)
-			return
+		if userErr, sysErr, status := ValidateDSCapabilities(dsList, serverInfo.HostName, tx); userErr != nil || sysErr != nil {
+			return serverInfo, nil, userErr, sysErr, status
 		}
 	}
 
 	// We already know the CDN exists because that's part of the serverInfo query above
 	serverCDN, _, err := dbhelpers.GetCDNNameFromID(tx, int64(serverInfo.CDNID))
 	if err != nil {
-		sysErr = fmt.Errorf("Failed to get CDN name from ID: %v", err)
-		errCode = http.StatusInternalServerError
-		api.HandleErr(w, r, tx, errCode, nil, sysErr)
-		return
+		return serverInfo, nil, nil, fmt.Errorf("Failed to get CDN name from ID: %v", err), http.StatusInternalServerError
 	}
 
 	if len(dsList) > 0 {
-		if errCode, userErr, sysErr = checkTenancyAndCDN(tx, string(serverCDN), server, serverInfo, dsList, inf.User); userErr != nil || sysErr != nil {
-			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
-			return
+		if errCode, userErr, sysErr := checkTenancyAndCDN(tx, string(serverCDN), server, serverInfo, dsList, user); userErr != nil || sysErr != nil {
+			return serverInfo, nil, userErr, sysErr, errCode
 		}
 		if strings.HasPrefix(serverInfo.Type, tc.OriginTypeName) {
 			if userErr, sysErr, status := checkOriginInTopologies(tx, serverInfo.Cachegroup, dsList); userErr != nil || sysErr != nil {
-				api.HandleErr(w, r, tx, status, userErr, sysErr)
-				return
+				return serverInfo, nil, userErr, sysErr, status
 			}
 		}
 	}
@@ -166,9 +171,7 @@ func AssignDeliveryServicesToServerHandler(w http.ResponseWriter, r *http.Reques
 	if replace && (serverInfo.Status == tc.CacheStatusOnline.String() || serverInfo.Status == tc.CacheStatusReported.String()) {
 		currentDSIDs, err := checkForLastServerInActiveDeliveryServices(server, dsList, tx)
 		if err != nil {
-			sysErr = fmt.Errorf("checking for deliveryservices to which server #%d is the last assigned: %v", server, err)
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-			return
+			return serverInfo, nil, nil, fmt.Errorf("checking for deliveryservices to which server #%d is the last assigned: %v", server, err), http.StatusInternalServerError
 		}
 		if len(currentDSIDs) > 0 {
 			alertText := "Delivery Service assignment would leave Active Delivery Service"
@@ -184,22 +187,15 @@ func AssignDeliveryServicesToServerHandler(w http.ResponseWriter, r *http.Reques
 				alertText += fmt.Sprintf("s %s, and #%d", strings.Join(dsNums, ", "), currentDSIDs[len(currentDSIDs)-1])
 			}
 			alertText += fmt.Sprintf("  with no '%s' or '%s' servers", tc.CacheStatusOnline, tc.CacheStatusReported)
-			api.WriteAlerts(w, r, http.StatusConflict, tc.CreateAlerts(tc.ErrorLevel, alertText))
-			return
+			return serverInfo, nil, errors.New(alertText), nil, http.StatusConflict
 		}
 	}
 
 	assignedDSes, err := assignDeliveryServicesToServer(server, dsList, replace, tx)
 	if err != nil {
-		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting server name from ID: "+err.Error()))
-		return
-		// TODO: Where does this 'ok' come from?
-	} else if !ok {
-		api.HandleErr(w, r, tx, http.StatusNotFound, errors.New("no server with that ID found"), nil)
+		return serverInfo, nil, nil, errors.New("assigning delivery services to server: " + err.Error()), http.StatusInternalServerError
 	}
-
-	api.CreateChangeLogRawTx(api.ApiChange, "SERVER: "+serverInfo.HostName+", ID: "+strconv.Itoa(server)+", ACTION: Assigned "+strconv.Itoa(len(assignedDSes))+" DSes to server", inf.User, tx)
-	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "successfully assigned dses to server", tc.AssignedDsResponse{server, assignedDSes, replace})
+	return serverInfo, assignedDSes, nil, nil, http.StatusOK
 }
 
 // checkOriginInTopologies checks to make sure the given ORG server's cachegroup belongs
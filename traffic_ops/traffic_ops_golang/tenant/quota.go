@@ -0,0 +1,331 @@
+package tenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// quota.go defines functions to enforce and report the resource quotas of tenants.
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// subtreeCTE selects the IDs of the tenant with the ID $1 and all of its descendants.
+const subtreeCTE = `
+WITH RECURSIVE subtree AS (
+  SELECT id FROM tenant WHERE id = $1
+  UNION
+  SELECT t.id FROM tenant t JOIN subtree s ON t.parent_id = s.id
+)
+`
+
+// usageQueries select the usage of each limited resource by the tenant with the ID $1 and its descendants, both
+// including and excluding the delivery service with the ID $2, which may be null.
+var usageQueries = map[string]string{
+	tc.TenantQuotaDeliveryServices: subtreeCTE + `
+SELECT COUNT(*), COUNT(*) FILTER (WHERE ds.id IS DISTINCT FROM $2)
+FROM deliveryservice ds
+WHERE ds.tenant_id IN (SELECT id FROM subtree)
+`,
+	tc.TenantQuotaJobsPerDay: subtreeCTE + `
+SELECT COUNT(*), COUNT(*) FILTER (WHERE ds.id IS DISTINCT FROM $2)
+FROM job j
+JOIN deliveryservice ds ON ds.id = j.job_deliveryservice
+WHERE ds.tenant_id IN (SELECT id FROM subtree)
+AND j.entered_time > now() - interval '1 day'
+`,
+	tc.TenantQuotaRegexesPerDeliveryService: subtreeCTE + `
+SELECT COALESCE(MAX(c.count), 0), COALESCE(MAX(c.count) FILTER (WHERE c.deliveryservice IS DISTINCT FROM $2), 0)
+FROM (
+  SELECT dsr.deliveryservice, COUNT(*) AS count
+  FROM deliveryservice_regex dsr
+  JOIN deliveryservice ds ON ds.id = dsr.deliveryservice
+  WHERE ds.tenant_id IN (SELECT id FROM subtree)
+  GROUP BY dsr.deliveryservice
+) c
+`,
+	// Delivery services without a globalMaxMbps aren't limited, so can't be created or updated under a bandwidth
+	// quota; those which predate the quota use none of it until they're next updated, when they must be given one.
+	tc.TenantQuotaBandwidthMbps: subtreeCTE + `
+SELECT COALESCE(SUM(ds.global_max_mbps), 0), COALESCE(SUM(ds.global_max_mbps) FILTER (WHERE ds.id IS DISTINCT FROM $2), 0)
+FROM deliveryservice ds
+WHERE ds.tenant_id IN (SELECT id FROM subtree)
+`,
+}
+
+// ancestorQuotasQuery selects the quotas of the tenant with the ID $1 and its ancestors, nearest first.
+const ancestorQuotasQuery = `
+WITH RECURSIVE ancestors AS (
+  SELECT id, name, parent_id, 0 AS depth FROM tenant WHERE id = $1
+  UNION
+  SELECT t.id, t.name, t.parent_id, a.depth + 1 FROM tenant t JOIN ancestors a ON t.id = a.parent_id
+)
+SELECT a.name, q.tenant_id, q.max_delivery_services, q.max_jobs_per_day, q.max_regexes_per_ds, q.max_bandwidth_mbps, q.last_updated
+FROM ancestors a
+JOIN tenant_quota q ON q.tenant_id = a.id
+ORDER BY a.depth
+`
+
+// lockAncestorQuotasQuery is ancestorQuotasQuery, locking the quotas until the end of the transaction, so that
+// concurrent changes limited by the same quota are checked one after another, each seeing the usage of the last.
+const lockAncestorQuotasQuery = ancestorQuotasQuery + `FOR UPDATE OF q
+`
+
+// namedQuota is a tenant's quota along with the tenant's name.
+type namedQuota struct {
+	tenantName string
+	tc.TenantQuota
+}
+
+// quotaLimit is the limit a tenant's quota imposes on the usage of a resource by the tenant and its descendants.
+type quotaLimit struct {
+	tenantName string
+	limit      int64
+	// usage is the current usage of the resource.
+	usage int64
+	// usageExcluding is the current usage of the resource, excluding the delivery service being changed, if any.
+	usageExcluding int64
+}
+
+// exceededBy returns whether adding the given amount to the usage excluding the delivery service being changed
+// would exceed the limit. Changes which don't increase the usage never exceed it, so lowering a quota below the
+// current usage doesn't prevent changes which don't add to it.
+func (l quotaLimit) exceededBy(amount int64) bool {
+	after := l.usageExcluding + amount
+	return after > l.limit && after > l.usage
+}
+
+// getAncestorQuotas returns the quotas of the tenant with the given ID and its ancestors, nearest first. If lock is
+// true, the quotas are locked until the end of the transaction, as they must be when checking a change against them.
+func getAncestorQuotas(tx *sql.Tx, tenantID int, lock bool) ([]namedQuota, error) {
+	qry := ancestorQuotasQuery
+	if lock {
+		qry = lockAncestorQuotasQuery
+	}
+	rows, err := tx.Query(qry, tenantID)
+	if err != nil {
+		return nil, errors.New("querying tenant quotas: " + err.Error())
+	}
+	defer rows.Close()
+
+	quotas := []namedQuota{}
+	for rows.Next() {
+		q := namedQuota{}
+		if err := rows.Scan(&q.tenantName, &q.TenantID, &q.MaxDeliveryServices, &q.MaxJobsPerDay, &q.MaxRegexesPerDeliveryService, &q.MaxBandwidthMbps, &q.LastUpdated); err != nil {
+			return nil, errors.New("scanning tenant quotas: " + err.Error())
+		}
+		quotas = append(quotas, q)
+	}
+	return quotas, nil
+}
+
+// getUsage returns the usage of the given resource by the tenant with the given ID and its descendants, both
+// including and excluding the delivery service with the given ID, if it isn't nil.
+func getUsage(tx *sql.Tx, tenantID int, quota string, dsID *int) (int64, int64, error) {
+	usage, usageExcluding := int64(0), int64(0)
+	if err := tx.QueryRow(usageQueries[quota], tenantID, dsID).Scan(&usage, &usageExcluding); err != nil {
+		return 0, 0, fmt.Errorf("querying tenant %d usage of %s: %v", tenantID, quota, err)
+	}
+	return usage, usageExcluding, nil
+}
+
+// getLimits returns the limits the quotas of the tenant with the given ID and its ancestors impose on the given
+// resource, along with the usage of the delivery service with the given ID, if it isn't nil, excluded. The quotas are
+// locked until the end of the transaction.
+func getLimits(tx *sql.Tx, tenantID int, quota string, dsID *int) ([]quotaLimit, error) {
+	quotas, err := getAncestorQuotas(tx, tenantID, true)
+	if err != nil {
+		return nil, err
+	}
+	limits := []quotaLimit{}
+	for _, q := range quotas {
+		limit := q.Limit(quota)
+		if limit == nil {
+			continue
+		}
+		usage, usageExcluding, err := getUsage(tx, *q.TenantID, quota, dsID)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, quotaLimit{tenantName: q.tenantName, limit: *limit, usage: usage, usageExcluding: usageExcluding})
+	}
+	return limits, nil
+}
+
+// checkLimits returns a user error if adding the given amount of the given resource would exceed any of the given limits.
+func checkLimits(limits []quotaLimit, quota string, amount int64) error {
+	for _, l := range limits {
+		if l.exceededBy(amount) {
+			return fmt.Errorf("tenant '%s' quota exceeded: %s is %d, and %d is in use", l.tenantName, quota, l.limit, l.usage)
+		}
+	}
+	return nil
+}
+
+// CheckDeliveryServiceQuotas checks that creating a delivery service of the tenant with the given ID, or updating
+// the delivery service with the given ID to belong to it, with the given globalMaxMbps, wouldn't exceed the quotas of
+// the tenant or its ancestors. The dsID is nil for creations. The quotas stay locked until the end of the transaction,
+// so the delivery service must be created or updated in it.
+//
+// A delivery service without a globalMaxMbps isn't limited, so it can't be given to a tenant with a bandwidth quota.
+// If an update omits it, the delivery service's stored globalMaxMbps is checked instead, and must be kept.
+// Returns the globalMaxMbps the delivery service must be stored with, a user error, system error, and the HTTP status
+// code to be returned to the user if an error occurred.
+func CheckDeliveryServiceQuotas(tx *sql.Tx, tenantID int, dsID *int, globalMaxMBPS *int) (*int, error, error, int) {
+	limits, err := getLimits(tx, tenantID, tc.TenantQuotaDeliveryServices, dsID)
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError
+	}
+	if err := checkLimits(limits, tc.TenantQuotaDeliveryServices, 1); err != nil {
+		return nil, err, nil, http.StatusForbidden
+	}
+
+	limits, err = getLimits(tx, tenantID, tc.TenantQuotaBandwidthMbps, dsID)
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError
+	}
+	if len(limits) == 0 {
+		return globalMaxMBPS, nil, nil, http.StatusOK
+	}
+	if globalMaxMBPS == nil && dsID != nil {
+		if err := tx.QueryRow(`SELECT global_max_mbps FROM deliveryservice WHERE id = $1`, *dsID).Scan(&globalMaxMBPS); err != nil && err != sql.ErrNoRows {
+			return nil, nil, errors.New("querying delivery service globalMaxMbps: " + err.Error()), http.StatusInternalServerError
+		}
+	}
+	if globalMaxMBPS == nil {
+		return nil, fmt.Errorf("globalMaxMbps is required, because tenant '%s' has a %s quota", limits[0].tenantName, tc.TenantQuotaBandwidthMbps), nil, http.StatusBadRequest
+	}
+	if err := checkLimits(limits, tc.TenantQuotaBandwidthMbps, int64(*globalMaxMBPS)); err != nil {
+		return nil, err, nil, http.StatusForbidden
+	}
+	return globalMaxMBPS, nil, nil, http.StatusOK
+}
+
+// CheckJobQuota checks that creating a content invalidation job for the delivery service with the given ID wouldn't
+// exceed the quotas of its tenant or the tenant's ancestors.
+// Returns a user error, system error, and the HTTP status code to be returned to the user if an error occurred.
+func CheckJobQuota(tx *sql.Tx, dsID int) (error, error, int) {
+	tenantID, ok, err := GetDSTenantIDByIDTx(tx, dsID)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if !ok || tenantID == nil {
+		return nil, nil, http.StatusOK
+	}
+	limits, err := getLimits(tx, *tenantID, tc.TenantQuotaJobsPerDay, nil)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if err := checkLimits(limits, tc.TenantQuotaJobsPerDay, 1); err != nil {
+		return err, nil, http.StatusForbidden
+	}
+	return nil, nil, http.StatusOK
+}
+
+// CheckRegexQuota checks that adding a regular expression to the delivery service with the given ID wouldn't exceed
+// the quotas of its tenant or the tenant's ancestors. Unlike other quotas, the least limit of any of them applies to
+// each delivery service separately.
+// Returns a user error, system error, and the HTTP status code to be returned to the user if an error occurred.
+func CheckRegexQuota(tx *sql.Tx, dsID int) (error, error, int) {
+	tenantID, ok, err := GetDSTenantIDByIDTx(tx, dsID)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if !ok || tenantID == nil {
+		return nil, nil, http.StatusOK
+	}
+	quotas, err := getAncestorQuotas(tx, *tenantID, true)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	limit := (*quotaLimit)(nil)
+	for _, q := range quotas {
+		if q.MaxRegexesPerDeliveryService != nil && (limit == nil || *q.MaxRegexesPerDeliveryService < limit.limit) {
+			limit = &quotaLimit{tenantName: q.tenantName, limit: *q.MaxRegexesPerDeliveryService}
+		}
+	}
+	if limit == nil {
+		return nil, nil, http.StatusOK
+	}
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM deliveryservice_regex WHERE deliveryservice = $1`, dsID).Scan(&limit.usage); err != nil {
+		return nil, errors.New("querying delivery service regex count: " + err.Error()), http.StatusInternalServerError
+	}
+	limit.usageExcluding = limit.usage
+	if err := checkLimits([]quotaLimit{*limit}, tc.TenantQuotaRegexesPerDeliveryService, 1); err != nil {
+		return err, nil, http.StatusForbidden
+	}
+	return nil, nil, http.StatusOK
+}
+
+// GetQuotaReport returns the quota of the tenant with the given ID, along with its usage of each limited resource, and
+// how much more of each it may use before reaching its own quota or that of one of its ancestors.
+// Returns false if no such tenant exists.
+func GetQuotaReport(tx *sql.Tx, tenantID int) (tc.TenantQuotaReport, bool, error) {
+	report := tc.TenantQuotaReport{TenantID: tenantID}
+	if err := tx.QueryRow(`SELECT name FROM tenant WHERE id = $1`, tenantID).Scan(&report.TenantName); err != nil {
+		if err == sql.ErrNoRows {
+			return report, false, nil
+		}
+		return report, false, errors.New("querying tenant name: " + err.Error())
+	}
+	quotas, err := getAncestorQuotas(tx, tenantID, false)
+	if err != nil {
+		return report, false, err
+	}
+	report.Quota = tc.TenantQuota{TenantID: &tenantID}
+	if len(quotas) > 0 && *quotas[0].TenantID == tenantID {
+		report.Quota = quotas[0].TenantQuota
+	}
+
+	for _, quota := range tc.TenantQuotas {
+		usage, _, err := getUsage(tx, tenantID, quota, nil)
+		if err != nil {
+			return report, false, err
+		}
+		u := tc.TenantQuotaUsage{Quota: quota, Usage: usage}
+		for _, q := range quotas {
+			limit := q.Limit(quota)
+			if limit == nil {
+				continue
+			}
+			// regexes are limited per delivery service, the rest by the usage of the whole subtree of the limiting tenant
+			limitUsage := usage
+			if quota != tc.TenantQuotaRegexesPerDeliveryService && *q.TenantID != tenantID {
+				if limitUsage, _, err = getUsage(tx, *q.TenantID, quota, nil); err != nil {
+					return report, false, err
+				}
+			}
+			available := *limit - limitUsage
+			if available < 0 {
+				available = 0
+			}
+			if u.Available == nil || available < *u.Available {
+				name := q.tenantName
+				u.Available = &available
+				u.LimitedBy = &name
+			}
+		}
+		report.Usage = append(report.Usage, u)
+	}
+	return report, true, nil
+}
@@ -0,0 +1,135 @@
+package tenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestQuotaLimitExceededBy(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    quotaLimit
+		amount   int64
+		expected bool
+	}{
+		{"under the limit", quotaLimit{limit: 10, usage: 5, usageExcluding: 5}, 1, false},
+		{"reaching the limit", quotaLimit{limit: 10, usage: 9, usageExcluding: 9}, 1, false},
+		{"over the limit", quotaLimit{limit: 10, usage: 10, usageExcluding: 10}, 1, true},
+		{"replacing a changed delivery service", quotaLimit{limit: 100, usage: 80, usageExcluding: 50}, 60, true},
+		{"already over a lowered limit without increasing", quotaLimit{limit: 5, usage: 10, usageExcluding: 9}, 1, false},
+		{"already over a lowered limit and increasing", quotaLimit{limit: 5, usage: 10, usageExcluding: 10}, 1, true},
+	}
+	for _, test := range tests {
+		if actual := test.limit.exceededBy(test.amount); actual != test.expected {
+			t.Errorf("%s: expected exceeded %v, actual: %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestCheckRegexQuota(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	quotaCols := []string{"name", "tenant_id", "max_delivery_services", "max_jobs_per_day", "max_regexes_per_ds", "max_bandwidth_mbps", "last_updated"}
+	expectCheck := func(regexes int) {
+		mock.ExpectQuery("SELECT tenant_id FROM deliveryservice").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"tenant_id"}).AddRow(3))
+		mock.ExpectQuery("WITH RECURSIVE ancestors").WithArgs(3).WillReturnRows(sqlmock.NewRows(quotaCols).
+			AddRow("child", 3, nil, nil, 5, nil, time.Now()).
+			AddRow("root", 1, 100, nil, 3, nil, time.Now()))
+		mock.ExpectQuery("SELECT COUNT").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(regexes))
+	}
+
+	mock.ExpectBegin()
+	expectCheck(2)
+	expectCheck(3)
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatalf("beginning transaction: %v", err)
+	}
+
+	if userErr, sysErr, _ := CheckRegexQuota(tx, 7); userErr != nil || sysErr != nil {
+		t.Errorf("expected a third regex to be within the least quota of 3, actual: %v %v", userErr, sysErr)
+	}
+	userErr, sysErr, errCode := CheckRegexQuota(tx, 7)
+	if sysErr != nil {
+		t.Fatalf("unexpected system error: %v", sysErr)
+	}
+	if userErr == nil || errCode != http.StatusForbidden {
+		t.Errorf("expected a fourth regex to exceed the root tenant's quota of 3 with a 403, actual: %v %d", userErr, errCode)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestCheckDeliveryServiceQuotasBandwidth(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	quotaCols := []string{"name", "tenant_id", "max_delivery_services", "max_jobs_per_day", "max_regexes_per_ds", "max_bandwidth_mbps", "last_updated"}
+	// ds 7 uses 30 of the child tenant's 80 Mbps, of a quota of 100
+	expectLimits := func() {
+		mock.ExpectQuery("WITH RECURSIVE ancestors.*FOR UPDATE OF q").WithArgs(3).WillReturnRows(sqlmock.NewRows(quotaCols).AddRow("child", 3, nil, nil, nil, 100, time.Now()))
+		mock.ExpectQuery("WITH RECURSIVE ancestors.*FOR UPDATE OF q").WithArgs(3).WillReturnRows(sqlmock.NewRows(quotaCols).AddRow("child", 3, nil, nil, nil, 100, time.Now()))
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(ds.global_max_mbps\\)").WithArgs(3, 7).WillReturnRows(sqlmock.NewRows([]string{"usage", "usage_excluding"}).AddRow(80, 50))
+	}
+	dsID := 7
+
+	mock.ExpectBegin()
+	expectLimits()
+	mock.ExpectQuery("SELECT global_max_mbps FROM deliveryservice").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"global_max_mbps"}).AddRow(30))
+	expectLimits()
+	mock.ExpectQuery("SELECT global_max_mbps FROM deliveryservice").WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"global_max_mbps"}).AddRow(nil))
+	expectLimits()
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatalf("beginning transaction: %v", err)
+	}
+
+	stored, userErr, sysErr, _ := CheckDeliveryServiceQuotas(tx, 3, &dsID, nil)
+	if userErr != nil || sysErr != nil {
+		t.Errorf("expected an update omitting globalMaxMbps to be checked with the stored 30, actual: %v %v", userErr, sysErr)
+	} else if stored == nil || *stored != 30 {
+		t.Errorf("expected an update omitting globalMaxMbps to keep the stored 30, actual: %v", stored)
+	}
+
+	if _, userErr, sysErr, errCode := CheckDeliveryServiceQuotas(tx, 3, &dsID, nil); sysErr != nil || userErr == nil || errCode != http.StatusBadRequest {
+		t.Errorf("expected a 400 updating a delivery service without a globalMaxMbps under a bandwidth quota, actual: %v %v %d", userErr, sysErr, errCode)
+	}
+
+	bandwidth := 60
+	if _, userErr, sysErr, errCode := CheckDeliveryServiceQuotas(tx, 3, &dsID, &bandwidth); sysErr != nil || userErr == nil || errCode != http.StatusForbidden {
+		t.Errorf("expected a 403 raising the delivery service's globalMaxMbps to 60, over the quota, actual: %v %v %d", userErr, sysErr, errCode)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
@@ -0,0 +1,479 @@
+package topology
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cachegroup"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// simulatedDSXMLID is the XMLID given to a candidate Delivery Service that
+// doesn't have one.
+const simulatedDSXMLID = "topology-simulation"
+
+// Simulate is the handler for POST requests to topologies/simulate. It
+// resolves the servers that would serve a candidate Delivery Service in its
+// Topology, and the parent.config lines they would get for it, without
+// changing anything.
+func Simulate(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	req := tc.TopologySimulationRequest{}
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	ds := req.DeliveryService
+
+	if ds.TenantID != nil {
+		if authorized, err := tenant.IsResourceAuthorizedToUserTx(*ds.TenantID, inf.User, tx); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking tenant: "+err.Error()))
+			return
+		} else if !authorized {
+			api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("not authorized on this tenant"), nil)
+			return
+		}
+	}
+
+	cdn, userErr, sysErr, errCode := getSimulationCDN(tx, ds)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	topology, ok, err := getTopology(inf.Tx, *ds.Topology)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting topology: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("no such topology: %s", *ds.Topology), nil)
+		return
+	}
+
+	sim, userErr, sysErr, errCode := simulate(inf.Tx, topology, cdn, ds, req.RequiredCapabilities)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, sim)
+}
+
+// getSimulationCDN returns the CDN of the candidate Delivery Service, which
+// may be identified by ID or by Name.
+func getSimulationCDN(tx *sql.Tx, ds tc.DeliveryServiceV4) (tc.CDN, error, error, int) {
+	cdn := tc.CDN{}
+	if ds.CDNID != nil {
+		name, ok, err := dbhelpers.GetCDNNameFromID(tx, int64(*ds.CDNID))
+		if err != nil {
+			return cdn, nil, errors.New("getting CDN name: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return cdn, fmt.Errorf("no such CDN: %d", *ds.CDNID), nil, http.StatusBadRequest
+		}
+		cdn.ID = *ds.CDNID
+		cdn.Name = string(name)
+	} else {
+		id, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(*ds.CDNName))
+		if err != nil {
+			return cdn, nil, errors.New("getting CDN ID: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return cdn, fmt.Errorf("no such CDN: %s", *ds.CDNName), nil, http.StatusBadRequest
+		}
+		cdn.ID = id
+		cdn.Name = *ds.CDNName
+	}
+
+	domain, _, err := dbhelpers.GetCDNDomainFromName(tx, tc.CDNName(cdn.Name))
+	if err != nil {
+		return cdn, nil, errors.New("getting CDN domain: " + err.Error()), http.StatusInternalServerError
+	}
+	cdn.DomainName = domain
+	return cdn, nil, nil, http.StatusOK
+}
+
+// getTopology returns the Topology with the given name, and whether it exists.
+func getTopology(tx *sqlx.Tx, name string) (tc.Topology, bool, error) {
+	reader := TOTopology{APIInfoImpl: api.APIInfoImpl{ReqInfo: &api.APIInfo{
+		Tx:     tx,
+		Params: map[string]string{"name": name},
+	}}}
+	topologies, _, sysErr, _, _ := reader.Read(nil, false)
+	if sysErr != nil {
+		return tc.Topology{}, false, sysErr
+	}
+	if len(topologies) == 0 {
+		return tc.Topology{}, false, nil
+	}
+	return topologies[0].(tc.Topology), true, nil
+}
+
+// simulate places the candidate Delivery Service in the given Topology.
+func simulate(tx *sqlx.Tx, topology tc.Topology, cdn tc.CDN, ds tc.DeliveryServiceV4, requiredCapabilities []string) (tc.TopologySimulation, error, error, int) {
+	if requiredCapabilities == nil {
+		requiredCapabilities = []string{}
+	}
+	sim := tc.TopologySimulation{
+		Topology:             topology.Name,
+		CDNName:              cdn.Name,
+		RequiredCapabilities: requiredCapabilities,
+		CacheGroups:          []tc.TopologySimulationCacheGroup{},
+		Warnings:             []string{},
+	}
+
+	cacheGroupNames := make([]string, len(topology.Nodes))
+	for index, node := range topology.Nodes {
+		cacheGroupNames[index] = node.Cachegroup
+	}
+	cacheGroupMap, userErr, sysErr, errCode := cachegroup.GetCacheGroupsByName(cacheGroupNames, tx)
+	if userErr != nil || sysErr != nil {
+		return sim, userErr, sysErr, errCode
+	}
+	cacheGroups := make([]tc.CacheGroupNullable, len(topology.Nodes))
+	for index, node := range topology.Nodes {
+		cg, ok := cacheGroupMap[node.Cachegroup]
+		if !ok {
+			return sim, nil, fmt.Errorf("topology %s references nonexistent cachegroup %s", topology.Name, node.Cachegroup), http.StatusInternalServerError
+		}
+		cacheGroups[index] = cg
+	}
+
+	// ATS config generation also needs the Cache Groups' non-Topology parents.
+	parentNames := []string{}
+	for _, cg := range cacheGroups {
+		for _, parent := range []*string{cg.ParentName, cg.SecondaryParentName} {
+			if parent == nil || *parent == "" {
+				continue
+			}
+			if _, ok := cacheGroupMap[*parent]; !ok && !util.ContainsStr(parentNames, *parent) {
+				parentNames = append(parentNames, *parent)
+			}
+		}
+	}
+	if len(parentNames) > 0 {
+		parents, userErr, sysErr, errCode := cachegroup.GetCacheGroupsByName(parentNames, tx)
+		if userErr != nil || sysErr != nil {
+			return sim, userErr, sysErr, errCode
+		}
+		for name, cg := range parents {
+			cacheGroupMap[name] = cg
+		}
+	}
+	allCacheGroups := make([]tc.CacheGroupNullable, 0, len(cacheGroupMap))
+	for _, cg := range cacheGroupMap {
+		allCacheGroups = append(allCacheGroups, cg)
+	}
+
+	sim.Warnings = append(sim.Warnings, checkSimulatedTopology(topology, cacheGroups)...)
+
+	eligibleServers, err := deliveryservice.GetEligibleServers(tx.Tx, cdn.ID, requiredCapabilities, []string{tc.EdgeTypePrefix, tc.MidTypePrefix})
+	if err != nil {
+		return sim, nil, errors.New("getting eligible servers: " + err.Error()), http.StatusInternalServerError
+	}
+	sort.Slice(eligibleServers, func(i, j int) bool {
+		return *eligibleServers[i].HostName < *eligibleServers[j].HostName
+	})
+
+	servers := make([]atscfg.Server, 0, len(eligibleServers))
+	serverCapabilities := map[int]map[atscfg.ServerCapability]struct{}{}
+	cacheGroupServers := map[string][]atscfg.Server{}
+	for _, eligible := range eligibleServers {
+		sv := simulationServer(eligible, cdn)
+		servers = append(servers, sv)
+		cacheGroupServers[*sv.Cachegroup] = append(cacheGroupServers[*sv.Cachegroup], sv)
+		serverCapabilities[*sv.ID] = map[atscfg.ServerCapability]struct{}{}
+		for _, capability := range eligible.ServerCapabilities {
+			serverCapabilities[*sv.ID][atscfg.ServerCapability(capability)] = struct{}{}
+		}
+	}
+
+	candidate := simulationDeliveryService(ds, cdn)
+	dsRequiredCapabilities := map[int]map[atscfg.ServerCapability]struct{}{*candidate.ID: {}}
+	for _, capability := range requiredCapabilities {
+		dsRequiredCapabilities[*candidate.ID][atscfg.ServerCapability(capability)] = struct{}{}
+	}
+
+	params, err := getSimulationParams(tx.Tx)
+	if err != nil {
+		return sim, nil, errors.New("getting parent.config parameters: " + err.Error()), http.StatusInternalServerError
+	}
+
+	for index, node := range topology.Nodes {
+		cg := tc.TopologySimulationCacheGroup{
+			Name:    node.Cachegroup,
+			Type:    *cacheGroups[index].Type,
+			Parents: []string{},
+			Servers: []tc.TopologySimulationServer{},
+		}
+		for _, parent := range node.Parents {
+			cg.Parents = append(cg.Parents, topology.Nodes[parent].Cachegroup)
+		}
+		if cg.Type == tc.CacheGroupOriginTypeName {
+			sim.CacheGroups = append(sim.CacheGroups, cg)
+			continue
+		}
+
+		for _, sv := range cacheGroupServers[node.Cachegroup] {
+			simServer := tc.TopologySimulationServer{
+				ID:       *sv.ID,
+				HostName: *sv.HostName,
+				Type:     sv.Type,
+				Status:   *sv.Status,
+				Profile:  *sv.Profile,
+			}
+			if sv.DomainName != nil {
+				simServer.DomainName = *sv.DomainName
+			}
+			cg.Servers = append(cg.Servers, simServer)
+		}
+		if len(cg.Servers) == 0 {
+			sim.Warnings = append(sim.Warnings, fmt.Sprintf("cachegroup %s has no servers in CDN %s with the required capabilities", node.Cachegroup, cdn.Name))
+		} else if len(availableServers(cacheGroupServers[node.Cachegroup])) == 0 {
+			sim.Warnings = append(sim.Warnings, fmt.Sprintf("cachegroup %s has no %s or %s servers in CDN %s with the required capabilities", node.Cachegroup, tc.CacheStatusOnline, tc.CacheStatusReported, cdn.Name))
+		}
+
+		if len(node.Parents) > 0 && !hasAvailableParent(topology, node, cacheGroups, cacheGroupServers) {
+			sim.Warnings = append(sim.Warnings, fmt.Sprintf("cachegroup %s has no eligible parents: none of its parent cachegroups has %s or %s servers in CDN %s with the required capabilities", node.Cachegroup, tc.CacheStatusOnline, tc.CacheStatusReported, cdn.Name))
+		}
+
+		if len(cacheGroupServers[node.Cachegroup]) == 0 {
+			sim.CacheGroups = append(sim.CacheGroups, cg)
+			continue
+		}
+		sv := cacheGroupServers[node.Cachegroup][0]
+		line, warnings, err := makeSimulatedParentLine(candidate, sv, servers, topology, params, serverCapabilities, dsRequiredCapabilities, allCacheGroups, cdn)
+		for _, warning := range warnings {
+			sim.Warnings = append(sim.Warnings, "cachegroup "+node.Cachegroup+": "+warning)
+		}
+		if err != nil {
+			sim.Warnings = append(sim.Warnings, "cachegroup "+node.Cachegroup+": generating parent.config: "+err.Error())
+		}
+		cg.ParentConfigServer = sv.HostName
+		cg.ParentConfigLine = line
+		sim.CacheGroups = append(sim.CacheGroups, cg)
+	}
+
+	return sim, nil, nil, http.StatusOK
+}
+
+// checkSimulatedTopology returns warnings about the structure of the given
+// Topology, whose nodes' Cache Groups are given in the same order.
+func checkSimulatedTopology(topology tc.Topology, cacheGroups []tc.CacheGroupNullable) []string {
+	warnings := []string{}
+	checker := TOTopology{Topology: topology}
+	for index := range topology.Nodes {
+		if err := checker.checkForEdgeParents(cacheGroups, index); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	for _, alert := range checker.Alerts.Alerts {
+		warnings = append(warnings, alert.Text)
+	}
+	for _, leafMid := range checkForLeafMids(topology.Nodes, cacheGroups) {
+		warnings = append(warnings, fmt.Sprintf("cachegroup %v's type is %v, but it has no child cachegroups", leafMid.Cachegroup, tc.CacheGroupMidTypeName))
+	}
+	if _, err := checkForCycles(topology.Nodes); err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	return warnings
+}
+
+// availableServers returns those of the given servers that can be parents,
+// i.e. that are ONLINE or REPORTED.
+func availableServers(servers []atscfg.Server) []atscfg.Server {
+	available := []atscfg.Server{}
+	for _, sv := range servers {
+		if *sv.Status == string(tc.CacheStatusOnline) || *sv.Status == string(tc.CacheStatusReported) {
+			available = append(available, sv)
+		}
+	}
+	return available
+}
+
+// hasAvailableParent returns whether any of the parents of the given node can
+// be used as a parent by the node's servers. Origin Cache Groups always can.
+func hasAvailableParent(topology tc.Topology, node tc.TopologyNode, cacheGroups []tc.CacheGroupNullable, cacheGroupServers map[string][]atscfg.Server) bool {
+	for _, parent := range node.Parents {
+		if *cacheGroups[parent].Type == tc.CacheGroupOriginTypeName {
+			return true
+		}
+		if len(availableServers(cacheGroupServers[topology.Nodes[parent].Cachegroup])) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// simulationServer converts an eligible server into a server ATS config
+// generation can use.
+func simulationServer(eligible tc.DSServerV4, cdn tc.CDN) atscfg.Server {
+	sv := atscfg.Server{}
+	sv.ID = eligible.ID
+	sv.HostName = eligible.HostName
+	sv.DomainName = eligible.DomainName
+	sv.Cachegroup = eligible.Cachegroup
+	sv.CachegroupID = eligible.CachegroupID
+	sv.CDNID = util.IntPtr(cdn.ID)
+	sv.CDNName = util.StrPtr(cdn.Name)
+	sv.Profile = eligible.Profile
+	sv.ProfileID = eligible.ProfileID
+	sv.Status = eligible.Status
+	sv.StatusID = eligible.StatusID
+	sv.TCPPort = eligible.TCPPort
+	sv.HTTPSPort = eligible.HTTPSPort
+	sv.Type = eligible.Type
+	sv.TypeID = eligible.TypeID
+	if eligible.ServerInterfaces != nil {
+		sv.Interfaces = *eligible.ServerInterfaces
+	}
+	return sv
+}
+
+// simulationDeliveryService fills in the properties of the candidate Delivery
+// Service that ATS config generation needs, but which the candidate may not
+// have because it doesn't exist yet.
+func simulationDeliveryService(ds tc.DeliveryServiceV4, cdn tc.CDN) atscfg.DeliveryService {
+	if ds.ID == nil {
+		ds.ID = util.IntPtr(0)
+	}
+	if ds.XMLID == nil || *ds.XMLID == "" {
+		ds.XMLID = util.StrPtr(simulatedDSXMLID)
+	}
+	if ds.Type == nil {
+		dsType := tc.DSTypeHTTP
+		ds.Type = &dsType
+	}
+	ds.CDNID = util.IntPtr(cdn.ID)
+	ds.CDNName = util.StrPtr(cdn.Name)
+	return atscfg.DeliveryService(ds)
+}
+
+// getSimulationParams returns the Parameters ATS config generation uses to
+// make parent.config files, along with the names of their Profiles.
+func getSimulationParams(tx *sql.Tx) ([]tc.Parameter, error) {
+	rows, err := tx.Query(`
+SELECT p.id, p.name, p.config_file, p.value,
+COALESCE((SELECT json_agg(pr.name) FROM profile_parameter pp JOIN profile pr ON pp.profile = pr.id WHERE pp.parameter = p.id), '[]'::json) AS profiles
+FROM parameter p
+WHERE p.config_file = $1
+OR (p.config_file = 'package' AND p.name = 'trafficserver')
+`, atscfg.ParentConfigFileName)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer rows.Close()
+
+	params := []tc.Parameter{}
+	for rows.Next() {
+		param := tc.Parameter{}
+		var profiles []byte
+		if err := rows.Scan(&param.ID, &param.Name, &param.ConfigFile, &param.Value, &profiles); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		param.Profiles = profiles
+		params = append(params, param)
+	}
+	return params, rows.Err()
+}
+
+// makeSimulatedParentLine generates the parent.config of the given server,
+// and returns its line for the candidate Delivery Service, if it has one.
+func makeSimulatedParentLine(
+	ds atscfg.DeliveryService,
+	server atscfg.Server,
+	servers []atscfg.Server,
+	topology tc.Topology,
+	params []tc.Parameter,
+	serverCapabilities map[int]map[atscfg.ServerCapability]struct{},
+	dsRequiredCapabilities map[int]map[atscfg.ServerCapability]struct{},
+	cacheGroups []tc.CacheGroupNullable,
+	cdn tc.CDN,
+) (*string, []string, error) {
+	serverParams := []tc.Parameter{}
+	for _, param := range params {
+		profiles := []string{}
+		if err := json.Unmarshal(param.Profiles, &profiles); err != nil {
+			return nil, nil, fmt.Errorf("parsing profiles of parameter %d: %v", param.ID, err)
+		}
+		if util.ContainsStr(profiles, *server.Profile) {
+			serverParams = append(serverParams, param)
+		}
+	}
+
+	cfg, err := atscfg.MakeParentDotConfig(
+		[]atscfg.DeliveryService{ds},
+		&server,
+		servers,
+		[]tc.Topology{topology},
+		serverParams,
+		params,
+		serverCapabilities,
+		dsRequiredCapabilities,
+		cacheGroups,
+		[]tc.DeliveryServiceServer{},
+		&cdn,
+		atscfg.ParentConfigOpts{},
+	)
+	if err != nil {
+		return nil, cfg.Warnings, err
+	}
+	line := findParentLine(cfg.Text, *ds.OrgServerFQDN)
+	if line == nil {
+		return nil, cfg.Warnings, errors.New("no parent.config line was generated for the delivery service")
+	}
+	return line, cfg.Warnings, nil
+}
+
+// findParentLine returns the line of the given parent.config text for the
+// given origin, if there is one.
+func findParentLine(text string, origin string) *string {
+	orgURI, err := url.Parse(origin)
+	if err != nil {
+		return nil
+	}
+	prefix := "dest_domain=" + orgURI.Hostname() + " "
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, prefix) {
+			return util.StrPtr(line)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package topology
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func simulationTestTopology() (tc.Topology, []tc.CacheGroupNullable) {
+	topology := tc.Topology{
+		Name: "simulation",
+		Nodes: []tc.TopologyNode{
+			{Cachegroup: "edge", Parents: []int{1, 2}},
+			{Cachegroup: "mid1"},
+			{Cachegroup: "mid2"},
+		},
+	}
+	cacheGroups := []tc.CacheGroupNullable{
+		{ID: util.IntPtr(1), Name: util.StrPtr("edge"), Type: util.StrPtr(tc.CacheGroupEdgeTypeName)},
+		{ID: util.IntPtr(2), Name: util.StrPtr("mid1"), Type: util.StrPtr(tc.CacheGroupMidTypeName)},
+		{ID: util.IntPtr(3), Name: util.StrPtr("mid2"), Type: util.StrPtr(tc.CacheGroupMidTypeName)},
+	}
+	return topology, cacheGroups
+}
+
+func simulationTestServer(id int, hostName string, cacheGroup string, serverType string, status tc.CacheStatus) atscfg.Server {
+	sv := atscfg.Server{}
+	sv.ID = util.IntPtr(id)
+	sv.HostName = util.StrPtr(hostName)
+	sv.DomainName = util.StrPtr("example.test")
+	sv.Cachegroup = util.StrPtr(cacheGroup)
+	sv.CDNName = util.StrPtr("cdn")
+	sv.Profile = util.StrPtr(serverType + "_PROFILE")
+	sv.Status = util.StrPtr(string(status))
+	sv.TCPPort = util.IntPtr(80)
+	sv.Type = serverType
+	return sv
+}
+
+func TestCheckSimulatedTopology(t *testing.T) {
+	topology, cacheGroups := simulationTestTopology()
+	if warnings := checkSimulatedTopology(topology, cacheGroups); len(warnings) != 0 {
+		t.Errorf("expected no warnings for a valid topology, actual: %v", warnings)
+	}
+
+	topology.Nodes = append(topology.Nodes, tc.TopologyNode{Cachegroup: "mid3", Parents: []int{1}})
+	cacheGroups = append(cacheGroups, tc.CacheGroupNullable{ID: util.IntPtr(4), Name: util.StrPtr("mid3"), Type: util.StrPtr(tc.CacheGroupMidTypeName)})
+	warnings := checkSimulatedTopology(topology, cacheGroups)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "mid3") {
+		t.Errorf("expected a leaf mid warning for mid3, actual: %v", warnings)
+	}
+}
+
+func TestHasAvailableParent(t *testing.T) {
+	topology, cacheGroups := simulationTestTopology()
+	servers := map[string][]atscfg.Server{
+		"mid1": {simulationTestServer(1, "mid1a", "mid1", "MID", tc.CacheStatusAdminDown)},
+	}
+	if hasAvailableParent(topology, topology.Nodes[0], cacheGroups, servers) {
+		t.Error("expected edge without ONLINE or REPORTED parents to have no available parent")
+	}
+
+	servers["mid2"] = []atscfg.Server{simulationTestServer(2, "mid2a", "mid2", "MID", tc.CacheStatusReported)}
+	if !hasAvailableParent(topology, topology.Nodes[0], cacheGroups, servers) {
+		t.Error("expected edge with a REPORTED secondary parent to have an available parent")
+	}
+
+	*cacheGroups[1].Type = tc.CacheGroupOriginTypeName
+	delete(servers, "mid2")
+	if !hasAvailableParent(topology, topology.Nodes[0], cacheGroups, servers) {
+		t.Error("expected edge with an origin parent to have an available parent")
+	}
+}
+
+func TestMakeSimulatedParentLine(t *testing.T) {
+	topology, cacheGroups := simulationTestTopology()
+	edge := simulationTestServer(1, "edge", "edge", "EDGE", tc.CacheStatusReported)
+	servers := []atscfg.Server{
+		edge,
+		simulationTestServer(2, "mid1a", "mid1", "MID", tc.CacheStatusReported),
+		simulationTestServer(3, "mid2a", "mid2", "MID", tc.CacheStatusOnline),
+	}
+	cdn := tc.CDN{ID: 1, Name: "cdn", DomainName: "cdn.test"}
+	ds := tc.DeliveryServiceV4{}
+	ds.Topology = util.StrPtr(topology.Name)
+	ds.OrgServerFQDN = util.StrPtr("http://origin.example.test")
+	candidate := simulationDeliveryService(ds, cdn)
+	if *candidate.XMLID != simulatedDSXMLID || *candidate.Type != tc.DSTypeHTTP || *candidate.CDNName != "cdn" {
+		t.Fatalf("unexpected simulated delivery service: %+v", candidate)
+	}
+
+	line, _, err := makeSimulatedParentLine(candidate, edge, servers, topology, []tc.Parameter{}, nil, nil, cacheGroups, cdn)
+	if err != nil {
+		t.Fatalf("unexpected error making parent line: %v", err)
+	}
+	if line == nil {
+		t.Fatal("expected a parent line, actual: nil")
+	}
+	if !strings.HasPrefix(*line, "dest_domain=origin.example.test ") {
+		t.Errorf("expected the parent line to be for the origin, actual: %s", *line)
+	}
+	if !strings.Contains(*line, `parent="mid1a.example.test:80`) || !strings.Contains(*line, `secondary_parent="mid2a.example.test:80`) {
+		t.Errorf("expected the parent line to have mid1a as parent and mid2a as secondary parent, actual: %s", *line)
+	}
+}
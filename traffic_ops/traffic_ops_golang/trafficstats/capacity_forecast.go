@@ -0,0 +1,420 @@
+package trafficstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	influx "github.com/influxdata/influxdb/client/v2"
+	"github.com/influxdata/influxdb/models"
+	"github.com/lib/pq"
+)
+
+const (
+	// cgCapacityQuery gets the latest maximum bandwidth of each cache.
+	cgCapacityQuery = `
+SELECT last(value)
+FROM "%s"."monthly"."maxkbps.1min"
+WHERE cdn = $cdn_name
+AND time > $start
+AND time <= $end
+GROUP BY cachegroup, hostname`
+
+	// cgDailyPeakQuery gets the daily peaks of the per-minute sum of the bandwidth of the caches of each Cache Group.
+	// It requires InfluxDB 1.2 or later, for subqueries.
+	cgDailyPeakQuery = `
+SELECT max(value)
+FROM (
+	SELECT sum(value) AS value
+	FROM "%s"."monthly"."bandwidth.1min"
+	WHERE cdn = $cdn_name
+	AND time > $start
+	AND time <= $end
+	GROUP BY time(1m), cachegroup
+)
+WHERE time > $start
+AND time <= $end
+GROUP BY time(1d), cachegroup fill(none)`
+
+	cdnDailyPeakQuery = `
+SELECT max(value)
+FROM "%s"."monthly"."bandwidth.cdn.1min"
+WHERE cdn = $cdn_name
+AND time > $start
+AND time <= $end
+GROUP BY time(1d) fill(none)`
+
+	dsPeakQuery = `
+SELECT max(value)
+FROM "%s"."monthly"."kbps.cg.1min"
+WHERE cdn = $cdn_name
+AND time > $start
+AND time <= $end
+GROUP BY cachegroup, deliveryservice`
+
+	cacheThresholdsQuery = `
+SELECT s.host_name, pa.value
+FROM server AS s
+JOIN cdn AS c ON c.id = s.cdn_id
+JOIN profile_parameter AS pp ON pp.profile = s.profile
+JOIN parameter AS pa ON pa.id = pp.parameter
+WHERE c.name = $1
+AND pa.config_file = 'rascal-config.txt'
+AND pa.name = 'health.threshold.availableBandwidthInKbps'`
+
+	authorizedDSesQuery = `
+SELECT ds.xml_id
+FROM deliveryservice AS ds
+JOIN cdn AS c ON c.id = ds.cdn_id
+WHERE c.name = $1
+AND ds.tenant_id = ANY($2::bigint[])`
+)
+
+// maxDaysToSaturation is the greatest number of days to saturation which is forecast. Slower trends are treated as
+// never saturating.
+const maxDaysToSaturation = 36500
+
+// GetCapacityForecast is the handler for GET requests to /cdns/{name}/capacity/forecast.
+func GetCapacityForecast(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	days := tc.CapacityForecastDefaultDays
+	if daysStr, ok := inf.Params["days"]; ok {
+		var err error
+		if days, err = strconv.Atoi(daysStr); err != nil || days < 1 || days > tc.CapacityForecastMaxDays {
+			userErr = fmt.Errorf("days must be an integer from 1 to %d", tc.CapacityForecastMaxDays)
+			api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+			return
+		}
+	}
+
+	cdn := inf.Params["name"]
+	exists, err := dbhelpers.CDNExists(cdn, tx)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !exists {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+		return
+	}
+
+	client, err := inf.CreateInfluxClient()
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if client == nil {
+		sysErr = errors.New("Traffic Stats is not configured, but a capacity forecast was requested")
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
+		return
+	}
+	defer (*client).Close()
+
+	// only whole days, so that today's partial peak doesn't bias the trend
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	start := end.AddDate(0, 0, -days)
+	forecast, err := getCapacityForecast(tx, client, inf.Config.ConfigInflux.CacheDBName, inf.Config.ConfigInflux.DSDBName, inf.User, cdn, start, end)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting capacity forecast: %v", err))
+		return
+	}
+	api.WriteResp(w, r, forecast)
+}
+
+func getCapacityForecast(tx *sql.Tx, client *influx.Client, cacheDB string, dsDB string, user *auth.CurrentUser, cdn string, start time.Time, end time.Time) (tc.CapacityForecast, error) {
+	forecast := tc.CapacityForecast{CDNName: cdn, StartDate: start, EndDate: end, CacheGroups: []tc.CapacityForecastCacheGroup{}}
+
+	thresholds, err := getCacheThresholds(tx, cdn)
+	if err != nil {
+		return forecast, err
+	}
+	dsNames, err := getAuthorizedDSNames(tx, user, cdn)
+	if err != nil {
+		return forecast, err
+	}
+
+	params := map[string]interface{}{"cdn_name": cdn, "start": end.Add(-24 * time.Hour), "end": end}
+	rows, err := queryRows(client, influx.NewQueryWithParameters(fmt.Sprintf(cgCapacityQuery, cacheDB), cacheDB, "rfc3339", params))
+	if err != nil {
+		return forecast, fmt.Errorf("querying cache capacity: %v", err)
+	}
+	capacities := map[string]float64{}
+	for _, row := range rows {
+		values, err := parseSamples(row)
+		if err != nil {
+			return forecast, fmt.Errorf("parsing cache capacity: %v", err)
+		}
+		if len(values) > 0 {
+			if capacity := values[0].Kbps - thresholds[row.Tags["hostname"]]; capacity > 0 {
+				capacities[row.Tags["cachegroup"]] += capacity
+			}
+		}
+	}
+
+	params["start"] = start
+	rows, err = queryRows(client, influx.NewQueryWithParameters(fmt.Sprintf(cgDailyPeakQuery, cacheDB), cacheDB, "rfc3339", params))
+	if err != nil {
+		return forecast, fmt.Errorf("querying cache group bandwidth: %v", err)
+	}
+	peaks := map[string][]tc.CapacityForecastSample{}
+	for _, row := range rows {
+		if peaks[row.Tags["cachegroup"]], err = parseSamples(row); err != nil {
+			return forecast, fmt.Errorf("parsing cache group bandwidth: %v", err)
+		}
+	}
+
+	rows, err = queryRows(client, influx.NewQueryWithParameters(fmt.Sprintf(dsPeakQuery, dsDB), dsDB, "rfc3339", params))
+	if err != nil {
+		return forecast, fmt.Errorf("querying delivery service bandwidth: %v", err)
+	}
+	dsPeaks := map[string][]tc.CapacityForecastDeliveryService{}
+	for _, row := range rows {
+		xmlID := row.Tags["deliveryservice"]
+		if _, ok := dsNames[xmlID]; !ok {
+			continue
+		}
+		values, err := parseSamples(row)
+		if err != nil {
+			return forecast, fmt.Errorf("parsing delivery service bandwidth: %v", err)
+		}
+		if len(values) > 0 {
+			cg := row.Tags["cachegroup"]
+			dsPeaks[cg] = append(dsPeaks[cg], tc.CapacityForecastDeliveryService{XMLID: xmlID, PeakKbps: values[0].Kbps})
+		}
+	}
+
+	rows, err = queryRows(client, influx.NewQueryWithParameters(fmt.Sprintf(cdnDailyPeakQuery, cacheDB), cacheDB, "rfc3339", params))
+	if err != nil {
+		return forecast, fmt.Errorf("querying CDN bandwidth: %v", err)
+	}
+	cdnPeaks := []tc.CapacityForecastSample{}
+	if len(rows) > 0 {
+		if cdnPeaks, err = parseSamples(rows[0]); err != nil {
+			return forecast, fmt.Errorf("parsing CDN bandwidth: %v", err)
+		}
+	}
+
+	totalCapacity := 0.0
+	for _, capacity := range capacities {
+		totalCapacity += capacity
+	}
+	forecast.Total = forecastEntry(cdnPeaks, totalCapacity, end)
+
+	names := []string{}
+	for name := range capacities {
+		names = append(names, name)
+	}
+	for name := range peaks {
+		if _, ok := capacities[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cg := tc.CapacityForecastCacheGroup{
+			Name:                  name,
+			CapacityForecastEntry: forecastEntry(peaks[name], capacities[name], end),
+			DeliveryServices:      dsPeaks[name],
+		}
+		if cg.DeliveryServices == nil {
+			cg.DeliveryServices = []tc.CapacityForecastDeliveryService{}
+		}
+		for i, ds := range cg.DeliveryServices {
+			if cg.PeakKbps > 0 {
+				cg.DeliveryServices[i].PeakSharePercent = ds.PeakKbps * 100 / cg.PeakKbps
+			}
+		}
+		sort.Slice(cg.DeliveryServices, func(i, j int) bool {
+			if cg.DeliveryServices[i].PeakKbps != cg.DeliveryServices[j].PeakKbps {
+				return cg.DeliveryServices[i].PeakKbps > cg.DeliveryServices[j].PeakKbps
+			}
+			return cg.DeliveryServices[i].XMLID < cg.DeliveryServices[j].XMLID
+		})
+		forecast.CacheGroups = append(forecast.CacheGroups, cg)
+	}
+	return forecast, nil
+}
+
+// forecastEntry returns the forecast of a Cache Group or CDN with the given daily peaks, oldest first, and capacity,
+// as of the given time.
+func forecastEntry(peaks []tc.CapacityForecastSample, capacity float64, end time.Time) tc.CapacityForecastEntry {
+	entry := tc.CapacityForecastEntry{CapacityKbps: capacity, DailyPeaks: peaks}
+	if entry.DailyPeaks == nil {
+		entry.DailyPeaks = []tc.CapacityForecastSample{}
+	}
+	for _, peak := range peaks {
+		if peak.Kbps > entry.PeakKbps {
+			entry.PeakKbps = peak.Kbps
+		}
+	}
+	if capacity > 0 {
+		utilization := entry.PeakKbps * 100 / capacity
+		entry.PeakUtilizationPercent = &utilization
+	}
+	if len(peaks) < 2 {
+		return entry
+	}
+
+	// least-squares fit of the daily peaks against the days since the first of them
+	n, sumX, sumY, sumXX, sumXY := float64(len(peaks)), 0.0, 0.0, 0.0, 0.0
+	for _, peak := range peaks {
+		x := peak.Time.Sub(peaks[0].Time).Hours() / 24
+		sumX += x
+		sumY += peak.Kbps
+		sumXX += x * x
+		sumXY += x * peak.Kbps
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return entry
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	intercept := (sumY - slope*sumX) / n
+	entry.TrendKbpsPerDay = &slope
+	if capacity <= 0 {
+		return entry
+	}
+
+	current := intercept + slope*end.Sub(peaks[0].Time).Hours()/24
+	days := 0.0
+	if current < capacity {
+		if slope <= 0 {
+			return entry
+		}
+		days = (capacity - current) / slope
+		if days > maxDaysToSaturation {
+			return entry
+		}
+	}
+	date := end.Add(time.Duration(days * float64(24*time.Hour)))
+	entry.DaysToSaturation = &days
+	entry.SaturationDate = &date
+	return entry
+}
+
+// queryRows returns all of the series of the response to the given query.
+func queryRows(client *influx.Client, q influx.Query) ([]models.Row, error) {
+	log.Debugf("InfluxDB capacity forecast query: %+v", q)
+	resp, err := (*client).Query(q)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error() != nil {
+		return nil, resp.Error()
+	}
+	if len(resp.Results) != 1 {
+		log.Debugf("InfluxDB capacity forecast response: %+v", resp)
+		return nil, errors.New("'results' missing or improper")
+	}
+	return resp.Results[0].Series, nil
+}
+
+// parseSamples returns the values of the given series, which has a time column and a value column, skipping nulls.
+func parseSamples(row models.Row) ([]tc.CapacityForecastSample, error) {
+	samples := []tc.CapacityForecastSample{}
+	for _, value := range row.Values {
+		if len(value) != 2 {
+			return nil, fmt.Errorf("expected 2 columns, got %d", len(value))
+		}
+		if value[1] == nil {
+			continue
+		}
+		timeStr, ok := value[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid type for time - expected 'string', got %T (%v)", value[0], value[0])
+		}
+		t, err := time.Parse(time.RFC3339Nano, timeStr)
+		if err != nil {
+			return nil, fmt.Errorf("parsing time: %v", err)
+		}
+		kbps, err := extractFloat64(row.Columns[1], map[string]interface{}{row.Columns[1]: value[1]})
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, tc.CapacityForecastSample{Time: t, Kbps: kbps})
+	}
+	return samples, nil
+}
+
+// getCacheThresholds returns the available bandwidth health thresholds of the caches of the given CDN, by host name.
+func getCacheThresholds(tx *sql.Tx, cdn string) (map[string]float64, error) {
+	rows, err := tx.Query(cacheThresholdsQuery, cdn)
+	if err != nil {
+		return nil, errors.New("querying cache thresholds: " + err.Error())
+	}
+	defer rows.Close()
+
+	thresholds := map[string]float64{}
+	for rows.Next() {
+		hostName := ""
+		threshStr := ""
+		if err := rows.Scan(&hostName, &threshStr); err != nil {
+			return nil, errors.New("scanning cache thresholds: " + err.Error())
+		}
+		thresh, err := strconv.ParseFloat(strings.TrimPrefix(threshStr, ">"), 64)
+		if err != nil {
+			return nil, errors.New("server '" + hostName + "' health.threshold.availableBandwidthInKbps is not a number")
+		}
+		thresholds[hostName] = thresh
+	}
+	return thresholds, nil
+}
+
+// getAuthorizedDSNames returns the XMLIDs of the Delivery Services of the given CDN to which the user has access.
+func getAuthorizedDSNames(tx *sql.Tx, user *auth.CurrentUser, cdn string) (map[string]struct{}, error) {
+	tenantIDs, err := tenant.GetUserTenantIDListTx(tx, user.TenantID)
+	if err != nil {
+		return nil, errors.New("getting user tenants: " + err.Error())
+	}
+	rows, err := tx.Query(authorizedDSesQuery, cdn, pq.Array(tenantIDs))
+	if err != nil {
+		return nil, errors.New("querying delivery services: " + err.Error())
+	}
+	defer rows.Close()
+
+	names := map[string]struct{}{}
+	for rows.Next() {
+		name := ""
+		if err := rows.Scan(&name); err != nil {
+			return nil, errors.New("scanning delivery services: " + err.Error())
+		}
+		names[name] = struct{}{}
+	}
+	return names, nil
+}
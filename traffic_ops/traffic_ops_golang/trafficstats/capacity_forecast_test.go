@@ -0,0 +1,101 @@
+package trafficstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/influxdata/influxdb/models"
+)
+
+func TestForecastEntry(t *testing.T) {
+	first := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	peaks := []tc.CapacityForecastSample{}
+	for i := 0; i < 10; i++ {
+		peaks = append(peaks, tc.CapacityForecastSample{Time: first.AddDate(0, 0, i), Kbps: 1000 + 100*float64(i)})
+	}
+	end := first.AddDate(0, 0, 10)
+
+	entry := forecastEntry(peaks, 4000, end)
+	if entry.PeakKbps != 1900 {
+		t.Errorf("expected peak 1900, actual: %f", entry.PeakKbps)
+	}
+	if entry.PeakUtilizationPercent == nil || math.Abs(*entry.PeakUtilizationPercent-47.5) > 1e-9 {
+		t.Errorf("expected peak utilization 47.5%%, actual: %v", entry.PeakUtilizationPercent)
+	}
+	if entry.TrendKbpsPerDay == nil || math.Abs(*entry.TrendKbpsPerDay-100) > 1e-9 {
+		t.Errorf("expected trend 100 kbps/day, actual: %v", entry.TrendKbpsPerDay)
+	}
+	// the trend is 2000 at the end, so it reaches 4000 in 20 days
+	if entry.DaysToSaturation == nil || math.Abs(*entry.DaysToSaturation-20) > 1e-9 {
+		t.Fatalf("expected 20 days to saturation, actual: %v", entry.DaysToSaturation)
+	}
+	if expected := end.AddDate(0, 0, 20); entry.SaturationDate == nil || !entry.SaturationDate.Equal(expected) {
+		t.Errorf("expected saturation date %v, actual: %v", expected, entry.SaturationDate)
+	}
+
+	if entry = forecastEntry(peaks, 1500, end); entry.DaysToSaturation == nil || *entry.DaysToSaturation != 0 {
+		t.Errorf("expected an already saturated capacity to have 0 days to saturation, actual: %v", entry.DaysToSaturation)
+	}
+	if entry = forecastEntry(peaks, 0, end); entry.PeakUtilizationPercent != nil || entry.DaysToSaturation != nil || entry.TrendKbpsPerDay == nil {
+		t.Errorf("expected an unknown capacity to have a trend but no utilization or saturation, actual: %+v", entry)
+	}
+
+	for i := range peaks {
+		peaks[i].Kbps = 2000 - 50*float64(i)
+	}
+	if entry = forecastEntry(peaks, 4000, end); entry.DaysToSaturation != nil || entry.SaturationDate != nil {
+		t.Errorf("expected a declining trend never to saturate, actual: %v", entry.DaysToSaturation)
+	}
+	if entry = forecastEntry(peaks[:1], 4000, end); entry.TrendKbpsPerDay != nil || entry.PeakKbps != 2000 {
+		t.Errorf("expected a single day to have a peak but no trend, actual: %+v", entry)
+	}
+}
+
+func TestParseSamples(t *testing.T) {
+	row := models.Row{
+		Columns: []string{"time", "max"},
+		Values: [][]interface{}{
+			{"2021-04-01T00:00:00Z", json.Number("1500.5")},
+			{"2021-04-02T00:00:00Z", nil},
+			{"2021-04-03T00:00:00Z", json.Number("1700")},
+		},
+	}
+	samples, err := parseSamples(row)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected null values to be skipped, leaving 2 samples, actual: %d", len(samples))
+	}
+	if samples[0].Kbps != 1500.5 || !samples[1].Time.Equal(time.Date(2021, 4, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected samples: %+v", samples)
+	}
+
+	row.Values = [][]interface{}{{"2021-04-01T00:00:00Z", "not a number"}}
+	if _, err := parseSamples(row); err == nil {
+		t.Error("expected an error for a non-numeric value, actual: nil")
+	}
+}
@@ -0,0 +1,105 @@
+package trafficvault
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Disabled is the TrafficVault used when no backend is configured. Every operation returns ErrNotConfigured.
+type Disabled struct{}
+
+var _ TrafficVault = Disabled{}
+
+func (Disabled) Name() string { return "" }
+
+func (Disabled) GetDeliveryServiceSSLKeys(xmlID string, version string, tx *sql.Tx) (tc.DeliveryServiceSSLKeysV15, bool, error) {
+	return tc.DeliveryServiceSSLKeysV15{}, false, ErrNotConfigured
+}
+
+func (Disabled) PutDeliveryServiceSSLKeys(keys tc.DeliveryServiceSSLKeys, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) DeleteDeliveryServiceSSLKeys(xmlID string, version string, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) DeleteOldDeliveryServiceSSLKeys(existingXMLIDs map[tc.DeliveryServiceName]struct{}, cdn tc.CDNName, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) GetCDNSSLKeys(cdn string, tx *sql.Tx) ([]tc.CDNSSLKey, error) {
+	return nil, ErrNotConfigured
+}
+
+func (Disabled) GetDeliveryServiceSSLKeysCSR(xmlID string, tx *sql.Tx) (tc.DeliveryServiceSSLKeysPendingCSR, bool, error) {
+	return tc.DeliveryServiceSSLKeysPendingCSR{}, false, ErrNotConfigured
+}
+
+func (Disabled) PutDeliveryServiceSSLKeysCSR(csr tc.DeliveryServiceSSLKeysPendingCSR, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) DeleteDeliveryServiceSSLKeysCSR(xmlID string, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) GetDNSSECKeys(cdn string, tx *sql.Tx) (tc.DNSSECKeysRiak, bool, error) {
+	return nil, false, ErrNotConfigured
+}
+
+func (Disabled) PutDNSSECKeys(cdn string, keys tc.DNSSECKeysRiak, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) DeleteDNSSECKeys(cdn string, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) GetURLSigKeys(ds tc.DeliveryServiceName, tx *sql.Tx) (tc.URLSigKeys, bool, error) {
+	return nil, false, ErrNotConfigured
+}
+
+func (Disabled) PutURLSigKeys(ds tc.DeliveryServiceName, keys tc.URLSigKeys, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) GetURISigningKeys(xmlID string, tx *sql.Tx) ([]byte, bool, error) {
+	return nil, false, ErrNotConfigured
+}
+
+func (Disabled) PutURISigningKeys(xmlID string, keys []byte, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) DeleteURISigningKeys(xmlID string, tx *sql.Tx) error {
+	return ErrNotConfigured
+}
+
+func (Disabled) GetBucketKey(bucket string, key string, tx *sql.Tx) ([]byte, bool, error) {
+	return nil, false, ErrNotConfigured
+}
+
+func (Disabled) Ping(tx *sql.Tx) (tc.RiakPingResp, error) {
+	return tc.RiakPingResp{}, ErrNotConfigured
+}
@@ -0,0 +1,107 @@
+package postgres
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// readAESKey reads the base64-encoded AES key in the file at the given path. The key must be 16, 24, or 32 bytes, for AES-128, AES-192, or AES-256, respectively.
+func readAESKey(path string) ([]byte, error) {
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("reading AES key file: " + err.Error())
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(bts)))
+	if err != nil {
+		return nil, errors.New("decoding AES key: " + err.Error())
+	}
+	if err := validateAESKey(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func validateAESKey(key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	default:
+		return fmt.Errorf("AES key must be 16, 24, or 32 bytes, but is %d bytes", len(key))
+	}
+}
+
+// additionalData returns the AES-GCM additional authenticated data of the row of the given table with the given key
+// (Delivery Service XMLID or CDN name) and version, which is empty for tables whose keys aren't versioned. Binding
+// the ciphertext to its row this way means it can't be decrypted if it's moved to another row.
+func additionalData(table string, key string, version string) []byte {
+	return []byte(table + "\x00" + key + "\x00" + version)
+}
+
+// encrypt encrypts the given plaintext with AES-GCM, authenticating the given additional data, returning the random
+// nonce followed by the ciphertext.
+func encrypt(plaintext []byte, key []byte, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.New("generating nonce: " + err.Error())
+	}
+	return gcm.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// decrypt decrypts the given nonce and ciphertext, as created by encrypt with the same additional data.
+func decrypt(ciphertext []byte, key []byte, aad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext is shorter than the nonce")
+	}
+	nonce, ciphertext := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return nil, errors.New("decrypting: " + err.Error())
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.New("creating AES cipher: " + err.Error())
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.New("creating GCM: " + err.Error())
+	}
+	return gcm, nil
+}
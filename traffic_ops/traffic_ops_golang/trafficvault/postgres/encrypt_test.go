@@ -0,0 +1,120 @@
+package postgres
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	plaintext := []byte(`{"cdn":"mycdn","key":"secret"}`)
+	aad := additionalData("dnssec", "mycdn", "")
+
+	ciphertext, err := encrypt(plaintext, key, aad)
+	if err != nil {
+		t.Fatalf("encrypting: expected nil error, actual: %v", err)
+	}
+	if bytes.Contains(ciphertext, plaintext) {
+		t.Errorf("expected ciphertext to not contain the plaintext")
+	}
+
+	actual, err := decrypt(ciphertext, key, aad)
+	if err != nil {
+		t.Fatalf("decrypting: expected nil error, actual: %v", err)
+	}
+	if !bytes.Equal(actual, plaintext) {
+		t.Errorf("expected decrypted '%s', actual '%s'", plaintext, actual)
+	}
+
+	again, err := encrypt(plaintext, key, aad)
+	if err != nil {
+		t.Fatalf("encrypting: expected nil error, actual: %v", err)
+	}
+	if bytes.Equal(again, ciphertext) {
+		t.Errorf("expected encrypting the same plaintext twice to use different nonces")
+	}
+
+	wrongKey := []byte("fedcba9876543210fedcba9876543210")
+	if _, err := decrypt(ciphertext, wrongKey, aad); err == nil {
+		t.Errorf("decrypting with the wrong key: expected error, actual: nil")
+	}
+	if _, err := decrypt([]byte("short"), key, aad); err == nil {
+		t.Errorf("decrypting a short ciphertext: expected error, actual: nil")
+	}
+	for _, other := range [][]byte{
+		nil,
+		additionalData("dnssec", "othercdn", ""),
+		additionalData("sslkey_csr", "mycdn", ""),
+		additionalData("dnssec", "mycdn", "1"),
+		additionalData("dnssec", "mycd", "n"),
+	} {
+		if _, err := decrypt(ciphertext, key, other); err == nil {
+			t.Errorf("decrypting with the additional data of another row '%q': expected error, actual: nil", other)
+		}
+	}
+}
+
+func TestReadAESKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tv-aes-key")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		content string
+		keyLen  int
+		isErr   bool
+	}{
+		{"aes128", base64.StdEncoding.EncodeToString(make([]byte, 16)), 16, false},
+		{"aes256 with newline", base64.StdEncoding.EncodeToString(make([]byte, 32)) + "\n", 32, false},
+		{"invalid size", base64.StdEncoding.EncodeToString(make([]byte, 20)), 0, true},
+		{"not base64", "not*base64!", 0, true},
+	}
+	for _, test := range tests {
+		path := filepath.Join(dir, "key")
+		if err := ioutil.WriteFile(path, []byte(test.content), 0600); err != nil {
+			t.Fatalf("writing key file: %v", err)
+		}
+		key, err := readAESKey(path)
+		if test.isErr {
+			if err == nil {
+				t.Errorf("%s: expected error, actual: nil", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected nil error, actual: %v", test.name, err)
+		} else if len(key) != test.keyLen {
+			t.Errorf("%s: expected key length %d, actual %d", test.name, test.keyLen, len(key))
+		}
+	}
+
+	if _, err := readAESKey(filepath.Join(dir, "nonexistent")); err == nil {
+		t.Errorf("reading nonexistent key file: expected error, actual: nil")
+	}
+}
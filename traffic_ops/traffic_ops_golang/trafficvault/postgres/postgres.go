@@ -0,0 +1,456 @@
+package postgres
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+
+	"github.com/lib/pq"
+)
+
+const (
+	DefaultPort                   = 5432
+	DefaultMaxIdleConnections     = 10
+	DefaultConnMaxLifetimeSeconds = 60
+	DefaultQueryTimeoutSeconds    = 20
+)
+
+// latestVersion is the version under which the most recently stored SSL keys of each Delivery Service are also stored, as in Riak.
+const latestVersion = riaksvc.DSSSLKeyVersionLatest
+
+// Config is the configuration of the PostgreSQL Traffic Vault backend, given by the traffic_vault_config cdn.conf option.
+type Config struct {
+	DBName                 string `json:"dbname"`
+	Hostname               string `json:"hostname"`
+	User                   string `json:"user"`
+	Password               string `json:"password"`
+	Port                   int    `json:"port"`
+	SSL                    bool   `json:"ssl"`
+	MaxConnections         int    `json:"max_connections"`
+	MaxIdleConnections     int    `json:"max_idle_connections"`
+	ConnMaxLifetimeSeconds int    `json:"conn_max_lifetime_seconds"`
+	QueryTimeoutSeconds    int    `json:"query_timeout_seconds"`
+	// AESKeyLocation is the path of the file containing the base64-encoded AES key, with which all keys are encrypted in the database.
+	AESKeyLocation string `json:"aes_key_location"`
+}
+
+// ParseConfig parses the given JSON configuration, validating required fields and setting defaults.
+func ParseConfig(cfgJSON json.RawMessage) (Config, error) {
+	cfg := Config{}
+	if len(cfgJSON) == 0 {
+		return Config{}, errors.New("no traffic_vault_config")
+	}
+	if err := json.Unmarshal(cfgJSON, &cfg); err != nil {
+		return Config{}, errors.New("unmarshalling traffic_vault_config: " + err.Error())
+	}
+	missing := []string{}
+	if cfg.DBName == "" {
+		missing = append(missing, "dbname")
+	}
+	if cfg.Hostname == "" {
+		missing = append(missing, "hostname")
+	}
+	if cfg.User == "" {
+		missing = append(missing, "user")
+	}
+	if cfg.AESKeyLocation == "" {
+		missing = append(missing, "aes_key_location")
+	}
+	if len(missing) > 0 {
+		return Config{}, fmt.Errorf("traffic_vault_config missing required fields %v", missing)
+	}
+	if cfg.Port == 0 {
+		cfg.Port = DefaultPort
+	}
+	if cfg.MaxIdleConnections == 0 {
+		cfg.MaxIdleConnections = DefaultMaxIdleConnections
+	}
+	if cfg.ConnMaxLifetimeSeconds == 0 {
+		cfg.ConnMaxLifetimeSeconds = DefaultConnMaxLifetimeSeconds
+	}
+	if cfg.QueryTimeoutSeconds == 0 {
+		cfg.QueryTimeoutSeconds = DefaultQueryTimeoutSeconds
+	}
+	return cfg, nil
+}
+
+// TrafficVault is the PostgreSQL Traffic Vault backend. Keys are stored in their own database, separate from the Traffic Ops database, encrypted with AES-GCM.
+type TrafficVault struct {
+	db           *sql.DB
+	aesKey       []byte
+	queryTimeout time.Duration
+	server       string
+}
+
+var _ trafficvault.TrafficVault = &TrafficVault{}
+
+// New returns a PostgreSQL Traffic Vault backend with the given JSON configuration.
+// The database connection is opened lazily, so New does not fail if the database is unreachable.
+func New(cfgJSON json.RawMessage) (*TrafficVault, error) {
+	cfg, err := ParseConfig(cfgJSON)
+	if err != nil {
+		return nil, err
+	}
+	aesKey, err := readAESKey(cfg.AESKeyLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	sslMode := "require"
+	if !cfg.SSL {
+		sslMode = "disable"
+	}
+	server := cfg.Hostname + ":" + strconv.Itoa(cfg.Port)
+	connURL := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     server,
+		Path:     cfg.DBName,
+		RawQuery: "sslmode=" + sslMode + "&fallback_application_name=trafficops",
+	}
+	db, err := sql.Open("postgres", connURL.String())
+	if err != nil {
+		return nil, errors.New("opening Traffic Vault database: " + err.Error())
+	}
+	db.SetMaxOpenConns(cfg.MaxConnections)
+	db.SetMaxIdleConns(cfg.MaxIdleConnections)
+	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetimeSeconds) * time.Second)
+	return newTrafficVault(db, aesKey, time.Duration(cfg.QueryTimeoutSeconds)*time.Second, server), nil
+}
+
+func newTrafficVault(db *sql.DB, aesKey []byte, queryTimeout time.Duration, server string) *TrafficVault {
+	return &TrafficVault{db: db, aesKey: aesKey, queryTimeout: queryTimeout, server: server}
+}
+
+// Close closes the connections to the Traffic Vault database.
+func (tv *TrafficVault) Close() error {
+	return tv.db.Close()
+}
+
+func (tv *TrafficVault) Name() string {
+	return trafficvault.BackendPostgres
+}
+
+func (tv *TrafficVault) ctx() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), tv.queryTimeout)
+}
+
+// getData returns the decrypted data of the single row of the given table returned by the given query, or false if it
+// returns no rows. The query must select the row's key, version (an empty string if the table's keys aren't versioned),
+// and data.
+func (tv *TrafficVault) getData(table string, query string, args ...interface{}) ([]byte, bool, error) {
+	ctx, cancel := tv.ctx()
+	defer cancel()
+	key := ""
+	version := ""
+	data := []byte{}
+	if err := tv.db.QueryRowContext(ctx, query, args...).Scan(&key, &version, &data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	plaintext, err := decrypt(data, tv.aesKey, additionalData(table, key, version))
+	if err != nil {
+		return nil, false, err
+	}
+	return plaintext, true, nil
+}
+
+// getJSON unmarshals the decrypted data of the single row of the given table returned by the given query into v, returning false if the query returns no rows.
+// The query must select the row's key, version, and data, as for getData.
+func (tv *TrafficVault) getJSON(v interface{}, table string, query string, args ...interface{}) (bool, error) {
+	data, ok, err := tv.getData(table, query, args...)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, errors.New("unmarshalling: " + err.Error())
+	}
+	return true, nil
+}
+
+// exec executes the given query.
+func (tv *TrafficVault) exec(query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := tv.ctx()
+	defer cancel()
+	return tv.db.ExecContext(ctx, query, args...)
+}
+
+// encryptJSON marshals and encrypts the given value, to be stored in the row of the given table with the given key and version.
+func (tv *TrafficVault) encryptJSON(v interface{}, table string, key string, version string) ([]byte, error) {
+	bts, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.New("marshalling: " + err.Error())
+	}
+	return encrypt(bts, tv.aesKey, additionalData(table, key, version))
+}
+
+func (tv *TrafficVault) GetDeliveryServiceSSLKeys(xmlID string, version string, tx *sql.Tx) (tc.DeliveryServiceSSLKeysV15, bool, error) {
+	if version == "" {
+		version = latestVersion
+	}
+	keys := tc.DeliveryServiceSSLKeysV15{}
+	ok, err := tv.getJSON(&keys, "sslkey", `SELECT deliveryservice, version, data FROM sslkey WHERE deliveryservice = $1 AND version = $2`, xmlID, version)
+	if err != nil {
+		return tc.DeliveryServiceSSLKeysV15{}, false, errors.New("getting SSL keys: " + err.Error())
+	}
+	return keys, ok, nil
+}
+
+func (tv *TrafficVault) PutDeliveryServiceSSLKeys(keys tc.DeliveryServiceSSLKeys, tx *sql.Tx) error {
+	ctx, cancel := tv.ctx()
+	defer cancel()
+	vtx, err := tv.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.New("beginning Traffic Vault transaction: " + err.Error())
+	}
+	defer vtx.Rollback()
+
+	q := `
+INSERT INTO sslkey (deliveryservice, cdn, version, data) VALUES ($1, $2, $3, $4)
+ON CONFLICT (deliveryservice, version) DO UPDATE SET cdn = $2, data = $4, last_updated = now()
+`
+	for _, version := range []string{keys.Version.String(), latestVersion} {
+		data, err := tv.encryptJSON(keys, "sslkey", keys.DeliveryService, version)
+		if err != nil {
+			return errors.New("encrypting SSL keys version '" + version + "': " + err.Error())
+		}
+		if _, err := vtx.ExecContext(ctx, q, keys.DeliveryService, keys.CDN, version, data); err != nil {
+			return errors.New("storing SSL keys version '" + version + "': " + err.Error())
+		}
+	}
+	if err := vtx.Commit(); err != nil {
+		return errors.New("committing Traffic Vault transaction: " + err.Error())
+	}
+	return nil
+}
+
+func (tv *TrafficVault) DeleteDeliveryServiceSSLKeys(xmlID string, version string, tx *sql.Tx) error {
+	if version == "" {
+		version = latestVersion
+	}
+	if _, err := tv.exec(`DELETE FROM sslkey WHERE deliveryservice = $1 AND version = $2`, xmlID, version); err != nil {
+		return errors.New("deleting SSL keys: " + err.Error())
+	}
+	return nil
+}
+
+func (tv *TrafficVault) DeleteOldDeliveryServiceSSLKeys(existingXMLIDs map[tc.DeliveryServiceName]struct{}, cdn tc.CDNName, tx *sql.Tx) error {
+	xmlIDs := make([]string, 0, len(existingXMLIDs))
+	for xmlID := range existingXMLIDs {
+		xmlIDs = append(xmlIDs, string(xmlID))
+	}
+	result, err := tv.exec(`DELETE FROM sslkey WHERE cdn = $1 AND NOT (deliveryservice = ANY($2::text[]))`, cdn, pq.Array(xmlIDs))
+	if err != nil {
+		return errors.New("deleting SSL keys of deleted delivery services: " + err.Error())
+	}
+	if deleted, err := result.RowsAffected(); err == nil && deleted > 0 {
+		log.Infof("Deleted %d Traffic Vault SSL keys of delivery services in CDN '%s' which have been deleted in the database\n", deleted, cdn)
+	}
+	return nil
+}
+
+func (tv *TrafficVault) GetCDNSSLKeys(cdn string, tx *sql.Tx) ([]tc.CDNSSLKey, error) {
+	ctx, cancel := tv.ctx()
+	defer cancel()
+	rows, err := tv.db.QueryContext(ctx, `SELECT deliveryservice, version, data FROM sslkey WHERE cdn = $1 AND version = $2 ORDER BY deliveryservice`, cdn, latestVersion)
+	if err != nil {
+		return nil, errors.New("querying CDN SSL keys: " + err.Error())
+	}
+	defer rows.Close()
+
+	keys := []tc.CDNSSLKey{}
+	for rows.Next() {
+		xmlID := ""
+		version := ""
+		data := []byte{}
+		if err := rows.Scan(&xmlID, &version, &data); err != nil {
+			return nil, errors.New("scanning CDN SSL keys: " + err.Error())
+		}
+		plaintext, err := decrypt(data, tv.aesKey, additionalData("sslkey", xmlID, version))
+		if err != nil {
+			return nil, errors.New("decrypting CDN SSL keys: " + err.Error())
+		}
+		dsKeys := tc.DeliveryServiceSSLKeys{}
+		if err := json.Unmarshal(plaintext, &dsKeys); err != nil {
+			return nil, errors.New("unmarshalling CDN SSL keys: " + err.Error())
+		}
+		keys = append(keys, tc.CDNSSLKey{
+			DeliveryService: dsKeys.DeliveryService,
+			HostName:        dsKeys.Hostname,
+			Certificate:     tc.CDNSSLKeyCert{Crt: dsKeys.Certificate.Crt, Key: dsKeys.Certificate.Key},
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating CDN SSL keys: " + err.Error())
+	}
+	return keys, nil
+}
+
+func (tv *TrafficVault) GetDeliveryServiceSSLKeysCSR(xmlID string, tx *sql.Tx) (tc.DeliveryServiceSSLKeysPendingCSR, bool, error) {
+	csr := tc.DeliveryServiceSSLKeysPendingCSR{}
+	ok, err := tv.getJSON(&csr, "sslkey_csr", `SELECT deliveryservice, '', data FROM sslkey_csr WHERE deliveryservice = $1`, xmlID)
+	if err != nil {
+		return tc.DeliveryServiceSSLKeysPendingCSR{}, false, errors.New("getting SSL keys CSR: " + err.Error())
+	}
+	return csr, ok, nil
+}
+
+func (tv *TrafficVault) PutDeliveryServiceSSLKeysCSR(csr tc.DeliveryServiceSSLKeysPendingCSR, tx *sql.Tx) error {
+	data, err := tv.encryptJSON(csr, "sslkey_csr", csr.DeliveryService, "")
+	if err != nil {
+		return errors.New("encrypting SSL keys CSR: " + err.Error())
+	}
+	q := `INSERT INTO sslkey_csr (deliveryservice, data) VALUES ($1, $2) ON CONFLICT (deliveryservice) DO UPDATE SET data = $2, last_updated = now()`
+	if _, err := tv.exec(q, csr.DeliveryService, data); err != nil {
+		return errors.New("storing SSL keys CSR: " + err.Error())
+	}
+	return nil
+}
+
+func (tv *TrafficVault) DeleteDeliveryServiceSSLKeysCSR(xmlID string, tx *sql.Tx) error {
+	if _, err := tv.exec(`DELETE FROM sslkey_csr WHERE deliveryservice = $1`, xmlID); err != nil {
+		return errors.New("deleting SSL keys CSR: " + err.Error())
+	}
+	return nil
+}
+
+func (tv *TrafficVault) GetDNSSECKeys(cdn string, tx *sql.Tx) (tc.DNSSECKeysRiak, bool, error) {
+	keys := tc.DNSSECKeysRiak{}
+	ok, err := tv.getJSON(&keys, "dnssec", `SELECT cdn, '', data FROM dnssec WHERE cdn = $1`, cdn)
+	if err != nil {
+		return nil, false, errors.New("getting DNSSEC keys: " + err.Error())
+	}
+	return keys, ok, nil
+}
+
+func (tv *TrafficVault) PutDNSSECKeys(cdn string, keys tc.DNSSECKeysRiak, tx *sql.Tx) error {
+	data, err := tv.encryptJSON(keys, "dnssec", cdn, "")
+	if err != nil {
+		return errors.New("encrypting DNSSEC keys: " + err.Error())
+	}
+	q := `INSERT INTO dnssec (cdn, data) VALUES ($1, $2) ON CONFLICT (cdn) DO UPDATE SET data = $2, last_updated = now()`
+	if _, err := tv.exec(q, cdn, data); err != nil {
+		return errors.New("storing DNSSEC keys: " + err.Error())
+	}
+	return nil
+}
+
+func (tv *TrafficVault) DeleteDNSSECKeys(cdn string, tx *sql.Tx) error {
+	if _, err := tv.exec(`DELETE FROM dnssec WHERE cdn = $1`, cdn); err != nil {
+		return errors.New("deleting DNSSEC keys: " + err.Error())
+	}
+	return nil
+}
+
+func (tv *TrafficVault) GetURLSigKeys(ds tc.DeliveryServiceName, tx *sql.Tx) (tc.URLSigKeys, bool, error) {
+	keys := tc.URLSigKeys{}
+	ok, err := tv.getJSON(&keys, "url_sig_key", `SELECT deliveryservice, '', data FROM url_sig_key WHERE deliveryservice = $1`, ds)
+	if err != nil {
+		return nil, false, errors.New("getting URL Sig keys: " + err.Error())
+	}
+	return keys, ok, nil
+}
+
+func (tv *TrafficVault) PutURLSigKeys(ds tc.DeliveryServiceName, keys tc.URLSigKeys, tx *sql.Tx) error {
+	data, err := tv.encryptJSON(keys, "url_sig_key", string(ds), "")
+	if err != nil {
+		return errors.New("encrypting URL Sig keys: " + err.Error())
+	}
+	q := `INSERT INTO url_sig_key (deliveryservice, data) VALUES ($1, $2) ON CONFLICT (deliveryservice) DO UPDATE SET data = $2, last_updated = now()`
+	if _, err := tv.exec(q, ds, data); err != nil {
+		return errors.New("storing URL Sig keys: " + err.Error())
+	}
+	return nil
+}
+
+func (tv *TrafficVault) GetURISigningKeys(xmlID string, tx *sql.Tx) ([]byte, bool, error) {
+	keys, ok, err := tv.getData("uri_signing_key", `SELECT deliveryservice, '', data FROM uri_signing_key WHERE deliveryservice = $1`, xmlID)
+	if err != nil {
+		return nil, false, errors.New("getting URI Signing keys: " + err.Error())
+	}
+	return keys, ok, nil
+}
+
+func (tv *TrafficVault) PutURISigningKeys(xmlID string, keys []byte, tx *sql.Tx) error {
+	data, err := encrypt(keys, tv.aesKey, additionalData("uri_signing_key", xmlID, ""))
+	if err != nil {
+		return errors.New("encrypting URI Signing keys: " + err.Error())
+	}
+	q := `INSERT INTO uri_signing_key (deliveryservice, data) VALUES ($1, $2) ON CONFLICT (deliveryservice) DO UPDATE SET data = $2, last_updated = now()`
+	if _, err := tv.exec(q, xmlID, data); err != nil {
+		return errors.New("storing URI Signing keys: " + err.Error())
+	}
+	return nil
+}
+
+func (tv *TrafficVault) DeleteURISigningKeys(xmlID string, tx *sql.Tx) error {
+	if _, err := tv.exec(`DELETE FROM uri_signing_key WHERE deliveryservice = $1`, xmlID); err != nil {
+		return errors.New("deleting URI Signing keys: " + err.Error())
+	}
+	return nil
+}
+
+// GetBucketKey returns the value which the Riak backend would store under the given bucket and key. Keys of buckets which Traffic Ops doesn't use are never found.
+func (tv *TrafficVault) GetBucketKey(bucket string, key string, tx *sql.Tx) ([]byte, bool, error) {
+	table := ""
+	q := ""
+	switch bucket {
+	case riaksvc.DeliveryServiceSSLKeysBucket:
+		table, q = "sslkey", `SELECT deliveryservice, version, data FROM sslkey WHERE deliveryservice || '-' || version = $1`
+	case riaksvc.DNSSECKeysBucket:
+		table, q = "dnssec", `SELECT cdn, '', data FROM dnssec WHERE cdn = $1`
+	case riaksvc.URLSigKeysBucket:
+		table, q = "url_sig_key", `SELECT deliveryservice, '', data FROM url_sig_key WHERE 'url_sig_' || deliveryservice || '.config' = $1`
+	case riaksvc.URISigningKeysBucket:
+		table, q = "uri_signing_key", `SELECT deliveryservice, '', data FROM uri_signing_key WHERE deliveryservice = $1`
+	case riaksvc.DeliveryServiceSSLKeysCSRBucket:
+		table, q = "sslkey_csr", `SELECT deliveryservice, '', data FROM sslkey_csr WHERE deliveryservice = $1`
+	default:
+		return nil, false, nil
+	}
+	val, ok, err := tv.getData(table, q, key)
+	if err != nil {
+		return nil, false, errors.New("getting bucket '" + bucket + "' key '" + key + "': " + err.Error())
+	}
+	return val, ok, nil
+}
+
+func (tv *TrafficVault) Ping(tx *sql.Tx) (tc.RiakPingResp, error) {
+	ctx, cancel := tv.ctx()
+	defer cancel()
+	if err := tv.db.PingContext(ctx); err != nil {
+		return tc.RiakPingResp{}, errors.New("pinging Traffic Vault database: " + err.Error())
+	}
+	return tc.RiakPingResp{Status: "OK", Server: tv.server}, nil
+}
@@ -0,0 +1,240 @@
+package postgres
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/riaksvc"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+var testAESKey = []byte("0123456789abcdef")
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig(json.RawMessage(`{"dbname":"tv","hostname":"db.example.net","user":"tv","password":"pw","aes_key_location":"/etc/aes.key"}`))
+	if err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+	if cfg.Port != DefaultPort {
+		t.Errorf("expected default port %d, actual %d", DefaultPort, cfg.Port)
+	}
+	if cfg.QueryTimeoutSeconds != DefaultQueryTimeoutSeconds {
+		t.Errorf("expected default query timeout %d, actual %d", DefaultQueryTimeoutSeconds, cfg.QueryTimeoutSeconds)
+	}
+
+	if _, err := ParseConfig(json.RawMessage(`{"dbname":"tv","hostname":"db.example.net"}`)); err == nil {
+		t.Errorf("missing required fields: expected error, actual: nil")
+	}
+	if _, err := ParseConfig(nil); err == nil {
+		t.Errorf("empty config: expected error, actual: nil")
+	}
+}
+
+// encryptedMatcher matches query arguments which decrypt, with the given additional data, to the expected plaintext JSON.
+type encryptedMatcher struct {
+	t        *testing.T
+	aad      []byte
+	expected interface{}
+}
+
+func (m encryptedMatcher) Match(v driver.Value) bool {
+	bts, ok := v.([]byte)
+	if !ok {
+		return false
+	}
+	plaintext, err := decrypt(bts, testAESKey, m.aad)
+	if err != nil {
+		m.t.Errorf("decrypting stored data: %v", err)
+		return false
+	}
+	expected, err := json.Marshal(m.expected)
+	if err != nil {
+		m.t.Errorf("marshalling expected data: %v", err)
+		return false
+	}
+	return string(plaintext) == string(expected)
+}
+
+func TestGetDNSSECKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tv := newTrafficVault(db, testAESKey, time.Second, "db.example.net:5432")
+
+	keys := tc.DNSSECKeysRiak{"mycdn": tc.DNSSECKeySetV11{}}
+	bts, err := json.Marshal(keys)
+	if err != nil {
+		t.Fatalf("marshalling: %v", err)
+	}
+	data, err := encrypt(bts, testAESKey, additionalData("dnssec", "mycdn", ""))
+	if err != nil {
+		t.Fatalf("encrypting: %v", err)
+	}
+
+	mock.ExpectQuery("SELECT cdn, '', data FROM dnssec").WithArgs("mycdn").WillReturnRows(sqlmock.NewRows([]string{"cdn", "version", "data"}).AddRow("mycdn", "", data))
+	actual, ok, err := tv.GetDNSSECKeys("mycdn", nil)
+	if err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected keys to be found")
+	}
+	if _, ok := actual["mycdn"]; !ok {
+		t.Errorf("expected keys for 'mycdn', actual: %+v", actual)
+	}
+
+	mock.ExpectQuery("SELECT cdn, '', data FROM dnssec").WithArgs("othercdn").WillReturnRows(sqlmock.NewRows([]string{"cdn", "version", "data"}))
+	if _, ok, err := tv.GetDNSSECKeys("othercdn", nil); err != nil || ok {
+		t.Errorf("expected not found and nil error, actual: %v %v", ok, err)
+	}
+
+	// mycdn's keys, copied to othercdn's row, can't be decrypted.
+	mock.ExpectQuery("SELECT cdn, '', data FROM dnssec").WithArgs("othercdn").WillReturnRows(sqlmock.NewRows([]string{"cdn", "version", "data"}).AddRow("othercdn", "", data))
+	if _, _, err := tv.GetDNSSECKeys("othercdn", nil); err == nil {
+		t.Errorf("expected an error decrypting keys moved from another row, actual: nil")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestPutDeliveryServiceSSLKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tv := newTrafficVault(db, testAESKey, time.Second, "db.example.net:5432")
+
+	keys := tc.DeliveryServiceSSLKeys{
+		CDN:             "mycdn",
+		DeliveryService: "myds",
+		Hostname:        "myds.mycdn.example.net",
+		Version:         util.JSONIntStr(3),
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO sslkey").WithArgs("myds", "mycdn", "3", encryptedMatcher{t: t, aad: additionalData("sslkey", "myds", "3"), expected: keys}).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO sslkey").WithArgs("myds", "mycdn", latestVersion, encryptedMatcher{t: t, aad: additionalData("sslkey", "myds", latestVersion), expected: keys}).WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	if err := tv.PutDeliveryServiceSSLKeys(keys, nil); err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDeliveryServiceSSLKeysCSR(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tv := newTrafficVault(db, testAESKey, time.Second, "db.example.net:5432")
+
+	csr := tc.DeliveryServiceSSLKeysPendingCSR{
+		DeliveryServiceSSLKeysCSR: tc.DeliveryServiceSSLKeysCSR{
+			DeliveryService: "myds",
+			CDN:             "mycdn",
+			KeyAlgorithm:    tc.SSLKeyAlgorithmECDSAP256,
+			CSR:             "csr",
+		},
+		Key: "key",
+	}
+	match := encryptedMatcher{t: t, aad: additionalData("sslkey_csr", "myds", ""), expected: csr}
+	mock.ExpectExec("INSERT INTO sslkey_csr").WithArgs("myds", match).WillReturnResult(sqlmock.NewResult(1, 1))
+	if err := tv.PutDeliveryServiceSSLKeysCSR(csr, nil); err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+
+	bts, err := json.Marshal(csr)
+	if err != nil {
+		t.Fatalf("marshalling: %v", err)
+	}
+	data, err := encrypt(bts, testAESKey, additionalData("sslkey_csr", "myds", ""))
+	if err != nil {
+		t.Fatalf("encrypting: %v", err)
+	}
+	mock.ExpectQuery("SELECT deliveryservice, '', data FROM sslkey_csr").WithArgs("myds").WillReturnRows(sqlmock.NewRows([]string{"deliveryservice", "version", "data"}).AddRow("myds", "", data))
+	actual, ok, err := tv.GetDeliveryServiceSSLKeysCSR("myds", nil)
+	if err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected CSR to be found")
+	}
+	if actual.Key != "key" || actual.KeyAlgorithm != tc.SSLKeyAlgorithmECDSAP256 {
+		t.Errorf("expected stored CSR, actual: %+v", actual)
+	}
+
+	mock.ExpectExec("DELETE FROM sslkey_csr").WithArgs("myds").WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := tv.DeleteDeliveryServiceSSLKeysCSR("myds", nil); err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetBucketKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+	tv := newTrafficVault(db, testAESKey, time.Second, "db.example.net:5432")
+
+	data, err := encrypt([]byte(`{"key0":"abc"}`), testAESKey, additionalData("url_sig_key", "myds", ""))
+	if err != nil {
+		t.Fatalf("encrypting: %v", err)
+	}
+	mock.ExpectQuery("SELECT deliveryservice, '', data FROM url_sig_key").WithArgs("url_sig_myds.config").WillReturnRows(sqlmock.NewRows([]string{"deliveryservice", "version", "data"}).AddRow("myds", "", data))
+
+	val, ok, err := tv.GetBucketKey(riaksvc.URLSigKeysBucket, "url_sig_myds.config", nil)
+	if err != nil {
+		t.Fatalf("expected nil error, actual: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected key to be found")
+	}
+	if string(val) != `{"key0":"abc"}` {
+		t.Errorf("expected decrypted value, actual '%s'", val)
+	}
+
+	if _, ok, err := tv.GetBucketKey("unknown_bucket", "key", nil); err != nil || ok {
+		t.Errorf("unknown bucket: expected not found and nil error, actual: %v %v", ok, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,87 @@
+package trafficvault
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// These are the names of the supported Traffic Vault backends, as given by the traffic_vault_backend cdn.conf option.
+const (
+	BackendRiak     = "riak"
+	BackendPostgres = "postgres"
+)
+
+// ErrNotConfigured is returned by every operation of a disabled Traffic Vault.
+var ErrNotConfigured = errors.New("Traffic Vault is not configured")
+
+// TrafficVault is the storage of all secrets managed by Traffic Ops: Delivery Service SSL keys and pending certificate signing requests, CDN DNSSEC keys, and Delivery Service URL Sig and URI Signing keys.
+//
+// Every operation takes the Traffic Ops database transaction of the request, which a backend may use to look up information about itself (e.g. the Riak backend gets its servers from the Traffic Ops database). Backends with their own database do not take part in the transaction, so writes to Traffic Vault are not rolled back if the request fails after they are made.
+type TrafficVault interface {
+	// Name returns the name of the backend, one of the Backend constants.
+	Name() string
+
+	// GetDeliveryServiceSSLKeys returns the SSL keys of the given version of the given Delivery Service. If version is empty, the latest keys are returned.
+	// If the keys do not exist, false is returned.
+	GetDeliveryServiceSSLKeys(xmlID string, version string, tx *sql.Tx) (tc.DeliveryServiceSSLKeysV15, bool, error)
+	// PutDeliveryServiceSSLKeys stores the given SSL keys as both their own version and the latest version of their Delivery Service.
+	PutDeliveryServiceSSLKeys(keys tc.DeliveryServiceSSLKeys, tx *sql.Tx) error
+	// DeleteDeliveryServiceSSLKeys deletes the SSL keys of the given version of the given Delivery Service. If version is empty, the latest keys are deleted.
+	DeleteDeliveryServiceSSLKeys(xmlID string, version string, tx *sql.Tx) error
+	// DeleteOldDeliveryServiceSSLKeys deletes every version of the SSL keys of every Delivery Service in the given CDN which is not in existingXMLIDs.
+	DeleteOldDeliveryServiceSSLKeys(existingXMLIDs map[tc.DeliveryServiceName]struct{}, cdn tc.CDNName, tx *sql.Tx) error
+	// GetCDNSSLKeys returns the latest SSL keys of every Delivery Service in the given CDN.
+	GetCDNSSLKeys(cdn string, tx *sql.Tx) ([]tc.CDNSSLKey, error)
+
+	// GetDeliveryServiceSSLKeysCSR returns the pending certificate signing request and private key of the given Delivery Service. If there is none, false is returned.
+	GetDeliveryServiceSSLKeysCSR(xmlID string, tx *sql.Tx) (tc.DeliveryServiceSSLKeysPendingCSR, bool, error)
+	// PutDeliveryServiceSSLKeysCSR stores the pending certificate signing request and private key of its Delivery Service, replacing any existing one. Pending CSRs are not part of the Delivery Service's SSL keys.
+	PutDeliveryServiceSSLKeysCSR(csr tc.DeliveryServiceSSLKeysPendingCSR, tx *sql.Tx) error
+	// DeleteDeliveryServiceSSLKeysCSR deletes the pending certificate signing request of the given Delivery Service.
+	DeleteDeliveryServiceSSLKeysCSR(xmlID string, tx *sql.Tx) error
+
+	// GetDNSSECKeys returns the DNSSEC keys of the given CDN. If the keys do not exist, false is returned.
+	GetDNSSECKeys(cdn string, tx *sql.Tx) (tc.DNSSECKeysRiak, bool, error)
+	// PutDNSSECKeys stores the DNSSEC keys of the given CDN, replacing any existing keys.
+	PutDNSSECKeys(cdn string, keys tc.DNSSECKeysRiak, tx *sql.Tx) error
+	// DeleteDNSSECKeys deletes the DNSSEC keys of the given CDN.
+	DeleteDNSSECKeys(cdn string, tx *sql.Tx) error
+
+	// GetURLSigKeys returns the URL Sig keys of the given Delivery Service. If the keys do not exist, false is returned.
+	GetURLSigKeys(ds tc.DeliveryServiceName, tx *sql.Tx) (tc.URLSigKeys, bool, error)
+	// PutURLSigKeys stores the URL Sig keys of the given Delivery Service, replacing any existing keys.
+	PutURLSigKeys(ds tc.DeliveryServiceName, keys tc.URLSigKeys, tx *sql.Tx) error
+
+	// GetURISigningKeys returns the serialized URI Signing keys of the given Delivery Service. If the keys do not exist, false is returned.
+	GetURISigningKeys(xmlID string, tx *sql.Tx) ([]byte, bool, error)
+	// PutURISigningKeys stores the serialized URI Signing keys of the given Delivery Service, replacing any existing keys.
+	PutURISigningKeys(xmlID string, keys []byte, tx *sql.Tx) error
+	// DeleteURISigningKeys deletes the URI Signing keys of the given Delivery Service.
+	DeleteURISigningKeys(xmlID string, tx *sql.Tx) error
+
+	// GetBucketKey returns the raw, serialized value stored under the given key of the given Riak bucket, or the equivalent value of a backend which isn't Riak. If the value does not exist, false is returned.
+	GetBucketKey(bucket string, key string, tx *sql.Tx) ([]byte, bool, error)
+	// Ping checks that the backend is reachable, returning the server which responded.
+	Ping(tx *sql.Tx) (tc.RiakPingResp, error)
+}
@@ -0,0 +1,289 @@
+package user
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+// tokenAuditObjectType is the object type of API tokens in the audit log.
+const tokenAuditObjectType = "apiToken"
+
+const selectTokensQuery = `
+SELECT t.id,
+	t.name,
+	t.role,
+	r.name AS role_name,
+	t.capabilities,
+	t.expires_at,
+	t.last_used,
+	t.created_at
+FROM api_token AS t
+JOIN role AS r ON r.id = t.role
+WHERE t.tm_user = $1
+ORDER BY t.name
+`
+
+const insertTokenQuery = `
+INSERT INTO api_token (tm_user, name, token_hash, role, capabilities, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, created_at
+`
+
+// GetTokens is the handler for GET requests to /user/tokens, which returns the API tokens of the current user or, for
+// administrators, of the user named by the "username" query parameter.
+func GetTokens(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	userID := inf.User.ID
+	if username, ok := inf.Params["username"]; ok && username != inf.User.UserName {
+		if inf.User.PrivLevel < auth.PrivLevelAdmin {
+			api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("only administrators may view the API tokens of other users"), nil)
+			return
+		}
+		id, tenantID, ok, err := getUserIDAndTenant(tx, username)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no user named '%s'", username), nil)
+			return
+		}
+		if authorized, err := tenant.IsResourceAuthorizedToUserTx(tenantID, inf.User, tx); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking user tenant: "+err.Error()))
+			return
+		} else if !authorized {
+			api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("not authorized on this tenant"), nil)
+			return
+		}
+		userID = id
+	}
+
+	tokens, err := getTokens(tx, userID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, tokens)
+}
+
+// CreateToken is the handler for POST requests to /user/tokens. The new token may be scoped to any Role with a
+// privilege level no greater than the current user's, and to any subset of the current user's Capabilities - which, if
+// their Role has none, are those equivalent to its privilege level. The token itself is only ever returned in the
+// response.
+func CreateToken(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var tok tc.APIToken
+	if userErr = api.Parse(r.Body, tx, &tok); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if tok.Role == nil {
+		tok.Role = util.IntPtr(inf.User.Role)
+	}
+	userCapabilities := []string(inf.User.Capabilities)
+	if !inf.User.Scoped {
+		userCapabilities = auth.RoleCapabilities(inf.User.Capabilities, inf.User.PrivLevel, inf.Config.CapabilityPrivLevels)
+	}
+	if tok.Capabilities == nil {
+		tok.Capabilities = append([]string{}, userCapabilities...)
+	}
+
+	roleName, privLevel, ok, err := getRole(tx, *tok.Role)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("no role with id %d", *tok.Role), nil)
+		return
+	}
+	if privLevel > inf.User.PrivLevel {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("cannot create a token with a role of a higher privilege level than your own"), nil)
+		return
+	}
+	if missing := missingCapabilities(tok.Capabilities, userCapabilities); len(missing) > 0 {
+		api.HandleErr(w, r, tx, http.StatusForbidden, fmt.Errorf("cannot create a token with capabilities you do not have: %v", missing), nil)
+		return
+	}
+	tok.RoleName = &roleName
+
+	secret, err := auth.GenerateAPIToken()
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("generating API token: "+err.Error()))
+		return
+	}
+
+	tok.ID = new(int)
+	tok.CreatedAt = new(time.Time)
+	if err := tx.QueryRow(insertTokenQuery, inf.User.ID, tok.Name, auth.HashAPIToken(secret), tok.Role, pq.Array(tok.Capabilities), tok.ExpiresAt).Scan(tok.ID, tok.CreatedAt); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	audit := api.Audit{Action: api.Created, ObjectType: tokenAuditObjectType, ObjectID: strconv.Itoa(*tok.ID), TenantID: &inf.User.TenantID, After: tok}
+	if err := api.CreateAuditLog(api.ApiChange, "API TOKEN: "+*tok.Name+", ID: "+strconv.Itoa(*tok.ID)+", USER: "+inf.User.UserName+", ACTION: Created", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	tok.Token = &secret
+	api.WriteAlertsObj(w, r, http.StatusCreated, tc.CreateAlerts(tc.SuccessLevel, "API token was created. It will not be shown again, so store it securely."), tok)
+}
+
+// DeleteToken is the handler for DELETE requests to /user/tokens/{id}, which revokes an API token. Users may revoke
+// their own tokens, and administrators may revoke anyone's.
+func DeleteToken(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	var name string
+	var ownerID int
+	var ownerTenantID int
+	err := tx.QueryRow(`
+SELECT t.name, u.id, u.tenant_id
+FROM api_token AS t
+JOIN tm_user AS u ON u.id = t.tm_user
+WHERE t.id = $1
+`, id).Scan(&name, &ownerID, &ownerTenantID)
+	if err == sql.ErrNoRows {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no API token with id %d", id), nil)
+		return
+	} else if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying API token: "+err.Error()))
+		return
+	}
+	if ownerID != inf.User.ID {
+		if inf.User.PrivLevel < auth.PrivLevelAdmin {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no API token with id %d", id), nil)
+			return
+		}
+		if authorized, err := tenant.IsResourceAuthorizedToUserTx(ownerTenantID, inf.User, tx); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking user tenant: "+err.Error()))
+			return
+		} else if !authorized {
+			api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("not authorized on this tenant"), nil)
+			return
+		}
+	}
+
+	if _, err := tx.Exec(`DELETE FROM api_token WHERE id = $1`, id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	audit := api.Audit{Action: api.Deleted, ObjectType: tokenAuditObjectType, ObjectID: strconv.Itoa(id), TenantID: &ownerTenantID}
+	if err := api.CreateAuditLog(api.ApiChange, "API TOKEN: "+name+", ID: "+strconv.Itoa(id)+", ACTION: Revoked", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "API token was revoked.")
+}
+
+// getTokens returns the API tokens of the user with the given ID.
+func getTokens(tx *sql.Tx, userID int) ([]tc.APIToken, error) {
+	rows, err := tx.Query(selectTokensQuery, userID)
+	if err != nil {
+		return nil, errors.New("querying API tokens: " + err.Error())
+	}
+	defer rows.Close()
+
+	tokens := []tc.APIToken{}
+	for rows.Next() {
+		tok := tc.APIToken{}
+		if err := rows.Scan(&tok.ID, &tok.Name, &tok.Role, &tok.RoleName, pq.Array(&tok.Capabilities), &tok.ExpiresAt, &tok.LastUsed, &tok.CreatedAt); err != nil {
+			return nil, errors.New("scanning API tokens: " + err.Error())
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens, nil
+}
+
+// getUserIDAndTenant returns the ID and Tenant ID of the user with the given name, and whether the user exists.
+func getUserIDAndTenant(tx *sql.Tx, username string) (int, int, bool, error) {
+	id := 0
+	tenantID := 0
+	if err := tx.QueryRow(`SELECT id, tenant_id FROM tm_user WHERE username = $1`, username).Scan(&id, &tenantID); err == sql.ErrNoRows {
+		return 0, 0, false, nil
+	} else if err != nil {
+		return 0, 0, false, errors.New("querying user: " + err.Error())
+	}
+	return id, tenantID, true, nil
+}
+
+// getRole returns the name and privilege level of the Role with the given ID, and whether the Role exists.
+func getRole(tx *sql.Tx, id int) (string, int, bool, error) {
+	name := ""
+	privLevel := 0
+	if err := tx.QueryRow(`SELECT name, priv_level FROM role WHERE id = $1`, id).Scan(&name, &privLevel); err == sql.ErrNoRows {
+		return "", 0, false, nil
+	} else if err != nil {
+		return "", 0, false, errors.New("querying role: " + err.Error())
+	}
+	return name, privLevel, true, nil
+}
+
+// missingCapabilities returns the requested Capabilities which aren't in have.
+func missingCapabilities(requested []string, have []string) []string {
+	haveSet := make(map[string]struct{}, len(have))
+	for _, c := range have {
+		haveSet[c] = struct{}{}
+	}
+	missing := []string{}
+	for _, c := range requested {
+		if _, ok := haveSet[c]; !ok {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
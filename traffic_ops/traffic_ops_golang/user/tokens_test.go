@@ -0,0 +1,40 @@
+package user
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissingCapabilities(t *testing.T) {
+	have := []string{"servers-read", "servers-write", "cdns-read"}
+
+	if missing := missingCapabilities([]string{"servers-read", "cdns-read"}, have); len(missing) != 0 {
+		t.Errorf("expected no missing capabilities, got %v", missing)
+	}
+	if missing := missingCapabilities(nil, have); len(missing) != 0 {
+		t.Errorf("expected no missing capabilities for an empty request, got %v", missing)
+	}
+	expected := []string{"cdns-write", "users-write"}
+	if missing := missingCapabilities([]string{"servers-read", "cdns-write", "users-write"}, have); !reflect.DeepEqual(missing, expected) {
+		t.Errorf("expected missing capabilities %v, got %v", expected, missing)
+	}
+}
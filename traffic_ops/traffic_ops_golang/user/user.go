@@ -170,6 +170,7 @@ func (user *TOUser) Create() (error, error, int) {
 	user.Tenant = &tenant
 	user.RoleName = &rolename
 	user.LocalPassword = nil
+	user.ConfirmLocalPassword = nil
 
 	return nil, nil, http.StatusOK
 }
@@ -293,6 +294,7 @@ func (user *TOUser) Update(h http.Header) (error, error, int) {
 	user.Tenant = &tenant
 	user.RoleName = &rolename
 	user.LocalPassword = nil
+	user.ConfirmLocalPassword = nil
 
 	if rowsAffected != 1 {
 		if rowsAffected < 1 {
@@ -0,0 +1,239 @@
+package webhook
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// deliveryBatchSize is the maximum number of events claimed for delivery at once.
+const deliveryBatchSize = 100
+
+// MinRetryInterval is the time to wait before the first retry of a failed delivery. Each subsequent retry waits twice as long as the previous one, up to MaxRetryInterval.
+const MinRetryInterval = 10 * time.Second
+
+// MaxRetryInterval is the longest time to wait before retrying a failed delivery.
+const MaxRetryInterval = time.Hour
+
+// maxErrorBodyLen is the maximum number of bytes of a webhook's error response to record in the attempt log.
+const maxErrorBodyLen = 512
+
+// claimQuery claims the pending deliveries to active webhooks which are due, by pushing their next attempt past the time it takes to attempt them, so other Traffic Ops instances don't deliver them at the same time.
+const claimQuery = `
+UPDATE webhook_delivery AS d
+SET next_attempt = now() + $1 * interval '1 second'
+FROM webhook AS w
+WHERE d.webhook_id = w.id
+AND d.id IN (
+	SELECT dd.id
+	FROM webhook_delivery AS dd
+	JOIN webhook AS ww ON ww.id = dd.webhook_id
+	WHERE dd.status = 'pending'
+	AND dd.next_attempt <= now()
+	AND ww.active
+	ORDER BY dd.next_attempt
+	LIMIT $2
+	FOR UPDATE OF dd SKIP LOCKED
+)
+RETURNING d.id, d.event, d.payload, d.attempts, w.url, w.secret
+`
+
+// delivery is an event claimed for delivery to a webhook.
+type delivery struct {
+	ID       int64
+	Event    string
+	Payload  []byte
+	Attempts int
+	URL      string
+	Secret   *string
+}
+
+// StartDelivery starts delivering the events queued for webhooks, polling for them at the configured interval until the process exits.
+func StartDelivery(db *sql.DB, cfg config.Config) {
+	client := &http.Client{Timeout: time.Duration(cfg.WebhookTimeoutSeconds) * time.Second}
+	interval := time.Duration(cfg.WebhookPollIntervalSeconds) * time.Second
+	go func() {
+		for {
+			if err := deliverPending(db, client, cfg.WebhookMaxAttempts); err != nil {
+				log.Errorln("delivering webhook events: " + err.Error())
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// deliverPending attempts to deliver every event which is due for delivery.
+func deliverPending(db *sql.DB, client *http.Client, maxAttempts int) error {
+	for {
+		deliveries, err := claimPending(db, client.Timeout)
+		if err != nil {
+			return err
+		}
+		for _, d := range deliveries {
+			if err := attempt(db, client, d, maxAttempts); err != nil {
+				log.Errorf("recording attempt to deliver webhook event %d: %v\n", d.ID, err)
+			}
+		}
+		if len(deliveries) < deliveryBatchSize {
+			return nil
+		}
+	}
+}
+
+// claimPending claims up to deliveryBatchSize deliveries which are due, for the given lease time.
+func claimPending(db *sql.DB, lease time.Duration) ([]delivery, error) {
+	// the lease is doubled, so a delivery doesn't expire while its attempt is being recorded
+	rows, err := db.Query(claimQuery, int64(2*lease/time.Second)+1, deliveryBatchSize)
+	if err != nil {
+		return nil, errors.New("claiming webhook deliveries: " + err.Error())
+	}
+	defer log.Close(rows, "closing webhook delivery rows")
+
+	deliveries := []delivery{}
+	for rows.Next() {
+		d := delivery{}
+		if err := rows.Scan(&d.ID, &d.Event, &d.Payload, &d.Attempts, &d.URL, &d.Secret); err != nil {
+			return nil, errors.New("scanning webhook deliveries: " + err.Error())
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("reading webhook deliveries: " + err.Error())
+	}
+	return deliveries, nil
+}
+
+// attempt makes an attempt to deliver the given event, and records it.
+func attempt(db *sql.DB, client *http.Client, d delivery, maxAttempts int) error {
+	start := time.Now()
+	statusCode, deliverErr := deliver(client, d)
+	duration := time.Since(start)
+
+	attempts := d.Attempts + 1
+	status, nextAttempt := nextState(attempts, maxAttempts, deliverErr == nil, start)
+	if deliverErr != nil {
+		log.Warnf("attempt %d to deliver webhook event %d to %s failed: %v\n", attempts, d.ID, d.URL, deliverErr)
+	}
+
+	errStr := (*string)(nil)
+	if deliverErr != nil {
+		str := deliverErr.Error()
+		errStr = &str
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.New("beginning transaction: " + err.Error())
+	}
+	commitTx := false
+	defer func() {
+		if !commitTx {
+			tx.Rollback()
+		}
+	}()
+	if _, err := tx.Exec(`INSERT INTO webhook_delivery_attempt (delivery_id, attempt, attempted_at, status_code, error, duration_ms) VALUES ($1, $2, $3, $4, $5, $6)`, d.ID, attempts, start, statusCode, errStr, int64(duration/time.Millisecond)); err != nil {
+		return errors.New("inserting attempt: " + err.Error())
+	}
+	if _, err := tx.Exec(`UPDATE webhook_delivery SET status = $1, attempts = $2, next_attempt = $3 WHERE id = $4`, status, attempts, nextAttempt, d.ID); err != nil {
+		return errors.New("updating delivery: " + err.Error())
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.New("committing transaction: " + err.Error())
+	}
+	commitTx = true
+	return nil
+}
+
+// deliver POSTs the given event to its webhook, returning the status code of the response, if there was one, and an error if the delivery failed.
+func deliver(client *http.Client, d delivery) (*int, error) {
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return nil, errors.New("creating request: " + err.Error())
+	}
+	req.Header.Set(rfc.ContentType, rfc.ApplicationJSON)
+	req.Header.Set(tc.WebhookEventHeader, d.Event)
+	req.Header.Set(tc.WebhookDeliveryHeader, strconv.FormatInt(d.ID, 10))
+	if d.Secret != nil && *d.Secret != "" {
+		req.Header.Set(tc.WebhookSignatureHeader, Sign(*d.Secret, d.Payload))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	statusCode := resp.StatusCode
+	if statusCode >= 200 && statusCode < 300 {
+		io.Copy(ioutil.Discard, resp.Body)
+		return &statusCode, nil
+	}
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLen))
+	msg := "unexpected response status " + resp.Status
+	if len(body) > 0 {
+		msg += ": " + string(body)
+	}
+	return &statusCode, errors.New(msg)
+}
+
+// Sign returns the value of the tc.WebhookSignatureHeader of a delivery of the given body to a webhook with the given secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// nextState returns the status of a delivery after the given number of attempts, of which the last one was made at the given time, and when the next attempt should be made.
+func nextState(attempts int, maxAttempts int, delivered bool, lastAttempt time.Time) (string, time.Time) {
+	if delivered {
+		return tc.WebhookDeliveryStatusDelivered, lastAttempt
+	}
+	if attempts >= maxAttempts {
+		return tc.WebhookDeliveryStatusFailed, lastAttempt
+	}
+	return tc.WebhookDeliveryStatusPending, lastAttempt.Add(retryInterval(attempts))
+}
+
+// retryInterval returns how long to wait before retrying a delivery after the given number of failed attempts.
+func retryInterval(attempts int) time.Duration {
+	interval := MinRetryInterval
+	for i := 1; i < attempts; i++ {
+		interval *= 2
+		if interval >= MaxRetryInterval {
+			return MaxRetryInterval
+		}
+	}
+	return interval
+}
@@ -0,0 +1,136 @@
+package webhook
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"ds.Updated"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if actual := Sign("secret", body); actual != expected {
+		t.Errorf("Sign expected: %s, actual: %s", expected, actual)
+	}
+	if Sign("other", body) == expected {
+		t.Error("Sign expected different signatures for different secrets, actual: same")
+	}
+}
+
+func TestRetryInterval(t *testing.T) {
+	expected := map[int]time.Duration{
+		1:  MinRetryInterval,
+		2:  2 * MinRetryInterval,
+		3:  4 * MinRetryInterval,
+		20: MaxRetryInterval,
+	}
+	for attempts, interval := range expected {
+		if actual := retryInterval(attempts); actual != interval {
+			t.Errorf("retryInterval(%d) expected: %v, actual: %v", attempts, interval, actual)
+		}
+	}
+}
+
+func TestNextState(t *testing.T) {
+	now := time.Now()
+
+	status, next := nextState(1, 3, true, now)
+	if status != tc.WebhookDeliveryStatusDelivered {
+		t.Errorf("delivered status expected: %s, actual: %s", tc.WebhookDeliveryStatusDelivered, status)
+	}
+
+	status, next = nextState(2, 3, false, now)
+	if status != tc.WebhookDeliveryStatusPending {
+		t.Errorf("retried status expected: %s, actual: %s", tc.WebhookDeliveryStatusPending, status)
+	}
+	if !next.Equal(now.Add(retryInterval(2))) {
+		t.Errorf("retried next attempt expected: %v, actual: %v", now.Add(retryInterval(2)), next)
+	}
+
+	status, _ = nextState(3, 3, false, now)
+	if status != tc.WebhookDeliveryStatusFailed {
+		t.Errorf("exhausted status expected: %s, actual: %s", tc.WebhookDeliveryStatusFailed, status)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	payload := []byte(`{"event":"server.Updated"}`)
+	var reqHeader http.Header
+	var reqBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqHeader = r.Header
+		reqBody, _ = ioutil.ReadAll(r.Body)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("try again"))
+		}
+	}))
+	defer srv.Close()
+
+	secret := "hunter2"
+	d := delivery{ID: 7, Event: "server.Updated", Payload: payload, URL: srv.URL, Secret: &secret}
+	statusCode, err := deliver(srv.Client(), d)
+	if err != nil {
+		t.Fatalf("deliver err expected: nil, actual: %v", err)
+	}
+	if statusCode == nil || *statusCode != http.StatusOK {
+		t.Errorf("deliver status code expected: %d, actual: %v", http.StatusOK, statusCode)
+	}
+	if string(reqBody) != string(payload) {
+		t.Errorf("delivered body expected: %s, actual: %s", payload, reqBody)
+	}
+	if actual := reqHeader.Get(tc.WebhookEventHeader); actual != d.Event {
+		t.Errorf("event header expected: %s, actual: %s", d.Event, actual)
+	}
+	if actual := reqHeader.Get(tc.WebhookDeliveryHeader); actual != "7" {
+		t.Errorf("delivery header expected: 7, actual: %s", actual)
+	}
+	if actual := reqHeader.Get(tc.WebhookSignatureHeader); actual != Sign(secret, payload) {
+		t.Errorf("signature header expected: %s, actual: %s", Sign(secret, payload), actual)
+	}
+
+	d.URL = srv.URL + "/fail"
+	d.Secret = nil
+	statusCode, err = deliver(srv.Client(), d)
+	if err == nil {
+		t.Fatal("deliver to failing webhook err expected: not nil, actual: nil")
+	}
+	if !strings.Contains(err.Error(), "try again") {
+		t.Errorf("deliver to failing webhook err expected to contain response body, actual: %v", err)
+	}
+	if statusCode == nil || *statusCode != http.StatusInternalServerError {
+		t.Errorf("deliver to failing webhook status code expected: %d, actual: %v", http.StatusInternalServerError, statusCode)
+	}
+	if actual := reqHeader.Get(tc.WebhookSignatureHeader); actual != "" {
+		t.Errorf("signature header without a secret expected: empty, actual: %s", actual)
+	}
+}
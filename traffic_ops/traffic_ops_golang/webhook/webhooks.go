@@ -0,0 +1,440 @@
+package webhook
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+// auditObjectType is the object type of webhooks in the audit log.
+const auditObjectType = "webhook"
+
+// DefaultDeliveriesLimit is the number of deliveries returned by requests for the deliveries of a webhook that don't specify a limit.
+const DefaultDeliveriesLimit = 100
+
+const selectQuery = `
+SELECT w.id,
+	w.name,
+	w.url,
+	(w.secret IS NOT NULL AND w.secret <> '') AS has_secret,
+	w.object_types,
+	w.actions,
+	w.cdn,
+	w.tenant_id,
+	w.active,
+	w.last_updated
+FROM webhook AS w
+`
+
+const insertQuery = `
+INSERT INTO webhook (name, url, secret, object_types, actions, cdn, tenant_id, active)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, (secret IS NOT NULL AND secret <> ''), last_updated
+`
+
+const updateQuery = `
+UPDATE webhook SET
+	name = $1,
+	url = $2,
+	secret = COALESCE($3, secret),
+	object_types = $4,
+	actions = $5,
+	cdn = $6,
+	tenant_id = $7,
+	active = $8
+WHERE id = $9
+RETURNING (secret IS NOT NULL AND secret <> ''), last_updated
+`
+
+const selectDeliveriesQuery = `
+SELECT d.id,
+	d.webhook_id,
+	d.event,
+	d.status,
+	d.attempts,
+	d.next_attempt,
+	d.created_at,
+	d.payload
+FROM webhook_delivery AS d
+WHERE d.webhook_id = $1
+AND ($2::text IS NULL OR d.status = $2)
+ORDER BY d.id DESC
+LIMIT $3
+`
+
+const selectAttemptsQuery = `
+SELECT a.delivery_id,
+	a.attempt,
+	a.attempted_at,
+	a.status_code,
+	a.error,
+	a.duration_ms
+FROM webhook_delivery_attempt AS a
+WHERE a.delivery_id = ANY($1)
+ORDER BY a.delivery_id, a.attempt
+`
+
+// Get is the handler for GET requests to /webhooks.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"id":     {Column: "w.id", Checker: api.IsInt},
+		"name":   {Column: "w.name"},
+		"cdn":    {Column: "w.cdn"},
+		"active": {Column: "w.active", Checker: api.IsBool},
+	}
+	if _, ok := inf.Params["orderby"]; !ok {
+		inf.Params["orderby"] = "name"
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+
+	rows, err := inf.Tx.NamedQuery(selectQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = errors.New("webhook read query: " + sysErr.Error())
+		}
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	webhooks := []tc.Webhook{}
+	for rows.Next() {
+		wh, err := scanWebhook(rows)
+		if err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		webhooks = append(webhooks, wh)
+	}
+	api.WriteResp(w, r, webhooks)
+}
+
+// Create is the handler for POST requests to /webhooks.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var wh tc.Webhook
+	if userErr = api.Parse(r.Body, tx, &wh); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenant(inf, wh.TenantID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	setDefaults(&wh)
+
+	wh.ID = new(int)
+	wh.LastUpdated = new(tc.TimeNoMod)
+	if err := tx.QueryRow(insertQuery, wh.Name, wh.URL, wh.Secret, pq.Array(wh.ObjectTypes), pq.Array(wh.Actions), wh.CDNName, wh.TenantID, wh.Active).Scan(wh.ID, &wh.HasSecret, wh.LastUpdated); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	wh.Secret = nil
+
+	audit := api.Audit{Action: api.Created, ObjectType: auditObjectType, ObjectID: strconv.Itoa(*wh.ID), CDNName: wh.CDNName, After: wh}
+	if err := api.CreateAuditLog(api.ApiChange, "WEBHOOK: "+*wh.Name+", ID: "+strconv.Itoa(*wh.ID)+", ACTION: Created", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/webhooks?id=%d", inf.Version.Major, inf.Version.Minor, *wh.ID))
+	api.WriteAlertsObj(w, r, http.StatusCreated, tc.CreateAlerts(tc.SuccessLevel, "webhook was created."), wh)
+}
+
+// Update is the handler for PUT requests to /webhooks/{id}.
+func Update(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	before, ok, err := getWebhook(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no webhook with id %d", id), nil)
+		return
+	}
+	if !api.IsUnmodified(r.Header, before.LastUpdated.Time) {
+		api.HandleErr(w, r, tx, http.StatusPreconditionFailed, errors.New("webhook could not be modified because the precondition failed"), nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenant(inf, before.TenantID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var wh tc.Webhook
+	if userErr = api.Parse(r.Body, tx, &wh); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenant(inf, wh.TenantID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	setDefaults(&wh)
+
+	wh.ID = &id
+	wh.LastUpdated = new(tc.TimeNoMod)
+	if err := tx.QueryRow(updateQuery, wh.Name, wh.URL, wh.Secret, pq.Array(wh.ObjectTypes), pq.Array(wh.Actions), wh.CDNName, wh.TenantID, wh.Active, id).Scan(&wh.HasSecret, wh.LastUpdated); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	wh.Secret = nil
+
+	audit := api.Audit{Action: api.Updated, ObjectType: auditObjectType, ObjectID: strconv.Itoa(id), CDNName: wh.CDNName, Before: before, After: wh}
+	if err := api.CreateAuditLog(api.ApiChange, "WEBHOOK: "+*wh.Name+", ID: "+strconv.Itoa(id)+", ACTION: Updated", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "webhook was updated.", wh)
+}
+
+// Delete is the handler for DELETE requests to /webhooks/{id}.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	before, ok, err := getWebhook(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no webhook with id %d", id), nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenant(inf, before.TenantID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if _, err := tx.Exec(`DELETE FROM webhook WHERE id = $1`, id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	audit := api.Audit{Action: api.Deleted, ObjectType: auditObjectType, ObjectID: strconv.Itoa(id), CDNName: before.CDNName, Before: before}
+	if err := api.CreateAuditLog(api.ApiChange, "WEBHOOK: "+*before.Name+", ID: "+strconv.Itoa(id)+", ACTION: Deleted", audit, inf); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "webhook was deleted.")
+}
+
+// GetDeliveries is the handler for GET requests to /webhooks/{id}/deliveries, which returns the most recent events queued for delivery to a webhook, along with their attempt logs.
+func GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	if _, ok, err := getWebhook(tx, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no webhook with id %d", id), nil)
+		return
+	}
+
+	status := (*string)(nil)
+	if st, ok := inf.Params["status"]; ok {
+		if st != tc.WebhookDeliveryStatusPending && st != tc.WebhookDeliveryStatusDelivered && st != tc.WebhookDeliveryStatusFailed {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("status must be one of '"+tc.WebhookDeliveryStatusPending+"', '"+tc.WebhookDeliveryStatusDelivered+"', or '"+tc.WebhookDeliveryStatusFailed+"'"), nil)
+			return
+		}
+		status = &st
+	}
+	limit := DefaultDeliveriesLimit
+	if limitStr, ok := inf.Params["limit"]; ok {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 1 {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("limit must be a positive integer"), nil)
+			return
+		}
+		limit = l
+	}
+
+	deliveries, err := getDeliveries(tx, id, status, limit)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, deliveries)
+}
+
+// scanner is a *sql.Rows, *sql.Row, or *sqlx.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanWebhook scans a webhook selected by selectQuery.
+func scanWebhook(row scanner) (tc.Webhook, error) {
+	wh := tc.Webhook{}
+	if err := row.Scan(&wh.ID, &wh.Name, &wh.URL, &wh.HasSecret, pq.Array(&wh.ObjectTypes), pq.Array(&wh.Actions), &wh.CDNName, &wh.TenantID, &wh.Active, &wh.LastUpdated); err != nil {
+		return wh, err
+	}
+	return wh, nil
+}
+
+// getWebhook returns the webhook with the given ID, and whether it exists.
+func getWebhook(tx *sql.Tx, id int) (tc.Webhook, bool, error) {
+	wh, err := scanWebhook(tx.QueryRow(selectQuery+`WHERE w.id = $1`, id))
+	if err == sql.ErrNoRows {
+		return wh, false, nil
+	} else if err != nil {
+		return wh, false, errors.New("querying webhook: " + err.Error())
+	}
+	return wh, true, nil
+}
+
+// checkTenant returns an error if the given tenant ID isn't nil, and the tenant isn't accessible to the requesting user.
+func checkTenant(inf *api.APIInfo, tenantID *int) (error, error, int) {
+	if tenantID == nil {
+		return nil, nil, http.StatusOK
+	}
+	ok, err := tenant.IsResourceAuthorizedToUserTx(*tenantID, inf.User, inf.Tx.Tx)
+	if err != nil {
+		return nil, errors.New("checking webhook tenant: " + err.Error()), http.StatusInternalServerError
+	}
+	if !ok {
+		return errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+	}
+	return nil, nil, http.StatusOK
+}
+
+// setDefaults sets the fields of the given webhook that may be omitted from requests, but not stored as null.
+func setDefaults(wh *tc.Webhook) {
+	if wh.ObjectTypes == nil {
+		wh.ObjectTypes = []string{}
+	}
+	if wh.Actions == nil {
+		wh.Actions = []string{}
+	}
+	if wh.Active == nil {
+		wh.Active = util.BoolPtr(true)
+	}
+}
+
+// getDeliveries returns up to limit of the most recent deliveries to the webhook with the given ID, with the given status if it isn't nil.
+func getDeliveries(tx *sql.Tx, webhookID int, status *string, limit int) ([]tc.WebhookDelivery, error) {
+	rows, err := tx.Query(selectDeliveriesQuery, webhookID, status, limit)
+	if err != nil {
+		return nil, errors.New("querying webhook deliveries: " + err.Error())
+	}
+	defer rows.Close()
+
+	deliveries := []tc.WebhookDelivery{}
+	ids := []int64{}
+	idxs := map[int64]int{}
+	for rows.Next() {
+		d := tc.WebhookDelivery{AttemptLog: []tc.WebhookDeliveryAttempt{}}
+		nextAttempt := time.Time{}
+		payload := []byte{}
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Status, &d.Attempts, &nextAttempt, &d.CreatedAt, &payload); err != nil {
+			return nil, errors.New("scanning webhook deliveries: " + err.Error())
+		}
+		d.Payload = json.RawMessage(payload)
+		if d.Status == tc.WebhookDeliveryStatusPending {
+			d.NextAttempt = &nextAttempt
+		}
+		idxs[d.ID] = len(deliveries)
+		ids = append(ids, d.ID)
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("reading webhook deliveries: " + err.Error())
+	}
+	if len(ids) == 0 {
+		return deliveries, nil
+	}
+
+	attemptRows, err := tx.Query(selectAttemptsQuery, pq.Array(ids))
+	if err != nil {
+		return nil, errors.New("querying webhook delivery attempts: " + err.Error())
+	}
+	defer attemptRows.Close()
+	for attemptRows.Next() {
+		deliveryID := int64(0)
+		a := tc.WebhookDeliveryAttempt{}
+		if err := attemptRows.Scan(&deliveryID, &a.Attempt, &a.AttemptedAt, &a.StatusCode, &a.Error, &a.DurationMS); err != nil {
+			return nil, errors.New("scanning webhook delivery attempts: " + err.Error())
+		}
+		idx, ok := idxs[deliveryID]
+		if !ok {
+			continue
+		}
+		deliveries[idx].AttemptLog = append(deliveries[idx].AttemptLog, a)
+	}
+	if err := attemptRows.Err(); err != nil {
+		return nil, errors.New("reading webhook delivery attempts: " + err.Error())
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,95 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	APIUserTokens = "/user/tokens"
+)
+
+// bearerTransport is an http.RoundTripper which authenticates every request
+// with an API token.
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req)
+}
+
+// NewSessionWithAPIToken returns a new Session which authenticates every
+// request with the given API token, instead of logging in with a username and
+// password.
+func NewSessionWithAPIToken(toURL string, token string, insecure bool, userAgent string, requestTimeout time.Duration) *Session {
+	client := &http.Client{
+		Timeout: requestTimeout,
+		Transport: &bearerTransport{
+			token: token,
+			base: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+			},
+		},
+	}
+	return &Session{TOClient: *toclientlib.NewClient("", "", toURL, userAgent, client, apiVersions())}
+}
+
+// GetAPITokens returns the API tokens of the current user.
+func (to *Session) GetAPITokens(header http.Header) ([]tc.APIToken, toclientlib.ReqInf, error) {
+	var data tc.APITokensResponse
+	reqInf, err := to.get(APIUserTokens, header, &data)
+	return data.Response, reqInf, err
+}
+
+// GetAPITokensByUsername returns the API tokens of the user with the given
+// username. Only administrators may view the tokens of other users.
+func (to *Session) GetAPITokensByUsername(username string, header http.Header) ([]tc.APIToken, toclientlib.ReqInf, error) {
+	var data tc.APITokensResponse
+	params := url.Values{}
+	params.Add("username", username)
+	route := fmt.Sprintf("%s?%s", APIUserTokens, params.Encode())
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
+
+// CreateAPIToken creates an API token for the current user. The token itself
+// is only returned in the response.
+func (to *Session) CreateAPIToken(token tc.APIToken) (tc.APITokenResponse, toclientlib.ReqInf, error) {
+	var data tc.APITokenResponse
+	reqInf, err := to.post(APIUserTokens, token, nil, &data)
+	return data, reqInf, err
+}
+
+// DeleteAPIToken revokes the API token with the given ID.
+func (to *Session) DeleteAPIToken(id int) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	route := APIUserTokens + "/" + strconv.Itoa(id)
+	reqInf, err := to.del(route, nil, &alerts)
+	return alerts, reqInf, err
+}
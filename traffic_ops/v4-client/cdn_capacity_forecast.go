@@ -0,0 +1,43 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// APICDNCapacityForecast is the API path on which Traffic Ops serves the
+// capacity forecast of the CDN with the name inserted into it.
+const APICDNCapacityForecast = APICDNs + "/%s/capacity/forecast"
+
+// GetCDNCapacityForecast returns the capacity forecast of the CDN with the
+// given name, based on the given number of days of history, or the default
+// number of days if it's zero.
+func (to *Session) GetCDNCapacityForecast(cdnName string, days int, header http.Header) (tc.CapacityForecastResponse, toclientlib.ReqInf, error) {
+	path := fmt.Sprintf(APICDNCapacityForecast, url.PathEscape(cdnName))
+	if days != 0 {
+		path += "?days=" + strconv.Itoa(days)
+	}
+	var resp tc.CapacityForecastResponse
+	reqInf, err := to.get(path, header, &resp)
+	return resp, reqInf, err
+}
@@ -0,0 +1,49 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+func cdnConfigurationRoute(cdnName string) string {
+	return "/cdns/" + url.PathEscape(cdnName) + "/configuration"
+}
+
+// GetCDNConfiguration exports the configuration of the CDN with the given name, along with the global objects it uses.
+func (to *Session) GetCDNConfiguration(cdnName string, header http.Header) (tc.CDNConfiguration, toclientlib.ReqInf, error) {
+	var data tc.CDNConfiguration
+	reqInf, err := to.get(cdnConfigurationRoute(cdnName), header, &data)
+	return data, reqInf, err
+}
+
+// PlanCDNConfiguration returns the changes that applying the given configuration to the CDN it names would make, without making them.
+func (to *Session) PlanCDNConfiguration(cfg tc.CDNConfiguration, header http.Header) (tc.CDNConfigurationPlanResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNConfigurationPlanResponse
+	reqInf, err := to.post(cdnConfigurationRoute(cfg.CDN.Name)+"/plan", cfg, header, &data)
+	return data, reqInf, err
+}
+
+// ApplyCDNConfiguration makes the changes needed for the CDN the given configuration names to have that configuration, in a single transaction, and returns the changes that were made.
+func (to *Session) ApplyCDNConfiguration(cfg tc.CDNConfiguration, header http.Header) (tc.CDNConfigurationPlanResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNConfigurationPlanResponse
+	reqInf, err := to.put(cdnConfigurationRoute(cfg.CDN.Name), cfg, header, &data)
+	return data, reqInf, err
+}
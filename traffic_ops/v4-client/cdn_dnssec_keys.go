@@ -0,0 +1,42 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// GetDNSSECDSRecords returns the DS records of the KSKs of the CDN with the given name, including those which must be
+// published in the parent zone for a KSK rollover to finish.
+func (to *Session) GetDNSSECDSRecords(cdnName string, header http.Header) ([]tc.DNSSECDSRecord, toclientlib.ReqInf, error) {
+	var data tc.DNSSECDSRecordsResponse
+	route := APICDNs + "/name/" + url.PathEscape(cdnName) + "/dnsseckeys/ds"
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
+
+// ConfirmDNSSECDSPublished confirms the DS record of the pending KSK with the given key tag, of the CDN with the given
+// name, was published in the parent zone, which finishes the KSK's rollover.
+func (to *Session) ConfirmDNSSECDSPublished(cdnName string, keyTag uint16) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	route := APICDNs + "/name/" + url.PathEscape(cdnName) + "/dnsseckeys/ds"
+	reqInf, err := to.post(route, tc.DNSSECDSPublishedRequest{KeyTag: &keyTag}, nil, &alerts)
+	return alerts, reqInf, err
+}
@@ -85,3 +85,49 @@ func (to *Session) SnapshotCRConfigByID(id int) (tc.Alerts, toclientlib.ReqInf,
 	reqInf, err := to.put(url, nil, nil, &alerts)
 	return alerts, reqInf, err
 }
+
+// GetSnapshotHistoryWithHdr returns the Snapshots of the CDN retained by Traffic Ops, newest first.
+func (to *Session) GetSnapshotHistoryWithHdr(cdn string, header http.Header) ([]tc.SnapshotHistoryEntry, toclientlib.ReqInf, error) {
+	uri := `/cdns/` + url.PathEscape(cdn) + `/snapshot/history`
+	resp := tc.SnapshotHistoryResponse{}
+	reqInf, err := to.get(uri, header, &resp)
+	return resp.Response, reqInf, err
+}
+
+// GetSnapshotHistoryEntryWithHdr returns the retained Snapshot of the CDN with the given ID, including its CRConfig and monitoring configuration.
+func (to *Session) GetSnapshotHistoryEntryWithHdr(cdn string, id int64, header http.Header) (tc.SnapshotHistoryContent, toclientlib.ReqInf, error) {
+	uri := fmt.Sprintf("/cdns/%s/snapshot/history/%d", url.PathEscape(cdn), id)
+	resp := tc.SnapshotHistoryContentResponse{}
+	reqInf, err := to.get(uri, header, &resp)
+	return resp.Response, reqInf, err
+}
+
+// GetSnapshotDiffWithHdr returns the difference between two Snapshots of the CDN.
+// Each of fromSnapshot and toSnapshot may be tc.SnapshotCurrent, tc.SnapshotNew, or the ID of a retained Snapshot. If empty, fromSnapshot defaults to the current Snapshot, and toSnapshot to a new one.
+func (to *Session) GetSnapshotDiffWithHdr(cdn string, fromSnapshot string, toSnapshot string, header http.Header) (tc.SnapshotDiff, toclientlib.ReqInf, error) {
+	params := url.Values{}
+	if fromSnapshot != "" {
+		params.Set("from", fromSnapshot)
+	}
+	if toSnapshot != "" {
+		params.Set("to", toSnapshot)
+	}
+	uri := `/cdns/` + url.PathEscape(cdn) + `/snapshot/diff`
+	if len(params) > 0 {
+		uri += "?" + params.Encode()
+	}
+	resp := tc.SnapshotDiffResponse{}
+	reqInf, err := to.get(uri, header, &resp)
+	return resp.Response, reqInf, err
+}
+
+// RollbackSnapshot makes the retained Snapshot of the CDN with the given ID its current Snapshot, and returns the new Snapshot history entry.
+func (to *Session) RollbackSnapshot(cdn string, id int64) (tc.SnapshotHistoryEntry, tc.Alerts, toclientlib.ReqInf, error) {
+	uri := fmt.Sprintf("/cdns/%s/snapshot/history/%d/rollback", url.PathEscape(cdn), id)
+	resp := struct {
+		Response tc.SnapshotHistoryEntry `json:"response"`
+		tc.Alerts
+	}{}
+	reqInf, err := to.post(uri, nil, nil, &resp)
+	return resp.Response, resp.Alerts, reqInf, err
+}
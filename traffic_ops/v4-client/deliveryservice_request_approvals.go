@@ -0,0 +1,98 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// APIDSRApprovalPolicies is the API version-relative path to the
+	// /deliveryservice_request_approval_policies API endpoint.
+	APIDSRApprovalPolicies = "/deliveryservice_request_approval_policies"
+	// APIDSRApprovals is the API version-relative path to the
+	// /deliveryservice_requests/{{ID}}/approvals API endpoint, with a
+	// placeholder for the ID of the DSR.
+	APIDSRApprovals = APIDSRequests + "/%d/approvals"
+)
+
+// GetDSRApprovalPolicies returns all Delivery Service Request approval
+// policies.
+func (to *Session) GetDSRApprovalPolicies(header http.Header) ([]tc.DSRApprovalPolicy, toclientlib.ReqInf, error) {
+	var data tc.DSRApprovalPoliciesResponse
+	reqInf, err := to.get(APIDSRApprovalPolicies, header, &data)
+	return data.Response, reqInf, err
+}
+
+// GetDSRApprovalPolicyByID returns the Delivery Service Request approval
+// policy with the given ID, if it exists.
+func (to *Session) GetDSRApprovalPolicyByID(id int, header http.Header) ([]tc.DSRApprovalPolicy, toclientlib.ReqInf, error) {
+	var data tc.DSRApprovalPoliciesResponse
+	params := url.Values{}
+	params.Add("id", strconv.Itoa(id))
+	route := fmt.Sprintf("%s?%s", APIDSRApprovalPolicies, params.Encode())
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
+
+// CreateDSRApprovalPolicy creates a Delivery Service Request approval policy.
+func (to *Session) CreateDSRApprovalPolicy(p tc.DSRApprovalPolicy) (tc.DSRApprovalPolicyResponse, toclientlib.ReqInf, error) {
+	var data tc.DSRApprovalPolicyResponse
+	reqInf, err := to.post(APIDSRApprovalPolicies, p, nil, &data)
+	return data, reqInf, err
+}
+
+// UpdateDSRApprovalPolicy replaces the Delivery Service Request approval
+// policy with the given ID.
+func (to *Session) UpdateDSRApprovalPolicy(id int, p tc.DSRApprovalPolicy, header http.Header) (tc.DSRApprovalPolicyResponse, toclientlib.ReqInf, error) {
+	var data tc.DSRApprovalPolicyResponse
+	route := APIDSRApprovalPolicies + "/" + strconv.Itoa(id)
+	reqInf, err := to.put(route, p, header, &data)
+	return data, reqInf, err
+}
+
+// DeleteDSRApprovalPolicy deletes the Delivery Service Request approval
+// policy with the given ID.
+func (to *Session) DeleteDSRApprovalPolicy(id int) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	route := APIDSRApprovalPolicies + "/" + strconv.Itoa(id)
+	reqInf, err := to.del(route, nil, &alerts)
+	return alerts, reqInf, err
+}
+
+// GetDeliveryServiceRequestApprovals returns the approvals of the Delivery
+// Service Request with the given ID, along with the number it needs.
+func (to *Session) GetDeliveryServiceRequestApprovals(id int, header http.Header) (tc.DeliveryServiceRequestApprovals, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceRequestApprovalsResponse
+	reqInf, err := to.get(fmt.Sprintf(APIDSRApprovals, id), header, &data)
+	return data.Response, reqInf, err
+}
+
+// ApproveDeliveryServiceRequest approves the Delivery Service Request with
+// the given ID as the current user, with an optional comment. If the approval
+// is the last one the request needs, the change it requests is made.
+func (to *Session) ApproveDeliveryServiceRequest(id int, comment *string) (tc.DeliveryServiceRequestApprovalsResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceRequestApprovalsResponse
+	req := tc.DeliveryServiceRequestApprovalRequest{Comment: comment}
+	reqInf, err := to.post(fmt.Sprintf(APIDSRApprovals, id), req, nil, &data)
+	return data, reqInf, err
+}
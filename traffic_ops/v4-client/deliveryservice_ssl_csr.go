@@ -0,0 +1,63 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// APIDeliveryServiceXMLIDSSLKeysCSR is the API path on which Traffic Ops serves the pending
+	// certificate signing request of the Delivery Service with the XMLID given by the format verb.
+	APIDeliveryServiceXMLIDSSLKeysCSR = APIDeliveryServiceXMLIDSSLKeys + "/csr"
+)
+
+// GenerateDeliveryServiceSSLKeysCSR generates a new private key and certificate signing request
+// for the Delivery Service with the given XMLID, replacing any pending CSR.
+func (to *Session) GenerateDeliveryServiceSSLKeysCSR(xmlID string, req tc.DeliveryServiceSSLKeysCSRReq) (tc.DeliveryServiceSSLKeysCSRResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceSSLKeysCSRResponse
+	reqInf, err := to.post(fmt.Sprintf(APIDeliveryServiceXMLIDSSLKeysCSR, url.QueryEscape(xmlID)), req, nil, &data)
+	return data, reqInf, err
+}
+
+// GetDeliveryServiceSSLKeysCSR returns the pending certificate signing request of the Delivery
+// Service with the given XMLID.
+func (to *Session) GetDeliveryServiceSSLKeysCSR(xmlID string, header http.Header) (tc.DeliveryServiceSSLKeysCSR, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceSSLKeysCSRResponse
+	reqInf, err := to.get(fmt.Sprintf(APIDeliveryServiceXMLIDSSLKeysCSR, url.QueryEscape(xmlID)), header, &data)
+	return data.Response, reqInf, err
+}
+
+// CompleteDeliveryServiceSSLKeysCSR submits the certificate signed for the pending certificate
+// signing request of the Delivery Service with the given XMLID, which becomes its new SSL keys.
+func (to *Session) CompleteDeliveryServiceSSLKeysCSR(xmlID string, req tc.DeliveryServiceSSLKeysCSRCertReq) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.put(fmt.Sprintf(APIDeliveryServiceXMLIDSSLKeysCSR, url.QueryEscape(xmlID)), req, nil, &alerts)
+	return alerts, reqInf, err
+}
+
+// DeleteDeliveryServiceSSLKeysCSR discards the pending certificate signing request of the
+// Delivery Service with the given XMLID.
+func (to *Session) DeleteDeliveryServiceSSLKeysCSR(xmlID string) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(APIDeliveryServiceXMLIDSSLKeysCSR, url.QueryEscape(xmlID)), nil, &alerts)
+	return alerts, reqInf, err
+}
@@ -0,0 +1,44 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// APIDeliveryServicesSSLKeysInventory is the API path on which Traffic Ops serves the
+	// inventory of Delivery Service certificates.
+	APIDeliveryServicesSSLKeysInventory = "/deliveryservices/sslkeys/inventory"
+)
+
+// GetDeliveryServiceCertificates returns the latest certificate of every Delivery Service with
+// SSL keys which the user's tenant may see. The list may be filtered with the cdn, tenant and
+// expiresWithinDays query parameters, which may be nil.
+func (to *Session) GetDeliveryServiceCertificates(params url.Values, header http.Header) ([]tc.DeliveryServiceCertificate, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceCertificatesResponse
+	route := APIDeliveryServicesSSLKeysInventory
+	if len(params) > 0 {
+		route = fmt.Sprintf("%s?%s", route, params.Encode())
+	}
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
@@ -25,7 +25,9 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
 )
 
-// Creates a new Content Invalidation Job
+// Creates a new Content Invalidation Job. Its InvalidationType may be any of
+// tc.InvalidationTypeRefresh - the default - tc.InvalidationTypeRefetch or
+// tc.InvalidationTypePurge, in which case its Regex is the exact path to purge.
 func (to *Session) CreateInvalidationJob(job tc.InvalidationJobInput) (tc.Alerts, toclientlib.ReqInf, error) {
 	var alerts tc.Alerts
 	reqInf, err := to.post(`/jobs`, job, nil, &alerts)
@@ -0,0 +1,49 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// APILetsEncryptHTTPChallenges is the API path on which Traffic Ops serves pending ACME
+	// HTTP-01 challenges.
+	APILetsEncryptHTTPChallenges = "/letsencrypt/httpchallenges"
+)
+
+// GetAcmeHTTPChallenges returns the pending ACME HTTP-01 challenges, which Traffic Routers and
+// caches answer at tc.AcmeHTTPChallengePathPrefix.
+func (to *Session) GetAcmeHTTPChallenges(header http.Header) ([]tc.AcmeHTTPChallenge, toclientlib.ReqInf, error) {
+	var data tc.AcmeHTTPChallengesResponse
+	reqInf, err := to.get(APILetsEncryptHTTPChallenges, header, &data)
+	return data.Response, reqInf, err
+}
+
+// GetAcmeHTTPChallengesByDomain returns the pending ACME HTTP-01 challenges for the given domain.
+func (to *Session) GetAcmeHTTPChallengesByDomain(domain string, header http.Header) ([]tc.AcmeHTTPChallenge, toclientlib.ReqInf, error) {
+	var data tc.AcmeHTTPChallengesResponse
+	params := url.Values{}
+	params.Add("domain", domain)
+	route := fmt.Sprintf("%s?%s", APILetsEncryptHTTPChallenges, params.Encode())
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
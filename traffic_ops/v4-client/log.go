@@ -17,6 +17,7 @@ package client
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
@@ -27,24 +28,40 @@ const (
 )
 
 // GetLogsByQueryParams gets a list of logs filtered by query params.
-func (to *Session) GetLogsByQueryParams(queryParams string) ([]tc.Log, toclientlib.ReqInf, error) {
+func (to *Session) GetLogsByQueryParams(queryParams string) ([]tc.LogV4, toclientlib.ReqInf, error) {
 	uri := APILogs + queryParams
-	var data tc.LogsResponse
+	var data tc.LogsResponseV4
 	reqInf, err := to.get(uri, nil, &data)
 	return data.Response, reqInf, err
 }
 
 // GetLogs gets a list of logs.
-func (to *Session) GetLogs() ([]tc.Log, toclientlib.ReqInf, error) {
+func (to *Session) GetLogs() ([]tc.LogV4, toclientlib.ReqInf, error) {
 	return to.GetLogsByQueryParams("")
 }
 
 // GetLogsByLimit gets a list of logs limited to a certain number of logs.
-func (to *Session) GetLogsByLimit(limit int) ([]tc.Log, toclientlib.ReqInf, error) {
+func (to *Session) GetLogsByLimit(limit int) ([]tc.LogV4, toclientlib.ReqInf, error) {
 	return to.GetLogsByQueryParams(fmt.Sprintf("?limit=%d", limit))
 }
 
 // GetLogsByDays gets a list of logs limited to a certain number of days.
-func (to *Session) GetLogsByDays(days int) ([]tc.Log, toclientlib.ReqInf, error) {
+func (to *Session) GetLogsByDays(days int) ([]tc.LogV4, toclientlib.ReqInf, error) {
 	return to.GetLogsByQueryParams(fmt.Sprintf("?days=%d", days))
 }
+
+// GetLogWithHdr gets the log with the given ID, including the changed object as it was before and after the change.
+func (to *Session) GetLogWithHdr(id int, header http.Header) (tc.LogDetailV4, toclientlib.ReqInf, error) {
+	uri := fmt.Sprintf("%s/%d", APILogs, id)
+	var data tc.LogDetailResponseV4
+	reqInf, err := to.get(uri, header, &data)
+	return data.Response, reqInf, err
+}
+
+// GetLogDiffWithHdr gets the field-level difference between the changed object before and after the change recorded by the log with the given ID.
+func (to *Session) GetLogDiffWithHdr(id int, header http.Header) (tc.LogDiffV4, toclientlib.ReqInf, error) {
+	uri := fmt.Sprintf("%s/%d/diff", APILogs, id)
+	var data tc.LogDiffResponseV4
+	reqInf, err := to.get(uri, header, &data)
+	return data.Response, reqInf, err
+}
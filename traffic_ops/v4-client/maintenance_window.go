@@ -0,0 +1,80 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	APIMaintenanceWindows = "/maintenance_windows"
+)
+
+// GetMaintenanceWindows returns all maintenance windows.
+func (to *Session) GetMaintenanceWindows(header http.Header) ([]tc.MaintenanceWindow, toclientlib.ReqInf, error) {
+	var data tc.MaintenanceWindowsResponse
+	reqInf, err := to.get(APIMaintenanceWindows, header, &data)
+	return data.Response, reqInf, err
+}
+
+// GetMaintenanceWindowByID returns the maintenance window with the given ID, if it exists.
+func (to *Session) GetMaintenanceWindowByID(id int, header http.Header) ([]tc.MaintenanceWindow, toclientlib.ReqInf, error) {
+	var data tc.MaintenanceWindowsResponse
+	params := url.Values{}
+	params.Add("id", strconv.Itoa(id))
+	route := fmt.Sprintf("%s?%s", APIMaintenanceWindows, params.Encode())
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
+
+// GetMaintenanceWindowsByState returns the maintenance windows in the given state, e.g. tc.MaintenanceWindowStateActive.
+func (to *Session) GetMaintenanceWindowsByState(state string, header http.Header) ([]tc.MaintenanceWindow, toclientlib.ReqInf, error) {
+	var data tc.MaintenanceWindowsResponse
+	params := url.Values{}
+	params.Add("state", state)
+	route := fmt.Sprintf("%s?%s", APIMaintenanceWindows, params.Encode())
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
+
+// CreateMaintenanceWindow schedules a maintenance window.
+func (to *Session) CreateMaintenanceWindow(mw tc.MaintenanceWindow) (tc.MaintenanceWindowResponse, toclientlib.ReqInf, error) {
+	var data tc.MaintenanceWindowResponse
+	reqInf, err := to.post(APIMaintenanceWindows, mw, nil, &data)
+	return data, reqInf, err
+}
+
+// UpdateMaintenanceWindow replaces the maintenance window with the given ID. Only the reason and end time of an active window can be changed.
+func (to *Session) UpdateMaintenanceWindow(id int, mw tc.MaintenanceWindow, header http.Header) (tc.MaintenanceWindowResponse, toclientlib.ReqInf, error) {
+	var data tc.MaintenanceWindowResponse
+	route := APIMaintenanceWindows + "/" + strconv.Itoa(id)
+	reqInf, err := to.put(route, mw, header, &data)
+	return data, reqInf, err
+}
+
+// DeleteMaintenanceWindow deletes the maintenance window with the given ID, which must not be active.
+func (to *Session) DeleteMaintenanceWindow(id int) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	route := APIMaintenanceWindows + "/" + strconv.Itoa(id)
+	reqInf, err := to.del(route, nil, &alerts)
+	return alerts, reqInf, err
+}
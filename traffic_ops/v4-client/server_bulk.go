@@ -0,0 +1,70 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	APIServersBulk                 = APIServers + "/bulk"
+	APIServersBulkStatus           = APIServersBulk + "/status"
+	APIServersBulkProfile          = APIServersBulk + "/profile"
+	APIServersBulkDeliveryServices = APIServersBulk + "/deliveryservices"
+)
+
+// CreateServersBulk creates the given servers, reporting the result for each
+// of them.
+func (to *Session) CreateServersBulk(req tc.ServerBulkRequest, header http.Header) (tc.ServerBulkResponse, toclientlib.ReqInf, error) {
+	var resp tc.ServerBulkResponse
+	reqInf, err := to.post(APIServersBulk, req, header, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateServersBulk updates the given servers, identified by their IDs,
+// reporting the result for each of them.
+func (to *Session) UpdateServersBulk(req tc.ServerBulkRequest, header http.Header) (tc.ServerBulkResponse, toclientlib.ReqInf, error) {
+	var resp tc.ServerBulkResponse
+	reqInf, err := to.put(APIServersBulk, req, header, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateServersStatusBulk sets the Status of the servers with the given IDs,
+// reporting the result for each of them.
+func (to *Session) UpdateServersStatusBulk(req tc.ServerBulkStatusRequest, header http.Header) (tc.ServerBulkResponse, toclientlib.ReqInf, error) {
+	var resp tc.ServerBulkResponse
+	reqInf, err := to.put(APIServersBulkStatus, req, header, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateServersProfileBulk sets the Profile of the servers with the given
+// IDs, reporting the result for each of them.
+func (to *Session) UpdateServersProfileBulk(req tc.ServerBulkProfileRequest, header http.Header) (tc.ServerBulkResponse, toclientlib.ReqInf, error) {
+	var resp tc.ServerBulkResponse
+	reqInf, err := to.put(APIServersBulkProfile, req, header, &resp)
+	return resp, reqInf, err
+}
+
+// AssignDeliveryServicesToServersBulk assigns the given Delivery Services to
+// the servers with the given IDs, reporting the result for each of them.
+func (to *Session) AssignDeliveryServicesToServersBulk(req tc.ServerBulkAssignRequest, header http.Header) (tc.ServerBulkResponse, toclientlib.ReqInf, error) {
+	var resp tc.ServerBulkResponse
+	reqInf, err := to.post(APIServersBulkDeliveryServices, req, header, &resp)
+	return resp, reqInf, err
+}
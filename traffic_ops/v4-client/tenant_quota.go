@@ -0,0 +1,43 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// APITenantQuota is the API path on which Traffic Ops serves the quota of the
+// Tenant with the ID inserted into it.
+const APITenantQuota = APITenants + "/%d/quota"
+
+// GetTenantQuota returns the quota of the Tenant with the given ID, along
+// with its usage of each limited resource.
+func (to *Session) GetTenantQuota(id int, header http.Header) (tc.TenantQuotaReportResponse, toclientlib.ReqInf, error) {
+	var resp tc.TenantQuotaReportResponse
+	reqInf, err := to.get(fmt.Sprintf(APITenantQuota, id), header, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateTenantQuota replaces the quota of the Tenant with the given ID.
+func (to *Session) UpdateTenantQuota(id int, quota tc.TenantQuota, header http.Header) (tc.TenantQuotaReportResponse, toclientlib.ReqInf, error) {
+	var resp tc.TenantQuotaReportResponse
+	reqInf, err := to.put(fmt.Sprintf(APITenantQuota, id), quota, header, &resp)
+	return resp, reqInf, err
+}
@@ -79,3 +79,12 @@ func (to *Session) DeleteTopology(name string) (tc.Alerts, toclientlib.ReqInf, e
 	reqInf, err := to.del(reqUrl, nil, &alerts)
 	return alerts, reqInf, err
 }
+
+// SimulateTopology resolves the servers that would serve the given candidate
+// Delivery Service in its Topology, and the parent.config lines they would
+// get for it, without creating or changing anything.
+func (to *Session) SimulateTopology(req tc.TopologySimulationRequest) (tc.TopologySimulationResponse, toclientlib.ReqInf, error) {
+	var resp tc.TopologySimulationResponse
+	reqInf, err := to.post(APITopologies+"/simulate", req, nil, &resp)
+	return resp, reqInf, err
+}
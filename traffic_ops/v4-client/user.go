@@ -93,6 +93,13 @@ func (to *Session) GetUserCurrent() (*tc.UserCurrent, toclientlib.ReqInf, error)
 	return to.GetUserCurrentWithHdr(nil)
 }
 
+// GetUserCurrentPermissions returns the current user's effective Capabilities, and which endpoints they may use.
+func (to *Session) GetUserCurrentPermissions(header http.Header) (tc.UserPermissions, toclientlib.ReqInf, error) {
+	resp := tc.UserPermissionsResponse{}
+	reqInf, err := to.get(`/user/current/permissions`, header, &resp)
+	return resp.Response, reqInf, err
+}
+
 // UpdateCurrentUser replaces the current user data with the provided tc.User structure.
 func (to *Session) UpdateCurrentUser(u tc.User) (*tc.UpdateUserResponse, toclientlib.ReqInf, error) {
 	user := struct {
@@ -0,0 +1,84 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	APIWebhooks = "/webhooks"
+)
+
+// GetWebhooks returns all webhooks.
+func (to *Session) GetWebhooks(header http.Header) ([]tc.Webhook, toclientlib.ReqInf, error) {
+	var data tc.WebhooksResponse
+	reqInf, err := to.get(APIWebhooks, header, &data)
+	return data.Response, reqInf, err
+}
+
+// GetWebhookByName returns the webhook with the given name, if it exists.
+func (to *Session) GetWebhookByName(name string, header http.Header) ([]tc.Webhook, toclientlib.ReqInf, error) {
+	var data tc.WebhooksResponse
+	params := url.Values{}
+	params.Add("name", name)
+	route := fmt.Sprintf("%s?%s", APIWebhooks, params.Encode())
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
+
+// CreateWebhook creates a webhook.
+func (to *Session) CreateWebhook(webhook tc.Webhook) (tc.WebhookResponse, toclientlib.ReqInf, error) {
+	var data tc.WebhookResponse
+	reqInf, err := to.post(APIWebhooks, webhook, nil, &data)
+	return data, reqInf, err
+}
+
+// UpdateWebhook replaces the webhook with the given ID. Its secret is left unchanged if the given webhook's is nil.
+func (to *Session) UpdateWebhook(id int, webhook tc.Webhook, header http.Header) (tc.WebhookResponse, toclientlib.ReqInf, error) {
+	var data tc.WebhookResponse
+	route := APIWebhooks + "/" + strconv.Itoa(id)
+	reqInf, err := to.put(route, webhook, header, &data)
+	return data, reqInf, err
+}
+
+// DeleteWebhook deletes the webhook with the given ID, along with its queued deliveries.
+func (to *Session) DeleteWebhook(id int) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	route := APIWebhooks + "/" + strconv.Itoa(id)
+	reqInf, err := to.del(route, nil, &alerts)
+	return alerts, reqInf, err
+}
+
+// GetWebhookDeliveries returns the most recent events queued for delivery to the webhook with the given ID, along with their attempt logs.
+// If status isn't empty, only deliveries with that status are returned.
+func (to *Session) GetWebhookDeliveries(id int, status string, header http.Header) ([]tc.WebhookDelivery, toclientlib.ReqInf, error) {
+	var data tc.WebhookDeliveriesResponse
+	route := APIWebhooks + "/" + strconv.Itoa(id) + "/deliveries"
+	if status != "" {
+		params := url.Values{}
+		params.Add("status", status)
+		route += "?" + params.Encode()
+	}
+	reqInf, err := to.get(route, header, &data)
+	return data.Response, reqInf, err
+}
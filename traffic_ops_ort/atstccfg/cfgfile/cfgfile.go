@@ -270,7 +270,11 @@ func GetTOData(cfg config.TCCfg) (*config.TOData, []net.Addr, error) {
 	}
 	jobsF := func() error {
 		defer func(start time.Time) { log.Infof("jobsF took %v\n", time.Since(start)) }(time.Now())
-		jobs, toAddr, err := cfg.TOClient.GetJobs() // TODO add cdn query param to jobs endpoint
+		jobs, toAddr, unsupported, err := cfg.TOClientNew.GetJobs() // TODO add cdn query param to jobs endpoint
+		if err == nil && unsupported {
+			log.Warnln("Traffic Ops newer than ORT, falling back to previous API Jobs, without invalidation types!")
+			jobs, toAddr, err = cfg.TOClient.GetJobs()
+		}
 		if err != nil {
 			return errors.New("getting jobs: " + err.Error())
 		}
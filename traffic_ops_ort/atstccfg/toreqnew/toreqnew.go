@@ -185,6 +185,71 @@ func (cl *TOClient) GetServers() ([]atscfg.Server, net.Addr, bool, error) {
 	return servers, toAddr, false, nil
 }
 
+// GetJobs returns the content invalidation jobs, whether this client's version is unsupported by the server, and any error.
+// Unlike older versions of Traffic Ops, the latest returns the InvalidationType of each job, which config generation needs to make REFETCH and PURGE rules.
+// Note if the server returns a 404 or 503, this returns false and a nil error.
+// Users should check the "not supported" bool, and use the vendored TOClient if it's set, whose jobs are all REFRESH jobs.
+func (cl *TOClient) GetJobs() ([]tc.Job, net.Addr, bool, error) {
+	jobs := []tc.Job{}
+	toAddr := net.Addr(nil)
+	unsupported := false
+	err := torequtil.GetRetry(cl.NumRetries, "jobs", &jobs, func(obj interface{}) error {
+		toInvalidationJobs, reqInf, err := cl.C.GetInvalidationJobs(nil, nil)
+		if err != nil {
+			if IsUnsupportedErr(err) {
+				unsupported = true
+				return nil
+			}
+			return errors.New("getting jobs from Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		jobs := obj.(*[]tc.Job)
+		*jobs = toJobs(toInvalidationJobs)
+		toAddr = reqInf.RemoteAddr
+		return nil
+	})
+	if unsupported {
+		return nil, nil, true, nil
+	}
+	if err != nil {
+		return nil, nil, false, errors.New("getting jobs: " + err.Error())
+	}
+	return jobs, toAddr, false, nil
+}
+
+// toJobs converts the given content invalidation jobs to the Jobs used by config generation.
+func toJobs(invalidationJobs []tc.InvalidationJob) []tc.Job {
+	jobs := make([]tc.Job, 0, len(invalidationJobs))
+	for _, ij := range invalidationJobs {
+		job := tc.Job{}
+		if ij.AssetURL != nil {
+			job.AssetURL = *ij.AssetURL
+		}
+		if ij.CreatedBy != nil {
+			job.CreatedBy = *ij.CreatedBy
+		}
+		if ij.DeliveryService != nil {
+			job.DeliveryService = *ij.DeliveryService
+		}
+		if ij.ID != nil {
+			job.ID = int64(*ij.ID)
+		}
+		if ij.InvalidationType != nil {
+			job.InvalidationType = *ij.InvalidationType
+		}
+		if ij.Keyword != nil {
+			job.Keyword = *ij.Keyword
+		}
+		if ij.Parameters != nil {
+			job.Parameters = *ij.Parameters
+		}
+		if ij.StartTime != nil {
+			job.StartTime = ij.StartTime.Format(tc.JobTimeFormat)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
 func IsUnsupportedErr(err error) bool {
 	errStr := strings.ToLower(err.Error())
 	return strings.Contains(errStr, "not found") || strings.Contains(errStr, "not impl")
@@ -0,0 +1,100 @@
+package toreqnew
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestGetJobsRegexRevalidate(t *testing.T) {
+	startTime := time.Now().Add(-time.Hour).Format(tc.JobTimeFormat)
+	jobsResp := `{"response":[
+	{"id":1,"assetUrl":"http://myds.example.net/refresh/.*","createdBy":"me","deliveryService":"myds","keyword":"PURGE","parameters":"TTL:24h","startTime":"` + startTime + `","invalidationType":"REFRESH"},
+	{"id":2,"assetUrl":"http://myds.example.net/refetch/.*","createdBy":"me","deliveryService":"myds","keyword":"PURGE","parameters":"TTL:24h","startTime":"` + startTime + `","invalidationType":"REFETCH"},
+	{"id":3,"assetUrl":"http://myds.example.net/purge/a.png","createdBy":"me","deliveryService":"myds","keyword":"PURGE","parameters":"TTL:24h","startTime":"` + startTime + `","invalidationType":"PURGE"}
+]}`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/jobs") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(jobsResp))
+	}))
+	defer srv.Close()
+
+	toURL, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("parsing test server URL: %v", err)
+	}
+	cl, err := New("", toURL, "user", "pass", true, 10*time.Second, "test")
+	if err != nil {
+		t.Fatalf("creating client: %v", err)
+	}
+
+	jobs, _, unsupported, err := cl.GetJobs()
+	if err != nil {
+		t.Fatalf("getting jobs: %v", err)
+	}
+	if unsupported {
+		t.Fatal("expected jobs to be supported, actual: unsupported")
+	}
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs, actual: %+v", jobs)
+	}
+
+	cdnName := "mycdn"
+	server := &atscfg.Server{}
+	server.CDNName = &cdnName
+	ds := atscfg.DeliveryService{}
+	ds.CDNName = &cdnName
+	ds.XMLID = util.StrPtr("myds")
+
+	cfg, err := atscfg.MakeRegexRevalidateDotConfig(server, []atscfg.DeliveryService{ds}, nil, jobs, "")
+	if err != nil {
+		t.Fatalf("making regex_revalidate.config: %v", err)
+	}
+
+	purgeEnd, err := time.Parse(tc.JobTimeFormat, startTime)
+	if err != nil {
+		t.Fatalf("parsing start time: %v", err)
+	}
+	end := strconv.FormatInt(purgeEnd.Add(24*time.Hour).Unix(), 10)
+	for _, line := range []string{
+		`http://myds.example.net/refresh/.* ` + end + "\n",
+		`http://myds.example.net/refetch/.* ` + end + ` MISS` + "\n",
+		`^http://myds\.example\.net/purge/a\.png$ ` + end + ` MISS` + "\n",
+	} {
+		if !strings.Contains(cfg.Text, line) {
+			t.Errorf("expected regex_revalidate.config to contain '%s', actual: '%s'", strings.TrimSpace(line), cfg.Text)
+		}
+	}
+}
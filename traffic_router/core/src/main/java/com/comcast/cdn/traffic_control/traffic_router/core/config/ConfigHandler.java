@@ -35,6 +35,7 @@ import java.util.stream.Collectors;
 import java.util.stream.Stream;
 
 import com.comcast.cdn.traffic_control.traffic_router.core.ds.LetsEncryptDnsChallengeWatcher;
+import com.comcast.cdn.traffic_control.traffic_router.core.ds.LetsEncryptHttpChallengeWatcher;
 import com.comcast.cdn.traffic_control.traffic_router.core.ds.SteeringWatcher;
 import com.comcast.cdn.traffic_control.traffic_router.core.loc.FederationsWatcher;
 import com.comcast.cdn.traffic_control.traffic_router.core.loc.GeolocationDatabaseUpdater;
@@ -95,6 +96,7 @@ public class ConfigHandler {
 	private AnonymousIpDatabaseUpdater anonymousIpDatabaseUpdater;
 	private SteeringWatcher steeringWatcher;
 	private LetsEncryptDnsChallengeWatcher letsEncryptDnsChallengeWatcher;
+	private LetsEncryptHttpChallengeWatcher letsEncryptHttpChallengeWatcher;
 	private CertificatesPoller certificatesPoller;
 	private CertificatesPublisher certificatesPublisher;
 	private BlockingQueue<Boolean> publishStatusQueue;
@@ -238,6 +240,7 @@ public class ConfigHandler {
 				federationsWatcher.configure(config);
 				steeringWatcher.configure(config);
 				letsEncryptDnsChallengeWatcher.configure(config);
+				letsEncryptHttpChallengeWatcher.configure(config);
 				trafficRouterManager.setCacheRegister(cacheRegister);
 				trafficRouterManager.getNameServer().setEcsEnable(JsonUtils.optBoolean(config, "ecsEnable", false));
 				trafficRouterManager.getNameServer().setEcsEnabledDses(deliveryServices.stream().filter(DeliveryService::isEcsEnabled).collect(Collectors.toSet()));
@@ -904,6 +907,10 @@ public class ConfigHandler {
 		this.letsEncryptDnsChallengeWatcher = letsEncryptDnsChallengeWatcher;
 	}
 
+	public void setLetsEncryptHttpChallengeWatcher(final LetsEncryptHttpChallengeWatcher letsEncryptHttpChallengeWatcher) {
+		this.letsEncryptHttpChallengeWatcher = letsEncryptHttpChallengeWatcher;
+	}
+
 	public void setCertificatesPoller(final CertificatesPoller certificatesPoller) {
 		this.certificatesPoller = certificatesPoller;
 	}
@@ -0,0 +1,107 @@
+package com.comcast.cdn.traffic_control.traffic_router.core.ds;
+
+/*
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import com.comcast.cdn.traffic_control.traffic_router.core.util.AbstractResourceWatcher;
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.log4j.Logger;
+
+import java.io.IOException;
+import java.util.Collections;
+import java.util.HashMap;
+import java.util.Map;
+
+/**
+ * Polls Traffic Ops for the pending ACME HTTP-01 challenges of HTTP Delivery Services, so that Traffic Router can
+ * answer requests for {@code /.well-known/acme-challenge/<token>} itself instead of redirecting them to caches.
+ */
+public class LetsEncryptHttpChallengeWatcher extends AbstractResourceWatcher {
+    private static final Logger LOGGER = Logger.getLogger(LetsEncryptHttpChallengeWatcher.class);
+    public static final String DEFAULT_LE_HTTP_CHALLENGE_URL = "https://${toHostname}/api/4.0/letsencrypt/httpchallenges/";
+    public static final String CHALLENGE_PATH_PREFIX = "/.well-known/acme-challenge/";
+
+    private final ObjectMapper mapper = new ObjectMapper();
+    private volatile Map<String, String> keyAuthorizations = Collections.emptyMap();
+
+    public LetsEncryptHttpChallengeWatcher() {
+        setDatabaseUrl(DEFAULT_LE_HTTP_CHALLENGE_URL);
+        setDefaultDatabaseUrl(DEFAULT_LE_HTTP_CHALLENGE_URL);
+    }
+
+    @Override
+    public boolean useData(final String data) {
+        try {
+            keyAuthorizations = parse(data);
+            return true;
+        } catch (Exception e) {
+            LOGGER.warn("Failed updating http challenges with data from " + dataBaseURL + ":", e);
+        }
+
+        return false;
+    }
+
+    @Override
+    protected boolean verifyData(final String data) {
+        try {
+            parse(data);
+            return true;
+        } catch (Exception e) {
+            LOGGER.warn("Failed to build http challenge data while verifying:", e);
+        }
+
+        return false;
+    }
+
+    @Override
+    public String getWatcherConfigPrefix() {
+        return "httpchallengemapping";
+    }
+
+    /**
+     * Returns the key authorization with which a request for the given challenge path on the given host must be
+     * answered, or null if the path isn't that of a pending challenge for the host.
+     */
+    public String getKeyAuthorization(final String host, final String path) {
+        if (host == null || path == null || !path.startsWith(CHALLENGE_PATH_PREFIX)) {
+            return null;
+        }
+        return keyAuthorizations.get(key(host, path.substring(CHALLENGE_PATH_PREFIX.length())));
+    }
+
+    private Map<String, String> parse(final String data) throws IOException {
+        final JsonNode response = mapper.readTree(data).get("response");
+        if (response == null || !response.isArray()) {
+            throw new IOException("http challenge data has no response array");
+        }
+
+        final Map<String, String> challenges = new HashMap<>();
+        for (final JsonNode challenge : response) {
+            final JsonNode domain = challenge.get("domain");
+            final JsonNode token = challenge.get("token");
+            final JsonNode keyAuthorization = challenge.get("keyAuthorization");
+            if (domain == null || token == null || keyAuthorization == null) {
+                throw new IOException("http challenge is missing its domain, token or keyAuthorization");
+            }
+            challenges.put(key(domain.asText(), token.asText()), keyAuthorization.asText());
+        }
+        return challenges;
+    }
+
+    private static String key(final String host, final String token) {
+        return host.toLowerCase() + "/" + token;
+    }
+}
@@ -16,6 +16,7 @@
 package com.comcast.cdn.traffic_control.traffic_router.core.http;
 
 import com.comcast.cdn.traffic_control.traffic_router.core.ds.DeliveryService;
+import com.comcast.cdn.traffic_control.traffic_router.core.ds.LetsEncryptHttpChallengeWatcher;
 import com.comcast.cdn.traffic_control.traffic_router.core.request.HTTPRequest;
 import com.comcast.cdn.traffic_control.traffic_router.core.router.HTTPRouteResult;
 import com.comcast.cdn.traffic_control.traffic_router.core.router.StatTracker;
@@ -50,6 +51,8 @@ public class RouterFilter extends OncePerRequestFilter {
 
 	private List<String> staticContentWhiteList;
 
+	private LetsEncryptHttpChallengeWatcher letsEncryptHttpChallengeWatcher;
+
 	private boolean doNotLog = false;
 
 	@Override
@@ -61,6 +64,21 @@ public class RouterFilter extends OncePerRequestFilter {
 			return;
 		}
 
+		if (letsEncryptHttpChallengeWatcher != null) {
+			final String keyAuthorization = letsEncryptHttpChallengeWatcher.getKeyAuthorization(request.getServerName(), request.getRequestURI());
+			if (keyAuthorization != null) {
+				response.setStatus(HttpServletResponse.SC_OK);
+				response.setContentType("text/plain");
+				if (!HEAD.equals(request.getMethod())) {
+					response.getWriter().print(keyAuthorization);
+				}
+
+				final HTTPAccessRecord access = new HTTPAccessRecord.Builder(requestDate, request).responseCode(HttpServletResponse.SC_OK).build();
+				ACCESS.info(HTTPAccessEventBuilder.create(access));
+				return;
+			}
+		}
+
 		if (staticContentWhiteList.contains(request.getRequestURI())) {
 			chain.doFilter(request, response);
 
@@ -210,4 +228,8 @@ public class RouterFilter extends OncePerRequestFilter {
 	public void setStaticContentWhiteList(final List<String> staticContentWhiteList) {
 		this.staticContentWhiteList = staticContentWhiteList;
 	}
+
+	public void setLetsEncryptHttpChallengeWatcher(final LetsEncryptHttpChallengeWatcher letsEncryptHttpChallengeWatcher) {
+		this.letsEncryptHttpChallengeWatcher = letsEncryptHttpChallengeWatcher;
+	}
 }
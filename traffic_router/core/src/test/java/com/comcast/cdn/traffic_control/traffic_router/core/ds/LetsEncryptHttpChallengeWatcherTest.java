@@ -0,0 +1,59 @@
+/*
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package com.comcast.cdn.traffic_control.traffic_router.core.ds;
+
+import org.junit.Test;
+
+import static org.hamcrest.MatcherAssert.assertThat;
+import static org.hamcrest.Matchers.equalTo;
+import static org.hamcrest.Matchers.nullValue;
+
+public class LetsEncryptHttpChallengeWatcherTest {
+	private static final String JSON = "{ \"response\": [" +
+		"{ \"domain\": \"demo1.mycdn.ciab.test\", \"token\": \"tok1\", \"keyAuthorization\": \"tok1.thumbprint\", \"lastUpdated\": \"2021-03-28T16:21:07.524611-06:00\" }," +
+		"{ \"domain\": \"demo2.mycdn.ciab.test\", \"token\": \"tok2\", \"keyAuthorization\": \"tok2.thumbprint\", \"lastUpdated\": \"2021-03-28T16:21:07.524611-06:00\" }" +
+		"] }";
+
+	@Test
+	public void itAnswersPendingChallenges() {
+		final LetsEncryptHttpChallengeWatcher watcher = new LetsEncryptHttpChallengeWatcher();
+		assertThat(watcher.verifyData(JSON), equalTo(true));
+		assertThat(watcher.useData(JSON), equalTo(true));
+
+		assertThat(watcher.getKeyAuthorization("demo1.mycdn.ciab.test", "/.well-known/acme-challenge/tok1"), equalTo("tok1.thumbprint"));
+		assertThat(watcher.getKeyAuthorization("DEMO2.mycdn.ciab.test", "/.well-known/acme-challenge/tok2"), equalTo("tok2.thumbprint"));
+	}
+
+	@Test
+	public void itIgnoresOtherRequests() {
+		final LetsEncryptHttpChallengeWatcher watcher = new LetsEncryptHttpChallengeWatcher();
+		watcher.useData(JSON);
+
+		assertThat(watcher.getKeyAuthorization("demo2.mycdn.ciab.test", "/.well-known/acme-challenge/tok1"), nullValue());
+		assertThat(watcher.getKeyAuthorization("demo1.mycdn.ciab.test", "/tok1"), nullValue());
+		assertThat(watcher.getKeyAuthorization(null, "/.well-known/acme-challenge/tok1"), nullValue());
+	}
+
+	@Test
+	public void itRejectsInvalidData() {
+		final LetsEncryptHttpChallengeWatcher watcher = new LetsEncryptHttpChallengeWatcher();
+		watcher.useData(JSON);
+
+		assertThat(watcher.verifyData("{ \"response\": [ { \"domain\": \"demo1.mycdn.ciab.test\" } ] }"), equalTo(false));
+		assertThat(watcher.useData("not json"), equalTo(false));
+		assertThat(watcher.getKeyAuthorization("demo1.mycdn.ciab.test", "/.well-known/acme-challenge/tok1"), equalTo("tok1.thumbprint"));
+	}
+}